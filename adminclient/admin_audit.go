@@ -0,0 +1,101 @@
+// adminclient/admin_audit.go
+//
+// Auditoría de administración (AdminGetAuditLog), desde la opción 26 del
+// menú: quién cambió qué, cuándo, de qué valor a cuál y con qué resultado.
+// Cada RPC de este cliente declara al administrador con ADMIN_ID (por
+// defecto usuario@host); con mTLS el Matchmaker usa en cambio el CN del
+// certificado.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// defaultAdminID es usuario@host, o "admin" si no se conoce el usuario.
+func defaultAdminID() string {
+	name := os.Getenv("USER")
+	if name == "" {
+		name = "admin"
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
+
+func auditLogMenu(client pb.MatchmakerClient, reader *bufio.Reader, namespace string) {
+	fmt.Print("   ➤ Servidor, jugador, modo o partida (vacío = todos): ")
+	targetRaw, _ := reader.ReadString('\n')
+	fmt.Print("   ➤ Administrador (vacío = todos): ")
+	adminRaw, _ := reader.ReadString('\n')
+	fmt.Print("   ➤ Últimos minutos (vacío = todo): ")
+	minutesRaw, _ := reader.ReadString('\n')
+	req := &pb.AuditLogRequest{
+		Namespace: namespace,
+		Target:    strings.TrimSpace(targetRaw),
+		AdminId:   strings.TrimSpace(adminRaw),
+	}
+	if mins, err := strconv.Atoi(strings.TrimSpace(minutesRaw)); err == nil && mins > 0 {
+		req.SinceUnixMs = time.Now().Add(-time.Duration(mins) * time.Minute).UnixMilli()
+	}
+
+	fmt.Println("\n================== AUDITORÍA DE ADMINISTRACIÓN ==================")
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := client.AdminGetAuditLog(ctx, req)
+		cancel()
+		if err != nil {
+			log.Printf("[AdminClient] ERROR al obtener la auditoría: %v\n", err)
+			break
+		}
+		printAuditLog(resp)
+		if !resp.GetMore() {
+			break
+		}
+		fmt.Print("   ➤ ¿Ver más? (s/N): ")
+		more, _ := reader.ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(more), "s") {
+			break
+		}
+		req.AfterSeq = resp.GetLastSequence()
+	}
+	fmt.Print("=================================================================\n\n")
+}
+
+func printAuditLog(resp *pb.AuditLogResponse) {
+	if len(resp.GetEntries()) == 0 {
+		fmt.Println("  (sin acciones registradas)")
+	}
+	for _, e := range resp.GetEntries() {
+		fmt.Printf("  #%-5d %s | %-16s | %s por %s (%s, req %s)\n",
+			e.GetSequence(), time.UnixMilli(e.GetUnixMs()).Format("2006-01-02 15:04:05.000"), e.GetResult(),
+			e.GetMethod(), e.GetAdminId(), e.GetPeer(), e.GetRequestId())
+		if e.GetTarget() != "" {
+			fmt.Printf("         %s: %s → %s\n", e.GetTarget(), orDash(e.GetPreviousValue()), orDash(e.GetNewValue()))
+		}
+		if e.GetMessage() != "" {
+			fmt.Printf("         %s\n", e.GetMessage())
+		}
+		if e.GetClock() != nil {
+			fmt.Printf("         reloj [%s] %s\n", e.GetNamespace(), clocks.FromProto(e.GetClock()))
+		}
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
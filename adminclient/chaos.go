@@ -0,0 +1,116 @@
+// adminclient/chaos.go
+//
+// Fallos inyectados (AdminSetChaos), para reproducir los escenarios de
+// tolerancia a fallos del laboratorio. Desde la opción 23 del menú se piden
+// el destino y las probabilidades; como
+// `adminclient chaos [-target gs1|*] [-drop 0.1] [-delay 0.2] [-max-delay 2s]
+// [-clock 0.05] [-crash 0.01] [-seed 42]`, de un solo disparo. Sin
+// probabilidades se apagan los fallos del destino. Con la misma semilla la
+// secuencia de fallos se repite.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// setChaos envía la configuración e informa el resultado; devuelve false
+// si no se aplicó.
+func setChaos(client pb.MatchmakerClient, req *pb.AdminSetChaosRequest) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.AdminSetChaos(ctx, req)
+	switch {
+	case err != nil:
+		log.Printf("[AdminClient] ERROR al configurar los fallos: %v\n", err)
+		return false
+	case resp.Status != pb.AdminUpdateResponse_OK:
+		fmt.Printf("   ❌  %s\n", resp.Message)
+		return false
+	}
+	fmt.Printf("   ✅  %s\n", resp.Message)
+	return true
+}
+
+// readProb pide una probabilidad; vacío o inválido = 0.
+func readProb(reader *bufio.Reader, prompt string) float64 {
+	fmt.Printf("   ➤ %s (0-1) [0]: ", prompt)
+	raw, _ := reader.ReadString('\n')
+	p, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return 0
+	}
+	return p
+}
+
+func chaosMenu(client pb.MatchmakerClient, reader *bufio.Reader, namespace string) {
+	fmt.Print("   ➤ Destino (vacío = Matchmaker, ID de servidor, * = todos los servidores): ")
+	targetRaw, _ := reader.ReadString('\n')
+	cfg := &pb.ChaosConfig{
+		DropProb:  readProb(reader, "Descartar RPCs"),
+		DelayProb: readProb(reader, "Demorar RPCs"),
+	}
+	if cfg.DelayProb > 0 {
+		fmt.Print("   ➤ Demora máxima (p.ej. 2s) [2s]: ")
+		raw, _ := reader.ReadString('\n')
+		if d, err := time.ParseDuration(strings.TrimSpace(raw)); err == nil {
+			cfg.MaxDelayMs = d.Milliseconds()
+		}
+	}
+	cfg.ClockProb = readProb(reader, "Adelantar relojes")
+	cfg.CrashProb = readProb(reader, "Caerse")
+	fmt.Print("   ➤ Semilla (vacío = no reiniciar la secuencia): ")
+	seedRaw, _ := reader.ReadString('\n')
+	cfg.Seed, _ = strconv.ParseInt(strings.TrimSpace(seedRaw), 10, 64)
+
+	setChaos(client, &pb.AdminSetChaosRequest{
+		Namespace: namespace,
+		Target:    strings.TrimSpace(targetRaw),
+		Config:    cfg,
+	})
+}
+
+// runChaos es `adminclient chaos`.
+func runChaos(client pb.MatchmakerClient, namespace string, args []string) int {
+	fs := flag.NewFlagSet("chaos", flag.ContinueOnError)
+	target := fs.String("target", "", "vacío = el Matchmaker; ID de servidor; * = todos los servidores")
+	drop := fs.Float64("drop", 0, "probabilidad de descartar una RPC")
+	delay := fs.Float64("delay", 0, "probabilidad de demorar una RPC")
+	maxDelay := fs.Duration("max-delay", 0, "demora máxima (0 = 2s)")
+	clock := fs.Float64("clock", 0, "probabilidad de adelantar el reloj de una respuesta")
+	crash := fs.Float64("crash", 0, "probabilidad de caerse")
+	seed := fs.Int64("seed", 0, "semilla; 0 = no reiniciar la secuencia")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		log.Printf("[AdminClient] chaos: argumentos de más: %v", fs.Args())
+		return 2
+	}
+
+	ok := setChaos(client, &pb.AdminSetChaosRequest{
+		Namespace: namespace,
+		Target:    *target,
+		Config: &pb.ChaosConfig{
+			DropProb:   *drop,
+			DelayProb:  *delay,
+			MaxDelayMs: maxDelay.Milliseconds(),
+			ClockProb:  *clock,
+			CrashProb:  *crash,
+			Seed:       *seed,
+		},
+	})
+	if !ok {
+		return 1
+	}
+	return 0
+}
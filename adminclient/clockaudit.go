@@ -0,0 +1,116 @@
+// adminclient/clockaudit.go
+//
+// Auditoría de relojes: compara el último reloj que cada jugador y servidor
+// envió al Matchmaker (AdminGetClockState) con la vista fusionada del
+// namespace. Como el Matchmaker fusiona todo reloj que recibe, dos
+// situaciones indican una anomalía de causalidad:
+//
+//   - un componente de la entidad por delante de la vista del Matchmaker
+//     (incluidas sus lápidas): el Matchmaker perdió eventos que la entidad
+//     ya conoce, p. ej. tras conmutar a un respaldo atrasado;
+//   - el propio componente de la entidad por detrás de lo que el Matchmaker
+//     ya vio de ella: el reloj de la entidad retrocedió (se reinició sin
+//     conservarlo) o sus mensajes llegaron fuera de orden.
+//
+// Se usa desde la opción 21 del menú o como `adminclient clockaudit`, que
+// termina con código 1 si encuentra anomalías.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// clockAnomaly es una violación de causalidad en el reloj de una entidad.
+type clockAnomaly struct {
+	Entity    *pb.EntityClock
+	Component string
+	Got       int32 // valor en el reloj de la entidad
+	View      int32 // valor en la vista del Matchmaker
+	Detail    string
+}
+
+// auditClocks busca anomalías en resp, en el orden de sus entidades.
+func auditClocks(resp *pb.ClockStateResponse) []clockAnomaly {
+	view := make(map[string]int32)
+	for id, v := range resp.GetVectorClock().GetCounters() {
+		view[id] = v
+	}
+	for id, v := range resp.GetTombstones().GetCounters() {
+		if v > view[id] {
+			view[id] = v
+		}
+	}
+
+	var out []clockAnomaly
+	for _, e := range resp.GetEntities() {
+		counters := e.GetClock().GetCounters()
+		ids := make([]string, 0, len(counters))
+		for id := range counters {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			if counters[id] <= view[id] {
+				continue
+			}
+			detail := "adelantado respecto de la vista del Matchmaker"
+			if id == resp.GetMatchmakerId() {
+				detail = "conoce eventos del Matchmaker que éste no tiene (¿conmutación a un respaldo atrasado?)"
+			}
+			out = append(out, clockAnomaly{Entity: e, Component: id, Got: counters[id], View: view[id], Detail: detail})
+		}
+		if own := counters[e.GetId()]; view[e.GetId()] > own {
+			out = append(out, clockAnomaly{
+				Entity: e, Component: e.GetId(), Got: own, View: view[e.GetId()],
+				Detail: "su propio componente retrocedió (¿reinicio sin conservar el reloj o mensajes reordenados?)",
+			})
+		}
+	}
+	return out
+}
+
+// printClockAudit muestra el resumen de la auditoría y devuelve cuántas
+// anomalías encontró.
+func printClockAudit(resp *pb.ClockStateResponse) int {
+	anomalies := auditClocks(resp)
+
+	fmt.Println("\n================== AUDITORÍA DE RELOJES ==================")
+	fmt.Printf("  Matchmaker %s: %d componentes, %d lápidas\n", resp.GetMatchmakerId(),
+		len(resp.GetVectorClock().GetCounters()), len(resp.GetTombstones().GetCounters()))
+	fmt.Printf("  Relojes recibidos: %d entidades\n", len(resp.GetEntities()))
+	for _, e := range resp.GetEntities() {
+		fmt.Printf("    %-6s %-14s | %2d componentes | hace %v\n", e.GetKind(), e.GetId(),
+			len(e.GetClock().GetCounters()), time.Since(time.UnixMilli(e.GetLastSeenUnixMs())).Round(time.Second))
+	}
+	if len(anomalies) == 0 {
+		fmt.Println("  ✅  Sin anomalías de causalidad")
+	}
+	for _, a := range anomalies {
+		fmt.Printf("  ⚠️  %s %s: %s=%d, Matchmaker=%d — %s\n",
+			a.Entity.GetKind(), a.Entity.GetId(), a.Component, a.Got, a.View, a.Detail)
+	}
+	fmt.Print("==========================================================\n\n")
+	return len(anomalies)
+}
+
+// runClockAudit es `adminclient clockaudit`: devuelve el código de salida.
+func runClockAudit(client pb.MatchmakerClient, namespace string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.AdminGetClockState(ctx, &pb.AdminRequest{Namespace: namespace})
+	if err != nil {
+		fmt.Printf("[AdminClient] ERROR al obtener los relojes: %v\n", err)
+		return 2
+	}
+	if printClockAudit(resp) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,333 @@
+// adminclient/export.go
+//
+// Exportación para análisis fuera de línea: `adminclient export` consulta
+// AdminGetSystemStatus y el historial de partidas (GetMatchHistory) del
+// namespace y los escribe en archivos legibles por máquina dentro de -dir:
+//
+//   - con -format json (por defecto), status.jsonl con una línea por
+//     instantánea ({"taken_at": ..., "status": {...}}) y matches.jsonl con
+//     una línea por partida terminada;
+//   - con -format csv, servers.csv, queue.csv y modes.csv con una fila por
+//     elemento de cada instantánea (la primera columna es taken_at) y
+//     matches.csv con una fila por partida.
+//
+// Los archivos se abren para agregar: si ya existen se escriben a
+// continuación y el encabezado CSV sólo va en los nuevos. Con -watch 30s
+// repite la instantánea a ese ritmo hasta Ctrl+C y agrega sólo las partidas
+// que no había escrito; sin -watch hace una sola. -history=false omite el
+// historial. Sale con código 1 si alguna consulta o escritura falla.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// exportOptions son los argumentos de `adminclient export`.
+type exportOptions struct {
+	Format  string
+	Dir     string
+	Watch   time.Duration
+	History bool
+}
+
+func parseExportArgs(args []string) (exportOptions, error) {
+	var o exportOptions
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.StringVar(&o.Format, "format", "json", "json o csv")
+	fs.StringVar(&o.Dir, "dir", ".", "directorio de los archivos")
+	fs.DurationVar(&o.Watch, "watch", 0, "repite la exportación con este período (0 = una sola vez)")
+	fs.BoolVar(&o.History, "history", true, "exporta también el historial de partidas")
+	if err := fs.Parse(args); err != nil {
+		return o, err
+	}
+	switch {
+	case o.Format != "json" && o.Format != "csv":
+		return o, fmt.Errorf("-format debe ser json o csv (recibido %q)", o.Format)
+	case o.Watch < 0 || (o.Watch > 0 && o.Watch < time.Second):
+		return o, fmt.Errorf("-watch debe ser 0 o al menos 1s (recibido %v)", o.Watch)
+	case fs.NArg() > 0:
+		return o, fmt.Errorf("argumentos de más: %v", fs.Args())
+	}
+	return o, nil
+}
+
+// exporter escribe las instantáneas; recuerda las partidas ya exportadas
+// en esta corrida para no repetirlas con -watch.
+type exporter struct {
+	client    pb.MatchmakerClient
+	shards    []shardClient // para el estado; ver shards.go
+	namespace string
+	opts      exportOptions
+	seen      map[string]bool
+}
+
+// runExport ejecuta `adminclient export` y devuelve el código de salida.
+func runExport(shards []shardClient, namespace string, args []string) int {
+	opts, err := parseExportArgs(args)
+	if err != nil {
+		log.Printf("[AdminClient] export: %v", err)
+		return 2
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		log.Printf("[AdminClient] export: %v", err)
+		return 1
+	}
+	e := &exporter{client: shards[0].client, shards: shards, namespace: namespace, opts: opts, seen: map[string]bool{}}
+	if opts.Watch == 0 {
+		if err := e.snapshot(time.Now()); err != nil {
+			log.Printf("[AdminClient] export: %v", err)
+			return 1
+		}
+		return 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	tick := time.NewTicker(opts.Watch)
+	defer tick.Stop()
+	log.Printf("[AdminClient] Exportando cada %v a %s (%s); Ctrl+C para terminar", opts.Watch, opts.Dir, opts.Format)
+	for {
+		// con -watch una falla no corta la serie: se reintenta en la próxima
+		if err := e.snapshot(time.Now()); err != nil {
+			log.Printf("[AdminClient] export: %v", err)
+		}
+		select {
+		case <-tick.C:
+		case <-ctx.Done():
+			return 0
+		}
+	}
+}
+
+// snapshot exporta el estado y las partidas nuevas tomadas en now.
+func (e *exporter) snapshot(now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, _, err := fetchStatus(ctx, e.shards, e.namespace)
+	if err != nil {
+		return fmt.Errorf("AdminGetSystemStatus: %v", err)
+	}
+	var matches []*pb.MatchRecord
+	if e.opts.History {
+		if matches, err = e.newMatches(ctx); err != nil {
+			return fmt.Errorf("GetMatchHistory: %v", err)
+		}
+	}
+
+	if e.opts.Format == "csv" {
+		err = e.writeCSV(now, status, matches)
+	} else {
+		err = e.writeJSON(now, status, matches)
+	}
+	if err != nil {
+		return err
+	}
+	for _, mt := range matches {
+		e.seen[mt.GetMatchId()] = true
+	}
+	log.Printf("[AdminClient] Instantánea %s: %d servidores, %d en cola, %d partidas nuevas",
+		now.Format("15:04:05"), len(status.GetServers()), len(status.GetPlayerQueue()), len(matches))
+	return nil
+}
+
+// newMatches recorre el historial, de la partida más reciente a la más
+// antigua, hasta la primera ya exportada; las devuelve en orden de cierre.
+func (e *exporter) newMatches(ctx context.Context) ([]*pb.MatchRecord, error) {
+	var out []*pb.MatchRecord
+	req := &pb.MatchHistoryRequest{Namespace: e.namespace, PageSize: 100}
+	for {
+		resp, err := e.client.GetMatchHistory(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, mt := range resp.GetMatches() {
+			if e.seen[mt.GetMatchId()] {
+				return reverseMatches(out), nil
+			}
+			out = append(out, mt)
+		}
+		if resp.GetNextPageToken() == "" {
+			return reverseMatches(out), nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
+
+func reverseMatches(ms []*pb.MatchRecord) []*pb.MatchRecord {
+	for i, j := 0, len(ms)-1; i < j; i, j = i+1, j-1 {
+		ms[i], ms[j] = ms[j], ms[i]
+	}
+	return ms
+}
+
+// appendFile abre name dentro de -dir para agregar; isNew indica si estaba
+// vacío (y por lo tanto le falta el encabezado).
+func (e *exporter) appendFile(name string) (f *os.File, isNew bool, err error) {
+	f, err = os.OpenFile(filepath.Join(e.opts.Dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, false, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, false, err
+	}
+	return f, st.Size() == 0, nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                    JSON
+───────────────────────────────────────────────────────────────────────────────*/
+
+var exportJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+func (e *exporter) writeJSON(now time.Time, status *pb.SystemStatusResponse, matches []*pb.MatchRecord) error {
+	raw, err := exportJSON.Marshal(status)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(struct {
+		TakenAt string          `json:"taken_at"`
+		Status  json.RawMessage `json:"status"`
+	}{now.Format(time.RFC3339Nano), raw})
+	if err != nil {
+		return err
+	}
+	if err := e.appendLines("status.jsonl", [][]byte{line}); err != nil {
+		return err
+	}
+
+	if !e.opts.History {
+		return nil
+	}
+	lines := make([][]byte, 0, len(matches))
+	for _, mt := range matches {
+		raw, err := exportJSON.Marshal(mt)
+		if err != nil {
+			return err
+		}
+		// protojson no garantiza una sola línea
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return err
+		}
+		lines = append(lines, compact.Bytes())
+	}
+	return e.appendLines("matches.jsonl", lines)
+}
+
+func (e *exporter) appendLines(name string, lines [][]byte) error {
+	f, _, err := e.appendFile(name)
+	if err != nil {
+		return err
+	}
+	for _, l := range lines {
+		if _, err := f.Write(append(l, '\n')); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                    CSV
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (e *exporter) writeCSV(now time.Time, status *pb.SystemStatusResponse, matches []*pb.MatchRecord) error {
+	at := now.Format(time.RFC3339)
+	ns := status.GetNamespace()
+
+	var servers [][]string
+	for _, s := range status.GetServers() {
+		servers = append(servers, []string{at, ns, s.GetServerId(), s.GetStatus().String(),
+			strconv.FormatBool(s.GetDraining()), s.GetAddress(), s.GetRegion(), itoa(int64(s.GetLatencyMs())),
+			itoa(int64(s.GetFreeSlots())), itoa(int64(s.GetCapacity())), ftoa(s.GetUtilization()),
+			itoa(int64(s.GetMatchesRecent())), s.GetCurrentMatchId()})
+	}
+	if err := e.appendCSV("servers.csv", []string{"taken_at", "namespace", "server_id", "status", "draining",
+		"address", "region", "latency_ms", "free_slots", "capacity", "utilization", "matches_recent", "matches"}, servers); err != nil {
+		return err
+	}
+
+	var queue [][]string
+	for _, q := range status.GetPlayerQueue() {
+		queue = append(queue, []string{at, ns, q.GetPlayerId(), q.GetGameMode(), itoa(q.GetSecondsInQueue()),
+			strings.TrimPrefix(q.GetPriority().String(), "PRIORITY_"), strings.TrimPrefix(q.GetTier().String(), "TIER_"),
+			q.GetRegion(), q.GetPartyId()})
+	}
+	if err := e.appendCSV("queue.csv", []string{"taken_at", "namespace", "player_id", "game_mode",
+		"seconds_in_queue", "priority", "tier", "region", "party_id"}, queue); err != nil {
+		return err
+	}
+
+	var modes [][]string
+	for _, md := range status.GetModes() {
+		modes = append(modes, []string{at, ns, md.GetGameMode(), strconv.FormatBool(md.GetEnabled()),
+			itoa(int64(md.GetQueued())), itoa(md.GetAvgWaitMs()), itoa(md.GetMaxWaitMs()),
+			itoa(int64(md.GetActiveMatches())), itoa(int64(md.GetMatchesRecent())), itoa(md.GetAvgMatchedWaitMs())})
+	}
+	if err := e.appendCSV("modes.csv", []string{"taken_at", "namespace", "game_mode", "enabled", "queued",
+		"avg_wait_ms", "max_wait_ms", "active_matches", "matches_recent", "avg_matched_wait_ms"}, modes); err != nil {
+		return err
+	}
+
+	if !e.opts.History {
+		return nil
+	}
+	var rows [][]string
+	for _, mt := range matches {
+		rows = append(rows, []string{ns, itoa(int64(mt.GetSequence())), mt.GetMatchId(), mt.GetServerId(),
+			mt.GetGameMode(), strings.Join(mt.GetPlayerIds(), ";"), mt.GetWinnerId(), mt.GetOutcome().String(),
+			itoa(mt.GetDurationMs()), unixMs(mt.GetStartedAtUnixMs()), unixMs(mt.GetFinishedAtUnixMs())})
+	}
+	return e.appendCSV("matches.csv", []string{"namespace", "sequence", "match_id", "server_id", "game_mode",
+		"players", "winner_id", "outcome", "duration_ms", "started_at", "finished_at"}, rows)
+}
+
+// appendCSV agrega rows a name, con header si el archivo es nuevo.
+func (e *exporter) appendCSV(name string, header []string, rows [][]string) error {
+	f, isNew, err := e.appendFile(name)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if isNew {
+		w.Write(header)
+	}
+	w.WriteAll(rows) // WriteAll vacía el buffer
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func itoa(n int64) string { return strconv.FormatInt(n, 10) }
+
+func ftoa(f float64) string { return strconv.FormatFloat(f, 'f', 4, 64) }
+
+func unixMs(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).Format(time.RFC3339Nano)
+}
@@ -0,0 +1,248 @@
+// adminclient/healthcheck.go
+//
+// Diagnóstico de un solo disparo: `adminclient healthcheck` consulta el
+// servicio de salud (grpc.health.v1) del Matchmaker y de cada GameServer
+// registrado en cualquier namespace, y resume el resultado en semáforo con
+// la latencia de cada componente. El código de salida es 0 (verde),
+// 1 (amarillo) o 2 (rojo), para usarlo también desde scripts. Si
+// MATCHMAKER_ADDR lista varios Matchmakers (o un SRV), sondea cada uno y
+// toma la lista de servidores del que atiende.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vimsent/L3/internal/discovery"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthLevel es el color de un componente; el mayor manda en el resumen.
+type healthLevel int
+
+const (
+	healthGreen healthLevel = iota
+	healthYellow
+	healthRed
+)
+
+func (l healthLevel) String() string {
+	switch l {
+	case healthGreen:
+		return "🟢 VERDE"
+	case healthYellow:
+		return "🟡 AMARILLO"
+	}
+	return "🔴 ROJO"
+}
+
+// componentHealth es el resultado de sondear un componente.
+type componentHealth struct {
+	Name    string
+	Addr    string
+	Level   healthLevel
+	Latency time.Duration
+	Detail  string
+}
+
+// healthOptions fija los umbrales del diagnóstico.
+type healthOptions struct {
+	Timeout time.Duration // plazo de cada sonda
+	Slow    time.Duration // por encima, el componente queda amarillo
+}
+
+// probeHealth consulta el servicio de salud en addr para service.
+func probeHealth(addr, service string, creds credentials.TransportCredentials, timeout time.Duration) (healthpb.HealthCheckResponse_ServingStatus, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(creds), grpc.WithBlock())
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, time.Since(start), err
+	}
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		return healthpb.HealthCheckResponse_UNKNOWN, time.Since(start), err
+	}
+	return res.GetStatus(), time.Since(start), nil
+}
+
+// runHealthcheck sondea el Matchmaker y sus GameServers, imprime el
+// resumen y devuelve el código de salida.
+func runHealthcheck(addr string, client mmclient.Options, opts healthOptions) int {
+	report, active := probeMatchmakers(addr, client.Creds, opts)
+
+	if active != "" {
+		servers, err := listAllServers(active, client, opts.Timeout)
+		if err != nil {
+			report = append(report, componentHealth{
+				Name: "GameServers", Level: healthRed,
+				Detail: fmt.Sprintf("no se pudo listar: %v", err),
+			})
+		} else if len(servers) == 0 {
+			report = append(report, componentHealth{
+				Name: "GameServers", Level: healthYellow,
+				Detail: "ninguno registrado: no se pueden formar partidas",
+			})
+		}
+		// en paralelo: un servidor caído no debe sumar su plazo a los demás
+		probes := make([]componentHealth, len(servers))
+		var wg sync.WaitGroup
+		for i, s := range servers {
+			wg.Add(1)
+			go func(i int, s nsServer) {
+				defer wg.Done()
+				probes[i] = probeGameServer(s, client.Creds, opts)
+			}(i, s)
+		}
+		wg.Wait()
+		report = append(report, probes...)
+	}
+
+	overall := healthGreen
+	fmt.Println("\n==================== HEALTHCHECK ====================")
+	for _, c := range report {
+		fmt.Printf("  %-12s %-28s %-22s %8s  %s\n",
+			c.Level, c.Name, c.Addr, c.Latency.Round(time.Millisecond), c.Detail)
+		if c.Level > overall {
+			overall = c.Level
+		}
+	}
+	fmt.Printf("\nResumen: %s\n", overall)
+	fmt.Println("=====================================================")
+	return int(overall)
+}
+
+// probeMatchmakers sondea cada Matchmaker candidato de addr (ver
+// internal/discovery) y devuelve su resultado y el primero que atiende, o
+// "" si ninguno. Con un solo candidato, no atender es rojo; con varios,
+// basta con que uno atienda y los demás se dan por respaldos.
+func probeMatchmakers(addr string, creds credentials.TransportCredentials, opts healthOptions) ([]componentHealth, string) {
+	candidates := []string{addr}
+	if spec, _ := discovery.Parse(addr); spec.Multi() {
+		ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+		list, err := discovery.Lookup(ctx, addr)
+		cancel()
+		if err != nil {
+			return []componentHealth{{
+				Name: "Matchmaker", Addr: addr, Level: healthRed,
+				Detail: fmt.Sprintf("no se pudo resolver: %v", err),
+			}}, ""
+		}
+		candidates = list
+	}
+	multi := len(candidates) > 1
+
+	var report []componentHealth
+	active := ""
+	for _, cand := range candidates {
+		mm := componentHealth{Name: "Matchmaker", Addr: cand}
+		st, lat, err := probeHealth(cand, pb.Matchmaker_ServiceDesc.ServiceName, creds, opts.Timeout)
+		mm.Latency = lat
+		switch {
+		case err != nil && multi:
+			mm.Level, mm.Detail = healthYellow, fmt.Sprintf("no responde: %v", err)
+		case err != nil:
+			mm.Level, mm.Detail = healthRed, fmt.Sprintf("no responde: %v", err)
+		case st != healthpb.HealthCheckResponse_SERVING && multi:
+			mm.Detail = fmt.Sprintf("%s (respaldo en espera)", st)
+		case st != healthpb.HealthCheckResponse_SERVING:
+			mm.Level, mm.Detail = healthRed, fmt.Sprintf("%s (¿respaldo aún no promovido?)", st)
+		case lat > opts.Slow:
+			mm.Level, mm.Detail = healthYellow, "responde lento"
+		default:
+			mm.Detail = "SERVING"
+		}
+		if err == nil && st == healthpb.HealthCheckResponse_SERVING && active == "" {
+			active = cand
+		}
+		report = append(report, mm)
+	}
+	if multi && active == "" {
+		report = append(report, componentHealth{
+			Name: "Matchmaker", Addr: addr, Level: healthRed,
+			Detail: "ningún candidato atiende (SERVING)",
+		})
+	}
+	return report, active
+}
+
+// nsServer es un GameServer con el namespace en que está registrado.
+type nsServer struct {
+	Namespace string
+	*pb.ServerInfo
+}
+
+// listAllServers recorre todos los namespaces conocidos por el Matchmaker.
+func listAllServers(addr string, dial mmclient.Options, timeout time.Duration) ([]nsServer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, append(mmclient.DialOptions(dial), grpc.WithBlock())...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	client := pb.NewMatchmakerClient(conn)
+
+	first, err := client.AdminGetSystemStatus(ctx, &pb.AdminRequest{})
+	if err != nil {
+		return nil, err
+	}
+	names := first.GetNamespaces()
+	sort.Strings(names)
+
+	var out []nsServer
+	for _, name := range names {
+		resp, err := client.AdminGetSystemStatus(ctx, &pb.AdminRequest{Namespace: name})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range resp.GetServers() {
+			out = append(out, nsServer{Namespace: name, ServerInfo: s})
+		}
+	}
+	return out, nil
+}
+
+// probeGameServer sondea un GameServer y contrasta la respuesta con el
+// estado que el Matchmaker tiene registrado.
+func probeGameServer(s nsServer, creds credentials.TransportCredentials, opts healthOptions) componentHealth {
+	c := componentHealth{
+		Name: fmt.Sprintf("GS %s [%s]", s.GetServerId(), s.Namespace),
+		Addr: s.GetAddress(),
+	}
+	knownDown := s.GetStatus() == pb.ServerStatus_CAIDO
+
+	st, lat, err := probeHealth(s.GetAddress(), pb.GameServer_ServiceDesc.ServiceName, creds, opts.Timeout)
+	c.Latency = lat
+	switch {
+	case err != nil && knownDown:
+		c.Level, c.Detail = healthYellow, "caído (el Matchmaker ya lo sabe)"
+	case err != nil:
+		c.Level, c.Detail = healthRed, fmt.Sprintf("no responde pero figura %s: %v", s.GetStatus(), err)
+	case st != healthpb.HealthCheckResponse_SERVING:
+		c.Level, c.Detail = healthRed, st.String()
+	case knownDown:
+		c.Level, c.Detail = healthYellow, "responde pero el Matchmaker lo cree CAIDO"
+	case s.GetDraining():
+		c.Level, c.Detail = healthYellow, "en drenaje"
+	case lat > opts.Slow:
+		c.Level, c.Detail = healthYellow, "responde lento"
+	default:
+		c.Detail = fmt.Sprintf("SERVING • %s • libres %d/%d", s.GetStatus(), s.GetFreeSlots(), s.GetCapacity())
+	}
+	return c
+}
@@ -0,0 +1,141 @@
+// adminclient/log_tail.go
+//
+// Log del Matchmaker en vivo (AdminStreamLogs), para seguir una demo sin
+// entrar por SSH al contenedor. Desde la opción 22 del menú se pide el
+// nivel mínimo y las etiquetas y se muestra hasta presionar Enter; como
+// `adminclient logs [-level warn] [-tags default,loadgen]`, hasta Ctrl+C.
+// Las etiquetas son las que van entre corchetes al inicio de cada línea: el
+// componente ("Matchmaker") o el namespace. Con SHARD_ROUTES se sigue sólo
+// al Matchmaker de MATCHMAKER_ADDR.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// splitTags separa una lista de etiquetas escrita con comas.
+func splitTags(raw string) []string {
+	var out []string
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func printLogLine(l *pb.LogLine) {
+	if n := l.GetDropped(); n > 0 {
+		fmt.Printf("   … %d líneas perdidas (el cliente no alcanzó a recibirlas)\n", n)
+	}
+	tags := ""
+	for _, t := range l.GetTags() {
+		tags += "[" + t + "] "
+	}
+	ts := time.UnixMilli(l.GetUnixMs()).Format("15:04:05.000")
+	fmt.Printf("%s[%s]%s %s %s%s\n", levelColor(l.GetLevel()), l.GetLevel(), colorReset, ts, tags, l.GetMessage())
+}
+
+const colorReset = "\033[0m"
+
+func levelColor(level string) string {
+	switch level {
+	case "DEBUG":
+		return "\033[36m"
+	case "WARN":
+		return "\033[33m"
+	case "ERROR":
+		return "\033[31m"
+	}
+	return "\033[32m"
+}
+
+// streamLogs imprime el log del Matchmaker hasta que ctx se cancele.
+func streamLogs(ctx context.Context, client pb.MatchmakerClient, req *pb.LogStreamRequest) error {
+	stream, err := client.AdminStreamLogs(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		l, err := stream.Recv()
+		if err == io.EOF || status.Code(err) == codes.Canceled || errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		printLogLine(l)
+	}
+}
+
+// tailLogsMenu es la opción 22 del menú: sigue el log hasta que se presione
+// Enter.
+func tailLogsMenu(client pb.MatchmakerClient, reader *bufio.Reader) {
+	fmt.Print("   ➤ Nivel mínimo (DEBUG, INFO, WARN, ERROR) [INFO]: ")
+	levelRaw, _ := reader.ReadString('\n')
+	fmt.Print("   ➤ Etiquetas separadas por comas (vacío = todas): ")
+	tagsRaw, _ := reader.ReadString('\n')
+	req := &pb.LogStreamRequest{MinLevel: strings.TrimSpace(levelRaw), Tags: splitTags(tagsRaw)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	enter := make(chan struct{})
+	go func() {
+		reader.ReadString('\n')
+		close(enter)
+		cancel()
+	}()
+
+	fmt.Println("\n================ LOG DEL MATCHMAKER (Enter para volver) ================")
+	if err := streamLogs(ctx, client, req); err != nil {
+		log.Printf("[AdminClient] ERROR al seguir el log: %v\n", err)
+	}
+	if ctx.Err() == nil {
+		fmt.Println("   (el Matchmaker cerró el log; Enter para volver al menú)")
+	}
+	<-enter // la goroutine consume el Enter; no debe quedar leyendo el menú
+	fmt.Print("========================================================================\n\n")
+}
+
+// runLogs ejecuta `adminclient logs` y devuelve el código de salida.
+func runLogs(client pb.MatchmakerClient, args []string) int {
+	fs := flag.NewFlagSet("logs", flag.ContinueOnError)
+	level := fs.String("level", "INFO", "nivel mínimo: DEBUG, INFO, WARN o ERROR")
+	tags := fs.String("tags", "", "etiquetas separadas por comas (componente o namespace); vacío = todas")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		log.Printf("[AdminClient] logs: argumentos de más: %v", fs.Args())
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err := streamLogs(ctx, client, &pb.LogStreamRequest{MinLevel: *level, Tags: splitTags(*tags)})
+	if status.Code(err) == codes.InvalidArgument {
+		log.Printf("[AdminClient] logs: %v", status.Convert(err).Message())
+		return 2
+	}
+	if err != nil {
+		log.Printf("[AdminClient] logs: %v", err)
+		return 1
+	}
+	return 0
+}
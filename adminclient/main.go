@@ -5,42 +5,447 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/shard"
+	"github.com/vimsent/L3/internal/tlsutil"
+	"github.com/vimsent/L3/internal/tracing"
+	"github.com/vimsent/L3/pkg/mmclient"
 	pb "github.com/vimsent/L3/proto" // ⬅️  ajusta esta ruta a tu módulo
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // ===== Utilidades de impresión =====
 
 func printSystemStatus(resp *pb.SystemStatusResponse) {
 	fmt.Println("\n==================== ESTADO DEL SISTEMA ====================")
+	fmt.Printf("Namespace: %s (conocidos: %s)\n", resp.Namespace, strings.Join(resp.Namespaces, ", "))
+	if len(resp.OwnedModes) > 0 {
+		fmt.Printf("Matchmaker: %s (modos: %s)\n", resp.MatchmakerId, strings.Join(resp.OwnedModes, ", "))
+	}
 
-	fmt.Println("\n🖥  Servidores de Partida")
+	fmt.Println("\n🖥  Servidores de Partida (* = en drenaje)")
 	if len(resp.Servers) == 0 {
 		fmt.Println("  (ninguno registrado)")
 	}
 	for _, s := range resp.Servers {
-		fmt.Printf("  - ID: %-12s | Estado: %-10s | Addr: %-18s | Partida: %s\n",
-			s.Id, s.Status.String(), s.Address, s.CurrentMatchId)
+		status := s.Status.String()
+		if s.Draining {
+			status += "*"
+		}
+		fmt.Printf("  - ID: %-12s | Estado: %-10s | Addr: %-18s | Región: %-8s | Latencia: %4d ms | Libres: %d/%d | Uso: %3.0f%% | Recientes: %-3d | Partidas: %s\n",
+			s.ServerId, status, s.Address, s.Region, s.LatencyMs, s.FreeSlots, s.Capacity,
+			s.Utilization*100, s.MatchesRecent, s.CurrentMatchId)
+	}
+	if len(resp.Removed) > 0 {
+		fmt.Println("\n🗑  Servidores retirados del pool (ya no cuentan; los CAIDO siguen arriba)")
+		for _, r := range resp.Removed {
+			fmt.Printf("  - ID: %-12s | Motivo: %-10s | Addr: %-18s | Retirado: %s\n",
+				r.ServerId, r.Reason, r.Address, time.UnixMilli(r.RemovedAtUnixMs).Format("15:04:05"))
+		}
+	}
+
+	if rl := resp.QueueRateLimit; rl != nil {
+		fmt.Println("\n🚦  Límite de QueuePlayer (todos los namespaces; ritmo 0 = sin límite)")
+		fmt.Printf("  - Por jugador: %.2f/s (ráfaga %d) | Global: %.2f/s (ráfaga %d, quedan %.0f) | Jugadores activos: %d\n",
+			rl.PlayerRate, rl.PlayerBurst, rl.GlobalRate, rl.GlobalBurst, rl.GlobalTokens, rl.ActivePlayers)
+		fmt.Printf("  - Aceptados: %d | Rechazados por jugador: %d | Rechazados globales: %d\n",
+			rl.Allowed, rl.RejectedPlayer, rl.RejectedGlobal)
+	}
+
+	window := time.Duration(resp.WindowS) * time.Second
+	fmt.Printf("\n🕹  Modos de juego (recientes = últimos %v; - = deshabilitado)\n", window)
+	if len(resp.Modes) == 0 {
+		fmt.Println("  (sin modos)")
+	}
+	for _, md := range resp.Modes {
+		name := md.GameMode
+		if !md.Enabled {
+			name = "-" + name
+		}
+		fmt.Printf("  - Modo: %-10s | En cola: %-4d | Espera media: %-6v (máx %-6v) | En curso: %-3d | Recientes: %-4d | Espera al emparejar: %v\n",
+			name, md.Queued, msDuration(md.AvgWaitMs), msDuration(md.MaxWaitMs),
+			md.ActiveMatches, md.MatchesRecent, msDuration(md.AvgMatchedWaitMs))
 	}
 
 	fmt.Println("\n🎮  Jugadores en Cola")
-	if len(resp.Queue) == 0 {
+	if len(resp.PlayerQueue) == 0 {
 		fmt.Println("  (no hay jugadores esperando)")
 	}
-	for _, q := range resp.Queue {
-		fmt.Printf("  - PlayerID: %-12s | Segundos en cola: %d\n",
-			q.PlayerId, q.SecondsInQueue)
+	for _, q := range resp.PlayerQueue {
+		fmt.Printf("  - PlayerID: %-12s | Modo: %-10s | Segundos en cola: %-4d | Prioridad: %-8s | Nivel: %-6s | Región: %-8s | Grupo: %s\n",
+			q.PlayerId, q.GameMode, q.SecondsInQueue, strings.TrimPrefix(q.Priority.String(), "PRIORITY_"),
+			strings.TrimPrefix(q.Tier.String(), "TIER_"), q.Region, q.PartyId)
+	}
+
+	fmt.Println("\n⚔️  Partidas activas (hora de entrada a cada fase; - = aún no)")
+	if len(resp.Matches) == 0 {
+		fmt.Println("  (ninguna)")
+	}
+	for _, mt := range resp.Matches {
+		fmt.Printf("  - MatchID: %-12s | Modo: %-10s | Servidor: %-12s | Fase: %-11s | Creada: %s | Asignada: %s | En curso: %s | Jugadores: %s\n",
+			mt.MatchId, mt.GameMode, mt.ServerId, strings.TrimPrefix(mt.Phase.String(), "MATCH_PHASE_"),
+			phaseClock(mt.CreatedUnixMs), phaseClock(mt.AssignedUnixMs), phaseClock(mt.InProgressUnixMs),
+			strings.Join(mt.PlayerIds, ","))
+	}
+
+	if len(resp.Banned) > 0 {
+		fmt.Println("\n🚫  Jugadores baneados")
+		for _, b := range resp.Banned {
+			fmt.Printf("  - PlayerID: %-12s | Motivo: %s\n", b.PlayerId, b.Reason)
+		}
+	}
+
+	fmt.Print("============================================================\n\n")
+}
+
+// phaseClock muestra la hora de entrada a una fase; "-" si no llegó.
+func phaseClock(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).Format("15:04:05")
+}
+
+// msDuration redondea una duración en milisegundos para mostrarla.
+func msDuration(ms int64) time.Duration {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond)
+}
+
+func printClockMetrics(resp *pb.ClockMetricsResponse) {
+	fmt.Println("\n================ MÉTRICAS DEL RELOJ VECTORIAL ================")
+	fmt.Printf("  Componentes del reloj     : %d\n", resp.Entries)
+	fmt.Printf("  Merges                    : %d (prom %.2f µs, máx %.2f µs)\n",
+		resp.Merges, resp.AvgMergeMicros, resp.MaxMergeMicros)
+	fmt.Printf("  Serializaciones           : %d (prom %.1f B, última %d B)\n",
+		resp.Serializations, resp.AvgSerializedBytes, resp.LastSerializedBytes)
+	fmt.Printf("  Podados / lápidas         : %d / %d\n", resp.PrunedEntries, resp.Tombstones)
+	if resp.ReplicaConflicts > 0 {
+		fmt.Printf("  Conflictos de réplica     : %d\n", resp.ReplicaConflicts)
+	}
+	fmt.Print("==============================================================\n\n")
+}
+
+func printServerReliability(resp *pb.ReliabilityResponse) {
+	fmt.Println("\n============ CONFIABILIDAD DE SERVIDORES (⚠ = lag crónico) ============")
+	if len(resp.Servers) == 0 {
+		fmt.Println("  (sin partidas finalizadas)")
+	}
+	for _, s := range resp.Servers {
+		mark := " "
+		if s.Laggy {
+			mark = "⚠"
+		}
+		fmt.Printf("  %s %-12s | Partidas: %-4d | Opiniones: %-4d | Nota: %.1f | Lag: %d (%.0f%%)\n",
+			mark, s.ServerId, s.Matches, s.FeedbackCount, s.AvgRating, s.LagReports, s.LagRatio*100)
+	}
+	fmt.Print("=======================================================================\n\n")
+}
+
+func printMatchHistory(resp *pb.MatchHistoryResponse) {
+	if len(resp.Matches) == 0 {
+		fmt.Println("  (sin partidas terminadas)")
+	}
+	for _, mt := range resp.Matches {
+		outcome := mt.Outcome.String()
+		switch {
+		case mt.Outcome != pb.MatchRecord_COMPLETED:
+		case len(mt.WinnerIds) > 1:
+			outcome = "ganan " + strings.Join(mt.WinnerIds, "+")
+		case mt.WinnerId == "":
+			outcome = "empate"
+		default:
+			outcome = "ganó " + mt.WinnerId
+		}
+		fmt.Printf("  #%-5d %s | %-12s | Modo: %-6s | Server: %-12s | %8v | %-18s | Jugadores: %s\n",
+			mt.Sequence, time.UnixMilli(mt.FinishedAtUnixMs).Format("2006-01-02 15:04:05"), mt.MatchId,
+			mt.GameMode, mt.ServerId, (time.Duration(mt.DurationMs) * time.Millisecond).Round(time.Second),
+			outcome, strings.Join(mt.PlayerIds, ", "))
+	}
+}
+
+func printEventLog(resp *pb.EventLogResponse) {
+	if len(resp.Events) == 0 {
+		fmt.Println("  (sin eventos)")
+	}
+	for _, ev := range resp.Events {
+		var who []string
+		if len(ev.PlayerIds) > 0 {
+			who = append(who, "Jugadores: "+strings.Join(ev.PlayerIds, ", "))
+		}
+		if ev.MatchId != "" {
+			who = append(who, "Partida: "+ev.MatchId)
+		}
+		if ev.ServerId != "" {
+			who = append(who, "Server: "+ev.ServerId)
+		}
+		if ev.Detail != "" {
+			who = append(who, ev.Detail)
+		}
+		fmt.Printf("  #%-6d %s | %-10s | %-16s | %s\n",
+			ev.Sequence, time.UnixMilli(ev.UnixMs).Format("2006-01-02 15:04:05.000"), ev.Namespace,
+			ev.Kind, strings.Join(who, " | "))
+	}
+}
+
+func printLeaderboard(resp *pb.LeaderboardResponse) {
+	if len(resp.Entries) == 0 {
+		fmt.Println("  (sin jugadores)")
+	}
+	for _, e := range resp.Entries {
+		fmt.Printf("  #%-4d %-14s | Partidas: %-4d | Victorias: %-4d (%5.1f%%) | Espera media: %8v (%d emparejamientos)\n",
+			e.Rank, e.PlayerId, e.MatchesPlayed, e.Wins, e.WinRate*100,
+			(time.Duration(e.AvgWaitMs) * time.Millisecond).Round(100*time.Millisecond), e.Waits)
+	}
+}
+
+// printMatchTimeline muestra cada hito con el tiempo transcurrido desde el
+// primero y desde el anterior.
+func printMatchTimeline(resp *pb.MatchTimelineResponse) {
+	fmt.Printf("\n============ LÍNEA DE TIEMPO DE %s (%s, server %s) ============\n",
+		resp.MatchId, resp.GameMode, resp.ServerId)
+	var first, prev int64
+	for i, ev := range resp.Events {
+		if i == 0 {
+			first, prev = ev.UnixMs, ev.UnixMs
+		}
+		who := ev.PlayerId
+		if who == "" {
+			who = ev.Detail
+		}
+		fmt.Printf("  %-8s | %s | +%-9v | Δ %-9v | %-14s | %s\n",
+			ev.Stage, time.UnixMilli(ev.UnixMs).Format("15:04:05.000"),
+			time.Duration(ev.UnixMs-first)*time.Millisecond, time.Duration(ev.UnixMs-prev)*time.Millisecond,
+			who, clocks.FromProto(ev.VectorClock))
+		prev = ev.UnixMs
+	}
+	fmt.Print("=====================================================================\n\n")
+}
+
+func printSLOStatus(resp *pb.SLOStatusResponse) {
+	fmt.Printf("\n============ SLO DE ESPERA EN COLA (ventana %v, ⚠ = en riesgo) ============\n",
+		time.Duration(resp.WindowMs)*time.Millisecond)
+	if len(resp.Slos) == 0 {
+		fmt.Println("  (sin SLOs configurados; ver MATCH_SLOS)")
+	}
+	for _, s := range resp.Slos {
+		mark := " "
+		if s.AtRisk {
+			mark = "⚠"
+		}
+		fmt.Printf("  %s %-8s | Objetivo: %.1f%% en %v | Cumple: %.1f%% (%d/%d, %d fuera de plazo en cola) | Burn rate: %.2f\n",
+			mark, s.Mode, s.Target*100, time.Duration(s.ThresholdMs)*time.Millisecond,
+			s.Compliance*100, s.WithinThreshold, s.Matched, s.Overdue, s.BurnRate)
+	}
+	fmt.Print("==========================================================================\n\n")
+}
+
+// exportQueueHistory descarga el CSV de muestras de la cola y lo guarda en path.
+func exportQueueHistory(client pb.MatchmakerClient, namespace, path string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream, err := client.AdminExportQueueHistory(ctx, &pb.QueueHistoryRequest{Namespace: namespace})
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	total := 0
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+		n, err := f.Write(chunk.GetData())
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// readGameMode pide por consola los campos de un modo de juego.
+func readGameMode(reader *bufio.Reader) (*pb.GameMode, bool) {
+	ask := func(prompt string) string {
+		fmt.Print(prompt)
+		raw, _ := reader.ReadString('\n')
+		return strings.TrimSpace(raw)
+	}
+
+	name := ask("   ➤ Nombre del modo: ")
+	teamSize, err1 := strconv.Atoi(ask("   ➤ Jugadores por equipo: "))
+	minDur, err2 := strconv.Atoi(ask("   ➤ Duración mínima (s): "))
+	maxDur, err3 := strconv.Atoi(ask("   ➤ Duración máxima (s): "))
+	enabled := strings.ToLower(ask("   ➤ ¿Habilitado? (s/n): ")) != "n"
+	optional := func(prompt string) (int, bool) {
+		raw := ask(prompt)
+		if raw == "" {
+			return 0, true
+		}
+		n, err := strconv.Atoi(raw)
+		return n, err == nil
+	}
+	cooldown, ok := optional("   ➤ Cooldown tras partida (s) [0]: ")
+	if !ok {
+		return nil, false
+	}
+	// sala por tiempo: todos contra todos, arranca llena o tras la espera
+	var lobbyMin, lobbyMax, lobbyWait int
+	if lobbyMax, ok = optional("   ➤ Sala por tiempo: máximo de jugadores [0 = dos equipos]: "); !ok {
+		return nil, false
+	}
+	if lobbyMax > 0 {
+		var okMin, okWait bool
+		lobbyMin, okMin = optional("   ➤ Sala: mínimo de jugadores para arrancar: ")
+		lobbyWait, okWait = optional("   ➤ Sala: espera tras reunir el mínimo (s): ")
+		if !okMin || !okWait {
+			return nil, false
+		}
+	}
+	// simulación en el GameServer; vacío = valores por defecto del servidor
+	scoring := pb.GameMode_DEATHMATCH
+	if lobbyMax == 0 {
+		switch strings.ToLower(ask("   ➤ Puntuación: (i)ndividual o por (e)quipos [i]: ")) {
+		case "", "i":
+		case "e":
+			scoring = pb.GameMode_TEAM_DEATHMATCH
+		default:
+			return nil, false
+		}
+	}
+	eventMs, okEvent := optional("   ➤ Cadencia de eventos de puntaje (ms) [1000]: ")
+	var scoreProb float64
+	if raw := ask("   ➤ Probabilidad de eliminación por evento [0.6]: "); raw != "" {
+		p, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, false
+		}
+		scoreProb = p
+	}
+	killPoints, okKill := optional("   ➤ Puntos por eliminación [100]: ")
+	deathPoints, okDeath := optional("   ➤ Puntos que resta cada muerte [25]: ")
+	if !okEvent || !okKill || !okDeath {
+		return nil, false
+	}
+	if name == "" || err1 != nil || err2 != nil || err3 != nil {
+		return nil, false
+	}
+	return &pb.GameMode{
+		Name:            name,
+		TeamSize:        int32(teamSize),
+		MinDurationS:    int32(minDur),
+		MaxDurationS:    int32(maxDur),
+		Enabled:         enabled,
+		CooldownS:       int32(cooldown),
+		LobbyMinPlayers: int32(lobbyMin),
+		LobbyMaxPlayers: int32(lobbyMax),
+		LobbyWaitS:      int32(lobbyWait),
+		Scoring:         scoring,
+		EventIntervalMs: int32(eventMs),
+		ScoreProb:       float32(scoreProb),
+		KillPoints:      int32(killPoints),
+		DeathPoints:     int32(deathPoints),
+	}, true
+}
+
+// readWhatIf pide por consola el escenario del simulador de capacidad; una
+// respuesta vacía conserva el valor actual.
+func readWhatIf(reader *bufio.Reader) (*pb.WhatIfRequest, bool) {
+	ask := func(prompt string) string {
+		fmt.Print(prompt)
+		raw, _ := reader.ReadString('\n')
+		return strings.TrimSpace(raw)
 	}
+	req := &pb.WhatIfRequest{GameMode: ask("   ➤ Modo a proyectar [todos]: ")}
 
-	fmt.Println("============================================================\n")
+	if raw := ask("   ➤ Servidores a sumar (negativo = quitar) [0]: "); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, false
+		}
+		req.ExtraServers = int32(n)
+	}
+	if raw := ask("   ➤ Jugadores por equipo [actual]: "); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return nil, false
+		}
+		req.TeamSize = int32(n)
+	}
+	if raw := ask("   ➤ Factor de duración de partida (0.5 = el doble de rápidas) [1]: "); raw != "" {
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil || f <= 0 {
+			return nil, false
+		}
+		req.DurationFactor = f
+	}
+	if raw := ask("   ➤ Historial a reproducir (p.ej. 1h) [todo]: "); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			return nil, false
+		}
+		req.WindowMs = d.Milliseconds()
+	}
+	return req, true
+}
+
+func printWhatIf(req *pb.WhatIfRequest, resp *pb.WhatIfResponse) {
+	ms := msDuration
+	row := func(label string, w *pb.WaitProjection) {
+		fmt.Printf("  %-11s | p50 %8v | p90 %8v | p99 %8v | emparejados %4d | sin partida %4d | ocupación %5.1f%%\n",
+			label, ms(w.GetP50Ms()), ms(w.GetP90Ms()), ms(w.GetP99Ms()),
+			w.GetMatched(), w.GetUnmatched(), w.GetUtilization()*100)
+	}
+	mode := req.GetGameMode()
+	if mode == "" {
+		mode = "todos los modos"
+	}
+
+	fmt.Printf("\n==================== SIMULACIÓN DE CAPACIDAD (%s) ====================\n", mode)
+	if resp.GetArrivals() == 0 {
+		fmt.Println("  (sin llegadas registradas en el historial; encola jugadores y vuelve a intentar)")
+	}
+	fmt.Printf("  Historial: %d jugadores en %v | Escenario: %d servidores, %d huecos, partidas de %v\n",
+		resp.GetArrivals(), ms(resp.GetWindowMs()), resp.GetServers(), resp.GetSlots(), ms(resp.GetAvgMatchMs()))
+	row("Actual", resp.GetCurrent())
+	row("Proyectado", resp.GetProjected())
+	fmt.Print("=======================================================================\n\n")
+}
+
+func printReloadConfig(resp *pb.ReloadConfigResponse) {
+	switch resp.GetStatusCode() {
+	case pb.ReloadConfigResponse_OK:
+		fmt.Printf("   ✅  %s (%s)\n", resp.GetMessage(), resp.GetPath())
+		for _, c := range resp.GetChanges() {
+			fmt.Printf("       %-20s %s → %s\n", c.GetKey(), c.GetOldValue(), c.GetNewValue())
+		}
+	case pb.ReloadConfigResponse_INVALID:
+		fmt.Printf("   ❌  %s (%s):\n", resp.GetMessage(), resp.GetPath())
+		for _, e := range resp.GetErrors() {
+			fmt.Printf("       • %s\n", e)
+		}
+	default:
+		fmt.Printf("   ❌  %s\n", resp.GetMessage())
+	}
+	fmt.Println()
 }
 
 // ===== Conversión de texto a enum =====
@@ -60,14 +465,40 @@ func parseServerStatus(input string) (pb.ServerStatus, bool) {
 
 // ===== Menú principal =====
 
-func adminMenu(client pb.MatchmakerClient) {
+func adminMenu(shards []shardClient, namespace string) {
+	client := shards[0].client
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
 		fmt.Println("=========== CLIENTE ADMINISTRADOR ===========")
+		fmt.Printf("   (namespace actual: %s)\n", namespace)
 		fmt.Println("1) Ver estado completo del sistema")
 		fmt.Println("2) Cambiar estado de un servidor")
-		fmt.Println("3) Salir")
+		fmt.Println("3) Ver métricas del reloj vectorial")
+		fmt.Println("4) Cambiar de namespace")
+		fmt.Println("5) Drenar un servidor")
+		fmt.Println("6) Exportar historial de la cola (CSV)")
+		fmt.Println("7) Crear/editar modo de juego")
+		fmt.Println("8) Asignar nivel a un jugador")
+		fmt.Println("9) Ver confiabilidad de servidores")
+		fmt.Println("10) Ver SLOs de espera en cola")
+		fmt.Println("11) Expulsar a un jugador (cola o partida)")
+		fmt.Println("12) Banear / desbanear a un jugador")
+		fmt.Println("13) Quitar el cooldown a un jugador")
+		fmt.Println("14) Simular capacidad (¿qué pasaría si…?)")
+		fmt.Println("15) Ver historial de partidas")
+		fmt.Println("16) Ver línea de tiempo de una partida")
+		fmt.Println("17) Recargar configuración del Matchmaker")
+		fmt.Println("18) Terminar una partida en curso")
+		fmt.Println("19) Ver log de eventos")
+		fmt.Println("20) Ver clasificación de jugadores")
+		fmt.Println("21) Auditar relojes (anomalías de causalidad)")
+		fmt.Println("22) Seguir el log del Matchmaker en vivo")
+		fmt.Println("23) Inyectar fallos (chaos)")
+		fmt.Println("24) Ver sesiones de jugadores")
+		fmt.Println("25) Ensayar el emparejamiento (sin formar partidas)")
+		fmt.Println("26) Ver auditoría de administración")
+		fmt.Println("27) Salir")
 		fmt.Print("Selecciona una opción: ")
 
 		optionRaw, _ := reader.ReadString('\n')
@@ -78,11 +509,12 @@ func adminMenu(client pb.MatchmakerClient) {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 			defer cancel()
 
-			resp, err := client.AdminGetSystemStatus(ctx, &pb.AdminRequest{})
+			resp, results, err := fetchStatus(ctx, shards, namespace)
 			if err != nil {
 				log.Printf("[AdminClient] ERROR al obtener estado del sistema: %v\n", err)
 				continue
 			}
+			printShards(results)
 			printSystemStatus(resp)
 
 		case "2":
@@ -105,6 +537,7 @@ func adminMenu(client pb.MatchmakerClient) {
 			_, err := client.AdminUpdateServerState(ctx, &pb.AdminServerUpdateRequest{
 				ServerId:  serverID,
 				NewStatus: newStatus,
+				Namespace: namespace,
 			})
 			if err != nil {
 				log.Printf("[AdminClient] ERROR al actualizar estado: %v\n", err)
@@ -113,6 +546,390 @@ func adminMenu(client pb.MatchmakerClient) {
 			}
 
 		case "3":
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminGetClockMetrics(ctx, &pb.AdminRequest{Namespace: namespace})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al obtener métricas del reloj: %v\n", err)
+				continue
+			}
+			printClockMetrics(resp)
+
+		case "4":
+			fmt.Print("   ➤ Namespace: ")
+			nsRaw, _ := reader.ReadString('\n')
+			if ns := strings.TrimSpace(nsRaw); ns != "" {
+				namespace = ns
+			}
+
+		case "5":
+			fmt.Print("   ➤ ID del servidor a drenar: ")
+			serverIDRaw, _ := reader.ReadString('\n')
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminDrainServer(ctx, &pb.AdminDrainServerRequest{
+				ServerId:  strings.TrimSpace(serverIDRaw),
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al drenar servidor: %v\n", err)
+			} else if resp.Status != pb.AdminUpdateResponse_OK {
+				fmt.Printf("   ❌  %s\n", resp.Message)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "6":
+			fmt.Print("   ➤ Archivo de salida [queue_history.csv]: ")
+			pathRaw, _ := reader.ReadString('\n')
+			path := strings.TrimSpace(pathRaw)
+			if path == "" {
+				path = "queue_history.csv"
+			}
+
+			n, err := exportQueueHistory(client, namespace, path)
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al exportar historial: %v\n", err)
+			} else {
+				fmt.Printf("   ✅  %d bytes escritos en %s\n", n, path)
+			}
+
+		case "7":
+			mode, ok := readGameMode(reader)
+			if !ok {
+				fmt.Println("   ❌  Valores inválidos. Intenta nuevamente.")
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminUpsertGameMode(ctx, &pb.AdminUpsertGameModeRequest{
+				Mode:      mode,
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al guardar modo de juego: %v\n", err)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "8":
+			fmt.Print("   ➤ ID del jugador: ")
+			playerRaw, _ := reader.ReadString('\n')
+			fmt.Print("   ➤ Nivel (NORMAL/VIP/TESTER): ")
+			tierRaw, _ := reader.ReadString('\n')
+
+			tier, ok := pb.PlayerTier_value["TIER_"+strings.ToUpper(strings.TrimSpace(tierRaw))]
+			if !ok {
+				fmt.Println("   ❌  Nivel no reconocido. Intenta nuevamente.")
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminSetPlayerTier(ctx, &pb.AdminSetPlayerTierRequest{
+				PlayerId:  strings.TrimSpace(playerRaw),
+				Tier:      pb.PlayerTier(tier),
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al asignar nivel: %v\n", err)
+			} else if resp.Status != pb.AdminUpdateResponse_OK {
+				fmt.Printf("   ❌  %s\n", resp.Message)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "9":
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminGetServerReliability(ctx, &pb.AdminRequest{Namespace: namespace})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al obtener la confiabilidad: %v\n", err)
+				continue
+			}
+			printServerReliability(resp)
+
+		case "10":
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminGetSLOStatus(ctx, &pb.AdminRequest{Namespace: namespace})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al obtener los SLOs: %v\n", err)
+				continue
+			}
+			printSLOStatus(resp)
+
+		case "11":
+			fmt.Print("   ➤ ID del jugador: ")
+			playerRaw, _ := reader.ReadString('\n')
+			fmt.Print("   ➤ Motivo: ")
+			reasonRaw, _ := reader.ReadString('\n')
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminKickPlayer(ctx, &pb.AdminPlayerActionRequest{
+				PlayerId:  strings.TrimSpace(playerRaw),
+				Reason:    strings.TrimSpace(reasonRaw),
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al expulsar: %v\n", err)
+			} else if resp.Status != pb.AdminUpdateResponse_OK {
+				fmt.Printf("   ❌  %s\n", resp.Message)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "12":
+			fmt.Print("   ➤ ID del jugador: ")
+			playerRaw, _ := reader.ReadString('\n')
+			fmt.Print("   ➤ ¿Banear (b) o levantar el ban (l)? ")
+			actionRaw, _ := reader.ReadString('\n')
+			lift := strings.EqualFold(strings.TrimSpace(actionRaw), "l")
+			reason := ""
+			if !lift {
+				fmt.Print("   ➤ Motivo: ")
+				reasonRaw, _ := reader.ReadString('\n')
+				reason = strings.TrimSpace(reasonRaw)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminBanPlayer(ctx, &pb.AdminPlayerActionRequest{
+				PlayerId:  strings.TrimSpace(playerRaw),
+				Reason:    reason,
+				Lift:      lift,
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al banear: %v\n", err)
+			} else if resp.Status != pb.AdminUpdateResponse_OK {
+				fmt.Printf("   ❌  %s\n", resp.Message)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "13":
+			fmt.Print("   ➤ ID del jugador: ")
+			playerRaw, _ := reader.ReadString('\n')
+			fmt.Print("   ➤ Motivo: ")
+			reasonRaw, _ := reader.ReadString('\n')
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminResetCooldown(ctx, &pb.AdminPlayerActionRequest{
+				PlayerId:  strings.TrimSpace(playerRaw),
+				Reason:    strings.TrimSpace(reasonRaw),
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al quitar el cooldown: %v\n", err)
+			} else if resp.Status != pb.AdminUpdateResponse_OK {
+				fmt.Printf("   ❌  %s\n", resp.Message)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "14":
+			req, ok := readWhatIf(reader)
+			if !ok {
+				fmt.Println("   ❌  Valores inválidos. Intenta nuevamente.")
+				continue
+			}
+			req.Namespace = namespace
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminSimulateCapacity(ctx, req)
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al simular capacidad: %v\n", err)
+				continue
+			}
+			printWhatIf(req, resp)
+
+		case "15":
+			fmt.Print("   ➤ ID del jugador (vacío = todas): ")
+			playerRaw, _ := reader.ReadString('\n')
+			req := &pb.MatchHistoryRequest{PlayerId: strings.TrimSpace(playerRaw), Namespace: namespace}
+
+			fmt.Println("\n==================== HISTORIAL DE PARTIDAS ====================")
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				resp, err := client.GetMatchHistory(ctx, req)
+				cancel()
+				if err != nil {
+					log.Printf("[AdminClient] ERROR al obtener el historial: %v\n", err)
+					break
+				}
+				printMatchHistory(resp)
+				if resp.NextPageToken == "" {
+					break
+				}
+				fmt.Print("   ➤ ¿Ver más? (s/N): ")
+				more, _ := reader.ReadString('\n')
+				if !strings.EqualFold(strings.TrimSpace(more), "s") {
+					break
+				}
+				req.PageToken = resp.NextPageToken
+			}
+			fmt.Print("===============================================================\n\n")
+
+		case "16":
+			fmt.Print("   ➤ ID de la partida: ")
+			matchRaw, _ := reader.ReadString('\n')
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.GetMatchTimeline(ctx, &pb.MatchTimelineRequest{
+				MatchId:   strings.TrimSpace(matchRaw),
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al obtener la línea de tiempo: %v\n", err)
+			} else if !resp.Found {
+				fmt.Println("   ❌  Partida desconocida (o ya fuera del historial)")
+			} else {
+				printMatchTimeline(resp)
+			}
+
+		case "17":
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminReloadConfig(ctx, &pb.ReloadConfigRequest{})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al recargar la configuración: %v\n", err)
+				continue
+			}
+			printReloadConfig(resp)
+
+		case "18":
+			fmt.Print("   ➤ ID de la partida: ")
+			matchRaw, _ := reader.ReadString('\n')
+			fmt.Print("   ➤ Motivo: ")
+			reasonRaw, _ := reader.ReadString('\n')
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminTerminateMatch(ctx, &pb.AdminTerminateMatchRequest{
+				MatchId:   strings.TrimSpace(matchRaw),
+				Reason:    strings.TrimSpace(reasonRaw),
+				Namespace: namespace,
+			})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al terminar la partida: %v\n", err)
+			} else if resp.Status != pb.AdminUpdateResponse_OK {
+				fmt.Printf("   ❌  %s\n", resp.Message)
+			} else {
+				fmt.Printf("   ✅  %s\n", resp.Message)
+			}
+
+		case "19":
+			fmt.Print("   ➤ Jugador, partida o servidor (vacío = todos): ")
+			entityRaw, _ := reader.ReadString('\n')
+			fmt.Print("   ➤ Últimos minutos (vacío = todo): ")
+			minutesRaw, _ := reader.ReadString('\n')
+			req := &pb.EventLogRequest{EntityId: strings.TrimSpace(entityRaw), Namespace: namespace}
+			if mins, err := strconv.Atoi(strings.TrimSpace(minutesRaw)); err == nil && mins > 0 {
+				req.SinceUnixMs = time.Now().Add(-time.Duration(mins) * time.Minute).UnixMilli()
+			}
+
+			fmt.Println("\n======================= LOG DE EVENTOS =======================")
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				resp, err := client.AdminGetEventLog(ctx, req)
+				cancel()
+				if err != nil {
+					log.Printf("[AdminClient] ERROR al obtener el log de eventos: %v\n", err)
+					break
+				}
+				printEventLog(resp)
+				if !resp.More {
+					break
+				}
+				fmt.Print("   ➤ ¿Ver más? (s/N): ")
+				more, _ := reader.ReadString('\n')
+				if !strings.EqualFold(strings.TrimSpace(more), "s") {
+					break
+				}
+				req.AfterSeq = resp.LastSequence
+			}
+			fmt.Print("==============================================================\n\n")
+
+		case "20":
+			fmt.Print("   ➤ Ordenar por (1=victorias, 2=partidas, 3=% victorias, 4=espera media) [1]: ")
+			sortRaw, _ := reader.ReadString('\n')
+			req := &pb.LeaderboardRequest{Namespace: namespace}
+			if n, err := strconv.Atoi(strings.TrimSpace(sortRaw)); err == nil && n >= 1 && n <= 4 {
+				req.SortBy = pb.LeaderboardRequest_SortBy(n - 1)
+			}
+
+			fmt.Println("\n===================== CLASIFICACIÓN =====================")
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				resp, err := client.GetLeaderboard(ctx, req)
+				cancel()
+				if err != nil {
+					log.Printf("[AdminClient] ERROR al obtener la clasificación: %v\n", err)
+					break
+				}
+				if req.PageToken == "" {
+					fmt.Printf("   %d jugadores, por %s\n", resp.TotalPlayers, req.SortBy)
+				}
+				printLeaderboard(resp)
+				if resp.NextPageToken == "" {
+					break
+				}
+				fmt.Print("   ➤ ¿Ver más? (s/N): ")
+				more, _ := reader.ReadString('\n')
+				if !strings.EqualFold(strings.TrimSpace(more), "s") {
+					break
+				}
+				req.PageToken = resp.NextPageToken
+			}
+			fmt.Print("=========================================================\n\n")
+
+		case "21":
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			resp, err := client.AdminGetClockState(ctx, &pb.AdminRequest{Namespace: namespace})
+			if err != nil {
+				log.Printf("[AdminClient] ERROR al obtener los relojes: %v\n", err)
+				continue
+			}
+			printClockAudit(resp)
+
+		case "22":
+			tailLogsMenu(client, reader)
+
+		case "23":
+			chaosMenu(client, reader, namespace)
+
+		case "24":
+			sessionsMenu(client, reader, namespace)
+
+		case "25":
+			simulateMatchingMenu(client, reader, namespace)
+
+		case "26":
+			auditLogMenu(client, reader, namespace)
+
+		case "27":
 			fmt.Println("Saliendo del cliente administrador. ¡Hasta pronto!")
 			return
 
@@ -125,17 +942,40 @@ func adminMenu(client pb.MatchmakerClient) {
 // ===== main =====
 
 func main() {
-	// 1. Resolver dirección del Matchmaker
-	addr := os.Getenv("MATCHMAKER_ADDR")
-	if addr == "" {
-		addr = "localhost:50051" // valor por defecto para entorno local
+	// 1. Resolver y validar dirección del Matchmaker
+	cfg := config.NewReport("adminclient")
+	addr := cfg.Matchmaker("MATCHMAKER_ADDR", "localhost:50051") // valor por defecto para entorno local
+	namespace := cfg.String("NAMESPACE", "default")
+	adminID := cfg.String("ADMIN_ID", defaultAdminID())
+	healthOpts := healthOptions{
+		Timeout: cfg.Duration("HEALTH_TIMEOUT", 2*time.Second, 100*time.Millisecond, time.Minute),
+		Slow:    cfg.Duration("HEALTH_SLOW", 200*time.Millisecond, time.Millisecond, time.Minute),
+	}
+	tlsFiles := cfg.TLS()
+	clientCfg := cfg.Client()
+	routes := shard.FromConfig(cfg)
+	tracing.Configure(cfg, "AdminClient")
+	cfg.MustValidate()
+
+	creds, err := tlsutil.ClientCredentials(tlsFiles)
+	if err != nil {
+		log.Fatalf("[AdminClient] TLS: %v", err)
+	}
+	clientOpts := mmclient.Options{
+		Creds:    creds,
+		Token:    clientCfg.Token,
+		ClientID: "admin",
+		AdminID:  adminID,
+		Retries:  clientCfg.Retries,
+	}
+
+	// `adminclient healthcheck`: diagnóstico de un solo disparo, sin menú
+	if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+		os.Exit(runHealthcheck(addr, clientOpts, healthOpts))
 	}
 
 	// 2. Conectar vía gRPC
-	conn, err := grpc.Dial(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
-	)
+	conn, err := mmclient.Dial(addr, clientOpts, grpc.WithBlock())
 	if err != nil {
 		log.Fatalf("[AdminClient] No pudo conectar al Matchmaker (%s): %v", addr, err)
 	}
@@ -144,6 +984,49 @@ func main() {
 	client := pb.NewMatchmakerClient(conn)
 	log.Printf("[AdminClient] Conectado a Matchmaker en %s\n", addr)
 
+	// con SHARD_ROUTES, el estado del sistema junta a todos los Matchmakers
+	shards, shardConns, err := dialShards(routes, shardClient{addr: addr, client: client}, clientOpts)
+	for _, c := range shardConns {
+		defer c.Close()
+	}
+	if err != nil {
+		log.Fatalf("[AdminClient] No pudo conectar a los shards: %v", err)
+	}
+	if len(shards) > 1 {
+		log.Printf("[AdminClient] %d Matchmakers en SHARD_ROUTES", len(shards))
+	}
+
+	// `adminclient clockaudit`: auditoría de relojes de un solo disparo
+	if len(os.Args) > 1 && os.Args[1] == "clockaudit" {
+		code := runClockAudit(client, namespace)
+		conn.Close()
+		os.Exit(code)
+	}
+
+	// `adminclient logs [-level warn] [-tags default]`: log del Matchmaker
+	// en vivo hasta Ctrl+C (log_tail.go)
+	if len(os.Args) > 1 && os.Args[1] == "logs" {
+		code := runLogs(client, os.Args[2:])
+		conn.Close()
+		os.Exit(code)
+	}
+
+	// `adminclient chaos [-target id|*] [-drop p] …`: fallos inyectados
+	// (chaos.go)
+	if len(os.Args) > 1 && os.Args[1] == "chaos" {
+		code := runChaos(client, namespace, os.Args[2:])
+		conn.Close()
+		os.Exit(code)
+	}
+
+	// `adminclient export [-format json|csv] [-dir d] [-watch 30s]`: archivos
+	// para análisis fuera de línea (export.go)
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		code := runExport(shards, namespace, os.Args[2:])
+		conn.Close()
+		os.Exit(code)
+	}
+
 	// 3. Manejar Ctrl+C para salir limpiamente
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
@@ -151,7 +1034,7 @@ func main() {
 	// 4. Lanzar menú en una goroutine para poder cancelar con señal
 	done := make(chan struct{})
 	go func() {
-		adminMenu(client)
+		adminMenu(shards, namespace)
 		close(done)
 	}()
 
@@ -0,0 +1,56 @@
+// adminclient/sessions.go
+//
+// Sesiones de jugador (AdminListSessions), desde la opción 24 del menú: una
+// línea por jugador con su sesión vigente, de dónde llega, si sigue viva y
+// cuántos conflictos tuvo. Sirve para encontrar dos clientes usando el mismo
+// PLAYER_ID.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func sessionsMenu(client pb.MatchmakerClient, reader *bufio.Reader, namespace string) {
+	fmt.Print("   ➤ ID del jugador (vacío = todos): ")
+	playerRaw, _ := reader.ReadString('\n')
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.AdminListSessions(ctx, &pb.AdminListSessionsRequest{
+		Namespace: namespace,
+		PlayerId:  strings.TrimSpace(playerRaw),
+	})
+	if err != nil {
+		log.Printf("[AdminClient] ERROR al listar las sesiones: %v\n", err)
+		return
+	}
+	printSessions(resp)
+}
+
+func printSessions(resp *pb.AdminListSessionsResponse) {
+	fmt.Println("\n================ SESIONES DE JUGADOR ================")
+	if len(resp.GetSessions()) == 0 {
+		fmt.Println("   (ninguna: los clientes no envían sesión o no hubo RPC recientes)")
+	}
+	for _, s := range resp.GetSessions() {
+		state := "expirada"
+		if s.GetLive() {
+			state = "viva"
+		}
+		fmt.Printf("   • %-12s sesión %-16s %-8s desde %-21s vista %s • %d RPC\n",
+			s.GetPlayerId(), s.GetSessionId(), state, s.GetPeer(),
+			time.UnixMilli(s.GetLastSeenUnixMs()).Format("15:04:05"), s.GetRpcs())
+		if s.GetConflicts() > 0 || len(s.GetFenced()) > 0 {
+			fmt.Printf("     ⚠️  %d conflictos • reemplazadas: %v\n", s.GetConflicts(), s.GetFenced())
+		}
+	}
+	fmt.Print("=====================================================\n\n")
+}
@@ -0,0 +1,166 @@
+// adminclient/shards.go
+//
+// Vista de varios Matchmakers. Con SHARD_ROUTES (internal/shard) el cliente
+// administrador se conecta también a cada Matchmaker de la tabla y el
+// estado del sistema (opción 1 y `adminclient export`) junta las
+// respuestas de todos: servidores, cola, modos, baneos y retirados de cada
+// shard, más una línea por shard con su ID, sus modos y si respondió. Las
+// demás opciones siguen yendo al Matchmaker de MATCHMAKER_ADDR.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vimsent/L3/internal/shard"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// shardClient es uno de los Matchmakers consultados.
+type shardClient struct {
+	addr   string
+	client pb.MatchmakerClient
+}
+
+// shardResult es lo que respondió un shard en la última consulta.
+type shardResult struct {
+	addr string
+	id   string
+	own  []string // modos propios; vacío = todos
+	err  error
+}
+
+// dialShards conecta con los Matchmakers de routes distintos de main.
+func dialShards(routes *shard.Routes, main shardClient, opts mmclient.Options) ([]shardClient, []*grpc.ClientConn, error) {
+	shards := []shardClient{main}
+	var conns []*grpc.ClientConn
+	for _, addr := range routes.Addrs(main.addr)[1:] {
+		conn, err := mmclient.Dial(addr, opts)
+		if err != nil {
+			return nil, conns, fmt.Errorf("%s: %v", addr, err)
+		}
+		conns = append(conns, conn)
+		shards = append(shards, shardClient{addr: addr, client: pb.NewMatchmakerClient(conn)})
+	}
+	return shards, conns, nil
+}
+
+// fetchStatus consulta AdminGetSystemStatus; con un solo Matchmaker
+// devuelve su respuesta tal cual y con varios las junta. Falla sólo si no
+// respondió ninguno.
+func fetchStatus(ctx context.Context, shards []shardClient, namespace string) (*pb.SystemStatusResponse, []shardResult, error) {
+	if len(shards) == 1 {
+		resp, err := shards[0].client.AdminGetSystemStatus(ctx, &pb.AdminRequest{Namespace: namespace})
+		return resp, nil, err
+	}
+	var parts []*pb.SystemStatusResponse
+	results := make([]shardResult, 0, len(shards))
+	var firstErr error
+	for _, sh := range shards {
+		resp, err := sh.client.AdminGetSystemStatus(ctx, &pb.AdminRequest{Namespace: namespace})
+		r := shardResult{addr: sh.addr, err: err}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else {
+			r.id, r.own = resp.MatchmakerId, resp.OwnedModes
+			parts = append(parts, resp)
+		}
+		results = append(results, r)
+	}
+	if len(parts) == 0 {
+		return nil, results, firstErr
+	}
+	return mergeStatus(parts), results, nil
+}
+
+// mergeStatus junta las vistas de varios shards del mismo namespace. Un
+// modo que figure en más de uno se toma del primero; el reloj vectorial se
+// omite porque cada shard tiene el suyo.
+func mergeStatus(parts []*pb.SystemStatusResponse) *pb.SystemStatusResponse {
+	out := &pb.SystemStatusResponse{Namespace: parts[0].Namespace, WindowS: parts[0].WindowS}
+	namespaces := map[string]bool{}
+	modes := map[string]bool{}
+	banned := map[string]bool{}
+	var ids []string
+	for _, p := range parts {
+		ids = append(ids, p.MatchmakerId)
+		out.Servers = append(out.Servers, p.Servers...)
+		out.PlayerQueue = append(out.PlayerQueue, p.PlayerQueue...)
+		out.Removed = append(out.Removed, p.Removed...)
+		out.OwnedModes = append(out.OwnedModes, p.OwnedModes...)
+		out.Matches = append(out.Matches, p.Matches...)
+		for _, n := range p.Namespaces {
+			namespaces[n] = true
+		}
+		for _, md := range p.Modes {
+			if !modes[md.GameMode] {
+				modes[md.GameMode] = true
+				out.Modes = append(out.Modes, md)
+			}
+		}
+		for _, b := range p.Banned {
+			if !banned[b.PlayerId] {
+				banned[b.PlayerId] = true
+				out.Banned = append(out.Banned, b)
+			}
+		}
+		out.QueueRateLimit = addRateLimits(out.QueueRateLimit, p.QueueRateLimit)
+	}
+	out.MatchmakerId = strings.Join(ids, ",")
+	for n := range namespaces {
+		out.Namespaces = append(out.Namespaces, n)
+	}
+	sort.Strings(out.Namespaces)
+	sort.Slice(out.Servers, func(i, j int) bool { return out.Servers[i].ServerId < out.Servers[j].ServerId })
+	sort.Slice(out.Modes, func(i, j int) bool { return out.Modes[i].GameMode < out.Modes[j].GameMode })
+	sort.Slice(out.Matches, func(i, j int) bool { return out.Matches[i].MatchId < out.Matches[j].MatchId })
+	sort.SliceStable(out.PlayerQueue, func(i, j int) bool {
+		return out.PlayerQueue[i].SecondsInQueue > out.PlayerQueue[j].SecondsInQueue
+	})
+	sort.SliceStable(out.Removed, func(i, j int) bool { return out.Removed[i].RemovedAtUnixMs > out.Removed[j].RemovedAtUnixMs })
+	return out
+}
+
+// addRateLimits suma los contadores de dos limitadores; los ritmos son los
+// del primero (cada shard limita por su cuenta).
+func addRateLimits(a, b *pb.RateLimitStats) *pb.RateLimitStats {
+	if a == nil || b == nil {
+		if a == nil {
+			return b
+		}
+		return a
+	}
+	sum := proto.Clone(a).(*pb.RateLimitStats)
+	sum.Allowed += b.Allowed
+	sum.RejectedPlayer += b.RejectedPlayer
+	sum.RejectedGlobal += b.RejectedGlobal
+	sum.ActivePlayers += b.ActivePlayers
+	return sum
+}
+
+func printShards(results []shardResult) {
+	if len(results) == 0 {
+		return
+	}
+	fmt.Println("\n🧩  Matchmakers (SHARD_ROUTES)")
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Printf("  - Addr: %-24s | ❌ sin respuesta: %v\n", r.addr, r.err)
+			continue
+		}
+		own := "todos"
+		if len(r.own) > 0 {
+			own = strings.Join(r.own, ", ")
+		}
+		fmt.Printf("  - Addr: %-24s | ID: %-14s | Modos: %s\n", r.addr, r.id, own)
+	}
+}
@@ -0,0 +1,66 @@
+// adminclient/simulate_matching.go
+//
+// Ensayo del emparejamiento (AdminSimulateMatching), desde la opción 25 del
+// menú: las partidas que formaría ahora mismo el Matchmaker con su política
+// y, para cada jugador en cola que quedaría fuera, el motivo. No modifica
+// nada, así que puede repetirse tras cambiar MATCH_POLICY o las regiones.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func simulateMatchingMenu(client pb.MatchmakerClient, reader *bufio.Reader, namespace string) {
+	fmt.Print("   ➤ Modo de juego (vacío = todos): ")
+	modeRaw, _ := reader.ReadString('\n')
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.AdminSimulateMatching(ctx, &pb.SimulateMatchingRequest{
+		Namespace: namespace,
+		GameMode:  strings.TrimSpace(modeRaw),
+	})
+	if err != nil {
+		log.Printf("[AdminClient] ERROR al ensayar el emparejamiento: %v\n", err)
+		return
+	}
+	printSimulatedMatching(resp)
+}
+
+func printSimulatedMatching(resp *pb.SimulateMatchingResponse) {
+	fmt.Printf("\n============ ENSAYO DE EMPAREJAMIENTO (%s) ============\n", resp.GetPolicy())
+	if len(resp.GetMatches()) == 0 {
+		fmt.Println("   (no se formaría ninguna partida)")
+	}
+	for _, sm := range resp.GetMatches() {
+		fmt.Printf("   ✅ %-13s %-12s en %-10s %v", sm.GetKind(), sm.GetGameMode(), sm.GetServerId(), sm.GetPlayerIds())
+		if sm.GetMatchId() != "" {
+			fmt.Printf(" → %s", sm.GetMatchId())
+		}
+		if sm.GetRelaxed() != "" {
+			fmt.Printf(" (relajada: %s)", sm.GetRelaxed())
+		}
+		fmt.Println()
+	}
+	if len(resp.GetSkipped()) > 0 {
+		fmt.Println("   Quedarían en cola:")
+	}
+	for _, s := range resp.GetSkipped() {
+		region := s.GetRegion()
+		if region == "" {
+			region = "-"
+		}
+		fmt.Printf("   • %-12s %-12s región %-8s espera %-6v %s\n", s.GetPlayerId(), s.GetGameMode(), region,
+			(time.Duration(s.GetWaitMs()) * time.Millisecond).Round(time.Second), s.GetReason())
+	}
+	fmt.Printf("   Servidores libres después: %d\n", resp.GetFreeServers())
+	fmt.Print("=======================================================\n\n")
+}
@@ -0,0 +1,261 @@
+// cmd/gateway/main.go
+//
+// Pasarela HTTP/JSON hacia el Matchmaker, para paneles web y scripts con
+// curl que no tienen cliente gRPC. Cada petición se traduce a la RPC
+// correspondiente a través de pkg/mmclient (token, ID de petición y
+// reintentos incluidos) y la respuesta se devuelve como JSON con los nombres
+// de campo del .proto.
+//
+//	POST /v1/players/{id}/register ?namespace=…  → token de jugador
+//	POST /v1/players/{id}/queue   cuerpo: PlayerInfoRequest (sin player_id)
+//	GET  /v1/players/{id}/status  ?namespace=…&clock=Matchmaker=4,P1=2&consistency=strong
+//	GET  /v1/matches/{id}/timeline ?namespace=…
+//	GET  /v1/admin/status         ?namespace=…
+//
+// Si el Matchmaker exige tokens de jugador (PLAYER_AUTH=required), la
+// cabecera X-Player-Token de la petición se reenvía como "x-player-token".
+//
+// Los errores gRPC se traducen a su código HTTP (UNAVAILABLE → 503, …) con
+// cuerpo {"code": "...", "error": "..."}.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/tlsutil"
+	"github.com/vimsent/L3/internal/tracing"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	rpcTimeout   = 10 * time.Second
+	maxBodyBytes = 64 << 10
+)
+
+var jsonOut = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// gateway traduce HTTP a RPC sobre una conexión compartida.
+type gateway struct {
+	client    pb.MatchmakerClient
+	namespace string // por defecto, si la petición no indica uno
+}
+
+func (g *gateway) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/players/{id}/register", g.registerPlayer)
+	mux.HandleFunc("POST /v1/players/{id}/queue", g.queuePlayer)
+	mux.HandleFunc("GET /v1/players/{id}/status", g.playerStatus)
+	mux.HandleFunc("GET /v1/matches/{id}/timeline", g.matchTimeline)
+	mux.HandleFunc("GET /v1/admin/status", g.systemStatus)
+	return mux
+}
+
+// rpcContext acota la RPC y propaga el X-Request-Id y el X-Player-Token del
+// cliente HTTP.
+func rpcContext(r *http.Request) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(r.Context(), rpcTimeout)
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		ctx = mmclient.WithRequestID(ctx, id)
+	}
+	ctx = mmclient.WithPlayerToken(ctx, r.Header.Get("X-Player-Token"))
+	return ctx, cancel
+}
+
+func (g *gateway) nsFrom(r *http.Request) string {
+	if ns := r.URL.Query().Get("namespace"); ns != "" {
+		return ns
+	}
+	return g.namespace
+}
+
+func (g *gateway) registerPlayer(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := rpcContext(r)
+	defer cancel()
+	res, err := g.client.RegisterPlayer(ctx, &pb.RegisterPlayerRequest{PlayerId: r.PathValue("id"), Namespace: g.nsFrom(r)})
+	reply(w, res, err)
+}
+
+func (g *gateway) queuePlayer(w http.ResponseWriter, r *http.Request) {
+	req := &pb.PlayerInfoRequest{}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err == nil && len(body) > 0 {
+		err = protojson.Unmarshal(body, req)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("cuerpo inválido: %v", err))
+		return
+	}
+	req.PlayerId = r.PathValue("id")
+	if req.Namespace == "" {
+		req.Namespace = g.nsFrom(r)
+	}
+
+	ctx, cancel := rpcContext(r)
+	defer cancel()
+	res, err := g.client.QueuePlayer(ctx, req)
+	reply(w, res, err)
+}
+
+func (g *gateway) playerStatus(w http.ResponseWriter, r *http.Request) {
+	req := &pb.PlayerStatusRequest{PlayerId: r.PathValue("id"), Namespace: g.nsFrom(r)}
+	if raw := r.URL.Query().Get("clock"); raw != "" {
+		vc := clocks.New()
+		if err := vc.FromString(raw); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("reloj inválido: %v", err))
+			return
+		}
+		req.Clock = vc.ToProto()
+	}
+	if raw := r.URL.Query().Get("consistency"); raw != "" {
+		v, ok := pb.Consistency_value["CONSISTENCY_"+strings.ToUpper(raw)]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("consistencia inválida %q: eventual, read_your_writes o strong", raw))
+			return
+		}
+		req.Consistency = pb.Consistency(v)
+	}
+
+	ctx, cancel := rpcContext(r)
+	defer cancel()
+	res, err := g.client.GetPlayerStatus(ctx, req)
+	reply(w, res, err)
+}
+
+func (g *gateway) matchTimeline(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := rpcContext(r)
+	defer cancel()
+	res, err := g.client.GetMatchTimeline(ctx, &pb.MatchTimelineRequest{MatchId: r.PathValue("id"), Namespace: g.nsFrom(r)})
+	if err == nil && !res.GetFound() {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "partida desconocida")
+		return
+	}
+	reply(w, res, err)
+}
+
+func (g *gateway) systemStatus(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := rpcContext(r)
+	defer cancel()
+	res, err := g.client.AdminGetSystemStatus(ctx, &pb.AdminRequest{Namespace: g.nsFrom(r)})
+	reply(w, res, err)
+}
+
+// reply escribe la respuesta de una RPC o su error.
+func reply(w http.ResponseWriter, res proto.Message, err error) {
+	if err != nil {
+		st := status.Convert(err)
+		for _, d := range st.Details() {
+			if ri, ok := d.(*errdetails.RetryInfo); ok {
+				// Retry-After va en segundos enteros
+				secs := (ri.GetRetryDelay().AsDuration() + time.Second - 1) / time.Second
+				w.Header().Set("Retry-After", strconv.FormatInt(int64(secs), 10))
+			}
+		}
+		writeError(w, httpStatus(st.Code()), codeName(st.Code()), st.Message())
+		return
+	}
+	data, err := jsonOut.Marshal(res)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, httpCode int, code, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpCode)
+	json.NewEncoder(w).Encode(map[string]string{"code": code, "error": msg})
+}
+
+// codeName devuelve el nombre canónico del código ("DEADLINE_EXCEEDED"), el
+// mismo que muestran los clientes gRPC de otros lenguajes.
+func codeName(c codes.Code) string {
+	var b strings.Builder
+	for i, r := range c.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// httpStatus sigue la tabla de equivalencias habitual entre gRPC y HTTP.
+func httpStatus(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // el cliente cerró la conexión
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+func main() {
+	cfg := config.NewReport("gateway")
+	port := cfg.Port("GATEWAY_PORT", 8080)
+	addr := cfg.Matchmaker("MATCHMAKER_ADDR", "localhost:50051")
+	namespace := cfg.String("NAMESPACE", "default")
+	tlsFiles := cfg.TLS()
+	clientCfg := cfg.Client()
+	tracing.Configure(cfg, "Gateway")
+	cfg.MustValidate()
+
+	creds, err := tlsutil.ClientCredentials(tlsFiles)
+	if err != nil {
+		log.Fatalf("[Gateway] TLS: %v", err)
+	}
+	conn, err := mmclient.Dial(addr, mmclient.Options{
+		Creds:    creds,
+		Token:    clientCfg.Token,
+		ClientID: "gateway",
+		Retries:  clientCfg.Retries,
+	})
+	if err != nil {
+		log.Fatalf("[Gateway] No se pudo preparar la conexión al Matchmaker (%s): %v", addr, err)
+	}
+	defer conn.Close()
+
+	g := &gateway{client: pb.NewMatchmakerClient(conn), namespace: namespace}
+	log.Printf("[Gateway] HTTP/JSON en :%d → Matchmaker %s", port, addr)
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), g.routes()); err != nil {
+		log.Fatalf("[Gateway] %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+// cmd/loadgen/players.go
+//
+// Jugadores simulados. Cada uno mantiene su suscripción a MatchUpdate y
+// repite el ciclo: esperar su turno según -queue-rate, encolarse, esperar
+// MATCH_FOUND (aceptando la confirmación previa, si el Matchmaker la pide)
+// y luego el fin de la partida. Si pasa -stall sin noticias
+// consulta su estado, por si se perdió una notificación. Si el Matchmaker
+// exige tokens (PLAYER_AUTH=required), cada jugador se registra al empezar;
+// como el registro reclama el ID, cada corrida necesita su propio -namespace.
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+type simPlayer struct {
+	id     string
+	cfg    *loadConfig
+	client pb.MatchmakerClient
+	rec    *recorder
+	turns  <-chan struct{} // un turno por encolado permitido
+	events chan *pb.MatchUpdate
+}
+
+func (p *simPlayer) run(ctx context.Context) {
+	ctx = p.register(ctx)
+	go p.subscribe(ctx)
+	for ctx.Err() == nil {
+		select {
+		case <-p.turns:
+		case <-ctx.Done():
+			return
+		}
+		queuedAt := time.Now()
+		if !p.queue(ctx) {
+			continue
+		}
+		p.play(ctx, queuedAt)
+	}
+}
+
+// register obtiene el token del jugador, si el Matchmaker lo exige, y lo deja
+// en el contexto de sus RPC.
+func (p *simPlayer) register(ctx context.Context) context.Context {
+	res, err := p.client.RegisterPlayer(ctx, &pb.RegisterPlayerRequest{PlayerId: p.id, Namespace: p.cfg.namespace})
+	switch {
+	case err != nil:
+		log.Printf("[LoadGen] %s: RegisterPlayer: %v", p.id, err)
+	case res.GetStatusCode() == pb.RegisterPlayerResponse_OK:
+		return mmclient.WithPlayerToken(ctx, res.GetToken())
+	case res.GetStatusCode() != pb.RegisterPlayerResponse_AUTH_DISABLED:
+		log.Printf("[LoadGen] %s: RegisterPlayer %s: %s", p.id, res.GetStatusCode(), res.GetMessage())
+	}
+	return ctx
+}
+
+// queue encola al jugador; false si no quedó en cola ni en partida.
+func (p *simPlayer) queue(ctx context.Context) bool {
+	res, err := p.client.QueuePlayer(ctx, &pb.PlayerInfoRequest{
+		PlayerId:  p.id,
+		GameMode:  p.cfg.mode,
+		Namespace: p.cfg.namespace,
+	})
+	if err != nil {
+		sleep(ctx, time.Second)
+		return false
+	}
+	switch res.GetStatusCode() {
+	case pb.QueuePlayerResponse_OK:
+		p.rec.count(evQueued)
+		return true
+	case pb.QueuePlayerResponse_ALREADY_IN_QUEUE, pb.QueuePlayerResponse_IN_MATCH:
+		// quedó de una vuelta anterior (p.ej. reencolado tras una caída)
+		return true
+	case pb.QueuePlayerResponse_COOLDOWN:
+		p.rec.count(evCooldown)
+		sleep(ctx, time.Duration(res.GetCooldownRemainingMs())*time.Millisecond)
+	default:
+		sleep(ctx, time.Second)
+	}
+	return false
+}
+
+// play espera MATCH_FOUND y después el desenlace de la partida.
+func (p *simPlayer) play(ctx context.Context, queuedAt time.Time) {
+	stall := time.NewTimer(p.cfg.stall)
+	defer stall.Stop()
+	found := false
+	for {
+		select {
+		case upd := <-p.events:
+			switch upd.GetEvent() {
+			case pb.MatchUpdate_MATCH_FOUND:
+				if !found {
+					found = true
+					p.rec.count(evMatchFound)
+					p.rec.wait(time.Since(queuedAt))
+				}
+			case pb.MatchUpdate_MATCH_FINISHED:
+				p.rec.count(evFinished)
+				return
+			case pb.MatchUpdate_MATCH_ABORTED:
+				p.rec.count(evAborted)
+				return
+			case pb.MatchUpdate_REMOVED:
+				p.rec.count(evRemoved)
+				return
+			case pb.MatchUpdate_PLAYER_LEFT:
+				p.rec.count(evPlayerLeft)
+				return
+			case pb.MatchUpdate_QUEUE_TIMED_OUT:
+				p.rec.count(evQueueTimedOut)
+				return
+			case pb.MatchUpdate_READY_CHECK:
+				p.accept(ctx, upd.GetMatchId())
+			case pb.MatchUpdate_READY_CHECK_FAILED:
+				p.rec.count(evReadyFailed)
+				if !upd.GetRequeued() {
+					return
+				}
+			}
+			stall.Reset(p.cfg.stall)
+		case <-stall.C:
+			if p.idle(ctx) {
+				p.rec.count(evDesync)
+				return
+			}
+			stall.Reset(p.cfg.stall)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// accept responde al READY_CHECK de matchID.
+func (p *simPlayer) accept(ctx context.Context, matchID string) {
+	res, err := p.client.AcceptMatch(ctx, &pb.AcceptMatchRequest{
+		PlayerId:  p.id,
+		MatchId:   matchID,
+		Accept:    true,
+		Namespace: p.cfg.namespace,
+	})
+	if err == nil && res.GetStatusCode() == pb.AcceptMatchResponse_OK {
+		p.rec.count(evReadyAccepted)
+	}
+}
+
+// idle consulta si el Matchmaker ya considera libre al jugador.
+func (p *simPlayer) idle(ctx context.Context) bool {
+	res, err := p.client.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: p.id, Namespace: p.cfg.namespace})
+	return err == nil && (res.GetStatus() == "IDLE" || res.GetStatus() == "QUEUE_TIMED_OUT")
+}
+
+// subscribe mantiene abierto el stream de notificaciones, reanudando desde
+// el último evento recibido si se corta.
+func (p *simPlayer) subscribe(ctx context.Context) {
+	var last *pb.VectorClock
+	for ctx.Err() == nil {
+		stream, err := p.client.SubscribeMatchUpdates(ctx, &pb.SubscribeRequest{
+			PlayerId:   p.id,
+			Namespace:  p.cfg.namespace,
+			ResumeFrom: last,
+		})
+		for err == nil {
+			var upd *pb.MatchUpdate
+			if upd, err = stream.Recv(); err == nil {
+				last = upd.GetVectorClock()
+				select {
+				case p.events <- upd:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		if ctx.Err() == nil {
+			p.rec.count(evStreamBroken)
+		}
+		sleep(ctx, time.Second)
+	}
+}
+
+// sleep espera d o hasta que se cancele ctx.
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,204 @@
+// cmd/loadgen/servers.go
+//
+// GameServers simulados: escuchan de verdad (el Matchmaker les hace
+// AssignMatch y CancelMatch), se anuncian con UpdateServerStatus cada
+// -heartbeat y juegan cada partida durante -match-duration (±50 %). Al
+// terminar una partida, con probabilidad -crash-rate el servidor se cae en
+// vez de informar: deja de escuchar y de latir, pierde sus partidas en
+// curso y vuelve -restart-after después, como un proceso reiniciado.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+type simServer struct {
+	pb.UnimplementedGameServerServer
+
+	id   string
+	addr string // host:port anunciado
+	port int
+	cfg  *loadConfig
+	ctx  context.Context // vida de la carga
+	mm   pb.MatchmakerClient
+	rec  *recorder
+
+	mu     sync.Mutex
+	srv    *grpc.Server // nil mientras está caído
+	active map[string]bool
+	boot   int // generación: las partidas de una vida anterior no informan
+}
+
+// start levanta el servidor y lo anuncia; devuelve error si el puerto no
+// está libre.
+func (s *simServer) start() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return err
+	}
+	srv := grpc.NewServer()
+	pb.RegisterGameServerServer(srv, s)
+
+	s.mu.Lock()
+	s.srv = srv
+	s.active = make(map[string]bool)
+	s.boot++
+	boot := s.boot
+	s.mu.Unlock()
+
+	go srv.Serve(lis)
+	go s.heartbeat(boot)
+	return nil
+}
+
+// heartbeat anuncia el estado cada -heartbeat mientras dure esta vida.
+func (s *simServer) heartbeat(boot int) {
+	t := time.NewTicker(s.cfg.heartbeat)
+	defer t.Stop()
+	for {
+		if !s.alive(boot) {
+			return
+		}
+		s.sendStatus()
+		select {
+		case <-t.C:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *simServer) alive(boot int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.srv != nil && s.boot == boot
+}
+
+func (s *simServer) sendStatus() {
+	s.mu.Lock()
+	free := s.cfg.capacity - len(s.active)
+	s.mu.Unlock()
+	st := pb.ServerStatus_DISPONIBLE
+	if free <= 0 {
+		st = pb.ServerStatus_OCUPADO
+	}
+	s.mm.UpdateServerStatus(s.ctx, &pb.ServerStatusUpdateRequest{
+		ServerId:  s.id,
+		NewStatus: st,
+		Address:   s.addr,
+		Namespace: s.cfg.namespace,
+		Capacity:  int32(s.cfg.capacity),
+		FreeSlots: int32(free),
+	})
+}
+
+func (s *simServer) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	s.mu.Lock()
+	if s.srv == nil || len(s.active) >= s.cfg.capacity {
+		s.mu.Unlock()
+		s.rec.count(evBusy)
+		return &pb.AssignMatchResponse{StatusCode: pb.AssignMatchResponse_BUSY}, nil
+	}
+	s.active[req.GetMatchId()] = true
+	boot := s.boot
+	s.mu.Unlock()
+	s.rec.count(evAssigned)
+
+	go s.play(boot, req)
+	return &pb.AssignMatchResponse{StatusCode: pb.AssignMatchResponse_OK}, nil
+}
+
+func (s *simServer) CancelMatch(ctx context.Context, req *pb.CancelMatchRequest) (*pb.CancelMatchResponse, error) {
+	s.mu.Lock()
+	ok := s.active[req.GetMatchId()]
+	delete(s.active, req.GetMatchId())
+	s.mu.Unlock()
+	if !ok {
+		return &pb.CancelMatchResponse{StatusCode: pb.CancelMatchResponse_UNKNOWN_MATCH}, nil
+	}
+	s.rec.count(evCancelled)
+	return &pb.CancelMatchResponse{StatusCode: pb.CancelMatchResponse_OK}, nil
+}
+
+// play simula la partida y, al terminar, informa el resultado o se cae.
+func (s *simServer) play(boot int, req *pb.AssignMatchRequest) {
+	d := s.cfg.matchDuration/2 + time.Duration(rand.Int63n(int64(s.cfg.matchDuration)+1))
+	sleep(s.ctx, d)
+
+	s.mu.Lock()
+	running := s.boot == boot && s.active[req.GetMatchId()]
+	delete(s.active, req.GetMatchId())
+	s.mu.Unlock()
+	if !running {
+		return // cancelada o perdida en una caída
+	}
+	if rand.Float64() < s.cfg.crashRate {
+		s.crash()
+		return
+	}
+
+	res := &pb.MatchResultRequest{
+		MatchId:    req.GetMatchId(),
+		ServerId:   s.id,
+		DurationMs: d.Milliseconds(),
+		Namespace:  s.cfg.namespace,
+	}
+	if players := req.GetPlayerIds(); len(players) > 0 {
+		res.WinnerId = players[rand.Intn(len(players))]
+	}
+	if _, err := s.mm.ReportMatchResult(s.ctx, res); err == nil {
+		s.rec.count(evReported)
+	}
+	s.sendStatus()
+}
+
+// crash detiene el servidor sin avisar y lo relanza tras -restart-after.
+func (s *simServer) crash() {
+	s.mu.Lock()
+	srv := s.srv
+	s.srv = nil
+	s.active = make(map[string]bool)
+	s.mu.Unlock()
+	if srv == nil {
+		return
+	}
+	srv.Stop()
+	s.rec.count(evServerCrash)
+	if s.cfg.verbose {
+		log.Printf("[LoadGen] %s se cae; vuelve en %v", s.id, s.cfg.restartAfter)
+	}
+
+	go func() {
+		sleep(s.ctx, s.cfg.restartAfter)
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err := s.start(); err != nil {
+			log.Printf("[LoadGen] %s no pudo reiniciar: %v", s.id, err)
+			return
+		}
+		s.rec.count(evServerReboot)
+	}()
+}
+
+// stop apaga el servidor al terminar la carga.
+func (s *simServer) stop() {
+	s.mu.Lock()
+	srv := s.srv
+	s.srv = nil
+	s.mu.Unlock()
+	if srv != nil {
+		srv.Stop()
+	}
+}
@@ -0,0 +1,196 @@
+// cmd/loadgen/stats.go
+//
+// Recolección de resultados: latencia y fallos de cada RPC (un interceptor
+// por fuera de los reintentos de mmclient, así que mide lo que ve el
+// cliente), espera en cola hasta MATCH_FOUND y contadores de eventos.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Eventos contados durante la carga.
+const (
+	evQueued        = "encolados"
+	evMatchFound    = "partidas encontradas"
+	evFinished      = "partidas finalizadas"
+	evAborted       = "partidas abortadas"
+	evRemoved       = "expulsiones"
+	evPlayerLeft    = "abandonos"
+	evQueueTimedOut = "esperas vencidas"
+	evReadyAccepted = "confirmaciones aceptadas"
+	evReadyFailed   = "confirmaciones fallidas"
+	evCooldown      = "rechazos por cooldown"
+	evDesync        = "notificaciones perdidas"
+	evStreamBroken  = "suscripciones cortadas"
+	evAssigned      = "asignaciones aceptadas"
+	evBusy          = "asignaciones rechazadas (BUSY)"
+	evCancelled     = "cancelaciones recibidas"
+	evReported      = "resultados informados"
+	evServerCrash   = "caídas de servidor"
+	evServerReboot  = "reinicios de servidor"
+)
+
+type callStats struct {
+	latencies []time.Duration
+	failures  map[codes.Code]int
+}
+
+// recorder acumula las mediciones; es seguro para uso concurrente.
+type recorder struct {
+	mu     sync.Mutex
+	calls  map[string]*callStats // método corto → mediciones
+	waits  []time.Duration       // encolado → MATCH_FOUND
+	events map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		calls:  make(map[string]*callStats),
+		events: make(map[string]int),
+	}
+}
+
+// unary mide cada RPC unaria, reintentos incluidos. Las cortadas por el fin
+// de la carga no cuentan.
+func (r *recorder) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil || ctx.Err() == nil {
+		r.call(method, time.Since(start), err)
+	}
+	return err
+}
+
+func (r *recorder) call(method string, d time.Duration, err error) {
+	method = method[strings.LastIndexByte(method, '/')+1:]
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cs, ok := r.calls[method]
+	if !ok {
+		cs = &callStats{failures: make(map[codes.Code]int)}
+		r.calls[method] = cs
+	}
+	if err != nil {
+		cs.failures[status.Code(err)]++
+		return
+	}
+	cs.latencies = append(cs.latencies, d)
+}
+
+func (r *recorder) count(event string) {
+	r.mu.Lock()
+	r.events[event]++
+	r.mu.Unlock()
+}
+
+func (r *recorder) wait(d time.Duration) {
+	r.mu.Lock()
+	r.waits = append(r.waits, d)
+	r.mu.Unlock()
+}
+
+// total devuelve cuántas RPC unarias se midieron, fallidas incluidas.
+func (r *recorder) total() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, cs := range r.calls {
+		n += len(cs.latencies)
+		for _, c := range cs.failures {
+			n += c
+		}
+	}
+	return n
+}
+
+// percentile devuelve el percentil p (0–100) de una muestra ordenada.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted)-1) * p / 100)
+	return sorted[i]
+}
+
+func sortedCopy(d []time.Duration) []time.Duration {
+	out := append([]time.Duration(nil), d...)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func round(d time.Duration) time.Duration {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(100 * time.Microsecond)
+}
+
+// report imprime el resumen de la carga. Devuelve el total de RPC fallidas.
+func (r *recorder) report(w io.Writer, elapsed time.Duration) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fmt.Fprintf(w, "\n================ RESULTADO DE LA CARGA (%v) ================\n", elapsed.Round(time.Second))
+	fmt.Fprintf(w, "  %-24s %9s %8s %10s %10s %10s\n", "RPC", "llamadas", "fallos", "p50", "p99", "llam./s")
+
+	methods := make([]string, 0, len(r.calls))
+	for m := range r.calls {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+	failed := 0
+	failures := make(map[codes.Code]int)
+	for _, m := range methods {
+		cs := r.calls[m]
+		lat := sortedCopy(cs.latencies)
+		n := len(lat)
+		for code, c := range cs.failures {
+			n += c
+			failed += c
+			failures[code] += c
+		}
+		fmt.Fprintf(w, "  %-24s %9d %8d %10v %10v %10.1f\n", m, n, n-len(lat),
+			round(percentile(lat, 50)), round(percentile(lat, 99)), float64(n)/elapsed.Seconds())
+	}
+	if len(failures) > 0 {
+		codesSeen := make([]codes.Code, 0, len(failures))
+		for c := range failures {
+			codesSeen = append(codesSeen, c)
+		}
+		sort.Slice(codesSeen, func(i, j int) bool { return codesSeen[i] < codesSeen[j] })
+		parts := make([]string, 0, len(codesSeen))
+		for _, c := range codesSeen {
+			parts = append(parts, fmt.Sprintf("%s=%d", c, failures[c]))
+		}
+		fmt.Fprintf(w, "  Fallos por código: %s\n", strings.Join(parts, ", "))
+	}
+
+	waits := sortedCopy(r.waits)
+	fmt.Fprintf(w, "\n  Espera hasta MATCH_FOUND: p50 %v • p99 %v • máx %v (%d muestras)\n",
+		round(percentile(waits, 50)), round(percentile(waits, 99)), round(percentile(waits, 100)), len(waits))
+	fmt.Fprintf(w, "  Rendimiento: %.2f partidas finalizadas/s • %.2f encolados/s\n",
+		float64(r.events[evFinished])/elapsed.Seconds(), float64(r.events[evQueued])/elapsed.Seconds())
+
+	fmt.Fprintln(w)
+	for _, ev := range []string{
+		evQueued, evCooldown, evMatchFound, evFinished, evAborted, evRemoved, evPlayerLeft, evQueueTimedOut,
+		evReadyAccepted, evReadyFailed, evDesync,
+		evStreamBroken, evAssigned, evBusy, evCancelled, evReported, evServerCrash, evServerReboot,
+	} {
+		fmt.Fprintf(w, "  %-32s %d\n", ev, r.events[ev])
+	}
+	fmt.Fprint(w, "=============================================================\n\n")
+	return failed
+}
@@ -0,0 +1,141 @@
+// cmd/logorder/main.go
+//
+// Ordena causalmente líneas de log de varias máquinas. Lee los archivos
+// indicados (o la entrada estándar), toma las líneas emitidas con
+// slog.WithClock —las que terminan en " vc=[id1=3,id2=1]"— e imprime un
+// orden compatible con sus relojes vectoriales: si una línea ocurrió antes
+// que otra, aparece antes. Entre líneas concurrentes se respeta el orden de
+// lectura, y se marcan con "∥" las que son concurrentes con la anterior.
+//
+//	go run ./cmd/logorder mm.log gs1.log player1.log
+//	docker logs player1 2>&1 | go run ./cmd/logorder
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/vimsent/L3/internal/clocks"
+	slog "github.com/vimsent/L3/internal/log"
+)
+
+var (
+	clockRe = regexp.MustCompile(regexp.QuoteMeta(slog.ClockField) + `\[([^\]]*)\]\s*$`)
+	ansiRe  = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+// entry es una línea con reloj y su origen.
+type entry struct {
+	source string
+	line   int
+	text   string
+	clock  *clocks.Vector
+}
+
+// readEntries extrae las líneas con reloj; devuelve también cuántas no lo
+// tenían.
+func readEntries(source string, r io.Reader) ([]entry, int, error) {
+	var out []entry
+	skipped := 0
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		text := ansiRe.ReplaceAllString(sc.Text(), "")
+		m := clockRe.FindStringSubmatch(text)
+		if m == nil {
+			skipped++
+			continue
+		}
+		vc := clocks.New()
+		if err := vc.FromString(m[1]); err != nil {
+			return nil, 0, fmt.Errorf("%s:%d: %v", source, n, err)
+		}
+		out = append(out, entry{source: source, line: n, text: strings.TrimSpace(text), clock: vc})
+	}
+	return out, skipped, sc.Err()
+}
+
+// causalOrder devuelve los índices de entries en un orden topológico de la
+// relación "ocurrió antes"; a igualdad elige la primera en orden de lectura.
+func causalOrder(entries []entry) []int {
+	n := len(entries)
+	preds := make([]int, n)
+	succs := make([][]int, n)
+	for i := range entries {
+		for j := range entries {
+			if i != j && entries[i].clock.HappensBefore(entries[j].clock) {
+				succs[i] = append(succs[i], j)
+				preds[j]++
+			}
+		}
+	}
+
+	order := make([]int, 0, n)
+	done := make([]bool, n)
+	for len(order) < n {
+		next := -1
+		for i := 0; i < n; i++ {
+			if !done[i] && preds[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// imposible con relojes válidos: HappensBefore es un orden estricto
+			log.Fatalf("ciclo en la relación causal")
+		}
+		done[next] = true
+		order = append(order, next)
+		for _, j := range succs[next] {
+			preds[j]--
+		}
+	}
+	return order
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("logorder: ")
+
+	var entries []entry
+	skipped := 0
+	add := func(source string, r io.Reader) {
+		es, sk, err := readEntries(source, r)
+		if err != nil {
+			log.Fatal(err)
+		}
+		entries = append(entries, es...)
+		skipped += sk
+	}
+
+	if len(os.Args) < 2 {
+		add("stdin", os.Stdin)
+	}
+	for _, path := range os.Args[1:] {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		add(path, f)
+		f.Close()
+	}
+
+	var prev *entry
+	for _, i := range causalOrder(entries) {
+		e := &entries[i]
+		mark := " "
+		if prev != nil && e.clock.Concurrent(prev.clock) {
+			mark = "∥"
+		}
+		fmt.Printf("%s %s:%d  %s\n", mark, e.source, e.line, e.text)
+		prev = e
+	}
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "logorder: %d líneas sin reloj ignoradas\n", skipped)
+	}
+}
@@ -0,0 +1,125 @@
+// cmd/migrate/main.go
+//
+// Migra el estado del Matchmaker entre almacenes (ver store.go) y verifica
+// la integridad antes y después de la transferencia: consistencia
+// referencial entre cola, jugadores, partidas, servidores y grupos, y que
+// el reloj vectorial de cada namespace no retroceda en el destino.
+//
+//	# respaldo en frío de un primario lanzado con BACKUP_ADDR=localhost:50099
+//	go run ./cmd/migrate -from listen:localhost:50099 -to file:state.pb
+//	# inspección legible
+//	go run ./cmd/migrate -from file:state.pb -to json:state.json
+//	# restauración en un Matchmaker nuevo con MATCHMAKER_ROLE=backup
+//	go run ./cmd/migrate -from json:state.json -to backup:localhost:50052
+//
+// Sale con 0 si todo coincide, 1 ante un error y 2 si alguna verificación
+// de integridad falla.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vimsent/L3/internal/config"
+	slog "github.com/vimsent/L3/internal/log"
+	"github.com/vimsent/L3/internal/tlsutil"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func main() {
+	from := flag.String("from", "", "almacén de origen (file:, json: o listen:)")
+	to := flag.String("to", "", "almacén de destino (file:, json: o backup:)")
+	timeout := flag.Duration("timeout", time.Minute, "plazo total de la migración")
+	force := flag.Bool("force", false, "escribir aunque el origen no sea consistente")
+	flag.Parse()
+	if *from == "" || *to == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	cfg := config.NewReport("migrate")
+	tlsFiles := cfg.TLS()
+	cfg.MustValidate()
+	serverCreds, err := tlsutil.ServerCredentials(tlsFiles)
+	if err != nil {
+		slog.Error("TLS: %v", err)
+		os.Exit(1)
+	}
+	clientCreds, err := tlsutil.ClientCredentials(tlsFiles)
+	if err != nil {
+		slog.Error("TLS: %v", err)
+		os.Exit(1)
+	}
+
+	src, err := openStore(*from, serverCreds, clientCreds)
+	if err == nil {
+		var dst store
+		if dst, err = openStore(*to, serverCreds, clientCreds); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+			defer cancel()
+			os.Exit(migrate(ctx, src, dst, *force))
+		}
+	}
+	slog.Error("%v", err)
+	os.Exit(1)
+}
+
+// migrate copia el estado de src a dst y devuelve el código de salida.
+func migrate(ctx context.Context, src, dst store, force bool) int {
+	snap, err := src.Load(ctx)
+	if err != nil {
+		slog.Error("leyendo %s: %v", src, err)
+		return 1
+	}
+	slog.Info("Leído %s: %s", src, summarize(snap))
+
+	problems := checkIntegrity(snap)
+	for _, p := range problems {
+		slog.Warn("origen inconsistente: %s", p)
+	}
+	if len(problems) > 0 && !force {
+		slog.Error("%d problemas de integridad en el origen; usa -force para migrarlo igual", len(problems))
+		return 2
+	}
+
+	if err := dst.Save(ctx, snap); err != nil {
+		slog.Error("escribiendo %s: %v", dst, err)
+		return 1
+	}
+
+	// releemos el destino cuando es posible (los archivos)
+	var back *pb.StateSnapshot
+	if _, ok := dst.(*fileStore); ok {
+		if back, err = dst.Load(ctx); err != nil {
+			slog.Error("releyendo %s: %v", dst, err)
+			return 1
+		}
+	}
+	after := checkTransfer(snap, back)
+	for _, p := range after {
+		slog.Error("destino: %s", p)
+	}
+	if len(after) > 0 {
+		return 2
+	}
+	if back == nil {
+		slog.Info("Estado entregado a %s (confirmado por el destino, sin relectura)", dst)
+	} else {
+		slog.Info("Estado migrado a %s y verificado", dst)
+	}
+	return 0
+}
+
+func summarize(snap *pb.StateSnapshot) string {
+	var players, matches, servers int
+	for _, ns := range snap.GetNamespaces() {
+		players += len(ns.GetPlayers())
+		matches += len(ns.GetMatches())
+		servers += len(ns.GetServers())
+	}
+	return fmt.Sprintf("primario %q, secuencia %d, %d namespaces, %d jugadores, %d partidas en curso, %d servidores",
+		snap.GetPrimaryId(), snap.GetSequence(), len(snap.GetNamespaces()), players, matches, servers)
+}
@@ -0,0 +1,215 @@
+// cmd/migrate/store.go
+//
+// Almacenes de estado entre los que migrar. El estado del Matchmaker vive
+// en memoria y sólo sale del proceso como StateSnapshot (el mismo mensaje
+// que replica el primario), así que cada almacén sabe leer y/o escribir
+// uno:
+//
+//	file:<ruta>      snapshot en binario protobuf
+//	json:<ruta>      snapshot en JSON (legible y comparable con diff)
+//	listen:<addr>    lee: escucha como respaldo y toma el primer snapshot
+//	                 que envíe un primario con BACKUP_ADDR=<addr>
+//	backup:<addr>    escribe: envía el snapshot a un Matchmaker respaldo
+//	                 (MATCHMAKER_ROLE=backup), que lo adopta y se promueve
+//	                 a los 5 s
+//
+// No hay almacenes BoltDB, Redis ni Postgres: cada uno necesita un driver
+// de terceros y el proyecto sólo usa la biblioteca estándar más gRPC y
+// protobuf. El snapshot en archivo ocupa ese lugar (se guarda, se copia y
+// se restaura con backup:), y openStore rechaza esos tipos con un mensaje
+// que lo explica en vez de tratarlos como un error de tipeo.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// store es un origen o destino de la migración.
+type store interface {
+	Load(ctx context.Context) (*pb.StateSnapshot, error)
+	Save(ctx context.Context, snap *pb.StateSnapshot) error
+	String() string
+}
+
+// openStore interpreta "tipo:dirección". listen: atiende con serverCreds y
+// backup: se conecta con clientCreds.
+func openStore(spec string, serverCreds, clientCreds credentials.TransportCredentials) (store, error) {
+	kind, target, ok := strings.Cut(spec, ":")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("%q no tiene forma tipo:dirección", spec)
+	}
+	switch kind {
+	case "file":
+		return &fileStore{path: target}, nil
+	case "json":
+		return &fileStore{path: target, json: true}, nil
+	case "listen":
+		return &listenStore{addr: target, creds: serverCreds}, nil
+	case "backup":
+		return &backupStore{addr: target, creds: clientCreds}, nil
+	case "bolt", "redis", "postgres":
+		return nil, fmt.Errorf("almacén %q no disponible: necesita un driver de terceros; usa file: o json: y restaura con backup:", kind)
+	}
+	return nil, fmt.Errorf("almacén %q desconocido (file, json, listen o backup)", kind)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                               Archivos
+───────────────────────────────────────────────────────────────────────────────*/
+
+type fileStore struct {
+	path string
+	json bool
+}
+
+func (f *fileStore) String() string {
+	if f.json {
+		return "json:" + f.path
+	}
+	return "file:" + f.path
+}
+
+func (f *fileStore) Load(ctx context.Context) (*pb.StateSnapshot, error) {
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	snap := &pb.StateSnapshot{}
+	if f.json {
+		err = protojson.Unmarshal(data, snap)
+	} else {
+		err = proto.Unmarshal(data, snap)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+	return snap, nil
+}
+
+// Save escribe a un temporal y lo renombra, para no dejar un snapshot a
+// medias si el proceso se interrumpe.
+func (f *fileStore) Save(ctx context.Context, snap *pb.StateSnapshot) error {
+	var data []byte
+	var err error
+	if f.json {
+		data, err = protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(snap)
+	} else {
+		data, err = proto.Marshal(snap)
+	}
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), ".migrate-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                          Matchmaker en ejecución
+───────────────────────────────────────────────────────────────────────────────*/
+
+// listenStore se hace pasar por el respaldo de un primario.
+type listenStore struct {
+	pb.UnimplementedMatchmakerServer
+	addr  string
+	creds credentials.TransportCredentials
+	got   chan *pb.StateSnapshot
+}
+
+func (l *listenStore) String() string { return "listen:" + l.addr }
+
+func (l *listenStore) Load(ctx context.Context) (*pb.StateSnapshot, error) {
+	lis, err := net.Listen("tcp", l.addr)
+	if err != nil {
+		return nil, err
+	}
+	l.got = make(chan *pb.StateSnapshot, 1)
+	srv := grpc.NewServer(grpc.Creds(l.creds))
+	pb.RegisterMatchmakerServer(srv, l)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	select {
+	case snap := <-l.got:
+		return snap, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("ningún primario envió estado a %s: %w", l.addr, ctx.Err())
+	}
+}
+
+func (l *listenStore) Save(ctx context.Context, snap *pb.StateSnapshot) error {
+	return fmt.Errorf("%s sólo puede usarse como origen", l)
+}
+
+// ReplicateState toma el primer snapshot y corta el stream; el primario lo
+// registra como replicación interrumpida y sigue funcionando.
+func (l *listenStore) ReplicateState(stream pb.Matchmaker_ReplicateStateServer) error {
+	snap, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	select {
+	case l.got <- snap:
+	default:
+	}
+	return stream.SendAndClose(&pb.ReplicationAck{LastSequence: snap.GetSequence()})
+}
+
+// backupStore entrega el estado a un Matchmaker respaldo.
+type backupStore struct {
+	addr  string
+	creds credentials.TransportCredentials
+}
+
+func (b *backupStore) String() string { return "backup:" + b.addr }
+
+func (b *backupStore) Load(ctx context.Context) (*pb.StateSnapshot, error) {
+	return nil, fmt.Errorf("%s sólo puede usarse como destino (para leer, usa listen:)", b)
+}
+
+func (b *backupStore) Save(ctx context.Context, snap *pb.StateSnapshot) error {
+	conn, err := grpc.DialContext(ctx, b.addr, grpc.WithTransportCredentials(b.creds), grpc.WithBlock())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewMatchmakerClient(conn).ReplicateState(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(snap); err != nil && err != io.EOF {
+		return err
+	}
+	ack, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("el respaldo rechazó el estado: %w", err)
+	}
+	if ack.GetLastSequence() != snap.GetSequence() {
+		return fmt.Errorf("el respaldo confirmó la secuencia %d, se envió %d", ack.GetLastSequence(), snap.GetSequence())
+	}
+	return nil
+}
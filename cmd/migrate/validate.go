@@ -0,0 +1,125 @@
+// cmd/migrate/validate.go
+//
+// Verificaciones de integridad de un snapshot y de la transferencia.
+
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// checkIntegrity revisa la consistencia referencial de cada namespace:
+// la cola, las partidas, los servidores y los grupos sólo nombran
+// jugadores que existen y cuyo estado concuerda.
+func checkIntegrity(snap *pb.StateSnapshot) []string {
+	var problems []string
+	report := func(ns, format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf("[%s] %s", ns, fmt.Sprintf(format, a...)))
+	}
+
+	for _, ns := range snap.GetNamespaces() {
+		name := ns.GetName()
+		players := make(map[string]*pb.PlayerSnapshot)
+		for _, p := range ns.GetPlayers() {
+			if _, dup := players[p.GetPlayerId()]; dup {
+				report(name, "jugador %s duplicado", p.GetPlayerId())
+			}
+			players[p.GetPlayerId()] = p
+		}
+		servers := make(map[string]bool)
+		for _, s := range ns.GetServers() {
+			servers[s.GetServerId()] = true
+		}
+
+		queued := make(map[string]bool)
+		for _, pid := range ns.GetQueue() {
+			p, ok := players[pid]
+			switch {
+			case queued[pid]:
+				report(name, "%s aparece dos veces en la cola", pid)
+			case !ok:
+				report(name, "la cola nombra al jugador inexistente %s", pid)
+			case p.GetStatus() != "IN_QUEUE":
+				report(name, "%s está en la cola con estado %s", pid, p.GetStatus())
+			}
+			queued[pid] = true
+		}
+
+		matches := make(map[string]map[string]bool)
+		for _, mt := range ns.GetMatches() {
+			members := make(map[string]bool)
+			for _, pid := range mt.GetPlayerIds() {
+				members[pid] = true
+				p, ok := players[pid]
+				if !ok {
+					report(name, "la partida %s nombra al jugador inexistente %s", mt.GetMatchId(), pid)
+				} else if p.GetMatchId() != mt.GetMatchId() {
+					report(name, "la partida %s incluye a %s, que figura en %q", mt.GetMatchId(), pid, p.GetMatchId())
+				}
+			}
+			if !servers[mt.GetServerId()] {
+				report(name, "la partida %s corre en el servidor inexistente %s", mt.GetMatchId(), mt.GetServerId())
+			}
+			matches[mt.GetMatchId()] = members
+		}
+
+		for _, p := range ns.GetPlayers() {
+			switch p.GetStatus() {
+			case "IN_QUEUE":
+				if !queued[p.GetPlayerId()] {
+					report(name, "%s figura IN_QUEUE pero no está en la cola", p.GetPlayerId())
+				}
+			case "IN_MATCH":
+				if members, ok := matches[p.GetMatchId()]; !ok || !members[p.GetPlayerId()] {
+					report(name, "%s figura IN_MATCH en %q, que no lo incluye", p.GetPlayerId(), p.GetMatchId())
+				}
+			}
+		}
+
+		for _, pt := range ns.GetParties() {
+			for _, pid := range pt.GetMemberIds() {
+				if p, ok := players[pid]; ok && p.GetPartyId() != pt.GetPartyId() {
+					report(name, "el grupo %s incluye a %s, que figura en %q", pt.GetPartyId(), pid, p.GetPartyId())
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// checkTransfer compara el snapshot leído del destino con el original. El
+// reloj de cada namespace no puede retroceder (monotonía) y el contenido
+// debe coincidir; si el destino no se puede releer, dst es nil y sólo se
+// revisa el original.
+func checkTransfer(src, dst *pb.StateSnapshot) []string {
+	if dst == nil {
+		return nil
+	}
+	var problems []string
+	out := make(map[string]*pb.NamespaceSnapshot)
+	for _, ns := range dst.GetNamespaces() {
+		out[ns.GetName()] = ns
+	}
+
+	for _, ns := range src.GetNamespaces() {
+		got, ok := out[ns.GetName()]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("[%s] el namespace no llegó al destino", ns.GetName()))
+			continue
+		}
+		before := clocks.FromProto(ns.GetVectorClock())
+		after := clocks.FromProto(got.GetVectorClock())
+		if !before.Equal(after) && !before.HappensBefore(after) {
+			problems = append(problems, fmt.Sprintf("[%s] el reloj retrocedió: %v → %v", ns.GetName(), before, after))
+		}
+		if !proto.Equal(ns, got) {
+			problems = append(problems, fmt.Sprintf("[%s] el contenido difiere del original", ns.GetName()))
+		}
+	}
+	return problems
+}
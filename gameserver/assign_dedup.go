@@ -0,0 +1,61 @@
+// gameserver/assign_dedup.go
+//
+// Idempotencia de AssignMatch. Si la respuesta a un AssignMatch se pierde
+// (vence el plazo, se corta la conexión), el Matchmaker puede reenviar la
+// misma partida con otro número de intento. El servidor recuerda cada
+// partida que aceptó, con el intento que la trajo y la respuesta que dio, y
+// ante una repetición devuelve esa respuesta marcada como duplicada en vez
+// de ocupar otro hueco o responder BUSY. Los rechazos no se recuerdan: un
+// reintento posterior puede encontrar un hueco libre. Las partidas se
+// olvidan assignDedupTTL después de aceptadas; el registro no se guarda en
+// el archivo de estado, porque tras un reinicio las partidas a medias se
+// abortan igual.
+
+package main
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// cuánto se recuerda una partida aceptada
+const assignDedupTTL = 10 * time.Minute
+
+// acceptedAssign es una partida aceptada y la respuesta que se le dio.
+type acceptedAssign struct {
+	attempt  int32
+	at       time.Time
+	response *pb.AssignMatchResponse
+	repeats  int // repeticiones recibidas
+}
+
+// duplicateAssign devuelve la respuesta original si matchID ya fue aceptada.
+// debe llamarse con gs.mu bloqueado
+func (gs *gameServer) duplicateAssign(matchID string, now time.Time) (*acceptedAssign, *pb.AssignMatchResponse) {
+	for id, a := range gs.accepted {
+		if now.Sub(a.at) > assignDedupTTL {
+			delete(gs.accepted, id)
+		}
+	}
+	a, ok := gs.accepted[matchID]
+	if !ok {
+		return nil, nil
+	}
+	a.repeats++
+	res := proto.Clone(a.response).(*pb.AssignMatchResponse)
+	res.Duplicate, res.OriginalAttempt = true, a.attempt
+	return a, res
+}
+
+// rememberAssign guarda la respuesta con que se aceptó matchID.
+// debe llamarse con gs.mu bloqueado
+func (gs *gameServer) rememberAssign(req *pb.AssignMatchRequest, res *pb.AssignMatchResponse, now time.Time) {
+	gs.accepted[req.GetMatchId()] = &acceptedAssign{
+		attempt:  req.GetAttempt(),
+		at:       now,
+		response: proto.Clone(res).(*pb.AssignMatchResponse),
+	}
+}
@@ -0,0 +1,90 @@
+// gameserver/backfill.go
+//
+// Relleno de salas. Si una sala arranca sin llenarse, el servidor pide al
+// Matchmaker con RequestBackfill los jugadores que le faltan; si alguno se
+// desconecta a mitad de partida (DISCONNECT_PROB), lo informa y pide su
+// reemplazo. Los jugadores de la cola que el Matchmaker suma llegan con la
+// orden BACKFILL por el stream Heartbeat y entran en la partida en curso:
+// figuran en el resultado y en los latidos. Los modos por equipos no se
+// rellenan.
+
+package main
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// requestBackfill pide open huecos para matchID e informa los jugadores
+// que se desconectaron.
+func (gs *gameServer) requestBackfill(matchID string, open int, departed []string) {
+	ctx, cancel := context.WithTimeout(gs.matchContext(matchID), 3*time.Second)
+	defer cancel()
+	res, err := gs.matchmakerCli.RequestBackfill(ctx, &pb.RequestBackfillRequest{
+		ServerId:          gs.id,
+		Namespace:         gs.namespace,
+		MatchId:           matchID,
+		OpenSlots:         int32(open),
+		DepartedPlayerIds: departed,
+	})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		log.Printf("[GameServer %s] El Matchmaker no soporta RequestBackfill; la partida %s sigue incompleta", gs.id, matchID)
+	case err != nil:
+		log.Printf("[GameServer %s] WARNING: no pude pedir relleno para %s: %v", gs.id, matchID, err)
+	case res.GetStatusCode() != pb.RequestBackfillResponse_OK:
+		log.Printf("[GameServer %s] Relleno de %s rechazado (%s): %s", gs.id, matchID, res.GetStatusCode(), res.GetMessage())
+	default:
+		log.Printf("[GameServer %s] Relleno pedido para %s: %d huecos abiertos", gs.id, matchID, res.GetOpenSlots())
+	}
+}
+
+// addBackfill suma a la partida en curso los jugadores de una orden
+// BACKFILL; si ya terminó, la orden se ignora.
+func (gs *gameServer) addBackfill(matchID string, players []string) {
+	gs.mu.Lock()
+	rm, ok := gs.active[matchID]
+	if ok {
+		rm.PlayerIds = append(rm.PlayerIds, players...)
+	}
+	gs.mu.Unlock()
+	if !ok {
+		log.Printf("[GameServer %s] Relleno de %s ignorado: la partida ya no está en curso", gs.id, matchID)
+		return
+	}
+	gs.saveState()
+	log.Printf("[GameServer %s] Se suman %v a la partida %s", gs.id, players, matchID)
+}
+
+// simulateDisconnects desconecta a cada jugador de la sala con probabilidad
+// disconnectProb y pide reemplazarlos.
+func (gs *gameServer) simulateDisconnects(matchID string) {
+	gs.mu.Lock()
+	rm, ok := gs.active[matchID]
+	var departed []string
+	if ok {
+		kept := rm.PlayerIds[:0:0]
+		for _, pid := range rm.PlayerIds {
+			if rand.Float64() < gs.disconnectProb {
+				departed = append(departed, pid)
+			} else {
+				kept = append(kept, pid)
+			}
+		}
+		rm.PlayerIds = kept
+	}
+	gs.mu.Unlock()
+	if len(departed) == 0 {
+		return
+	}
+	gs.saveState()
+	log.Printf("[GameServer %s] Se desconectaron %v de la partida %s; pidiendo reemplazo", gs.id, departed, matchID)
+	gs.requestBackfill(matchID, len(departed), departed)
+}
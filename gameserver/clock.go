@@ -0,0 +1,65 @@
+// gameserver/clock.go
+//
+// Reloj vectorial del GameServer. Avanza su componente en cada cambio de
+// estado que anuncia al Matchmaker (un latido que repite el estado no lo
+// avanza) y adopta lo que el Matchmaker le muestra en cada respuesta y en
+// AssignMatch. El componente propio nunca se toma de afuera: tras un
+// reinicio vuelve a empezar en 1, y así el Matchmaker reconoce la nueva
+// encarnación en vez de creer que sus anuncios son viejos
+// (matchmaker/server_causal.go).
+
+package main
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// clockHooks conecta internal/clockpropagation con el reloj del servidor:
+// lo recibido en la metadata se adopta como en observeClock y las
+// respuestas sin vector_clock devuelven el reloj actual en la cabecera.
+func (gs *gameServer) clockHooks() clockpropagation.Hooks {
+	return clockpropagation.Hooks{
+		Merge: func(_ context.Context, _ interface{}, remote *clocks.Vector) {
+			gs.observeClock(remote.ToProto())
+		},
+		Current: func(context.Context, interface{}) *clocks.Vector {
+			return gs.clock.Copy()
+		},
+	}
+}
+
+// stampStatus pone el reloj en req, avanzándolo si el estado cambió desde
+// el último anuncio.
+func (gs *gameServer) stampStatus(req *pb.ServerStatusUpdateRequest) {
+	state := proto.Clone(req).(*pb.ServerStatusUpdateRequest)
+	state.LatencyMs, state.MatchId, state.Traceparent, state.Clock = 0, "", "", nil
+
+	gs.clockMu.Lock()
+	defer gs.clockMu.Unlock()
+	if gs.lastState == nil || !proto.Equal(state, gs.lastState) {
+		gs.clock.Tick(gs.id)
+		gs.lastState = state
+	}
+	req.Clock = gs.clock.ToProto()
+}
+
+// observeClock fusiona un reloj recibido del Matchmaker, salvo el
+// componente propio.
+func (gs *gameServer) observeClock(c *pb.VectorClock) {
+	if len(c.GetCounters()) == 0 {
+		return
+	}
+	others := &pb.VectorClock{Counters: make(map[string]int32, len(c.GetCounters()))}
+	for id, val := range c.GetCounters() {
+		if id != gs.id {
+			others.Counters[id] = val
+		}
+	}
+	gs.clock.Merge(clocks.FromProto(others))
+}
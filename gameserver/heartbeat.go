@@ -0,0 +1,255 @@
+// gameserver/heartbeat.go
+//
+// Stream Heartbeat con el Matchmaker. Los cambios de estado viajan por él y,
+// cada HEARTBEAT_INTERVAL, se repite el estado actual como latido, de modo
+// que un servidor sin partidas no se dé por caído. Por el mismo stream
+// llegan las órdenes del Matchmaker: DRAIN (el administrador lo drenó),
+// ABORT_MATCH (el Matchmaker cerró una partida suya y hay que soltarla),
+// BACKFILL (jugadores que se suman a una sala en curso, ver backfill.go) y
+// CHAOS (nuevos fallos inyectados, ver internal/chaos).
+// CancelMatch hace lo mismo como llamada directa, para los abandonos.
+//
+// Sin stream abierto, sendStatus usa UpdateServerStatus; si el Matchmaker
+// no conoce Heartbeat (versión anterior) los latidos también van por ahí.
+// El aviso de CAIDO y el re-registro tras una caída del Matchmaker van
+// siempre por la llamada unaria, que confirma la entrega. Al apagarse, el
+// servidor se da de baja con DeregisterServer y el Matchmaker lo quita del
+// pool; uno anterior, sin esa RPC, recibe el aviso de CAIDO.
+
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/chaos"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const defaultHeartbeatInterval = 5 * time.Second
+
+// runHeartbeat mantiene abierto el stream; al cortarse lo reabre con espera
+// exponencial (2 s a 30 s), igual que el bucle de reconexión.
+func (gs *gameServer) runHeartbeat(every time.Duration) {
+	wait := reconnectMin
+	for {
+		start := time.Now()
+		err := gs.heartbeatSession(every)
+		if status.Code(err) == codes.Unimplemented {
+			log.Printf("[GameServer %s] El Matchmaker no soporta Heartbeat; latidos por UpdateServerStatus", gs.id)
+			gs.unaryHeartbeat(every)
+			return
+		}
+		gs.markDisconnected(err)
+		if time.Since(start) > reconnectMax {
+			wait = reconnectMin
+		}
+		gs.wall.Sleep(wait)
+		if wait *= 2; wait > reconnectMax {
+			wait = reconnectMax
+		}
+	}
+}
+
+// heartbeatSession abre un stream, lo usa para los estados y latidos y
+// atiende sus órdenes hasta que se corta.
+func (gs *gameServer) heartbeatSession(every time.Duration) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := gs.matchmakerCli.Heartbeat(ctx)
+	if err != nil {
+		return err
+	}
+	gs.hbMu.Lock()
+	gs.hb, gs.hbSent = stream, nil
+	gs.hbMu.Unlock()
+	defer func() {
+		gs.hbMu.Lock()
+		if gs.hb == stream {
+			gs.hb = nil
+		}
+		gs.hbMu.Unlock()
+	}()
+
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			gs.handleControl(msg)
+		}
+	}()
+
+	ticker := gs.wall.NewTicker(every)
+	defer ticker.Stop()
+	gs.beat()
+	for {
+		select {
+		case <-ticker.C():
+			gs.beat()
+		case err := <-errc:
+			return err
+		}
+	}
+}
+
+// unaryHeartbeat es el latido para un Matchmaker sin stream Heartbeat.
+func (gs *gameServer) unaryHeartbeat(every time.Duration) {
+	ticker := gs.wall.NewTicker(every)
+	defer ticker.Stop()
+	for range ticker.C() {
+		gs.beat()
+	}
+}
+
+// beat repite el estado actual. Un servidor ya retirado del pool calla:
+// volver a anunciarse lo registraría de nuevo.
+func (gs *gameServer) beat() {
+	gs.mu.Lock()
+	retired := gs.retired
+	gs.mu.Unlock()
+	if retired {
+		return
+	}
+	if err := gs.sendStatus(gs.status(), ""); err != nil {
+		log.Printf("[GameServer %s] Latido fallido: %v", gs.id, err)
+	}
+}
+
+// sendHeartbeat envía la actualización por el stream, si hay uno abierto.
+func (gs *gameServer) sendHeartbeat(req *pb.ServerStatusUpdateRequest) bool {
+	gs.hbMu.Lock()
+	defer gs.hbMu.Unlock()
+	if gs.hb == nil {
+		return false
+	}
+	if err := gs.hb.Send(req); err != nil {
+		gs.hb = nil
+		return false
+	}
+	gs.hbSent = append(gs.hbSent, time.Now())
+	return true
+}
+
+// handleControl atiende un mensaje del Matchmaker. Los ACK llegan en el
+// orden de los envíos, así que el más antiguo pendiente da el RTT.
+func (gs *gameServer) handleControl(msg *pb.ServerControl) {
+	switch msg.GetCommand() {
+	case pb.ServerControl_ACK:
+		gs.hbMu.Lock()
+		var sent time.Time
+		if len(gs.hbSent) > 0 {
+			sent, gs.hbSent = gs.hbSent[0], gs.hbSent[1:]
+		}
+		gs.hbMu.Unlock()
+		if !sent.IsZero() {
+			gs.mu.Lock()
+			gs.lastRTT = time.Since(sent)
+			gs.mu.Unlock()
+		}
+		if err := gs.checkStatusResponse(msg.GetAck()); err != nil {
+			log.Printf("[GameServer %s] ERROR: %v", gs.id, err)
+		}
+	case pb.ServerControl_DRAIN:
+		if msg.GetRetired() {
+			gs.markRetired()
+			return
+		}
+		log.Printf("[GameServer %s] Drenado por el Matchmaker: terminará sus partidas en curso y no recibirá nuevas.", gs.id)
+	case pb.ServerControl_ABORT_MATCH:
+		gs.abortMatch(msg.GetMatchId(), msg.GetReason())
+	case pb.ServerControl_BACKFILL:
+		gs.addBackfill(msg.GetMatchId(), msg.GetPlayerIds())
+	case pb.ServerControl_CHAOS:
+		cfg := chaos.FromProto(msg.GetChaos())
+		if err := cfg.Validate(); err != nil {
+			log.Printf("[GameServer %s] WARNING: orden CHAOS inválida: %v", gs.id, err)
+			return
+		}
+		gs.chaos.Set(cfg)
+		log.Printf("[GameServer %s] WARNING: fallos inyectados: %s", gs.id, cfg)
+	}
+}
+
+// markRetired registra que el Matchmaker lo sacó del pool.
+func (gs *gameServer) markRetired() {
+	gs.mu.Lock()
+	already := gs.retired
+	gs.retired = true
+	gs.mu.Unlock()
+	if !already {
+		log.Printf("[GameServer %s] Drenado por el Matchmaker: ya no recibirá partidas, puede apagarse.", gs.id)
+	}
+}
+
+// deregister da de baja el servidor al apagarse: deja de anunciarse y el
+// Matchmaker lo quita del pool, cerrando sus partidas en curso.
+func (gs *gameServer) deregister() {
+	gs.mu.Lock()
+	gs.retired = true
+	gs.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	res, err := gs.matchmakerCli.DeregisterServer(ctx, &pb.DeregisterServerRequest{
+		ServerId:  gs.id,
+		Namespace: gs.namespace,
+		Reason:    "shutdown",
+	})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		log.Printf("[GameServer %s] El Matchmaker no soporta DeregisterServer; notificando CAIDO…", gs.id)
+		_ = gs.sendStatus(statusCrashed, "")
+	case err != nil:
+		log.Printf("[GameServer %s] No se pudo dar de baja en el Matchmaker: %v", gs.id, err)
+	default:
+		log.Printf("[GameServer %s] Dado de baja en el Matchmaker: %s", gs.id, res.GetMessage())
+	}
+}
+
+// abortMatch suelta una partida que el Matchmaker ya cerró: libera el hueco
+// sin informar resultado. simulateMatch ve que ya no está y no informa.
+// Devuelve false si la partida no estaba en curso.
+func (gs *gameServer) abortMatch(matchID, reason string) bool {
+	gs.mu.Lock()
+	_, ok := gs.active[matchID]
+	delete(gs.active, matchID)
+	delete(gs.traces, matchID)
+	delete(gs.sims, matchID)
+	gs.ports.release(matchID)
+	running := len(gs.active)
+	gs.mu.Unlock()
+	if !ok {
+		return false
+	}
+	gs.saveState()
+	gs.metrics.matches.With("aborted").Inc()
+	gs.metrics.activeMatches.Set(float64(running))
+	log.Printf("[GameServer %s] Partida %s abortada por el Matchmaker (%s)", gs.id, matchID, reason)
+
+	if err := gs.sendStatus(gs.status(), ""); err != nil {
+		log.Printf("[GameServer %s] ERROR al notificar el hueco liberado: %v", gs.id, err)
+	}
+	return true
+}
+
+// runningMatches lista las partidas en curso, ordenadas para que dos
+// latidos sin cambios sean idénticos.
+// debe llamarse con gs.mu bloqueado
+func (gs *gameServer) runningMatches() []*pb.RunningMatch {
+	running := make([]*pb.RunningMatch, 0, len(gs.active))
+	for _, rm := range gs.active {
+		running = append(running, rm)
+	}
+	sort.Slice(running, func(i, j int) bool { return running[i].GetMatchId() < running[j].GetMatchId() })
+	return running
+}
@@ -11,10 +11,44 @@
 //   • SERVER_ID         → ID lógico único de la instancia
 //                         (p.e. "GameServer1").        [def: GameServer-<rand>]
 //   • PORT              → Puerto TCP que expondrá el servicio gRPC. [def: 60051]
+//   • ADVERTISE_ADDR    → host:puerto con que el Matchmaker debe contactarlo
+//                         (p.e. "gameserver1:60051" o la IP de la VM). Si no se
+//                         define, el Matchmaker usa la IP de origen de la
+//                         conexión y el PORT anunciado.
 //   • MATCHMAKER_ADDR   → host:puerto donde escucha el Matchmaker. [def: localhost:50051]
 //   • CRASH_PROB        → Probabilidad (0-1) de “caerse” tras terminar una
 //                         partida, para testear tolerancia a fallos.
-//                         Se ignora si no se puede convertir a float. [def: 0.1]
+//                         Un valor fuera de [0,1] aborta el arranque. [def: 0.1]
+//   • CHAOS_DROP_PROB, CHAOS_DELAY_PROB, CHAOS_MAX_DELAY, CHAOS_CLOCK_PROB,
+//     CHAOS_SEED        → Más fallos inyectados en sus RPC (internal/chaos);
+//                         el Matchmaker los cambia en caliente con la orden
+//                         CHAOS del stream Heartbeat (AdminSetChaos). [def: 0]
+//   • NAMESPACE         → Namespace (tenant) del Matchmaker en el que se
+//                         registra el servidor.                  [def: default]
+//   • REGION            → Región del servidor; el Matchmaker prefiere
+//                         servidores de la región de los jugadores. [def: —]
+//   • LATENCY_ESTIMATE  → Latencia estimada a declarar (p.ej. 35ms). Si no se
+//                         define, se informa el RTT medido al Matchmaker.
+//   • METRICS_PORT      → Puerto HTTP del endpoint /metrics.      [def: 2113]
+//   • MAX_CONCURRENT_MATCHES → Partidas simultáneas que acepta; el servidor
+//                         informa los huecos libres en cada actualización. [def: 1]
+//   • MATCH_PORT_RANGE  → Rango "inicio-fin" de puertos dedicados: cada
+//                         partida recibe uno y AssignMatch devuelve su
+//                         dirección (ver match_ports.go). Debe tener al menos
+//                         MAX_CONCURRENT_MATCHES puertos.     [def: —, sin pool]
+//   • HEARTBEAT_INTERVAL → Cada cuánto repite su estado por el stream
+//                         Heartbeat para que no lo den por caído. [def: 5s]
+//   • KEEPALIVE_TIME    → Cada cuánto hace ping a la conexión con el
+//                         Matchmaker, aun sin RPC en curso (mín. 10s). [def: 10s]
+//   • KEEPALIVE_TIMEOUT → Sin respuesta al ping en este plazo, la conexión
+//                         se da por perdida y se re-registra al volver. [def: 5s]
+//   • STATE_FILE        → Archivo donde guarda sus partidas en curso para
+//                         abortarlas si se reinicia tras una caída.
+//                         [def: <tmp>/gameserver-<SERVER_ID>.json]
+//   • TLS_CERT/TLS_KEY  → Certificado propio; activa TLS en el servidor y se
+//                         presenta como cliente ante el Matchmaker (mTLS).
+//   • TLS_CA            → CA para verificar al Matchmaker y, con
+//                         TLS_CLIENT_AUTH=require, al propio Matchmaker como cliente.
 //
 // ▸ Librerías externas
 //   ──────────────────
@@ -26,12 +60,30 @@
 //   1. Arranca, crea conexión gRPC cliente con Matchmaker.
 //   2. Envía UPDATE( DISPO ) para registrarse.
 //   3. Levanta su propio servidor gRPC (implementa AssignMatch).
-//   4. Cada vez que recibe AssignMatch:
-//        ▸ cambia a OCUPADO, notifica,
-//        ▸ simula partida (10-20 s),
+//   4. Cada vez que recibe AssignMatch (si le quedan huecos):
+//        ▸ registra la partida y notifica sus huecos libres (OCUPADO si
+//          ya no le queda ninguno),
+//        ▸ simula la partida evento a evento (ver simulation.go), según la
+//          pista de duración del modo (10-20 s por defecto), y publica sus
+//          eventos en el Matchmaker para que los retransmita a jugadores y
+//          espectadores (ver match_relay.go),
 //        ▸ con probabilidad CRASH_PROB => simula caída: `os.Exit(1)`,
-//        ▸ de lo contrario vuelve a DISPO y notifica.
-//   5. Maneja SIGINT/SIGTERM enviando cambio a CAIDO antes de cerrar.
+//        ▸ de lo contrario informa el resultado (ReportMatchResult),
+//          libera el hueco y notifica (DISPO).
+//   5. Si el administrador lo drenó (AdminDrainServer), el Matchmaker se lo
+//      avisa (DRAIN) y responde DRAINED al volver a DISPO: ya no recibirá
+//      partidas y puede apagarse.
+//   6. Maneja SIGINT/SIGTERM dándose de baja (DeregisterServer) antes de
+//      cerrar, para que el Matchmaker lo quite del pool.
+//   7. Al arrancar, aborta (AbortMatch) las partidas que su archivo de estado
+//      registra de una ejecución anterior que se cayó.
+//   8. Si pierde la conexión con el Matchmaker (o éste deja de responder a
+//      los pings de keepalive), reintenta cada 2-30 s y al
+//      volver se re-registra declarando sus partidas en curso y reenvía los
+//      resultados pendientes (ver reconnect.go).
+//   9. Los estados y un latido cada HEARTBEAT_INTERVAL viajan por el stream
+//      Heartbeat, por el que el Matchmaker también le ordena drenar o
+//      abortar una partida (ver heartbeat.go).
 //
 
 package main
@@ -44,14 +96,27 @@ import (
 	"net"
 	"os"
 	"os/signal"
-	"strconv"
+	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/vimsent/L3/internal/chaos"
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/config"
+	slog "github.com/vimsent/L3/internal/log"
+	"github.com/vimsent/L3/internal/middleware"
+	"github.com/vimsent/L3/internal/shard"
+	"github.com/vimsent/L3/internal/tlsutil"
+	"github.com/vimsent/L3/internal/tracing"
+	"github.com/vimsent/L3/internal/walltime"
+	"github.com/vimsent/L3/pkg/mmclient"
 	pb "github.com/vimsent/L3/proto" // => generado con `go_package` = "github.com/yourrepo/proto;pb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // ───────────────────────────────────────────────────────────────────────────────
@@ -62,9 +127,9 @@ const (
 	defaultPort          = 60051
 	defaultMMAddr        = "localhost:50051"
 	defaultCrashProb     = 0.1
-	statusAvailable      = "DISPONIBLE"
-	statusBusy           = "OCUPADO"
-	statusCrashed        = "CAIDO"
+	statusAvailable      = pb.ServerStatus_DISPONIBLE
+	statusBusy           = pb.ServerStatus_OCUPADO
+	statusCrashed        = pb.ServerStatus_CAIDO
 	matchDurationMinSecs = 10
 	matchDurationMaxSecs = 20
 )
@@ -76,26 +141,84 @@ const (
 type gameServer struct {
 	pb.UnimplementedGameServerServer
 
-	id            string
-	address       string
-	crashProb     float64
-	matchmakerCli pb.MatchmakerClient
-
-	mu            sync.Mutex
-	currentStatus string
-	currentMatch  string
+	id             string
+	namespace      string
+	address        string
+	chaos          *chaos.Injector // fallos inyectados; su CrashProb es CRASH_PROB
+	disconnectProb float64         // por jugador y partida de sala (backfill.go)
+	region         string
+	latency        time.Duration // fija por configuración; 0 = medir
+	maxMatches     int
+	ports          *portPool // puertos dedicados por partida; nil = sin pool
+	matchmakerCli  pb.MatchmakerClient
+	metrics        *gsMetrics
+	wall           walltime.Clock // hora de pared; virtual en pruebas
+
+	mu      sync.Mutex
+	active  map[string]*pb.RunningMatch  // partidas en curso
+	traces  map[string]tracing.SpanContext // traza de cada partida en curso (ver matchContext)
+	sims    map[string]*matchSim         // simulación de cada partida en curso (ver simulation.go)
+	lastRTT time.Duration                // RTT de la última actualización de estado
+	// partidas aceptadas, para responder a los AssignMatch repetidos
+	// (ver assign_dedup.go)
+	accepted map[string]*acceptedAssign
+
+	// reconexión con el Matchmaker (ver reconnect.go)
+	mmDown    bool
+	mmEpoch   uint64                            // época del Matchmaker en la última respuesta; 0 = ninguna
+	retired   bool                              // drenado y retirado del pool: ya no se anuncia
+	pending   map[string]*pb.MatchResultRequest // resultados sin confirmar
+	reconnect chan struct{}
+	reachable chan struct{} // la conexión volvió a estar lista (watchMatchmaker)
+
+	// stream Heartbeat (ver heartbeat.go)
+	hbMu   sync.Mutex
+	hb     pb.Matchmaker_HeartbeatClient // nil = sin stream abierto
+	hbSent []time.Time                   // envíos aún sin ACK, para el RTT
+
+	// reloj vectorial (ver clock.go)
+	clockMu   sync.Mutex
+	clock     *clocks.Vector
+	lastState *pb.ServerStatusUpdateRequest // último estado anunciado, sin reloj
+
+	// archivo de estado (ver snapshot.go)
+	statePath string
+	saveMu    sync.Mutex
+	stale     []string // partidas perdidas en el reinicio, aún sin abortar
 }
 
 // newGameServer crea la instancia, registra “DISPONIBLE” y devuelve el puntero.
-func newGameServer(id, listenAddr string, crashProb float64, mmcli pb.MatchmakerClient) *gameServer {
+func newGameServer(cfg settings, advertiseAddr string, mmcli pb.MatchmakerClient, mt *gsMetrics) *gameServer {
 	gs := &gameServer{
-		id:            id,
-		address:       listenAddr,
-		crashProb:     crashProb,
-		matchmakerCli: mmcli,
-		currentStatus: statusAvailable,
+		id:             cfg.id,
+		namespace:      cfg.namespace,
+		address:        advertiseAddr,
+		chaos:          cfg.chaos,
+		disconnectProb: cfg.disconnectProb,
+		region:         cfg.region,
+		latency:        cfg.latency,
+		maxMatches:     cfg.maxMatches,
+		ports:          cfg.ports,
+		matchmakerCli:  mmcli,
+		metrics:        mt,
+		wall:           walltime.Real,
+		active:         make(map[string]*pb.RunningMatch),
+		traces:         make(map[string]tracing.SpanContext),
+		sims:           make(map[string]*matchSim),
+		accepted:       make(map[string]*acceptedAssign),
+		pending:        make(map[string]*pb.MatchResultRequest),
+		reconnect:      make(chan struct{}, 1),
+		reachable:      make(chan struct{}, 1),
+		clock:          clocks.New(),
+		statePath:      cfg.stateFile,
 	}
-	// Primer registro en el Matchmaker.
+	// Partidas que quedaron a medias si la ejecución anterior se cayó.
+	gs.stale = gs.loadStaleMatches()
+	gs.abortStale()
+	gs.saveState()
+
+	// Primer registro en el Matchmaker; si aún no está arriba, el bucle de
+	// reconexión reintenta.
 	if err := gs.sendStatus(statusAvailable, ""); err != nil {
 		log.Printf("[GameServer %s] ERROR registrando en Matchmaker: %v", gs.id, err)
 	}
@@ -104,108 +227,399 @@ func newGameServer(id, listenAddr string, crashProb float64, mmcli pb.Matchmaker
 
 // AssignMatch es el RPC que invoca el Matchmaker.
 func (gs *gameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	now := gs.wall.Now()
+	gs.observeClock(req.GetVectorClock())
 	gs.mu.Lock()
-	if gs.currentStatus != statusAvailable {
+	// Una repetición recibe la respuesta original.
+	if orig, res := gs.duplicateAssign(req.GetMatchId(), now); res != nil {
+		gs.mu.Unlock()
+		gs.metrics.assignments.With("duplicate").Inc()
+		log.Printf("[GameServer %s] AssignMatch repetido de %s (intento %d; aceptada en el intento %d, %d repeticiones)",
+			gs.id, req.GetMatchId(), req.GetAttempt(), orig.attempt, orig.repeats)
+		return res, nil
+	}
+	addr, free := gs.matchAddr(req.GetMatchId())
+	if len(gs.active) >= gs.maxMatches || !free {
+		gs.ports.release(req.GetMatchId())
 		gs.mu.Unlock()
+		gs.metrics.assignments.With("rejected").Inc()
+		log.Printf("[GameServer %s] Rechazo la partida %s (intento %d): sin huecos", gs.id, req.GetMatchId(), req.GetAttempt())
 		return &pb.AssignMatchResponse{
 			StatusCode: pb.AssignMatchResponse_BUSY,
 			Message:    "Game server not available",
 		}, nil
 	}
 
-	// Transición a OCUPADO.
-	gs.currentStatus = statusBusy
-	gs.currentMatch = req.GetMatchId()
+	// Ocupa un hueco.
+	duration := matchDuration(req.GetMode())
+	res := &pb.AssignMatchResponse{
+		StatusCode: pb.AssignMatchResponse_OK,
+		Message:    "Match accepted",
+		MatchAddr:  addr,
+	}
+	gs.rememberAssign(req, res, now)
+	gs.active[req.GetMatchId()] = &pb.RunningMatch{
+		MatchId:         req.GetMatchId(),
+		PlayerIds:       req.GetPlayerIds(),
+		GameMode:        req.GetMode().GetName(),
+		StartedAtUnixMs: now.UnixMilli(),
+		EndsAtUnixMs:    now.Add(duration).UnixMilli(),
+		MatchAddr:       addr,
+	}
+	gs.traces[req.GetMatchId()] = tracing.SpanContextFromContext(ctx)
+	gs.sims[req.GetMatchId()] = newMatchSim(req.GetMode(), req.GetPlayerIds())
+	running := len(gs.active)
 	gs.mu.Unlock()
+	gs.saveState()
+	gs.metrics.assignments.With("accepted").Inc()
+	gs.metrics.activeMatches.Set(float64(running))
+
+	log.Printf("[GameServer %s] Recibiendo partida %s (intento %d) con jugadores %v (%d/%d en curso)",
+		gs.id, req.GetMatchId(), req.GetAttempt(), req.GetPlayerIds(), running, gs.maxMatches)
+	if addr != "" {
+		log.Printf("[GameServer %s] Partida %s en %s", gs.id, req.GetMatchId(), addr)
+	}
 
-	log.Printf("[GameServer %s] Recibiendo partida %s con jugadores %v", gs.id, req.GetMatchId(), req.GetPlayerIds())
+	// Notifica inmediatamente al Matchmaker los huecos que le quedan.
+	if err := gs.sendStatus(gs.status(), req.GetMatchId()); err != nil {
+		log.Printf("[GameServer %s] WARNING: no pude notificar el nuevo estado: %v", gs.id, err)
+	}
 
-	// Notifica inmediatamente al Matchmaker que está ocupado.
-	if err := gs.sendStatus(statusBusy, gs.currentMatch); err != nil {
-		log.Printf("[GameServer %s] WARNING: no pude notificar estado OCUPADO: %v", gs.id, err)
+	// Una sala que arranca incompleta pide los jugadores que le faltan.
+	if lobby := int(req.GetMode().GetLobbyMaxPlayers()); lobby > len(req.GetPlayerIds()) {
+		go gs.requestBackfill(req.GetMatchId(), lobby-len(req.GetPlayerIds()), nil)
 	}
 
 	// Simulación de la partida en una goroutine para no bloquear el RPC.
-	go gs.simulateMatch(req.GetMatchId())
+	go gs.simulateMatch(req.GetMatchId(), req.GetMode(), duration)
+	go gs.publishMatch(req.GetMatchId())
 
-	return &pb.AssignMatchResponse{
-		StatusCode: pb.AssignMatchResponse_OK,
-		Message:    "Match accepted",
+	return res, nil
+}
+
+// CancelMatch es el RPC con que el Matchmaker termina una partida que un
+// jugador abandonó.
+func (gs *gameServer) CancelMatch(ctx context.Context, req *pb.CancelMatchRequest) (*pb.CancelMatchResponse, error) {
+	if !gs.abortMatch(req.GetMatchId(), req.GetReason()) {
+		return &pb.CancelMatchResponse{
+			StatusCode: pb.CancelMatchResponse_UNKNOWN_MATCH,
+			Message:    "Match not running",
+		}, nil
+	}
+	return &pb.CancelMatchResponse{
+		StatusCode: pb.CancelMatchResponse_OK,
+		Message:    "Match cancelled",
 	}, nil
 }
 
-// simulateMatch duerme entre 10-20 s y luego actualiza estado.
-func (gs *gameServer) simulateMatch(matchID string) {
-	duration := time.Duration(matchDurationMinSecs+rand.Intn(matchDurationMaxSecs-matchDurationMinSecs+1)) * time.Second
-	log.Printf("[GameServer %s] Simulando partida %s durante %v", gs.id, matchID, duration)
-	time.Sleep(duration)
+// status es DISPONIBLE mientras quede algún hueco libre.
+func (gs *gameServer) status() pb.ServerStatus {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if len(gs.active) < gs.maxMatches {
+		return statusAvailable
+	}
+	return statusBusy
+}
+
+// matchDuration elige la duración de la partida dentro de la pista del modo
+// de juego o, si el modo no la define, entre 10-20 s.
+func matchDuration(mode *pb.GameMode) time.Duration {
+	lo, hi := matchDurationMinSecs, matchDurationMaxSecs
+	if mode.GetMaxDurationS() > 0 {
+		lo, hi = int(mode.GetMinDurationS()), int(mode.GetMaxDurationS())
+	}
+	return time.Duration(lo+rand.Intn(hi-lo+1)) * time.Second
+}
+
+// simulateMatch juega la partida durante la duración indicada, un evento de
+// puntaje por vez (ver simulation.go), informa el resultado y luego
+// actualiza estado. En las salas, a mitad de partida pueden desconectarse
+// jugadores (DISCONNECT_PROB); el resultado lleva a los que siguen en ella.
+func (gs *gameServer) simulateMatch(matchID string, gm *pb.GameMode, duration time.Duration) {
+	mode := gm.GetName()
+	ctx, span := tracing.Start(gs.matchContext(matchID), "PlayMatch",
+		tracing.String("match.id", matchID), tracing.String("match.mode", mode))
+	defer span.End()
+
+	interval := defaultEventInterval
+	gs.mu.Lock()
+	if sim := gs.sims[matchID]; sim != nil {
+		interval = sim.interval
+	}
+	gs.mu.Unlock()
+	log.Printf("[GameServer %s] Simulando partida %s durante %v (%s, un evento cada %v)",
+		gs.id, matchID, duration, gm.GetScoring(), interval)
+
+	disconnects := gm.GetLobbyMaxPlayers() > 0 && gs.disconnectProb > 0
+	for at := time.Duration(0); at < duration; {
+		step := interval
+		if rest := duration - at; rest < step {
+			step = rest
+		}
+		gs.wall.Sleep(step)
+		at += step
+		if disconnects && at >= duration/2 {
+			gs.simulateDisconnects(matchID)
+			disconnects = false
+		}
+		// el Matchmaker pudo abortarla mientras tanto (ABORT_MATCH)
+		if !gs.playEvent(matchID, mode, at) {
+			span.SetAttributes(tracing.Bool("match.aborted", true))
+			return
+		}
+	}
+
+	gs.mu.Lock()
+	rm, playing := gs.active[matchID]
+	var result *pb.MatchResultRequest
+	if playing {
+		result = gs.sims[matchID].result(rm.GetPlayerIds())
+	}
+	gs.mu.Unlock()
+	if !playing {
+		span.SetAttributes(tracing.Bool("match.aborted", true))
+		return
+	}
+	gs.metrics.matchDuration.Observe(duration.Seconds(), mode)
 
 	// ¿Se “cae”?
-	if rand.Float64() < gs.crashProb {
+	if gs.chaos.Crash() {
+		gs.metrics.matches.With("crashed").Inc()
 		log.Printf("[GameServer %s] ¡Simulando CAÍDA después de la partida %s!", gs.id, matchID)
 		_ = gs.sendStatus(statusCrashed, "")
 		os.Exit(1)
 		return
 	}
 
-	// Si no se cayó, vuelve a DISPONIBLE.
+	// Informa el resultado antes de liberarse; si el Matchmaker no responde
+	// queda pendiente hasta reconectar.
+	if err := gs.reportResult(ctx, matchID, result, duration); err != nil {
+		log.Printf("[GameServer %s] WARNING: no pude informar resultado de %s: %v", gs.id, matchID, err)
+	}
+
+	// Si no se cayó, libera el hueco.
 	gs.mu.Lock()
-	gs.currentStatus = statusAvailable
-	gs.currentMatch = ""
+	delete(gs.active, matchID)
+	delete(gs.traces, matchID)
+	delete(gs.sims, matchID)
+	gs.ports.release(matchID)
+	running := len(gs.active)
 	gs.mu.Unlock()
+	gs.saveState()
+	gs.metrics.matches.With("completed").Inc()
+	gs.metrics.activeMatches.Set(float64(running))
 
 	if err := gs.sendStatus(statusAvailable, ""); err != nil {
 		log.Printf("[GameServer %s] ERROR al volver a DISPONIBLE: %v", gs.id, err)
 	} else {
-		log.Printf("[GameServer %s] Partida %s finalizada. Estado DISPONIBLE.", gs.id, matchID)
+		log.Printf("[GameServer %s] Partida %s finalizada. Estado DISPONIBLE (%d/%d en curso).", gs.id, matchID, running, gs.maxMatches)
 	}
 }
 
-// sendStatus encapsula la llamada UpdateServerStatus al Matchmaker.
-func (gs *gameServer) sendStatus(status, matchID string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// reportResult completa el resultado de la simulación con los datos de la
+// partida y lo envía con ReportMatchResult.
+func (gs *gameServer) reportResult(ctx context.Context, matchID string, req *pb.MatchResultRequest, duration time.Duration) error {
+	req.MatchId = matchID
+	req.ServerId = gs.id
+	req.DurationMs = duration.Milliseconds()
+	req.Namespace = gs.namespace
+	return gs.sendResult(ctx, req)
+}
+
+// sendResult envía un resultado; si el Matchmaker no está disponible lo
+// guarda para reenviarlo tras el re-registro.
+func (gs *gameServer) sendResult(ctx context.Context, req *pb.MatchResultRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	_, err := gs.matchmakerCli.UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{
+	_, err := gs.matchmakerCli.ReportMatchResult(ctx, req)
+	if err != nil {
+		if gs.markDisconnected(err) {
+			gs.mu.Lock()
+			gs.pending[req.GetMatchId()] = req
+			gs.mu.Unlock()
+		}
+		return err
+	}
+	if len(req.GetWinnerIds()) == 0 {
+		log.Printf("[GameServer %s] Resultado de %s enviado: empate (%d eliminaciones)", gs.id, req.GetMatchId(), len(req.GetEvents()))
+		return nil
+	}
+	log.Printf("[GameServer %s] Resultado de %s enviado: ganan %v (%d eliminaciones)", gs.id, req.GetMatchId(), req.GetWinnerIds(), len(req.GetEvents()))
+	return nil
+}
+
+// latencyEstimate devuelve la latencia a declarar: la configurada o, si no
+// hay, el RTT de la última actualización enviada al Matchmaker.
+func (gs *gameServer) latencyEstimate() time.Duration {
+	if gs.latency > 0 {
+		return gs.latency
+	}
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	return gs.lastRTT
+}
+
+// sendStatus notifica el estado al Matchmaker por el stream Heartbeat o,
+// si no hay uno abierto (o es el aviso de CAIDO), con UpdateServerStatus.
+// Si el cambio es por una partida, lleva su traza.
+func (gs *gameServer) sendStatus(status pb.ServerStatus, matchID string) error {
+	req := gs.statusRequest(status, matchID)
+	ctx := gs.matchContext(matchID)
+	req.Traceparent = tracing.Traceparent(ctx)
+	if status != statusCrashed && gs.sendHeartbeat(req) {
+		return nil
+	}
+	return gs.updateStatus(ctx, req)
+}
+
+// matchContext devuelve un contexto sin plazo con la traza que el
+// Matchmaker envió en el AssignMatch de la partida.
+func (gs *gameServer) matchContext(matchID string) context.Context {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	return tracing.WithParent(context.Background(), gs.traces[matchID])
+}
+
+// statusRequest arma la actualización con los huecos y partidas actuales.
+func (gs *gameServer) statusRequest(status pb.ServerStatus, matchID string) *pb.ServerStatusUpdateRequest {
+	gs.mu.Lock()
+	free := gs.maxMatches - len(gs.active)
+	running := gs.runningMatches()
+	epoch := gs.mmEpoch
+	gs.mu.Unlock()
+
+	req := &pb.ServerStatusUpdateRequest{
 		ServerId:  gs.id,
 		NewStatus: status,
 		Address:   gs.address,
 		MatchId:   matchID,
-	})
-	return err
+		Namespace: gs.namespace,
+		Region:    gs.region,
+		LatencyMs: uint32(gs.latencyEstimate().Milliseconds()),
+		Capacity:  int32(gs.maxMatches),
+		FreeSlots: int32(free),
+
+		RunningMatches: running,
+		Epoch:          epoch,
+	}
+	gs.stampStatus(req)
+	return req
 }
 
-// ───────────────────────────────────────────────────────────────────────────────
-// Funciones auxiliares de inicialización
-// ───────────────────────────────────────────────────────────────────────────────
+// updateStatus encapsula la llamada UpdateServerStatus al Matchmaker.
+func (gs *gameServer) updateStatus(ctx context.Context, req *pb.ServerStatusUpdateRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
 
-// loadEnv obtiene configuración desde las variables de entorno.
-func loadEnv() (id string, port int, matchmakerAddr string, crashProb float64) {
-	id = os.Getenv("SERVER_ID")
-	if id == "" {
-		// Genera ID pseudoaleatorio si no se proporciona.
-		id = fmt.Sprintf("GameServer-%d", rand.Intn(10000))
+	start := time.Now()
+	res, err := gs.matchmakerCli.UpdateServerStatus(ctx, req)
+	if err != nil {
+		gs.metrics.statusUpdateFailures.With(req.GetNewStatus().String()).Inc()
+		gs.markDisconnected(err)
+		return err
 	}
+	gs.mu.Lock()
+	gs.lastRTT = time.Since(start)
+	gs.mu.Unlock()
+	return gs.checkStatusResponse(res)
+}
 
-	port = defaultPort
-	if p := os.Getenv("PORT"); p != "" {
-		if n, err := strconv.Atoi(p); err == nil {
-			port = n
-		}
+// checkStatusResponse interpreta la respuesta a una actualización, llegue
+// por la llamada unaria o en un ACK del stream.
+func (gs *gameServer) checkStatusResponse(res *pb.ServerStatusUpdateResponse) error {
+	gs.observeClock(res.GetVectorClock())
+	gs.noteEpoch(res.GetEpoch())
+	switch res.GetStatusCode() {
+	case pb.ServerStatusUpdateResponse_INVALID_ADDRESS:
+		return fmt.Errorf("el Matchmaker rechazó la dirección %q: %s", gs.address, res.GetMessage())
+	case pb.ServerStatusUpdateResponse_WRONG_NAMESPACE:
+		return fmt.Errorf("el Matchmaker rechazó el registro en el namespace %q: %s", gs.namespace, res.GetMessage())
+	case pb.ServerStatusUpdateResponse_DRAINED:
+		gs.markRetired()
 	}
+	return nil
+}
 
-	matchmakerAddr = os.Getenv("MATCHMAKER_ADDR")
-	if matchmakerAddr == "" {
-		matchmakerAddr = defaultMMAddr
-	}
+// ───────────────────────────────────────────────────────────────────────────────
+// Funciones auxiliares de inicialización
+// ───────────────────────────────────────────────────────────────────────────────
+
+// settings reúne la configuración leída del entorno.
+type settings struct {
+	id             string
+	port           int
+	advertiseAddr  string
+	matchmakerAddr string
+	chaos          *chaos.Injector
+	disconnectProb float64
+	namespace      string
+	region         string
+	latency        time.Duration
+	metricsPort    int
+	maxMatches     int
+	ports          *portPool
+	heartbeat      time.Duration
+	keepalive      time.Duration
+	keepaliveWait  time.Duration
+	stateFile      string
+	tls            config.TLSFiles
+	client         config.ClientSettings
+	logFile        config.LogFile
+	statusErrors   bool // rechazos también como errores gRPC (RPC_STATUS_ERRORS)
+}
 
-	crashProb = defaultCrashProb
-	if cpStr := os.Getenv("CRASH_PROB"); cpStr != "" {
-		if cp, err := strconv.ParseFloat(cpStr, 64); err == nil && cp >= 0 && cp <= 1 {
-			crashProb = cp
+// loadEnv obtiene configuración desde las variables de entorno, la valida y
+// aborta con un resumen de errores si algo es incoherente.
+func loadEnv() settings {
+	cfg := config.NewReport("gameserver")
+	var s settings
+	s.id = cfg.StringOr("SERVER_ID", func() string {
+		// Genera ID pseudoaleatorio si no se proporciona.
+		return fmt.Sprintf("GameServer-%d", rand.Intn(10000))
+	})
+	s.port = cfg.Port("PORT", defaultPort)
+	s.advertiseAddr = cfg.OptionalHostPort("ADVERTISE_ADDR")
+	s.matchmakerAddr = cfg.Matchmaker("MATCHMAKER_ADDR", defaultMMAddr)
+	// con varios Matchmakers (SHARD_ROUTES) el servidor se registra en el
+	// que atiende SHARD_MODE; vacío = la ruta "*" o MATCHMAKER_ADDR
+	shardMode := cfg.String("SHARD_MODE", "")
+	routes := shard.FromConfig(cfg)
+	s.chaos = chaos.FromConfig(cfg, "CRASH_PROB", defaultCrashProb)
+	s.disconnectProb = cfg.Float("DISCONNECT_PROB", 0, 0, 1)
+	s.namespace = cfg.String("NAMESPACE", "default")
+	s.region = cfg.String("REGION", "")
+	s.latency = cfg.Duration("LATENCY_ESTIMATE", 0, 0, 10*time.Second)
+	s.metricsPort = cfg.Port("METRICS_PORT", defaultMetricsPort)
+	s.maxMatches = cfg.Int("MAX_CONCURRENT_MATCHES", 1, 1, 64)
+	cfg.Spec("MATCH_PORT_RANGE", "", func(raw string) error {
+		pool, err := parsePortRange(raw)
+		if err == nil && pool != nil && pool.size() < s.maxMatches {
+			err = fmt.Errorf("%d puertos no alcanzan para MAX_CONCURRENT_MATCHES=%d", pool.size(), s.maxMatches)
 		}
-	}
-	return
+		s.ports = pool
+		return err
+	})
+	s.heartbeat = cfg.Duration("HEARTBEAT_INTERVAL", defaultHeartbeatInterval, 500*time.Millisecond, time.Minute)
+	s.keepalive = cfg.Duration("KEEPALIVE_TIME", defaultKeepaliveTime, 10*time.Second, 10*time.Minute)
+	s.keepaliveWait = cfg.Duration("KEEPALIVE_TIMEOUT", defaultKeepaliveTimeout, time.Second, time.Minute)
+	s.stateFile = cfg.String("STATE_FILE", filepath.Join(os.TempDir(), "gameserver-"+s.id+".json"))
+	s.tls = cfg.TLS()
+	s.client = cfg.Client()
+	s.logFile = cfg.LogFile()
+	s.statusErrors = cfg.OneOf("RPC_STATUS_ERRORS", "0", "0", "1") == "1"
+	tracing.Configure(cfg, "GameServer "+s.id)
+	cfg.MustValidate()
+	s.matchmakerAddr = routes.Addr(shardMode, s.matchmakerAddr)
+	return s
+}
+
+// isHealthMethod indica si la RPC es de grpc.health.v1, que no sufre los
+// fallos inyectados: el healthcheck del admin debe distinguir un servidor
+// caído de uno con chaos.
+func isHealthMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
 }
 
 // ───────────────────────────────────────────────────────────────────────────────
@@ -216,11 +630,40 @@ func main() {
 	rand.Seed(time.Now().UnixNano())
 
 	// 1. Cargar configuración.
-	id, port, mmAddr, crashProb := loadEnv()
-	listenAddr := fmt.Sprintf("0.0.0.0:%d", port)
+	cfg := loadEnv()
+	id, mmAddr := cfg.id, cfg.matchmakerAddr
+	listenAddr := fmt.Sprintf("0.0.0.0:%d", cfg.port)
+	// sin host: el Matchmaker lo completa con la IP de origen de la conexión
+	advertiseAddr := cfg.advertiseAddr
+	if advertiseAddr == "" {
+		advertiseAddr = fmt.Sprintf(":%d", cfg.port)
+	}
+	if cfg.logFile.Path != "" {
+		if err := slog.UseFile(cfg.logFile.Path, cfg.logFile.MaxBytes, cfg.logFile.Backups); err != nil {
+			log.Fatalf("[GameServer %s] LOG_FILE: %v", id, err)
+		}
+		log.SetOutput(slog.Writer())
+	}
+
+	serverCreds, err := tlsutil.ServerCredentials(cfg.tls)
+	if err != nil {
+		log.Fatalf("[GameServer %s] TLS del servidor: %v", id, err)
+	}
+	clientCreds, err := tlsutil.ClientCredentials(cfg.tls)
+	if err != nil {
+		log.Fatalf("[GameServer %s] TLS de cliente: %v", id, err)
+	}
+
+	mt := newGSMetrics()
+	go mt.serve(id, cfg.metricsPort)
 
 	// 2. Crear conexión al Matchmaker.
-	connMM, err := grpc.Dial(mmAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	connMM, err := mmclient.Dial(mmAddr, mmclient.Options{
+		Creds:    clientCreds,
+		Token:    cfg.client.Token,
+		ClientID: id,
+		Retries:  cfg.client.Retries,
+	}, grpc.WithUnaryInterceptor(mt.unaryClientInterceptor), keepaliveOption(cfg.keepalive, cfg.keepaliveWait))
 	if err != nil {
 		log.Fatalf("[GameServer %s] No pude conectar al Matchmaker en %s: %v", id, mmAddr, err)
 	}
@@ -228,7 +671,10 @@ func main() {
 	mmClient := pb.NewMatchmakerClient(connMM)
 
 	// 3. Crear GameServer y registrar.
-	gs := newGameServer(id, listenAddr, crashProb, mmClient)
+	gs := newGameServer(cfg, advertiseAddr, mmClient, mt)
+	go gs.watchMatchmaker(connMM)
+	go gs.reconnectLoop()
+	go gs.runHeartbeat(cfg.heartbeat)
 
 	// 4. Levantar servidor gRPC local.
 	lis, err := net.Listen("tcp", listenAddr)
@@ -236,19 +682,29 @@ func main() {
 		log.Fatalf("[GameServer %s] No pude escuchar en %s: %v", id, listenAddr, err)
 	}
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.Creds(serverCreds),
+		middleware.ChainUnary("GameServer", mt.unaryServerInterceptor, middleware.StatusErrors(cfg.statusErrors), cfg.chaos.UnaryServerInterceptor(isHealthMethod, nil),
+			clockpropagation.UnaryServer(gs.clockHooks())),
+		middleware.ChainStream("GameServer", cfg.chaos.StreamServerInterceptor(isHealthMethod), clockpropagation.StreamServer(gs.clockHooks())),
+	)
 	pb.RegisterGameServerServer(s, gs) // registra servicio
+	hs := health.NewServer()           // grpc.health.v1, para el healthcheck del admin
+	hs.SetServingStatus(pb.GameServer_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, hs)
 
-	log.Printf("[GameServer %s] Escuchando en %s (Matchmaker: %s, CrashProb: %.2f)",
-		id, listenAddr, mmAddr, crashProb)
+	log.Printf("[GameServer %s] Escuchando en %s, anunciado como %s (Matchmaker: %s, Namespace: %s, Región: %q, Chaos: %s)",
+		id, listenAddr, advertiseAddr, mmAddr, cfg.namespace, cfg.region, cfg.chaos.Config())
 
 	// 5. Manejar señales para apagado limpio.
 	go func() {
 		ch := make(chan os.Signal, 1)
 		signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
 		<-ch
-		log.Printf("[GameServer %s] Recibida señal de terminación. Notificando CAIDO…", id)
-		_ = gs.sendStatus(statusCrashed, "")
+		log.Printf("[GameServer %s] Recibida señal de terminación. Dándose de baja…", id)
+		gs.saveState()
+		gs.deregister()
+		hs.Shutdown()
 		s.GracefulStop()
 	}()
 
@@ -0,0 +1,97 @@
+// gameserver/match_ports.go
+//
+// Puertos dedicados por partida. Con MATCH_PORT_RANGE (p.ej. "61000-61015")
+// cada partida aceptada recibe un puerto libre del rango y AssignMatch
+// responde su dirección (match_addr): el host con que se anuncia el
+// servidor y ese puerto. El Matchmaker la guarda con la partida y se la da a
+// los jugadores en GetPlayerStatus para que sepan dónde "conectarse". El
+// puerto se devuelve al pool cuando la partida termina o se aborta.
+//
+// La simulación no tiene tráfico de juego: nadie escucha en esos puertos,
+// sólo se reservan para que dos partidas simultáneas nunca compartan uno.
+// El gRPC del servidor (AssignMatch, SpectateMatch) sigue en PORT. Sin
+// MATCH_PORT_RANGE no hay pool y los jugadores usan la dirección del
+// servidor, como antes.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// portPool reparte los puertos de un rango entre las partidas en curso.
+type portPool struct {
+	lo, hi int
+	next   int            // próximo a probar: rota para no reusar enseguida
+	used   map[int]string // puerto → partida
+}
+
+// parsePortRange interpreta "inicio-fin"; vacío = sin pool.
+func parsePortRange(raw string) (*portPool, error) {
+	if raw = strings.TrimSpace(raw); raw == "" {
+		return nil, nil
+	}
+	from, to, ok := strings.Cut(raw, "-")
+	if !ok {
+		return nil, fmt.Errorf("se esperaba inicio-fin, p.ej. 61000-61015: %q", raw)
+	}
+	lo, err1 := strconv.Atoi(strings.TrimSpace(from))
+	hi, err2 := strconv.Atoi(strings.TrimSpace(to))
+	if err1 != nil || err2 != nil || lo < 1 || hi > 65535 || lo > hi {
+		return nil, fmt.Errorf("rango de puertos inválido %q", raw)
+	}
+	return &portPool{lo: lo, hi: hi, next: lo, used: map[int]string{}}, nil
+}
+
+func (p *portPool) size() int { return p.hi - p.lo + 1 }
+
+// take reserva un puerto libre para matchID; false si no queda ninguno.
+// debe llamarse con gs.mu bloqueado
+func (p *portPool) take(matchID string) (int, bool) {
+	for i := 0; i < p.size(); i++ {
+		port := p.next
+		if p.next++; p.next > p.hi {
+			p.next = p.lo
+		}
+		if _, busy := p.used[port]; !busy {
+			p.used[port] = matchID
+			return port, true
+		}
+	}
+	return 0, false
+}
+
+// release devuelve al pool el puerto de matchID. Admite un pool nil.
+// debe llamarse con gs.mu bloqueado
+func (p *portPool) release(matchID string) {
+	if p == nil {
+		return
+	}
+	for port, id := range p.used {
+		if id == matchID {
+			delete(p.used, port)
+		}
+	}
+}
+
+// matchAddr reserva el puerto de matchID y devuelve su dirección, con el
+// host anunciado por el servidor (vacío si no anuncia host: lo completa el
+// Matchmaker). Sin pool devuelve "" y true.
+// debe llamarse con gs.mu bloqueado
+func (gs *gameServer) matchAddr(matchID string) (string, bool) {
+	if gs.ports == nil {
+		return "", true
+	}
+	port, ok := gs.ports.take(matchID)
+	if !ok {
+		return "", false
+	}
+	host, _, err := net.SplitHostPort(gs.address)
+	if err != nil {
+		host = ""
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port)), true
+}
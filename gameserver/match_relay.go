@@ -0,0 +1,73 @@
+// gameserver/match_relay.go
+//
+// Publicación de partidas en el Matchmaker. Mientras una partida está en
+// curso, publishMatch le envía por el stream PublishMatchEvents la misma
+// secuencia que vería un espectador (spectate.go), y el Matchmaker la
+// retransmite a los jugadores y espectadores suscritos, que así no necesitan
+// llegar a este servidor. Si el stream se corta —el Matchmaker se reinició o
+// se promovió el respaldo— se reabre cada relayRetry, empezando por un
+// SNAPSHOT nuevo, mientras la partida siga en curso. Un Matchmaker anterior
+// a la retransmisión responde Unimplemented y la partida sólo se ve con
+// SpectateMatch.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const relayRetry = 2 * time.Second
+
+// publishMatch publica la partida matchID hasta que termina.
+func (gs *gameServer) publishMatch(matchID string) {
+	for {
+		err := gs.publishOnce(matchID)
+		switch status.Code(err) {
+		case codes.OK, codes.NotFound:
+			// terminó, o ya no estaba en curso al reabrir
+			return
+		case codes.Unimplemented:
+			log.Printf("[GameServer %s] El Matchmaker no retransmite partidas: %s sólo se ve con SpectateMatch", gs.id, matchID)
+			return
+		case codes.FailedPrecondition:
+			log.Printf("[GameServer %s] El Matchmaker rechazó los eventos de %s: %v", gs.id, matchID, err)
+			return
+		}
+		log.Printf("[GameServer %s] WARNING: se cortó la retransmisión de %s (%v); reintento en %v", gs.id, matchID, err, relayRetry)
+		gs.wall.Sleep(relayRetry)
+	}
+}
+
+// publishOnce abre un stream PublishMatchEvents y publica la partida hasta
+// ENDED o hasta que el stream falle.
+func (gs *gameServer) publishOnce(matchID string) error {
+	ctx, cancel := context.WithCancel(gs.matchContext(matchID))
+	defer cancel()
+	stream, err := gs.matchmakerCli.PublishMatchEvents(ctx)
+	if err != nil {
+		return err
+	}
+	err = gs.streamMatch(ctx, matchID, "El Matchmaker (retransmisión)", func(ev *pb.SpectateEvent) error {
+		return stream.Send(&pb.PublishMatchEventsRequest{Namespace: gs.namespace, ServerId: gs.id, Event: ev})
+	})
+	// con io.EOF el Matchmaker cerró el stream: su estado llega en CloseAndRecv
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	log.Printf("[GameServer %s] Partida %s retransmitida: %d eventos, %d entregas, %d descartadas por suscriptores lentos",
+		gs.id, matchID, res.GetReceived(), res.GetDelivered(), res.GetDropped())
+	return nil
+}
@@ -0,0 +1,96 @@
+// gameserver/metrics.go
+//
+// Endpoint HTTP /metrics en formato Prometheus, análogo al del Matchmaker:
+// asignaciones aceptadas/rechazadas, partidas completadas/caídas, duración
+// de las simulaciones, eliminaciones simuladas, fallos al informar estado, re-registros tras perder
+// al Matchmaker y latencia de las RPC (entrantes y hacia el Matchmaker)
+// medida con interceptores.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/metrics"
+)
+
+const defaultMetricsPort = 2113
+
+// buckets de duración de partida, en segundos
+var matchDurationBuckets = []float64{5, 10, 15, 20, 30, 45, 60, 90, 120, 300}
+
+type gsMetrics struct {
+	reg *metrics.Registry
+
+	activeMatches        *metrics.Gauge
+	assignments          *metrics.Counter
+	matches              *metrics.Counter
+	matchDuration        *metrics.Histogram
+	scoreEvents          *metrics.Counter
+	statusUpdateFailures *metrics.Counter
+	reregistrations      metrics.CounterSeries
+	rpcDuration          *metrics.Histogram
+	clientRPCDuration    *metrics.Histogram
+}
+
+func newGSMetrics() *gsMetrics {
+	reg := metrics.NewRegistry()
+	mt := &gsMetrics{
+		reg: reg,
+		activeMatches: reg.NewGauge("gameserver_active_matches",
+			"Partidas en curso en este servidor."),
+		assignments: reg.NewCounter("gameserver_assignments_total",
+			"AssignMatch recibidos por resultado.", "result"),
+		matches: reg.NewCounter("gameserver_matches_total",
+			"Partidas terminadas por desenlace.", "outcome"),
+		matchDuration: reg.NewHistogram("gameserver_match_duration_seconds",
+			"Duración de las partidas simuladas.", matchDurationBuckets, "mode"),
+		scoreEvents: reg.NewCounter("gameserver_score_events_total",
+			"Eliminaciones generadas por la simulación.", "mode"),
+		statusUpdateFailures: reg.NewCounter("gameserver_status_update_failures_total",
+			"UpdateServerStatus que no llegaron al Matchmaker.", "status"),
+		reregistrations: reg.NewCounter("gameserver_matchmaker_reregistrations_total",
+			"Re-registros completos tras perder la conexión con el Matchmaker.").With(),
+		rpcDuration: reg.NewHistogram("gameserver_rpc_duration_seconds",
+			"Latencia de las RPC unarias recibidas.", metrics.DefaultBuckets, "method", "code"),
+		clientRPCDuration: reg.NewHistogram("gameserver_matchmaker_rpc_duration_seconds",
+			"Latencia de las RPC enviadas al Matchmaker.", metrics.DefaultBuckets, "method", "code"),
+	}
+	mt.activeMatches.Set(0)
+	return mt
+}
+
+// unaryServerInterceptor mide la latencia de cada RPC recibida.
+func (mt *gsMetrics) unaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	mt.rpcDuration.Observe(time.Since(start).Seconds(), path.Base(info.FullMethod), status.Code(err).String())
+	return resp, err
+}
+
+// unaryClientInterceptor mide la latencia de cada RPC hacia el Matchmaker.
+func (mt *gsMetrics) unaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	mt.clientRPCDuration.Observe(time.Since(start).Seconds(), path.Base(method), status.Code(err).String())
+	return err
+}
+
+// serve expone /metrics; un error aquí no detiene el GameServer.
+func (mt *gsMetrics) serve(id string, port int) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", mt.reg)
+
+	log.Printf("[GameServer %s] Métricas Prometheus en :%d/metrics", id, port)
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+		log.Printf("[GameServer %s] ERROR: endpoint de métricas detenido: %v", id, err)
+	}
+}
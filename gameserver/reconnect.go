@@ -0,0 +1,174 @@
+// gameserver/reconnect.go
+//
+// Tolerancia a reinicios del Matchmaker. watchMatchmaker observa el estado
+// de la conexión gRPC y, además, cualquier RPC que falle con Unavailable o
+// DeadlineExceeded marca al Matchmaker como caído. Mientras lo esté,
+// reconnectLoop reintenta con espera exponencial (2 s a 30 s) un
+// re-registro completo: estado, huecos libres y partidas en curso, que el
+// Matchmaker reconstruye si las perdió. Al lograrlo reenvía los resultados
+// que no se pudieron informar. Si la conexión vuelve a estar lista antes de
+// que venza la espera, el re-registro se intenta en el acto.
+//
+// Un Matchmaker que muere sin cerrar la conexión (VM apagada, red partida)
+// no cambia el estado por sí solo: la conexión seguiría "lista" y el stream
+// Heartbeat colgado para siempre. Por eso el cliente envía pings de
+// keepalive cada KEEPALIVE_TIME, incluso sin RPC en curso, y da la conexión
+// por perdida si uno no recibe respuesta en KEEPALIVE_TIMEOUT; gRPC la
+// vuelve a marcar por su cuenta y watchMatchmaker ve la caída.
+//
+// Cada respuesta a una actualización trae además la época del Matchmaker,
+// que cambia con cada ejecución. Si cambia, el Matchmaker nuevo puede no
+// conocer a este servidor ni sus partidas, y noteEpoch lo re-registra en el
+// acto, aunque la conexión nunca se haya visto caer.
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	reconnectMin = 2 * time.Second
+	reconnectMax = 30 * time.Second
+
+	// gRPC no envía pings más seguido que cada 10 s
+	defaultKeepaliveTime    = 10 * time.Second
+	defaultKeepaliveTimeout = 5 * time.Second
+)
+
+// keepaliveOption configura los pings hacia el Matchmaker.
+func keepaliveOption(every, timeout time.Duration) grpc.DialOption {
+	return grpc.WithKeepaliveParams(keepalive.ClientParameters{
+		Time:                every,
+		Timeout:             timeout,
+		PermitWithoutStream: true, // un servidor sin stream Heartbeat también
+	})
+}
+
+// markDisconnected registra la pérdida del Matchmaker si err la indica y
+// despierta al bucle de reconexión. Devuelve si err era de conexión.
+func (gs *gameServer) markDisconnected(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+	default:
+		return false
+	}
+
+	gs.mu.Lock()
+	already := gs.mmDown
+	gs.mmDown = true
+	gs.mu.Unlock()
+
+	if !already {
+		log.Printf("[GameServer %s] WARNING: perdí contacto con el Matchmaker (%v); reintentando en segundo plano", gs.id, err)
+		select {
+		case gs.reconnect <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// watchMatchmaker detecta el cierre de la conexión aunque no haya RPC en
+// curso (p.ej. un servidor sin partidas cuando el Matchmaker se reinicia) y
+// avisa a reconnectLoop cuando vuelve a estar lista.
+func (gs *gameServer) watchMatchmaker(conn *grpc.ClientConn) {
+	wasReady := false
+	for st := conn.GetState(); st != connectivity.Shutdown; st = conn.GetState() {
+		switch st {
+		case connectivity.Ready:
+			wasReady = true
+			gs.mu.Lock()
+			down := gs.mmDown
+			gs.mu.Unlock()
+			if down {
+				select {
+				case gs.reachable <- struct{}{}:
+				default:
+				}
+			}
+		case connectivity.Idle, connectivity.TransientFailure:
+			if wasReady {
+				wasReady = false
+				gs.markDisconnected(status.Error(codes.Unavailable, "conexión cerrada"))
+			}
+		}
+		conn.WaitForStateChange(context.Background(), st)
+	}
+}
+
+// reconnectLoop atiende cada pérdida de conexión hasta re-registrarse.
+func (gs *gameServer) reconnectLoop() {
+	for range gs.reconnect {
+		wait := reconnectMin
+		for {
+			select {
+			case <-gs.wall.After(wait):
+			case <-gs.reachable:
+				log.Printf("[GameServer %s] Conexión con el Matchmaker lista de nuevo; re-registrando", gs.id)
+			}
+			if gs.reregister() {
+				break
+			}
+			if wait *= 2; wait > reconnectMax {
+				wait = reconnectMax
+			}
+		}
+	}
+}
+
+// noteEpoch compara la época de una respuesta con la última vista. Si el
+// Matchmaker cambió de ejecución, se re-registra con el estado completo;
+// si ya se sabe caído, de eso se encarga reconnectLoop.
+func (gs *gameServer) noteEpoch(epoch uint64) {
+	if epoch == 0 {
+		return // Matchmaker anterior a las épocas
+	}
+	gs.mu.Lock()
+	prev := gs.mmEpoch
+	gs.mmEpoch = epoch
+	down := gs.mmDown
+	gs.mu.Unlock()
+	if prev == 0 || prev == epoch || down {
+		return
+	}
+	log.Printf("[GameServer %s] El Matchmaker se reinició (época %016x → %016x); re-sincronizando", gs.id, prev, epoch)
+	go gs.reregister()
+}
+
+// reregister envía el estado completo y, si llega, reenvía los resultados
+// pendientes. Mientras mmDown siga en true, los fallos no vuelven a
+// despertar al bucle.
+func (gs *gameServer) reregister() bool {
+	if err := gs.updateStatus(context.Background(), gs.statusRequest(gs.status(), "")); err != nil {
+		log.Printf("[GameServer %s] Matchmaker aún no disponible: %v", gs.id, err)
+		return false
+	}
+
+	gs.mu.Lock()
+	gs.mmDown = false
+	running := len(gs.active)
+	pending := gs.pending
+	gs.pending = make(map[string]*pb.MatchResultRequest)
+	gs.mu.Unlock()
+	gs.metrics.reregistrations.Inc()
+	gs.abortStale()
+
+	log.Printf("[GameServer %s] Matchmaker de vuelta: re-registrado con %d partidas en curso y %d resultados pendientes",
+		gs.id, running, len(pending))
+	for _, req := range pending {
+		// si vuelve a fallar, sendResult lo deja pendiente otra vez
+		_ = gs.sendResult(context.Background(), req)
+	}
+	return true
+}
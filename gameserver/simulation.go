@@ -0,0 +1,229 @@
+// gameserver/simulation.go
+//
+// Motor de simulación de partidas. Cada partida en curso tiene un matchSim
+// que, cada event_interval_ms del modo, decide si hubo una eliminación
+// (score_prob) entre los jugadores que siguen en ella y lleva el marcador
+// con los puntos del modo (kill_points al que elimina, death_points menos
+// al eliminado). Al terminar el tiempo decide el ganador según scoring:
+//
+//   - DEATHMATCH: el de mayor puntaje; desempatan las eliminaciones y, si
+//     siguen iguales, es un empate (sin ganador).
+//   - TEAM_DEATHMATCH: el equipo con más eliminaciones (la primera mitad de
+//     los jugadores asignados es el equipo 1); desempata el puntaje total.
+//     Sólo se elimina a rivales. Las salas no tienen equipos y se juegan
+//     siempre como DEATHMATCH.
+//
+// El resultado (marcador, ganadores y eliminaciones) se informa con
+// ReportMatchResult, y los espectadores (spectate.go) ven este mismo
+// marcador mientras la partida sigue en curso.
+
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultEventInterval = time.Second
+	defaultScoreProb     = 0.6
+	defaultKillPoints    = 100
+	defaultDeathPoints   = 25
+	maxReportedEvents    = 500 // eliminaciones que viajan en el resultado
+)
+
+// matchSim es el estado de la simulación de una partida. Se lee y modifica
+// con gs.mu bloqueado.
+type matchSim struct {
+	rng         *rand.Rand
+	interval    time.Duration
+	scoreProb   float64
+	killPoints  int32
+	deathPoints int32
+	teams       map[string]int // jugador → equipo (1 o 2); nil = todos contra todos
+	stats       map[string]*pb.PlayerMatchStats
+	events      []*pb.ScoreEvent
+}
+
+// newMatchSim prepara la simulación de una partida del modo gm entre los
+// jugadores asignados.
+func newMatchSim(gm *pb.GameMode, players []string) *matchSim {
+	s := &matchSim{
+		rng:         rand.New(rand.NewSource(rand.Int63())),
+		interval:    defaultEventInterval,
+		scoreProb:   defaultScoreProb,
+		killPoints:  defaultKillPoints,
+		deathPoints: defaultDeathPoints,
+		stats:       make(map[string]*pb.PlayerMatchStats),
+	}
+	if ms := gm.GetEventIntervalMs(); ms > 0 {
+		s.interval = time.Duration(ms) * time.Millisecond
+	}
+	if p := gm.GetScoreProb(); p > 0 {
+		s.scoreProb = float64(p)
+	}
+	if p := gm.GetKillPoints(); p > 0 {
+		s.killPoints = p
+	}
+	if p := gm.GetDeathPoints(); p > 0 {
+		s.deathPoints = p
+	}
+	if gm.GetScoring() == pb.GameMode_TEAM_DEATHMATCH && gm.GetLobbyMaxPlayers() == 0 {
+		s.teams = make(map[string]int, len(players))
+		for i, pid := range players {
+			s.teams[pid] = 1 + i*2/len(players)
+		}
+	}
+	for _, pid := range players {
+		s.player(pid)
+	}
+	return s
+}
+
+func (s *matchSim) player(pid string) *pb.PlayerMatchStats {
+	st, ok := s.stats[pid]
+	if !ok {
+		st = &pb.PlayerMatchStats{PlayerId: pid}
+		s.stats[pid] = st
+	}
+	return st
+}
+
+// step simula un evento en el instante at entre los jugadores que siguen
+// en la partida. Devuelve la eliminación o nil si no hubo.
+func (s *matchSim) step(players []string, at time.Duration) *pb.ScoreEvent {
+	for _, pid := range players {
+		s.player(pid)
+	}
+	if len(players) < 2 || s.rng.Float64() >= s.scoreProb {
+		return nil
+	}
+	killer := players[s.rng.Intn(len(players))]
+	var rivals []string
+	for _, pid := range players {
+		if pid != killer && (s.teams == nil || s.teams[pid] != s.teams[killer]) {
+			rivals = append(rivals, pid)
+		}
+	}
+	if len(rivals) == 0 {
+		return nil
+	}
+	victim := rivals[s.rng.Intn(len(rivals))]
+
+	k, v := s.player(killer), s.player(victim)
+	k.Kills++
+	k.Score += s.killPoints
+	v.Deaths++
+	v.Score -= s.deathPoints
+	ev := &pb.ScoreEvent{AtMs: at.Milliseconds(), PlayerId: killer, VictimId: victim}
+	s.events = append(s.events, ev)
+	return ev
+}
+
+// scoreboard devuelve una copia del marcador de los jugadores dados (todos
+// los que pasaron por la partida si players es nil), de mayor a menor
+// puntaje.
+func (s *matchSim) scoreboard(players []string) []*pb.PlayerMatchStats {
+	if players == nil {
+		for pid := range s.stats {
+			players = append(players, pid)
+		}
+	}
+	out := make([]*pb.PlayerMatchStats, 0, len(players))
+	for _, pid := range players {
+		st := s.player(pid)
+		out = append(out, &pb.PlayerMatchStats{
+			PlayerId: st.GetPlayerId(),
+			Score:    st.GetScore(),
+			Kills:    st.GetKills(),
+			Deaths:   st.GetDeaths(),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].GetScore() != out[j].GetScore() {
+			return out[i].GetScore() > out[j].GetScore()
+		}
+		if out[i].GetKills() != out[j].GetKills() {
+			return out[i].GetKills() > out[j].GetKills()
+		}
+		return out[i].GetPlayerId() < out[j].GetPlayerId()
+	})
+	return out
+}
+
+// winners decide quiénes ganaron entre los jugadores que terminaron la
+// partida, a partir de su marcador ordenado. Vacío = empate.
+func (s *matchSim) winners(board []*pb.PlayerMatchStats) []string {
+	if len(board) == 0 {
+		return nil
+	}
+	if s.teams == nil {
+		if len(board) > 1 && board[1].GetScore() == board[0].GetScore() && board[1].GetKills() == board[0].GetKills() {
+			return nil
+		}
+		return []string{board[0].GetPlayerId()}
+	}
+	var kills, score [3]int32
+	for _, st := range board {
+		t := s.teams[st.GetPlayerId()]
+		kills[t] += st.GetKills()
+		score[t] += st.GetScore()
+	}
+	var team int
+	switch {
+	case kills[1] != kills[2]:
+		team = 1
+		if kills[2] > kills[1] {
+			team = 2
+		}
+	case score[1] != score[2]:
+		team = 1
+		if score[2] > score[1] {
+			team = 2
+		}
+	default:
+		return nil
+	}
+	var out []string
+	for _, st := range board {
+		if s.teams[st.GetPlayerId()] == team {
+			out = append(out, st.GetPlayerId())
+		}
+	}
+	return out
+}
+
+// result arma el MatchResultRequest de la partida con los jugadores que la
+// terminaron. winner_id es el de mayor puntaje entre los ganadores.
+func (s *matchSim) result(players []string) *pb.MatchResultRequest {
+	board := s.scoreboard(players)
+	req := &pb.MatchResultRequest{PlayerStats: board, WinnerIds: s.winners(board)}
+	if len(req.WinnerIds) > 0 {
+		req.WinnerId = req.WinnerIds[0]
+	}
+	events := s.events
+	if len(events) > maxReportedEvents {
+		events = events[:maxReportedEvents]
+	}
+	req.Events = append(req.Events, events...)
+	return req
+}
+
+// playEvent avanza un evento la simulación de matchID. Devuelve false si la
+// partida ya no está en curso (abortada o cancelada).
+func (gs *gameServer) playEvent(matchID, mode string, at time.Duration) bool {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	rm, ok := gs.active[matchID]
+	sim := gs.sims[matchID]
+	if !ok || sim == nil {
+		return false
+	}
+	if sim.step(rm.GetPlayerIds(), at) != nil {
+		gs.metrics.scoreEvents.With(mode).Inc()
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+// gameserver/snapshot.go
+//
+// Archivo de estado local (STATE_FILE). Se reescribe con las partidas en
+// curso cada vez que se acepta o termina una y al apagarse con una señal.
+// Si el proceso muere sin terminarlas (CRASH_PROB simula un os.Exit(1)), al
+// arrancar de nuevo las encuentra en el archivo y se las aborta al
+// Matchmaker con AbortMatch, que libera a sus jugadores sin esperar al
+// barrido de partidas huérfanas.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+type stateSnapshot struct {
+	ServerID  string             `json:"server_id"`
+	Namespace string             `json:"namespace"`
+	SavedAt   time.Time          `json:"saved_at"`
+	Matches   []*pb.RunningMatch `json:"matches"`
+}
+
+// saveState escribe las partidas en curso; el rename hace que un lector
+// nunca vea el archivo a medio escribir.
+func (gs *gameServer) saveState() {
+	if gs.statePath == "" {
+		return
+	}
+	gs.saveMu.Lock()
+	defer gs.saveMu.Unlock()
+
+	snap := stateSnapshot{ServerID: gs.id, Namespace: gs.namespace, SavedAt: gs.wall.Now(), Matches: []*pb.RunningMatch{}}
+	gs.mu.Lock()
+	for _, rm := range gs.active {
+		snap.Matches = append(snap.Matches, rm)
+	}
+	gs.mu.Unlock()
+	sort.Slice(snap.Matches, func(i, j int) bool { return snap.Matches[i].MatchId < snap.Matches[j].MatchId })
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err == nil {
+		tmp := gs.statePath + ".tmp"
+		if err = os.WriteFile(tmp, data, 0o644); err == nil {
+			err = os.Rename(tmp, gs.statePath)
+		}
+	}
+	if err != nil {
+		log.Printf("[GameServer %s] WARNING: no pude guardar el estado en %s: %v", gs.id, gs.statePath, err)
+	}
+}
+
+// loadStaleMatches lee el archivo de la ejecución anterior y devuelve las
+// partidas que quedaron sin terminar.
+func (gs *gameServer) loadStaleMatches() []string {
+	if gs.statePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(gs.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	var snap stateSnapshot
+	if err == nil {
+		err = json.Unmarshal(data, &snap)
+	}
+	if err != nil {
+		log.Printf("[GameServer %s] WARNING: archivo de estado %s ilegible, se ignora: %v", gs.id, gs.statePath, err)
+		return nil
+	}
+	if snap.ServerID != gs.id || snap.Namespace != gs.namespace {
+		log.Printf("[GameServer %s] WARNING: %s pertenece a %s/%s, se ignora", gs.id, gs.statePath, snap.Namespace, snap.ServerID)
+		return nil
+	}
+
+	var ids []string
+	for _, rm := range snap.Matches {
+		ids = append(ids, rm.GetMatchId())
+	}
+	if len(ids) > 0 {
+		log.Printf("[GameServer %s] Partidas sin terminar de la ejecución anterior (guardadas %s): %v",
+			gs.id, snap.SavedAt.Format(time.RFC3339), ids)
+	}
+	return ids
+}
+
+// abortStale avisa al Matchmaker de las partidas perdidas en el reinicio.
+// Las que no llegan por falta de conexión se reintentan al re-registrarse.
+func (gs *gameServer) abortStale() {
+	gs.mu.Lock()
+	ids := gs.stale
+	gs.stale = nil
+	gs.mu.Unlock()
+
+	var left []string
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		res, err := gs.matchmakerCli.AbortMatch(ctx, &pb.AbortMatchRequest{
+			MatchId:   id,
+			ServerId:  gs.id,
+			Reason:    "el GameServer se reinició durante la partida",
+			Namespace: gs.namespace,
+		})
+		cancel()
+		if err != nil {
+			if gs.markDisconnected(err) {
+				left = append(left, id)
+			} else {
+				log.Printf("[GameServer %s] WARNING: no pude abortar %s: %v", gs.id, id, err)
+			}
+			continue
+		}
+		log.Printf("[GameServer %s] Partida %s abortada tras el reinicio (%s)", gs.id, id, res.GetStatusCode())
+	}
+
+	gs.mu.Lock()
+	gs.stale = append(gs.stale, left...)
+	gs.mu.Unlock()
+}
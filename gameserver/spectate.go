@@ -0,0 +1,116 @@
+// gameserver/spectate.go
+//
+// Espectadores. SpectateMatch transmite una partida en curso tal como la
+// juega el motor de simulación (simulation.go): al conectarse el espectador
+// recibe el marcador completo (SNAPSHOT) y luego, a la cadencia de eventos
+// del modo, una eliminación (SCORE) por cada una que ocurrió o sólo el paso
+// del tiempo (TICK). Todos los espectadores de una partida ven el mismo
+// marcador, que es el que se informa al Matchmaker. El stream termina con
+// ENDED cuando la partida deja de estar en curso (finalizada, abortada o
+// cancelada). La misma secuencia se publica en el Matchmaker para que la
+// retransmita (match_relay.go).
+
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// SpectateMatch es el RPC con que un jugador mira una partida en curso.
+func (gs *gameServer) SpectateMatch(req *pb.SpectateRequest, stream pb.GameServer_SpectateMatchServer) error {
+	return gs.streamMatch(stream.Context(), req.GetMatchId(), req.GetSpectatorId(), stream.Send)
+}
+
+// streamMatch envía con send los eventos de matchID hasta ENDED, o hasta que
+// send falle o ctx se cancele. who identifica al destinatario en el log.
+// NotFound si la partida no está en curso.
+func (gs *gameServer) streamMatch(ctx context.Context, matchID, who string, send func(*pb.SpectateEvent) error) error {
+	rm, board, events, interval := gs.spectateView(matchID, 0)
+	if rm == nil {
+		return status.Errorf(codes.NotFound, "match %s not running", matchID)
+	}
+	log.Printf("[GameServer %s] %s mira la partida %s", gs.id, who, matchID)
+
+	// el SNAPSHOT ya refleja lo que ocurrió antes de que llegara
+	seen := len(events)
+	if err := send(gs.spectateEvent(rm, board, pb.SpectateEvent_SNAPSHOT)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-gs.wall.After(interval):
+		}
+
+		cur, curBoard, events, _ := gs.spectateView(matchID, seen)
+		if cur == nil {
+			ev := gs.spectateEvent(&pb.RunningMatch{MatchId: matchID, GameMode: rm.GetGameMode()}, board, pb.SpectateEvent_ENDED)
+			ev.Message = "la partida terminó"
+			return send(ev)
+		}
+		rm, board = cur, curBoard
+		seen += len(events)
+		if len(events) == 0 {
+			if err := send(gs.spectateEvent(rm, board, pb.SpectateEvent_TICK)); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, sc := range events {
+			ev := gs.spectateEvent(rm, board, pb.SpectateEvent_SCORE)
+			ev.PlayerId, ev.VictimId = sc.GetPlayerId(), sc.GetVictimId()
+			if err := send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// spectateView devuelve una copia de la partida en curso, su marcador, las
+// eliminaciones desde la número from y la cadencia de eventos; rm es nil si
+// la partida ya no está en curso.
+func (gs *gameServer) spectateView(matchID string, from int) (rm *pb.RunningMatch, board []*pb.PlayerMatchStats, events []*pb.ScoreEvent, interval time.Duration) {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	cur, ok := gs.active[matchID]
+	sim := gs.sims[matchID]
+	if !ok || sim == nil {
+		return nil, nil, nil, 0
+	}
+	rm = &pb.RunningMatch{
+		MatchId:         cur.GetMatchId(),
+		PlayerIds:       append([]string(nil), cur.GetPlayerIds()...),
+		GameMode:        cur.GetGameMode(),
+		StartedAtUnixMs: cur.GetStartedAtUnixMs(),
+		EndsAtUnixMs:    cur.GetEndsAtUnixMs(),
+	}
+	if from < len(sim.events) {
+		events = append(events, sim.events[from:]...)
+	}
+	return rm, sim.scoreboard(nil), events, sim.interval
+}
+
+// spectateEvent arma un evento con el marcador y el tiempo restante.
+func (gs *gameServer) spectateEvent(rm *pb.RunningMatch, board []*pb.PlayerMatchStats, kind pb.SpectateEvent_Kind) *pb.SpectateEvent {
+	now := gs.wall.Now().UnixMilli()
+	ev := &pb.SpectateEvent{
+		Kind:       kind,
+		MatchId:    rm.GetMatchId(),
+		GameMode:   rm.GetGameMode(),
+		Scoreboard: board,
+		UnixMs:     now,
+	}
+	if end := rm.GetEndsAtUnixMs(); end > now {
+		ev.RemainingMs = end - now
+	}
+	return ev
+}
@@ -5,6 +5,7 @@ go 1.23.0
 toolchain go1.24.4
 
 require (
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // detalles de error gRPC (RetryInfo)
 	google.golang.org/grpc v1.73.0 // gRPC para Go
 	google.golang.org/protobuf v1.36.6 // Runtime de Protocol Buffers
 )
@@ -13,5 +14,4 @@ require (
 	golang.org/x/net v0.38.0 // indirect
 	golang.org/x/sys v0.31.0 // indirect
 	golang.org/x/text v0.23.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20250324211829-b45e905df463 // indirect
 )
@@ -0,0 +1,126 @@
+// Package authtoken emite y verifica los tokens con los que un jugador
+// prueba que es dueño de su ID. Un token es
+//
+//	"<carga>.<firma>"
+//
+// con la carga en JSON ({"ns":…,"sub":…,"exp":…}) y la firma HMAC-SHA256
+// de la carga, ambas en base64url sin relleno. No se guarda nada del lado
+// del servidor: basta la clave para verificar, así que cualquier proceso con
+// la misma clave (p.ej. el respaldo) acepta los tokens del primario.
+//
+// Uso:
+//
+//	s := authtoken.NewSigner(secret, 24*time.Hour, time.Now)
+//	tok, claims := s.Issue("default", "Player7")
+//	claims, err := s.Verify(tok) // err == ErrExpired: firma válida pero vencido
+package authtoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Errores de Verify.
+var (
+	ErrMalformed = errors.New("token mal formado")
+	ErrSignature = errors.New("firma del token inválida")
+	ErrExpired   = errors.New("token vencido")
+)
+
+// Header es la clave de metadata gRPC en la que viaja el token.
+const Header = "x-player-token"
+
+var b64 = base64.RawURLEncoding
+
+// Claims es lo que certifica un token.
+type Claims struct {
+	Namespace string
+	PlayerID  string
+	ExpiresAt time.Time // con resolución de segundos
+}
+
+// payload es la forma serializada de Claims.
+type payload struct {
+	NS  string `json:"ns"`
+	Sub string `json:"sub"`
+	Exp int64  `json:"exp"` // segundos unix
+}
+
+// Signer firma y verifica tokens con una clave; es seguro para uso
+// concurrente porque no tiene estado mutable.
+type Signer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewSigner crea un firmante con la clave y la vigencia de los tokens.
+func NewSigner(key []byte, ttl time.Duration, now func() time.Time) *Signer {
+	return &Signer{key: append([]byte(nil), key...), ttl: ttl, now: now}
+}
+
+// NewKey genera una clave aleatoria de 32 bytes, para cuando no se configura
+// una: los tokens emitidos dejan de valer al reiniciar el proceso.
+func NewKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// TTL devuelve la vigencia de los tokens emitidos.
+func (s *Signer) TTL() time.Duration { return s.ttl }
+
+// Issue emite un token para playerID en namespace.
+func (s *Signer) Issue(namespace, playerID string) (string, Claims) {
+	p := payload{NS: namespace, Sub: playerID, Exp: s.now().Add(s.ttl).Unix()}
+	raw, _ := json.Marshal(p) // sólo strings y un entero: no falla
+	return b64.EncodeToString(raw) + "." + b64.EncodeToString(s.sign(raw)), p.claims()
+}
+
+// Verify comprueba la firma y la vigencia de token. Si la firma es válida
+// devuelve sus Claims aunque esté vencido (con ErrExpired), para que quien
+// lo presentó pueda renovarlo.
+func (s *Signer) Verify(token string) (Claims, error) {
+	enc, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrMalformed
+	}
+	raw, err := b64.DecodeString(enc)
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	mac, err := b64.DecodeString(sig)
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	if !hmac.Equal(mac, s.sign(raw)) {
+		return Claims{}, ErrSignature
+	}
+	var p payload
+	if err := json.Unmarshal(raw, &p); err != nil || p.Sub == "" {
+		return Claims{}, ErrMalformed
+	}
+	c := p.claims()
+	if !s.now().Before(c.ExpiresAt) {
+		return c, ErrExpired
+	}
+	return c, nil
+}
+
+func (p payload) claims() Claims {
+	return Claims{Namespace: p.NS, PlayerID: p.Sub, ExpiresAt: time.Unix(p.Exp, 0)}
+}
+
+func (s *Signer) sign(raw []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write(raw)
+	return h.Sum(nil)
+}
@@ -0,0 +1,294 @@
+// Package chaos inyecta fallos para probar la tolerancia a fallos del
+// Matchmaker y del GameServer: descarta RPCs entrantes, demora respuestas,
+// adelanta el reloj vectorial que viaja en ellas y hace caer el proceso.
+// Generaliza el CRASH_PROB original del GameServer, que sigue siendo su
+// probabilidad de caída.
+//
+// Todas las decisiones salen de un único generador pseudoaleatorio: con una
+// semilla (CHAOS_SEED, o la de AdminSetChaos) la misma secuencia de llamadas
+// produce los mismos fallos, así que un escenario del laboratorio se puede
+// repetir. Con RPCs concurrentes el orden en que consumen el generador puede
+// variar de una corrida a otra.
+//
+// La configuración se cambia en caliente con Set; el Matchmaker lo hace en
+// AdminSetChaos y reenvía la de los GameServers por el stream Heartbeat.
+//
+// Uso:
+//
+//	inj := chaos.FromConfig(cfg, "CHAOS_CRASH_PROB", 0)
+//	srv := grpc.NewServer(
+//		grpc.ChainUnaryInterceptor(inj.UnaryServerInterceptor(exempt, onCrash)),
+//		grpc.ChainStreamInterceptor(inj.StreamServerInterceptor(exempt)),
+//	)
+//	…
+//	if inj.Crash() {
+//		os.Exit(1)
+//	}
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/vimsent/L3/internal/config"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	// DefaultMaxDelay es la demora máxima si no se configura otra.
+	DefaultMaxDelay = 2 * time.Second
+	maxDelayLimit   = time.Minute
+	// cuánto puede adelantarse como mucho una entrada del reloj
+	maxClockSkew = 100
+)
+
+// Config fija la probabilidad (0-1) de cada fallo; todo en 0 = sin fallos.
+type Config struct {
+	DropProb  float64       // la RPC se descarta con UNAVAILABLE sin ejecutarse
+	DelayProb float64       // la RPC se demora entre 1 ms y MaxDelay
+	MaxDelay  time.Duration // 0 = DefaultMaxDelay
+	ClockProb float64       // el reloj de la respuesta llega adelantado
+	CrashProb float64       // el proceso se cae en el punto de caída del componente
+	Seed      int64         // != 0: reinicia el generador con esta semilla
+}
+
+// Enabled indica si se inyecta algún fallo.
+func (c Config) Enabled() bool {
+	return c.DropProb > 0 || c.DelayProb > 0 || c.ClockProb > 0 || c.CrashProb > 0
+}
+
+// Validate comprueba los rangos.
+func (c Config) Validate() error {
+	for _, p := range []struct {
+		name string
+		v    float64
+	}{{"drop", c.DropProb}, {"delay", c.DelayProb}, {"clock", c.ClockProb}, {"crash", c.CrashProb}} {
+		if p.v < 0 || p.v > 1 {
+			return fmt.Errorf("probabilidad %s %v fuera de [0,1]", p.name, p.v)
+		}
+	}
+	if c.MaxDelay < 0 || c.MaxDelay > maxDelayLimit {
+		return fmt.Errorf("demora máxima %v fuera de [0,%v]", c.MaxDelay, maxDelayLimit)
+	}
+	return nil
+}
+
+func (c Config) String() string {
+	if !c.Enabled() {
+		return "sin fallos"
+	}
+	s := fmt.Sprintf("drop=%.2f delay=%.2f(≤%v) clock=%.2f crash=%.2f", c.DropProb, c.DelayProb, c.maxDelay(), c.ClockProb, c.CrashProb)
+	if c.Seed != 0 {
+		s += fmt.Sprintf(" seed=%d", c.Seed)
+	}
+	return s
+}
+
+func (c Config) maxDelay() time.Duration {
+	if c.MaxDelay <= 0 {
+		return DefaultMaxDelay
+	}
+	return c.MaxDelay
+}
+
+// FromProto convierte la configuración recibida por gRPC.
+func FromProto(p *pb.ChaosConfig) Config {
+	return Config{
+		DropProb:  p.GetDropProb(),
+		DelayProb: p.GetDelayProb(),
+		MaxDelay:  time.Duration(p.GetMaxDelayMs()) * time.Millisecond,
+		ClockProb: p.GetClockProb(),
+		CrashProb: p.GetCrashProb(),
+		Seed:      p.GetSeed(),
+	}
+}
+
+// ToProto es la inversa de FromProto.
+func (c Config) ToProto() *pb.ChaosConfig {
+	return &pb.ChaosConfig{
+		DropProb:   c.DropProb,
+		DelayProb:  c.DelayProb,
+		MaxDelayMs: c.MaxDelay.Milliseconds(),
+		ClockProb:  c.ClockProb,
+		CrashProb:  c.CrashProb,
+		Seed:       c.Seed,
+	}
+}
+
+// FromConfig lee CHAOS_DROP_PROB, CHAOS_DELAY_PROB, CHAOS_MAX_DELAY,
+// CHAOS_CLOCK_PROB y CHAOS_SEED; la probabilidad de caída se lee de
+// crashKey, que cada componente nombra (el GameServer conserva CRASH_PROB).
+func FromConfig(cfg *config.Report, crashKey string, crashDef float64) *Injector {
+	return New(Config{
+		DropProb:  cfg.Float("CHAOS_DROP_PROB", 0, 0, 1),
+		DelayProb: cfg.Float("CHAOS_DELAY_PROB", 0, 0, 1),
+		MaxDelay:  cfg.Duration("CHAOS_MAX_DELAY", DefaultMaxDelay, time.Millisecond, maxDelayLimit),
+		ClockProb: cfg.Float("CHAOS_CLOCK_PROB", 0, 0, 1),
+		CrashProb: cfg.Float(crashKey, crashDef, 0, 1),
+		Seed:      int64(cfg.Int("CHAOS_SEED", 0, 0, 1<<31-1)),
+	})
+}
+
+// Injector decide qué fallos ocurren. Es seguro para uso concurrente.
+type Injector struct {
+	mu  sync.Mutex
+	cfg Config
+	rng *rand.Rand
+}
+
+// New crea un inyector; sin semilla, el generador se siembra con la hora.
+func New(cfg Config) *Injector {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Injector{cfg: cfg, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Set reemplaza la configuración; con semilla, la secuencia vuelve a empezar.
+func (i *Injector) Set(cfg Config) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.cfg = cfg
+	if cfg.Seed != 0 {
+		i.rng.Seed(cfg.Seed)
+	}
+}
+
+// Config devuelve la configuración vigente.
+func (i *Injector) Config() Config {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.cfg
+}
+
+// roll sortea un fallo de probabilidad p(cfg); con p = 0 no consume el
+// generador, para que activar un fallo no altere la secuencia de los demás
+// mientras esté apagado.
+func (i *Injector) roll(p func(Config) float64) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	prob := p(i.cfg)
+	return prob > 0 && i.rng.Float64() < prob
+}
+
+// Drop indica si descartar la RPC.
+func (i *Injector) Drop() bool {
+	return i.roll(func(c Config) float64 { return c.DropProb })
+}
+
+// Crash indica si el proceso debe caerse en este punto.
+func (i *Injector) Crash() bool {
+	return i.roll(func(c Config) float64 { return c.CrashProb })
+}
+
+// Delay devuelve cuánto demorar la RPC; 0 = nada.
+func (i *Injector) Delay() time.Duration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.cfg.DelayProb <= 0 || i.rng.Float64() >= i.cfg.DelayProb {
+		return 0
+	}
+	return time.Millisecond + time.Duration(i.rng.Int63n(int64(i.cfg.maxDelay())))
+}
+
+// CorruptClock devuelve vc o, si toca, una copia con una de sus entradas
+// adelantada entre 1 y 100: un reloj "del futuro" que el receptor no
+// debería poder satisfacer.
+func (i *Injector) CorruptClock(vc *pb.VectorClock) *pb.VectorClock {
+	if len(vc.GetCounters()) == 0 {
+		return vc
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.cfg.ClockProb <= 0 || i.rng.Float64() >= i.cfg.ClockProb {
+		return vc
+	}
+	ids := make([]string, 0, len(vc.GetCounters()))
+	for id := range vc.GetCounters() {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids) // el orden del mapa no es reproducible
+	out := proto.Clone(vc).(*pb.VectorClock)
+	out.Counters[ids[i.rng.Intn(len(ids))]] += int32(1 + i.rng.Intn(maxClockSkew))
+	return out
+}
+
+var vectorClockName = (&pb.VectorClock{}).ProtoReflect().Descriptor().FullName()
+
+// corruptResponse adelanta el campo vector_clock de la respuesta, si lo
+// tiene; trabaja sobre una copia, porque el handler puede devolver un
+// mensaje que también guarda.
+func (i *Injector) corruptResponse(resp interface{}) interface{} {
+	msg, ok := resp.(proto.Message)
+	if !ok || msg == nil {
+		return resp
+	}
+	fd := msg.ProtoReflect().Descriptor().Fields().ByName("vector_clock")
+	if fd == nil || fd.Message() == nil || fd.Message().FullName() != vectorClockName {
+		return resp
+	}
+	vc, _ := msg.ProtoReflect().Get(fd).Message().Interface().(*pb.VectorClock)
+	bad := i.CorruptClock(vc)
+	if bad == vc {
+		return resp
+	}
+	out := proto.Clone(msg)
+	out.ProtoReflect().Set(fd, protoreflect.ValueOfMessage(bad.ProtoReflect()))
+	return out
+}
+
+// UnaryServerInterceptor aplica los fallos a cada RPC que exempt no excluye
+// (nil = ninguna), en orden: descarte sin ejecutar el handler, demora antes
+// de ejecutarlo y reloj adelantado en la respuesta. Si onCrash no es nil,
+// el interceptor es además un punto de caída: tras el handler —el estado ya
+// cambió, pero el cliente no recibe respuesta— llama a onCrash, que no
+// debería volver.
+func (i *Injector) UnaryServerInterceptor(exempt func(method string) bool, onCrash func(method string)) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if exempt != nil && exempt(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if i.Drop() {
+			return nil, status.Error(codes.Unavailable, "chaos: RPC descartada")
+		}
+		if d := i.Delay(); d > 0 {
+			t := time.NewTimer(d)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+		resp, err := handler(ctx, req)
+		if onCrash != nil && i.Crash() {
+			onCrash(info.FullMethod)
+		}
+		if err != nil {
+			return resp, err
+		}
+		return i.corruptResponse(resp), nil
+	}
+}
+
+// StreamServerInterceptor descarta la apertura de streams no exentos con la
+// misma probabilidad que las RPC unarias; un stream abierto no se altera.
+func (i *Injector) StreamServerInterceptor(exempt func(method string) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if (exempt == nil || !exempt(info.FullMethod)) && i.Drop() {
+			return status.Error(codes.Unavailable, "chaos: stream descartado")
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,236 @@
+// Package clockpropagation lleva los relojes vectoriales en la metadata
+// gRPC, para que toda RPC propague causalidad sin declarar campos de reloj
+// en el .proto:
+//
+//   - el cliente envía su reloj en la metadata de la petición y fusiona el
+//     que el servidor devuelve en la cabecera de la respuesta (lo hace
+//     pkg/mmclient);
+//   - en el servidor, UnaryServer y StreamServer completan con el reloj de
+//     la metadata el campo clock (vector_clock en las peticiones del
+//     GameServer) de una petición que llega sin él, así que los handlers
+//     que ya lo leen no cambian; si el mensaje no tiene ese campo, el reloj
+//     va a Hooks.Merge;
+//   - la respuesta unaria devuelve el reloj en la cabecera: el de su campo
+//     vector_clock o, si no lo tiene, el de Hooks.Current.
+//
+// Los campos de los mensajes quedan como respaldo: los clientes y
+// servidores anteriores, que no conocen la cabecera, los siguen usando, y
+// un campo lleno manda sobre la metadata.
+//
+// La serialización es intercambiable (Codec): Text ("x-vector-clock",
+// "id=n,id=n", la de siempre, que entienden los servidores anteriores) o
+// Binary ("x-vector-clock-bin", el mensaje VectorClock en protobuf, más
+// compacto con muchos componentes). El servidor acepta las dos y responde
+// con la que usó el cliente.
+package clockpropagation
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// Codec serializa un reloj en una clave de metadata.
+type Codec interface {
+	Name() string
+	// Key es la clave de metadata; las que terminan en "-bin" viajan en
+	// binario (gRPC las codifica en base64).
+	Key() string
+	Encode(v *clocks.Vector) string
+	Decode(s string) (*clocks.Vector, error)
+}
+
+var (
+	// Text es "id=n,id=n" en "x-vector-clock".
+	Text Codec = textCodec{}
+	// Binary es el mensaje VectorClock en "x-vector-clock-bin".
+	Binary Codec = binaryCodec{}
+)
+
+// codecs en el orden en que se buscan en la metadata recibida.
+var codecs = []Codec{Binary, Text}
+
+// ByName devuelve el codec "text" o "binary"; nil si no existe.
+func ByName(name string) Codec {
+	for _, c := range codecs {
+		if c.Name() == name {
+			return c
+		}
+	}
+	return nil
+}
+
+type textCodec struct{}
+
+func (textCodec) Name() string                   { return "text" }
+func (textCodec) Key() string                    { return "x-vector-clock" }
+func (textCodec) Encode(v *clocks.Vector) string { return v.String() }
+
+func (textCodec) Decode(s string) (*clocks.Vector, error) {
+	v := clocks.New()
+	if err := v.FromString(s); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type binaryCodec struct{}
+
+func (binaryCodec) Name() string { return "binary" }
+func (binaryCodec) Key() string  { return "x-vector-clock-bin" }
+
+func (binaryCodec) Encode(v *clocks.Vector) string {
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(v.ToProto())
+	return string(b)
+}
+
+func (binaryCodec) Decode(s string) (*clocks.Vector, error) {
+	var p pb.VectorClock
+	if err := proto.Unmarshal([]byte(s), &p); err != nil {
+		return nil, err
+	}
+	return clocks.FromProto(&p), nil
+}
+
+// FromMetadata devuelve el reloj de md y el codec con que vino; nil si no
+// trae ninguno. Uno mal formado se ignora, como si no se hubiera enviado.
+func FromMetadata(md metadata.MD) (*clocks.Vector, Codec) {
+	for _, c := range codecs {
+		if v := md.Get(c.Key()); len(v) > 0 {
+			if vc, err := c.Decode(v[0]); err == nil {
+				return vc, c
+			}
+		}
+	}
+	return nil, nil
+}
+
+// FromIncoming es FromMetadata con la metadata recibida en ctx.
+func FromIncoming(ctx context.Context) (*clocks.Vector, Codec) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return FromMetadata(md)
+}
+
+// Hooks conectan los interceptores con el reloj del servidor; los dos son
+// opcionales.
+type Hooks struct {
+	// Merge recibe el reloj de una petición cuyo mensaje no tiene campo de
+	// reloj.
+	Merge func(ctx context.Context, req interface{}, remote *clocks.Vector)
+	// Current devuelve el reloj de la cabecera cuando la respuesta no tiene
+	// campo vector_clock; nil = no enviarlo.
+	Current func(ctx context.Context, req interface{}) *clocks.Vector
+}
+
+// UnaryServer propaga el reloj de las RPC unarias. Va al final de la
+// cadena, junto al handler, para ver la respuesta antes de que otro
+// interceptor la convierta en error.
+func UnaryServer(h Hooks) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		remote, codec := FromIncoming(ctx)
+		if remote != nil && !fillRequest(req, remote) && h.Merge != nil {
+			h.Merge(ctx, req, remote)
+		}
+		resp, err := handler(ctx, req)
+		if codec == nil {
+			// el cliente no envía el reloj en la metadata: tampoco lo leerá
+			return resp, err
+		}
+		local := clockField(resp, "vector_clock")
+		if local == nil && h.Current != nil {
+			local = h.Current(ctx, req)
+		}
+		if local != nil {
+			grpc.SetHeader(ctx, metadata.Pairs(codec.Key(), codec.Encode(local)))
+		}
+		return resp, err
+	}
+}
+
+// StreamServer completa el reloj de los mensajes que recibe un stream. Los
+// mensajes que envía el servidor llevan su propio vector_clock.
+func StreamServer(h Hooks) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		remote, _ := FromIncoming(ss.Context())
+		if remote == nil {
+			return handler(srv, ss)
+		}
+		return handler(srv, &recvStream{ServerStream: ss, remote: remote, hooks: h})
+	}
+}
+
+type recvStream struct {
+	grpc.ServerStream
+	remote *clocks.Vector
+	hooks  Hooks
+}
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if !fillRequest(m, s.remote) && s.hooks.Merge != nil {
+		s.hooks.Merge(s.Context(), m, s.remote)
+	}
+	return nil
+}
+
+// requestFields son los nombres del campo de reloj en las peticiones.
+var requestFields = []protoreflect.Name{"clock", "vector_clock"}
+
+// clockType es el descriptor de VectorClock.
+var clockType = (&pb.VectorClock{}).ProtoReflect().Descriptor()
+
+// field devuelve el campo VectorClock de nombre name de msg, o nil.
+func field(msg proto.Message, name protoreflect.Name) protoreflect.FieldDescriptor {
+	fd := msg.ProtoReflect().Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.MessageKind || fd.Message().FullName() != clockType.FullName() {
+		return nil
+	}
+	return fd
+}
+
+// fillRequest pone remote en el campo de reloj de req si llegó vacío.
+// Devuelve false si req no tiene campo de reloj.
+func fillRequest(req interface{}, remote *clocks.Vector) bool {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return false
+	}
+	for _, name := range requestFields {
+		fd := field(msg, name)
+		if fd == nil {
+			continue
+		}
+		r := msg.ProtoReflect()
+		if vc, _ := r.Get(fd).Message().Interface().(*pb.VectorClock); len(vc.GetCounters()) == 0 {
+			r.Set(fd, protoreflect.ValueOfMessage(remote.ToProto().ProtoReflect()))
+		}
+		return true
+	}
+	return false
+}
+
+// clockField devuelve el reloj del campo name de msg; nil si no lo tiene o
+// está vacío.
+func clockField(msg interface{}, name protoreflect.Name) *clocks.Vector {
+	m, ok := msg.(proto.Message)
+	if !ok || m == nil || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	fd := field(m, name)
+	if fd == nil {
+		return nil
+	}
+	vc, _ := m.ProtoReflect().Get(fd).Message().Interface().(*pb.VectorClock)
+	if len(vc.GetCounters()) == 0 {
+		return nil
+	}
+	return clocks.FromProto(vc)
+}
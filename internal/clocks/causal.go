@@ -0,0 +1,234 @@
+package clocks
+
+import (
+	"sync"
+	"time"
+)
+
+// Outcome es lo que Receive hizo con un evento.
+type Outcome int
+
+const (
+	Delivered Outcome = iota // entregado, quizá junto con retenidos que destrabó
+	Buffered                 // retenido hasta que lleguen sus predecesores
+	Duplicate                // repite el último entregado del emisor
+	Stale                    // anterior al último entregado del emisor
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Delivered:
+		return "delivered"
+	case Buffered:
+		return "buffered"
+	case Duplicate:
+		return "duplicate"
+	case Stale:
+		return "stale"
+	}
+	return "unknown"
+}
+
+// Event es un evento de Sender con su reloj; Payload es del llamador.
+type Event struct {
+	Sender  string
+	Clock   *Vector
+	Payload interface{}
+	At      time.Time // llegada; la usa Expire
+}
+
+// Causal es un buffer de entrega causal, seguro para uso concurrente:
+// retiene los eventos que llegan antes que alguno de sus predecesores y los
+// entrega en orden causal. Cada evento viene de un emisor y trae su reloj;
+// es entregable cuando
+//
+//	reloj[emisor] == entregados[emisor] + 1   (es el siguiente del emisor)
+//	reloj[k]      <= entregados[k]            (para cada otro emisor k conocido)
+//
+// Los componentes de quien no es emisor (el propio receptor, por ejemplo)
+// no se comprueban. Para que un emisor nuevo o reiniciado no quede trabado:
+//
+//   - el primer evento de un emisor desconocido se entrega y fija su punto
+//     de partida;
+//   - reloj[emisor] == 1 indica que el emisor se reinició: se descartan sus
+//     eventos retenidos y su cuenta vuelve a empezar; los valores de la
+//     encarnación anterior siguen valiendo como piso para las dependencias
+//     de los demás;
+//   - reloj[emisor] == entregados[emisor] repite el último entregado
+//     (Duplicate) y uno menor es viejo (Stale): ninguno se retiene;
+//   - un evento retenido más de maxDelay se entrega igual (Expire): sus
+//     predecesores se dan por perdidos.
+type Causal struct {
+	mu        sync.Mutex
+	maxDelay  time.Duration
+	delivered map[string]int64 // emisor → último valor entregado
+	floor     map[string]int64 // emisor → máximo de encarnaciones anteriores
+	pending   []Event
+}
+
+// NewCausal crea un buffer que retiene cada evento a lo sumo maxDelay.
+func NewCausal(maxDelay time.Duration) *Causal {
+	return &Causal{
+		maxDelay:  maxDelay,
+		delivered: make(map[string]int64),
+		floor:     make(map[string]int64),
+	}
+}
+
+// Receive recibe ev y devuelve, en orden causal, los eventos que quedaron
+// entregables: ev primero (si lo es) y luego los retenidos que destrabó.
+// Un evento sin valor para su emisor no lleva orden y se entrega tal cual.
+func (c *Causal) Receive(ev Event) (Outcome, []Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := ev.Clock.Get(ev.Sender)
+	if n == 0 {
+		return Delivered, []Event{ev}
+	}
+	d, known := c.delivered[ev.Sender]
+	switch {
+	case !known:
+		c.delivered[ev.Sender] = n
+		return Delivered, append([]Event{ev}, c.drain()...)
+	case n == 1 && d > 1:
+		// reinicio: la encarnación anterior ya no enviará nada útil
+		if d > c.floor[ev.Sender] {
+			c.floor[ev.Sender] = d
+		}
+		c.dropPending(ev.Sender)
+		c.delivered[ev.Sender] = 0
+	case n == d:
+		return Duplicate, nil
+	case n < d:
+		return Stale, nil
+	}
+	if !c.ready(ev) {
+		c.pending = append(c.pending, ev)
+		return Buffered, nil
+	}
+	c.delivered[ev.Sender] = n
+	return Delivered, append([]Event{ev}, c.drain()...)
+}
+
+// Expire entrega los eventos retenidos desde hace más de maxDelay, dando
+// por perdidos sus predecesores, junto con los que eso destrabe. forced
+// cuenta los entregados por vencimiento.
+func (c *Causal) Expire(now time.Time) (out []Event, forced int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		i := c.oldestExpired(now)
+		if i < 0 {
+			return out, forced
+		}
+		// dentro del emisor, el de menor valor primero: los demás siguen en orden
+		for j, p := range c.pending {
+			if p.Sender == c.pending[i].Sender && p.Clock.Get(p.Sender) < c.pending[i].Clock.Get(p.Sender) {
+				i = j
+			}
+		}
+		ev := c.pending[i]
+		c.pending = append(c.pending[:i], c.pending[i+1:]...)
+		c.delivered[ev.Sender] = ev.Clock.Get(ev.Sender)
+		out = append(out, ev)
+		out = append(out, c.drain()...)
+		forced++
+	}
+}
+
+// Forget olvida a sender (se fue del sistema) y descarta sus retenidos. Su
+// último valor queda como piso, como en un reinicio.
+func (c *Causal) Forget(sender string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d := c.delivered[sender]; d > c.floor[sender] {
+		c.floor[sender] = d
+	}
+	delete(c.delivered, sender)
+	c.dropPending(sender)
+}
+
+// Pending devuelve cuántos eventos hay retenidos.
+func (c *Causal) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pending)
+}
+
+// ready indica si ev es entregable. Debe llamarse con c.mu bloqueado.
+func (c *Causal) ready(ev Event) bool {
+	if ev.Clock.Get(ev.Sender) != c.delivered[ev.Sender]+1 {
+		return false
+	}
+	ids, values := ev.Clock.ToSlice()
+	for i, id := range ids {
+		if id == ev.Sender {
+			continue
+		}
+		d, known := c.delivered[id]
+		if !known {
+			continue
+		}
+		if f := c.floor[id]; f > d {
+			d = f
+		}
+		if values[i] > d {
+			return false
+		}
+	}
+	return true
+}
+
+// drain entrega los retenidos que quedaron entregables, hasta que no quede
+// ninguno, y descarta los que quedaron viejos. Debe llamarse con c.mu
+// bloqueado.
+func (c *Causal) drain() []Event {
+	var out []Event
+	for progress := true; progress; {
+		progress = false
+		kept := c.pending[:0]
+		for _, p := range c.pending {
+			switch {
+			case p.Clock.Get(p.Sender) <= c.delivered[p.Sender]:
+				// un vencimiento lo dejó atrás
+			case c.ready(p):
+				c.delivered[p.Sender] = p.Clock.Get(p.Sender)
+				out = append(out, p)
+				progress = true
+			default:
+				kept = append(kept, p)
+			}
+		}
+		c.pending = kept
+	}
+	return out
+}
+
+// dropPending descarta los retenidos de sender. Debe llamarse con c.mu
+// bloqueado.
+func (c *Causal) dropPending(sender string) {
+	kept := c.pending[:0]
+	for _, p := range c.pending {
+		if p.Sender != sender {
+			kept = append(kept, p)
+		}
+	}
+	c.pending = kept
+}
+
+// oldestExpired devuelve el índice del retenido más antiguo con más de
+// maxDelay, o -1. Debe llamarse con c.mu bloqueado.
+func (c *Causal) oldestExpired(now time.Time) int {
+	best := -1
+	for i, p := range c.pending {
+		if now.Sub(p.At) < c.maxDelay {
+			continue
+		}
+		if best < 0 || p.At.Before(c.pending[best].At) {
+			best = i
+		}
+	}
+	return best
+}
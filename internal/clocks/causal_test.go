@@ -0,0 +1,317 @@
+// internal/clocks/causal_test.go
+//
+// Causal: entrega en orden aunque los eventos lleguen desordenados (del
+// mismo emisor o con dependencias entre emisores), duplicados y viejos que
+// no se retienen ni se entregan dos veces, reinicios, vencimiento y varios
+// emisores concurrentes (correr con -race).
+
+package clocks
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var t0 = time.Unix(1_700_000_000, 0)
+
+// ev arma un evento de sender con el reloj "id=n,…"; el payload es su
+// nombre, p.ej. "A2".
+func ev(t *testing.T, sender, clock string) Event {
+	t.Helper()
+	v := New()
+	if err := v.FromString(clock); err != nil {
+		t.Fatalf("reloj %q: %v", clock, err)
+	}
+	return Event{Sender: sender, Clock: v, Payload: fmt.Sprintf("%s%d", sender, v.Get(sender)), At: t0}
+}
+
+func names(evs []Event) string {
+	out := make([]string, len(evs))
+	for i, e := range evs {
+		out[i] = e.Payload.(string)
+	}
+	return strings.Join(out, ",")
+}
+
+// step es una llegada y lo que se espera de ella.
+type step struct {
+	sender, clock string
+	outcome       Outcome
+	delivered     string // payloads entregados, en orden
+}
+
+func run(t *testing.T, c *Causal, steps []step) {
+	t.Helper()
+	for i, s := range steps {
+		got, out := c.Receive(ev(t, s.sender, s.clock))
+		if got != s.outcome || names(out) != s.delivered {
+			t.Fatalf("paso %d (%s %s): %v [%s], se esperaba %v [%s]",
+				i, s.sender, s.clock, got, names(out), s.outcome, s.delivered)
+		}
+	}
+}
+
+func TestCausalReceive(t *testing.T) {
+	cases := []struct {
+		name    string
+		steps   []step
+		pending int
+	}{
+		{"en orden", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=2", Delivered, "A2"},
+			{"A", "A=3", Delivered, "A3"},
+		}, 0},
+		{"desordenado del mismo emisor", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=4", Buffered, ""},
+			{"A", "A=3", Buffered, ""},
+			{"A", "A=2", Delivered, "A2,A3,A4"},
+		}, 0},
+		{"espera a otro emisor", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"B", "B=1", Delivered, "B1"},
+			{"B", "A=2,B=2", Buffered, ""}, // B vio A2, que aún no llegó
+			{"A", "A=2", Delivered, "A2,B2"},
+		}, 0},
+		{"cadena entre emisores", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"B", "B=1", Delivered, "B1"},
+			{"C", "C=1", Delivered, "C1"},
+			{"C", "A=2,B=2,C=2", Buffered, ""},
+			{"B", "A=2,B=2", Buffered, ""},
+			{"A", "A=2", Delivered, "A2,B2,C2"},
+		}, 0},
+		{"sin valor del emisor", []step{
+			{"A", "B=4", Delivered, "A0"},
+		}, 0},
+		{"componentes de desconocidos no se esperan", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=2,MM=9", Delivered, "A2"},
+		}, 0},
+		{"duplicado y viejo", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=2", Delivered, "A2"},
+			{"A", "A=3", Delivered, "A3"},
+			{"A", "A=3", Duplicate, ""},
+			{"A", "A=2", Stale, ""}, // A=1 sería un reinicio
+		}, 0},
+		{"duplicado de un retenido", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=3", Buffered, ""},
+			{"A", "A=3", Buffered, ""},
+			{"A", "A=2", Delivered, "A2,A3"}, // la copia se descarta
+			{"A", "A=3", Duplicate, ""},
+		}, 0},
+		{"primer evento de un desconocido fija el punto de partida", []step{
+			{"A", "A=7", Delivered, "A7"},
+			{"A", "A=9", Buffered, ""},
+			{"A", "A=8", Delivered, "A8,A9"},
+		}, 0},
+		{"reinicio descarta los retenidos", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=2", Delivered, "A2"},
+			{"A", "A=5", Buffered, ""},
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=2", Delivered, "A2"},
+		}, 0},
+		{"el piso del reinicio vale para los demás", []step{
+			{"A", "A=1", Delivered, "A1"},
+			{"A", "A=2", Delivered, "A2"},
+			{"A", "A=3", Delivered, "A3"},
+			{"B", "B=1", Delivered, "B1"},
+			{"A", "A=1", Delivered, "A1"},     // A se reinicia
+			{"B", "A=3,B=2", Delivered, "B2"}, // A3 ya se entregó antes
+			{"B", "A=4,B=3", Buffered, ""},    // A4 no existe aún
+			{"A", "A=2", Delivered, "A2"},     // ni A2 de la nueva encarnación lo destraba
+		}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewCausal(time.Minute)
+			run(t, c, tc.steps)
+			if c.Pending() != tc.pending {
+				t.Fatalf("Pending = %d, se esperaba %d", c.Pending(), tc.pending)
+			}
+		})
+	}
+}
+
+func TestCausalExpire(t *testing.T) {
+	c := NewCausal(time.Second)
+	run(t, c, []step{
+		{"A", "A=1", Delivered, "A1"},
+		{"B", "B=1", Delivered, "B1"},
+		{"A", "A=4", Buffered, ""},
+		{"A", "A=3", Buffered, ""},
+		{"B", "A=4,B=2", Buffered, ""},
+	})
+	if out, forced := c.Expire(t0.Add(time.Second - time.Millisecond)); len(out) != 0 || forced != 0 {
+		t.Fatalf("Expire antes de maxDelay entregó %s", names(out))
+	}
+	// A2 se da por perdido: sale A3 (el menor de A) y destraba A4 y B2
+	out, forced := c.Expire(t0.Add(time.Second))
+	if names(out) != "A3,A4,B2" || forced != 1 {
+		t.Fatalf("Expire = [%s] (%d forzados), se esperaba [A3,A4,B2] (1)", names(out), forced)
+	}
+	if c.Pending() != 0 {
+		t.Fatalf("Pending = %d tras Expire", c.Pending())
+	}
+	run(t, c, []step{{"A", "A=2", Stale, ""}})
+}
+
+func TestCausalForget(t *testing.T) {
+	c := NewCausal(time.Minute)
+	run(t, c, []step{
+		{"A", "A=1", Delivered, "A1"},
+		{"A", "A=2", Delivered, "A2"},
+		{"A", "A=4", Buffered, ""},
+		{"B", "B=1", Delivered, "B1"},
+	})
+	c.Forget("A")
+	if c.Pending() != 0 {
+		t.Fatalf("Forget dejó %d retenidos de A", c.Pending())
+	}
+	// A vuelve como desconocido; su último valor sigue como piso para B
+	run(t, c, []step{
+		{"B", "A=2,B=2", Delivered, "B2"},
+		{"A", "A=1", Delivered, "A1"},
+	})
+}
+
+// history simula procesos que se envían eventos: antes de cada envío el
+// emisor puede fusionar un evento ya enviado por otro. Cada proceso envía
+// primero un evento sin dependencias, para que el receptor lo conozca
+// (el primer evento de un desconocido se entrega sin comprobar nada).
+func history(rng *rand.Rand, senders []string, events int) (first, rest []Event) {
+	clocks := make(map[string]*Vector, len(senders))
+	var sent []Event
+	for _, s := range senders {
+		clocks[s] = New()
+		clocks[s].Tick(s)
+		e := Event{Sender: s, Clock: clocks[s].Copy(), Payload: fmt.Sprintf("%s1", s), At: t0}
+		first = append(first, e)
+		sent = append(sent, e)
+	}
+	for i := 0; i < events; i++ {
+		s := senders[rng.Intn(len(senders))]
+		if other := sent[rng.Intn(len(sent))]; other.Sender != s && rng.Intn(2) == 0 {
+			clocks[s].Merge(other.Clock)
+		}
+		n := clocks[s].Tick(s)
+		e := Event{Sender: s, Clock: clocks[s].Copy(), Payload: fmt.Sprintf("%s%d", s, n), At: t0}
+		rest = append(rest, e)
+		sent = append(sent, e)
+	}
+	return first, rest
+}
+
+// checkCausalOrder verifica que cada evento salga una vez y después de
+// todos los que lo preceden.
+func checkCausalOrder(t *testing.T, all, delivered []Event) {
+	t.Helper()
+	if len(delivered) != len(all) {
+		t.Fatalf("se entregaron %d eventos de %d", len(delivered), len(all))
+	}
+	pos := make(map[string]int, len(delivered))
+	for i, e := range delivered {
+		name := e.Payload.(string)
+		if _, dup := pos[name]; dup {
+			t.Fatalf("%s entregado dos veces", name)
+		}
+		pos[name] = i
+	}
+	for i, a := range all {
+		for j, b := range all {
+			if i != j && a.Clock.HappensBefore(b.Clock) && pos[a.Payload.(string)] > pos[b.Payload.(string)] {
+				t.Fatalf("%s (%s) salió después de %s (%s)", a.Payload, a.Clock, b.Payload, b.Clock)
+			}
+		}
+	}
+}
+
+// Llegadas en cualquier orden, con duplicados, de varios emisores: se
+// entrega todo una vez y en orden causal.
+func TestCausalShuffledArrivals(t *testing.T) {
+	senders := []string{"A", "B", "C"}
+	for seed := int64(1); seed <= 20; seed++ {
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(seed))
+			first, rest := history(rng, senders, 60)
+			arrivals := append([]Event(nil), rest...)
+			for i := 0; i < 10; i++ { // duplicados de la red
+				arrivals = append(arrivals, rest[rng.Intn(len(rest))])
+			}
+			rng.Shuffle(len(arrivals), func(i, j int) { arrivals[i], arrivals[j] = arrivals[j], arrivals[i] })
+
+			c := NewCausal(time.Minute)
+			var delivered []Event
+			for _, e := range append(first, arrivals...) {
+				_, out := c.Receive(e)
+				delivered = append(delivered, out...)
+			}
+			if c.Pending() != 0 {
+				t.Fatalf("quedaron %d retenidos", c.Pending())
+			}
+			checkCausalOrder(t, append(first, rest...), delivered)
+		})
+	}
+}
+
+// Emisores concurrentes, cada uno en su goroutine y en su orden: nada se
+// pierde ni se repite, y cada tanda que devuelve Receive está en orden.
+func TestCausalConcurrentSenders(t *testing.T) {
+	senders := []string{"A", "B", "C", "D"}
+	rng := rand.New(rand.NewSource(7))
+	first, rest := history(rng, senders, 400)
+
+	c := NewCausal(time.Minute)
+	var mu sync.Mutex
+	var delivered []Event
+	for _, e := range first {
+		_, out := c.Receive(e)
+		delivered = append(delivered, out...)
+	}
+	var wg sync.WaitGroup
+	for _, s := range senders {
+		var own []Event
+		for _, e := range rest {
+			if e.Sender == s {
+				own = append(own, e)
+			}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, e := range own {
+				_, out := c.Receive(e)
+				for i := 1; i < len(out); i++ {
+					if out[i].Clock.HappensBefore(out[i-1].Clock) {
+						t.Errorf("tanda fuera de orden: %s", names(out))
+					}
+				}
+				mu.Lock()
+				delivered = append(delivered, out...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if c.Pending() != 0 {
+		t.Fatalf("quedaron %d retenidos", c.Pending())
+	}
+	seen := make(map[string]bool, len(delivered))
+	for _, e := range delivered {
+		if seen[e.Payload.(string)] {
+			t.Fatalf("%s entregado dos veces", e.Payload)
+		}
+		seen[e.Payload.(string)] = true
+	}
+	if len(seen) != len(first)+len(rest) {
+		t.Fatalf("se entregaron %d eventos de %d", len(seen), len(first)+len(rest))
+	}
+}
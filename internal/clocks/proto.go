@@ -0,0 +1,55 @@
+package clocks
+
+import (
+	pb "github.com/vimsent/L3/proto"
+)
+
+// ToProto serializa los componentes vivos al mensaje VectorClock; las
+// lápidas no viajan (ver TombstonesToProto).
+func (v *Vector) ToProto() *pb.VectorClock {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	out := &pb.VectorClock{Counters: make(map[string]int32, len(v.clock))}
+	for id, val := range v.clock {
+		out.Counters[id] = int32(val)
+	}
+	return out
+}
+
+// FromProto reconstruye un reloj a partir del mensaje VectorClock. Un
+// mensaje nil da un reloj vacío.
+func FromProto(p *pb.VectorClock) *Vector {
+	v := New()
+	for id, val := range p.GetCounters() {
+		v.clock[id] = int64(val)
+	}
+	return v
+}
+
+// TombstonesToProto serializa las lápidas (id → último valor), para que una
+// réplica del reloj siga sin resucitar los componentes podados.
+func (v *Vector) TombstonesToProto() *pb.VectorClock {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	out := &pb.VectorClock{Counters: make(map[string]int32, len(v.tombs))}
+	for id, t := range v.tombs {
+		out.Counters[id] = int32(t.value)
+	}
+	return out
+}
+
+// RestoreTombstones agrega las lápidas serializadas con TombstonesToProto,
+// salvo las de componentes vivos con un valor igual o mayor. Cuentan para
+// el TTL desde la próxima poda.
+func (v *Vector) RestoreTombstones(p *pb.VectorClock) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for id, val := range p.GetCounters() {
+		if cur, ok := v.clock[id]; ok && cur >= int64(val) {
+			continue
+		}
+		delete(v.clock, id)
+		delete(v.seen, id)
+		v.tombs[id] = tombstone{value: int64(val)}
+	}
+}
@@ -1,60 +1,184 @@
 // Package clocks implementa un reloj vectorial ligero, thread-safe y
 // fácil de serializar a una slice de enteros o a un string “k=v,k=v,…”.
+//
+// Para que el reloj no crezca sin límite a medida que entran y salen
+// actores, Prune descarta los componentes que no avanzan hace más de un TTL.
+// Cada componente descartado deja una lápida con su último valor: un merge
+// que traiga ese valor (o uno menor) no lo resucita, y la comparación sigue
+// usándolo como si el componente estuviera presente. Sólo un valor mayor —el
+// actor volvió a estar activo— reemplaza a la lápida. Las lápidas, a su vez,
+// se olvidan tras otro TTL.
+//
+// El reloj no consulta la hora: toda la cuenta del TTL usa la hora que
+// recibe Prune. Cada pasada anota el valor de cada componente y, si cambió
+// desde la anterior, lo da por avanzado en ese momento; así un reloj sólo
+// necesita la hora de quien lo poda, con la resolución del período de poda.
 package clocks
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Vector almacena el reloj en un mapa id->contador y un mutex para concurrencia.
 type Vector struct {
 	mu    sync.Mutex
 	clock map[string]int64
+	seen  map[string]mark      // valor de cada componente en la última poda
+	tombs map[string]tombstone // componentes descartados por Prune
+}
+
+// mark es el valor de un componente visto por Prune y desde cuándo lo tiene.
+type mark struct {
+	value int64
+	since time.Time
+}
+
+// tombstone recuerda el último valor de un componente descartado; at es la
+// poda que lo descartó (cero si aún no pasó ninguna, tras RestoreTombstones).
+type tombstone struct {
+	value int64
+	at    time.Time
 }
 
 // New crea un reloj con todos los ids inicializados en 0.
 func New(ids ...string) *Vector {
-	c := make(map[string]int64, len(ids))
+	v := &Vector{
+		clock: make(map[string]int64, len(ids)),
+		seen:  make(map[string]mark),
+		tombs: make(map[string]tombstone),
+	}
 	for _, id := range ids {
-		c[id] = 0
+		v.clock[id] = 0
 	}
-	return &Vector{clock: c}
+	return v
 }
 
 // Copy devuelve una copia profunda (independiente) del reloj.
 func (v *Vector) Copy() *Vector {
 	v.mu.Lock()
 	defer v.mu.Unlock()
-	out := make(map[string]int64, len(v.clock))
+	out := New()
 	for k, val := range v.clock {
-		out[k] = val
+		out.clock[k] = val
 	}
-	return &Vector{clock: out}
+	for k, t := range v.seen {
+		out.seen[k] = t
+	}
+	for k, t := range v.tombs {
+		out.tombs[k] = t
+	}
+	return out
 }
 
 // Tick incrementa el contador del id local y devuelve el nuevo valor.
 func (v *Vector) Tick(id string) int64 {
 	v.mu.Lock()
 	defer v.mu.Unlock()
-	v.clock[id]++
+	v.clock[id] = v.get(id) + 1
+	delete(v.tombs, id)
 	return v.clock[id]
 }
 
-// Merge fusiona otro reloj en el actual aplicando max por componente.
+// Merge fusiona otro reloj en el actual aplicando max por componente. Un
+// componente con lápida sólo vuelve si other trae un valor mayor.
 func (v *Vector) Merge(other *Vector) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
+	v.merge(other)
+}
+
+// MergeTick hace Merge(other) y Tick(id) en un solo paso: nadie ve el reloj
+// fusionado sin el avance de id. Devuelve el nuevo valor de id.
+func (v *Vector) MergeTick(other *Vector, id string) int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.merge(other)
+	v.clock[id] = v.get(id) + 1
+	delete(v.tombs, id)
+	return v.clock[id]
+}
+
+// merge es Merge con v.mu ya bloqueado.
+func (v *Vector) merge(other *Vector) {
 	other.mu.Lock()
 	defer other.mu.Unlock()
 
 	for id, val := range other.clock {
-		if cur, ok := v.clock[id]; !ok || val > cur {
+		if val > v.get(id) {
 			v.clock[id] = val
+			delete(v.tombs, id)
+		}
+	}
+}
+
+// get devuelve el valor efectivo de id: el del reloj o, si fue descartado,
+// el de su lápida. Debe llamarse con v.mu bloqueado.
+func (v *Vector) get(id string) int64 {
+	if val, ok := v.clock[id]; ok {
+		return val
+	}
+	return v.tombs[id].value
+}
+
+// Prune descarta los componentes que no avanzan desde hace más de ttl,
+// salvo los ids de keep (normalmente el propio), dejando una lápida por
+// cada uno; también olvida las lápidas con más de ttl. Un componente cuenta
+// como avanzado en la primera pasada que lo ve con un valor nuevo, así que
+// Prune debe llamarse periódicamente y siempre con la misma fuente de hora.
+// Devuelve cuántos componentes descartó.
+func (v *Vector) Prune(now time.Time, ttl time.Duration, keep ...string) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	kept := make(map[string]bool, len(keep))
+	for _, id := range keep {
+		kept[id] = true
+	}
+	for id, t := range v.tombs {
+		switch {
+		case t.at.IsZero():
+			// restaurada: cuenta desde esta pasada
+			v.tombs[id] = tombstone{value: t.value, at: now}
+		case now.Sub(t.at) > ttl:
+			delete(v.tombs, id)
+		}
+	}
+	pruned := 0
+	for id, val := range v.clock {
+		m, ok := v.seen[id]
+		if !ok || m.value != val {
+			// nuevo o avanzado desde la pasada anterior
+			v.seen[id] = mark{value: val, since: now}
+			continue
+		}
+		if kept[id] || now.Sub(m.since) <= ttl {
+			continue
 		}
+		v.tombs[id] = tombstone{value: val, at: now}
+		delete(v.clock, id)
+		delete(v.seen, id)
+		pruned++
 	}
+	return pruned
+}
+
+// Len devuelve la cantidad de componentes vivos.
+func (v *Vector) Len() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.clock)
+}
+
+// Tombstones devuelve la cantidad de lápidas retenidas.
+func (v *Vector) Tombstones() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return len(v.tombs)
 }
 
 // HappensBefore devuelve true si v < other en el orden parcial de relojes
@@ -65,9 +189,11 @@ func (v *Vector) HappensBefore(other *Vector) bool {
 	other.mu.Lock()
 	defer other.mu.Unlock()
 
+	// los componentes descartados cuentan con el valor de su lápida; un id
+	// ausente en ambos lados vale 0
 	less := false
-	for id, val := range v.clock {
-		o := other.clock[id]
+	for _, id := range v.ids(other) {
+		val, o := v.get(id), other.get(id)
 		if val > o {
 			return false
 		}
@@ -75,16 +201,80 @@ func (v *Vector) HappensBefore(other *Vector) bool {
 			less = true
 		}
 	}
-	// Si other tiene ids que v no posee y alguno es >0, v también es menor.
-	for id, oval := range other.clock {
-		if _, ok := v.clock[id]; !ok && oval > 0 {
-			less = true
+	return less
+}
+
+// Equal indica si ambos relojes tienen el mismo valor efectivo (lápidas
+// incluidas) en todos los componentes.
+func (v *Vector) Equal(other *Vector) bool {
+	if v == other {
+		return true
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	other.mu.Lock()
+	defer other.mu.Unlock()
+
+	for _, id := range v.ids(other) {
+		if v.get(id) != other.get(id) {
+			return false
 		}
 	}
-	return less
+	return true
+}
+
+// Concurrent indica si ningún reloj precede al otro ni son iguales: cada
+// uno vio algún evento que el otro no.
+func (v *Vector) Concurrent(other *Vector) bool {
+	if v == other {
+		return false
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	other.mu.Lock()
+	defer other.mu.Unlock()
+
+	ahead, behind := false, false
+	for _, id := range v.ids(other) {
+		switch val, o := v.get(id), other.get(id); {
+		case val > o:
+			ahead = true
+		case val < o:
+			behind = true
+		}
+	}
+	return ahead && behind
+}
+
+// Get devuelve el valor efectivo de id (0 si no lo tiene).
+func (v *Vector) Get(id string) int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.get(id)
+}
+
+// ids devuelve los ids con valor en v u other, incluidas las lápidas.
+// Debe llamarse con ambos relojes bloqueados.
+func (v *Vector) ids(other *Vector) []string {
+	set := make(map[string]struct{}, len(v.clock)+len(other.clock))
+	for _, m := range []map[string]int64{v.clock, other.clock} {
+		for id := range m {
+			set[id] = struct{}{}
+		}
+	}
+	for _, m := range []map[string]tombstone{v.tombs, other.tombs} {
+		for id := range m {
+			set[id] = struct{}{}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for id := range set {
+		out = append(out, id)
+	}
+	return out
 }
 
-// String serializa a "id1=3,id2=1".
+// String serializa a "id1=3,id2=1", con los ids ordenados.
 func (v *Vector) String() string {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -92,6 +282,7 @@ func (v *Vector) String() string {
 	for id, val := range v.clock {
 		parts = append(parts, fmt.Sprintf("%s=%d", id, val))
 	}
+	sort.Strings(parts)
 	return strings.Join(parts, ",")
 }
 
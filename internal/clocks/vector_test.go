@@ -0,0 +1,121 @@
+// internal/clocks/vector_test.go
+//
+// Poda del reloj con la hora que recibe Prune: un componente cuenta como
+// activo en la primera pasada que lo ve con un valor nuevo, keep nunca se
+// poda, las lápidas impiden resucitar con un valor viejo y se olvidan tras
+// otro TTL, y las restauradas cuentan desde la próxima pasada.
+
+package clocks
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// pruneEvery llama a Prune cada step hasta cubrir d, como el bucle del
+// Matchmaker, y devuelve la hora final y cuántos componentes descartó.
+func pruneEvery(v *Vector, now time.Time, d, step, ttl time.Duration, keep ...string) (time.Time, int) {
+	pruned := 0
+	for end := now.Add(d); now.Before(end); {
+		now = now.Add(step)
+		pruned += v.Prune(now, ttl, keep...)
+	}
+	return now, pruned
+}
+
+func TestPruneByInactivity(t *testing.T) {
+	const ttl, step = time.Minute, 5 * time.Second
+	v := New()
+	v.Tick("MM")
+	v.Merge(FromProto(&pb.VectorClock{Counters: map[string]int32{"P1": 3, "P2": 1}}))
+	now := t0
+	v.Prune(now, ttl, "MM") // primera pasada: todo recién visto
+
+	now, _ = pruneEvery(v, now, 40*time.Second, step, ttl, "MM")
+	v.Merge(FromProto(&pb.VectorClock{Counters: map[string]int32{"P2": 2}})) // P2 avanza
+	now, pruned := pruneEvery(v, now, 30*time.Second, step, ttl, "MM")
+
+	// P1 lleva 70 s igual; P2 avanzó hace 30 s; MM está en keep
+	if pruned != 1 || v.Len() != 2 || v.Tombstones() != 1 {
+		t.Fatalf("podados %d, quedan %d y %d lápidas: %s", pruned, v.Len(), v.Tombstones(), v)
+	}
+	if v.Get("P1") != 3 {
+		t.Fatalf("la lápida de P1 vale %d, se esperaba 3", v.Get("P1"))
+	}
+
+	// un valor igual o menor no resucita a P1; uno mayor sí
+	v.Merge(FromProto(&pb.VectorClock{Counters: map[string]int32{"P1": 3}}))
+	if v.Len() != 2 {
+		t.Fatalf("P1 resucitó con su valor podado: %s", v)
+	}
+	v.Merge(FromProto(&pb.VectorClock{Counters: map[string]int32{"P1": 4}}))
+	if v.Len() != 3 || v.Tombstones() != 0 {
+		t.Fatalf("P1 con 4 no volvió: %s (%d lápidas)", v, v.Tombstones())
+	}
+
+	// sin avances, todo menos MM se poda (P1 cuenta desde la pasada que vio
+	// su 4); las lápidas duran otro TTL
+	now, _ = pruneEvery(v, now, ttl+3*step, step, ttl, "MM")
+	if v.Len() != 1 || v.Tombstones() != 2 {
+		t.Fatalf("tras un TTL sin avances: %s (%d lápidas)", v, v.Tombstones())
+	}
+	pruneEvery(v, now, ttl+step, step, ttl, "MM")
+	if v.Tombstones() != 0 || v.Get("P1") != 0 {
+		t.Fatalf("lápidas tras otro TTL: %d", v.Tombstones())
+	}
+}
+
+// Un componente que no se ve en ninguna pasada no se poda: la primera que
+// lo ve empieza su cuenta, aunque haya llegado hace mucho.
+func TestPruneCountsFromFirstPass(t *testing.T) {
+	v := FromProto(&pb.VectorClock{Counters: map[string]int32{"P1": 1}})
+	if n := v.Prune(t0.Add(time.Hour), time.Minute); n != 0 {
+		t.Fatalf("la primera pasada podó %d", n)
+	}
+	if n := v.Prune(t0.Add(time.Hour+time.Minute), time.Minute); n != 0 {
+		t.Fatalf("podado justo en el TTL: %d", n)
+	}
+	if n := v.Prune(t0.Add(time.Hour+time.Minute+time.Second), time.Minute); n != 1 {
+		t.Fatalf("no podado tras el TTL: %d", n)
+	}
+}
+
+func TestRestoredTombstonesCountFromNextPrune(t *testing.T) {
+	src := New()
+	src.Merge(FromProto(&pb.VectorClock{Counters: map[string]int32{"P1": 2}}))
+	src.Prune(t0, time.Minute)
+	src.Prune(t0.Add(2*time.Minute), time.Minute)
+	if src.Tombstones() != 1 {
+		t.Fatalf("origen sin lápida: %d", src.Tombstones())
+	}
+
+	dst := FromProto(src.ToProto())
+	dst.RestoreTombstones(src.TombstonesToProto())
+	if dst.Get("P1") != 2 || dst.Tombstones() != 1 {
+		t.Fatalf("lápida restaurada: P1=%d, %d lápidas", dst.Get("P1"), dst.Tombstones())
+	}
+	later := t0.Add(24 * time.Hour)
+	dst.Prune(later, time.Minute)
+	if dst.Tombstones() != 1 {
+		t.Fatal("la lápida restaurada se olvidó en la primera pasada")
+	}
+	dst.Prune(later.Add(2*time.Minute), time.Minute)
+	if dst.Tombstones() != 0 {
+		t.Fatal("la lápida restaurada no se olvidó tras el TTL")
+	}
+}
+
+// Copy conserva lo visto por Prune: la copia sigue la misma cuenta.
+func TestCopyKeepsPruneMarks(t *testing.T) {
+	v := FromProto(&pb.VectorClock{Counters: map[string]int32{"P1": 1}})
+	v.Prune(t0, time.Minute)
+	c := v.Copy()
+	if n := c.Prune(t0.Add(2*time.Minute), time.Minute); n != 1 {
+		t.Fatalf("la copia no heredó la marca de P1: podó %d", n)
+	}
+	if v.Len() != 1 {
+		t.Fatal("podar la copia tocó el original")
+	}
+}
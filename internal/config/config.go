@@ -0,0 +1,399 @@
+// Package config centraliza la lectura y validación de variables de entorno
+// de los binarios. Cada binario crea un Report, lee sus parámetros a través
+// de él y al final llama a MustValidate, que imprime un único resumen y
+// aborta el proceso si alguna variable es incoherente.
+//
+// Con UseFile los valores pueden venir también de un archivo JSON, que tiene
+// prioridad sobre el entorno; así un binario puede releerlo en caliente
+// creando un Report nuevo (ver file.go).
+//
+// Uso:
+//
+//	cfg := config.NewReport("gameserver")
+//	port := cfg.Port("PORT", 60051)
+//	mm := cfg.Matchmaker("MATCHMAKER_ADDR", "localhost:50051")
+//	cfg.MustValidate()
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vimsent/L3/internal/discovery"
+	slog "github.com/vimsent/L3/internal/log"
+)
+
+// Origen de cada valor mostrado en el resumen.
+const (
+	sourceEnv       = "env"
+	sourceDefault   = "default"
+	sourceGenerated = "generado"
+	sourceFile      = "archivo"
+)
+
+type entry struct {
+	key    string
+	value  string
+	source string
+}
+
+// Report acumula los valores leídos y los errores de validación.
+type Report struct {
+	component string
+	entries   []entry
+	errs      []string
+
+	file     map[string]string // valores de UseFile; nil = sólo entorno
+	used     map[string]bool   // claves del archivo consultadas
+	filePath string
+	checked  bool // claves del archivo ya verificadas (ver checkFile)
+}
+
+// NewReport crea un reporte vacío para el componente indicado.
+func NewReport(component string) *Report {
+	return &Report{component: component}
+}
+
+func (r *Report) record(key, value, source string) {
+	r.entries = append(r.entries, entry{key: key, value: value, source: source})
+}
+
+func (r *Report) fail(key, format string, a ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf("%s: %s", key, fmt.Sprintf(format, a...)))
+}
+
+// String devuelve la variable o def si no está definida.
+func (r *Report) String(key, def string) string {
+	if v, src := r.lookup(key); v != "" {
+		r.record(key, v, src)
+		return v
+	}
+	r.record(key, def, sourceDefault)
+	return def
+}
+
+// StringOr devuelve la variable o, si no está definida, el valor que
+// produzca gen (p.ej. un ID aleatorio).
+func (r *Report) StringOr(key string, gen func() string) string {
+	if v, src := r.lookup(key); v != "" {
+		r.record(key, v, src)
+		return v
+	}
+	v := gen()
+	r.record(key, v, sourceGenerated)
+	return v
+}
+
+// OneOf lee una variable que sólo admite los valores listados.
+func (r *Report) OneOf(key, def string, allowed ...string) string {
+	v := r.String(key, def)
+	for _, a := range allowed {
+		if v == a {
+			return v
+		}
+	}
+	r.fail(key, "%q no es válido (opciones: %s)", v, strings.Join(allowed, ", "))
+	return def
+}
+
+// Port lee un puerto TCP y exige que esté en [1, 65535].
+func (r *Report) Port(key string, def int) int {
+	raw, src := r.lookup(key)
+	if raw == "" {
+		r.record(key, strconv.Itoa(def), sourceDefault)
+		return def
+	}
+	r.record(key, raw, src)
+	p, err := strconv.Atoi(raw)
+	if err != nil {
+		r.fail(key, "%q no es un número de puerto", raw)
+		return def
+	}
+	if p < 1 || p > 65535 {
+		r.fail(key, "puerto %d fuera de rango (1-65535)", p)
+		return def
+	}
+	return p
+}
+
+// HostPort lee una dirección "host:puerto" y valida su formato.
+func (r *Report) HostPort(key, def string) string {
+	v := r.String(key, def)
+	if err := checkHostPort(v); err != nil {
+		r.fail(key, "%v (se esperaba host:puerto, p.ej. %s)", err, def)
+	}
+	return v
+}
+
+// Matchmaker lee cómo encontrar al Matchmaker: host:puerto, semillas
+// separadas por comas o "srv:<nombre>" (ver internal/discovery).
+func (r *Report) Matchmaker(key, def string) string {
+	v := r.String(key, def)
+	if _, err := discovery.Parse(v); err != nil {
+		r.fail(key, "%v (se esperaba host:puerto, h1:p1,h2:p2 o srv:_servicio._tcp.dominio)", err)
+	}
+	return v
+}
+
+// OptionalHostPort es como HostPort pero sin valor por defecto: devuelve ""
+// si la variable no está definida.
+func (r *Report) OptionalHostPort(key string) string {
+	v, src := r.lookup(key)
+	if v == "" {
+		return ""
+	}
+	r.record(key, v, src)
+	if err := checkHostPort(v); err != nil {
+		r.fail(key, "%v (se esperaba host:puerto)", err)
+	}
+	return v
+}
+
+// Int lee un entero y exige que esté en [min, max].
+func (r *Report) Int(key string, def, min, max int) int {
+	raw, src := r.lookup(key)
+	if raw == "" {
+		r.record(key, strconv.Itoa(def), sourceDefault)
+		return def
+	}
+	r.record(key, raw, src)
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		r.fail(key, "%q no es un entero", raw)
+		return def
+	}
+	if n < min || n > max {
+		r.fail(key, "%d fuera de rango [%d, %d]", n, min, max)
+		return def
+	}
+	return n
+}
+
+// Float lee un flotante y exige que esté en [min, max].
+func (r *Report) Float(key string, def, min, max float64) float64 {
+	raw, src := r.lookup(key)
+	if raw == "" {
+		r.record(key, strconv.FormatFloat(def, 'g', -1, 64), sourceDefault)
+		return def
+	}
+	r.record(key, raw, src)
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		r.fail(key, "%q no es un número", raw)
+		return def
+	}
+	if f < min || f > max {
+		r.fail(key, "%g fuera de rango [%g, %g]", f, min, max)
+		return def
+	}
+	return f
+}
+
+// Duration lee una duración en formato Go (p.ej. "5s", "250ms") y exige que
+// esté en [min, max].
+func (r *Report) Duration(key string, def, min, max time.Duration) time.Duration {
+	raw, src := r.lookup(key)
+	if raw == "" {
+		r.record(key, def.String(), sourceDefault)
+		return def
+	}
+	r.record(key, raw, src)
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		r.fail(key, "%q no es una duración (p.ej. 5s, 250ms)", raw)
+		return def
+	}
+	if d < min || d > max {
+		r.fail(key, "%v fuera de rango [%v, %v]", d, min, max)
+		return def
+	}
+	return d
+}
+
+// Weights lee una lista "clave=valor,clave=valor" de números ≥ 0. Las claves
+// no listadas conservan el valor de def.
+func (r *Report) Weights(key, def string) map[string]float64 {
+	out := make(map[string]float64)
+	r.Spec(key, def, func(raw string) error {
+		for _, part := range strings.Split(raw, ",") {
+			k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok {
+				return fmt.Errorf("%q no tiene forma clave=valor", part)
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil || f < 0 {
+				return fmt.Errorf("peso inválido en %q", part)
+			}
+			out[strings.TrimSpace(k)] = f
+		}
+		return nil
+	})
+	return out
+}
+
+// Spec lee una variable con un formato propio del componente. parse valida
+// el texto y guarda el resultado; se aplica primero a def, por lo que un
+// valor por defecto inválido es un bug y provoca panic.
+func (r *Report) Spec(key, def string, parse func(raw string) error) {
+	if err := parse(def); err != nil {
+		panic("config: valor por defecto inválido para " + key + ": " + err.Error())
+	}
+	raw := r.String(key, def)
+	if raw != def {
+		if err := parse(raw); err != nil {
+			r.fail(key, "%v", err)
+		}
+	}
+}
+
+// File lee una ruta opcional; si se define, el archivo debe existir.
+func (r *Report) File(key string) string {
+	v, src := r.lookup(key)
+	if v == "" {
+		return ""
+	}
+	r.record(key, v, src)
+	if st, err := os.Stat(v); err != nil {
+		r.fail(key, "no se puede leer %q: %v", v, err)
+	} else if st.IsDir() {
+		r.fail(key, "%q es un directorio, se esperaba un archivo", v)
+	}
+	return v
+}
+
+// TLSFiles reúne la configuración TLS común a todos los binarios.
+type TLSFiles struct {
+	Cert, Key  string // certificado propio (servidor, o cliente en mTLS)
+	CA         string // CA para verificar al otro extremo
+	ServerName string // nombre esperado en el certificado del servidor
+
+	RequireClientCert bool // mTLS: el servidor exige certificado de cliente
+}
+
+// Enabled indica si se configuró TLS.
+func (t TLSFiles) Enabled() bool { return t.Cert != "" || t.CA != "" }
+
+// TLS lee TLS_CERT, TLS_KEY, TLS_CA, TLS_SERVER_NAME y TLS_CLIENT_AUTH
+// (none | require) y valida que sean coherentes entre sí.
+func (r *Report) TLS() TLSFiles {
+	t := TLSFiles{
+		Cert: r.File("TLS_CERT"),
+		Key:  r.File("TLS_KEY"),
+		CA:   r.File("TLS_CA"),
+	}
+	if (t.Cert == "") != (t.Key == "") {
+		r.fail("TLS_CERT/TLS_KEY", "deben definirse juntos")
+	}
+	if !t.Enabled() {
+		return t
+	}
+	t.ServerName = strings.TrimSpace(os.Getenv("TLS_SERVER_NAME"))
+	if t.ServerName != "" {
+		r.record("TLS_SERVER_NAME", t.ServerName, sourceEnv)
+	}
+	t.RequireClientCert = r.OneOf("TLS_CLIENT_AUTH", "none", "none", "require") == "require"
+	if t.RequireClientCert && t.CA == "" {
+		r.fail("TLS_CLIENT_AUTH", "require necesita TLS_CA para verificar a los clientes")
+	}
+	return t
+}
+
+// LogFile reúne la configuración del archivo de log de un binario.
+type LogFile struct {
+	Path     string // "" = sólo salida estándar
+	MaxBytes int64  // tamaño a partir del cual rota
+	Backups  int    // archivos rotados que se conservan
+}
+
+// LogFile lee LOG_FILE, LOG_MAX_SIZE_MB y LOG_MAX_BACKUPS.
+func (r *Report) LogFile() LogFile {
+	l := LogFile{Path: r.String("LOG_FILE", "")}
+	if l.Path == "" {
+		return l
+	}
+	l.MaxBytes = int64(r.Int("LOG_MAX_SIZE_MB", 10, 1, 10240)) << 20
+	l.Backups = r.Int("LOG_MAX_BACKUPS", 5, 0, 100)
+	return l
+}
+
+// ClientSettings reúne la configuración común a los clientes del Matchmaker
+// (ver pkg/mmclient).
+type ClientSettings struct {
+	Token   string // token de autenticación; "" = sin autenticación
+	Retries int    // reintentos de las RPC que fallan con UNAVAILABLE
+}
+
+// Client lee AUTH_TOKEN y RPC_RETRIES. El token no se muestra en el resumen.
+func (r *Report) Client() ClientSettings {
+	c := ClientSettings{Token: r.Secret("AUTH_TOKEN")}
+	c.Retries = r.Int("RPC_RETRIES", 2, 0, 10)
+	return c
+}
+
+// Secret lee del entorno una clave que no se muestra en el resumen (ni se
+// admite en el archivo); "" si no está definida.
+func (r *Report) Secret(key string) string {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v != "" {
+		r.record(key, "********", sourceEnv)
+	}
+	return v
+}
+
+// Errors devuelve los errores de validación acumulados.
+func (r *Report) Errors() []string {
+	r.checkFile()
+	return r.errs
+}
+
+// Summary formatea el resumen "clave = valor (origen)" de todas las variables.
+func (r *Report) Summary() string {
+	width := 0
+	for _, e := range r.entries {
+		if len(e.key) > width {
+			width = len(e.key)
+		}
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "configuración de %s:", r.component)
+	for _, e := range r.entries {
+		fmt.Fprintf(&sb, "\n    %-*s = %s (%s)", width, e.key, e.value, e.source)
+	}
+	return sb.String()
+}
+
+// MustValidate imprime el resumen y termina el proceso con código 1 si hubo
+// errores, listando cada uno para que el usuario sepa qué corregir.
+func (r *Report) MustValidate() {
+	r.checkFile()
+	slog.Info("%s", r.Summary())
+	if len(r.errs) == 0 {
+		return
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "configuración inválida de %s:", r.component)
+	for _, e := range r.errs {
+		fmt.Fprintf(&sb, "\n    • %s", e)
+	}
+	slog.Error("%s", sb.String())
+	os.Exit(1)
+}
+
+func checkHostPort(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("falta el host en %q", addr)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("puerto inválido en %q", addr)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// UseFile carga un archivo JSON plano con los mismos nombres que las
+// variables de entorno:
+//
+//	{"QUEUE_AGING": "45s", "MATCH_HISTORY_LIMIT": 500, "ASSIGN_FAILOVER": "0"}
+//
+// Las lecturas posteriores toman el valor del archivo si lo define y, si no,
+// el del entorno. Una clave que ninguna lectura consulta se informa como
+// error al validar, para no ignorar en silencio una errata.
+func (r *Report) UseFile(path string) {
+	r.filePath = path
+	r.file = make(map[string]string)
+	r.used = make(map[string]bool)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		r.fail(path, "no se puede leer: %v", err)
+		return
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		r.fail(path, "JSON inválido: %v", err)
+		return
+	}
+	for k, v := range raw {
+		s, err := fileValue(v)
+		if err != nil {
+			r.fail(path, "%s: %v", k, err)
+			continue
+		}
+		r.file[k] = s
+	}
+}
+
+// fileValue convierte un valor del archivo en el texto que tendría la
+// variable de entorno: textos, números y booleanos (true = "1").
+func fileValue(v json.RawMessage) (string, error) {
+	var x interface{}
+	d := json.NewDecoder(strings.NewReader(string(v)))
+	d.UseNumber()
+	if err := d.Decode(&x); err != nil {
+		return "", err
+	}
+	switch x := x.(type) {
+	case string:
+		return strings.TrimSpace(x), nil
+	case json.Number:
+		return x.String(), nil
+	case bool:
+		if x {
+			return "1", nil
+		}
+		return "0", nil
+	}
+	return "", fmt.Errorf("se esperaba texto, número o booleano")
+}
+
+// lookup devuelve el valor de key y su origen; "" si no está definida.
+func (r *Report) lookup(key string) (string, string) {
+	if v, ok := r.file[key]; ok {
+		r.used[key] = true
+		if v != "" {
+			return v, sourceFile
+		}
+	}
+	return strings.TrimSpace(os.Getenv(key)), sourceEnv
+}
+
+// checkFile agrega un error por cada clave del archivo que nadie leyó.
+func (r *Report) checkFile() {
+	if r.checked || r.file == nil {
+		return
+	}
+	r.checked = true
+	var unknown []string
+	for k := range r.file {
+		if !r.used[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		r.fail(r.filePath, "clave desconocida o no admitida en el archivo: %s", k)
+	}
+}
+
+// Values devuelve el valor final de cada variable leída, para comparar dos
+// lecturas de la misma configuración.
+func (r *Report) Values() map[string]string {
+	out := make(map[string]string, len(r.entries))
+	for _, e := range r.entries {
+		out[e.key] = e.value
+	}
+	return out
+}
@@ -0,0 +1,252 @@
+// Package discovery encuentra al Matchmaker vigente. MATCHMAKER_ADDR admite
+// tres formas:
+//
+//	matchmaker:50051                     una sola dirección (como siempre)
+//	matchmaker:50051,respaldo:50051      semillas: primario y respaldo(s)
+//	srv:_matchmaker._tcp.l3.local        registro DNS SRV
+//
+// Con una sola dirección Target la devuelve tal cual. Con varias, o con un
+// SRV, devuelve un target del esquema "mm" que resuelve este paquete: el
+// resolver re-consulta el SRV cada 30 s y, en cualquier caso, cada vez que
+// gRPC lo pide tras una falla de conexión. La conexión usa round_robin con
+// health checking (grpc.health.v1) sobre el servicio Matchmaker, así que las
+// RPC van sólo al Matchmaker que responde SERVING: un respaldo pasivo
+// responde NOT_SERVING hasta que se promueve, y entonces recibe el tráfico
+// sin que los clientes cambien MATCHMAKER_ADDR.
+//
+// Uso (pkg/mmclient.Dial ya lo hace):
+//
+//	target, opts, err := discovery.Target(addr)
+//	conn, err := grpc.Dial(target, append(opts, otras...)...)
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/health" // registra el health checking del cliente
+	"google.golang.org/grpc/resolver"
+
+	slog "github.com/vimsent/L3/internal/log"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// Scheme es el esquema de los targets que resuelve este paquete.
+const Scheme = "mm"
+
+const (
+	srvPrefix   = "srv:"
+	refreshSRV  = 30 * time.Second // re-consulta periódica del SRV
+	minInterval = time.Second      // entre dos resoluciones seguidas
+	lookupLimit = 5 * time.Second
+)
+
+// serviceConfig reparte las RPC entre los Matchmakers que responden
+// SERVING; en la práctica, el primario.
+var serviceConfig = fmt.Sprintf(`{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"healthCheckConfig": {"serviceName": %q}
+}`, pb.Matchmaker_ServiceDesc.ServiceName)
+
+// Spec es un valor de MATCHMAKER_ADDR ya interpretado.
+type Spec struct {
+	Seeds []string // host:puerto, en orden de preferencia
+	SRV   string   // nombre del registro SRV; excluye Seeds
+}
+
+// Multi indica si hay que resolver: varias semillas o un SRV.
+func (s Spec) Multi() bool { return s.SRV != "" || len(s.Seeds) > 1 }
+
+// Parse interpreta raw y valida cada dirección.
+func Parse(raw string) (Spec, error) {
+	raw = strings.TrimSpace(raw)
+	if name, ok := strings.CutPrefix(raw, srvPrefix); ok {
+		if name == "" || strings.ContainsAny(name, ", ") {
+			return Spec{}, fmt.Errorf("nombre SRV inválido en %q", raw)
+		}
+		return Spec{SRV: name}, nil
+	}
+	var s Spec
+	for _, addr := range strings.Split(raw, ",") {
+		addr = strings.TrimSpace(addr)
+		if err := checkHostPort(addr); err != nil {
+			return Spec{}, err
+		}
+		s.Seeds = append(s.Seeds, addr)
+	}
+	return s, nil
+}
+
+// Target devuelve el target de grpc.Dial para raw y las opciones que
+// necesita: ninguna si es una sola dirección.
+func Target(raw string) (string, []grpc.DialOption, error) {
+	spec, err := Parse(raw)
+	if err != nil {
+		return "", nil, err
+	}
+	if !spec.Multi() {
+		return spec.Seeds[0], nil, nil
+	}
+	return Scheme + ":///" + strings.TrimSpace(raw), []grpc.DialOption{
+		grpc.WithResolvers(builder{}),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+	}, nil
+}
+
+// Lookup resuelve raw a las direcciones candidatas, en orden de preferencia;
+// sirve para sondearlas una por una (p.ej. el healthcheck).
+func Lookup(ctx context.Context, raw string) ([]string, error) {
+	spec, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return spec.lookup(ctx)
+}
+
+func checkHostPort(addr string) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("falta el host en %q", addr)
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("puerto inválido en %q", addr)
+	}
+	return nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                    Resolver
+───────────────────────────────────────────────────────────────────────────────*/
+
+type builder struct{}
+
+func (builder) Scheme() string { return Scheme }
+
+func (builder) Build(t resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	spec, err := Parse(t.Endpoint())
+	if err != nil {
+		return nil, err
+	}
+	r := &mmResolver{
+		spec: spec,
+		cc:   cc,
+		now:  make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	go r.watch()
+	return r, nil
+}
+
+type mmResolver struct {
+	spec Spec
+	cc   resolver.ClientConn
+	now  chan struct{} // ResolveNow pendiente
+	done chan struct{}
+	once sync.Once
+	last []string // direcciones de la última resolución, para el registro
+}
+
+// ResolveNow lo llama gRPC cuando una conexión falla.
+func (r *mmResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.now <- struct{}{}:
+	default:
+	}
+}
+
+func (r *mmResolver) Close() { r.once.Do(func() { close(r.done) }) }
+
+// watch resuelve al empezar, con cada ResolveNow y, si es un SRV,
+// periódicamente; nunca más de una vez por segundo.
+func (r *mmResolver) watch() {
+	for {
+		r.resolve()
+		var tick <-chan time.Time
+		if r.spec.SRV != "" {
+			tick = time.After(refreshSRV)
+		}
+		select {
+		case <-r.now:
+		case <-tick:
+		case <-r.done:
+			return
+		}
+		select {
+		case <-time.After(minInterval):
+		case <-r.done:
+			return
+		}
+	}
+}
+
+func (r *mmResolver) resolve() {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupLimit)
+	defer cancel()
+	addrs, err := r.spec.lookup(ctx)
+	if err != nil {
+		slog.Warn("discovery: no se pudo resolver %s: %v", r.spec.SRV, err)
+		r.cc.ReportError(err)
+		return
+	}
+	if !equal(addrs, r.last) {
+		slog.Info("discovery: Matchmakers candidatos %v", addrs)
+		r.last = addrs
+	}
+	state := resolver.State{}
+	for _, a := range addrs {
+		host, _, _ := net.SplitHostPort(a)
+		// el certificado de cada Matchmaker se verifica contra su propio host
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: a, ServerName: host})
+	}
+	if err := r.cc.UpdateState(state); err != nil {
+		slog.Debug("discovery: estado rechazado por gRPC: %v", err)
+	}
+}
+
+// lookup devuelve las direcciones candidatas; las semillas no cambian.
+func (s Spec) lookup(ctx context.Context) ([]string, error) {
+	if s.SRV == "" {
+		return s.Seeds, nil
+	}
+	_, recs, err := net.DefaultResolver.LookupSRV(ctx, "", "", s.SRV)
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, errors.New("el registro SRV no tiene destinos")
+	}
+	// LookupSRV ya ordena por prioridad y sortea por peso
+	addrs := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		host := strings.TrimSuffix(rec.Target, ".")
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(int(rec.Port))))
+	}
+	return addrs, nil
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string(nil), a...), append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+// Package idgen genera identificadores únicos y crecientes con el formato
+// "<prefijo>-<16 dígitos hex>". Los dígitos codifican los milisegundos unix
+// de creación (bits altos) y un contador dentro del mismo milisegundo (los
+// 12 bits bajos), así que dos IDs del mismo generador se ordenan igual como
+// texto que por creación y Parse recupera la hora en que se crearon.
+//
+// El generador nunca retrocede: si el reloj de pared vuelve atrás, o si más
+// de 4096 IDs caen en un milisegundo, sigue desde el último emitido. Para que
+// un proceso que retoma el trabajo de otro (un respaldo promovido, un
+// reinicio desde estado persistido) no repita IDs, Observe lo adelanta hasta
+// el último ID conocido.
+//
+// Uso:
+//
+//	gen := idgen.New("Matchmaker", time.Now)
+//	id := gen.Next()          // "Matchmaker-001a13f5bac06000"
+//	gen.Observe(persistedID)  // tras restaurar estado
+package idgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bits del contador dentro de un milisegundo
+const seqBits = 12
+
+// Generator emite IDs crecientes; es seguro para uso concurrente.
+type Generator struct {
+	prefix string
+	now    func() time.Time
+
+	mu   sync.Mutex
+	last uint64 // valor del último ID emitido u observado
+}
+
+// New crea un generador con el prefijo dado (sin '-') y la fuente de hora
+// indicada.
+func New(prefix string, now func() time.Time) *Generator {
+	return &Generator{prefix: prefix, now: now}
+}
+
+// Next devuelve un ID nuevo, mayor que todos los emitidos u observados.
+func (g *Generator) Next() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	v := uint64(g.now().UnixMilli()) << seqBits
+	if v <= g.last {
+		v = g.last + 1
+	}
+	g.last = v
+	return g.format(v)
+}
+
+// Last devuelve el último ID emitido u observado; vacío si no hay ninguno.
+func (g *Generator) Last() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.last == 0 {
+		return ""
+	}
+	return g.format(g.last)
+}
+
+// Observe adelanta el generador hasta id, si es de su prefijo y posterior
+// al último conocido. Los IDs ajenos o con otro formato se ignoran.
+func (g *Generator) Observe(id string) {
+	prefix, v, ok := split(id)
+	if !ok || prefix != g.prefix {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if v > g.last {
+		g.last = v
+	}
+}
+
+func (g *Generator) format(v uint64) string {
+	return fmt.Sprintf("%s-%016x", g.prefix, v)
+}
+
+// Parse separa un ID en su prefijo y su hora de creación.
+func Parse(id string) (prefix string, created time.Time, err error) {
+	prefix, v, ok := split(id)
+	if !ok {
+		return "", time.Time{}, fmt.Errorf("idgen: ID inválido %q", id)
+	}
+	return prefix, time.UnixMilli(int64(v >> seqBits)), nil
+}
+
+func split(id string) (prefix string, v uint64, ok bool) {
+	i := strings.LastIndexByte(id, '-')
+	if i < 0 || len(id)-i-1 != 16 {
+		return "", 0, false
+	}
+	v, err := strconv.ParseUint(id[i+1:], 16, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return id[:i], v, true
+}
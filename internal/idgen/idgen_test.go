@@ -0,0 +1,197 @@
+// internal/idgen/idgen_test.go
+//
+// Generator: IDs crecientes dentro de un milisegundo, desborde del
+// contador hacia el milisegundo siguiente, reloj que retrocede y Observe de
+// un ID por delante de la hora local. El reloj es manual.
+
+package idgen
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualClock es una hora fija que el test mueve a mano.
+type manualClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *manualClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *manualClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+var t0 = time.UnixMilli(1_700_000_000_000)
+
+func newTestGen() (*Generator, *manualClock) {
+	clk := &manualClock{t: t0}
+	return New("MM", clk.now), clk
+}
+
+// created devuelve la hora codificada en id.
+func created(t *testing.T, id string) time.Time {
+	t.Helper()
+	prefix, at, err := Parse(id)
+	if err != nil || prefix != "MM" {
+		t.Fatalf("Parse(%q) = %q, %v", id, prefix, err)
+	}
+	return at
+}
+
+// next emite n IDs y verifica que crecen estrictamente, también como texto.
+func next(t *testing.T, g *Generator, n int, prev string) []string {
+	t.Helper()
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = g.Next()
+		if ids[i] <= prev {
+			t.Fatalf("Next = %s tras %s: no crece", ids[i], prev)
+		}
+		prev = ids[i]
+	}
+	return ids
+}
+
+func TestNextMonotonicWithinMillisecond(t *testing.T) {
+	g, _ := newTestGen()
+	ids := next(t, g, 100, "")
+	for i, id := range ids {
+		if at := created(t, id); !at.Equal(t0) {
+			t.Fatalf("ID %d (%s) creado en %v, se esperaba %v", i, id, at, t0)
+		}
+		_, v, _ := split(id)
+		if seq := v & (1<<seqBits - 1); seq != uint64(i) {
+			t.Fatalf("contador del ID %d = %d", i, seq)
+		}
+	}
+	if g.Last() != ids[len(ids)-1] {
+		t.Fatalf("Last = %s, se esperaba %s", g.Last(), ids[len(ids)-1])
+	}
+}
+
+// Más de 4096 IDs en un milisegundo toman prestado el siguiente, y cuando
+// el reloj lo alcanza no se repiten.
+func TestNextSequenceOverflow(t *testing.T) {
+	g, clk := newTestGen()
+	ids := next(t, g, 1<<seqBits+1, "")
+	if at := created(t, ids[1<<seqBits-1]); !at.Equal(t0) {
+		t.Fatalf("el ID 4096 cayó en %v, se esperaba %v", at, t0)
+	}
+	overflow := ids[1<<seqBits]
+	if at := created(t, overflow); !at.Equal(t0.Add(time.Millisecond)) {
+		t.Fatalf("el ID 4097 cayó en %v, se esperaba el milisegundo siguiente", at)
+	}
+
+	clk.set(t0.Add(time.Millisecond))
+	id := next(t, g, 1, overflow)[0]
+	if at := created(t, id); !at.Equal(t0.Add(time.Millisecond)) {
+		t.Fatalf("tras alcanzar el reloj: %v", at)
+	}
+}
+
+func TestNextClockStepsBack(t *testing.T) {
+	for _, back := range []time.Duration{time.Millisecond, time.Second, time.Hour} {
+		t.Run(back.String(), func(t *testing.T) {
+			g, clk := newTestGen()
+			first := g.Next()
+			clk.set(t0.Add(-back))
+			ids := next(t, g, 3, first)
+			for _, id := range ids {
+				if at := created(t, id); !at.Equal(t0) {
+					t.Fatalf("con el reloj atrasado %v: ID en %v, se esperaba seguir en %v", back, at, t0)
+				}
+			}
+			// al volver el reloj, los IDs retoman su hora
+			clk.set(t0.Add(time.Second))
+			if at := created(t, next(t, g, 1, ids[2])[0]); !at.Equal(t0.Add(time.Second)) {
+				t.Fatalf("tras recuperar el reloj: %v", at)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	ahead := fmt.Sprintf("MM-%016x", uint64(t0.Add(time.Hour).UnixMilli())<<seqBits|7)
+	cases := []struct {
+		name     string
+		observed string
+		want     time.Time // hora del Next siguiente
+	}{
+		{"por delante de la hora local", ahead, t0.Add(time.Hour)},
+		{"anterior al último", fmt.Sprintf("MM-%016x", uint64(t0.Add(-time.Hour).UnixMilli())<<seqBits), t0},
+		{"otro prefijo", "GS-" + ahead[3:], t0},
+		{"prefijo con guiones", "MM-x-" + ahead[3:], t0},
+		{"mal formado", "MM-123", t0},
+		{"no hex", "MM-zzzzzzzzzzzzzzzz", t0},
+		{"vacío", "", t0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, _ := newTestGen()
+			first := g.Next()
+			g.Observe(tc.observed)
+			id := next(t, g, 1, first)[0]
+			if at := created(t, id); !at.Equal(tc.want) {
+				t.Fatalf("Next tras Observe(%q) en %v, se esperaba %v", tc.observed, at, tc.want)
+			}
+			if tc.observed == ahead && id <= ahead {
+				t.Fatalf("Next = %s no supera el observado %s", id, ahead)
+			}
+		})
+	}
+}
+
+func TestLastAndObserveOnFreshGenerator(t *testing.T) {
+	g, _ := newTestGen()
+	if g.Last() != "" {
+		t.Fatalf("Last sin IDs = %q", g.Last())
+	}
+	id := fmt.Sprintf("MM-%016x", uint64(t0.UnixMilli())<<seqBits|5)
+	g.Observe(id)
+	if g.Last() != id {
+		t.Fatalf("Last tras Observe = %q, se esperaba %q", g.Last(), id)
+	}
+}
+
+func TestParseRejectsMalformed(t *testing.T) {
+	for _, id := range []string{"", "MM", "MM-", "MM-12345", "MM-0123456789abcdef0", "MM-0123456789abcdeg"} {
+		if _, _, err := Parse(id); err == nil {
+			t.Errorf("Parse(%q) sin error", id)
+		}
+	}
+}
+
+// IDs pedidos en paralelo no se repiten (correr con -race).
+func TestNextConcurrentUnique(t *testing.T) {
+	g, _ := newTestGen()
+	const workers, each = 8, 1000
+	var mu sync.Mutex
+	seen := make(map[string]bool, workers*each)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				id := g.Next()
+				mu.Lock()
+				if seen[id] {
+					t.Errorf("ID repetido: %s", id)
+				}
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -3,17 +3,29 @@
 //
 //	slog.Info("arrancó el servidor en %s", addr)
 //	slog.SetLevel(slog.DebugLevel)
+//	slog.WithClock(clock).Info("encolado %s", id) // … vc=[Matchmaker=4,P1=2]
+//
+//	mm := slog.New("Matchmaker")
+//	mm.Warn("servidor %s caído", id) // [WARN] 12:00:00.000 [Matchmaker] servidor gs1 caído
 //
 // Niveles por defecto: INFO, WARN, ERROR; DEBUG se activa con LOG_LEVEL=debug
+//
+// Las líneas van a la salida estándar (con colores) y a cada salida extra de
+// AddOutput (sin colores). UseFile agrega un archivo que rota por tamaño, para
+// conservar los logs de cada binario y analizar fallas después; Writer deja
+// que el paquete log estándar escriba en las mismas salidas. Subscribe
+// entrega las líneas a medida que se emiten, para seguirlas en vivo.
 package log
 
 import (
 	"fmt"
-	"log"
+	"io"
 	"os"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
 )
 
 // Level representa severidad.
@@ -38,10 +50,19 @@ var color = map[Level]string{
 
 const reset = "\033[0m"
 
+// output es un destino de las líneas; sólo los de color reciben ANSI.
+type output struct {
+	w     io.Writer
+	color bool
+}
+
 var (
 	minLevel   = InfoLevel
-	logger     = log.New(os.Stdout, "", 0)
 	levelMutex sync.RWMutex
+
+	outputs  = []output{{w: os.Stdout, color: true}}
+	outMutex sync.Mutex // serializa las escrituras y protege outputs
+	muted    bool       // consola silenciada (SetConsole); la protege outMutex
 )
 
 // init lee LOG_LEVEL.
@@ -63,21 +84,139 @@ func init() {
 // SetLevel permite cambiarlo en caliente.
 func SetLevel(l Level) { levelMutex.Lock(); minLevel = l; levelMutex.Unlock() }
 
-// logf central.
-func logf(lvl Level, format string, a ...interface{}) {
+// AddOutput agrega w como salida sin colores, además de las actuales.
+func AddOutput(w io.Writer) {
+	outMutex.Lock()
+	outputs = append(outputs, output{w: w})
+	outMutex.Unlock()
+}
+
+// SetConsole activa o silencia la consola (stdout). Las demás salidas y las
+// suscripciones siguen recibiendo las líneas: sirve a una interfaz que
+// ocupa la pantalla y las muestra por su cuenta.
+func SetConsole(on bool) {
+	outMutex.Lock()
+	muted = !on
+	outMutex.Unlock()
+}
+
+// UseFile agrega como salida el archivo path, rotado a los maxBytes con
+// backups respaldos (ver OpenFile). Con path vacío no hace nada. Las
+// escrituras no pasan por un búfer, así que no hace falta cerrarlo al salir.
+func UseFile(path string, maxBytes int64, backups int) error {
+	if path == "" {
+		return nil
+	}
+	f, err := OpenFile(path, maxBytes, backups)
+	if err != nil {
+		return err
+	}
+	AddOutput(f)
+	return nil
+}
+
+// Writer devuelve un io.Writer que copia lo escrito, tal cual, en todas las
+// salidas; sirve para log.SetOutput del paquete estándar.
+func Writer() io.Writer { return writerFunc(write) }
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+func write(p []byte) (int, error) {
+	outMutex.Lock()
+	defer outMutex.Unlock()
+	for _, o := range outputs {
+		if o.color && muted {
+			continue
+		}
+		o.w.Write(p)
+	}
+	publishRaw(p)
+	return len(p), nil
+}
+
+// logf central; component vacío = sin prefijo de componente.
+func logf(component string, lvl Level, format string, a ...interface{}) {
 	levelMutex.RLock()
-	defer levelMutex.RUnlock()
-	if lvl < minLevel {
+	skip := lvl < minLevel
+	levelMutex.RUnlock()
+	if skip {
 		return
 	}
-	ts := time.Now().Format("15:04:05.000")
+	now := time.Now()
+	ts := now.Format("15:04:05.000")
 	msg := fmt.Sprintf(format, a...)
-	prefix := fmt.Sprintf("%s[%s] %s%s ", color[lvl], levelNames[lvl], ts, reset)
-	logger.Printf(prefix + msg)
+	if component != "" {
+		msg = "[" + component + "] " + msg
+	}
+	if !strings.HasSuffix(msg, "\n") {
+		msg += "\n"
+	}
+	plain := fmt.Sprintf("[%s] %s %s", levelNames[lvl], ts, msg)
+	colored := fmt.Sprintf("%s[%s] %s%s %s", color[lvl], levelNames[lvl], ts, reset, msg)
+
+	outMutex.Lock()
+	defer outMutex.Unlock()
+	for _, o := range outputs {
+		if o.color && muted {
+			continue
+		}
+		if o.color {
+			io.WriteString(o.w, colored)
+		} else {
+			io.WriteString(o.w, plain)
+		}
+	}
+	if len(tails) > 0 {
+		tags, text := splitTags(strings.TrimRight(msg, "\n"))
+		publish(Line{Time: now, Level: lvl, Tags: tags, Message: text})
+	}
 }
 
 // Helpers públicos.
-func Debug(format string, a ...interface{}) { logf(DebugLevel, format, a...) }
-func Info(format string, a ...interface{})  { logf(InfoLevel, format, a...) }
-func Warn(format string, a ...interface{})  { logf(WarnLevel, format, a...) }
-func Error(format string, a ...interface{}) { logf(ErrorLevel, format, a...) }
+func Debug(format string, a ...interface{}) { logf("", DebugLevel, format, a...) }
+func Info(format string, a ...interface{})  { logf("", InfoLevel, format, a...) }
+func Warn(format string, a ...interface{})  { logf("", WarnLevel, format, a...) }
+func Error(format string, a ...interface{}) { logf("", ErrorLevel, format, a...) }
+
+// Logger antepone a cada línea el nombre de un componente.
+type Logger struct {
+	component string
+}
+
+// New crea un logger cuyas líneas llevan el prefijo "[component] ".
+func New(component string) *Logger { return &Logger{component: component} }
+
+func (l *Logger) Debug(format string, a ...interface{}) { logf(l.component, DebugLevel, format, a...) }
+func (l *Logger) Info(format string, a ...interface{})  { logf(l.component, InfoLevel, format, a...) }
+func (l *Logger) Warn(format string, a ...interface{})  { logf(l.component, WarnLevel, format, a...) }
+func (l *Logger) Error(format string, a ...interface{}) { logf(l.component, ErrorLevel, format, a...) }
+
+// WithClock es la variante de WithClock que conserva el componente.
+func (l *Logger) WithClock(c *clocks.Vector) *Entry {
+	return &Entry{component: l.component, clock: c.String()}
+}
+
+// ClockField es la marca con que WithClock agrega el reloj al final de la
+// línea; los lectores de logs la buscan para ordenarlas causalmente.
+const ClockField = "vc="
+
+// Entry es un logger que agrega a cada línea el reloj vectorial.
+type Entry struct {
+	component string
+	clock     string
+}
+
+// WithClock captura el reloj en su estado actual; las líneas emitidas con
+// el Entry terminan en " vc=[id1=3,id2=1]".
+func WithClock(c *clocks.Vector) *Entry { return &Entry{clock: c.String()} }
+
+func (e *Entry) logf(lvl Level, format string, a ...interface{}) {
+	logf(e.component, lvl, "%s %s[%s]", fmt.Sprintf(format, a...), ClockField, e.clock)
+}
+
+func (e *Entry) Debug(format string, a ...interface{}) { e.logf(DebugLevel, format, a...) }
+func (e *Entry) Info(format string, a ...interface{})  { e.logf(InfoLevel, format, a...) }
+func (e *Entry) Warn(format string, a ...interface{})  { e.logf(WarnLevel, format, a...) }
+func (e *Entry) Error(format string, a ...interface{}) { e.logf(ErrorLevel, format, a...) }
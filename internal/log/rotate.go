@@ -0,0 +1,132 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RotatingFile es un archivo de log que rota por tamaño: cuando la próxima
+// escritura superaría MaxBytes, el archivo actual pasa a ser "<path>.1", el
+// ".1" pasa a ".2" y así hasta Backups; el más viejo se descarta. Una
+// escritura nunca se parte entre dos archivos.
+type RotatingFile struct {
+	path     string
+	maxBytes int64
+	backups  int
+
+	mu      sync.Mutex
+	f       *os.File
+	size    int64
+	failing bool // la última rotación falló; evita repetir el aviso
+}
+
+// OpenFile abre (o crea, junto con su directorio) path para agregar líneas.
+// maxBytes <= 0 desactiva la rotación; backups = 0 descarta el archivo
+// completo en vez de conservarlo.
+func OpenFile(path string, maxBytes int64, backups int) (*RotatingFile, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	r := &RotatingFile{path: path, maxBytes: maxBytes, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size = f, st.Size()
+	return nil
+}
+
+// Write agrega p al archivo, rotándolo antes si hace falta. Si la rotación
+// falla, la línea igual se escribe en el archivo actual (que sigue creciendo
+// hasta que una rotación posterior funcione) y se devuelve el error.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return 0, os.ErrClosed
+	}
+	var rotErr error
+	if r.maxBytes > 0 && r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		rotErr = r.rotate()
+		if r.f == nil {
+			return 0, rotErr
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotErr
+	}
+	return n, err
+}
+
+// rotate desplaza los respaldos y reabre path vacío. Pase lo que pase con los
+// renombres, path se vuelve a abrir: un renombre fallido no debe dejar el
+// archivo cerrado y al logger escribiendo en el vacío. La primera falla se
+// avisa por stderr, ya que el logger no puede loguearse a sí mismo.
+// debe llamarse con r.mu bloqueado
+func (r *RotatingFile) rotate() error {
+	cerr := r.f.Close()
+	r.f = nil
+	err := r.shift()
+	if oerr := r.open(); err == nil {
+		err = oerr
+	}
+	if err == nil {
+		err = cerr
+	}
+	if err != nil && !r.failing {
+		fmt.Fprintf(os.Stderr, "log: no se pudo rotar %s: %v\n", r.path, err)
+	}
+	r.failing = err != nil
+	return err
+}
+
+// shift renombra path a "<path>.1" corriendo los respaldos anteriores, o lo
+// borra si no se conservan respaldos.
+func (r *RotatingFile) shift() error {
+	if r.backups == 0 {
+		if err := os.Remove(r.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	for i := r.backups - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", r.path, i)
+		if err := os.Rename(from, fmt.Sprintf("%s.%d", r.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Close cierra el archivo; las escrituras posteriores fallan.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
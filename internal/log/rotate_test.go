@@ -0,0 +1,124 @@
+// internal/log/rotate_test.go
+//
+// RotatingFile: rota al superar MaxBytes sin partir escrituras, conserva
+// sólo Backups respaldos y, si un renombre falla, sigue escribiendo en el
+// archivo actual en vez de quedar cerrado.
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("leyendo %s: %v", path, err)
+	}
+	return string(b)
+}
+
+func TestRotatingFileRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "mm.log")
+	r, err := OpenFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	defer r.Close()
+
+	for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("Write(%q): %v", line, err)
+		}
+	}
+	// "aaaa\nbbbb\n" llena los 10 bytes; "cccc\n" va a un archivo nuevo.
+	if got := readFile(t, path); got != "cccc\n" {
+		t.Fatalf("actual = %q, se esperaba %q", got, "cccc\n")
+	}
+	if got := readFile(t, path+".1"); got != "aaaa\nbbbb\n" {
+		t.Fatalf(".1 = %q, se esperaba %q", got, "aaaa\nbbbb\n")
+	}
+}
+
+func TestRotatingFileKeepsBackups(t *testing.T) {
+	cases := []struct {
+		name    string
+		backups int
+		want    []string // contenido de .1, .2, …
+	}{
+		{"sin respaldos", 0, nil},
+		{"uno", 1, []string{"3\n"}},
+		{"dos", 2, []string{"3\n", "2\n"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "gs.log")
+			r, err := OpenFile(path, 2, tc.backups)
+			if err != nil {
+				t.Fatalf("OpenFile: %v", err)
+			}
+			defer r.Close()
+			for _, line := range []string{"1\n", "2\n", "3\n", "4\n"} {
+				if _, err := r.Write([]byte(line)); err != nil {
+					t.Fatalf("Write(%q): %v", line, err)
+				}
+			}
+			if got := readFile(t, path); got != "4\n" {
+				t.Fatalf("actual = %q, se esperaba %q", got, "4\n")
+			}
+			for i, want := range tc.want {
+				if got := readFile(t, path+"."+string(rune('1'+i))); got != want {
+					t.Fatalf(".%d = %q, se esperaba %q", i+1, got, want)
+				}
+			}
+			extra := path + "." + string(rune('1'+len(tc.want)))
+			if _, err := os.Stat(extra); !os.IsNotExist(err) {
+				t.Fatalf("%s no debería existir (err=%v)", filepath.Base(extra), err)
+			}
+		})
+	}
+}
+
+func TestRotatingFileSurvivesRenameFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mm.log")
+	// Un directorio no vacío en "<path>.1" hace fallar el renombre.
+	if err := os.MkdirAll(filepath.Join(path+".1", "ocupado"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenFile(path, 4, 1)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	defer r.Close()
+
+	if _, err := r.Write([]byte("uno\n")); err != nil {
+		t.Fatalf("primer Write: %v", err)
+	}
+	if n, err := r.Write([]byte("dos\n")); err == nil || n != 4 {
+		t.Fatalf("Write con rotación fallida = (%d, %v), se esperaba (4, error)", n, err)
+	}
+	if _, err := r.Write([]byte("tres\n")); err == nil {
+		t.Fatal("la rotación sigue imposible y Write no lo informó")
+	}
+	if got := readFile(t, path); got != "uno\ndos\ntres\n" {
+		t.Fatalf("actual = %q: se perdieron líneas tras la rotación fallida", got)
+	}
+
+	// Cuando el obstáculo desaparece, la siguiente escritura rota normalmente.
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Write([]byte("cuatro\n")); err != nil {
+		t.Fatalf("Write tras liberar .1: %v", err)
+	}
+	if got := readFile(t, path); got != "cuatro\n" {
+		t.Fatalf("actual = %q, se esperaba %q", got, "cuatro\n")
+	}
+	if got := readFile(t, path+".1"); !strings.HasPrefix(got, "uno\n") {
+		t.Fatalf(".1 = %q, se esperaba lo escrito antes", got)
+	}
+}
@@ -0,0 +1,138 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Line es una línea de log tal como la reciben los suscriptores de Subscribe.
+type Line struct {
+	Time    time.Time
+	Level   Level
+	Tags    []string // etiquetas entre corchetes al inicio: componente, namespace…
+	Message string   // sin nivel, hora ni etiquetas
+}
+
+// String devuelve el nombre del nivel ("INFO").
+func (l Level) String() string {
+	if l < DebugLevel || l > ErrorLevel {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return levelNames[l]
+}
+
+// ParseLevel interpreta un nombre de nivel como los de LOG_LEVEL.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	}
+	return InfoLevel, fmt.Errorf("nivel de log desconocido %q (DEBUG, INFO, WARN o ERROR)", s)
+}
+
+// Tail recibe en C las líneas emitidas desde que se suscribió. Nunca frena
+// a quien escribe: si C está lleno, la línea se pierde y se cuenta en
+// Dropped.
+type Tail struct {
+	C <-chan Line
+
+	c       chan Line
+	dropped atomic.Uint64
+}
+
+// tails son las suscripciones activas; las protege outMutex, así que cada
+// Tail recibe las líneas en el mismo orden en que se escriben.
+var tails = map[*Tail]struct{}{}
+
+// Subscribe abre una suscripción a las líneas de este proceso (las de los
+// Logger y las del paquete log estándar que pasen por Writer) con un búfer
+// de buf líneas. Las que están por debajo del nivel mínimo no llegan.
+func Subscribe(buf int) *Tail {
+	c := make(chan Line, buf)
+	t := &Tail{C: c, c: c}
+	outMutex.Lock()
+	tails[t] = struct{}{}
+	outMutex.Unlock()
+	return t
+}
+
+// Close termina la suscripción y cierra C.
+func (t *Tail) Close() {
+	outMutex.Lock()
+	defer outMutex.Unlock()
+	if _, ok := tails[t]; ok {
+		delete(tails, t)
+		close(t.c)
+	}
+}
+
+// Dropped devuelve las líneas perdidas hasta ahora por no leer C a tiempo.
+func (t *Tail) Dropped() uint64 { return t.dropped.Load() }
+
+// publish entrega la línea a cada suscripción sin bloquear.
+// debe llamarse con outMutex bloqueado
+func publish(l Line) {
+	for t := range tails {
+		select {
+		case t.c <- l:
+		default:
+			t.dropped.Add(1)
+		}
+	}
+}
+
+// publishRaw entrega una línea del paquete log estándar, que no trae nivel:
+// se deduce de la palabra con que empieza el mensaje (WARN, ERROR…), como
+// en "[Matchmaker] [default] WARNING: …"; sin ella es INFO.
+// debe llamarse con outMutex bloqueado
+func publishRaw(p []byte) {
+	if len(tails) == 0 {
+		return
+	}
+	msg := strings.TrimRight(string(p), "\n")
+	// prefijo de log.LstdFlags: "2006/01/02 15:04:05 "
+	if len(msg) > 20 && msg[4] == '/' && msg[19] == ' ' {
+		if _, err := time.Parse("2006/01/02 15:04:05", msg[:19]); err == nil {
+			msg = msg[20:]
+		}
+	}
+	tags, msg := splitTags(msg)
+	lvl, rest := InfoLevel, ""
+	word, after, _ := strings.Cut(msg, " ")
+	switch strings.ToUpper(strings.TrimSuffix(word, ":")) {
+	case "DEBUG":
+		lvl, rest = DebugLevel, after
+	case "WARN", "WARNING":
+		lvl, rest = WarnLevel, after
+	case "ERROR", "FATAL":
+		lvl, rest = ErrorLevel, after
+	}
+	if lvl != InfoLevel {
+		// "WARN [default] Snapshot …": las etiquetas pueden seguir al nivel
+		more, m := splitTags(rest)
+		tags, msg = append(tags, more...), m
+	}
+	publish(Line{Time: time.Now(), Level: lvl, Tags: tags, Message: msg})
+}
+
+// splitTags separa las etiquetas "[x] " del principio de msg.
+func splitTags(msg string) ([]string, string) {
+	var tags []string
+	for strings.HasPrefix(msg, "[") {
+		end := strings.Index(msg, "] ")
+		if end < 0 {
+			break
+		}
+		tags = append(tags, msg[1:end])
+		msg = msg[end+2:]
+	}
+	return tags, msg
+}
@@ -0,0 +1,265 @@
+// Package metrics implementa un registro mínimo de métricas con salida en el
+// formato de texto de Prometheus (text/plain; version=0.0.4), usando sólo la
+// librería estándar.
+//
+// Uso:
+//
+//	reg := metrics.NewRegistry()
+//	matches := reg.NewCounter("app_matches_total", "Partidas creadas.", "namespace")
+//	matches.With("default").Inc()
+//	http.Handle("/metrics", reg)
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets son límites de histograma pensados para latencias de RPC
+// en segundos.
+var DefaultBuckets = []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
+
+type kind string
+
+const (
+	kindCounter   kind = "counter"
+	kindGauge     kind = "gauge"
+	kindHistogram kind = "histogram"
+)
+
+// Registry agrupa familias de métricas y las expone vía HTTP.
+type Registry struct {
+	mu       sync.Mutex
+	families []*family
+	hooks    []func()
+}
+
+// NewRegistry crea un registro vacío.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// OnScrape registra fn para ejecutarse antes de cada exposición; sirve para
+// actualizar gauges que se calculan a partir del estado (p.ej. largo de cola).
+func (r *Registry) OnScrape(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, fn)
+}
+
+type family struct {
+	name    string
+	help    string
+	kind    kind
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	counts      []uint64 // histogramas: acumulado por bucket
+	count       uint64
+	sum         float64
+}
+
+func (r *Registry) register(name, help string, k kind, buckets []float64, labels []string) *family {
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    k,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.mu.Lock()
+	r.families = append(r.families, f)
+	r.mu.Unlock()
+	return f
+}
+
+// get devuelve (creándola si no existe) la serie con esos valores de etiqueta.
+// debe llamarse con f.mu bloqueado
+func (f *family) get(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s espera %d etiquetas, recibió %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), values...)}
+		if f.kind == kindHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+/*──────────────────────────────── Counter ────────────────────────────────*/
+
+// Counter es una familia de contadores monótonos.
+type Counter struct{ f *family }
+
+// NewCounter registra un contador con las etiquetas indicadas.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{r.register(name, help, kindCounter, nil, labels)}
+}
+
+// With selecciona la serie con esos valores de etiqueta.
+func (c *Counter) With(values ...string) CounterSeries {
+	return CounterSeries{c.f, values}
+}
+
+// CounterSeries es una serie concreta de un Counter.
+type CounterSeries struct {
+	f      *family
+	values []string
+}
+
+// Inc suma 1.
+func (c CounterSeries) Inc() { c.Add(1) }
+
+// Add suma v (debe ser ≥ 0).
+func (c CounterSeries) Add(v float64) {
+	c.f.mu.Lock()
+	c.f.get(c.values).value += v
+	c.f.mu.Unlock()
+}
+
+/*───────────────────────────────── Gauge ─────────────────────────────────*/
+
+// Gauge es una familia de valores que suben y bajan.
+type Gauge struct{ f *family }
+
+// NewGauge registra un gauge con las etiquetas indicadas.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r.register(name, help, kindGauge, nil, labels)}
+}
+
+// Set fija el valor de la serie con esos valores de etiqueta.
+func (g *Gauge) Set(v float64, values ...string) {
+	g.f.mu.Lock()
+	g.f.get(values).value = v
+	g.f.mu.Unlock()
+}
+
+// Reset elimina todas las series; útil antes de recalcular un gauge
+// completo en OnScrape para no exponer series obsoletas.
+func (g *Gauge) Reset() {
+	g.f.mu.Lock()
+	g.f.series = make(map[string]*series)
+	g.f.mu.Unlock()
+}
+
+/*─────────────────────────────── Histogram ───────────────────────────────*/
+
+// Histogram es una familia de histogramas con buckets fijos.
+type Histogram struct{ f *family }
+
+// NewHistogram registra un histograma; buckets debe estar ordenado.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{r.register(name, help, kindHistogram, buckets, labels)}
+}
+
+// Observe registra una muestra en la serie con esos valores de etiqueta.
+func (h *Histogram) Observe(v float64, values ...string) {
+	h.f.mu.Lock()
+	defer h.f.mu.Unlock()
+	s := h.f.get(values)
+	for i, le := range h.f.buckets {
+		if v <= le {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+}
+
+/*──────────────────────────────── Salida ─────────────────────────────────*/
+
+// ServeHTTP expone todas las métricas en formato de texto de Prometheus.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// WriteTo escribe todas las métricas en w.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	hooks := append([]func(){}, r.hooks...)
+	families := append([]*family(nil), r.families...)
+	r.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+
+	var sb strings.Builder
+	for _, f := range families {
+		f.write(&sb)
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+func (f *family) write(sb *strings.Builder) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+
+	keys := make([]string, 0, len(f.series))
+	for k := range f.series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		s := f.series[k]
+		if f.kind != kindHistogram {
+			fmt.Fprintf(sb, "%s%s %s\n", f.name, f.labelString(s.labelValues, ""), formatFloat(s.value))
+			continue
+		}
+		for i, le := range f.buckets {
+			fmt.Fprintf(sb, "%s_bucket%s %d\n", f.name, f.labelString(s.labelValues, formatFloat(le)), s.counts[i])
+		}
+		fmt.Fprintf(sb, "%s_bucket%s %d\n", f.name, f.labelString(s.labelValues, "+Inf"), s.count)
+		fmt.Fprintf(sb, "%s_sum%s %s\n", f.name, f.labelString(s.labelValues, ""), formatFloat(s.sum))
+		fmt.Fprintf(sb, "%s_count%s %d\n", f.name, f.labelString(s.labelValues, ""), s.count)
+	}
+}
+
+// labelString arma {k="v",...}; le se agrega como etiqueta de bucket.
+func (f *family) labelString(values []string, le string) string {
+	if len(values) == 0 && le == "" {
+		return ""
+	}
+	parts := make([]string, 0, len(values)+1)
+	for i, v := range values {
+		parts = append(parts, fmt.Sprintf("%s=%q", f.labels[i], v))
+	}
+	if le != "" {
+		parts = append(parts, fmt.Sprintf("le=%q", le))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,210 @@
+// Package middleware reúne los interceptores de servidor gRPC comunes al
+// Matchmaker y al GameServer:
+//
+//   - registro de cada RPC con su duración, código de estado e ID de
+//     petición (y el reloj del cliente, para ordenarla con cmd/logorder);
+//   - recuperación de pánicos: el handler que entra en pánico responde
+//     codes.Internal y el proceso sigue atendiendo;
+//   - el reloj vectorial que el cliente envía en la metadata (ver
+//     internal/clockpropagation) queda disponible en el contexto con
+//     ClockFrom;
+//   - un span por RPC, hijo del que llega en la metadata "traceparent"
+//     (ver internal/tracing);
+//   - con StatusErrors, los rechazos que las RPC informan en status_code
+//     (y que responden con error nil) salen además como errores gRPC
+//     —NotFound, AlreadyExists, FailedPrecondition…— que entienden los
+//     reintentos y circuit breakers genéricos; la respuesta completa viaja
+//     como detalle y pkg/mmclient la restaura, así que los clientes del
+//     repositorio leen status_code igual con o sin la opción.
+//
+// Uso:
+//
+//	srv := grpc.NewServer(
+//		middleware.ChainUnary("Matchmaker", m.metricsUnaryInterceptor),
+//		middleware.ChainStream("Matchmaker"),
+//	)
+package middleware
+
+import (
+	"context"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+	slog "github.com/vimsent/L3/internal/log"
+	"github.com/vimsent/L3/internal/tracing"
+	"github.com/vimsent/L3/pkg/mmclient"
+)
+
+type ctxKey int
+
+const (
+	clockKey ctxKey = iota
+	requestIDKey
+)
+
+// ClockFrom devuelve el reloj que el cliente envió en la metadata, o nil.
+func ClockFrom(ctx context.Context) *clocks.Vector {
+	vc, _ := ctx.Value(clockKey).(*clocks.Vector)
+	return vc
+}
+
+// RequestIDFrom devuelve el ID de petición del cliente, o "".
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// ChainUnary encadena traza, registro, recuperación y reloj por delante de
+// los interceptores propios del componente.
+func ChainUnary(component string, extra ...grpc.UnaryServerInterceptor) grpc.ServerOption {
+	return grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+		tracing.UnaryServer, UnaryLogging(component), UnaryRecovery(component), UnaryClock,
+	}, extra...)...)
+}
+
+// ChainStream es el equivalente para streams.
+func ChainStream(component string, extra ...grpc.StreamServerInterceptor) grpc.ServerOption {
+	return grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{
+		tracing.StreamServer, StreamLogging(component), StreamRecovery(component), StreamClock,
+	}, extra...)...)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                 Registro
+───────────────────────────────────────────────────────────────────────────────*/
+
+// logRPC escribe una línea por RPC: INFO si salió bien, WARN con error del
+// cliente o del estado del sistema, ERROR con errores internos.
+func logRPC(ctx context.Context, l *slog.Logger, method string, elapsed time.Duration, err error) {
+	code := status.Code(err)
+	format := "%s %s en %v (req %s)"
+	args := []interface{}{path.Base(method), code, elapsed.Round(time.Microsecond), requestID(ctx)}
+	if err != nil {
+		format += ": %s"
+		args = append(args, status.Convert(err).Message())
+	}
+
+	info, warn, fail := l.Info, l.Warn, l.Error
+	if vc, _ := clockpropagation.FromIncoming(ctx); vc != nil {
+		e := l.WithClock(vc)
+		info, warn, fail = e.Info, e.Warn, e.Error
+	}
+	switch code {
+	case codes.OK:
+		info(format, args...)
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		fail(format, args...)
+	default:
+		warn(format, args...)
+	}
+}
+
+// UnaryLogging registra cada RPC unaria.
+func UnaryLogging(component string) grpc.UnaryServerInterceptor {
+	l := slog.New(component)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(ctx, l, info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// StreamLogging registra cada stream al cerrarse.
+func StreamLogging(component string) grpc.StreamServerInterceptor {
+	l := slog.New(component)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logRPC(ss.Context(), l, info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                          Recuperación de pánicos
+───────────────────────────────────────────────────────────────────────────────*/
+
+func recovered(component, method string, p interface{}) error {
+	slog.New(component).Error("pánico en %s: %v\n%s", path.Base(method), p, debug.Stack())
+	return status.Errorf(codes.Internal, "error interno en %s", path.Base(method))
+}
+
+// UnaryRecovery convierte un pánico del handler en codes.Internal.
+func UnaryRecovery(component string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, recovered(component, info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery hace lo mismo para streams.
+func StreamRecovery(component string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(component, info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                           Reloj e ID de petición
+───────────────────────────────────────────────────────────────────────────────*/
+
+func firstValue(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func requestID(ctx context.Context) string {
+	if id := firstValue(ctx, mmclient.RequestIDHeader); id != "" {
+		return id
+	}
+	return "-"
+}
+
+func withMetadata(ctx context.Context) context.Context {
+	if vc, _ := clockpropagation.FromIncoming(ctx); vc != nil {
+		ctx = context.WithValue(ctx, clockKey, vc)
+	}
+	if id := firstValue(ctx, mmclient.RequestIDHeader); id != "" {
+		ctx = context.WithValue(ctx, requestIDKey, id)
+	}
+	return ctx
+}
+
+// UnaryClock deja en el contexto el reloj y el ID de petición del cliente.
+func UnaryClock(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withMetadata(ctx), req)
+}
+
+// StreamClock hace lo mismo para streams.
+func StreamClock(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: ss, ctx: withMetadata(ss.Context())})
+}
+
+// contextStream reemplaza el contexto de un stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
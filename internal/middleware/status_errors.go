@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// statusCodes traduce los valores de los enums StatusCode del .proto. Un
+// valor que no figura es un rechazo por el estado del sistema
+// (FailedPrecondition).
+var statusCodes = map[string]codes.Code{
+	"OK": codes.OK,
+	// informativos: la RPC hizo lo que debía
+	"DRAINED":       codes.OK,
+	"AUTH_DISABLED": codes.OK,
+
+	"NOT_FOUND":       codes.NotFound,
+	"UNKNOWN_MATCH":   codes.NotFound,
+	"UNKNOWN_SERVER":  codes.NotFound,
+	"UNKNOWN_MODE":    codes.NotFound,
+	"PARTY_NOT_FOUND": codes.NotFound,
+	"LOBBY_NOT_FOUND": codes.NotFound,
+	"NOT_IN_QUEUE":    codes.NotFound,
+	"NOT_IN_MATCH":    codes.NotFound,
+	"NOT_IN_PARTY":    codes.NotFound,
+	"NOT_IN_LOBBY":    codes.NotFound,
+	"NOT_PENDING":     codes.NotFound,
+
+	"ALREADY_IN_QUEUE":   codes.AlreadyExists,
+	"ALREADY_REGISTERED": codes.AlreadyExists,
+	"ALREADY_IN_PARTY":   codes.AlreadyExists,
+	"ALREADY_IN_LOBBY":   codes.AlreadyExists,
+	"DUPLICATE":          codes.AlreadyExists,
+
+	"INVALID":         codes.InvalidArgument,
+	"INVALID_ADDRESS": codes.InvalidArgument,
+	"INVALID_PLAYER":  codes.InvalidArgument,
+	"INVALID_RATING":  codes.InvalidArgument,
+
+	"BANNED":           codes.PermissionDenied,
+	"NOT_OWNER":        codes.PermissionDenied,
+	"NOT_PARTY_LEADER": codes.PermissionDenied,
+	"NOT_A_PLAYER":     codes.PermissionDenied,
+	"WRONG_NAMESPACE":  codes.PermissionDenied,
+
+	// llenos: puede volver a intentarse más tarde, no en el acto
+	"BUSY":       codes.ResourceExhausted,
+	"PARTY_FULL": codes.ResourceExhausted,
+	"LOBBY_FULL": codes.ResourceExhausted,
+}
+
+// StatusCodeOf devuelve el código gRPC que corresponde al status_code de
+// resp (status en AdminUpdateResponse) y el nombre de su valor. Una
+// respuesta sin ese campo es OK.
+func StatusCodeOf(resp interface{}) (codes.Code, string) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return codes.OK, ""
+	}
+	r := msg.ProtoReflect()
+	fd := statusField(r.Descriptor())
+	if fd == nil {
+		return codes.OK, ""
+	}
+	v := fd.Enum().Values().ByNumber(r.Get(fd).Enum())
+	if v == nil {
+		return codes.Unknown, fmt.Sprint(r.Get(fd).Enum())
+	}
+	name := string(v.Name())
+	if c, ok := statusCodes[name]; ok {
+		return c, name
+	}
+	return codes.FailedPrecondition, name
+}
+
+// statusField devuelve el campo del enum StatusCode de la respuesta, o nil.
+func statusField(md protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	for _, name := range []protoreflect.Name{"status_code", "status"} {
+		fd := md.Fields().ByName(name)
+		if fd != nil && fd.Kind() == protoreflect.EnumKind && fd.Enum().Name() == "StatusCode" {
+			return fd
+		}
+	}
+	return nil
+}
+
+// StatusError devuelve el error gRPC de una respuesta rechazada, con la
+// respuesta como detalle, o nil si su status_code no es un rechazo.
+func StatusError(resp interface{}) error {
+	c, name := StatusCodeOf(resp)
+	if c == codes.OK {
+		return nil
+	}
+	msg := resp.(proto.Message)
+	text := name
+	if fd := msg.ProtoReflect().Descriptor().Fields().ByName("message"); fd != nil && fd.Kind() == protoreflect.StringKind {
+		if s := msg.ProtoReflect().Get(fd).String(); s != "" {
+			text += ": " + s
+		}
+	}
+	st, err := status.New(c, text).WithDetails(protoadapt.MessageV1Of(msg))
+	if err != nil {
+		return status.Error(c, text)
+	}
+	return st.Err()
+}
+
+// StatusErrors devuelve el interceptor que convierte los rechazos en errores
+// gRPC; con on en false deja pasar la respuesta tal cual. Va después de los
+// interceptores que deban ver el código (métricas) y antes de los que
+// responden rechazos propios en el cuerpo (sesiones), para convertirlos.
+func StatusErrors(on bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if !on || err != nil {
+			return resp, err
+		}
+		if serr := StatusError(resp); serr != nil {
+			return nil, serr
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,104 @@
+// Package provision levanta y retira GameServers bajo demanda. Docker lo hace
+// con el CLI de docker local: cada servidor es un contenedor con --rm cuyo
+// nombre deriva del namespace y del ID del servidor, así que retirarlo es un
+// `docker stop` (SIGTERM: el GameServer se da de baja del Matchmaker y el
+// contenedor se borra solo).
+//
+// Uso:
+//
+//	d := &provision.Docker{Image: "l3-gameserver", Network: "l3_sd-net", MatchmakerAddr: "matchmaker:50051"}
+//	err := d.Provision(ctx, "default", "auto-1")
+//	...
+//	err = d.Release(ctx, "default", "auto-1")
+package provision
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// puerto gRPC del GameServer dentro del contenedor
+const defaultPort = 60051
+
+// Docker crea GameServers como contenedores locales.
+type Docker struct {
+	Bin            string   // ejecutable de docker; "" = "docker"
+	Image          string   // imagen del GameServer (gameserver/Dockerfile)
+	Network        string   // red compartida con el Matchmaker; "" = la por defecto
+	MatchmakerAddr string   // MATCHMAKER_ADDR que recibe el GameServer
+	Port           int      // GRPC_PORT dentro del contenedor; 0 = 60051
+	Env            []string // variables extra, "CLAVE=valor"
+}
+
+// ContainerName es el nombre del contenedor de serverID en namespace.
+func ContainerName(namespace, serverID string) string {
+	return sanitize("l3-" + namespace + "-" + serverID)
+}
+
+// Provision arranca el contenedor de serverID y vuelve sin esperar a que el
+// GameServer se registre.
+func (d *Docker) Provision(ctx context.Context, namespace, serverID string) error {
+	port := d.Port
+	if port == 0 {
+		port = defaultPort
+	}
+	name := ContainerName(namespace, serverID)
+	args := []string{"run", "--detach", "--rm", "--name", name}
+	if d.Network != "" {
+		args = append(args, "--network", d.Network)
+	}
+	env := append([]string{
+		"MATCHMAKER_ADDR=" + d.MatchmakerAddr,
+		"SERVER_ID=" + serverID,
+		"NAMESPACE=" + namespace,
+		fmt.Sprintf("GRPC_PORT=%d", port),
+		fmt.Sprintf("ADVERTISE_ADDR=%s:%d", name, port),
+	}, d.Env...)
+	for _, kv := range env {
+		args = append(args, "--env", kv)
+	}
+	args = append(args, d.Image)
+	return d.run(ctx, args...)
+}
+
+// Release detiene el contenedor de serverID. Uno que ya no existe no es un
+// error.
+func (d *Docker) Release(ctx context.Context, namespace, serverID string) error {
+	err := d.run(ctx, "stop", ContainerName(namespace, serverID))
+	if err != nil && strings.Contains(err.Error(), "No such container") {
+		return nil
+	}
+	return err
+}
+
+// run ejecuta docker con args y agrega su stderr al error.
+func (d *Docker) run(ctx context.Context, args ...string) error {
+	bin := d.Bin
+	if bin == "" {
+		bin = "docker"
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("docker %s: %v: %s", args[0], err, msg)
+		}
+		return fmt.Errorf("docker %s: %v", args[0], err)
+	}
+	return nil
+}
+
+// sanitize deja sólo los caracteres que docker admite en un nombre.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		}
+		return '-'
+	}, name)
+}
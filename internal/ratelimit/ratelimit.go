@@ -0,0 +1,181 @@
+// Package ratelimit limita la frecuencia de una operación con token buckets:
+// uno por clave (p.ej. por jugador) y uno global que comparten todas. Cada
+// bucket se rellena a Rate fichas por segundo hasta Burst; una llamada
+// consume una ficha de su clave y otra del global, o ninguna si alguno está
+// vacío, y en ese caso informa cuánto falta para la próxima.
+//
+// Los buckets de clave que vuelven a estar llenos se descartan, así que la
+// memoria crece con las claves activas, no con todas las vistas.
+//
+// Uso:
+//
+//	l := ratelimit.New(ratelimit.Config{KeyRate: 1, KeyBurst: 5, GlobalRate: 500, GlobalBurst: 1000}, time.Now)
+//	if r := l.Allow(playerID); !r.OK {
+//		// rechazar; reintentar tras r.Wait
+//	}
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// cada cuántas llamadas se descartan los buckets de clave llenos
+const pruneEvery = 1024
+
+// Config fija los límites; un Rate de 0 desactiva ese nivel.
+type Config struct {
+	KeyRate     float64 // fichas por segundo de cada clave
+	KeyBurst    int     // máximo acumulable por clave (mínimo 1)
+	GlobalRate  float64 // fichas por segundo entre todas las claves
+	GlobalBurst int     // máximo acumulable global (mínimo 1)
+}
+
+// Stats resume la actividad del limitador desde su creación.
+type Stats struct {
+	Allowed        uint64
+	RejectedKey    uint64 // rechazos por el bucket de la clave
+	RejectedGlobal uint64 // rechazos por el bucket global
+	ActiveKeys     int    // claves con bucket aún no lleno
+	GlobalTokens   float64
+}
+
+// Result es la decisión de Allow.
+type Result struct {
+	OK     bool
+	Wait   time.Duration // hasta que haya fichas; 0 si OK
+	Global bool          // lo rechazó el bucket global, no el de la clave
+}
+
+type bucket struct {
+	rate, burst float64
+	tokens      float64
+	last        time.Time
+}
+
+func newBucket(rate float64, burst int, now time.Time) *bucket {
+	b := math.Max(float64(burst), 1)
+	return &bucket{rate: rate, burst: b, tokens: b, last: now}
+}
+
+// refill suma las fichas acumuladas desde la última vez.
+func (b *bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// wait es el tiempo hasta que haya una ficha entera; 0 si ya la hay.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// Limiter aplica Config; es seguro para uso concurrente.
+type Limiter struct {
+	cfg Config
+	now func() time.Time
+
+	mu     sync.Mutex
+	global *bucket            // nil = sin límite global
+	keys   map[string]*bucket // vacío si KeyRate es 0
+	calls  uint64
+	stats  Stats
+}
+
+// New crea un limitador con los buckets llenos.
+func New(cfg Config, now func() time.Time) *Limiter {
+	l := &Limiter{cfg: cfg, now: now, keys: make(map[string]*bucket)}
+	if cfg.GlobalRate > 0 {
+		l.global = newBucket(cfg.GlobalRate, cfg.GlobalBurst, now())
+	}
+	return l
+}
+
+// Config devuelve los límites vigentes.
+func (l *Limiter) Config() Config { return l.cfg }
+
+// Allow consume una ficha de key y una global. Si alguna falta no consume
+// ninguna e informa la espera hasta que ambas estén disponibles.
+func (l *Limiter) Allow(key string) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+
+	l.calls++
+	if l.calls%pruneEvery == 0 {
+		l.prune(now)
+	}
+
+	var kb *bucket
+	if l.cfg.KeyRate > 0 {
+		kb = l.keys[key]
+		if kb == nil {
+			kb = newBucket(l.cfg.KeyRate, l.cfg.KeyBurst, now)
+			l.keys[key] = kb
+		}
+		kb.refill(now)
+	}
+	if l.global != nil {
+		l.global.refill(now)
+	}
+
+	var r Result
+	if kb != nil {
+		r.Wait = kb.wait()
+	}
+	if l.global != nil && l.global.wait() > 0 {
+		r.Global = r.Wait == 0
+		if w := l.global.wait(); w > r.Wait {
+			r.Wait = w
+		}
+	}
+	switch {
+	case r.Global:
+		l.stats.RejectedGlobal++
+		return r
+	case r.Wait > 0:
+		l.stats.RejectedKey++
+		return r
+	}
+
+	if kb != nil {
+		kb.tokens--
+	}
+	if l.global != nil {
+		l.global.tokens--
+	}
+	l.stats.Allowed++
+	return Result{OK: true}
+}
+
+// prune descarta los buckets de clave que ya se rellenaron por completo:
+// recrearlos da el mismo resultado.
+// debe llamarse con l.mu bloqueado
+func (l *Limiter) prune(now time.Time) {
+	for key, b := range l.keys {
+		if b.refill(now); b.tokens >= b.burst {
+			delete(l.keys, key)
+		}
+	}
+}
+
+// Stats devuelve los contadores y el estado actual.
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.prune(now)
+
+	st := l.stats
+	st.ActiveKeys = len(l.keys)
+	if l.global != nil {
+		l.global.refill(now)
+		st.GlobalTokens = l.global.tokens
+	}
+	return st
+}
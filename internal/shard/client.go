@@ -0,0 +1,226 @@
+package shard
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// Client es un pb.MatchmakerClient para varios Matchmakers. QueuePlayer y
+// CreateLobby van al que atiende el modo pedido, que pasa a ser el actual
+// del jugador; el resto de las RPC de jugador (estado, cancelar, abandonar,
+// aceptar, revancha, latidos, opiniones, historial, salir de la sala) van al
+// actual.
+// RegisterPlayer se hace en todos, ResumeSession busca la sesión en todos,
+// JoinLobby busca la sala en todos y ListGameModes junta los modos de
+// todos. Las demás RPC, incluidos los grupos, van al Matchmaker por defecto
+// (el de MATCHMAKER_ADDR), que empieza siendo el actual.
+//
+// Las notificaciones llegan por el Matchmaker que emparejó al jugador: hay
+// que suscribirse a cada uno de Shards.
+type Client struct {
+	pb.MatchmakerClient // Matchmaker por defecto
+
+	routes   *Routes
+	fallback string
+	byAddr   map[string]pb.MatchmakerClient
+
+	mu      sync.Mutex
+	current pb.MatchmakerClient
+}
+
+// NewClient arma el cliente; dial devuelve el cliente de cada dirección de
+// routes.Addrs(fallback).
+func NewClient(routes *Routes, fallback string, dial func(addr string) (pb.MatchmakerClient, error)) (*Client, error) {
+	c := &Client{routes: routes, fallback: fallback, byAddr: map[string]pb.MatchmakerClient{}}
+	for _, addr := range routes.Addrs(fallback) {
+		cl, err := dial(addr)
+		if err != nil {
+			return nil, err
+		}
+		c.byAddr[addr] = cl
+	}
+	c.MatchmakerClient = c.byAddr[fallback]
+	c.current = c.MatchmakerClient
+	return c, nil
+}
+
+// Shards devuelve el cliente de cada Matchmaker, el por defecto primero.
+func (c *Client) Shards() []pb.MatchmakerClient {
+	out := make([]pb.MatchmakerClient, 0, len(c.byAddr))
+	for _, addr := range c.routes.Addrs(c.fallback) {
+		out = append(out, c.byAddr[addr])
+	}
+	return out
+}
+
+// Current devuelve el Matchmaker actual del jugador.
+func (c *Client) Current() pb.MatchmakerClient {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current
+}
+
+func (c *Client) setCurrent(cl pb.MatchmakerClient) {
+	c.mu.Lock()
+	c.current = cl
+	c.mu.Unlock()
+}
+
+func (c *Client) QueuePlayer(ctx context.Context, in *pb.PlayerInfoRequest, opts ...grpc.CallOption) (*pb.QueuePlayerResponse, error) {
+	cl := c.byAddr[c.routes.Addr(in.GetGameMode(), c.fallback)]
+	res, err := cl.QueuePlayer(ctx, in, opts...)
+	if err == nil && (res.GetStatusCode() == pb.QueuePlayerResponse_OK || res.GetStatusCode() == pb.QueuePlayerResponse_ALREADY_IN_QUEUE) {
+		c.setCurrent(cl)
+	}
+	return res, err
+}
+
+func (c *Client) CreateLobby(ctx context.Context, in *pb.CreateLobbyRequest, opts ...grpc.CallOption) (*pb.LobbyResponse, error) {
+	cl := c.byAddr[c.routes.Addr(in.GetGameMode(), c.fallback)]
+	res, err := cl.CreateLobby(ctx, in, opts...)
+	if err == nil && res.GetStatusCode() == pb.LobbyResponse_OK {
+		c.setCurrent(cl)
+	}
+	return res, err
+}
+
+// JoinLobby prueba la sala en cada Matchmaker, el por defecto primero: el
+// código no dice de qué modo es. Devuelve la primera respuesta que no sea
+// LOBBY_NOT_FOUND.
+func (c *Client) JoinLobby(ctx context.Context, in *pb.LobbyRequest, opts ...grpc.CallOption) (*pb.LobbyResponse, error) {
+	var last *pb.LobbyResponse
+	var firstErr error
+	for _, cl := range c.Shards() {
+		res, err := cl.JoinLobby(ctx, in, opts...)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if res.GetStatusCode() != pb.LobbyResponse_LOBBY_NOT_FOUND {
+			if res.GetStatusCode() == pb.LobbyResponse_OK {
+				c.setCurrent(cl)
+			}
+			return res, nil
+		}
+		last = res
+	}
+	if last == nil {
+		return nil, firstErr
+	}
+	return last, nil
+}
+
+func (c *Client) LeaveLobby(ctx context.Context, in *pb.LobbyRequest, opts ...grpc.CallOption) (*pb.LobbyResponse, error) {
+	return c.Current().LeaveLobby(ctx, in, opts...)
+}
+
+func (c *Client) CancelQueue(ctx context.Context, in *pb.CancelQueueRequest, opts ...grpc.CallOption) (*pb.CancelQueueResponse, error) {
+	return c.Current().CancelQueue(ctx, in, opts...)
+}
+
+func (c *Client) LeaveMatch(ctx context.Context, in *pb.LeaveMatchRequest, opts ...grpc.CallOption) (*pb.LeaveMatchResponse, error) {
+	return c.Current().LeaveMatch(ctx, in, opts...)
+}
+
+func (c *Client) AcceptMatch(ctx context.Context, in *pb.AcceptMatchRequest, opts ...grpc.CallOption) (*pb.AcceptMatchResponse, error) {
+	return c.Current().AcceptMatch(ctx, in, opts...)
+}
+
+func (c *Client) RequestRematch(ctx context.Context, in *pb.RematchRequest, opts ...grpc.CallOption) (*pb.RematchResponse, error) {
+	return c.Current().RequestRematch(ctx, in, opts...)
+}
+
+func (c *Client) PlayerHeartbeat(ctx context.Context, in *pb.PlayerHeartbeatRequest, opts ...grpc.CallOption) (*pb.PlayerHeartbeatResponse, error) {
+	return c.Current().PlayerHeartbeat(ctx, in, opts...)
+}
+
+func (c *Client) GetPlayerStatus(ctx context.Context, in *pb.PlayerStatusRequest, opts ...grpc.CallOption) (*pb.PlayerStatusResponse, error) {
+	return c.Current().GetPlayerStatus(ctx, in, opts...)
+}
+
+func (c *Client) SubmitMatchFeedback(ctx context.Context, in *pb.MatchFeedbackRequest, opts ...grpc.CallOption) (*pb.MatchFeedbackResponse, error) {
+	return c.Current().SubmitMatchFeedback(ctx, in, opts...)
+}
+
+func (c *Client) GetMatchHistory(ctx context.Context, in *pb.MatchHistoryRequest, opts ...grpc.CallOption) (*pb.MatchHistoryResponse, error) {
+	return c.Current().GetMatchHistory(ctx, in, opts...)
+}
+
+func (c *Client) GetMatchTimeline(ctx context.Context, in *pb.MatchTimelineRequest, opts ...grpc.CallOption) (*pb.MatchTimelineResponse, error) {
+	return c.Current().GetMatchTimeline(ctx, in, opts...)
+}
+
+// RegisterPlayer reclama el ID en cada Matchmaker y devuelve la respuesta
+// del por defecto; falla si falla alguno.
+func (c *Client) RegisterPlayer(ctx context.Context, in *pb.RegisterPlayerRequest, opts ...grpc.CallOption) (*pb.RegisterPlayerResponse, error) {
+	var first *pb.RegisterPlayerResponse
+	for _, cl := range c.Shards() {
+		res, err := cl.RegisterPlayer(ctx, in, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if first == nil {
+			first = res
+		}
+	}
+	return first, nil
+}
+
+// ResumeSession devuelve la primera sesión en cola o en partida y toma su
+// Matchmaker como actual; si no hay ninguna, la respuesta del por defecto.
+func (c *Client) ResumeSession(ctx context.Context, in *pb.ResumeSessionRequest, opts ...grpc.CallOption) (*pb.ResumeSessionResponse, error) {
+	var first *pb.ResumeSessionResponse
+	for _, cl := range c.Shards() {
+		res, err := cl.ResumeSession(ctx, in, opts...)
+		if err != nil {
+			return nil, err
+		}
+		switch res.GetStatus() {
+		case "IN_QUEUE", "IN_MATCH", "READY_CHECK":
+			c.setCurrent(cl)
+			return res, nil
+		}
+		if first == nil {
+			first = res
+		}
+	}
+	return first, nil
+}
+
+// ListGameModes junta los modos de todos los Matchmakers; un Matchmaker
+// que no responde no cuenta.
+func (c *Client) ListGameModes(ctx context.Context, in *pb.ListGameModesRequest, opts ...grpc.CallOption) (*pb.ListGameModesResponse, error) {
+	var out *pb.ListGameModesResponse
+	var firstErr error
+	seen := map[string]bool{}
+	for _, cl := range c.Shards() {
+		res, err := cl.ListGameModes(ctx, in, opts...)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if out == nil {
+			out = &pb.ListGameModesResponse{VectorClock: res.GetVectorClock()}
+		}
+		for _, g := range res.GetModes() {
+			if !seen[g.GetName()] {
+				seen[g.GetName()] = true
+				out.Modes = append(out.Modes, g)
+			}
+		}
+	}
+	if out == nil {
+		return nil, firstErr
+	}
+	sort.Slice(out.Modes, func(i, j int) bool { return out.Modes[i].GetName() < out.Modes[j].GetName() })
+	return out, nil
+}
@@ -0,0 +1,136 @@
+// Package shard reparte los modos de juego entre varios Matchmakers. Cada
+// Matchmaker atiende un subconjunto de modos (MATCHMAKER_MODES) y todos los
+// componentes comparten la tabla de rutas SHARD_ROUTES, que dice qué
+// Matchmaker atiende cada modo:
+//
+//	SHARD_ROUTES="1v1=mm-a:50051; 2v2,5v5=mm-b:50051,mm-b-backup:50051; *=mm-a:50051"
+//
+// Las rutas se separan con ";" o saltos de línea; cada una es una lista de
+// modos, "=" y un valor de MATCHMAKER_ADDR (una dirección, semillas o un
+// SRV; ver internal/discovery). "*" es la ruta de los modos que no figuran;
+// sin ella, esos modos van al MATCHMAKER_ADDR del componente. Las líneas que
+// empiezan con "#" se ignoran, así que la tabla puede venir de un archivo
+// (SHARD_ROUTES="$(cat rutas.txt)").
+//
+// Client envuelve un cliente por Matchmaker y encamina las RPC de jugador.
+package shard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/discovery"
+)
+
+// Default es el modo comodín de las rutas.
+const Default = "*"
+
+// Routes es una tabla de rutas ya validada. El valor cero no tiene rutas.
+type Routes struct {
+	byMode map[string]string
+	def    string
+	addrs  []string // direcciones distintas, en orden de aparición
+}
+
+// Parse interpreta text; una tabla vacía devuelve nil.
+func Parse(text string) (*Routes, error) {
+	r := &Routes{byMode: map[string]string{}}
+	text = strings.ReplaceAll(text, "\n", ";")
+	for _, raw := range strings.Split(text, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+		modes, addr, ok := strings.Cut(raw, "=")
+		addr = strings.TrimSpace(addr)
+		if !ok || strings.TrimSpace(modes) == "" || addr == "" {
+			return nil, fmt.Errorf("ruta %q: se esperaba modos=dirección", raw)
+		}
+		if _, err := discovery.Parse(addr); err != nil {
+			return nil, fmt.Errorf("ruta %q: %v", raw, err)
+		}
+		for _, mode := range strings.Split(modes, ",") {
+			mode = strings.TrimSpace(mode)
+			switch {
+			case mode == "":
+				return nil, fmt.Errorf("ruta %q: modo vacío", raw)
+			case mode == Default && r.def != "", r.byMode[mode] != "":
+				return nil, fmt.Errorf("modo %q con más de una ruta", mode)
+			case mode == Default:
+				r.def = addr
+			default:
+				r.byMode[mode] = addr
+			}
+		}
+		r.add(addr)
+	}
+	if len(r.addrs) == 0 {
+		return nil, nil
+	}
+	return r, nil
+}
+
+func (r *Routes) add(addr string) {
+	for _, a := range r.addrs {
+		if a == addr {
+			return
+		}
+	}
+	r.addrs = append(r.addrs, addr)
+}
+
+// FromConfig lee SHARD_ROUTES de cfg; nil si no se definió.
+func FromConfig(cfg *config.Report) *Routes {
+	var r *Routes
+	cfg.Spec("SHARD_ROUTES", "", func(raw string) (err error) {
+		r, err = Parse(raw)
+		return err
+	})
+	return r
+}
+
+// Addr devuelve el Matchmaker de mode: su ruta, la de "*" o fallback.
+func (r *Routes) Addr(mode, fallback string) string {
+	if r == nil {
+		return fallback
+	}
+	if a, ok := r.byMode[mode]; ok {
+		return a
+	}
+	if r.def != "" {
+		return r.def
+	}
+	return fallback
+}
+
+// Addrs lista los Matchmakers de la tabla más fallback (si no figura), sin
+// repetir: fallback primero.
+func (r *Routes) Addrs(fallback string) []string {
+	out := []string{fallback}
+	if r == nil {
+		return out
+	}
+	for _, a := range r.addrs {
+		if a != fallback {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Modes lista, en orden alfabético, los modos con ruta explícita a addr.
+func (r *Routes) Modes(addr string) []string {
+	if r == nil {
+		return nil
+	}
+	var out []string
+	for mode, a := range r.byMode {
+		if a == addr {
+			out = append(out, mode)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"slices"
+	"sync"
+)
+
+// lockStripes es la cantidad de candados de un Locks; potencia de dos.
+const lockStripes = 256
+
+// Locks serializa las operaciones por id. Los ids se reparten en franjas
+// con un candado cada una: dos ids de la misma franja comparten candado,
+// lo que sólo cuesta algo de paralelismo.
+type Locks struct {
+	stripes [lockStripes]sync.Mutex
+}
+
+// Lock bloquea los ids dados y devuelve la función que los libera. Toma
+// las franjas en orden creciente y sin repetir, así que dos llamadas con
+// ids en común no se interbloquean, cualquiera sea el orden en que los
+// reciban.
+func (l *Locks) Lock(ids ...string) (unlock func()) {
+	idx := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		idx = append(idx, shardIndex(id, lockStripes))
+	}
+	slices.Sort(idx)
+	idx = slices.Compact(idx)
+	for _, i := range idx {
+		l.stripes[i].Lock()
+	}
+	return func() {
+		for j := len(idx) - 1; j >= 0; j-- {
+			l.stripes[idx[j]].Unlock()
+		}
+	}
+}
+
+// LockAll bloquea todos los ids, en el mismo orden que Lock, y devuelve la
+// función que los libera. Sirve para leer una vista coherente de todas las
+// entidades sin excluir a quien sólo lee el resto del estado.
+func (l *Locks) LockAll() (unlock func()) {
+	for i := range l.stripes {
+		l.stripes[i].Lock()
+	}
+	return func() {
+		for i := len(l.stripes) - 1; i >= 0; i-- {
+			l.stripes[i].Unlock()
+		}
+	}
+}
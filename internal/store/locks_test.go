@@ -0,0 +1,189 @@
+// internal/store/locks_test.go
+//
+// Locks: ids repetidos o de la misma franja no se bloquean a sí mismos,
+// ids en común excluyen, y dos grupos que comparten jugadores en distinto
+// orden no se interbloquean (correr con -race).
+
+package store
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sameStripe devuelve un id distinto de id que cae en su misma franja.
+func sameStripe(t *testing.T, id string) string {
+	t.Helper()
+	want := shardIndex(id, lockStripes)
+	for i := 0; i < 100000; i++ {
+		other := fmt.Sprintf("X%d", i)
+		if other != id && shardIndex(other, lockStripes) == want {
+			return other
+		}
+	}
+	t.Fatalf("no hay otro id en la franja de %s", id)
+	return ""
+}
+
+// lockedWithin dice si Lock(ids...) se obtiene antes de d.
+func lockedWithin(l *Locks, d time.Duration, ids ...string) bool {
+	got := make(chan func(), 1)
+	go func() { got <- l.Lock(ids...) }()
+	select {
+	case unlock := <-got:
+		unlock()
+		return true
+	case <-time.After(d):
+		// Lock sigue esperando; se libera cuando el test suelte el candado.
+		go func() { (<-got)() }()
+		return false
+	}
+}
+
+func TestLocksLock(t *testing.T) {
+	collide := sameStripe(t, "P1")
+	cases := []struct {
+		name  string
+		held  []string
+		try   []string
+		block bool
+	}{
+		{"duplicados en una llamada", nil, []string{"P1", "P1", "P1"}, false},
+		{"misma franja en una llamada", nil, []string{"P1", collide}, false},
+		{"sin ids", []string{"P1"}, nil, false},
+		{"disjuntos", []string{"P1"}, []string{"P2"}, false},
+		{"mismo id", []string{"P1"}, []string{"P1"}, true},
+		{"solapados", []string{"P1", "P2"}, []string{"P2", "P3"}, true},
+		{"misma franja", []string{"P1"}, []string{collide}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var l Locks
+			if tc.held != nil {
+				unlock := l.Lock(tc.held...)
+				defer unlock()
+			}
+			wait := time.Second
+			if tc.block {
+				wait = 50 * time.Millisecond
+			}
+			if got := lockedWithin(&l, wait, tc.try...); got == tc.block {
+				t.Fatalf("Lock(%v) con %v tomado: obtenido=%v, se esperaba bloqueo=%v", tc.try, tc.held, got, tc.block)
+			}
+		})
+	}
+}
+
+// Liberar permite que el que esperaba avance.
+func TestLocksUnlockReleases(t *testing.T) {
+	var l Locks
+	unlock := l.Lock("P1", "P2")
+	done := make(chan struct{})
+	go func() {
+		l.Lock("P2", "P1")()
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Lock no esperó al dueño")
+	case <-time.After(20 * time.Millisecond):
+	}
+	unlock()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Lock siguió bloqueado tras liberar")
+	}
+}
+
+// Dos partidas que comparten jugadores, pidiéndolos en órdenes opuestos y
+// muchas veces en paralelo: con franjas ordenadas nunca se interbloquean, y
+// el contador protegido no pierde incrementos.
+func TestLocksSharedPlayersNoDeadlock(t *testing.T) {
+	var l Locks
+	groups := [][]string{
+		{"P1", "P2", "P3"},
+		{"P3", "P2", "P1"},
+		{"P2", "P4", "P1"},
+		{"P4", "P3"},
+	}
+	const rounds = 2000
+	counter := 0
+	var wg sync.WaitGroup
+	for _, ids := range groups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				unlock := l.Lock(ids...)
+				counter++ // cada par de grupos comparte algún jugador
+				unlock()
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() { wg.Wait(); close(done) }()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("interbloqueo: los grupos no terminaron")
+	}
+	if want := rounds * len(groups); counter != want {
+		t.Fatalf("counter = %d, se esperaba %d", counter, want)
+	}
+}
+
+// LockAll excluye a cualquier Lock hasta liberar, y toma las franjas en el
+// mismo orden: no se interbloquea con grupos que piden en paralelo.
+func TestLocksLockAll(t *testing.T) {
+	var l Locks
+	unlock := l.LockAll()
+	if lockedWithin(&l, 50*time.Millisecond, "P1") {
+		t.Fatal("Lock(P1) obtenido con LockAll tomado")
+	}
+	unlock()
+	if !lockedWithin(&l, time.Second, "P1", "P2") {
+		t.Fatal("Lock siguió bloqueado tras liberar LockAll")
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				l.Lock("P3", "P1", "P2")()
+			}
+		}
+	}()
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 200; i++ {
+			l.LockAll()()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("interbloqueo entre LockAll y Lock")
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func BenchmarkLocksParty(b *testing.B) {
+	var l Locks
+	b.RunParallel(func(pb *testing.PB) {
+		ids := []string{"P1", "P2", "P3", "P4"}
+		for pb.Next() {
+			l.Lock(ids...)()
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+)
+
+// Entry es un lugar en la cola. Lleva el modo y la prioridad con que se
+// encoló, para ordenar y contar posiciones sin consultar al jugador.
+type Entry struct {
+	ID       string
+	Mode     string
+	Priority int // mayor va antes
+}
+
+// Queue es una cola ordenada por prioridad descendente, FIFO dentro de cada
+// nivel: una lista enlazada más un índice por id, así que quitar una
+// entrada no recorre la cola.
+type Queue struct {
+	mu    sync.Mutex
+	order list.List                  // de Entry
+	index map[string][]*list.Element // id → sus entradas (una, salvo error)
+}
+
+// Len devuelve la cantidad de entradas.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.order.Len()
+}
+
+// IDs devuelve una copia de los ids en orden.
+func (q *Queue) IDs() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ids := make([]string, 0, q.order.Len())
+	for e := q.order.Front(); e != nil; e = e.Next() {
+		ids = append(ids, e.Value.(Entry).ID)
+	}
+	return ids
+}
+
+// Clone devuelve una cola nueva con las mismas entradas.
+func (q *Queue) Clone() *Queue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := new(Queue)
+	for e := q.order.Front(); e != nil; e = e.Next() {
+		out.push(e.Value.(Entry))
+	}
+	return out
+}
+
+// Insert pone e al final de su nivel de prioridad o, con front, al
+// principio de él. Busca desde el final: el caso común (prioridad normal,
+// al final) no recorre la cola.
+func (q *Queue) Insert(e Entry, front bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	at := q.order.Back()
+	for at != nil {
+		if p := at.Value.(Entry).Priority; p > e.Priority || (!front && p == e.Priority) {
+			break
+		}
+		at = at.Prev()
+	}
+	var el *list.Element
+	if at == nil {
+		el = q.order.PushFront(e)
+	} else {
+		el = q.order.InsertAfter(e, at)
+	}
+	q.track(e.ID, el)
+}
+
+// Append pone e al final, sin mirar su prioridad (p.ej. al restaurar una
+// cola ya ordenada).
+func (q *Queue) Append(e Entry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.push(e)
+}
+
+// push es Append con q.mu bloqueado.
+func (q *Queue) push(e Entry) {
+	q.track(e.ID, q.order.PushBack(e))
+}
+
+// track agrega el elemento el al índice de id. Debe llamarse con q.mu bloqueado.
+func (q *Queue) track(id string, el *list.Element) {
+	if q.index == nil {
+		q.index = make(map[string][]*list.Element)
+	}
+	q.index[id] = append(q.index[id], el)
+}
+
+// Remove quita una entrada de id y dice si estaba.
+func (q *Queue) Remove(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	els := q.index[id]
+	if len(els) == 0 {
+		return false
+	}
+	q.order.Remove(els[0])
+	if len(els) == 1 {
+		delete(q.index, id)
+	} else {
+		q.index[id] = els[1:]
+	}
+	return true
+}
+
+// Position devuelve el lugar de id entre las entradas de su modo (1 = la
+// primera), o 0 si no está.
+func (q *Queue) Position(id string) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	els := q.index[id]
+	if len(els) == 0 {
+		return 0
+	}
+	mode := els[0].Value.(Entry).Mode
+	pos := 1
+	for e := els[0].Prev(); e != nil; e = e.Prev() {
+		if e.Value.(Entry).Mode == mode {
+			pos++
+		}
+	}
+	return pos
+}
+
+// SortStable reordena la cola con less, que compara ids, conservando el
+// orden relativo de las entradas equivalentes. less no debe usar la cola.
+func (q *Queue) SortStable(less func(a, b string) bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entries := make([]Entry, 0, q.order.Len())
+	for e := q.order.Front(); e != nil; e = e.Next() {
+		entries = append(entries, e.Value.(Entry))
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return less(entries[i].ID, entries[j].ID) })
+	q.order.Init()
+	q.index = nil
+	for _, e := range entries {
+		q.push(e)
+	}
+}
@@ -0,0 +1,132 @@
+// internal/store/queue_test.go
+//
+// Queue: orden por prioridad descendente y FIFO dentro de cada nivel,
+// inserción al frente, quitar por id, posición por modo y reordenamiento
+// estable.
+
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestQueueOrdering(t *testing.T) {
+	type ins struct {
+		id       string
+		priority int
+		front    bool
+	}
+	cases := []struct {
+		name string
+		ins  []ins
+		want string
+	}{
+		{"fifo", []ins{{"A", 0, false}, {"B", 0, false}, {"C", 0, false}}, "A,B,C"},
+		{"prioridad primero", []ins{{"A", 0, false}, {"B", 1, false}, {"C", 0, false}}, "B,A,C"},
+		{"fifo por nivel", []ins{{"A", 1, false}, {"B", 0, false}, {"C", 1, false}, {"D", 0, false}}, "A,C,B,D"},
+		{"al frente de su nivel", []ins{{"A", 0, false}, {"B", 0, false}, {"C", 0, true}}, "C,A,B"},
+		{"al frente sin pasar a mayores", []ins{{"A", 2, false}, {"B", 0, false}, {"C", 0, true}}, "A,C,B"},
+		{"menor al final", []ins{{"A", 1, false}, {"B", -1, true}, {"C", 0, false}}, "A,C,B"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var q Queue
+			for _, e := range tc.ins {
+				q.Insert(Entry{ID: e.id, Priority: e.priority}, e.front)
+			}
+			if got := strings.Join(q.IDs(), ","); got != tc.want {
+				t.Fatalf("IDs = %s, se esperaba %s", got, tc.want)
+			}
+			if q.Len() != len(tc.ins) {
+				t.Fatalf("Len = %d", q.Len())
+			}
+		})
+	}
+}
+
+func TestQueueRemove(t *testing.T) {
+	var q Queue
+	for _, id := range []string{"A", "B", "C"} {
+		q.Insert(Entry{ID: id}, false)
+	}
+	if !q.Remove("B") {
+		t.Fatal("Remove(B) = false")
+	}
+	if q.Remove("B") {
+		t.Fatal("Remove(B) dos veces = true")
+	}
+	if q.Remove("Z") {
+		t.Fatal("Remove(Z) = true")
+	}
+	if got := strings.Join(q.IDs(), ","); got != "A,C" {
+		t.Fatalf("IDs = %s", got)
+	}
+
+	// Una entrada duplicada (error del llamador) se quita de a una.
+	q.Append(Entry{ID: "A"})
+	q.Remove("A")
+	if got := strings.Join(q.IDs(), ","); got != "C,A" {
+		t.Fatalf("tras quitar un A duplicado: %s", got)
+	}
+}
+
+func TestQueuePositionByMode(t *testing.T) {
+	var q Queue
+	for _, e := range []Entry{
+		{ID: "A", Mode: "1v1"},
+		{ID: "B", Mode: "2v2"},
+		{ID: "C", Mode: "1v1"},
+		{ID: "D", Mode: "2v2", Priority: 1},
+	} {
+		q.Insert(e, false)
+	}
+	want := map[string]int{"D": 1, "A": 1, "B": 2, "C": 2, "Z": 0}
+	for id, pos := range want {
+		if got := q.Position(id); got != pos {
+			t.Errorf("Position(%s) = %d, se esperaba %d", id, got, pos)
+		}
+	}
+}
+
+func TestQueueCloneAndSortStable(t *testing.T) {
+	var q Queue
+	for _, id := range []string{"b1", "a1", "b2", "a2"} {
+		q.Insert(Entry{ID: id}, false)
+	}
+	c := q.Clone()
+	// Ordena sólo por la letra: el número conserva el orden previo.
+	q.SortStable(func(a, b string) bool { return a[0] < b[0] })
+	if got := strings.Join(q.IDs(), ","); got != "a1,a2,b1,b2" {
+		t.Fatalf("SortStable = %s", got)
+	}
+	if got := strings.Join(c.IDs(), ","); got != "b1,a1,b2,a2" {
+		t.Fatalf("el clon cambió con la original: %s", got)
+	}
+	// El índice se reconstruye: Remove sigue encontrando las entradas.
+	if !q.Remove("b1") || q.Position("b2") != 3 {
+		t.Fatalf("índice roto tras SortStable: %v", q.IDs())
+	}
+	if !slices.Equal(c.IDs(), []string{"b1", "a1", "b2", "a2"}) {
+		t.Fatal("Remove en la original tocó el clon")
+	}
+}
+
+// Encolar y quitar del medio de una cola larga no debe recorrerla.
+func BenchmarkQueueInsertRemove(b *testing.B) {
+	for _, queued := range []int{1000, 20000} {
+		b.Run(fmt.Sprintf("queued=%d", queued), func(b *testing.B) {
+			var q Queue
+			for i := 0; i < queued; i++ {
+				q.Insert(Entry{ID: fmt.Sprintf("Q%d", i)}, false)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				q.Insert(Entry{ID: "P"}, false)
+				q.Remove("P")
+			}
+		})
+	}
+}
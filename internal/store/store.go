@@ -0,0 +1,148 @@
+// Package store guarda estado en memoria con bloqueo por entidad, para que
+// operaciones sobre entidades distintas no compitan por un único mutex:
+//
+//   - Map: mapa string → V repartido en shards, cada uno con su candado.
+//   - Locks: candados por id (por franjas) para serializar las operaciones
+//     sobre una misma entidad, o sobre un grupo de ellas sin interbloqueos.
+//   - Queue: cola ordenada por prioridad con candado propio, cuyas entradas
+//     llevan el modo y la prioridad para no tener que consultar al jugador.
+//
+// Todos los tipos son seguros para uso concurrente y su valor cero está
+// listo para usarse. Ninguno bloquea mientras el llamador recorre su
+// contenido: Map.All y Queue.IDs trabajan sobre una copia.
+//
+// Uso:
+//
+//	var players store.Map[*player]
+//	var locks store.Locks
+//	unlock := locks.Lock("P1", "P2")
+//	p, _ := players.GetOrCreate("P1", func() *player { return &player{} })
+//	...
+//	unlock()
+package store
+
+import (
+	"iter"
+	"sync"
+)
+
+// shardCount es la cantidad de shards de un Map; potencia de dos.
+const shardCount = 64
+
+// Map es un mapa string → V particionado en shards.
+type Map[V any] struct {
+	shards [shardCount]mapShard[V]
+}
+
+type mapShard[V any] struct {
+	mu sync.RWMutex
+	m  map[string]V
+}
+
+// shardIndex reparte las claves entre n shards (potencia de dos) con FNV-1a.
+func shardIndex(key string, n uint32) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return h & (n - 1)
+}
+
+func (m *Map[V]) shard(key string) *mapShard[V] {
+	return &m.shards[shardIndex(key, shardCount)]
+}
+
+// Get devuelve el valor de key y si existe.
+func (m *Map[V]) Get(key string) (V, bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	return v, ok
+}
+
+// Value devuelve el valor de key, o el valor cero de V si no existe.
+func (m *Map[V]) Value(key string) V {
+	v, _ := m.Get(key)
+	return v
+}
+
+// Put guarda v bajo key, reemplazando el anterior.
+func (m *Map[V]) Put(key string, v V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[string]V)
+	}
+	s.m[key] = v
+}
+
+// GetOrCreate devuelve el valor de key o, si no existe, guarda y devuelve el
+// que construye create. El segundo resultado indica si se creó. Dos llamadas
+// concurrentes con la misma clave devuelven el mismo valor.
+func (m *Map[V]) GetOrCreate(key string, create func() V) (V, bool) {
+	if v, ok := m.Get(key); ok {
+		return v, false
+	}
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok {
+		return v, false
+	}
+	if s.m == nil {
+		s.m = make(map[string]V)
+	}
+	v := create()
+	s.m[key] = v
+	return v, true
+}
+
+// Delete quita key; si no existe no hace nada.
+func (m *Map[V]) Delete(key string) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+}
+
+// Len devuelve la cantidad de claves.
+func (m *Map[V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// All recorre las claves y valores, sin orden definido. Copia cada shard
+// antes de recorrerlo, así que el cuerpo del bucle puede modificar el mapa;
+// los cambios en shards aún no visitados sí se ven.
+func (m *Map[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		type kv struct {
+			k string
+			v V
+		}
+		var buf []kv
+		for i := range m.shards {
+			s := &m.shards[i]
+			s.mu.RLock()
+			buf = buf[:0]
+			for k, v := range s.m {
+				buf = append(buf, kv{k, v})
+			}
+			s.mu.RUnlock()
+			for _, e := range buf {
+				if !yield(e.k, e.v) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// internal/store/store_test.go
+//
+// Map: lectura, escritura y borrado por clave repartidos entre shards,
+// GetOrCreate concurrente y recorrido con All (incluso modificando el mapa
+// dentro del bucle).
+//
+//	go test ./internal/store -run ^$ -bench . -cpu 1,4
+
+package store
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMapGetPutDelete(t *testing.T) {
+	type op struct {
+		kind string // "put", "delete"
+		key  string
+		v    int
+	}
+	cases := []struct {
+		name string
+		ops  []op
+		want map[string]int
+	}{
+		{"vacío", nil, map[string]int{}},
+		{"put", []op{{"put", "P1", 1}, {"put", "P2", 2}}, map[string]int{"P1": 1, "P2": 2}},
+		{"reemplaza", []op{{"put", "P1", 1}, {"put", "P1", 5}}, map[string]int{"P1": 5}},
+		{"borra", []op{{"put", "P1", 1}, {"put", "P2", 2}, {"delete", "P1", 0}}, map[string]int{"P2": 2}},
+		{"borra inexistente", []op{{"delete", "P9", 0}}, map[string]int{}},
+		{"borra y vuelve", []op{{"put", "P1", 1}, {"delete", "P1", 0}, {"put", "P1", 3}}, map[string]int{"P1": 3}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var m Map[int]
+			for _, o := range tc.ops {
+				switch o.kind {
+				case "put":
+					m.Put(o.key, o.v)
+				case "delete":
+					m.Delete(o.key)
+				}
+			}
+			if m.Len() != len(tc.want) {
+				t.Fatalf("Len = %d, se esperaba %d", m.Len(), len(tc.want))
+			}
+			for k, want := range tc.want {
+				if v, ok := m.Get(k); !ok || v != want {
+					t.Fatalf("Get(%q) = (%d, %v), se esperaba (%d, true)", k, v, ok, want)
+				}
+			}
+			if v, ok := m.Get("ausente"); ok || v != 0 {
+				t.Fatalf("Get(ausente) = (%d, %v)", v, ok)
+			}
+			if got := maps.Collect(m.All()); !maps.Equal(got, tc.want) {
+				t.Fatalf("All = %v, se esperaba %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// Muchas claves caen en todos los shards y All las devuelve todas una vez.
+func TestMapAllCoversEveryShard(t *testing.T) {
+	var m Map[int]
+	const n = 10 * shardCount
+	for i := 0; i < n; i++ {
+		m.Put(fmt.Sprintf("P%d", i), i)
+	}
+	seen := make(map[string]int)
+	for k, v := range m.All() {
+		seen[k]++
+		if want := fmt.Sprintf("P%d", v); k != want {
+			t.Fatalf("All entregó %q → %d", k, v)
+		}
+	}
+	if len(seen) != n {
+		t.Fatalf("All recorrió %d claves, se esperaban %d", len(seen), n)
+	}
+	for k, c := range seen {
+		if c != 1 {
+			t.Fatalf("All entregó %q %d veces", k, c)
+		}
+	}
+}
+
+// El cuerpo del bucle puede borrar del mapa sin interbloquearse, y cortar
+// el recorrido lo detiene.
+func TestMapAllAllowsMutation(t *testing.T) {
+	var m Map[int]
+	for i := 0; i < 100; i++ {
+		m.Put(fmt.Sprintf("P%d", i), i)
+	}
+	for k := range m.All() {
+		m.Delete(k)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("Len = %d tras borrar todo dentro de All", m.Len())
+	}
+
+	for i := 0; i < 100; i++ {
+		m.Put(fmt.Sprintf("P%d", i), i)
+	}
+	visited := 0
+	for range m.All() {
+		visited++
+		if visited == 3 {
+			break
+		}
+	}
+	if visited != 3 {
+		t.Fatalf("All siguió después del break: %d", visited)
+	}
+}
+
+func TestMapGetOrCreateConcurrent(t *testing.T) {
+	var m Map[*int]
+	var created atomic.Int32
+	var wg sync.WaitGroup
+	got := make([]*int, 16)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i], _ = m.GetOrCreate("P1", func() *int { created.Add(1); return new(int) })
+		}()
+	}
+	wg.Wait()
+	if created.Load() != 1 {
+		t.Fatalf("create se llamó %d veces", created.Load())
+	}
+	for i, p := range got {
+		if p != got[0] {
+			t.Fatalf("la llamada %d devolvió otro valor", i)
+		}
+	}
+	if _, isNew := m.GetOrCreate("P1", func() *int { t.Fatal("create con clave existente"); return nil }); isNew {
+		t.Fatal("GetOrCreate informó creación de una clave existente")
+	}
+}
+
+func BenchmarkMapGet(b *testing.B) {
+	var m Map[int]
+	for i := 0; i < 5000; i++ {
+		m.Put(fmt.Sprintf("P%d", i), i)
+	}
+	var next atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		key := fmt.Sprintf("P%d", next.Add(1)%5000)
+		for pb.Next() {
+			m.Get(key)
+		}
+	})
+}
+
+func BenchmarkMapPutDelete(b *testing.B) {
+	var m Map[int]
+	var next atomic.Int64
+	b.RunParallel(func(pb *testing.PB) {
+		key := fmt.Sprintf("P%d", next.Add(1))
+		for pb.Next() {
+			m.Put(key, 1)
+			m.Delete(key)
+		}
+	})
+}
@@ -0,0 +1,312 @@
+// Package testkit reúne dobles de prueba para los tests del Matchmaker.
+//
+// FakeGameServer implementa el servicio GameServer sobre una red en memoria
+// (bufconn) y responde a cada AssignMatch según un guion de
+// comportamientos, para recorrer de forma determinista los caminos de fallo
+// del Matchmaker:
+//
+//	net := testkit.NewNetwork()
+//	gs := testkit.NewFakeGameServer(t, net, "gs1:60051", mmClient)
+//	gs.Script(testkit.Reject, testkit.Accept)
+//	mm.dialer = net.Dial
+package testkit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// Behavior es la reacción del servidor falso ante un AssignMatch.
+type Behavior int
+
+const (
+	// Accept acepta la partida e informa su resultado al Matchmaker.
+	Accept Behavior = iota
+	// Reject responde BUSY sin aceptar la partida.
+	Reject
+	// Delay espera FakeGameServer.Delay (o a que venza el plazo de la
+	// llamada) antes de aceptar.
+	Delay
+	// CrashAfterAccept acepta y luego deja de escuchar, como un proceso
+	// que muere a mitad de partida: nunca informa resultado ni estado.
+	CrashAfterAccept
+	// NeverReport acepta y nunca informa el resultado.
+	NeverReport
+	// Unavailable falla con UNAVAILABLE sin tocar la partida, como un
+	// servidor que reinicia o una conexión que se corta.
+	Unavailable
+)
+
+func (b Behavior) String() string {
+	switch b {
+	case Accept:
+		return "Accept"
+	case Reject:
+		return "Reject"
+	case Delay:
+		return "Delay"
+	case CrashAfterAccept:
+		return "CrashAfterAccept"
+	case NeverReport:
+		return "NeverReport"
+	case Unavailable:
+		return "Unavailable"
+	}
+	return fmt.Sprintf("Behavior(%d)", int(b))
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                              Red en memoria
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Network asocia direcciones ficticias ("gs1:60051") a listeners bufconn.
+type Network struct {
+	mu        sync.Mutex
+	listeners map[string]*bufconn.Listener
+}
+
+func NewNetwork() *Network {
+	return &Network{listeners: make(map[string]*bufconn.Listener)}
+}
+
+// Dial conecta con el listener registrado en addr. Tiene la firma de
+// grpc.WithContextDialer.
+func (n *Network) Dial(ctx context.Context, addr string) (net.Conn, error) {
+	n.mu.Lock()
+	lis, ok := n.listeners[addr]
+	n.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("testkit: nadie escucha en %s", addr)
+	}
+	return lis.DialContext(ctx)
+}
+
+func (n *Network) listen(addr string) *bufconn.Listener {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	lis := bufconn.Listen(1 << 20)
+	n.listeners[addr] = lis
+	return lis
+}
+
+func (n *Network) unlisten(addr string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if lis, ok := n.listeners[addr]; ok {
+		lis.Close()
+		delete(n.listeners, addr)
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                              FakeGameServer
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Assignment es un AssignMatch recibido y el comportamiento aplicado.
+type Assignment struct {
+	MatchID  string
+	Players  []string
+	Attempt  int32 // AssignMatchRequest.attempt
+	Behavior Behavior
+}
+
+// FakeGameServer responde AssignMatch según su guion. Con un cliente del
+// Matchmaker, Accept informa el resultado (ganador: el primer jugador).
+type FakeGameServer struct {
+	pb.UnimplementedGameServerServer
+
+	ID        string
+	Addr      string
+	Delay     time.Duration // para Delay
+	MatchAddr string        // match_addr de las partidas aceptadas; vacío = ninguna
+
+	net *Network
+	srv *grpc.Server
+	mm  pb.MatchmakerClient
+
+	mu        sync.Mutex
+	script    []Behavior
+	assigned  []Assignment
+	cancelled []string
+	crashed   bool
+	notify    chan struct{}
+}
+
+// NewFakeGameServer registra un servidor falso en addr; se detiene al
+// terminar el test. mm puede ser nil si no se quiere informar resultados.
+func NewFakeGameServer(t *testing.T, n *Network, addr string, mm pb.MatchmakerClient) *FakeGameServer {
+	t.Helper()
+	gs := &FakeGameServer{
+		ID:     addr,
+		Addr:   addr,
+		net:    n,
+		srv:    grpc.NewServer(),
+		mm:     mm,
+		script: []Behavior{Accept},
+		notify: make(chan struct{}, 1),
+	}
+	pb.RegisterGameServerServer(gs.srv, gs)
+	go gs.srv.Serve(n.listen(addr))
+	t.Cleanup(gs.Crash)
+	return gs
+}
+
+// Script fija los comportamientos de los próximos AssignMatch, en orden; el
+// último se repite indefinidamente.
+func (gs *FakeGameServer) Script(behaviors ...Behavior) {
+	if len(behaviors) == 0 {
+		behaviors = []Behavior{Accept}
+	}
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.script = append([]Behavior(nil), behaviors...)
+}
+
+// next consume el siguiente comportamiento del guion.
+func (gs *FakeGameServer) next() Behavior {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	b := gs.script[0]
+	if len(gs.script) > 1 {
+		gs.script = gs.script[1:]
+	}
+	return b
+}
+
+// Crash deja de escuchar: las conexiones nuevas fallan.
+func (gs *FakeGameServer) Crash() {
+	gs.mu.Lock()
+	if gs.crashed {
+		gs.mu.Unlock()
+		return
+	}
+	gs.crashed = true
+	gs.mu.Unlock()
+
+	gs.net.unlisten(gs.Addr)
+	gs.srv.Stop()
+}
+
+// Assigned devuelve una copia de los AssignMatch recibidos.
+func (gs *FakeGameServer) Assigned() []Assignment {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	return append([]Assignment(nil), gs.assigned...)
+}
+
+// WaitAssigned espera hasta haber recibido n AssignMatch.
+func (gs *FakeGameServer) WaitAssigned(t *testing.T, n int, timeout time.Duration) []Assignment {
+	t.Helper()
+	deadline := time.After(timeout)
+	for {
+		if got := gs.Assigned(); len(got) >= n {
+			return got
+		}
+		select {
+		case <-gs.notify:
+		case <-deadline:
+			t.Fatalf("%s: %d AssignMatch tras %v, se esperaban %d", gs.ID, len(gs.Assigned()), timeout, n)
+		}
+	}
+}
+
+func (gs *FakeGameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	b := gs.next()
+
+	gs.mu.Lock()
+	gs.assigned = append(gs.assigned, Assignment{
+		MatchID:  req.GetMatchId(),
+		Players:  append([]string(nil), req.GetPlayerIds()...),
+		Attempt:  req.GetAttempt(),
+		Behavior: b,
+	})
+	gs.mu.Unlock()
+	select {
+	case gs.notify <- struct{}{}:
+	default:
+	}
+
+	switch b {
+	case Unavailable:
+		return nil, status.Error(codes.Unavailable, "servidor no disponible")
+	case Reject:
+		return &pb.AssignMatchResponse{StatusCode: pb.AssignMatchResponse_BUSY, Message: "servidor lleno"}, nil
+	case Delay:
+		select {
+		case <-time.After(gs.Delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	case CrashAfterAccept:
+		// las conexiones nuevas fallan desde ya; GracefulStop deja salir
+		// esta respuesta antes de cerrar
+		gs.net.unlisten(gs.Addr)
+		go gs.srv.GracefulStop()
+	case Accept:
+		if gs.mm != nil {
+			go gs.report(req)
+		}
+	}
+	return &pb.AssignMatchResponse{StatusCode: pb.AssignMatchResponse_OK, MatchAddr: gs.MatchAddr}, nil
+}
+
+// CancelMatch registra la cancelación; el servidor falso no juega partidas,
+// así que siempre responde OK.
+func (gs *FakeGameServer) CancelMatch(ctx context.Context, req *pb.CancelMatchRequest) (*pb.CancelMatchResponse, error) {
+	gs.mu.Lock()
+	gs.cancelled = append(gs.cancelled, req.GetMatchId())
+	gs.mu.Unlock()
+	select {
+	case gs.notify <- struct{}{}:
+	default:
+	}
+	return &pb.CancelMatchResponse{StatusCode: pb.CancelMatchResponse_OK}, nil
+}
+
+// WaitCancelled espera hasta haber recibido n CancelMatch y devuelve sus
+// MatchID.
+func (gs *FakeGameServer) WaitCancelled(t *testing.T, n int, timeout time.Duration) []string {
+	t.Helper()
+	deadline := time.After(timeout)
+	for {
+		gs.mu.Lock()
+		got := append([]string(nil), gs.cancelled...)
+		gs.mu.Unlock()
+		if len(got) >= n {
+			return got
+		}
+		select {
+		case <-gs.notify:
+		case <-deadline:
+			t.Fatalf("%s: %d CancelMatch tras %v, se esperaban %d", gs.ID, len(got), timeout, n)
+		}
+	}
+}
+
+// report informa el resultado de una partida aceptada.
+func (gs *FakeGameServer) report(req *pb.AssignMatchRequest) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res := &pb.MatchResultRequest{
+		MatchId:    req.GetMatchId(),
+		ServerId:   gs.ID,
+		DurationMs: 1,
+		Namespace:  req.GetNamespace(),
+	}
+	if players := req.GetPlayerIds(); len(players) > 0 {
+		res.WinnerId = players[0]
+	}
+	gs.mm.ReportMatchResult(ctx, res)
+}
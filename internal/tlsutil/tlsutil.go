@@ -0,0 +1,92 @@
+// Package tlsutil construye las credenciales gRPC (TLS, mTLS o sin cifrar)
+// a partir de los archivos leídos con config.Report.TLS.
+//
+// Uso:
+//
+//	files := cfg.TLS()
+//	cfg.MustValidate()
+//	creds, err := tlsutil.ServerCredentials(files)
+//	srv := grpc.NewServer(grpc.Creds(creds))
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/vimsent/L3/internal/config"
+)
+
+// ServerCredentials devuelve credenciales para grpc.Creds. Sin TLS
+// configurado devuelve credenciales inseguras (modo laboratorio).
+func ServerCredentials(f config.TLSFiles) (credentials.TransportCredentials, error) {
+	if !f.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	if f.Cert == "" {
+		return nil, fmt.Errorf("un servidor TLS necesita TLS_CERT y TLS_KEY")
+	}
+	cert, err := tls.LoadX509KeyPair(f.Cert, f.Key)
+	if err != nil {
+		return nil, fmt.Errorf("cargando certificado: %w", err)
+	}
+
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if f.RequireClientCert {
+		pool, err := loadCA(f.CA)
+		if err != nil {
+			return nil, err
+		}
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(conf), nil
+}
+
+// ClientCredentials devuelve credenciales para grpc.WithTransportCredentials.
+// Verifica al servidor con TLS_CA (o las raíces del sistema) y, si hay
+// TLS_CERT/TLS_KEY, presenta ese certificado para mTLS.
+func ClientCredentials(f config.TLSFiles) (credentials.TransportCredentials, error) {
+	if !f.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	conf := &tls.Config{
+		ServerName: f.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if f.CA != "" {
+		pool, err := loadCA(f.CA)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootCAs = pool
+	}
+	if f.Cert != "" {
+		cert, err := tls.LoadX509KeyPair(f.Cert, f.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cargando certificado de cliente: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(conf), nil
+}
+
+func loadCA(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("leyendo CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s no contiene certificados PEM válidos", path)
+	}
+	return pool, nil
+}
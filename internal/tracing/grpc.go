@@ -0,0 +1,167 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// cabecera W3C Trace Context: "00-<trace_id>-<span_id>-<flags>"; tracestate
+// no se propaga
+const traceparentKey = "traceparent"
+
+// Traceparent serializa el span de ctx en formato W3C, para la metadata o
+// para llevarlo dentro de un mensaje (p.ej. por un stream abierto hace rato,
+// cuya metadata es de otra traza). Vacío si ctx no lleva un span válido.
+func Traceparent(ctx context.Context) string {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	// siempre "muestreado": aquí no hay muestreo
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-01"
+}
+
+// FromTraceparent es la inversa de Traceparent; un valor mal formado deja
+// ctx como estaba.
+func FromTraceparent(ctx context.Context, traceparent string) context.Context {
+	return WithParent(ctx, parseTraceparent(traceparent))
+}
+
+func parseTraceparent(v string) (sc SpanContext) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	// versiones futuras pueden agregar campos al final
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}
+	}
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}
+	}
+	return sc
+}
+
+// inject agrega el contexto de traza de ctx a la metadata saliente.
+func inject(ctx context.Context) context.Context {
+	tp := Traceparent(ctx)
+	if tp == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, traceparentKey, tp)
+}
+
+// extract toma el contexto de traza de la metadata entrante.
+func extract(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(traceparentKey); len(v) > 0 {
+		return FromTraceparent(ctx, v[0])
+	}
+	return ctx
+}
+
+func rpcAttrs(method string) []Attr {
+	return []Attr{
+		String("rpc.system", "grpc"),
+		String("rpc.service", path.Base(path.Dir(method))),
+		String("rpc.method", path.Base(method)),
+	}
+}
+
+// endRPC cierra el span de una RPC con su código de estado.
+func endRPC(span *Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(String("rpc.grpc.status_code", code.String()))
+	if err != nil && err != io.EOF {
+		span.SetError(status.Convert(err).Message())
+	}
+	span.End()
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                           Interceptores de servidor
+───────────────────────────────────────────────────────────────────────────────*/
+
+// UnaryServer abre un span por RPC, hijo del que envió el cliente.
+func UnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := start(extract(ctx), path.Base(info.FullMethod), kindServer, nil, rpcAttrs(info.FullMethod))
+	resp, err := handler(ctx, req)
+	endRPC(span, err)
+	return resp, err
+}
+
+// StreamServer abre un span que dura lo que el stream.
+func StreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := start(extract(ss.Context()), path.Base(info.FullMethod), kindServer, nil, rpcAttrs(info.FullMethod))
+	err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+	endRPC(span, err)
+	return err
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context { return s.ctx }
+
+/*───────────────────────────────────────────────────────────────────────────────
+                           Interceptores de cliente
+───────────────────────────────────────────────────────────────────────────────*/
+
+// UnaryClient abre un span por llamada (reintentos incluidos, si va por
+// fuera de ellos) y propaga su contexto en la metadata.
+func UnaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := start(ctx, path.Base(method), kindClient, nil, rpcAttrs(method))
+	err := invoker(inject(ctx), method, req, reply, cc, opts...)
+	endRPC(span, err)
+	return err
+}
+
+// StreamClient abre un span que termina cuando el stream se cierra o falla.
+func StreamClient(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx, span := start(ctx, path.Base(method), kindClient, nil, rpcAttrs(method))
+	cs, err := streamer(inject(ctx), desc, cc, method, opts...)
+	if err != nil {
+		endRPC(span, err)
+		return nil, err
+	}
+	return &tracedClientStream{ClientStream: cs, span: span}, nil
+}
+
+type tracedClientStream struct {
+	grpc.ClientStream
+	span *Span
+	once sync.Once
+}
+
+func (s *tracedClientStream) end(err error) {
+	s.once.Do(func() { endRPC(s.span, err) })
+}
+
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.end(err)
+	}
+	return err
+}
+
+func (s *tracedClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err != nil && err != io.EOF {
+		s.end(err)
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+// internal/tracing/grpc_test.go
+//
+// Propagación W3C: Traceparent y FromTraceparent son inversas, los valores
+// mal formados se ignoran, y de la metadata saliente a la entrante el span
+// del servidor queda como hijo del cliente (también sin exportador, que
+// sólo propaga).
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+type recorder struct{ recs []*SpanRecord }
+
+func (r *recorder) Export(rec *SpanRecord) { r.recs = append(r.recs, rec) }
+
+func TestTraceparentRoundTrip(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := FromTraceparent(context.Background(), tp)
+	sc := SpanContextFromContext(ctx)
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Fatalf("FromTraceparent = %+v", sc)
+	}
+	if got := Traceparent(ctx); got != tp {
+		t.Fatalf("Traceparent = %s, se esperaba %s", got, tp)
+	}
+	if got := Traceparent(context.Background()); got != "" {
+		t.Fatalf("Traceparent sin span = %q", got)
+	}
+}
+
+func TestTraceparentMalformed(t *testing.T) {
+	for _, tp := range []string{
+		"",
+		"basura",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",       // sin flags
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-xx", // campo extra en la versión 00
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",    // versión inválida
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",      // trace_id corto
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",    // no hex
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",    // trace_id cero
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",    // span_id cero
+	} {
+		if sc := SpanContextFromContext(FromTraceparent(context.Background(), tp)); sc.IsValid() {
+			t.Errorf("FromTraceparent(%q) = %+v, se esperaba inválido", tp, sc)
+		}
+	}
+	// versiones futuras pueden traer más campos
+	tp := "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"
+	if sc := SpanContextFromContext(FromTraceparent(context.Background(), tp)); !sc.IsValid() {
+		t.Errorf("FromTraceparent(%q) inválido", tp)
+	}
+}
+
+// serverSide simula el salto por la red: la metadata saliente de ctx llega
+// como entrante al servidor.
+func serverSide(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(inject(ctx))
+	return extract(metadata.NewIncomingContext(context.Background(), md))
+}
+
+func TestPropagationParentsServerSpan(t *testing.T) {
+	rec := &recorder{}
+	Init("test", rec)
+	defer Init("", nil)
+
+	ctx, client := start(context.Background(), "Call", kindClient, nil, nil)
+	_, server := start(serverSide(ctx), "Call", kindServer, nil, nil)
+	server.End()
+	End(client, context.Canceled)
+
+	if len(rec.recs) != 2 {
+		t.Fatalf("se exportaron %d spans, se esperaban 2", len(rec.recs))
+	}
+	srv, cli := rec.recs[0], rec.recs[1]
+	if srv.TraceID != cli.TraceID || srv.ParentID != cli.SpanID || cli.ParentID != "" {
+		t.Fatalf("servidor %+v no es hijo del cliente %+v", srv, cli)
+	}
+	if srv.Kind != kindServer || cli.Kind != kindClient {
+		t.Fatalf("tipos = %s/%s", srv.Kind, cli.Kind)
+	}
+	if cli.Status != "Error" || len(cli.Events) != 1 || cli.Events[0].Name != "exception" {
+		t.Fatalf("End con error: %+v", cli)
+	}
+}
+
+func TestDisabledStillPropagates(t *testing.T) {
+	Init("", nil)
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx, span := Start(FromTraceparent(context.Background(), tp), "Sin exportador")
+	if span.IsRecording() {
+		t.Fatal("span registrando sin exportador")
+	}
+	span.SetAttributes(String("k", "v"))
+	span.End()
+	if got := Traceparent(serverSide(ctx)); got != tp {
+		t.Fatalf("traceparent reenviado = %q, se esperaba %q", got, tp)
+	}
+
+	var none *Span
+	none.SetAttributes(Bool("k", true))
+	none.End()
+	if none.SpanContext().IsValid() {
+		t.Fatal("span nil con contexto válido")
+	}
+}
@@ -0,0 +1,408 @@
+// Package tracing implementa trazas distribuidas para seguir una partida de
+// punta a punta: QueuePlayer → formación de la partida → AssignMatch →
+// actualizaciones de estado → ReportMatchResult, aunque pase por varios
+// procesos.
+//
+// El contexto viaja entre procesos en la metadata gRPC con el formato W3C
+// Trace Context ("traceparent", ver grpc.go), así que las trazas son
+// compatibles con las de OpenTelemetry. Cada span terminado se exporta como
+// una línea JSON según TRACE_EXPORT:
+//
+//   - "" (por defecto): trazas desactivadas; los spans no registran nada,
+//     pero el contexto recibido se sigue propagando;
+//   - "log": una línea por span en el registro del proceso;
+//   - "file:<ruta>": líneas JSON agregadas al archivo, para juntar las de
+//     todos los procesos y reconstruir cada traza por su trace_id.
+//
+// Todo es propio, sólo con la biblioteca estándar (como internal/metrics):
+// se registran todos los spans, sin muestreo ni lotes, lo que alcanza para
+// el laboratorio.
+//
+// Uso:
+//
+//	tracing.Configure(cfg, "Matchmaker") // antes de cfg.MustValidate()
+//	ctx, span := tracing.Start(ctx, "FormMatch", tracing.String("match.id", id))
+//	defer span.End()
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vimsent/L3/internal/config"
+	slog "github.com/vimsent/L3/internal/log"
+)
+
+// provider del proceso; nil con las trazas desactivadas
+var global atomic.Pointer[provider]
+
+type provider struct {
+	service string
+	exp     Exporter
+}
+
+// Init instala el exportador del proceso. Con exp nil las trazas quedan
+// desactivadas.
+func Init(service string, exp Exporter) {
+	if exp == nil {
+		global.Store(nil)
+		return
+	}
+	global.Store(&provider{service: service, exp: exp})
+}
+
+// Configure lee TRACE_EXPORT de cfg e instala el exportador del proceso. Un
+// valor inválido queda como error del reporte y aborta en MustValidate.
+func Configure(cfg *config.Report, service string) {
+	var exp Exporter
+	cfg.Spec("TRACE_EXPORT", "", func(raw string) (err error) {
+		exp, err = ParseExporter(raw)
+		return err
+	})
+	Init(service, exp)
+}
+
+// Enabled indica si hay un exportador instalado.
+func Enabled() bool { return global.Load() != nil }
+
+/*───────────────────────────────────────────────────────────────────────────────
+                          Identificadores y contexto
+───────────────────────────────────────────────────────────────────────────────*/
+
+// TraceID identifica una traza (16 bytes, W3C).
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool  { return id != TraceID{} }
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanID identifica un span dentro de su traza (8 bytes, W3C).
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool  { return id != SpanID{} }
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+
+// SpanContext es la parte de un span que se propaga: basta para colgar de
+// él spans hijos, aquí o en otro proceso. El valor cero no es válido.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+func (sc SpanContext) IsValid() bool { return sc.TraceID.IsValid() && sc.SpanID.IsValid() }
+
+type ctxKey struct{}
+
+// SpanContextFromContext devuelve el span que lleva ctx (el valor cero si
+// no lleva ninguno).
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(ctxKey{}).(SpanContext)
+	return sc
+}
+
+// WithParent devuelve ctx con sc como span padre, para continuar una traza
+// guardada (p.ej. la del encolado de un jugador) en otra goroutine.
+func WithParent(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, sc)
+}
+
+// Detach devuelve un contexto sin plazo ni cancelación que conserva el span
+// de ctx, para el trabajo que sigue en otra goroutine después de la RPC.
+func Detach(ctx context.Context) context.Context {
+	return WithParent(context.Background(), SpanContextFromContext(ctx))
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                    Spans
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Attr es un atributo de un span o de un evento.
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, v string) Attr    { return Attr{key, v} }
+func Bool(key string, v bool) Attr { return Attr{key, v} }
+func Int(key string, v int) Attr   { return Attr{key, v} }
+
+// Strings copia vs: el span puede exportarse después de que el llamador la
+// modifique.
+func Strings(key string, vs []string) Attr {
+	return Attr{key, append([]string(nil), vs...)}
+}
+
+// tipo de span, como en OpenTelemetry
+const (
+	kindInternal = "internal"
+	kindServer   = "server"
+	kindClient   = "client"
+)
+
+// Span es una operación en curso. Con las trazas desactivadas no registra
+// nada; un *Span nil también es válido y no hace nada.
+type Span struct {
+	p      *provider // nil: no registra
+	sc     SpanContext
+	parent SpanContext
+	kind   string
+	start  time.Time
+
+	mu     sync.Mutex
+	ended  bool
+	name   string
+	errMsg string
+	failed bool
+	attrs  []Attr
+	links  []SpanContext
+	events []EventRecord
+}
+
+// Start abre un span interno hijo del que lleve ctx.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return start(ctx, name, kindInternal, nil, attrs)
+}
+
+// StartLinked es Start con enlaces a otros spans relacionados que no son el
+// padre (p.ej. los encolados de los demás jugadores de una partida).
+func StartLinked(ctx context.Context, name string, links []SpanContext, attrs ...Attr) (context.Context, *Span) {
+	return start(ctx, name, kindInternal, links, attrs)
+}
+
+func start(ctx context.Context, name, kind string, links []SpanContext, attrs []Attr) (context.Context, *Span) {
+	parent := SpanContextFromContext(ctx)
+	p := global.Load()
+	if p == nil {
+		// sin exportador sólo se propaga el contexto recibido
+		return ctx, &Span{sc: parent}
+	}
+	sc := SpanContext{TraceID: parent.TraceID, SpanID: newSpanID()}
+	if !parent.IsValid() {
+		sc.TraceID = newTraceID()
+	}
+	s := &Span{
+		p:      p,
+		sc:     sc,
+		parent: parent,
+		kind:   kind,
+		start:  time.Now(),
+		name:   name,
+		attrs:  append([]Attr(nil), attrs...),
+	}
+	for _, l := range links {
+		if l.IsValid() {
+			s.links = append(s.links, l)
+		}
+	}
+	return context.WithValue(ctx, ctxKey{}, sc), s
+}
+
+// SpanContext devuelve el contexto propagable del span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// IsRecording indica si el span se exportará al terminar.
+func (s *Span) IsRecording() bool {
+	if s == nil || s.p == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.ended
+}
+
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+func (s *Span) AddEvent(name string, attrs ...Attr) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, EventRecord{Name: name, At: time.Now(), Attributes: attrMap(attrs)})
+}
+
+// RecordError agrega un evento "exception" con el mensaje de err.
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.AddEvent("exception", String("exception.message", err.Error()))
+}
+
+// SetError marca el span como fallido con la descripción dada.
+func (s *Span) SetError(desc string) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failed, s.errMsg = true, desc
+}
+
+// End termina el span y lo exporta; llamarlo de nuevo no hace nada.
+func (s *Span) End() {
+	if s == nil || s.p == nil {
+		return
+	}
+	end := time.Now()
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	rec := &SpanRecord{
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Service:    s.p.service,
+		Name:       s.name,
+		Kind:       s.kind,
+		Start:      s.start,
+		DurationMs: float64(end.Sub(s.start).Microseconds()) / 1000,
+		Attributes: attrMap(s.attrs),
+		Events:     s.events,
+	}
+	if s.parent.IsValid() {
+		rec.ParentID = s.parent.SpanID.String()
+	}
+	if s.failed {
+		rec.Status = "Error"
+		rec.Error = s.errMsg
+	}
+	for _, l := range s.links {
+		rec.Links = append(rec.Links, l.TraceID.String()+"/"+l.SpanID.String())
+	}
+	s.mu.Unlock()
+	s.p.exp.Export(rec)
+}
+
+// End cierra el span marcándolo con error si err no es nil.
+func End(span *Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetError(err.Error())
+	}
+	span.End()
+}
+
+func attrMap(attrs []Attr) map[string]interface{} {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make(map[string]interface{}, len(attrs))
+	for _, a := range attrs {
+		out[a.Key] = a.Value
+	}
+	return out
+}
+
+func newTraceID() (id TraceID) {
+	rand.Read(id[:])
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	rand.Read(id[:])
+	return id
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                 Exportadores
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Exporter recibe cada span terminado.
+type Exporter interface {
+	Export(rec *SpanRecord)
+}
+
+// SpanRecord es la forma exportada de un span.
+type SpanRecord struct {
+	TraceID    string                 `json:"trace_id"`
+	SpanID     string                 `json:"span_id"`
+	ParentID   string                 `json:"parent_id,omitempty"`
+	Service    string                 `json:"service"`
+	Name       string                 `json:"name"`
+	Kind       string                 `json:"kind"`
+	Start      time.Time              `json:"start"`
+	DurationMs float64                `json:"duration_ms"`
+	Status     string                 `json:"status,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Links      []string               `json:"links,omitempty"` // trace_id/span_id
+	Events     []EventRecord          `json:"events,omitempty"`
+}
+
+// EventRecord es un evento dentro de un span.
+type EventRecord struct {
+	Name       string                 `json:"name"`
+	At         time.Time              `json:"at"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ParseExporter interpreta TRACE_EXPORT; "" devuelve nil (sin trazas).
+func ParseExporter(spec string) (Exporter, error) {
+	spec = strings.TrimSpace(spec)
+	switch {
+	case spec == "":
+		return nil, nil
+	case spec == "log":
+		return logExporter{}, nil
+	case strings.HasPrefix(spec, "file:"):
+		path := strings.TrimPrefix(spec, "file:")
+		if path == "" {
+			return nil, fmt.Errorf("falta la ruta en %q", spec)
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		return &writerExporter{w: f}, nil
+	}
+	return nil, fmt.Errorf("exportador desconocido %q (log o file:<ruta>)", spec)
+}
+
+type logExporter struct{}
+
+func (logExporter) Export(rec *SpanRecord) {
+	b, _ := json.Marshal(rec)
+	slog.Info("[trace] %s", b)
+}
+
+// writerExporter escribe una línea JSON por span.
+type writerExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (e *writerExporter) Export(rec *SpanRecord) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.w.Write(append(b, '\n'))
+}
@@ -0,0 +1,164 @@
+// Package walltime abstrae el reloj de pared (time.Now, time.Sleep,
+// time.After y los tickers) para inyectarlo en el Matchmaker y el
+// GameServer: Real en producción y Fake, con tiempo virtual que sólo avanza
+// a pedido, en pruebas y simulaciones. Así los timeouts de heartbeat o la
+// duración de las partidas se prueban sin esperas reales.
+//
+// No confundir con el paquete clocks, que implementa relojes vectoriales
+// (orden causal, no hora).
+//
+// Uso:
+//
+//	wall := walltime.NewFake(time.Now())
+//	mm.wall = wall
+//	wall.Advance(2 * serverHeartbeatTimeout) // dispara tickers y esperas
+package walltime
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock es la fuente de tiempo inyectable.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	After(d time.Duration) <-chan time.Time
+	Sleep(d time.Duration)
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker es el subconjunto de time.Ticker que usan los binarios.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real es el reloj del sistema.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct{ t *time.Ticker }
+
+func (r realTicker) C() <-chan time.Time { return r.t.C }
+func (r realTicker) Stop()               { r.t.Stop() }
+
+/*───────────────────────────────────────────────────────────────────────────────
+                              Tiempo virtual
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Fake es un reloj virtual: Now no cambia hasta que se llama a Advance, que
+// dispara en orden las esperas y los tickers vencidos.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*waiter
+}
+
+// waiter es una espera (After/Sleep) o un ticker pendiente.
+type waiter struct {
+	at     time.Time
+	period time.Duration // > 0 para tickers
+	c      chan time.Time
+}
+
+// NewFake crea un reloj virtual detenido en start.
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration { return f.Now().Sub(t) }
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &waiter{at: f.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- f.now
+		return w.c
+	}
+	f.waiters = append(f.waiters, w)
+	return w.c
+}
+
+// Sleep bloquea hasta que otro goroutine avance el reloj lo suficiente.
+func (f *Fake) Sleep(d time.Duration) { <-f.After(d) }
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("walltime: período de ticker no positivo")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w := &waiter{at: f.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	f.waiters = append(f.waiters, w)
+	return &fakeTicker{f: f, w: w}
+}
+
+// Advance mueve el reloj d hacia adelante. Cada espera vencida recibe su
+// instante; un ticker atrasado, como time.Ticker, descarta los pulsos que
+// su lector no alcanzó a consumir.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	target := f.now.Add(d)
+	for {
+		sort.SliceStable(f.waiters, func(i, j int) bool { return f.waiters[i].at.Before(f.waiters[j].at) })
+		if len(f.waiters) == 0 || f.waiters[0].at.After(target) {
+			break
+		}
+		w := f.waiters[0]
+		f.now = w.at
+		select {
+		case w.c <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			f.waiters = f.waiters[1:]
+		}
+	}
+	f.now = target
+}
+
+// Waiters devuelve cuántas esperas y tickers siguen pendientes; las pruebas
+// lo usan para saber que un goroutine ya se bloqueó antes de avanzar.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+func (f *Fake) remove(w *waiter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, x := range f.waiters {
+		if x == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+type fakeTicker struct {
+	f *Fake
+	w *waiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.c }
+func (t *fakeTicker) Stop()               { t.f.remove(t.w) }
@@ -0,0 +1,230 @@
+// matchmaker/admin_audit.go
+//
+// Auditoría de administración. Toda RPC Admin* que no sea de sólo lectura
+// deja una entrada con quién la hizo, desde dónde, cuándo, su resultado y
+// el reloj del namespace al terminar. El registro lo hace un interceptor, así
+// que una RPC de administración nueva queda auditada sin tocar nada: basta
+// con que no figure en adminReadOnly. Los handlers agregan, con
+// auditChange, el objeto que tocaron y su valor anterior y nuevo.
+//
+// El administrador es el CN del certificado de cliente cuando hay mTLS y,
+// si no, el que declara en "x-admin-id" (ADMIN_ID del cliente
+// administrador, ver pkg/mmclient); sin ninguno queda "anónimo". Las
+// entradas se consultan con AdminGetAuditLog. Como el log de eventos, viven
+// fuera de los namespaces: restore() no las toca, y un respaldo sólo
+// registra lo que ocurre tras su promoción.
+
+package main
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/middleware"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	maxAuditEntries = 10000 // entradas en memoria
+	auditTrimSlack  = maxAuditEntries / 10
+	anonymousAdmin  = "anónimo"
+)
+
+type adminAuditLog struct {
+	seq     uint64
+	entries []*pb.AdminAuditEntry
+}
+
+// adminReadOnly son las RPC de administración que sólo consultan y no se
+// auditan.
+var adminReadOnly = map[string]bool{
+	"AdminGetSystemStatus":      true,
+	"AdminGetClockMetrics":      true,
+	"AdminGetClockState":        true,
+	"AdminGetServerReliability": true,
+	"AdminExportQueueHistory":   true,
+	"AdminGetSLOStatus":         true,
+	"AdminSimulateCapacity":     true,
+	"AdminGetEventLog":          true,
+	"AdminStreamLogs":           true,
+	"AdminListSessions":         true,
+	"AdminSimulateMatching":     true,
+	"AdminGetAuditLog":          true,
+}
+
+// auditedMethod devuelve el nombre corto de la RPC y si se audita.
+func auditedMethod(fullMethod string) (string, bool) {
+	name := path.Base(fullMethod)
+	return name, strings.HasPrefix(name, "Admin") && !adminReadOnly[name]
+}
+
+type auditKey struct{}
+
+// auditChange anota en la entrada de auditoría de la RPC en curso qué se
+// cambió y sus valores anterior y nuevo. Fuera de una RPC auditada no hace
+// nada.
+func auditChange(ctx context.Context, target, prev, next string) {
+	if e, ok := ctx.Value(auditKey{}).(*pb.AdminAuditEntry); ok {
+		e.Target, e.PreviousValue, e.NewValue = target, prev, next
+	}
+}
+
+// adminIdentity devuelve quién hace la RPC de ctx: el CN verificado por
+// mTLS o, si no hay, el declarado en la metadata.
+func adminIdentity(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(ti.State.VerifiedChains) > 0 {
+			if cn := ti.State.VerifiedChains[0][0].Subject.CommonName; cn != "" {
+				return cn
+			}
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(mmclient.AdminHeader); len(v) > 0 && v[0] != "" {
+		return v[0]
+	}
+	return anonymousAdmin
+}
+
+// auditResult resume la respuesta de una RPC: el valor de su status_code
+// (o el código gRPC del error) y su mensaje.
+func auditResult(resp interface{}, err error) (string, string) {
+	if err != nil {
+		st := status.Convert(err)
+		return st.Code().String(), st.Message()
+	}
+	_, name := middleware.StatusCodeOf(resp)
+	if name == "" {
+		name = "OK"
+	}
+	msg := ""
+	if r, ok := resp.(interface{ GetMessage() string }); ok {
+		msg = r.GetMessage()
+	}
+	return name, msg
+}
+
+// adminAuditUnaryInterceptor registra las RPC de administración que
+// cambian estado. Va por dentro de StatusErrors, para leer el status_code de
+// la respuesta antes de que se convierta en error.
+func (m *matchmaker) adminAuditUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	method, ok := auditedMethod(info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+	e := &pb.AdminAuditEntry{AdminId: adminIdentity(ctx), Peer: incomingPeer(ctx), Method: method}
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get(mmclient.RequestIDHeader)) > 0 {
+		e.RequestId = md.Get(mmclient.RequestIDHeader)[0]
+	}
+	r, scoped := req.(interface{ GetNamespace() string })
+	if scoped {
+		e.Namespace = r.GetNamespace()
+	}
+
+	resp, err := handler(context.WithValue(ctx, auditKey{}, e), req)
+	e.Result, e.Message = auditResult(resp, err)
+	m.recordAdminAction(e, scoped)
+	return resp, err
+}
+
+// recordAdminAction numera la entrada, le pone la hora y el reloj de su
+// namespace y la agrega al registro. Las RPC globales (sin namespace en la
+// petición, como AdminReloadConfig) quedan sin reloj.
+func (m *matchmaker) recordAdminAction(e *pb.AdminAuditEntry, scoped bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ns := m.lookupNS(e.Namespace); scoped && ns != nil {
+		e.Namespace, e.Clock = ns.name, ns.vc.ToProto()
+	}
+	m.adminAudit.seq++
+	e.Sequence = m.adminAudit.seq
+	e.UnixMs = m.wall.Now().UnixMilli()
+	m.adminAudit.entries = append(m.adminAudit.entries, e)
+	if len(m.adminAudit.entries) > maxAuditEntries+auditTrimSlack {
+		drop := len(m.adminAudit.entries) - maxAuditEntries
+		m.adminAudit.entries = append([]*pb.AdminAuditEntry(nil), m.adminAudit.entries[drop:]...)
+	}
+
+	change := ""
+	if e.Target != "" {
+		change = " " + e.Target + ": " + dashIfEmpty(e.PreviousValue) + " → " + dashIfEmpty(e.NewValue)
+	}
+	m.logf("[admin] #%d %s por %s (%s)%s: %s", e.Sequence, e.Method, e.AdminId, e.Peer, change, e.Result)
+}
+
+// dashIfEmpty muestra un valor vacío como "—".
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+// recent devuelve las últimas maxAuditEntries entradas.
+func (l *adminAuditLog) recent() []*pb.AdminAuditEntry {
+	if n := len(l.entries); n > maxAuditEntries {
+		return l.entries[n-maxAuditEntries:]
+	}
+	return l.entries
+}
+
+// auditMatches indica si e cumple el filtro de req.
+func auditMatches(req *pb.AuditLogRequest, e *pb.AdminAuditEntry) bool {
+	switch {
+	case e.GetSequence() <= req.GetAfterSeq():
+		return false
+	case req.GetNamespace() != "" && e.GetNamespace() != req.GetNamespace():
+		return false
+	case req.GetAdminId() != "" && e.GetAdminId() != req.GetAdminId():
+		return false
+	case req.GetTarget() != "" && e.GetTarget() != req.GetTarget():
+		return false
+	case req.GetMethod() != "" && e.GetMethod() != req.GetMethod():
+		return false
+	case req.GetSinceUnixMs() > 0 && e.GetUnixMs() < req.GetSinceUnixMs():
+		return false
+	case req.GetUntilUnixMs() > 0 && e.GetUnixMs() > req.GetUntilUnixMs():
+		return false
+	}
+	return true
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+           RPC: AdminGetAuditLog – acciones de administración registradas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminGetAuditLog(ctx context.Context, req *pb.AuditLogRequest) (*pb.AuditLogResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultEventPage
+	}
+	if limit > maxEventPage {
+		limit = maxEventPage
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	out := &pb.AuditLogResponse{}
+	for _, e := range m.adminAudit.recent() {
+		if !auditMatches(req, e) {
+			continue
+		}
+		if len(out.Entries) == limit {
+			out.More = true
+			break
+		}
+		out.Entries = append(out.Entries, e)
+		out.LastSequence = e.GetSequence()
+	}
+	return out, nil
+}
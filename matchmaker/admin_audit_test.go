@@ -0,0 +1,64 @@
+// matchmaker/admin_audit_test.go
+//
+// Auditoría de administración: las RPC Admin* que cambian estado quedan
+// registradas con el administrador, el valor anterior y el nuevo, el
+// resultado y el reloj; las de sólo lectura no.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestAdminAuditLog(t *testing.T) {
+	r := newFailureRig(t)
+	asProfe := metadata.AppendToOutgoingContext(context.Background(), mmclient.AdminHeader, "profe")
+
+	if _, err := r.cli.AdminUpdateServerState(asProfe, &pb.AdminServerUpdateRequest{
+		ServerId: r.gs.ID, NewStatus: pb.ServerStatus_CAIDO,
+	}); err != nil {
+		t.Fatalf("AdminUpdateServerState: %v", err)
+	}
+	if _, err := r.cli.AdminUpdateServerState(context.Background(), &pb.AdminServerUpdateRequest{
+		ServerId: "nadie", NewStatus: pb.ServerStatus_DISPONIBLE,
+	}); err != nil {
+		t.Fatalf("AdminUpdateServerState: %v", err)
+	}
+	if _, err := r.cli.AdminGetSystemStatus(asProfe, &pb.AdminRequest{}); err != nil {
+		t.Fatalf("AdminGetSystemStatus: %v", err)
+	}
+
+	res, err := r.cli.AdminGetAuditLog(context.Background(), &pb.AuditLogRequest{})
+	if err != nil {
+		t.Fatalf("AdminGetAuditLog: %v", err)
+	}
+	if len(res.GetEntries()) != 2 {
+		t.Fatalf("se esperaban 2 entradas (la consulta no se audita), hay %d: %v", len(res.GetEntries()), res.GetEntries())
+	}
+	first, second := res.GetEntries()[0], res.GetEntries()[1]
+	if first.GetAdminId() != "profe" || first.GetMethod() != "AdminUpdateServerState" || first.GetTarget() != r.gs.ID ||
+		first.GetPreviousValue() != "DISPONIBLE" || first.GetNewValue() != "CAIDO" || first.GetResult() != "OK" {
+		t.Errorf("primera entrada = %v", first)
+	}
+	if first.GetNamespace() != defaultNamespace || len(first.GetClock().GetCounters()) == 0 {
+		t.Errorf("la entrada debía llevar el namespace y su reloj: %v", first)
+	}
+	if second.GetAdminId() != anonymousAdmin || second.GetResult() != "NOT_FOUND" || second.GetTarget() != "" {
+		t.Errorf("segunda entrada = %v", second)
+	}
+
+	res, err = r.cli.AdminGetAuditLog(context.Background(), &pb.AuditLogRequest{AdminId: "profe"})
+	if err != nil || len(res.GetEntries()) != 1 || res.GetEntries()[0].GetSequence() != first.GetSequence() {
+		t.Fatalf("filtro por administrador: %v %v", res.GetEntries(), err)
+	}
+	res, err = r.cli.AdminGetAuditLog(context.Background(), &pb.AuditLogRequest{Limit: 1})
+	if err != nil || len(res.GetEntries()) != 1 || !res.GetMore() {
+		t.Fatalf("la primera página debía tener una entrada y more: %v %v", res, err)
+	}
+}
@@ -0,0 +1,82 @@
+// matchmaker/assign_failure_action.go
+//
+// Qué pasa con los jugadores de una partida que ningún servidor aceptó.
+// Antes volvían siempre al frente de la cola, en silencio: quien ya había
+// recibido MATCH_FOUND no se enteraba. Ahora cada jugador elige al
+// encolarse (on_assign_failure, igual para todo su grupo):
+//
+//   - FAILURE_REQUEUE (por defecto): vuelve al frente de la cola, como antes;
+//   - FAILURE_ASK: queda IDLE y, si vuelve a encolarse en el mismo modo
+//     antes de REQUEUE_OFFER_TIMEOUT, recupera el frente de la cola;
+//   - FAILURE_IDLE: queda IDLE.
+//
+// En los tres casos recibe ASSIGN_FAILED, con requeued y, si corresponde,
+// el plazo de la oferta. Los clientes anteriores a la versión 3 del
+// protocolo no pueden elegir y no reciben el aviso (protocol_version.go).
+// Las partidas de una sala privada no pasan por aquí: vuelven a su sala.
+
+package main
+
+import (
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const defaultRequeueOffer = 30 * time.Second
+
+// releaseAfterAssignFailure devuelve a los jugadores de la partida fallida
+// según lo que eligió cada uno y les avisa con ASSIGN_FAILED.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) releaseAfterAssignFailure(ns *namespace, matchID string, players []string) {
+	now := m.wall.Now()
+	var requeued, released []string
+	// al revés, para que los reencolados al frente conserven su orden
+	for i := len(players) - 1; i >= 0; i-- {
+		p, ok := ns.players.Get(players[i])
+		if !ok || p.MatchID != matchID {
+			continue
+		}
+		p.MatchID = ""
+		upd := &pb.MatchUpdate{Event: pb.MatchUpdate_ASSIGN_FAILED, MatchId: matchID}
+		switch p.OnAssignFailure {
+		case pb.AssignFailureAction_FAILURE_ASK, pb.AssignFailureAction_FAILURE_IDLE:
+			p.Status = playerIdle
+			p.LastOp = now
+			p.QueueDeadline = time.Time{}
+			if p.OnAssignFailure == pb.AssignFailureAction_FAILURE_ASK {
+				p.RequeueOffer = now.Add(m.requeueOffer)
+				upd.RequeueOfferDeadlineUnixMs = p.RequeueOffer.UnixMilli()
+			}
+			released = append(released, p.ID)
+		default:
+			p.Status = playerInQueue
+			p.Priority = priorityRequeued
+			p.QueueDeadline = m.queueDeadline(now)
+			ns.enqueue(p.ID, true)
+			upd.Requeued = true
+			requeued = append(requeued, p.ID)
+		}
+		m.notify(ns, p.ID, upd)
+	}
+	m.logRequeued(ns, matchID, requeued, "asignación fallida")
+	if len(released) > 0 {
+		m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_DEQUEUED, PlayerIds: released, MatchId: matchID, Detail: "asignación fallida"})
+		m.logf("[%s] Partida %s sin servidor: %v quedan IDLE por su preferencia", ns.name, matchID, released)
+	}
+}
+
+// takeRequeueOffer consume la oferta de volver al frente de la cola que
+// dejó a los jugadores un fallo de asignación con FAILURE_ASK. La recupera
+// quien se encola en el mismo modo antes del plazo (por un grupo decide su
+// líder); cualquier otro QueuePlayer la descarta.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) takeRequeueOffer(leader *playerInfo, members []string, mode string, now time.Time) bool {
+	ok := !leader.RequeueOffer.IsZero() && now.Before(leader.RequeueOffer) && leader.Mode == mode
+	for _, pid := range members {
+		if p, found := ns.players.Get(pid); found {
+			p.RequeueOffer = time.Time{}
+		}
+	}
+	return ok
+}
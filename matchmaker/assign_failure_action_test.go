@@ -0,0 +1,107 @@
+// matchmaker/assign_failure_action_test.go
+//
+// on_assign_failure: tras un fallo de asignación cada jugador recibe
+// ASSIGN_FAILED y vuelve a la cola o queda IDLE según eligió; con
+// FAILURE_ASK recupera el frente de la cola si se encola antes del plazo.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// failAssign encola a a y b con sus preferencias y espera a que la
+// asignación de su partida falle.
+func failAssign(r *failureRig, a, b pb.AssignFailureAction) {
+	r.t.Helper()
+	for id, action := range map[string]pb.AssignFailureAction{"A": a, "B": b} {
+		if _, err := r.cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id, OnAssignFailure: action}); err != nil {
+			r.t.Fatalf("%s: QueuePlayer: %v", id, err)
+		}
+	}
+	r.mm.tryCreateMatch()
+	r.gs.WaitAssigned(r.t, 1, time.Second)
+	r.waitFor("partida deshecha", func(ns *namespace) bool { return ns.matches.Len() == 0 })
+}
+
+// lastUpdate devuelve la última notificación de pid.
+func lastUpdate(ns *namespace, pid string) *pb.MatchUpdate {
+	for i := len(ns.events) - 1; i >= 0; i-- {
+		if ns.events[i].PlayerID == pid {
+			return ns.events[i].Update
+		}
+	}
+	return nil
+}
+
+func queueAgain(t *testing.T, cli pb.MatchmakerClient, id string) *pb.QueuePlayerResponse {
+	t.Helper()
+	res, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id})
+	if err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+		t.Fatalf("%s: QueuePlayer: %v %v", id, res.GetStatusCode(), err)
+	}
+	return res
+}
+
+func TestAssignFailureFollowsPreference(t *testing.T) {
+	r := newFailureRig(t, testkit.Reject)
+	failAssign(r, pb.AssignFailureAction_FAILURE_ASK, pb.AssignFailureAction_FAILURE_IDLE)
+
+	r.inspect(func(ns *namespace) {
+		for _, id := range []string{"A", "B"} {
+			if p := ns.players.Value(id); p.Status != playerIdle || p.MatchID != "" {
+				t.Fatalf("%s: %v en %q, se esperaba IDLE", id, p.Status, p.MatchID)
+			}
+			if upd := lastUpdate(ns, id); upd.GetEvent() != pb.MatchUpdate_ASSIGN_FAILED || upd.GetRequeued() {
+				t.Fatalf("%s: última notificación %v", id, upd)
+			}
+		}
+		if lastUpdate(ns, "A").GetRequeueOfferDeadlineUnixMs() == 0 || lastUpdate(ns, "B").GetRequeueOfferDeadlineUnixMs() != 0 {
+			t.Fatalf("oferta de A %d, de B %d", lastUpdate(ns, "A").GetRequeueOfferDeadlineUnixMs(), lastUpdate(ns, "B").GetRequeueOfferDeadlineUnixMs())
+		}
+	})
+
+	// A recupera el frente aunque C llegó antes; B entra como cualquiera
+	queueAgain(t, r.cli, "C")
+	if res := queueAgain(t, r.cli, "A"); !strings.Contains(res.GetMessage(), "recuperaste") {
+		t.Fatalf("QueuePlayer(A) = %q", res.GetMessage())
+	}
+	queueAgain(t, r.cli, "B")
+	r.inspect(func(ns *namespace) {
+		if got := strings.Join(ns.queue.IDs(), ","); got != "A,C,B" {
+			t.Fatalf("cola = %s, se esperaba A,C,B", got)
+		}
+		if ns.players.Value("A").RequeueOffer != (time.Time{}) {
+			t.Fatalf("la oferta de A no se consumió")
+		}
+	})
+}
+
+func TestAssignFailureRequeueByDefaultAndOfferExpires(t *testing.T) {
+	wall := walltime.NewFake(time.Now())
+	r := newFailureRig(t, testkit.Reject)
+	r.mm.wall = wall
+	failAssign(r, pb.AssignFailureAction_FAILURE_REQUEUE, pb.AssignFailureAction_FAILURE_ASK)
+
+	r.inspect(func(ns *namespace) {
+		if !requeued(ns, "A") || !lastUpdate(ns, "A").GetRequeued() {
+			t.Fatalf("A no volvió a la cola avisado: %v %v", ns.players.Value("A").Status, lastUpdate(ns, "A"))
+		}
+	})
+
+	wall.Advance(defaultRequeueOffer + time.Second)
+	queueAgain(t, r.cli, "C")
+	queueAgain(t, r.cli, "B")
+	r.inspect(func(ns *namespace) {
+		if got := strings.Join(ns.queue.IDs(), ","); got != "A,C,B" || ns.players.Value("B").Priority != priorityNormal {
+			t.Fatalf("cola tras vencer la oferta = %s (B %v)", got, ns.players.Value("B").Priority)
+		}
+	})
+}
@@ -0,0 +1,343 @@
+// matchmaker/assign_failures_test.go
+//
+// Caminos de fallo de la asignación de partidas, recorridos contra
+// testkit.FakeGameServer: rechazo, demora más allá del plazo, caída tras
+// aceptar, partidas que nunca informan resultado y los reintentos y la
+// conmutación a otro servidor.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// failureRig es un Matchmaker con un único GameServer falso registrado.
+// Los reintentos de AssignMatch empiezan desactivados para que cada
+// escenario vea el primer fallo; los de reintento los configuran.
+type failureRig struct {
+	t   *testing.T
+	mm  *matchmaker
+	cli pb.MatchmakerClient
+	net *testkit.Network
+	gs  *testkit.FakeGameServer
+}
+
+func newFailureRig(t *testing.T, behaviors ...testkit.Behavior) *failureRig {
+	t.Helper()
+	mm, cli := startMatchmaker(t)
+	n := testkit.NewNetwork()
+	mm.dialer = n.Dial
+	mm.assignTimeout = 200 * time.Millisecond
+	mm.assignRetry = assignRetryPolicy{}
+
+	r := &failureRig{t: t, mm: mm, cli: cli, net: n, gs: testkit.NewFakeGameServer(t, n, "gs1:60051", cli)}
+	r.gs.Script(behaviors...)
+	r.register()
+	return r
+}
+
+// addServer registra un segundo servidor falso; su ID ordena después de
+// gs1, así que sólo recibe partidas cuando gs1 no puede.
+func (r *failureRig) addServer(behaviors ...testkit.Behavior) *testkit.FakeGameServer {
+	r.t.Helper()
+	gs := testkit.NewFakeGameServer(r.t, r.net, "gs2:60052", r.cli)
+	gs.Script(behaviors...)
+	r.registerServer(gs)
+	return gs
+}
+
+// register anuncia el servidor falso como DISPONIBLE.
+func (r *failureRig) register() {
+	r.t.Helper()
+	r.registerServer(r.gs)
+}
+
+func (r *failureRig) registerServer(gs *testkit.FakeGameServer) {
+	r.t.Helper()
+	res, err := r.cli.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+		ServerId: gs.ID, NewStatus: pb.ServerStatus_DISPONIBLE, Address: gs.Addr,
+	})
+	if err != nil || res.GetStatusCode() != pb.ServerStatusUpdateResponse_OK {
+		r.t.Fatalf("UpdateServerStatus: %v %v", res.GetStatusCode(), err)
+	}
+}
+
+// queuePair encola dos jugadores 1v1 y fuerza una vuelta de emparejamiento.
+func (r *failureRig) queuePair(a, b string) {
+	r.t.Helper()
+	for _, id := range []string{a, b} {
+		if _, err := r.cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id}); err != nil {
+			r.t.Fatalf("%s: QueuePlayer: %v", id, err)
+		}
+	}
+	r.mm.tryCreateMatch()
+}
+
+// inspect ejecuta fn con el namespace por defecto bajo el lock en
+// escritura: en lectura podría ver una RPC de jugador a medias (locking.go).
+func (r *failureRig) inspect(fn func(ns *namespace)) {
+	r.mm.mu.Lock()
+	defer r.mm.mu.Unlock()
+	fn(r.mm.lookupNS(defaultNamespace))
+}
+
+// waitFor sondea cond hasta que se cumpla o pasen 2 s.
+func (r *failureRig) waitFor(what string, cond func(ns *namespace) bool) {
+	r.t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		ok := false
+		r.inspect(func(ns *namespace) { ok = cond(ns) })
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			r.t.Fatalf("no se cumplió: %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func requeued(ns *namespace, players ...string) bool {
+	for _, pid := range players {
+		p := ns.players.Value(pid)
+		if p.Status != playerInQueue || p.Priority != priorityRequeued {
+			return false
+		}
+	}
+	return ns.matches.Len() == 0
+}
+
+func serverIs(ns *namespace, id string, st serverState) bool {
+	s, ok := ns.servers.Get(id)
+	return ok && s.Status == st
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                 Escenarios
+───────────────────────────────────────────────────────────────────────────────*/
+
+func TestAssignAcceptedMatchReportsResult(t *testing.T) {
+	r := newFailureRig(t, testkit.Accept)
+	r.queuePair("A", "B")
+
+	r.gs.WaitAssigned(t, 1, time.Second)
+	r.waitFor("resultado registrado y jugadores libres", func(ns *namespace) bool {
+		return len(ns.history) == 1 && ns.matches.Len() == 0 &&
+			ns.players.Value("A").Status == playerIdle && ns.players.Value("B").Status == playerIdle
+	})
+}
+
+// Un rechazo (BUSY) devuelve a los jugadores al frente de la cola sin dar
+// por caído al servidor; tras su próxima actualización vuelve a recibir.
+func TestAssignRejectedRequeuesAndKeepsServer(t *testing.T) {
+	r := newFailureRig(t, testkit.Reject, testkit.Accept)
+	r.queuePair("A", "B")
+
+	r.gs.WaitAssigned(t, 1, time.Second)
+	r.waitFor("jugadores reencolados y servidor OCUPADO", func(ns *namespace) bool {
+		return requeued(ns, "A", "B") && serverIs(ns, r.gs.ID, serverBusy)
+	})
+
+	r.register()
+	r.mm.tryCreateMatch()
+	got := r.gs.WaitAssigned(t, 2, time.Second)
+	if got[1].Behavior != testkit.Accept {
+		t.Fatalf("segunda asignación con %v", got[1].Behavior)
+	}
+}
+
+// Un servidor que tarda más que el plazo de AssignMatch se da por caído.
+// Dentro del plazo, la demora no es un fallo.
+func TestAssignDelay(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		delay time.Duration
+		fails bool
+	}{
+		{"dentro del plazo", 20 * time.Millisecond, false},
+		{"fuera del plazo", 5 * time.Second, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFailureRig(t, testkit.Delay, testkit.NeverReport)
+			r.gs.Delay = tc.delay
+			r.queuePair("A", "B")
+			r.gs.WaitAssigned(t, 1, time.Second)
+
+			if tc.fails {
+				r.waitFor("jugadores reencolados y servidor CAIDO", func(ns *namespace) bool {
+					return requeued(ns, "A", "B") && serverIs(ns, r.gs.ID, serverDown)
+				})
+				return
+			}
+			time.Sleep(tc.delay + 50*time.Millisecond)
+			r.inspect(func(ns *namespace) {
+				if ns.matches.Len() != 1 || ns.players.Value("A").Status != playerInMatch {
+					t.Fatalf("la partida demorada no quedó en curso: %d partidas, A=%v", ns.matches.Len(), ns.players.Value("A").Status)
+				}
+			})
+		})
+	}
+}
+
+// Un servidor que muere tras aceptar deja de enviar heartbeats: el barrido
+// cierra su partida y una nueva asignación a esa dirección falla al conectar.
+// El plazo de heartbeat transcurre en tiempo virtual.
+func TestAssignCrashAfterAccept(t *testing.T) {
+	wall := walltime.NewFake(time.Now())
+	r := newFailureRig(t, testkit.CrashAfterAccept)
+	r.mm.wall = wall
+	r.queuePair("A", "B")
+	r.gs.WaitAssigned(t, 1, time.Second)
+
+	wall.Advance(defaultHeartbeatTimeout / 2)
+	r.mm.detectServerTimeouts()
+	r.inspect(func(ns *namespace) {
+		if serverIs(ns, r.gs.ID, serverDown) {
+			t.Fatalf("servidor dado por caído antes del plazo de heartbeat")
+		}
+	})
+
+	wall.Advance(2 * defaultHeartbeatTimeout)
+	r.mm.detectServerTimeouts()
+	r.mm.sweepOrphanMatches(wall.Now())
+
+	r.inspect(func(ns *namespace) {
+		if ns.matches.Len() != 0 {
+			t.Fatalf("la partida del servidor caído sigue abierta")
+		}
+		for _, pid := range []string{"A", "B"} {
+			if ns.players.Value(pid).Status != playerIdle {
+				t.Fatalf("%s quedó %v tras el barrido", pid, ns.players.Value(pid).Status)
+			}
+		}
+	})
+
+	// el registro llega, pero el proceso ya no escucha
+	r.register()
+	r.queuePair("C", "D")
+	r.waitFor("jugadores reencolados tras fallar la conexión", func(ns *namespace) bool {
+		return requeued(ns, "C", "D") && serverIs(ns, r.gs.ID, serverDown)
+	})
+}
+
+// Una partida aceptada que nunca informa resultado expira y, con
+// ORPHAN_ACTION=requeue, sus jugadores vuelven a la cola.
+func TestAssignNeverReportedExpires(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	r.mm.orphans.requeue = true
+	r.queuePair("A", "B")
+	r.gs.WaitAssigned(t, 1, time.Second)
+
+	var limit time.Duration
+	r.inspect(func(ns *namespace) {
+		if ns.matches.Len() != 1 {
+			t.Fatalf("se esperaba 1 partida en curso, hay %d", ns.matches.Len())
+		}
+		limit = r.mm.orphans.limit(ns.modes[defaultGameMode])
+	})
+
+	r.mm.sweepOrphanMatches(time.Now().Add(limit / 2))
+	r.inspect(func(ns *namespace) {
+		if ns.matches.Len() != 1 {
+			t.Fatalf("la partida expiró antes del límite")
+		}
+	})
+
+	r.mm.sweepOrphanMatches(time.Now().Add(limit + time.Second))
+	r.inspect(func(ns *namespace) {
+		if !requeued(ns, "A", "B") {
+			t.Fatalf("jugadores no reencolados tras expirar: A=%v B=%v", ns.players.Value("A").Status, ns.players.Value("B").Status)
+		}
+	})
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                         Reintentos y conmutación
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Un UNAVAILABLE pasajero se reintenta en el mismo servidor.
+func TestAssignRetriesTransientFailure(t *testing.T) {
+	r := newFailureRig(t, testkit.Unavailable, testkit.Accept)
+	r.mm.assignRetry = assignRetryPolicy{retries: 2, backoff: time.Millisecond}
+	r.queuePair("A", "B")
+
+	got := r.gs.WaitAssigned(t, 2, time.Second)
+	if got[0].MatchID != got[1].MatchID {
+		t.Fatalf("el reintento llevó otra partida: %s y %s", got[0].MatchID, got[1].MatchID)
+	}
+	if got[0].Attempt != 1 || got[1].Attempt != 2 {
+		t.Fatalf("intentos %d y %d, se esperaban 1 y 2", got[0].Attempt, got[1].Attempt)
+	}
+	r.waitFor("resultado registrado sin dar por caído al servidor", func(ns *namespace) bool {
+		return len(ns.history) == 1 && !serverIs(ns, r.gs.ID, serverDown)
+	})
+}
+
+// Agotados los reintentos, o ante un rechazo, la partida pasa a otro
+// servidor y los jugadores no vuelven a la cola.
+func TestAssignFailsOverToAnotherServer(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		first    testkit.Behavior
+		attempts int
+		marked   serverState
+	}{
+		{"tras agotar reintentos", testkit.Unavailable, 2, serverDown},
+		{"tras un rechazo", testkit.Reject, 1, serverBusy},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFailureRig(t, tc.first)
+			r.mm.assignRetry = assignRetryPolicy{retries: 1, backoff: time.Millisecond, failover: true}
+			gs2 := r.addServer(testkit.Accept)
+			r.queuePair("A", "B")
+
+			if got := r.gs.WaitAssigned(t, tc.attempts, time.Second); len(got) != tc.attempts {
+				t.Fatalf("gs1 recibió %d AssignMatch, se esperaban %d", len(got), tc.attempts)
+			}
+			// los intentos se cuentan por partida, también tras conmutar
+			if got := gs2.WaitAssigned(t, 1, time.Second); got[0].Attempt != int32(tc.attempts+1) {
+				t.Fatalf("gs2 recibió el intento %d, se esperaba %d", got[0].Attempt, tc.attempts+1)
+			}
+			r.waitFor("partida terminada en gs2", func(ns *namespace) bool {
+				return len(ns.history) == 1 && ns.history[0].ServerID == gs2.ID &&
+					serverIs(ns, r.gs.ID, tc.marked) && ns.players.Value("A").Status == playerIdle
+			})
+		})
+	}
+}
+
+// Sólo se reencola cuando ningún servidor acepta la partida.
+func TestAssignRequeuesWhenAllServersFail(t *testing.T) {
+	r := newFailureRig(t, testkit.Reject)
+	r.mm.assignRetry = assignRetryPolicy{failover: true}
+	gs2 := r.addServer(testkit.Reject)
+	r.queuePair("A", "B")
+
+	r.gs.WaitAssigned(t, 1, time.Second)
+	gs2.WaitAssigned(t, 1, time.Second)
+	r.waitFor("jugadores reencolados y ambos servidores OCUPADOS", func(ns *namespace) bool {
+		return requeued(ns, "A", "B") && serverIs(ns, r.gs.ID, serverBusy) && serverIs(ns, gs2.ID, serverBusy)
+	})
+}
+
+func TestAssignRetryDelay(t *testing.T) {
+	p := assignRetryPolicy{backoff: 100 * time.Millisecond}
+	for n, max := range []time.Duration{100, 200, 400, 800, 1600, 2000, 2000} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			if d := p.delay(n + 1); d < max/2 || d > max {
+				t.Fatalf("delay(%d) = %v, fuera de [%v, %v]", n+1, d, max/2, max)
+			}
+		}
+	}
+	if d := (assignRetryPolicy{}).delay(3); d != 0 {
+		t.Fatalf("sin backoff delay = %v", d)
+	}
+}
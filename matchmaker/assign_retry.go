@@ -0,0 +1,149 @@
+// matchmaker/assign_retry.go
+//
+// Reintentos de AssignMatch. Un fallo que no llegó al servidor (no se pudo
+// conectar o respondió UNAVAILABLE) se reintenta en el mismo servidor hasta
+// ASSIGN_RETRIES veces, con espera exponencial desde ASSIGN_BACKOFF y
+// jitter. Si el servidor sigue sin responder, o rechaza la partida por
+// falta de huecos, la partida pasa al mejor servidor asignable que no se
+// haya probado (ASSIGN_FAILOVER=1); los jugadores sólo vuelven a la cola
+// cuando no queda ninguno.
+//
+// Un AssignMatch que vence el plazo no se reintenta en ningún servidor: el
+// GameServer pudo haberla aceptado, y reenviarla la jugaría dos veces. El
+// servidor se da por caído como antes y el barrido de huérfanas resuelve
+// el resto.
+//
+// Cada envío lleva su número de intento (AssignMatchRequest.attempt),
+// contado por partida a través de reintentos y conmutaciones. El GameServer
+// recuerda las partidas que aceptó y responde a una repetición con la
+// respuesta original (duplicate = true) en vez de BUSY, así que un
+// reintento que llega tras una aceptación perdida no se confunde con un
+// rechazo.
+
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const maxAssignBackoff = 2 * time.Second
+
+type assignRetryPolicy struct {
+	retries  int           // reintentos por servidor ante fallos de conexión
+	backoff  time.Duration // espera antes del primer reintento
+	failover bool          // probar otros servidores antes de reencolar
+}
+
+func defaultAssignRetryPolicy() assignRetryPolicy {
+	return assignRetryPolicy{retries: 2, backoff: 100 * time.Millisecond, failover: true}
+}
+
+// delay es la espera antes del reintento n (desde 1): se duplica en cada
+// uno hasta maxAssignBackoff, y se sortea entre la mitad y el total para
+// que varias partidas no reintenten a la vez contra el mismo servidor.
+func (p assignRetryPolicy) delay(n int) time.Duration {
+	d := p.backoff
+	for i := 1; i < n && d < maxAssignBackoff; i++ {
+		d *= 2
+	}
+	if d > maxAssignBackoff {
+		d = maxAssignBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// assignOutcome clasifica un intento de AssignMatch.
+type assignOutcome int
+
+const (
+	assignAccepted    assignOutcome = iota
+	assignRejected                  // vivo pero sin hueco
+	assignUnreachable               // no llegó al servidor: se puede reintentar
+	assignTimedOut                  // pudo haberla aceptado: no se reintenta
+	assignAbandoned                 // la partida se cerró o el Matchmaker se apaga
+)
+
+// assignWithRetries intenta asignar la partida a srv, reintentando los
+// fallos de conexión según m.assignRetry. sent cuenta los AssignMatch
+// enviados por la partida.
+func (m *matchmaker) assignWithRetries(ctx context.Context, ns *namespace, srv *gameServerInfo, matchID string, players []string, mode *pb.GameMode, snapshot *clocks.Vector, sent *int32) (assignOutcome, string) {
+	retry, _ := m.assignSettings()
+	for attempt := 1; ; attempt++ {
+		*sent++
+		outcome, msg := m.tryAssign(ctx, ns, srv, matchID, players, mode, snapshot, *sent)
+		if outcome != assignUnreachable || attempt > retry.retries {
+			return outcome, msg
+		}
+
+		wait := retry.delay(attempt)
+		m.logf("[%s] AssignMatch de %s a %s: %s; reintento %d/%d en %v", ns.name, matchID, srv.ID, msg, attempt, retry.retries, wait)
+		m.metrics.assignRetries.With(ns.name, "retry").Inc()
+		select {
+		case <-m.wall.After(wait):
+		case <-m.done:
+			return assignAbandoned, "Matchmaker apagándose"
+		}
+		if !m.matchOpen(ns, matchID) {
+			return assignAbandoned, "partida cerrada"
+		}
+	}
+}
+
+func (m *matchmaker) matchOpen(ns *namespace, matchID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := ns.matches.Get(matchID)
+	return ok
+}
+
+// failover marca el servidor que falló con st y mueve la partida al mejor
+// servidor asignable que no esté en tried, avisando a los jugadores de la
+// nueva dirección. Devuelve nil si la conmutación está desactivada, si la
+// partida está fijada a su servidor (sala privada), si no queda servidor o
+// si la partida ya se cerró.
+func (m *matchmaker) failover(ns *namespace, failed *gameServerInfo, matchID string, st serverState, tried map[string]bool) *gameServerInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("failover")
+
+	am, ok := ns.matches.Get(matchID)
+	if !ok || !m.assignRetry.failover || am.Pinned {
+		return nil
+	}
+	prev := failed.Status
+	failed.Status = st
+	if st == serverBusy {
+		failed.FreeSlots = 0
+	}
+	m.logServerTransition(ns, failed, prev, "asignación fallida")
+	next := ns.pickServer(am.Players)
+	if next == nil || tried[next.ID] {
+		return nil
+	}
+
+	am.ServerID = next.ID
+	am.MatchAddr = ""
+	if ns.freeSlots(next) == 0 {
+		next.Status = serverBusy
+	}
+	ns.vc.Tick(m.selfID)
+	m.metrics.assignRetries.With(ns.name, "failover").Inc()
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_MATCH_REASSIGNED, PlayerIds: am.Players, MatchId: matchID, ServerId: next.ID, Detail: "desde " + failed.ID})
+	for _, pid := range am.Players {
+		m.notify(ns, pid, &pb.MatchUpdate{
+			Event:      pb.MatchUpdate_MATCH_FOUND,
+			MatchId:    matchID,
+			ServerAddr: next.Address,
+		})
+	}
+	m.logClockf(ns, "[%s] Partida %s pasa de server %s a %s (%s)", ns.name, matchID, failed.ID, next.ID, next.Address)
+	return next
+}
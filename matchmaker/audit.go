@@ -0,0 +1,185 @@
+// matchmaker/audit.go
+//
+// Modo auditoría (MATCHMAKER_AUDIT=1, activo siempre en las pruebas). Tras
+// cada RPC y cada vuelta de las tareas de fondo que modifican estado se
+// verifican los invariantes de la máquina de estados:
+//
+//   - la cola no tiene duplicados y sólo contiene jugadores IN_QUEUE sin
+//     partida; todo jugador IN_QUEUE está en la cola;
+//   - un jugador IN_MATCH figura en una partida en curso que lo incluye, y
+//     sólo un jugador IN_MATCH tiene partida;
+//   - un servidor OCUPADO tiene partidas en curso o declaró no tener huecos;
+//   - los miembros de una sala privada existen, están IDLE y la tienen como
+//     su sala, y todo jugador con sala figura en ella;
+//   - el índice de direcciones de servidores coincide con los registrados.
+//
+// Si alguno falla, el proceso entra en pánico con el volcado completo del
+// estado (el snapshot de replicación, en JSON). Cada verificación recorre
+// todo el estado bajo el candado: es una herramienta de depuración, no para
+// producción.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// checkInvariants devuelve las violaciones encontradas, ordenadas.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) checkInvariants() []string {
+	var problems []string
+	report := func(ns *namespace, format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf("[%s] %s", ns.name, fmt.Sprintf(format, a...)))
+	}
+
+	for _, ns := range m.namespaces {
+		queued := make(map[string]bool, ns.queue.Len())
+		for _, pid := range ns.queue.IDs() {
+			p, ok := ns.players.Get(pid)
+			switch {
+			case queued[pid]:
+				report(ns, "%s aparece dos veces en la cola", pid)
+			case !ok:
+				report(ns, "la cola nombra al jugador inexistente %s", pid)
+			case p.Status != playerInQueue:
+				report(ns, "%s está en la cola con estado %s", pid, p.Status)
+			case p.MatchID != "":
+				report(ns, "%s está en la cola y en la partida %s", pid, p.MatchID)
+			}
+			queued[pid] = true
+		}
+
+		for _, p := range ns.players.All() {
+			switch p.Status {
+			case playerInQueue:
+				if !queued[p.ID] {
+					report(ns, "%s figura IN_QUEUE pero no está en la cola", p.ID)
+				}
+			case playerInMatch:
+				am, ok := ns.matches.Get(p.MatchID)
+				if !ok {
+					report(ns, "%s figura IN_MATCH en %q, que no está en curso", p.ID, p.MatchID)
+				} else if !contains(am.Players, p.ID) {
+					report(ns, "%s figura IN_MATCH en %s, que no lo incluye", p.ID, p.MatchID)
+				}
+			default:
+				if p.MatchID != "" {
+					report(ns, "%s tiene la partida %s con estado %s", p.ID, p.MatchID, p.Status)
+				}
+			}
+		}
+
+		for code, lb := range ns.privateLobbies {
+			for _, pid := range lb.Members {
+				p, ok := ns.players.Get(pid)
+				switch {
+				case !ok:
+					report(ns, "la sala privada %s nombra al jugador inexistente %s", code, pid)
+				case p.Status != playerIdle:
+					report(ns, "%s está en la sala privada %s con estado %s", pid, code, p.Status)
+				case p.LobbyCode != code:
+					report(ns, "%s está en la sala privada %s pero tiene la sala %q", pid, code, p.LobbyCode)
+				}
+			}
+		}
+		for _, p := range ns.players.All() {
+			if lb, ok := ns.privateLobbies[p.LobbyCode]; p.LobbyCode != "" && (!ok || !contains(lb.Members, p.ID)) {
+				report(ns, "%s tiene la sala privada %s, que no lo incluye", p.ID, p.LobbyCode)
+			}
+		}
+
+		for _, s := range ns.servers.All() {
+			if s.Status == serverBusy && s.FreeSlots > 0 && len(ns.serverMatches(s.ID)) == 0 {
+				report(ns, "el servidor %s está OCUPADO sin partidas y con %d huecos", s.ID, s.FreeSlots)
+			}
+		}
+	}
+
+	indexed := 0
+	for addr, refs := range m.serverAddrs {
+		for _, ref := range refs {
+			indexed++
+			ns := m.namespaces[ref.ns]
+			if ns == nil {
+				problems = append(problems, fmt.Sprintf("el índice de direcciones nombra el namespace inexistente %s", ref.ns))
+			} else if s, ok := ns.servers.Get(ref.id); !ok || s.Address != addr {
+				report(ns, "el índice lleva %s al servidor %s, que no tiene esa dirección", addr, ref.id)
+			}
+		}
+	}
+	registered := 0
+	for _, ns := range m.namespaces {
+		for _, s := range ns.servers.All() {
+			if s.Address != "" {
+				registered++
+			}
+		}
+	}
+	if indexed != registered {
+		problems = append(problems, fmt.Sprintf("el índice de direcciones tiene %d servidores y hay %d registrados con dirección", indexed, registered))
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+func contains(ids []string, id string) bool {
+	for _, x := range ids {
+		if x == id {
+			return true
+		}
+	}
+	return false
+}
+
+// audit verifica los invariantes si el modo auditoría está activo y, ante
+// una violación, entra en pánico con el volcado del estado.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) audit(op string) {
+	if !m.auditOn {
+		return
+	}
+	problems := m.checkInvariants()
+	if len(problems) == 0 {
+		return
+	}
+	dump, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m.snapshot())
+	if err != nil {
+		dump = []byte(err.Error())
+	}
+	panic(fmt.Sprintf("auditoría: invariantes violados tras %s:\n  - %s\nestado:\n%s",
+		op, strings.Join(problems, "\n  - "), dump))
+}
+
+// auditLocked es audit para quien no tiene m.mu. Lo toma en escritura:
+// con m.mu en lectura podría ver una RPC a medias (locking.go).
+func (m *matchmaker) auditLocked(op string) {
+	if !m.auditOn {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.audit(op)
+}
+
+// Los interceptores de auditoría deben ir por fuera de la recuperación de
+// pánicos de internal/middleware: una violación tiene que tumbar el
+// proceso, no convertirse en un INTERNAL.
+
+func (m *matchmaker) auditUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	m.auditLocked(path.Base(info.FullMethod))
+	return resp, err
+}
+
+func (m *matchmaker) auditStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	m.auditLocked(path.Base(info.FullMethod))
+	return err
+}
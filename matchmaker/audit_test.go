@@ -0,0 +1,49 @@
+// matchmaker/audit_test.go
+//
+// El modo auditoría corre en todas las pruebas que usan startMatchmaker;
+// aquí se comprueba que de verdad detecta un estado roto.
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vimsent/L3/internal/store"
+)
+
+func TestAuditDetectsBrokenState(t *testing.T) {
+	mm := newMatchmaker("Matchmaker")
+	ns := mm.ns("default")
+	ns.players.Put("A", &playerInfo{ID: "A", Status: playerInQueue})
+	ns.players.Put("B", &playerInfo{ID: "B", Status: playerInMatch, MatchID: "M1"})
+	ns.players.Put("C", &playerInfo{ID: "C", Status: playerInQueue})
+	for _, pid := range []string{"A", "A", "B"} {
+		ns.queue.Append(store.Entry{ID: pid})
+	}
+	ns.servers.Put("S1", &gameServerInfo{ID: "S1", Status: serverBusy, Capacity: 1, FreeSlots: 1})
+
+	want := []string{
+		"A aparece dos veces en la cola",
+		"B está en la cola con estado",
+		`B figura IN_MATCH en "M1", que no está en curso`,
+		"C figura IN_QUEUE pero no está en la cola",
+		"el servidor S1 está OCUPADO sin partidas",
+	}
+	got := strings.Join(mm.checkInvariants(), "\n")
+	for _, w := range want {
+		if !strings.Contains(got, w) {
+			t.Errorf("falta la violación %q en:\n%s", w, got)
+		}
+	}
+
+	mm.auditOn = true
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "tras prueba") || !strings.Contains(msg, `"players"`) {
+			t.Fatalf("pánico sin contexto ni volcado: %q", msg)
+		}
+	}()
+	mm.audit("prueba")
+	t.Fatal("audit no entró en pánico")
+}
@@ -0,0 +1,230 @@
+// matchmaker/autoscale.go
+//
+// Escalado automático de GameServers. Con SCALE_PROVISIONER definido, en cada
+// vuelta del bucle de emparejamiento el Matchmaker revisa cada namespace: si
+// la cola llegó a SCALE_UP_QUEUE jugadores y no queda ningún servidor que
+// pueda recibir partidas, le pide uno nuevo al ServerProvisioner (uno por vez
+// por namespace, hasta SCALE_MAX_SERVERS propios). El pedido queda pendiente
+// hasta que el servidor se registra; si no lo hace en SCALE_PROVISION_TIMEOUT
+// se da por perdido y se libera. En sentido inverso, un servidor propio sin
+// partidas durante SCALE_DOWN_IDLE con la cola vacía se drena, se retira del
+// pool (motivo "scaled_down") y se libera.
+//
+// Los servidores propios se reconocen por el prefijo autoServerPrefix de su
+// ID, así que un respaldo promovido o un Matchmaker reiniciado sigue
+// retirándolos; los pedidos pendientes, en cambio, no se replican. Los
+// servidores declarados a mano nunca se retiran por este medio.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/provision"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	autoServerPrefix = "auto-"
+
+	provisionerOff    = "off"
+	provisionerDocker = "docker"
+
+	defaultScaleUpQueue          = 4
+	defaultScaleMaxServers       = 5
+	defaultScaleDownIdle         = 5 * time.Minute
+	defaultScaleProvisionTimeout = 2 * time.Minute
+
+	// plazo de cada llamada al provisionador
+	provisionCallTimeout = time.Minute
+)
+
+// ServerProvisioner levanta y retira GameServers. Provision vuelve en cuanto
+// el servidor fue pedido; el servidor se registra después por su cuenta con
+// SERVER_ID = serverID y NAMESPACE = namespace.
+type ServerProvisioner interface {
+	Provision(ctx context.Context, namespace, serverID string) error
+	Release(ctx context.Context, namespace, serverID string) error
+}
+
+// autoscalePolicy son los umbrales del escalado.
+type autoscalePolicy struct {
+	UpQueue          int           // cola mínima para pedir un servidor
+	MaxServers       int           // servidores propios por namespace, incluidos los pendientes
+	DownIdle         time.Duration // inactividad tras la que se retira uno propio
+	ProvisionTimeout time.Duration // plazo para que un pedido se registre
+}
+
+// autoscaler es el estado del escalado; se lee y modifica con m.mu.
+type autoscaler struct {
+	prov    ServerProvisioner
+	policy  autoscalePolicy
+	pending map[string]time.Time // "ns/serverID" → cuándo se pidió
+	idle    map[string]time.Time // "ns/serverID" → libre desde
+}
+
+func newAutoscaler(prov ServerProvisioner, policy autoscalePolicy) *autoscaler {
+	return &autoscaler{
+		prov:    prov,
+		policy:  policy,
+		pending: make(map[string]time.Time),
+		idle:    make(map[string]time.Time),
+	}
+}
+
+// readAutoscale lee SCALE_*; devuelve nil si el escalado está desactivado.
+func readAutoscale(cfg *config.Report) *autoscaler {
+	kind := cfg.OneOf("SCALE_PROVISIONER", provisionerOff, provisionerOff, provisionerDocker)
+	policy := autoscalePolicy{
+		UpQueue:          cfg.Int("SCALE_UP_QUEUE", defaultScaleUpQueue, 1, 1e6),
+		MaxServers:       cfg.Int("SCALE_MAX_SERVERS", defaultScaleMaxServers, 1, 1000),
+		DownIdle:         cfg.Duration("SCALE_DOWN_IDLE", defaultScaleDownIdle, time.Second, 24*time.Hour),
+		ProvisionTimeout: cfg.Duration("SCALE_PROVISION_TIMEOUT", defaultScaleProvisionTimeout, time.Second, time.Hour),
+	}
+	docker := &provision.Docker{
+		Image:          cfg.String("SCALE_DOCKER_IMAGE", "l3-gameserver"),
+		Network:        cfg.String("SCALE_DOCKER_NETWORK", ""),
+		MatchmakerAddr: cfg.String("SCALE_DOCKER_MATCHMAKER_ADDR", "matchmaker:50051"),
+	}
+	if kind != provisionerDocker {
+		return nil
+	}
+	return newAutoscaler(docker, policy)
+}
+
+func isAutoServer(id string) bool { return strings.HasPrefix(id, autoServerPrefix) }
+
+// autoscale pide o retira servidores en cada namespace según m.scaler.
+func (m *matchmaker) autoscale(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.scaler == nil {
+		return
+	}
+	for _, ns := range m.namespaces {
+		m.expireProvisions(ns, now)
+		m.scaleUp(ns, now)
+		m.scaleDown(ns, now)
+	}
+}
+
+// expireProvisions olvida los pedidos ya registrados y libera los que no se
+// registraron a tiempo.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) expireProvisions(ns *namespace, now time.Time) {
+	sc := m.scaler
+	for key, since := range sc.pending {
+		nsName, id, _ := strings.Cut(key, "/")
+		if nsName != ns.name {
+			continue
+		}
+		if _, ok := ns.servers.Get(id); ok {
+			delete(sc.pending, key)
+			m.logf("[%s] Servidor %s aprovisionado y registrado en %v", ns.name, id, now.Sub(since).Round(time.Millisecond))
+			continue
+		}
+		if now.Sub(since) >= sc.policy.ProvisionTimeout {
+			delete(sc.pending, key)
+			m.metrics.autoscale.With("timeout").Inc()
+			m.logf("[%s] WARNING: el servidor %s no se registró en %v; se libera", ns.name, id, sc.policy.ProvisionTimeout)
+			go m.deprovisionServer(ns.name, id)
+		}
+	}
+}
+
+// scaleUp pide un servidor si la cola espera y no hay dónde emparejarla.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) scaleUp(ns *namespace, now time.Time) {
+	sc := m.scaler
+	if ns.queue.Len() < sc.policy.UpQueue || ns.availableServerCount() > 0 {
+		return
+	}
+	owned := 0
+	for id := range ns.servers.All() {
+		if isAutoServer(id) {
+			owned++
+		}
+	}
+	for key := range sc.pending {
+		if strings.HasPrefix(key, ns.name+"/") {
+			return // uno por vez: el pendiente puede bastar
+		}
+	}
+	if owned >= sc.policy.MaxServers {
+		return
+	}
+
+	id := fmt.Sprintf("%s%x", autoServerPrefix, now.UnixMilli())
+	sc.pending[ns.name+"/"+id] = now
+	m.metrics.autoscale.With("up").Inc()
+	m.logf("[%s] %d jugadores en cola y ningún servidor libre: se pide el servidor %s (%d/%d propios)",
+		ns.name, ns.queue.Len(), id, owned+1, sc.policy.MaxServers)
+	go m.provisionServer(ns.name, id)
+}
+
+// scaleDown retira los servidores propios que llevan SCALE_DOWN_IDLE sin
+// partidas mientras la cola está vacía.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) scaleDown(ns *namespace, now time.Time) {
+	sc := m.scaler
+	for key := range sc.idle {
+		nsName, id, _ := strings.Cut(key, "/")
+		if _, ok := ns.servers.Get(id); nsName == ns.name && !ok {
+			delete(sc.idle, key)
+		}
+	}
+	for id, srv := range ns.servers.All() {
+		key := ns.name + "/" + id
+		if !isAutoServer(id) || srv.Draining {
+			continue
+		}
+		if ns.queue.Len() > 0 || len(ns.serverMatches(id)) > 0 {
+			delete(sc.idle, key)
+			continue
+		}
+		since, ok := sc.idle[key]
+		if !ok {
+			sc.idle[key] = now
+			continue
+		}
+		if now.Sub(since) < sc.policy.DownIdle {
+			continue
+		}
+
+		delete(sc.idle, key)
+		srv.Draining = true
+		m.pushControl(ns, id, &pb.ServerControl{Command: pb.ServerControl_DRAIN, Retired: true})
+		m.removeServer(ns, srv, removedScaledDown)
+		m.metrics.autoscale.With("down").Inc()
+		m.logf("[%s] Servidor %s sin partidas hace %v: se libera", ns.name, id, now.Sub(since).Round(time.Second))
+		go m.deprovisionServer(ns.name, id)
+	}
+}
+
+// provisionServer llama al provisionador fuera del candado; si falla, el
+// pedido se descarta para reintentar en la próxima vuelta.
+func (m *matchmaker) provisionServer(nsName, id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), provisionCallTimeout)
+	defer cancel()
+	if err := m.scaler.prov.Provision(ctx, nsName, id); err != nil {
+		m.mu.Lock()
+		delete(m.scaler.pending, nsName+"/"+id)
+		m.mu.Unlock()
+		m.metrics.autoscale.With("error").Inc()
+		m.logf("[%s] WARNING: no se pudo aprovisionar el servidor %s: %v", nsName, id, err)
+	}
+}
+
+// deprovisionServer llama al provisionador fuera del candado.
+func (m *matchmaker) deprovisionServer(nsName, id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), provisionCallTimeout)
+	defer cancel()
+	if err := m.scaler.prov.Release(ctx, nsName, id); err != nil {
+		m.metrics.autoscale.With("error").Inc()
+		m.logf("[%s] WARNING: no se pudo liberar el servidor %s: %v", nsName, id, err)
+	}
+}
@@ -0,0 +1,138 @@
+// matchmaker/autoscale_test.go
+//
+// Escalado automático con un provisionador de prueba: pide un servidor por
+// vez cuando la cola espera sin servidores libres, olvida el pedido cuando
+// el servidor se registra, libera el que no se registra a tiempo y retira
+// los propios inactivos con la cola vacía. El tiempo lo fija cada llamada.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// fakeProvisioner anota los servidores pedidos y liberados.
+type fakeProvisioner struct {
+	provisioned chan string
+	released    chan string
+}
+
+func newFakeProvisioner() *fakeProvisioner {
+	return &fakeProvisioner{provisioned: make(chan string, 8), released: make(chan string, 8)}
+}
+
+func (f *fakeProvisioner) Provision(ctx context.Context, namespace, serverID string) error {
+	f.provisioned <- serverID
+	return nil
+}
+
+func (f *fakeProvisioner) Release(ctx context.Context, namespace, serverID string) error {
+	f.released <- serverID
+	return nil
+}
+
+func waitID(t *testing.T, ch chan string, what string) string {
+	t.Helper()
+	select {
+	case id := <-ch:
+		return id
+	case <-time.After(time.Second):
+		t.Fatalf("no se %s ningún servidor", what)
+		return ""
+	}
+}
+
+func expectNone(t *testing.T, ch chan string, what string) {
+	t.Helper()
+	select {
+	case id := <-ch:
+		t.Fatalf("se %s %s inesperadamente", what, id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+var testScalePolicy = autoscalePolicy{UpQueue: 2, MaxServers: 2, DownIdle: time.Minute, ProvisionTimeout: time.Minute}
+
+func TestAutoscaleUpAndDown(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	prov := newFakeProvisioner()
+	mm.scaler = newAutoscaler(prov, testScalePolicy)
+	ctx := context.Background()
+
+	cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "A"})
+	now := time.Now()
+	mm.autoscale(now)
+	expectNone(t, prov.provisioned, "pidió")
+
+	cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "B"})
+	mm.autoscale(now)
+	id := waitID(t, prov.provisioned, "pidió")
+	if !isAutoServer(id) {
+		t.Fatalf("ID %q sin el prefijo %q", id, autoServerPrefix)
+	}
+	mm.autoscale(now.Add(time.Second))
+	expectNone(t, prov.provisioned, "pidió (con uno pendiente)")
+
+	if _, err := cli.UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{
+		ServerId: id, NewStatus: pb.ServerStatus_DISPONIBLE, Address: "127.0.0.1:1",
+	}); err != nil {
+		t.Fatalf("UpdateServerStatus: %v", err)
+	}
+	mm.autoscale(now.Add(2 * time.Second))
+	mm.mu.RLock()
+	pending := len(mm.scaler.pending)
+	mm.mu.RUnlock()
+	if pending != 0 {
+		t.Fatalf("pedido sin olvidar tras registrarse")
+	}
+
+	// con cola no se retira aunque pase el plazo
+	mm.autoscale(now.Add(3 * time.Second))
+	mm.autoscale(now.Add(3*time.Second + 2*testScalePolicy.DownIdle))
+	expectNone(t, prov.released, "liberó")
+
+	for _, pid := range []string{"A", "B"} {
+		cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: pid})
+	}
+	idleAt := now.Add(time.Hour)
+	mm.autoscale(idleAt)
+	mm.autoscale(idleAt.Add(testScalePolicy.DownIdle - time.Second))
+	expectNone(t, prov.released, "liberó (antes del plazo)")
+	mm.autoscale(idleAt.Add(testScalePolicy.DownIdle))
+	if got := waitID(t, prov.released, "liberó"); got != id {
+		t.Fatalf("liberado %s, se esperaba %s", got, id)
+	}
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	ns := mm.lookupNS(defaultNamespace)
+	if _, ok := ns.servers.Get(id); ok || len(ns.removed) != 1 || ns.removed[0].Reason != removedScaledDown {
+		t.Errorf("el servidor no quedó retirado como %s: %v", removedScaledDown, ns.removed)
+	}
+}
+
+func TestAutoscaleReleasesLostProvision(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	prov := newFakeProvisioner()
+	mm.scaler = newAutoscaler(prov, testScalePolicy)
+	ctx := context.Background()
+
+	for _, pid := range []string{"A", "B"} {
+		cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: pid})
+	}
+	now := time.Now()
+	mm.autoscale(now)
+	lost := waitID(t, prov.provisioned, "pidió")
+
+	// nunca se registra: al vencer el plazo se libera y se pide otro
+	mm.autoscale(now.Add(testScalePolicy.ProvisionTimeout))
+	if got := waitID(t, prov.released, "liberó"); got != lost {
+		t.Fatalf("liberado %s, se esperaba %s", got, lost)
+	}
+	if next := waitID(t, prov.provisioned, "pidió"); next == lost {
+		t.Fatalf("se reutilizó el ID %s", lost)
+	}
+}
@@ -0,0 +1,201 @@
+// matchmaker/backfill.go
+//
+// Relleno de salas en curso. Una sala que arrancó sin llenarse (LobbyWait
+// vencido con LobbyMin jugadores) o que perdió jugadores por desconexión
+// puede pedir con RequestBackfill que el Matchmaker le sume jugadores de la
+// cola. La partida guarda los huecos pedidos en OpenSlots y, en cada vuelta
+// de tryCreateMatch, backfill los cubre antes de formar partidas nuevas del
+// mismo modo: los jugadores elegidos pasan a la partida, reciben MATCH_FOUND
+// con la dirección del servidor y el servidor recibe una orden BACKFILL por
+// el stream Heartbeat. Un grupo sólo entra si caben todos sus integrantes.
+//
+// Sólo las salas admiten relleno: en los modos por equipos la primera mitad
+// de Players es un equipo y la segunda el otro, y sumar jugadores los
+// desarmaría. Los huecos se replican con la partida.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+/*───────────────────────────────────────────────────────────────────────────────
+                              RPC: RequestBackfill
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) RequestBackfill(ctx context.Context, req *pb.RequestBackfillRequest) (*pb.RequestBackfillResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.noteServerClock(ns, req.GetServerId(), req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	matchID := req.GetMatchId()
+	am, ok := ns.matches.Get(matchID)
+	switch {
+	case !ok:
+		return &pb.RequestBackfillResponse{
+			StatusCode:  pb.RequestBackfillResponse_UNKNOWN_MATCH,
+			Message:     "Partida desconocida o ya cerrada",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	case am.ServerID != req.GetServerId():
+		return &pb.RequestBackfillResponse{
+			StatusCode:  pb.RequestBackfillResponse_NOT_OWNER,
+			Message:     fmt.Sprintf("La partida está asignada a %s", am.ServerID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	mode, ok := ns.modes[am.Mode]
+	if !ok || !mode.isLobby() {
+		return &pb.RequestBackfillResponse{
+			StatusCode:  pb.RequestBackfillResponse_NOT_LOBBY,
+			Message:     fmt.Sprintf("El modo %s es por equipos y no admite relleno", am.Mode),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	for _, pid := range req.GetDepartedPlayerIds() {
+		m.dropFromMatch(ns, matchID, am, pid)
+	}
+	open := int(req.GetOpenSlots())
+	if max := mode.LobbyMax - len(am.Players); open > max {
+		open = max
+	}
+	if open < 0 {
+		open = 0
+	}
+	am.OpenSlots = open
+	m.logf("[%s] Server %s pide relleno para %s: %d huecos (%d/%d jugadores)",
+		ns.name, am.ServerID, matchID, open, len(am.Players), mode.LobbyMax)
+
+	return &pb.RequestBackfillResponse{
+		StatusCode:  pb.RequestBackfillResponse_OK,
+		OpenSlots:   int32(open),
+		Message:     fmt.Sprintf("%d huecos abiertos", open),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+// dropFromMatch saca de la partida a un jugador que se desconectó del
+// servidor; queda IDLE y su lugar puede rellenarse.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) dropFromMatch(ns *namespace, matchID string, am *activeMatch, playerID string) {
+	kept := make([]string, 0, len(am.Players))
+	for _, pid := range am.Players {
+		if pid != playerID {
+			kept = append(kept, pid)
+		}
+	}
+	if len(kept) == len(am.Players) {
+		return
+	}
+	am.Players = kept
+	if p, ok := ns.players.Get(playerID); ok && p.MatchID == matchID {
+		p.Status, p.MatchID = playerIdle, ""
+		p.LastOp = m.wall.Now()
+	}
+	m.logf("[%s] Jugador %s se desconectó de la partida %s", ns.name, playerID, matchID)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                         Relleno desde el bucle de emparejamiento
+───────────────────────────────────────────────────────────────────────────────*/
+
+// backfill cubre con jugadores de la cola los huecos de las salas en curso
+// de mode, en orden de ID de partida.
+// debe llamarse con m.mu bloqueado y con la cola ya ordenada
+func (m *matchmaker) backfill(ns *namespace, mode *gameMode) {
+	if !mode.isLobby() {
+		return
+	}
+	for _, matchID := range ns.openMatches(mode.Name) {
+		am := ns.matches.Value(matchID)
+		srv, ok := ns.servers.Get(am.ServerID)
+		if !ok {
+			continue // el barrido de huérfanas la cerrará
+		}
+		players := ns.takeBackfill(mode, am.OpenSlots)
+		if players == nil {
+			continue // nadie de la cola cabe en sus huecos
+		}
+
+		now := m.wall.Now()
+		for _, pid := range players {
+			p := ns.players.Value(pid)
+			m.recordQueueWait(ns, mode.Name, now.Sub(p.LastOp), now)
+			ns.recordWait(pid, now.Sub(p.LastOp))
+			p.Status, p.MatchID = playerInMatch, matchID
+		}
+		am.Players = append(am.Players, players...)
+		am.OpenSlots -= len(players)
+
+		ns.vc.Tick(m.selfID)
+		am.Timeline = append(am.Timeline, m.newEvent(ns, pb.MatchEvent_BACKFILLED, strings.Join(players, ",")))
+		m.metrics.backfilled.With(ns.name, mode.Name).Add(float64(len(players)))
+		m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_MATCH_BACKFILLED, PlayerIds: players, MatchId: matchID, ServerId: srv.ID})
+
+		for _, pid := range players {
+			m.notify(ns, pid, &pb.MatchUpdate{
+				Event:      pb.MatchUpdate_MATCH_FOUND,
+				MatchId:    matchID,
+				ServerAddr: srv.Address,
+			})
+		}
+		m.pushControl(ns, srv.ID, &pb.ServerControl{
+			Command:   pb.ServerControl_BACKFILL,
+			MatchId:   matchID,
+			PlayerIds: players,
+		})
+		m.logClockf(ns, "[%s] Relleno de %s en server %s con jugadores %v (quedan %d huecos)",
+			ns.name, matchID, srv.ID, players, am.OpenSlots)
+	}
+}
+
+// openMatches lista, ordenadas, las partidas de mode con huecos pendientes.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) openMatches(mode string) []string {
+	var ids []string
+	for id, am := range ns.matches.All() {
+		if am.Mode == mode && am.OpenSlots > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// takeBackfill saca de la cola, en orden, hasta slots jugadores de mode sin
+// partir grupos. Devuelve nil si no eligió a nadie.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) takeBackfill(mode *gameMode, slots int) []string {
+	var picked []string
+	seen := make(map[string]bool)
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if !ok || p.Mode != mode.Name || seen[pid] {
+			continue
+		}
+		unit := ns.queuedUnit(p)
+		for _, id := range unit {
+			seen[id] = true
+		}
+		if len(picked)+len(unit) <= slots {
+			picked = append(picked, unit...)
+		}
+		if len(picked) == slots {
+			break
+		}
+	}
+	for _, pid := range picked {
+		ns.removeFromQueue(pid)
+	}
+	return picked
+}
@@ -0,0 +1,107 @@
+// matchmaker/backfill_test.go
+//
+// RequestBackfill: una sala incompleta se completa con la cola en la
+// siguiente vuelta, los desconectados liberan su lugar y los modos por
+// equipos no admiten relleno.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func requestBackfill(t *testing.T, cli pb.MatchmakerClient, req *pb.RequestBackfillRequest) *pb.RequestBackfillResponse {
+	t.Helper()
+	res, err := cli.RequestBackfill(context.Background(), req)
+	if err != nil {
+		t.Fatalf("RequestBackfill: %v", err)
+	}
+	return res
+}
+
+// startedLobby abre una sala "ffa" de 2 a 4 jugadores con A y B y devuelve
+// el ID de la partida.
+func startedLobby(r *failureRig) string {
+	r.t.Helper()
+	_, err := r.cli.AdminUpsertGameMode(context.Background(), &pb.AdminUpsertGameModeRequest{Mode: &pb.GameMode{
+		Name: "ffa", TeamSize: 1, Enabled: true, LobbyMinPlayers: 2, LobbyMaxPlayers: 4,
+	}})
+	if err != nil {
+		r.t.Fatalf("AdminUpsertGameMode: %v", err)
+	}
+	queue(r, "ffa", "A", "B")
+	return r.gs.WaitAssigned(r.t, 1, time.Second)[0].MatchID
+}
+
+func queue(r *failureRig, mode string, ids ...string) {
+	r.t.Helper()
+	for _, id := range ids {
+		if _, err := r.cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id, GameMode: mode}); err != nil {
+			r.t.Fatalf("%s: QueuePlayer: %v", id, err)
+		}
+	}
+	r.mm.tryCreateMatch()
+}
+
+func TestBackfillFillsOpenLobbySlots(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	matchID := startedLobby(r)
+
+	res := requestBackfill(t, r.cli, &pb.RequestBackfillRequest{ServerId: r.gs.ID, MatchId: matchID, OpenSlots: 5})
+	if res.GetStatusCode() != pb.RequestBackfillResponse_OK || res.GetOpenSlots() != 2 {
+		t.Fatalf("RequestBackfill = %v, se esperaban 2 huecos (sala de 4 con 2)", res)
+	}
+
+	queue(r, "ffa", "C")
+	r.inspect(func(ns *namespace) {
+		am := ns.matches.Value(matchID)
+		if len(am.Players) != 3 || am.Players[2] != "C" || am.OpenSlots != 1 {
+			t.Fatalf("partida = %v con %d huecos, se esperaba [A B C] con 1", am.Players, am.OpenSlots)
+		}
+		if p := ns.players.Value("C"); p.Status != playerInMatch || p.MatchID != matchID {
+			t.Errorf("C: %v en %q, se esperaba en %s", p.Status, p.MatchID, matchID)
+		}
+		if last := am.Timeline[len(am.Timeline)-1]; last.Stage != pb.MatchEvent_BACKFILLED || last.Detail != "C" {
+			t.Errorf("último hito = %v %q, se esperaba BACKFILLED C", last.Stage, last.Detail)
+		}
+		if len(ns.serverMatches(r.gs.ID)) != 1 {
+			t.Errorf("el relleno formó una partida nueva")
+		}
+	})
+
+	// A se desconecta: sale de la partida y su lugar queda libre
+	res = requestBackfill(t, r.cli, &pb.RequestBackfillRequest{
+		ServerId: r.gs.ID, MatchId: matchID, OpenSlots: 2, DepartedPlayerIds: []string{"A"},
+	})
+	if res.GetOpenSlots() != 2 {
+		t.Fatalf("RequestBackfill = %v, se esperaban 2 huecos", res)
+	}
+	r.inspect(func(ns *namespace) {
+		if got := ns.matches.Value(matchID).Players; len(got) != 2 || contains(got, "A") {
+			t.Errorf("jugadores = %v, A debía salir", got)
+		}
+		if p := ns.players.Value("A"); p.Status != playerIdle || p.MatchID != "" {
+			t.Errorf("A: %v en %q, se esperaba IDLE", p.Status, p.MatchID)
+		}
+	})
+}
+
+func TestBackfillRejectsTeamModesAndOtherServers(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	matchID := startedMatch(r)
+
+	if res := requestBackfill(t, r.cli, &pb.RequestBackfillRequest{ServerId: r.gs.ID, MatchId: matchID, OpenSlots: 1}); res.GetStatusCode() != pb.RequestBackfillResponse_NOT_LOBBY {
+		t.Errorf("modo 1v1: %v, se esperaba NOT_LOBBY", res.GetStatusCode())
+	}
+	if res := requestBackfill(t, r.cli, &pb.RequestBackfillRequest{ServerId: "otro", MatchId: matchID, OpenSlots: 1}); res.GetStatusCode() != pb.RequestBackfillResponse_NOT_OWNER {
+		t.Errorf("otro servidor: %v, se esperaba NOT_OWNER", res.GetStatusCode())
+	}
+	if res := requestBackfill(t, r.cli, &pb.RequestBackfillRequest{ServerId: r.gs.ID, MatchId: "nope", OpenSlots: 1}); res.GetStatusCode() != pb.RequestBackfillResponse_UNKNOWN_MATCH {
+		t.Errorf("partida desconocida: %v, se esperaba UNKNOWN_MATCH", res.GetStatusCode())
+	}
+}
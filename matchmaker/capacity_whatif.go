@@ -0,0 +1,356 @@
+// matchmaker/capacity_whatif.go
+//
+// Simulador de capacidad "¿qué pasaría si…?". Cada encolado queda registrado
+// como una llegada (instante, modo, tamaño del grupo). AdminSimulateCapacity
+// reproduce esas llegadas en una simulación de eventos discretos dos veces:
+// con los parámetros actuales del namespace y con los del escenario pedido
+// (más o menos servidores, otro tamaño de equipo, partidas más rápidas), y
+// devuelve los percentiles de espera proyectados para decidir cuántos
+// GameServers mantener.
+//
+// El modelo es deliberadamente simple: cola FIFO por modo, una partida ocupa
+// un hueco de servidor durante una duración tomada del historial de partidas
+// y se ignoran regiones, prioridades y el ritmo del bucle de emparejamiento.
+// Las salas por tiempo se aproximan con su mínimo de jugadores.
+
+package main
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	maxArrivalAge         = 24 * time.Hour
+	maxArrivals           = 100000
+	maxSimDurationSamples = 500
+	defaultSimMatchLength = 15 * time.Second
+)
+
+// arrival es un encolado registrado para el simulador.
+type arrival struct {
+	At   time.Time
+	Mode string
+	Size int // jugadores que entraron juntos (grupo)
+}
+
+// recordArrival guarda un encolado y descarta los que superan maxArrivalAge.
+// Las llegadas viven fuera de los namespaces para sobrevivir a restore().
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) recordArrival(ns *namespace, mode string, size int, now time.Time) {
+	m.arrivalsMu.Lock()
+	defer m.arrivalsMu.Unlock()
+	list := append(m.arrivals[ns.name], arrival{At: now, Mode: mode, Size: size})
+	cut := 0
+	for cut < len(list) && now.Sub(list[cut].At) > maxArrivalAge {
+		cut++
+	}
+	if len(list)-cut > maxArrivals {
+		cut = len(list) - maxArrivals
+	}
+	m.arrivals[ns.name] = list[cut:]
+}
+
+// whatIfScenario son los parámetros de una corrida del simulador.
+type whatIfScenario struct {
+	perMatch  map[string]int  // modo → jugadores por partida
+	servers   int             // servidores en el pool
+	slots     int             // partidas simultáneas en total
+	durations []time.Duration // duraciones de partida, se recorren en orden
+}
+
+// waitProjection es el resultado de una corrida.
+type waitProjection struct {
+	waits       []time.Duration // de los jugadores emparejados, ordenadas
+	unmatched   int
+	utilization float64
+}
+
+func (w waitProjection) percentile(p float64) time.Duration {
+	if len(w.waits) == 0 {
+		return 0
+	}
+	i := int(p*float64(len(w.waits)) + 0.5)
+	if i >= len(w.waits) {
+		i = len(w.waits) - 1
+	}
+	return w.waits[i]
+}
+
+func (w waitProjection) toProto() *pb.WaitProjection {
+	return &pb.WaitProjection{
+		P50Ms:       w.percentile(0.50).Milliseconds(),
+		P90Ms:       w.percentile(0.90).Milliseconds(),
+		P99Ms:       w.percentile(0.99).Milliseconds(),
+		Matched:     int32(len(w.waits)),
+		Unmatched:   int32(w.unmatched),
+		Utilization: w.utilization,
+	}
+}
+
+// endHeap son los finales de partida pendientes, el más próximo primero.
+type endHeap []time.Time
+
+func (h endHeap) Len() int            { return len(h) }
+func (h endHeap) Less(i, j int) bool  { return h[i].Before(h[j]) }
+func (h endHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *endHeap) Push(x interface{}) { *h = append(*h, x.(time.Time)) }
+func (h *endHeap) Pop() interface{} {
+	old := *h
+	t := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return t
+}
+
+// simulateWaits reproduce arrivals (en orden temporal) bajo sc y mide la
+// espera de los jugadores de focus ("" = todos los modos).
+func simulateWaits(arrivals []arrival, sc whatIfScenario, focus string) waitProjection {
+	var out waitProjection
+	if len(arrivals) == 0 {
+		return out
+	}
+	start := arrivals[0].At
+	horizon := arrivals[len(arrivals)-1].At
+
+	queues := make(map[string][]arrival)
+	var modes []string
+	for mode := range sc.perMatch {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+
+	ends := &endHeap{}
+	free := sc.slots
+	next := 0
+	var busy time.Duration
+
+	// form arma todas las partidas posibles en now, modo por modo
+	form := func(now time.Time) {
+		for _, mode := range modes {
+			for free > 0 {
+				picked := pickFIFO(queues[mode], sc.perMatch[mode])
+				if picked == nil {
+					break
+				}
+				rest := queues[mode][:0]
+				for i, a := range queues[mode] {
+					if !picked[i] {
+						rest = append(rest, a)
+					} else if focus == "" || focus == mode {
+						for k := 0; k < a.Size; k++ {
+							out.waits = append(out.waits, now.Sub(a.At))
+						}
+					}
+				}
+				queues[mode] = rest
+
+				d := defaultSimMatchLength
+				if len(sc.durations) > 0 {
+					d = sc.durations[next%len(sc.durations)]
+					next++
+				}
+				free--
+				busy += d
+				heap.Push(ends, now.Add(d))
+			}
+		}
+	}
+	release := func(until time.Time, all bool) {
+		for ends.Len() > 0 && (all || !(*ends)[0].After(until)) {
+			t := heap.Pop(ends).(time.Time)
+			if t.After(horizon) {
+				horizon = t
+			}
+			free++
+			form(t)
+		}
+	}
+
+	for _, a := range arrivals {
+		if _, ok := sc.perMatch[a.Mode]; !ok {
+			continue
+		}
+		release(a.At, false)
+		queues[a.Mode] = append(queues[a.Mode], a)
+		form(a.At)
+	}
+	release(time.Time{}, true)
+
+	for mode, q := range queues {
+		if focus != "" && focus != mode {
+			continue
+		}
+		for _, a := range q {
+			out.unmatched += a.Size
+		}
+	}
+	if span := horizon.Sub(start); sc.slots > 0 && span > 0 {
+		out.utilization = float64(busy) / (float64(span) * float64(sc.slots))
+		if out.utilization > 1 {
+			out.utilization = 1
+		}
+	}
+	sort.Slice(out.waits, func(i, j int) bool { return out.waits[i] < out.waits[j] })
+	return out
+}
+
+// pickFIFO elige, en orden de llegada, grupos que sumen exactamente n
+// jugadores. Un grupo que no cabe se salta, como en el emparejamiento real.
+func pickFIFO(queue []arrival, n int) map[int]bool {
+	if n <= 0 {
+		return nil
+	}
+	picked := make(map[int]bool)
+	total := 0
+	for i, a := range queue {
+		if total+a.Size > n {
+			continue
+		}
+		picked[i] = true
+		total += a.Size
+		if total == n {
+			return picked
+		}
+	}
+	return nil
+}
+
+// currentScenario describe el namespace tal como está ahora.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) currentScenario() whatIfScenario {
+	sc := whatIfScenario{perMatch: make(map[string]int)}
+	for name, g := range ns.modes {
+		n := 2 * g.TeamSize
+		if g.isLobby() {
+			n = g.LobbyMin
+		}
+		sc.perMatch[name] = n
+	}
+	for _, s := range ns.servers.All() {
+		if s.Status == serverDown || s.Draining {
+			continue
+		}
+		sc.servers++
+		sc.slots += s.Capacity
+	}
+
+	// duraciones reales más recientes; sin historial, la pista de cada modo
+	for i := len(ns.history) - 1; i >= 0 && len(sc.durations) < maxSimDurationSamples; i-- {
+		if d := ns.history[i].Duration; d > 0 && ns.history[i].completed() {
+			sc.durations = append(sc.durations, d)
+		}
+	}
+	if len(sc.durations) == 0 {
+		for _, g := range ns.sortedModes() {
+			if g.MaxDuration > 0 {
+				sc.durations = append(sc.durations, time.Duration(g.MinDuration+g.MaxDuration)*time.Second/2)
+			}
+		}
+	}
+	return sc
+}
+
+// apply deriva el escenario hipotético del pedido.
+func (sc whatIfScenario) apply(req *pb.WhatIfRequest) whatIfScenario {
+	out := whatIfScenario{
+		perMatch: make(map[string]int, len(sc.perMatch)),
+		servers:  sc.servers,
+		slots:    sc.slots,
+	}
+	for mode, n := range sc.perMatch {
+		if ts := int(req.GetTeamSize()); ts > 0 && (req.GetGameMode() == "" || req.GetGameMode() == mode) {
+			n = 2 * ts
+		}
+		out.perMatch[mode] = n
+	}
+
+	// los servidores nuevos (o quitados) tienen la capacidad media actual
+	if extra := int(req.GetExtraServers()); extra != 0 {
+		perServer := 1
+		if sc.servers > 0 {
+			perServer = (sc.slots + sc.servers/2) / sc.servers
+		}
+		out.servers = max(0, sc.servers+extra)
+		out.slots = max(0, sc.slots+extra*perServer)
+	}
+
+	factor := req.GetDurationFactor()
+	if factor <= 0 {
+		factor = 1
+	}
+	for _, d := range sc.durations {
+		out.durations = append(out.durations, time.Duration(float64(d)*factor))
+	}
+	if len(out.durations) == 0 {
+		out.durations = []time.Duration{time.Duration(float64(defaultSimMatchLength) * factor)}
+	}
+	return out
+}
+
+func (sc whatIfScenario) avgDuration() time.Duration {
+	if len(sc.durations) == 0 {
+		return defaultSimMatchLength
+	}
+	var total time.Duration
+	for _, d := range sc.durations {
+		total += d
+	}
+	return total / time.Duration(len(sc.durations))
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+          RPC: AdminSimulateCapacity – proyección de esperas hipotéticas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminSimulateCapacity(ctx context.Context, req *pb.WhatIfRequest) (*pb.WhatIfResponse, error) {
+	now := m.wall.Now()
+
+	// copiamos bajo el lock y simulamos fuera de él
+	m.mu.RLock()
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		m.mu.RUnlock()
+		return &pb.WhatIfResponse{}, nil
+	}
+	current := ns.currentScenario()
+	var arrivals []arrival
+	m.arrivalsMu.Lock()
+	for _, a := range m.arrivals[ns.name] {
+		if req.GetWindowMs() <= 0 || now.Sub(a.At) <= time.Duration(req.GetWindowMs())*time.Millisecond {
+			arrivals = append(arrivals, a)
+		}
+	}
+	m.arrivalsMu.Unlock()
+	clock := m.clockProto(ns)
+	m.mu.RUnlock()
+
+	projected := current.apply(req)
+	base := simulateWaits(arrivals, current.apply(&pb.WhatIfRequest{}), req.GetGameMode())
+	what := simulateWaits(arrivals, projected, req.GetGameMode())
+
+	res := &pb.WhatIfResponse{
+		Servers:     int32(projected.servers),
+		Slots:       int32(projected.slots),
+		AvgMatchMs:  projected.avgDuration().Milliseconds(),
+		Current:     base.toProto(),
+		Projected:   what.toProto(),
+		VectorClock: clock,
+	}
+	for _, a := range arrivals {
+		if req.GetGameMode() == "" || req.GetGameMode() == a.Mode {
+			res.Arrivals += int32(a.Size)
+		}
+	}
+	if len(arrivals) > 0 {
+		res.WindowMs = now.Sub(arrivals[0].At).Milliseconds()
+	}
+
+	m.logf("[%s] Simulación de capacidad (modo %q, %+d servidores, equipo %d, duración ×%.2f): p90 %v → %v sobre %d llegadas",
+		ns.name, req.GetGameMode(), req.GetExtraServers(), req.GetTeamSize(), req.GetDurationFactor(),
+		base.percentile(0.90).Round(time.Millisecond), what.percentile(0.90).Round(time.Millisecond), res.Arrivals)
+	return res, nil
+}
@@ -0,0 +1,112 @@
+// matchmaker/chaos.go
+//
+// Inyección de fallos (internal/chaos) en el Matchmaker. Con CHAOS_*_PROB o
+// AdminSetChaos, las RPC de jugadores y GameServers pueden descartarse con
+// UNAVAILABLE, demorarse o devolver un reloj vectorial adelantado, y el
+// proceso puede caerse justo después de atender una RPC, con el estado ya
+// cambiado y sin responder: el caso que debe cubrir el respaldo. Las RPC
+// Admin*, la replicación, el traspaso y la salud quedan exentas, para que
+// el administrador siempre pueda apagar los fallos.
+//
+// AdminSetChaos también configura los GameServers: la orden CHAOS viaja por
+// su stream Heartbeat y cada servidor la aplica a sus propias RPC y a su
+// caída tras una partida (el CRASH_PROB de siempre).
+
+package main
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/chaos"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// chaosExempt indica las RPC a las que no se inyectan fallos.
+func chaosExempt(fullMethod string) bool {
+	switch fullMethod {
+	case pb.Matchmaker_ReplicateState_FullMethodName, pb.Matchmaker_TransferState_FullMethodName:
+		return true
+	}
+	return isHealthMethod(fullMethod) || strings.HasPrefix(path.Base(fullMethod), "Admin")
+}
+
+// chaosCrash es el punto de caída del Matchmaker; m.exit es os.Exit salvo
+// en pruebas.
+func (m *matchmaker) chaosCrash(fullMethod string) {
+	m.logf("¡Simulando CAÍDA del Matchmaker tras %s! (chaos)", path.Base(fullMethod))
+	m.exit(1)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+              RPC: AdminSetChaos – fallos inyectados en caliente
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminSetChaos(ctx context.Context, req *pb.AdminSetChaosRequest) (*pb.AdminUpdateResponse, error) {
+	cfg := chaos.FromProto(req.GetConfig())
+	if err := cfg.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns != nil {
+		m.mergeClock(ns, req.GetClock())
+	}
+
+	// el Matchmaker es uno para todos los namespaces
+	if req.GetTarget() == "" {
+		auditChange(ctx, "Matchmaker", m.chaos.Config().String(), cfg.String())
+		m.chaos.Set(cfg)
+		m.logf("WARNING: fallos inyectados en el Matchmaker: %s", cfg)
+		res := &pb.AdminUpdateResponse{Status: pb.AdminUpdateResponse_OK, Message: "Matchmaker: " + cfg.String()}
+		if ns != nil {
+			res.VectorClock = m.clockProto(ns)
+		}
+		return res, nil
+	}
+	if ns == nil {
+		return &pb.AdminUpdateResponse{
+			Status:  pb.AdminUpdateResponse_NOT_FOUND,
+			Message: "Namespace desconocido",
+		}, nil
+	}
+
+	var targets []string
+	for id := range ns.servers.All() {
+		_, streaming := m.controls[controlKey(ns.name, id)]
+		if streaming && (req.GetTarget() == "*" || req.GetTarget() == id) {
+			targets = append(targets, id)
+		}
+	}
+	if len(targets) == 0 {
+		msg := "Servidor desconocido o sin stream Heartbeat"
+		if req.GetTarget() == "*" {
+			msg = "Ningún servidor con stream Heartbeat"
+		}
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_NOT_FOUND,
+			Message:     msg,
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	sort.Strings(targets)
+	// la configuración anterior de cada GameServer la conoce sólo él
+	auditChange(ctx, strings.Join(targets, ","), "", cfg.String())
+	for _, id := range targets {
+		m.pushControl(ns, id, &pb.ServerControl{Command: pb.ServerControl_CHAOS, Chaos: cfg.ToProto()})
+	}
+	m.logf("[%s] WARNING: fallos inyectados en %v: %s", ns.name, targets, cfg)
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     strings.Join(targets, ", ") + ": " + cfg.String(),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
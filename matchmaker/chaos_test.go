@@ -0,0 +1,133 @@
+// matchmaker/chaos_test.go
+//
+// Fallos inyectados: con la misma semilla el interceptor descarta las
+// mismas RPC, nunca las de administración; el reloj adelantado no toca el
+// estado guardado; la caída llega después del handler; y AdminSetChaos
+// configura el Matchmaker o reenvía la orden CHAOS a los servidores.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/chaos"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// callThrough pasa una RPC por el interceptor de m y devuelve su respuesta
+// y si se ejecutó el handler.
+func callThrough(m *matchmaker, method string, resp interface{}) (interface{}, bool, error) {
+	ran := false
+	out, err := m.chaos.UnaryServerInterceptor(chaosExempt, m.chaosCrash)(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			ran = true
+			return resp, nil
+		})
+	return out, ran, err
+}
+
+func TestChaosDropsAreReproducibleAndSpareAdmin(t *testing.T) {
+	m := newMatchmaker("Matchmaker")
+	pattern := func() []bool {
+		m.chaos.Set(chaos.Config{DropProb: 0.5, Seed: 7})
+		var out []bool
+		for i := 0; i < 40; i++ {
+			_, ran, err := callThrough(m, pb.Matchmaker_QueuePlayer_FullMethodName, &pb.QueuePlayerResponse{})
+			if !ran && status.Code(err) != codes.Unavailable {
+				t.Fatalf("descarte con %v, se esperaba UNAVAILABLE", err)
+			}
+			out = append(out, ran)
+		}
+		return out
+	}
+
+	first, second := pattern(), pattern()
+	dropped := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("RPC %d: con la misma semilla %v y luego %v", i, first[i], second[i])
+		}
+		if !first[i] {
+			dropped++
+		}
+	}
+	if dropped == 0 || dropped == len(first) {
+		t.Fatalf("%d de %d descartadas con probabilidad 0.5", dropped, len(first))
+	}
+
+	for i := 0; i < 20; i++ {
+		if _, ran, _ := callThrough(m, pb.Matchmaker_AdminSetChaos_FullMethodName, &pb.AdminUpdateResponse{}); !ran {
+			t.Fatalf("AdminSetChaos descartada por chaos")
+		}
+	}
+}
+
+func TestChaosClockAndCrash(t *testing.T) {
+	m := newMatchmaker("Matchmaker")
+	exits := 0
+	m.exit = func(int) { exits++ }
+
+	stored := &pb.PlayerStatusResponse{VectorClock: &pb.VectorClock{Counters: map[string]int32{"Matchmaker": 5}}}
+	m.chaos.Set(chaos.Config{ClockProb: 1, Seed: 1})
+	out, _, err := callThrough(m, pb.Matchmaker_GetPlayerStatus_FullMethodName, stored)
+	if err != nil {
+		t.Fatalf("GetPlayerStatus: %v", err)
+	}
+	if got := out.(*pb.PlayerStatusResponse).GetVectorClock().GetCounters()["Matchmaker"]; got <= 5 {
+		t.Fatalf("reloj de la respuesta = %d, se esperaba adelantado", got)
+	}
+	if stored.VectorClock.Counters["Matchmaker"] != 5 {
+		t.Fatalf("el reloj guardado cambió a %d", stored.VectorClock.Counters["Matchmaker"])
+	}
+
+	m.chaos.Set(chaos.Config{CrashProb: 1})
+	if _, ran, _ := callThrough(m, pb.Matchmaker_CancelQueue_FullMethodName, &pb.CancelQueueResponse{}); !ran || exits != 1 {
+		t.Fatalf("caída: handler ejecutado %v, %d salidas", ran, exits)
+	}
+}
+
+func TestAdminSetChaosTargets(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := &pb.ChaosConfig{DropProb: 0.25, Seed: 3}
+
+	res, err := cli.AdminSetChaos(ctx, &pb.AdminSetChaosRequest{Config: cfg})
+	if err != nil || res.GetStatus() != pb.AdminUpdateResponse_OK {
+		t.Fatalf("AdminSetChaos(Matchmaker): %v %v", res, err)
+	}
+	if got := mm.chaos.Config(); got.DropProb != 0.25 || got.Seed != 3 {
+		t.Fatalf("configuración del Matchmaker = %+v", got)
+	}
+	if _, err := cli.AdminSetChaos(ctx, &pb.AdminSetChaosRequest{Config: &pb.ChaosConfig{CrashProb: 2}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("probabilidad 2: %v, se esperaba InvalidArgument", err)
+	}
+
+	stream, err := cli.Heartbeat(ctx)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	if err := stream.Send(&pb.ServerStatusUpdateRequest{ServerId: "gs1", NewStatus: pb.ServerStatus_DISPONIBLE, Address: "127.0.0.1:60051"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if ack, err := stream.Recv(); err != nil || ack.GetCommand() != pb.ServerControl_ACK {
+		t.Fatalf("registro: %v %v", ack, err)
+	}
+
+	if res, _ := cli.AdminSetChaos(ctx, &pb.AdminSetChaosRequest{Target: "gs9", Config: cfg}); res.GetStatus() != pb.AdminUpdateResponse_NOT_FOUND {
+		t.Fatalf("servidor desconocido: %v", res)
+	}
+	if res, err := cli.AdminSetChaos(ctx, &pb.AdminSetChaosRequest{Target: "*", Config: cfg}); err != nil || res.GetStatus() != pb.AdminUpdateResponse_OK {
+		t.Fatalf("AdminSetChaos(*): %v %v", res, err)
+	}
+	msg, err := stream.Recv()
+	if err != nil || msg.GetCommand() != pb.ServerControl_CHAOS || msg.GetChaos().GetDropProb() != 0.25 {
+		t.Fatalf("el servidor recibió %v (%v), se esperaba CHAOS", msg, err)
+	}
+}
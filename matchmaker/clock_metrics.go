@@ -0,0 +1,104 @@
+// matchmaker/clock_metrics.go
+//
+// Instrumentación del reloj vectorial del Matchmaker: cantidad de
+// componentes, costo de cada merge y tamaño serializado de cada reloj
+// enviado. Sirve para medir el overhead de consistencia a medida que
+// crece la población de jugadores.
+
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+type clockMetrics struct {
+	merges          atomic.Uint64
+	mergeNanos      atomic.Uint64
+	maxMergeNanos   atomic.Uint64
+	serializations  atomic.Uint64
+	serializedBytes atomic.Uint64
+	lastSerialized  atomic.Int64
+	pruned          atomic.Uint64 // componentes podados (clock_pruning.go)
+}
+
+func (c *clockMetrics) observeMerge(d time.Duration) {
+	ns := uint64(d.Nanoseconds())
+	c.merges.Add(1)
+	c.mergeNanos.Add(ns)
+	for {
+		cur := c.maxMergeNanos.Load()
+		if ns <= cur || c.maxMergeNanos.CompareAndSwap(cur, ns) {
+			return
+		}
+	}
+}
+
+func (c *clockMetrics) observeSerialization(size int) {
+	c.serializations.Add(1)
+	c.serializedBytes.Add(uint64(size))
+	c.lastSerialized.Store(int64(size))
+}
+
+// mergeClock fusiona el reloj recibido en el del namespace midiendo su costo.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) mergeClock(ns *namespace, p *pb.VectorClock) {
+	start := time.Now()
+	ns.vc.Merge(clocks.FromProto(p))
+	m.vcStats.observeMerge(time.Since(start))
+}
+
+// mergeTick es mergeClock más el tick propio, en un solo paso del reloj:
+// con m.mu en lectura (locking.go) otra RPC no ve uno sin el otro.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) mergeTick(ns *namespace, p *pb.VectorClock) {
+	start := time.Now()
+	ns.vc.MergeTick(clocks.FromProto(p), m.selfID)
+	m.vcStats.observeMerge(time.Since(start))
+}
+
+// clockProto serializa el reloj del namespace registrando su tamaño.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) clockProto(ns *namespace) *pb.VectorClock {
+	out := ns.vc.ToProto()
+	m.vcStats.observeSerialization(proto.Size(out))
+	return out
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+              RPC: AdminGetClockMetrics – overhead del reloj vectorial
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminGetClockMetrics(ctx context.Context, req *pb.AdminRequest) (*pb.ClockMetricsResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	st := &m.vcStats
+	res := &pb.ClockMetricsResponse{
+		Merges:              st.merges.Load(),
+		MaxMergeMicros:      float64(st.maxMergeNanos.Load()) / 1e3,
+		Serializations:      st.serializations.Load(),
+		LastSerializedBytes: int32(st.lastSerialized.Load()),
+		PrunedEntries:       st.pruned.Load(),
+		ReplicaConflicts:    m.replica.conflicts.Load(),
+	}
+	// las métricas de costo son globales; el tamaño del reloj es del namespace
+	if ns := m.lookupNS(req.GetNamespace()); ns != nil {
+		res.Entries = int32(ns.vc.Len())
+		res.Tombstones = int32(ns.vc.Tombstones())
+		res.VectorClock = m.clockProto(ns)
+	}
+	if res.Merges > 0 {
+		res.AvgMergeMicros = float64(st.mergeNanos.Load()) / float64(res.Merges) / 1e3
+	}
+	if res.Serializations > 0 {
+		res.AvgSerializedBytes = float64(st.serializedBytes.Load()) / float64(res.Serializations)
+	}
+	return res, nil
+}
@@ -0,0 +1,54 @@
+// matchmaker/clock_propagation.go
+//
+// Relojes en la metadata (internal/clockpropagation). Los interceptores
+// completan el campo clock de las peticiones que llegan sin él y devuelven
+// el vector_clock de la respuesta en la cabecera; estos ganchos cubren las
+// RPC que no declaran campos de reloj: el reloj recibido se fusiona con el
+// del namespace de la petición y la cabecera lleva ese mismo reloj. Una
+// petición sin namespace usa el predeterminado; los ganchos no crean
+// namespaces.
+
+package main
+
+import (
+	"context"
+
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+)
+
+// namespacedRequest lo cumplen las peticiones con campo namespace.
+type namespacedRequest interface {
+	GetNamespace() string
+}
+
+// requestNS devuelve el namespace de req, o nil si no existe.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) requestNS(req interface{}) *namespace {
+	name := ""
+	if r, ok := req.(namespacedRequest); ok {
+		name = r.GetNamespace()
+	}
+	return m.lookupNS(name)
+}
+
+// clockHooks conecta los interceptores con los relojes de los namespaces.
+func (m *matchmaker) clockHooks() clockpropagation.Hooks {
+	return clockpropagation.Hooks{
+		Merge: func(_ context.Context, req interface{}, remote *clocks.Vector) {
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+			if ns := m.requestNS(req); ns != nil {
+				m.mergeClock(ns, remote.ToProto())
+			}
+		},
+		Current: func(_ context.Context, req interface{}) *clocks.Vector {
+			m.mu.RLock()
+			defer m.mu.RUnlock()
+			if ns := m.requestNS(req); ns != nil {
+				return ns.vc.Copy()
+			}
+			return nil
+		},
+	}
+}
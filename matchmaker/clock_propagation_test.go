@@ -0,0 +1,89 @@
+// matchmaker/clock_propagation_test.go
+//
+// Relojes en la metadata: una petición sin campo clock (o con el campo
+// vacío) se ordena con el reloj de la metadata, en cualquiera de los dos
+// codecs, y el cliente adopta el reloj que vuelve en la cabecera.
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// startClockPropagation levanta un Matchmaker con los interceptores de
+// relojes y devuelve un cliente de mmclient con el reloj local y el codec
+// dados.
+func startClockPropagation(t *testing.T, local *clocks.Vector, codec clockpropagation.Codec) (*matchmaker, pb.MatchmakerClient) {
+	t.Helper()
+	mm := newMatchmaker("Matchmaker")
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(clockpropagation.UnaryServer(mm.clockHooks())),
+		grpc.ChainStreamInterceptor(clockpropagation.StreamServer(mm.clockHooks())),
+	)
+	pb.RegisterMatchmakerServer(srv, mm)
+	go srv.Serve(lis)
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+	opts := mmclient.Options{ClientID: "P1", Clock: local, ClockCodec: codec}
+	conn, err := grpc.NewClient("passthrough:///bufnet", append(mmclient.DialOptions(opts), dialer)...)
+	if err != nil {
+		t.Fatalf("no se pudo conectar al Matchmaker: %v", err)
+	}
+	t.Cleanup(func() {
+		close(mm.done)
+		conn.Close()
+		srv.Stop()
+	})
+	return mm, pb.NewMatchmakerClient(conn)
+}
+
+func TestClockPropagation(t *testing.T) {
+	for _, codec := range []clockpropagation.Codec{clockpropagation.Text, clockpropagation.Binary} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			local := clocks.New("P1")
+			local.Tick("P1")
+			local.Tick("P1")
+			mm, cli := startClockPropagation(t, local, codec)
+			ctx := context.Background()
+
+			// QueuePlayer sin clock en el mensaje: lo completa la metadata
+			if res, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "P1"}); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+				t.Fatalf("QueuePlayer = %v (%v)", res, err)
+			}
+			mm.mu.RLock()
+			got := mm.lookupNS(defaultNamespace).vc.Get("P1")
+			mm.mu.RUnlock()
+			if got != 2 {
+				t.Fatalf("componente P1 del namespace = %d, se esperaba 2", got)
+			}
+			if local.Get(mm.selfID) == 0 {
+				t.Fatalf("el cliente no adoptó el reloj de la cabecera: %s", local)
+			}
+
+			// ListGameModesRequest no tiene campo de reloj: lo fusiona el gancho
+			local.Tick("P1")
+			if _, err := cli.ListGameModes(ctx, &pb.ListGameModesRequest{}); err != nil {
+				t.Fatalf("ListGameModes: %v", err)
+			}
+			mm.mu.RLock()
+			got = mm.lookupNS(defaultNamespace).vc.Get("P1")
+			mm.mu.RUnlock()
+			if got != 3 {
+				t.Fatalf("componente P1 tras ListGameModes = %d, se esperaba 3", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// matchmaker/clock_pruning.go
+//
+// Poda del reloj vectorial de cada namespace. Cada jugador que envía su
+// reloj agrega un componente, y sin poda el reloj crece con toda la
+// población histórica. En cada vuelta del bucle de emparejamiento se
+// descartan con clocks.Vector.Prune los componentes que no avanzan hace más
+// de CLOCK_TTL (nunca el propio); cada uno deja una lápida con su último
+// valor para que un cliente que reenvíe ese valor no lo resucite. Las
+// lápidas se olvidan tras otro CLOCK_TTL y viajan en los snapshots al
+// respaldo.
+//
+// El reloj no lleva hora propia: la inactividad se mide con la hora de
+// pared del Matchmaker (m.wall) que el bucle pasa a cada poda, con la
+// resolución de MATCH_CHECK_PERIOD.
+
+package main
+
+import (
+	"time"
+)
+
+const defaultClockTTL = 30 * time.Minute
+
+// pruneClocks poda el reloj de cada namespace; con clockTTL = 0 no poda.
+func (m *matchmaker) pruneClocks(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.clockTTL <= 0 {
+		return
+	}
+
+	for _, ns := range m.namespaces {
+		n := ns.vc.Prune(now, m.clockTTL, m.selfID)
+		if n == 0 {
+			continue
+		}
+		m.vcStats.pruned.Add(uint64(n))
+		m.logf("[%s] Reloj vectorial podado: %d componentes inactivos por más de %v (quedan %d, %d lápidas)",
+			ns.name, n, m.clockTTL, ns.vc.Len(), ns.vc.Tombstones())
+	}
+}
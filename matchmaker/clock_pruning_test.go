@@ -0,0 +1,54 @@
+// matchmaker/clock_pruning_test.go
+//
+// Poda del reloj de un namespace con la hora de pared del matchmaker: la
+// inactividad de cada componente se mide con la hora que el bucle pasa a
+// pruneClocks, no con la del proceso.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestPruneClocksUsesWallClock(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	wall := walltime.NewFake(time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC))
+	mm.wall = wall
+	mm.clockTTL = time.Minute
+
+	queue := func(id string) {
+		t.Helper()
+		_, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{
+			PlayerId: id, Clock: &pb.VectorClock{Counters: map[string]int32{id: 3}},
+		})
+		if err != nil {
+			t.Fatalf("%s: QueuePlayer: %v", id, err)
+		}
+	}
+	// como el bucle de emparejamiento: una poda por vuelta
+	tick := func(d time.Duration) {
+		for end := wall.Now().Add(d); wall.Now().Before(end); {
+			wall.Advance(5 * time.Second)
+			mm.pruneClocks(wall.Now())
+		}
+	}
+	queue("P1")
+	tick(30 * time.Second)
+	queue("P2")
+	tick(45 * time.Second) // P1 lleva 75 s sin avanzar, P2 45 s
+
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	vc := mm.lookupNS(defaultNamespace).vc
+	if vc.Tombstones() != 1 || vc.Get("P1") != 3 {
+		t.Fatalf("P1 debía quedar como lápida con 3: %d lápidas, P1=%d", vc.Tombstones(), vc.Get("P1"))
+	}
+	if _, live := vc.ToProto().GetCounters()["P2"]; !live {
+		t.Fatalf("P2 avanzó hace 45 s y se podó: %s", vc)
+	}
+}
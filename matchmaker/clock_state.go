@@ -0,0 +1,94 @@
+// matchmaker/clock_state.go
+//
+// Auditoría de la sincronización de relojes. Cada jugador y cada servidor
+// guarda el último reloj vectorial que envió al Matchmaker (el de su última
+// RPC con reloj no vacío), junto con la hora en que llegó.
+// AdminGetClockState devuelve esos relojes con la vista fusionada del
+// namespace y sus lápidas, para que el cliente administrador compare ambos
+// lados: como el Matchmaker fusiona todo reloj que recibe, ningún componente
+// de una entidad puede ir por delante de su vista, y el propio componente de
+// una entidad no puede retroceder entre una RPC y la siguiente. Los relojes
+// de las entidades no se replican: tras una conmutación el respaldo los
+// vuelve a conocer con la próxima RPC de cada una.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// sawClock guarda c como el último reloj del jugador; uno vacío (cliente
+// que no envía reloj) no borra el anterior.
+func (p *playerInfo) sawClock(c *pb.VectorClock, now time.Time) {
+	if len(c.GetCounters()) > 0 {
+		p.VC, p.VCAt = clocks.FromProto(c), now
+	}
+}
+
+// sawClock es la variante de playerInfo.sawClock para servidores.
+func (s *gameServerInfo) sawClock(c *pb.VectorClock, now time.Time) {
+	if len(c.GetCounters()) > 0 {
+		s.VC, s.VCAt = clocks.FromProto(c), now
+	}
+}
+
+// notePlayerClock guarda el reloj de playerID si el jugador ya existe.
+// debe llamarse con m.mu bloqueado en escritura, o en lectura con el
+// jugador bloqueado (locking.go)
+func (m *matchmaker) notePlayerClock(ns *namespace, playerID string, c *pb.VectorClock) {
+	if p, ok := ns.players.Get(playerID); ok {
+		p.sawClock(c, m.wall.Now())
+	}
+}
+
+// noteServerClock guarda el reloj de serverID si el servidor ya existe.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) noteServerClock(ns *namespace, serverID string, c *pb.VectorClock) {
+	if s, ok := ns.servers.Get(serverID); ok {
+		s.sawClock(c, m.wall.Now())
+	}
+}
+
+func entityClock(kind pb.EntityClock_Kind, id string, vc *clocks.Vector, at time.Time) *pb.EntityClock {
+	return &pb.EntityClock{Kind: kind, Id: id, Clock: vc.ToProto(), LastSeenUnixMs: at.UnixMilli()}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+         RPC: AdminGetClockState – relojes del Matchmaker y de sus clientes
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminGetClockState(ctx context.Context, req *pb.AdminRequest) (*pb.ClockStateResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := &pb.ClockStateResponse{MatchmakerId: m.selfID}
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return out, nil
+	}
+	out.VectorClock = m.clockProto(ns)
+	out.Tombstones = ns.vc.TombstonesToProto()
+	for id, p := range ns.players.All() {
+		if p.VC != nil && p.VC.Len() > 0 {
+			out.Entities = append(out.Entities, entityClock(pb.EntityClock_PLAYER, id, p.VC, p.VCAt))
+		}
+	}
+	for id, s := range ns.servers.All() {
+		if s.VC != nil && s.VC.Len() > 0 {
+			out.Entities = append(out.Entities, entityClock(pb.EntityClock_SERVER, id, s.VC, s.VCAt))
+		}
+	}
+	sort.Slice(out.Entities, func(i, j int) bool {
+		a, b := out.Entities[i], out.Entities[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Id < b.Id
+	})
+	return out, nil
+}
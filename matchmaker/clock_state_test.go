@@ -0,0 +1,70 @@
+// matchmaker/clock_state_test.go
+//
+// AdminGetClockState: guarda el último reloj de cada jugador y servidor que
+// envía uno, un reloj vacío no borra el anterior y la vista del Matchmaker
+// cubre todo lo recibido.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestClockStateRecordsLastSeenClocks(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	ctx := context.Background()
+
+	a := clocks.New()
+	a.Tick("A")
+	if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "A", Clock: a.ToProto()}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	a.Tick("A")
+	if _, err := cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: "A", Clock: a.ToProto()}); err != nil {
+		t.Fatalf("CancelQueue: %v", err)
+	}
+	// sin reloj: no reemplaza el anterior
+	if _, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: "A"}); err != nil {
+		t.Fatalf("GetPlayerStatus: %v", err)
+	}
+	if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "B"}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	gs := clocks.New()
+	gs.Tick("gs1")
+	if _, err := cli.UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{
+		ServerId: "gs1", NewStatus: pb.ServerStatus_DISPONIBLE, Address: "127.0.0.1:1", Clock: gs.ToProto(),
+	}); err != nil {
+		t.Fatalf("UpdateServerStatus: %v", err)
+	}
+
+	res, err := cli.AdminGetClockState(ctx, &pb.AdminRequest{})
+	if err != nil {
+		t.Fatalf("AdminGetClockState: %v", err)
+	}
+	if len(res.GetEntities()) != 2 {
+		t.Fatalf("entidades = %v; se esperaban A y gs1 (B nunca envió reloj)", res.GetEntities())
+	}
+	player, server := res.GetEntities()[0], res.GetEntities()[1]
+	if player.GetKind() != pb.EntityClock_PLAYER || player.GetId() != "A" || player.GetClock().GetCounters()["A"] != 2 {
+		t.Errorf("reloj de A = %v, se esperaba A=2", player)
+	}
+	if server.GetKind() != pb.EntityClock_SERVER || server.GetId() != "gs1" || server.GetClock().GetCounters()["gs1"] != 1 {
+		t.Errorf("reloj de gs1 = %v, se esperaba gs1=1", server)
+	}
+	view := res.GetVectorClock().GetCounters()
+	for _, e := range res.GetEntities() {
+		for id, v := range e.GetClock().GetCounters() {
+			if v > view[id] {
+				t.Errorf("%s: %s=%d por delante de la vista del Matchmaker (%d)", e.GetId(), id, v, view[id])
+			}
+		}
+	}
+	if res.GetMatchmakerId() == "" || view[res.GetMatchmakerId()] == 0 {
+		t.Errorf("falta el componente del Matchmaker: %v", res)
+	}
+}
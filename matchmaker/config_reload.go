@@ -0,0 +1,250 @@
+// matchmaker/config_reload.go
+//
+// Parámetros recargables en caliente. Se leen del entorno y, si se define
+// MATCHMAKER_CONFIG, de un archivo JSON con los mismos nombres, que tiene
+// prioridad:
+//
+//	{"MATCH_CHECK_PERIOD": "1s", "QUEUE_AGING": "45s", "MATCH_POLICY": "region"}
+//
+// SIGHUP o AdminReloadConfig releen el archivo y aplican los valores nuevos
+// de una vez bajo m.mu: la cola, las partidas y los servidores no se tocan.
+// Un archivo con errores (formato, rangos o claves desconocidas) se rechaza
+// entero y sigue la configuración anterior. Los parámetros de arranque
+// (puertos, rol, TLS, …) sólo se leen del entorno y exigen reiniciar; el
+// tamaño de equipo es de cada modo y se cambia con AdminUpsertGameMode.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// tunables reúne los parámetros recargables; matchmaker lo embebe, así que
+// se usan como m.policy, m.orphans, etc. y se leen con m.mu.
+type tunables struct {
+	checkPeriod      time.Duration // período del bucle de emparejamiento
+	eventMatching    bool          // MATCH_TRIGGER=event: los eventos lo despiertan (event_matching.go)
+	heartbeatTimeout time.Duration // sin latidos durante este plazo, el servidor cae
+	assignTimeout    time.Duration // plazo de AssignMatch, incluida la conexión
+	assignRetry      assignRetryPolicy
+	policy           queuePolicy
+	matchPolicy      matchPolicy // forma los equipos sobre la cola ya ordenada
+	orphans          orphanPolicy
+	leaveForfeit     bool // LeaveMatch: los demás ganan en vez de volver a la cola
+	slo              sloPolicy
+	clockTTL         time.Duration // inactividad tras la que se poda un componente
+	rywTimeout       time.Duration // espera máxima para Read-Your-Writes; 0 = no espera
+	strongTimeout    time.Duration // espera máxima de una lectura STRONG a su AssignMatch
+	historyLimit     int           // partidas retenidas por namespace; 0 = sin límite
+	statusWindow     time.Duration // ventana de los *_recent de AdminGetSystemStatus
+	queueTimeout     time.Duration // plazo de cada entrada en la cola; 0 = sin límite
+	playerTimeout    time.Duration // sin latidos del cliente, sale de la cola; 0 = no se vigila
+	minProtocol      int           // versión de protocolo mínima que se atiende (protocol_version.go)
+	readyTimeout     time.Duration // plazo para aceptar una partida; 0 = sin confirmación
+	readyPenalty     time.Duration // espera impuesta a quien no acepta
+	requeueOffer     time.Duration // FAILURE_ASK: plazo para recuperar el lugar en la cola
+	rematchWindow    time.Duration // plazo para pedir la revancha; 0 = sin revanchas
+	sessionTTL       time.Duration // una sesión sin RPC durante este plazo deja de estar viva
+	sessionFence     bool          // SESSION_CONFLICT=fence: la sesión nueva reemplaza a la viva
+}
+
+func defaultTunables() tunables {
+	return tunables{
+		checkPeriod:      defaultMatchCheckPeriod,
+		heartbeatTimeout: defaultHeartbeatTimeout,
+		assignTimeout:    defaultAssignTimeout,
+		assignRetry:      defaultAssignRetryPolicy(),
+		policy:           defaultQueuePolicy(),
+		matchPolicy:      fifoPolicy{},
+		orphans:          defaultOrphanPolicy(),
+		slo:              defaultSLOPolicy(),
+		clockTTL:         defaultClockTTL,
+		rywTimeout:       defaultRYWTimeout,
+		strongTimeout:    defaultStrongTimeout,
+		historyLimit:     defaultHistoryLimit,
+		statusWindow:     defaultStatusWindow,
+		queueTimeout:     defaultQueueTimeout,
+		playerTimeout:    defaultPlayerTimeout,
+		minProtocol:      mmclient.LegacyProtocolVersion,
+		readyTimeout:     defaultReadyCheckTimeout,
+		readyPenalty:     defaultReadyCheckPenalty,
+		requeueOffer:     defaultRequeueOffer,
+		rematchWindow:    defaultRematchWindow,
+		sessionTTL:       defaultSessionTTL,
+	}
+}
+
+// readTunables lee los parámetros recargables de cfg.
+func readTunables(cfg *config.Report) tunables {
+	t := defaultTunables()
+	t.checkPeriod = cfg.Duration("MATCH_CHECK_PERIOD", defaultMatchCheckPeriod, 100*time.Millisecond, time.Minute)
+	t.eventMatching = cfg.OneOf("MATCH_TRIGGER", triggerTick, triggerTick, triggerEvent) == triggerEvent
+	t.heartbeatTimeout = cfg.Duration("HEARTBEAT_TIMEOUT", defaultHeartbeatTimeout, time.Second, time.Hour)
+	t.assignTimeout = cfg.Duration("ASSIGN_TIMEOUT", defaultAssignTimeout, 100*time.Millisecond, time.Minute)
+	t.clockTTL = cfg.Duration("CLOCK_TTL", defaultClockTTL, 0, 24*time.Hour)
+	t.rywTimeout = cfg.Duration("RYW_TIMEOUT", defaultRYWTimeout, 0, time.Minute)
+	t.strongTimeout = cfg.Duration("STRONG_READ_TIMEOUT", defaultStrongTimeout, 0, time.Minute)
+	t.historyLimit = cfg.Int("MATCH_HISTORY_LIMIT", defaultHistoryLimit, 0, 1000000)
+	t.statusWindow = cfg.Duration("STATUS_WINDOW", defaultStatusWindow, time.Minute, 24*time.Hour)
+	t.queueTimeout = cfg.Duration("QUEUE_TIMEOUT", defaultQueueTimeout, 0, 24*time.Hour)
+	t.playerTimeout = cfg.Duration("PLAYER_HEARTBEAT_TIMEOUT", defaultPlayerTimeout, 0, time.Hour)
+	t.minProtocol = cfg.Int("MIN_PROTOCOL_VERSION", mmclient.LegacyProtocolVersion, mmclient.LegacyProtocolVersion, mmclient.ProtocolVersion)
+	t.readyTimeout = cfg.Duration("READY_CHECK_TIMEOUT", defaultReadyCheckTimeout, 0, maxReadyCheckTimeout)
+	t.readyPenalty = cfg.Duration("READY_CHECK_PENALTY", defaultReadyCheckPenalty, 0, time.Hour)
+	t.requeueOffer = cfg.Duration("REQUEUE_OFFER_TIMEOUT", defaultRequeueOffer, time.Second, time.Hour)
+	t.rematchWindow = cfg.Duration("REMATCH_WINDOW", defaultRematchWindow, 0, time.Hour)
+	t.sessionTTL = cfg.Duration("SESSION_TTL", defaultSessionTTL, time.Second, sessionRetention)
+	t.sessionFence = cfg.OneOf("SESSION_CONFLICT", sessionReject, sessionReject, sessionFence) == sessionFence
+	t.assignRetry = assignRetryPolicy{
+		retries:  cfg.Int("ASSIGN_RETRIES", defaultAssignRetryPolicy().retries, 0, 10),
+		backoff:  cfg.Duration("ASSIGN_BACKOFF", defaultAssignRetryPolicy().backoff, 0, maxAssignBackoff),
+		failover: cfg.OneOf("ASSIGN_FAILOVER", "1", "0", "1") == "1",
+	}
+	if p, err := newMatchPolicy(cfg.OneOf("MATCH_POLICY", defaultMatchPolicy, matchPolicyNames()...)); err == nil {
+		t.matchPolicy = p
+	}
+	t.policy = queuePolicy{
+		weights:    tierWeights(cfg.Weights("QUEUE_TIER_WEIGHTS", defaultTierWeights)),
+		aging:      cfg.Duration("QUEUE_AGING", defaultQueuePolicy().aging, 0, time.Hour),
+		maxWait:    cfg.Duration("QUEUE_MAX_WAIT", defaultQueuePolicy().maxWait, time.Second, 24*time.Hour),
+		relaxAfter: cfg.Duration("QUEUE_RELAX_AFTER", defaultRelaxAfter, 0, 24*time.Hour),
+	}
+	t.orphans = orphanPolicy{
+		timeout: cfg.Duration("MATCH_TIMEOUT", defaultOrphanPolicy().timeout, 10*time.Second, 24*time.Hour),
+		requeue: cfg.OneOf("ORPHAN_ACTION", orphanIdle, orphanIdle, orphanRequeue) == orphanRequeue,
+	}
+	t.leaveForfeit = cfg.OneOf("LEAVE_ACTION", leaveRequeue, leaveRequeue, leaveForfeit) == leaveForfeit
+	t.slo.window = cfg.Duration("SLO_WINDOW", defaultSLOWindow, time.Minute, 24*time.Hour)
+	t.slo.burnAlert = cfg.Float("SLO_BURN_ALERT", defaultBurnAlert, 0.1, 100)
+	cfg.Spec("MATCH_SLOS", "", func(raw string) (err error) {
+		t.slo.targets, err = parseSLOs(raw)
+		return err
+	})
+	return t
+}
+
+// loadTunables lee los parámetros recargables del entorno y, si path no es
+// vacío, del archivo. Los errores quedan en el reporte.
+func loadTunables(path string) (tunables, *config.Report) {
+	cfg := config.NewReport("matchmaker (recargable)")
+	if path != "" {
+		cfg.UseFile(path)
+	}
+	return readTunables(cfg), cfg
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                    Recarga: SIGHUP y RPC AdminReloadConfig
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminReloadConfig(ctx context.Context, req *pb.ReloadConfigRequest) (*pb.ReloadConfigResponse, error) {
+	res := m.reloadConfig()
+	if len(res.GetChanges()) > 0 {
+		var prev, next []string
+		for _, c := range res.GetChanges() {
+			prev = append(prev, c.GetKey()+"="+c.GetOldValue())
+			next = append(next, c.GetKey()+"="+c.GetNewValue())
+		}
+		auditChange(ctx, res.GetPath(), strings.Join(prev, " "), strings.Join(next, " "))
+	}
+	return res, nil
+}
+
+// reloadOnSIGHUP recarga la configuración con cada SIGHUP.
+func (m *matchmaker) reloadOnSIGHUP() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	defer signal.Stop(c)
+	for {
+		select {
+		case <-c:
+			m.reloadConfig()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// reloadConfig relee MATCHMAKER_CONFIG y, si es válido, aplica sus valores.
+func (m *matchmaker) reloadConfig() *pb.ReloadConfigResponse {
+	if m.configPath == "" {
+		m.logf("Recarga de configuración ignorada: no se definió MATCHMAKER_CONFIG")
+		return &pb.ReloadConfigResponse{
+			StatusCode: pb.ReloadConfigResponse_NO_CONFIG,
+			Message:    "El Matchmaker se lanzó sin MATCHMAKER_CONFIG",
+		}
+	}
+
+	t, cfg := loadTunables(m.configPath)
+	res := &pb.ReloadConfigResponse{Path: m.configPath}
+	if errs := cfg.Errors(); len(errs) > 0 {
+		m.metrics.configReloads.With("invalid").Inc()
+		m.logf("WARNING: configuración de %s rechazada; sigue la anterior:\n    • %s", m.configPath, strings.Join(errs, "\n    • "))
+		res.StatusCode = pb.ReloadConfigResponse_INVALID
+		res.Message = "Configuración inválida; sigue la anterior"
+		res.Errors = errs
+		return res
+	}
+	values := cfg.Values()
+
+	m.mu.Lock()
+	res.Changes = configChanges(m.configValues, values)
+	// la misma política conserva su estado (p.ej. la semilla de random)
+	if t.matchPolicy.Name() == m.matchPolicy.Name() {
+		t.matchPolicy = m.matchPolicy
+	}
+	m.tunables, m.configValues = t, values
+	m.mu.Unlock()
+
+	m.metrics.configReloads.With("ok").Inc()
+	if len(res.Changes) == 0 {
+		res.Message = "Configuración recargada sin cambios"
+		m.logf("Configuración recargada de %s: sin cambios", m.configPath)
+		return res
+	}
+	res.Message = fmt.Sprintf("Configuración recargada: %d cambios", len(res.Changes))
+	desc := make([]string, len(res.Changes))
+	for i, c := range res.Changes {
+		desc[i] = fmt.Sprintf("%s %s → %s", c.GetKey(), c.GetOldValue(), c.GetNewValue())
+	}
+	m.logf("Configuración recargada de %s: %s", m.configPath, strings.Join(desc, ", "))
+	return res
+}
+
+// configChanges lista, ordenadas por clave, las variables cuyo valor cambió.
+func configChanges(old, cur map[string]string) []*pb.ConfigChange {
+	var out []*pb.ConfigChange
+	for k, v := range cur {
+		if old[k] != v {
+			out = append(out, &pb.ConfigChange{Key: k, OldValue: old[k], NewValue: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+// matchCheckPeriod es el período vigente del bucle de emparejamiento.
+func (m *matchmaker) matchCheckPeriod() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.checkPeriod
+}
+
+// assignSettings devuelve la política de reintentos y el plazo vigentes,
+// para las asignaciones que corren fuera de m.mu.
+func (m *matchmaker) assignSettings() (assignRetryPolicy, time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.assignRetry, m.assignTimeout
+}
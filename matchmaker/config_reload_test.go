@@ -0,0 +1,107 @@
+// matchmaker/config_reload_test.go
+//
+// AdminReloadConfig: aplica los parámetros del archivo, informa qué cambió
+// y rechaza entero un archivo con errores.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// withConfigFile apunta MATCHMAKER_CONFIG de mm a un archivo con body, con
+// los valores de arranque tomados del entorno.
+func withConfigFile(t *testing.T, mm *matchmaker, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "matchmaker.json")
+	writeConfig(t, path, body)
+	_, cfg := loadTunables("")
+	mm.mu.Lock()
+	mm.configPath, mm.configValues = path, cfg.Values()
+	mm.mu.Unlock()
+	return path
+}
+
+func writeConfig(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func reload(t *testing.T, cli pb.MatchmakerClient) *pb.ReloadConfigResponse {
+	t.Helper()
+	res, err := cli.AdminReloadConfig(context.Background(), &pb.ReloadConfigRequest{})
+	if err != nil {
+		t.Fatalf("AdminReloadConfig: %v", err)
+	}
+	return res
+}
+
+func TestReloadConfigAppliesChanges(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	path := withConfigFile(t, mm, `{"QUEUE_AGING": "45s", "MATCH_POLICY": "region", "ASSIGN_FAILOVER": false}`)
+
+	res := reload(t, cli)
+	if res.GetStatusCode() != pb.ReloadConfigResponse_OK || res.GetPath() != path {
+		t.Fatalf("AdminReloadConfig = %v", res)
+	}
+	var keys []string
+	for _, c := range res.GetChanges() {
+		keys = append(keys, c.GetKey())
+	}
+	if want := []string{"ASSIGN_FAILOVER", "MATCH_POLICY", "QUEUE_AGING"}; !reflect.DeepEqual(keys, want) {
+		t.Fatalf("cambios = %v, se esperaba %v", keys, want)
+	}
+	mm.mu.RLock()
+	aging, policy, failover := mm.policy.aging, mm.matchPolicy.Name(), mm.assignRetry.failover
+	mm.mu.RUnlock()
+	if aging != 45*time.Second || policy != "region" || failover {
+		t.Fatalf("aplicado: aging %v, política %s, failover %v", aging, policy, failover)
+	}
+
+	// sin cambios en el archivo, la recarga no informa nada
+	if res := reload(t, cli); res.GetStatusCode() != pb.ReloadConfigResponse_OK || len(res.GetChanges()) != 0 {
+		t.Fatalf("segunda recarga = %v", res)
+	}
+}
+
+func TestReloadConfigRejectsInvalidFile(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	path := withConfigFile(t, mm, `{"QUEUE_AGING": "45s"}`)
+	if res := reload(t, cli); res.GetStatusCode() != pb.ReloadConfigResponse_OK {
+		t.Fatalf("AdminReloadConfig = %v", res)
+	}
+
+	// un valor fuera de rango y una errata: no se aplica nada
+	writeConfig(t, path, `{"QUEUE_AGING": "10s", "MATCH_TIMEOUT": "1s", "QUEUE_AGEING": "1m"}`)
+	res := reload(t, cli)
+	if res.GetStatusCode() != pb.ReloadConfigResponse_INVALID || len(res.GetErrors()) != 2 {
+		t.Fatalf("AdminReloadConfig = %v", res)
+	}
+	mm.mu.RLock()
+	aging := mm.policy.aging
+	mm.mu.RUnlock()
+	if aging != 45*time.Second {
+		t.Fatalf("QUEUE_AGING = %v tras una recarga rechazada, se esperaba 45s", aging)
+	}
+
+	writeConfig(t, path, `not json`)
+	if res := reload(t, cli); res.GetStatusCode() != pb.ReloadConfigResponse_INVALID {
+		t.Fatalf("AdminReloadConfig con JSON inválido = %v", res)
+	}
+}
+
+func TestReloadConfigWithoutFile(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	if res := reload(t, cli); res.GetStatusCode() != pb.ReloadConfigResponse_NO_CONFIG {
+		t.Fatalf("AdminReloadConfig = %v", res)
+	}
+}
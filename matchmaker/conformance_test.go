@@ -0,0 +1,393 @@
+// matchmaker/conformance_test.go
+//
+// Suite de conformidad de consistencia causal. Ejecuta intercalaciones
+// concurrentes (encolar, cancelar, consultar estado, actualizar servidores,
+// formar partidas) contra un Matchmaker en memoria y verifica, a partir de
+// los relojes vectoriales devueltos, las garantías que promete el sistema:
+//
+//   ▸ Read-Your-Writes: una lectura posterior a una escritura del mismo
+//     cliente devuelve un reloj que domina al de la escritura y refleja su
+//     efecto.
+//   ▸ Monotonic Reads: las lecturas sucesivas de un cliente nunca devuelven
+//     un reloj anterior al de la lectura previa.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/vimsent/L3/internal/clocks"
+	slog "github.com/vimsent/L3/internal/log"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard) // el Matchmaker registra cada operación
+	slog.SetConsole(false)    // y el middleware de newServer, cada RPC
+	os.Exit(m.Run())
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                               Infraestructura
+───────────────────────────────────────────────────────────────────────────────*/
+
+// startMatchmaker levanta un Matchmaker sobre bufconn, con los mismos
+// interceptores que main (newServer), y devuelve un cliente. Corre en modo
+// auditoría: un invariante roto tumba la prueba con el estado.
+func startMatchmaker(t *testing.T) (*matchmaker, pb.MatchmakerClient) {
+	t.Helper()
+
+	mm := newMatchmaker("Matchmaker")
+	mm.auditOn = true
+	lis := bufconn.Listen(1 << 20)
+	srv := mm.newServer(insecure.NewCredentials())
+	go srv.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("no se pudo conectar al Matchmaker: %v", err)
+	}
+	t.Cleanup(func() {
+		close(mm.done)
+		conn.Close()
+		srv.Stop()
+	})
+	return mm, pb.NewMatchmakerClient(conn)
+}
+
+// acceptingGameServer acepta toda partida asignada; escucha en TCP real
+// porque el Matchmaker marca directamente la dirección del servidor.
+type acceptingGameServer struct {
+	pb.UnimplementedGameServerServer
+}
+
+func (acceptingGameServer) AssignMatch(ctx context.Context, req *pb.AssignMatchRequest) (*pb.AssignMatchResponse, error) {
+	return &pb.AssignMatchResponse{StatusCode: pb.AssignMatchResponse_OK}, nil
+}
+
+func startGameServer(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("no se pudo escuchar: %v", err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterGameServerServer(srv, acceptingGameServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+// session es la vista causal de un cliente: su reloj local y el último
+// reloj leído, para comprobar las garantías en cada respuesta.
+type session struct {
+	t        *testing.T
+	id       string
+	clock    *clocks.Vector
+	lastRead *clocks.Vector
+}
+
+func newSession(t *testing.T, id string) *session {
+	return &session{t: t, id: id, clock: clocks.New(), lastRead: clocks.New()}
+}
+
+// send avanza el reloj local y lo serializa para una petición.
+func (s *session) send() *pb.VectorClock {
+	s.clock.Tick(s.id)
+	return s.clock.ToProto()
+}
+
+// wrote registra la respuesta de una escritura y devuelve su reloj.
+func (s *session) wrote(p *pb.VectorClock) *clocks.Vector {
+	vc := clocks.FromProto(p)
+	s.clock.Merge(vc)
+	return vc
+}
+
+// read registra la respuesta de una lectura verificando Monotonic Reads.
+func (s *session) read(op string, p *pb.VectorClock) *clocks.Vector {
+	vc := clocks.FromProto(p)
+	if !dominates(vc, s.lastRead) {
+		s.t.Errorf("%s: Monotonic Reads violado en %s: leído %v tras %v", s.id, op, vc, s.lastRead)
+	}
+	s.lastRead = vc
+	s.clock.Merge(vc)
+	return vc
+}
+
+// dominates indica si a ≥ b componente a componente.
+func dominates(a, b *clocks.Vector) bool {
+	return b.HappensBefore(a) || b.Equal(a)
+}
+
+func checkRYW(t *testing.T, who, op string, read, write *clocks.Vector) {
+	t.Helper()
+	if !dominates(read, write) {
+		t.Errorf("%s: Read-Your-Writes violado en %s: leído %v, escrito %v", who, op, read, write)
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                 Escenarios
+───────────────────────────────────────────────────────────────────────────────*/
+
+// Jugadores que se encolan en paralelo deben ver su propia escritura.
+func TestConformanceConcurrentQueueReadYourWrites(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			s := newSession(t, id)
+
+			q, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id, Clock: s.send()})
+			if err != nil {
+				t.Errorf("%s: QueuePlayer: %v", id, err)
+				return
+			}
+			w := s.wrote(q.GetVectorClock())
+
+			st, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id, Clock: s.send()})
+			if err != nil {
+				t.Errorf("%s: GetPlayerStatus: %v", id, err)
+				return
+			}
+			checkRYW(t, id, "GetPlayerStatus", s.read("GetPlayerStatus", st.GetVectorClock()), w)
+			if st.GetStatus() != "IN_QUEUE" {
+				t.Errorf("%s: tras encolarse se leyó %s", id, st.GetStatus())
+			}
+		}(fmt.Sprintf("P%02d", i))
+	}
+	wg.Wait()
+}
+
+// Un cliente que vio escrituras que el Matchmaker ya no tiene (reinicio sin
+// estado) recibe RETRY_LATER en vez de un estado que no las refleja.
+func TestConformanceStatusAheadOfServerRetriesLater(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	mm.rywTimeout = 30 * time.Millisecond
+	ctx := context.Background()
+	s := newSession(t, "P1")
+
+	q, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "P1", Clock: s.send()})
+	if err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	w := s.wrote(q.GetVectorClock())
+
+	ahead := w.Copy()
+	ahead.Tick(mm.selfID)
+	s.clock.Merge(ahead)
+	st, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: "P1", Clock: s.send()})
+	if err != nil {
+		t.Fatalf("GetPlayerStatus: %v", err)
+	}
+	if st.GetStatus() != statusRetryLater || st.GetRetryAfterMs() <= 0 {
+		t.Fatalf("con el reloj del cliente adelantado se leyó %s (retry %d ms)", st.GetStatus(), st.GetRetryAfterMs())
+	}
+
+	// una escritura posterior pone al Matchmaker al día
+	if _, err := cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: "P1", Clock: s.send()}); err != nil {
+		t.Fatalf("CancelQueue: %v", err)
+	}
+	st, err = cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: "P1", Clock: s.send()})
+	if err != nil {
+		t.Fatalf("GetPlayerStatus: %v", err)
+	}
+	if st.GetStatus() != "IDLE" {
+		t.Fatalf("tras ponerse al día se leyó %s", st.GetStatus())
+	}
+}
+
+// Ciclos encolar/cancelar intercalados con lecturas: cada lectura refleja la
+// última escritura propia y los relojes leídos nunca retroceden.
+func TestConformanceQueueCancelInterleaving(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			s := newSession(t, id)
+
+			for round := 0; round < 20; round++ {
+				q, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id, Clock: s.send()})
+				if err != nil {
+					t.Errorf("%s: QueuePlayer: %v", id, err)
+					return
+				}
+				w := s.wrote(q.GetVectorClock())
+
+				st, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id, Clock: s.send()})
+				if err != nil {
+					t.Errorf("%s: GetPlayerStatus: %v", id, err)
+					return
+				}
+				checkRYW(t, id, "GetPlayerStatus", s.read("GetPlayerStatus", st.GetVectorClock()), w)
+				if st.GetStatus() != "IN_QUEUE" {
+					t.Errorf("%s: ronda %d: tras encolarse se leyó %s", id, round, st.GetStatus())
+				}
+
+				c, err := cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: id, Clock: s.send()})
+				if err != nil {
+					t.Errorf("%s: CancelQueue: %v", id, err)
+					return
+				}
+				w = s.wrote(c.GetVectorClock())
+
+				st, err = cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id, Clock: s.send()})
+				if err != nil {
+					t.Errorf("%s: GetPlayerStatus: %v", id, err)
+					return
+				}
+				checkRYW(t, id, "GetPlayerStatus", s.read("GetPlayerStatus", st.GetVectorClock()), w)
+				if st.GetStatus() != "IDLE" {
+					t.Errorf("%s: ronda %d: tras cancelar se leyó %s", id, round, st.GetStatus())
+				}
+			}
+		}(fmt.Sprintf("P%02d", i))
+	}
+	wg.Wait()
+}
+
+// Servidores que se registran, jugadores que se encolan y el bucle de
+// emparejamiento corriendo a la vez; un observador administrador lee el
+// sistema continuamente. Todos los jugadores deben terminar en partida.
+func TestConformanceServersPlayersAndMatchLoop(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx := context.Background()
+	const players, servers = 16, 8
+
+	stop := make(chan struct{})
+	var bg sync.WaitGroup
+
+	// bucle de emparejamiento acelerado
+	bg.Add(1)
+	go func() {
+		defer bg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(5 * time.Millisecond):
+				mm.tryCreateMatch()
+			}
+		}
+	}()
+
+	// observador: Monotonic Reads sobre la vista global
+	bg.Add(1)
+	go func() {
+		defer bg.Done()
+		s := newSession(t, "Admin")
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			st, err := cli.AdminGetSystemStatus(ctx, &pb.AdminRequest{})
+			if err != nil {
+				t.Errorf("Admin: AdminGetSystemStatus: %v", err)
+				return
+			}
+			if st.GetVectorClock() != nil {
+				s.read("AdminGetSystemStatus", st.GetVectorClock())
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < servers; i++ {
+		addr := startGameServer(t)
+		wg.Add(1)
+		go func(id, addr string) {
+			defer wg.Done()
+			s := newSession(t, id)
+			u, err := cli.UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{
+				ServerId: id, NewStatus: pb.ServerStatus_DISPONIBLE, Address: addr, Clock: s.send(),
+			})
+			if err != nil {
+				t.Errorf("%s: UpdateServerStatus: %v", id, err)
+				return
+			}
+			w := s.wrote(u.GetVectorClock())
+
+			st, err := cli.AdminGetSystemStatus(ctx, &pb.AdminRequest{})
+			if err != nil {
+				t.Errorf("%s: AdminGetSystemStatus: %v", id, err)
+				return
+			}
+			checkRYW(t, id, "AdminGetSystemStatus", s.read("AdminGetSystemStatus", st.GetVectorClock()), w)
+			for _, srv := range st.GetServers() {
+				if srv.GetServerId() == id {
+					return
+				}
+			}
+			t.Errorf("%s: servidor registrado pero ausente del estado", id)
+		}(fmt.Sprintf("GS%02d", i), addr)
+	}
+
+	for i := 0; i < players; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			s := newSession(t, id)
+			q, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id, Clock: s.send()})
+			if err != nil {
+				t.Errorf("%s: QueuePlayer: %v", id, err)
+				return
+			}
+			w := s.wrote(q.GetVectorClock())
+
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				st, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id, Clock: s.send()})
+				if err != nil {
+					t.Errorf("%s: GetPlayerStatus: %v", id, err)
+					return
+				}
+				checkRYW(t, id, "GetPlayerStatus", s.read("GetPlayerStatus", st.GetVectorClock()), w)
+				switch st.GetStatus() {
+				case "IN_MATCH":
+					return
+				case "IN_QUEUE":
+				default:
+					t.Errorf("%s: estado inesperado %s tras encolarse", id, st.GetStatus())
+					return
+				}
+				if time.Now().After(deadline) {
+					t.Errorf("%s: sin partida tras 5 s", id)
+					return
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}(fmt.Sprintf("P%02d", i))
+	}
+
+	wg.Wait()
+	close(stop)
+	bg.Wait()
+}
@@ -0,0 +1,140 @@
+// matchmaker/contention_test.go
+//
+// Benchmarks de contención del estado del Matchmaker con miles de
+// jugadores: encolar/cancelar y consultar el estado en paralelo, con el
+// bucle de emparejamiento corriendo y el log de eventos lleno.
+//
+//	go test ./matchmaker -run ^$ -bench Contention -benchtime 2s
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const benchPlayers = 5000
+
+// newBenchMatchmaker prepara un Matchmaker sin auditoría con benchPlayers
+// jugadores en la cola y el log de eventos lleno.
+func newBenchMatchmaker(b *testing.B) *matchmaker {
+	return newBenchMatchmakerN(b, benchPlayers)
+}
+
+// newBenchMatchmakerN es newBenchMatchmaker con queued jugadores en cola.
+func newBenchMatchmakerN(b *testing.B, queued int) *matchmaker {
+	b.Helper()
+	m := newMatchmaker("Matchmaker")
+	ctx := context.Background()
+	for i := 0; i < queued; i++ {
+		if _, err := m.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: fmt.Sprintf("Q%d", i)}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for len(m.eventLog.entries) < maxStateEvents {
+		m.logEvent(m.ns(""), &pb.StateEvent{Kind: pb.StateEvent_PLAYER_QUEUED})
+	}
+	return m
+}
+
+// runMatchLoop simula el bucle de emparejamiento (sin servidores, así que
+// sólo ordena y recorre la cola) hasta que b termine.
+func runBenchLoop(b *testing.B, m *matchmaker) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+				m.tryCreateMatch()
+				m.expireQueue(m.wall.Now())
+			}
+		}
+	}()
+	b.Cleanup(func() { close(stop); <-done })
+}
+
+func BenchmarkContentionQueueCancel(b *testing.B) {
+	m := newBenchMatchmaker(b)
+	runBenchLoop(b, m)
+	var next atomic.Int64
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb_ *testing.PB) {
+		id := fmt.Sprintf("P%d", next.Add(1))
+		for pb_.Next() {
+			m.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id})
+			m.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: id})
+		}
+	})
+}
+
+// Encolar y cancelar con colas cada vez más largas: el costo por llamada no
+// debe crecer con la cola.
+func BenchmarkContentionQueueSize(b *testing.B) {
+	for _, queued := range []int{1000, 5000, 20000} {
+		b.Run(fmt.Sprintf("queued=%d", queued), func(b *testing.B) {
+			m := newBenchMatchmakerN(b, queued)
+			runBenchLoop(b, m)
+			var next atomic.Int64
+			ctx := context.Background()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb_ *testing.PB) {
+				id := fmt.Sprintf("P%d", next.Add(1))
+				for pb_.Next() {
+					m.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id})
+					m.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: id})
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkContentionStatus(b *testing.B) {
+	m := newBenchMatchmaker(b)
+	runBenchLoop(b, m)
+	var next atomic.Int64
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb_ *testing.PB) {
+		id := fmt.Sprintf("Q%d", next.Add(1)%benchPlayers)
+		for pb_.Next() {
+			m.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id})
+		}
+	})
+}
+
+// Mezcla de carga: por cada encolado, cuatro consultas de estado.
+func BenchmarkContentionMixed(b *testing.B) {
+	m := newBenchMatchmaker(b)
+	runBenchLoop(b, m)
+	var next atomic.Int64
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb_ *testing.PB) {
+		n := next.Add(1)
+		id := fmt.Sprintf("P%d", n)
+		for i := 0; pb_.Next(); i++ {
+			switch i % 6 {
+			case 0:
+				m.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id})
+			case 5:
+				m.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: id})
+			default:
+				m.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: fmt.Sprintf("Q%d", (n+int64(i))%benchPlayers)})
+			}
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// matchmaker/cooldown.go
+//
+// Espera obligatoria tras terminar una partida. Cada modo puede fijar un
+// cooldown_s: al registrarse el resultado, sus jugadores no pueden volver a
+// encolarse hasta que pase ese tiempo. QueuePlayer responde COOLDOWN con la
+// espera pendiente (la mayor del grupo) y el administrador puede quitarla con
+// AdminResetCooldown. Las partidas cerradas sin resultado no imponen espera.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// cooldownIn devuelve el jugador de la lista con la mayor espera pendiente
+// y cuánto le queda, o ("", 0) si ninguno está en cooldown.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) cooldownIn(players []string, now time.Time) (string, time.Duration) {
+	var who string
+	var left time.Duration
+	for _, pid := range players {
+		p, ok := ns.players.Get(pid)
+		if !ok {
+			continue
+		}
+		if d := p.CooldownUntil.Sub(now); d > left {
+			who, left = pid, d
+		}
+	}
+	return who, left
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: AdminResetCooldown – quita la espera de un jugador
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminResetCooldown(ctx context.Context, req *pb.AdminPlayerActionRequest) (*pb.AdminUpdateResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok || !pi.CooldownUntil.After(m.wall.Now()) {
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_NOT_FOUND,
+			Message:     fmt.Sprintf("Jugador %s sin cooldown pendiente", playerID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	left := time.Until(pi.CooldownUntil).Round(time.Second)
+	auditChange(ctx, playerID, "cooldown hasta "+pi.CooldownUntil.Format(time.RFC3339), "")
+	pi.CooldownUntil = time.Time{}
+
+	m.logf("[%s] Cooldown de %s quitado (le quedaban %v; motivo: %q)", ns.name, playerID, left, req.GetReason())
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     fmt.Sprintf("Cooldown quitado (quedaban %v)", left),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
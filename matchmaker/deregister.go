@@ -0,0 +1,124 @@
+// matchmaker/deregister.go
+//
+// Baja explícita de un GameServer. Antes un servidor que se apagaba avisaba
+// CAIDO y quedaba en el mapa para siempre, indistinguible de uno que se cayó
+// de verdad. Con DeregisterServer se quita del pool y sus partidas en curso
+// se cierran de inmediato como huérfanas (motivo "deregistered"), sin esperar
+// al barrido. Los servidores retirados (dados de baja o drenados) se recuerdan
+// en ns.removed para que AdminGetSystemStatus los muestre aparte de los CAIDO;
+// si el mismo ID vuelve a registrarse, deja de figurar como retirado. Esa
+// lista es sólo informativa y no se replica al respaldo.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// máximo de servidores retirados que recuerda cada namespace
+const maxRemovedServers = 20
+
+// Motivos de retiro.
+const (
+	removedShutdown   = "shutdown"
+	removedDrained    = "drained"
+	removedScaledDown = "scaled_down" // autoscale.go
+)
+
+// removedServer es un servidor retirado del pool.
+type removedServer struct {
+	ID        string
+	Address   string
+	Reason    string
+	RemovedAt time.Time
+}
+
+func (m *matchmaker) DeregisterServer(ctx context.Context, req *pb.DeregisterServerRequest) (*pb.DeregisterServerResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	sid := req.GetServerId()
+	srv, ok := ns.servers.Get(sid)
+	if !ok {
+		return &pb.DeregisterServerResponse{
+			StatusCode:  pb.DeregisterServerResponse_UNKNOWN_SERVER,
+			Message:     "Servidor no registrado",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	aborted := 0
+	for _, matchID := range ns.serverMatches(sid) {
+		m.closeOrphan(ns, matchID, ns.matches.Value(matchID), "deregistered")
+		aborted++
+	}
+	reason := req.GetReason()
+	if reason == "" {
+		reason = removedShutdown
+	}
+	m.removeServer(ns, srv, reason)
+
+	return &pb.DeregisterServerResponse{
+		StatusCode:     pb.DeregisterServerResponse_OK,
+		AbortedMatches: int32(aborted),
+		Message:        fmt.Sprintf("Servidor retirado del pool (%d partidas cerradas)", aborted),
+		VectorClock:    m.clockProto(ns),
+	}, nil
+}
+
+// removeServer quita srv del pool y lo recuerda como retirado.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) removeServer(ns *namespace, srv *gameServerInfo, reason string) {
+	ns.servers.Delete(srv.ID)
+	m.indexServerAddr(ns.name, srv.ID, srv.Address, "")
+	ns.vc.Tick(m.selfID)
+	ns.forgetRemoved(srv.ID)
+	ns.serverUpdates.Forget(srv.ID)
+	ns.removed = append(ns.removed, removedServer{
+		ID:        srv.ID,
+		Address:   srv.Address,
+		Reason:    reason,
+		RemovedAt: m.wall.Now(),
+	})
+	if len(ns.removed) > maxRemovedServers {
+		ns.removed = ns.removed[len(ns.removed)-maxRemovedServers:]
+	}
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_SERVER_REMOVED, ServerId: srv.ID, Detail: reason})
+	m.logf("[%s] Server %s retirado del pool (%s)", ns.name, srv.ID, reason)
+}
+
+// forgetRemoved borra a serverID de los retirados (p.ej. al re-registrarse).
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) forgetRemoved(serverID string) {
+	kept := ns.removed[:0]
+	for _, r := range ns.removed {
+		if r.ID != serverID {
+			kept = append(kept, r)
+		}
+	}
+	ns.removed = kept
+}
+
+// removedProto lista los retirados, los más recientes primero.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (ns *namespace) removedProto() []*pb.RemovedServer {
+	out := make([]*pb.RemovedServer, 0, len(ns.removed))
+	for i := len(ns.removed) - 1; i >= 0; i-- {
+		r := ns.removed[i]
+		out = append(out, &pb.RemovedServer{
+			ServerId:        r.ID,
+			Address:         r.Address,
+			Reason:          r.Reason,
+			RemovedAtUnixMs: r.RemovedAt.UnixMilli(),
+		})
+	}
+	return out
+}
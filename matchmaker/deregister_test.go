@@ -0,0 +1,90 @@
+// matchmaker/deregister_test.go
+//
+// DeregisterServer: el servidor sale del mapa (no queda CAIDO), sus
+// partidas se cierran y la vista de administración lo lista como retirado
+// hasta que vuelve a registrarse.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func deregister(t *testing.T, cli pb.MatchmakerClient, serverID string) *pb.DeregisterServerResponse {
+	t.Helper()
+	res, err := cli.DeregisterServer(context.Background(), &pb.DeregisterServerRequest{ServerId: serverID, Reason: "shutdown"})
+	if err != nil {
+		t.Fatalf("DeregisterServer: %v", err)
+	}
+	return res
+}
+
+func adminStatus(t *testing.T, cli pb.MatchmakerClient) *pb.SystemStatusResponse {
+	t.Helper()
+	res, err := cli.AdminGetSystemStatus(context.Background(), &pb.AdminRequest{})
+	if err != nil {
+		t.Fatalf("AdminGetSystemStatus: %v", err)
+	}
+	return res
+}
+
+func TestDeregisterServerRemovesItAndClosesMatches(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	matchID := startedMatch(r)
+
+	res := deregister(t, r.cli, r.gs.ID)
+	if res.GetStatusCode() != pb.DeregisterServerResponse_OK || res.GetAbortedMatches() != 1 {
+		t.Fatalf("DeregisterServer = %v", res)
+	}
+	r.inspect(func(ns *namespace) {
+		if _, ok := ns.servers.Get(r.gs.ID); ok {
+			t.Errorf("el servidor sigue en el mapa")
+		}
+		if _, ok := ns.matches.Get(matchID); ok {
+			t.Errorf("la partida %s sigue en curso", matchID)
+		}
+		if rec := ns.history[len(ns.history)-1].toProto(); rec.GetOutcome() != pb.MatchRecord_DEREGISTERED {
+			t.Errorf("desenlace en el historial = %v, se esperaba DEREGISTERED", rec.GetOutcome())
+		}
+		for _, pid := range []string{"A", "B"} {
+			if p := ns.players.Value(pid); p.Status != playerIdle || p.MatchID != "" {
+				t.Errorf("%s: %v en %q, se esperaba IDLE", pid, p.Status, p.MatchID)
+			}
+		}
+	})
+
+	st := adminStatus(t, r.cli)
+	if len(st.GetServers()) != 0 {
+		t.Errorf("servidores = %v, se esperaba ninguno", st.GetServers())
+	}
+	if rm := st.GetRemoved(); len(rm) != 1 || rm[0].GetServerId() != r.gs.ID || rm[0].GetReason() != "shutdown" {
+		t.Errorf("retirados = %v", rm)
+	}
+
+	if res := deregister(t, r.cli, r.gs.ID); res.GetStatusCode() != pb.DeregisterServerResponse_UNKNOWN_SERVER {
+		t.Errorf("segunda baja = %v", res)
+	}
+
+	// al volver deja de figurar como retirado
+	r.register()
+	st = adminStatus(t, r.cli)
+	if len(st.GetServers()) != 1 || len(st.GetRemoved()) != 0 {
+		t.Fatalf("tras re-registrarse: servidores %v, retirados %v", st.GetServers(), st.GetRemoved())
+	}
+}
+
+func TestDrainedServerListedAsRemoved(t *testing.T) {
+	r := newFailureRig(t)
+	res, err := r.cli.AdminDrainServer(context.Background(), &pb.AdminDrainServerRequest{ServerId: r.gs.ID})
+	if err != nil || res.GetStatus() != pb.AdminUpdateResponse_OK {
+		t.Fatalf("AdminDrainServer: %v %v", res, err)
+	}
+	// sin partidas en curso se retira enseguida
+	if rm := adminStatus(t, r.cli).GetRemoved(); len(rm) != 1 || rm[0].GetReason() != "drained" {
+		t.Fatalf("retirados = %v", rm)
+	}
+}
@@ -0,0 +1,209 @@
+// matchmaker/event_log.go
+//
+// Log de eventos. Cada transición de estado —jugador encolado, reencolado o
+// fuera de la cola, partida creada, reasignada, rellenada o terminada,
+// asignación fallida, servidor arriba, caído o retirado— se agrega a un log
+// de sólo anexado, numerada y con el reloj del namespace tras aplicarla. Con
+// EVENT_LOG_FILE cada evento se escribe también, una línea JSON por evento,
+// antes de responder a quien lo provocó; al arrancar se releen las últimas
+// maxStateEvents del archivo, así que el log sobrevive a un reinicio y es la
+// base para reconstruir el estado reproduciéndolo. AdminGetEventLog lo
+// consulta por namespace, entidad (jugador, partida o servidor), tipo y
+// rango de tiempo. El log vive fuera de los namespaces: restore() no lo
+// toca, y un respaldo sólo registra lo que ocurre tras su promoción.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	maxStateEvents   = 100000 // eventos en memoria
+	eventTrimSlack   = maxStateEvents / 10
+	defaultEventPage = 100
+	maxEventPage     = 1000
+	maxEventLine     = 1 << 20 // una línea del archivo, en bytes
+)
+
+type eventLog struct {
+	mu      sync.Mutex // logEvent corre también con m.mu en lectura (locking.go)
+	seq     uint64
+	entries []*pb.StateEvent
+	file    *os.File // nil = sólo en memoria
+}
+
+// openEventLog carga los eventos de path y lo deja abierto para anexar.
+func (m *matchmaker) openEventLog(path string) error {
+	f, err := os.Open(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 64*1024), maxEventLine)
+		for sc.Scan() {
+			ev := &pb.StateEvent{}
+			if err := protojson.Unmarshal(sc.Bytes(), ev); err != nil {
+				// una línea cortada por una caída a mitad de escritura
+				m.logf("WARNING: línea ilegible en %s, se ignora: %v", path, err)
+				continue
+			}
+			m.appendEvent(ev)
+		}
+		f.Close()
+		if err := sc.Err(); err != nil {
+			return err
+		}
+	}
+
+	m.eventLog.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	m.logf("Log de eventos en %s (%d eventos recuperados, último #%d)", path, len(m.eventLog.entries), m.eventLog.seq)
+	return nil
+}
+
+// appendEvent agrega ev al log en memoria, descartando los más antiguos.
+// Descarta de a eventTrimSlack: recortar en cada evento copiaba el log
+// entero en cada transición.
+func (m *matchmaker) appendEvent(ev *pb.StateEvent) {
+	if ev.GetSequence() > m.eventLog.seq {
+		m.eventLog.seq = ev.GetSequence()
+	}
+	m.eventLog.entries = append(m.eventLog.entries, ev)
+	if len(m.eventLog.entries) > maxStateEvents+eventTrimSlack {
+		drop := len(m.eventLog.entries) - maxStateEvents
+		m.eventLog.entries = append([]*pb.StateEvent(nil), m.eventLog.entries[drop:]...)
+	}
+}
+
+// recent devuelve los últimos maxStateEvents eventos.
+func (l *eventLog) recent() []*pb.StateEvent {
+	if n := len(l.entries); n > maxStateEvents {
+		return l.entries[n-maxStateEvents:]
+	}
+	return l.entries
+}
+
+// logEvent registra una transición del namespace, tras aplicarla. Copia
+// los jugadores: los que recibe suelen ser slices vivos del estado. El
+// reloj se lee con el log bloqueado, así que la secuencia y el reloj de
+// los eventos avanzan juntos aunque dos RPC registren a la vez.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) logEvent(ns *namespace, ev *pb.StateEvent) {
+	ev.PlayerIds = append([]string(nil), ev.PlayerIds...)
+	m.eventLog.mu.Lock()
+	defer m.eventLog.mu.Unlock()
+	ev.Sequence = m.eventLog.seq + 1
+	ev.UnixMs = m.wall.Now().UnixMilli()
+	ev.Namespace = ns.name
+	ev.Clock = ns.vc.ToProto()
+	m.appendEvent(ev)
+
+	if m.eventLog.file == nil {
+		return
+	}
+	line, err := protojson.Marshal(ev)
+	if err == nil {
+		_, err = m.eventLog.file.Write(append(line, '\n'))
+	}
+	if err != nil {
+		m.logf("WARNING: no se pudo escribir el evento #%d en el log: %v", ev.Sequence, err)
+	}
+}
+
+// logServerTransition registra SERVER_UP o SERVER_DOWN si el servidor cruzó
+// entre caído y activo; prev es su estado anterior (serverDown si es nuevo).
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) logServerTransition(ns *namespace, srv *gameServerInfo, prev serverState, detail string) {
+	var kind pb.StateEvent_Kind
+	switch {
+	case prev == serverDown && srv.Status != serverDown:
+		kind = pb.StateEvent_SERVER_UP
+	case prev != serverDown && srv.Status == serverDown:
+		kind = pb.StateEvent_SERVER_DOWN
+	default:
+		return
+	}
+	m.logEvent(ns, &pb.StateEvent{Kind: kind, ServerId: srv.ID, Detail: detail})
+}
+
+// logRequeued registra los jugadores de matchID que volvieron a la cola.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) logRequeued(ns *namespace, matchID string, players []string, detail string) {
+	if len(players) > 0 {
+		m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_REQUEUED, PlayerIds: players, MatchId: matchID, Detail: detail})
+	}
+}
+
+// eventFilter es un EventLogRequest con los tipos pedidos en un conjunto.
+type eventFilter struct {
+	*pb.EventLogRequest
+	kinds map[pb.StateEvent_Kind]bool
+}
+
+// matches indica si ev cumple el filtro.
+func (f *eventFilter) matches(ev *pb.StateEvent) bool {
+	switch {
+	case ev.GetSequence() <= f.GetAfterSeq():
+		return false
+	case f.GetNamespace() != "" && ev.GetNamespace() != f.GetNamespace():
+		return false
+	case ev.GetUnixMs() < f.GetSinceUnixMs():
+		return false
+	case f.GetUntilUnixMs() > 0 && ev.GetUnixMs() > f.GetUntilUnixMs():
+		return false
+	case len(f.kinds) > 0 && !f.kinds[ev.GetKind()]:
+		return false
+	}
+	if id := f.GetEntityId(); id != "" {
+		return ev.GetMatchId() == id || ev.GetServerId() == id || contains(ev.GetPlayerIds(), id)
+	}
+	return true
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: AdminGetEventLog – transiciones de estado registradas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminGetEventLog(ctx context.Context, req *pb.EventLogRequest) (*pb.EventLogResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultEventPage
+	}
+	if limit > maxEventPage {
+		limit = maxEventPage
+	}
+	filter := &eventFilter{EventLogRequest: req, kinds: make(map[pb.StateEvent_Kind]bool)}
+	for _, k := range req.GetKinds() {
+		filter.kinds[k] = true
+	}
+
+	m.eventLog.mu.Lock()
+	defer m.eventLog.mu.Unlock()
+
+	out := &pb.EventLogResponse{}
+	for _, ev := range m.eventLog.recent() {
+		if !filter.matches(ev) {
+			continue
+		}
+		if len(out.Events) == limit {
+			out.More = true
+			break
+		}
+		out.Events = append(out.Events, ev)
+		out.LastSequence = ev.GetSequence()
+	}
+	return out, nil
+}
@@ -0,0 +1,113 @@
+// matchmaker/event_log_test.go
+//
+// Log de eventos: las transiciones quedan anotadas en orden y con reloj,
+// AdminGetEventLog filtra y pagina, y EVENT_LOG_FILE sobrevive a un
+// reinicio.
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func eventKinds(events []*pb.StateEvent) []pb.StateEvent_Kind {
+	var kinds []pb.StateEvent_Kind
+	for _, ev := range events {
+		kinds = append(kinds, ev.GetKind())
+	}
+	return kinds
+}
+
+func TestEventLogRecordsTransitions(t *testing.T) {
+	r := newFailureRig(t, testkit.Reject)
+	r.queuePair("A", "B")
+	r.gs.WaitAssigned(t, 1, time.Second)
+	r.waitFor("jugadores reencolados", func(ns *namespace) bool { return requeued(ns, "A", "B") })
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		entity string
+		want   []pb.StateEvent_Kind
+	}{
+		{"A", []pb.StateEvent_Kind{pb.StateEvent_PLAYER_QUEUED, pb.StateEvent_MATCH_CREATED, pb.StateEvent_ASSIGN_FAILED, pb.StateEvent_PLAYER_REQUEUED}},
+		{r.gs.ID, []pb.StateEvent_Kind{pb.StateEvent_SERVER_UP, pb.StateEvent_MATCH_CREATED, pb.StateEvent_ASSIGN_FAILED}},
+	} {
+		res, err := r.cli.AdminGetEventLog(ctx, &pb.EventLogRequest{EntityId: tc.entity})
+		if err != nil {
+			t.Fatalf("AdminGetEventLog(%s): %v", tc.entity, err)
+		}
+		if got := eventKinds(res.GetEvents()); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("eventos de %s = %v, se esperaba %v", tc.entity, got, tc.want)
+		}
+		var last uint64
+		for _, ev := range res.GetEvents() {
+			if ev.GetSequence() <= last || len(ev.GetClock().GetCounters()) == 0 {
+				t.Errorf("evento #%d fuera de orden o sin reloj", ev.GetSequence())
+			}
+			last = ev.GetSequence()
+		}
+	}
+
+	// filtro por tipo, de a uno
+	req := &pb.EventLogRequest{Kinds: []pb.StateEvent_Kind{pb.StateEvent_PLAYER_QUEUED}, Limit: 1}
+	var players []string
+	for {
+		res, err := r.cli.AdminGetEventLog(ctx, req)
+		if err != nil {
+			t.Fatalf("AdminGetEventLog: %v", err)
+		}
+		for _, ev := range res.GetEvents() {
+			players = append(players, ev.GetPlayerIds()...)
+		}
+		if !res.GetMore() {
+			break
+		}
+		req.AfterSeq = res.GetLastSequence()
+	}
+	if !reflect.DeepEqual(players, []string{"A", "B"}) {
+		t.Errorf("encolados = %v, se esperaba [A B]", players)
+	}
+
+	res, err := r.cli.AdminGetEventLog(ctx, &pb.EventLogRequest{SinceUnixMs: time.Now().Add(time.Hour).UnixMilli()})
+	if err != nil || len(res.GetEvents()) != 0 {
+		t.Errorf("eventos en el futuro = %v, %v", res.GetEvents(), err)
+	}
+}
+
+func TestEventLogFileSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	open := func() *matchmaker {
+		mm := newMatchmaker("Matchmaker")
+		if err := mm.openEventLog(path); err != nil {
+			t.Fatalf("openEventLog: %v", err)
+		}
+		t.Cleanup(func() { mm.eventLog.file.Close() })
+		return mm
+	}
+
+	mm := open()
+	mm.mu.Lock()
+	ns := mm.ns("")
+	ns.vc.Tick(mm.selfID)
+	mm.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_QUEUED, PlayerIds: []string{"A"}})
+	mm.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_SERVER_DOWN, ServerId: "gs1"})
+	mm.mu.Unlock()
+
+	mm = open()
+	if got := eventKinds(mm.eventLog.entries); !reflect.DeepEqual(got, []pb.StateEvent_Kind{pb.StateEvent_PLAYER_QUEUED, pb.StateEvent_SERVER_DOWN}) {
+		t.Fatalf("eventos recuperados = %v", got)
+	}
+	mm.mu.Lock()
+	mm.logEvent(mm.ns(""), &pb.StateEvent{Kind: pb.StateEvent_SERVER_UP, ServerId: "gs1"})
+	mm.mu.Unlock()
+	if seq := mm.eventLog.entries[2].GetSequence(); seq != 3 {
+		t.Errorf("secuencia tras reiniciar = %d, se esperaba 3", seq)
+	}
+}
@@ -0,0 +1,43 @@
+// matchmaker/event_matching.go
+//
+// Emparejamiento por eventos. Con MATCH_TRIGGER=tick (por defecto) el bucle
+// forma partidas sólo cada MATCH_CHECK_PERIOD, lo que suma hasta un período
+// de espera a cada partida. Con MATCH_TRIGGER=event lo despiertan en el acto
+// QueuePlayer, la actualización de estado de un servidor con hueco
+// (UpdateServerStatus o el stream Heartbeat) y el resultado de una partida,
+// que libera el suyo, siempre que haya jugadores esperando. El ticker sigue
+// como barrido de respaldo: vencimientos, latidos perdidos, huérfanas y lo
+// que ningún evento anuncia, como la relajación de la política por la
+// espera.
+//
+// Los avisos se fusionan: el canal tiene lugar para uno, así que una ráfaga
+// de QueuePlayer mientras el bucle trabaja produce una sola vuelta más.
+// matchmaker_match_runs_total{trigger} cuenta las vueltas por origen.
+
+package main
+
+// Valores de MATCH_TRIGGER.
+const (
+	triggerTick  = "tick"
+	triggerEvent = "event"
+)
+
+// wakeMatcher pide una vuelta de emparejamiento inmediata si
+// MATCH_TRIGGER=event; si ya hay una pendiente, no hace nada.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) wakeMatcher() {
+	if !m.eventMatching {
+		return
+	}
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// hasWaiting dice si hay jugadores esperando partida en el namespace: en la
+// cola, en una sala privada o en una revancha.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) hasWaiting() bool {
+	return ns.queue.Len() > 0 || len(ns.privateLobbies) > 0 || len(ns.rematches) > 0
+}
@@ -0,0 +1,67 @@
+// matchmaker/event_matching_test.go
+//
+// Emparejamiento por eventos: con MATCH_TRIGGER=event la partida se forma
+// en cuanto llega el segundo jugador o se libera un servidor, sin esperar
+// al ticker, que en estas pruebas no vence nunca.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// newEventRig arma un failureRig con el bucle de emparejamiento corriendo
+// y un MATCH_CHECK_PERIOD de un minuto.
+func newEventRig(t *testing.T, event bool) *failureRig {
+	t.Helper()
+	r := newFailureRig(t, testkit.NeverReport)
+	r.mm.mu.Lock()
+	r.mm.checkPeriod, r.mm.eventMatching = time.Minute, event
+	r.mm.mu.Unlock()
+	go r.mm.runMatchLoop()
+	return r
+}
+
+func (r *failureRig) queue(ids ...string) {
+	r.t.Helper()
+	for _, id := range ids {
+		if _, err := r.cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id}); err != nil {
+			r.t.Fatalf("%s: QueuePlayer: %v", id, err)
+		}
+	}
+}
+
+func TestEventMatchingWakesOnQueue(t *testing.T) {
+	tick := newEventRig(t, false)
+	tick.queue("a", "b")
+	time.Sleep(300 * time.Millisecond)
+	if got := tick.gs.Assigned(); len(got) != 0 {
+		t.Fatalf("con MATCH_TRIGGER=tick la partida no debía formarse antes del ticker: %v", got)
+	}
+
+	event := newEventRig(t, true)
+	event.queue("a", "b")
+	event.gs.WaitAssigned(t, 1, time.Second)
+}
+
+func TestEventMatchingWakesOnServerStatus(t *testing.T) {
+	r := newEventRig(t, true)
+	res, err := r.cli.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+		ServerId: r.gs.ID, NewStatus: pb.ServerStatus_CAIDO, Address: r.gs.Addr,
+	})
+	if err != nil || res.GetStatusCode() != pb.ServerStatusUpdateResponse_OK {
+		t.Fatalf("UpdateServerStatus: %v %v", res.GetStatusCode(), err)
+	}
+	r.queue("a", "b")
+	r.waitFor("a y b en la cola sin servidor", func(ns *namespace) bool {
+		return ns.queue.Len() == 2 && ns.matches.Len() == 0
+	})
+
+	r.register()
+	r.gs.WaitAssigned(t, 1, time.Second)
+}
@@ -0,0 +1,142 @@
+// matchmaker/fairness.go
+//
+// Equidad de la espera. queuePolicy ya adelanta en la cola a quien espera
+// (QUEUE_AGING) y pasa al frente a quien supera QUEUE_MAX_WAIT, pero estar
+// al frente no basta si la política de emparejamiento filtra: con
+// MATCH_POLICY=region un jugador de una región poco poblada puede ver cómo
+// se forman partidas de otras regiones detrás de él sin entrar nunca.
+//
+// Por eso las restricciones de la política se relajan con la espera de cada
+// unidad, por etapas:
+//
+//	relaxNone    menos de QUEUE_RELAX_AFTER: restricciones completas
+//	relaxRegion  desde QUEUE_RELAX_AFTER: la unidad puede completar una
+//	             partida de cualquier región
+//	relaxAll     desde QUEUE_MAX_WAIT: la unidad entra en la próxima partida
+//	             que pueda formarse, aunque la política prefiera otra
+//
+// Así QUEUE_MAX_WAIT es una espera máxima garantizada mientras haya
+// jugadores y servidores para formar una partida. Las métricas
+// matchmaker_queue_wait_seconds, matchmaker_queue_oldest_wait_seconds y
+// matchmaker_matches_relaxed_total permiten comprobarlo.
+
+package main
+
+import "time"
+
+// relaxLevel indica cuánto se relajan las restricciones de la política para
+// una unidad, según su espera.
+type relaxLevel int
+
+const (
+	relaxNone relaxLevel = iota
+	relaxRegion
+	relaxAll
+)
+
+// stage es la etiqueta de matchmaker_matches_relaxed_total.
+func (r relaxLevel) stage() string {
+	switch r {
+	case relaxRegion:
+		return "region"
+	case relaxAll:
+		return "max_wait"
+	default:
+		return "none"
+	}
+}
+
+const defaultRelaxAfter = time.Minute
+
+// queueWaitBuckets son los límites (en segundos) del histograma de espera.
+var queueWaitBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800}
+
+// relaxFor devuelve la etapa que corresponde a una espera; relaxAfter = 0
+// desactiva la etapa intermedia.
+func (qp queuePolicy) relaxFor(wait time.Duration) relaxLevel {
+	switch {
+	case wait >= qp.maxWait:
+		return relaxAll
+	case qp.relaxAfter > 0 && wait >= qp.relaxAfter:
+		return relaxRegion
+	default:
+		return relaxNone
+	}
+}
+
+// formFairTeams forma los equipos con la política y, si deja fuera a una
+// unidad que superó QUEUE_MAX_WAIT, los vuelve a formar en el orden de la
+// cola, donde esa unidad ya está al frente. Devuelve también la etapa de
+// relajación que hizo falta: relaxAll si se pasó por alto a la política,
+// relaxRegion si entró una unidad relajada de otra región, relaxNone si no.
+func formFairTeams(policy matchPolicy, units []queueUnit, teamSize int) ([2][]string, relaxLevel, bool) {
+	teams, ok := policy.FormTeams(units, teamSize)
+	if late, found := oldestOverdue(units); found && (!ok || !placed(teams, late)) {
+		if fifo, ok := firstFit(units, teamSize); ok && placed(fifo, late) {
+			return fifo, relaxAll, true
+		}
+	}
+	if !ok {
+		return teams, relaxNone, false
+	}
+	return teams, crossedRegions(units, teams), true
+}
+
+// oldestOverdue devuelve la primera unidad, en el orden de la cola, que
+// superó QUEUE_MAX_WAIT.
+func oldestOverdue(units []queueUnit) (queueUnit, bool) {
+	for _, u := range units {
+		if u.Relax == relaxAll {
+			return u, true
+		}
+	}
+	return queueUnit{}, false
+}
+
+// placed indica si la unidad quedó en alguno de los equipos.
+func placed(teams [2][]string, u queueUnit) bool {
+	for _, team := range teams {
+		for _, pid := range team {
+			if pid == u.Players[0] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// crossedRegions devuelve relaxRegion si la partida mezcla regiones e
+// incluye una unidad relajada.
+func crossedRegions(units []queueUnit, teams [2][]string) relaxLevel {
+	regions := make(map[string]bool)
+	relaxed := false
+	for _, u := range units {
+		if !placed(teams, u) {
+			continue
+		}
+		if u.Region != "" {
+			regions[u.Region] = true
+		}
+		relaxed = relaxed || u.Relax >= relaxRegion
+	}
+	if relaxed && len(regions) > 1 {
+		return relaxRegion
+	}
+	return relaxNone
+}
+
+// oldestWaits devuelve la espera más larga de la cola por modo.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) oldestWaits(now time.Time) map[string]time.Duration {
+	out := make(map[string]time.Duration)
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if !ok {
+			continue
+		}
+		if wait := now.Sub(p.LastOp); wait > out[p.Mode] {
+			out[p.Mode] = wait
+		}
+	}
+	return out
+}
@@ -0,0 +1,92 @@
+// matchmaker/fairness_test.go
+//
+// Equidad de la espera: la etapa de relajación crece con la espera, una
+// unidad relajada completa partidas de otra región y, pasado QUEUE_MAX_WAIT,
+// entra en la próxima partida aunque la política prefiera otra.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func relaxed(id, region string, r relaxLevel) queueUnit {
+	u := solo(id, region)
+	u.Relax = r
+	return u
+}
+
+func TestRelaxForStages(t *testing.T) {
+	qp := queuePolicy{maxWait: 2 * time.Minute, relaxAfter: time.Minute}
+	for _, tc := range []struct {
+		wait time.Duration
+		want relaxLevel
+	}{
+		{0, relaxNone},
+		{59 * time.Second, relaxNone},
+		{time.Minute, relaxRegion},
+		{2 * time.Minute, relaxAll},
+	} {
+		if got := qp.relaxFor(tc.wait); got != tc.want {
+			t.Fatalf("relaxFor(%v) = %v, se esperaba %v", tc.wait, got, tc.want)
+		}
+	}
+	qp.relaxAfter = 0
+	if got := qp.relaxFor(90 * time.Second); got != relaxNone {
+		t.Fatalf("con QUEUE_RELAX_AFTER=0 la etapa es %v", got)
+	}
+}
+
+func TestRegionPolicyIncludesRelaxedUnit(t *testing.T) {
+	strict := []queueUnit{solo("A", "eu"), solo("B", "us"), solo("C", "us")}
+	teams, relax, ok := formFairTeams(regionPolicy{}, strict, 1)
+	if !ok || !reflect.DeepEqual(teams, [2][]string{{"B"}, {"C"}}) || relax != relaxNone {
+		t.Fatalf("sin relajar: %v (%v, ok=%v), se esperaba [[B] [C]]", teams, relax, ok)
+	}
+
+	late := []queueUnit{relaxed("A", "eu", relaxRegion), solo("B", "us"), solo("C", "us")}
+	teams, relax, ok = formFairTeams(regionPolicy{}, late, 1)
+	if !ok || !reflect.DeepEqual(teams, [2][]string{{"A"}, {"B"}}) || relax != relaxRegion {
+		t.Fatalf("relajado: %v (%v, ok=%v), se esperaba [[A] [B]] por region", teams, relax, ok)
+	}
+}
+
+func TestMaxWaitOverridesPolicy(t *testing.T) {
+	units := []queueUnit{relaxed("A", "eu", relaxAll), solo("B", "us"), solo("C", "us"), solo("D", "us")}
+	for seed := int64(1); seed <= 20; seed++ {
+		teams, relax, ok := formFairTeams(newRandomPolicy(seed), units, 1)
+		if !ok || !placed(teams, units[0]) || relax == relaxNone {
+			t.Fatalf("semilla %d: %v (%v) dejó fuera a A, que superó QUEUE_MAX_WAIT", seed, teams, relax)
+		}
+	}
+
+	// sin jugadores suficientes no hay partida que garantizar
+	if _, _, ok := formFairTeams(fifoPolicy{}, units[:1], 1); ok {
+		t.Fatal("formó una partida con un solo jugador")
+	}
+}
+
+func TestTakeQueuedRelaxesByWait(t *testing.T) {
+	ns := newNamespace("default")
+	now := time.Now()
+	for _, r := range []struct {
+		id, region string
+		wait       time.Duration
+	}{{"A", "eu", 3 * time.Minute}, {"B", "us", time.Second}, {"C", "us", time.Second}} {
+		ns.players.Put(r.id, &playerInfo{
+			ID: r.id, Status: playerInQueue, Mode: defaultGameMode, Region: r.region,
+			LastOp: now.Add(-r.wait),
+		})
+		ns.enqueue(r.id, false)
+	}
+
+	picked, relax := ns.takeQueued(ns.modes[defaultGameMode], regionPolicy{}, defaultQueuePolicy(), now)
+	if !reflect.DeepEqual(picked, []string{"A", "B"}) || relax == relaxNone {
+		t.Fatalf("takeQueued = %v (%v), se esperaba [A B] relajada", picked, relax)
+	}
+	if waits := ns.oldestWaits(now); waits[defaultGameMode] != time.Second {
+		t.Fatalf("espera más larga restante = %v, se esperaba 1s", waits[defaultGameMode])
+	}
+}
@@ -0,0 +1,154 @@
+// matchmaker/feedback.go
+//
+// Opiniones de los jugadores al terminar una partida (nota 1-5, reporte de
+// lag y comentario). Se guardan junto al registro de la partida y se agregan
+// por servidor en AdminGetServerReliability, que marca como "laggy" a los
+// servidores con reportes de lag crónicos.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	laggyMinFeedback = 3   // opiniones mínimas antes de juzgar un servidor
+	laggyLagRatio    = 0.3 // fracción de reportes de lag que lo marca
+	maxCommentLen    = 280
+)
+
+type matchFeedback struct {
+	PlayerID    string
+	Rating      int
+	Lag         bool
+	Comment     string
+	SubmittedAt time.Time
+}
+
+// findResult busca una partida finalizada con resultado en el historial.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) findResult(matchID string) *matchResult {
+	for i := len(ns.history) - 1; i >= 0; i-- {
+		if ns.history[i].MatchID == matchID && ns.history[i].completed() {
+			return ns.history[i]
+		}
+	}
+	return nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+           RPC: SubmitMatchFeedback – opinión del jugador sobre la partida
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) SubmitMatchFeedback(ctx context.Context, req *pb.MatchFeedbackRequest) (*pb.MatchFeedbackResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+
+	reject := func(code pb.MatchFeedbackResponse_StatusCode, msg string) (*pb.MatchFeedbackResponse, error) {
+		return &pb.MatchFeedbackResponse{StatusCode: code, Message: msg, VectorClock: m.clockProto(ns)}, nil
+	}
+
+	if r := req.GetRating(); r < 1 || r > 5 {
+		return reject(pb.MatchFeedbackResponse_INVALID_RATING, "La nota debe estar entre 1 y 5")
+	}
+	res := ns.findResult(req.GetMatchId())
+	if res == nil {
+		return reject(pb.MatchFeedbackResponse_UNKNOWN_MATCH, "Partida desconocida o aún en curso")
+	}
+	played := false
+	for _, pid := range res.Players {
+		played = played || pid == playerID
+	}
+	if !played {
+		return reject(pb.MatchFeedbackResponse_NOT_A_PLAYER, "No participaste en esta partida")
+	}
+	for _, fb := range res.Feedback {
+		if fb.PlayerID == playerID {
+			return reject(pb.MatchFeedbackResponse_DUPLICATE, "Ya calificaste esta partida")
+		}
+	}
+
+	comment := req.GetComment()
+	if len(comment) > maxCommentLen {
+		comment = comment[:maxCommentLen]
+	}
+	res.Feedback = append(res.Feedback, matchFeedback{
+		PlayerID:    playerID,
+		Rating:      int(req.GetRating()),
+		Lag:         req.GetLag(),
+		Comment:     comment,
+		SubmittedAt: m.wall.Now(),
+	})
+	ns.vc.Tick(m.selfID)
+
+	m.logf("[%s] Opinión de %s sobre %s (server %s): %d★ lag=%v", ns.name, playerID, res.MatchID, res.ServerID, req.GetRating(), req.GetLag())
+	return &pb.MatchFeedbackResponse{
+		StatusCode:  pb.MatchFeedbackResponse_OK,
+		Message:     "¡Gracias por tu opinión!",
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+        RPC: AdminGetServerReliability – agregado de opiniones por servidor
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminGetServerReliability(ctx context.Context, req *pb.AdminRequest) (*pb.ReliabilityResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.ReliabilityResponse{}, nil
+	}
+
+	byServer := make(map[string]*pb.ServerReliability)
+	ratingSum := make(map[string]int)
+	for _, res := range ns.history {
+		if !res.completed() {
+			continue
+		}
+		sr, ok := byServer[res.ServerID]
+		if !ok {
+			sr = &pb.ServerReliability{ServerId: res.ServerID}
+			byServer[res.ServerID] = sr
+		}
+		sr.Matches++
+		for _, fb := range res.Feedback {
+			sr.FeedbackCount++
+			ratingSum[res.ServerID] += fb.Rating
+			if fb.Lag {
+				sr.LagReports++
+			}
+		}
+	}
+
+	out := &pb.ReliabilityResponse{VectorClock: m.clockProto(ns)}
+	for id, sr := range byServer {
+		if sr.FeedbackCount > 0 {
+			sr.AvgRating = float64(ratingSum[id]) / float64(sr.FeedbackCount)
+			sr.LagRatio = float64(sr.LagReports) / float64(sr.FeedbackCount)
+		}
+		sr.Laggy = sr.FeedbackCount >= laggyMinFeedback && sr.LagRatio >= laggyLagRatio
+		out.Servers = append(out.Servers, sr)
+	}
+	// los servidores problemáticos primero
+	sort.Slice(out.Servers, func(i, j int) bool {
+		a, b := out.Servers[i], out.Servers[j]
+		if a.LagRatio != b.LagRatio {
+			return a.LagRatio > b.LagRatio
+		}
+		return a.ServerId < b.ServerId
+	})
+	return out, nil
+}
@@ -0,0 +1,226 @@
+// matchmaker/game_modes.go
+//
+// Registro de modos de juego por namespace. Reemplaza el antiguo contrato de
+// GameMode como texto libre: cada modo define el tamaño de equipo (una
+// partida enfrenta a dos equipos), una pista de duración para el GameServer
+// y si acepta jugadores nuevos.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultGameMode    = "1v1"
+	minEventIntervalMs = 100 // cadencia mínima de la simulación
+)
+
+type gameMode struct {
+	Name        string
+	TeamSize    int
+	MinDuration int // segundos; 0 = lo decide el GameServer
+	MaxDuration int
+	Enabled     bool
+	Cooldown    int // segundos de espera tras terminar una partida; 0 = ninguna
+
+	// sala por tiempo (lobbies.go); LobbyMax = 0 = equipos de TeamSize
+	LobbyMin  int
+	LobbyMax  int
+	LobbyWait int // segundos desde que se reúne LobbyMin
+
+	// simulación en el GameServer (gameserver/simulation.go); 0 = el valor
+	// por defecto del servidor
+	Scoring     pb.GameMode_Scoring
+	EventMs     int     // milisegundos entre eventos de puntaje
+	ScoreProb   float64 // probabilidad de una eliminación por evento
+	KillPoints  int
+	DeathPoints int
+}
+
+func (g *gameMode) toProto() *pb.GameMode {
+	return &pb.GameMode{
+		Name:            g.Name,
+		TeamSize:        int32(g.TeamSize),
+		MinDurationS:    int32(g.MinDuration),
+		MaxDurationS:    int32(g.MaxDuration),
+		Enabled:         g.Enabled,
+		CooldownS:       int32(g.Cooldown),
+		LobbyMinPlayers: int32(g.LobbyMin),
+		LobbyMaxPlayers: int32(g.LobbyMax),
+		LobbyWaitS:      int32(g.LobbyWait),
+		Scoring:         g.Scoring,
+		EventIntervalMs: int32(g.EventMs),
+		ScoreProb:       float32(g.ScoreProb),
+		KillPoints:      int32(g.KillPoints),
+		DeathPoints:     int32(g.DeathPoints),
+	}
+}
+
+func gameModeFromProto(p *pb.GameMode) *gameMode {
+	return &gameMode{
+		Name:        p.GetName(),
+		TeamSize:    int(p.GetTeamSize()),
+		MinDuration: int(p.GetMinDurationS()),
+		MaxDuration: int(p.GetMaxDurationS()),
+		Enabled:     p.GetEnabled(),
+		Cooldown:    int(p.GetCooldownS()),
+		LobbyMin:    int(p.GetLobbyMinPlayers()),
+		LobbyMax:    int(p.GetLobbyMaxPlayers()),
+		LobbyWait:   int(p.GetLobbyWaitS()),
+		Scoring:     p.GetScoring(),
+		EventMs:     int(p.GetEventIntervalMs()),
+		ScoreProb:   float64(p.GetScoreProb()),
+		KillPoints:  int(p.GetKillPoints()),
+		DeathPoints: int(p.GetDeathPoints()),
+	}
+}
+
+// defaultGameModes es el registro con el que nace cada namespace.
+func defaultGameModes() map[string]*gameMode {
+	return map[string]*gameMode{
+		defaultGameMode: {Name: defaultGameMode, TeamSize: 1, MinDuration: 10, MaxDuration: 20, Enabled: true},
+	}
+}
+
+// sortedModes devuelve los modos ordenados por nombre, para que el
+// emparejamiento y los listados sean deterministas.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) sortedModes() []*gameMode {
+	out := make([]*gameMode, 0, len(ns.modes))
+	for _, g := range ns.modes {
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// queuedUnits agrupa la cola del modo en unidades (un jugador solo o su
+// grupo completo), en el orden de la cola. La espera de una unidad es la de
+// su integrante más antiguo.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) queuedUnits(mode *gameMode, qp queuePolicy, now time.Time) []queueUnit {
+	var units []queueUnit
+	seen := make(map[string]bool)
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if !ok || p.Mode != mode.Name || seen[pid] {
+			continue
+		}
+		unit := ns.queuedUnit(p)
+		var wait time.Duration
+		for _, id := range unit {
+			seen[id] = true
+			if q, ok := ns.players.Get(id); ok && now.Sub(q.LastOp) > wait {
+				wait = now.Sub(q.LastOp)
+			}
+		}
+		units = append(units, queueUnit{Players: unit, Region: p.Region, Relax: qp.relaxFor(wait)})
+	}
+	return units
+}
+
+// takeQueued forma una partida del modo indicado con la política dada,
+// relajada según la espera (fairness.go). Devuelve el equipo 1 seguido del
+// equipo 2 y la relajación que hizo falta; si no se completan ambos no
+// modifica la cola y devuelve nil.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) takeQueued(mode *gameMode, policy matchPolicy, qp queuePolicy, now time.Time) ([]string, relaxLevel) {
+	teams, relax, ok := formFairTeams(policy, ns.queuedUnits(mode, qp, now), mode.TeamSize)
+	if !ok {
+		return nil, relaxNone
+	}
+	picked := append(teams[0], teams[1]...)
+	for _, pid := range picked {
+		ns.removeFromQueue(pid)
+	}
+	return picked, relax
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+              RPC: ListGameModes / AdminUpsertGameMode – registro
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) ListGameModes(ctx context.Context, req *pb.ListGameModesRequest) (*pb.ListGameModesResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := &pb.ListGameModesResponse{}
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		// namespace sin actividad: aún tiene el registro por defecto
+		for _, g := range defaultGameModes() {
+			if m.shards.ownsMode(g.Name) {
+				res.Modes = append(res.Modes, g.toProto())
+			}
+		}
+		return res, nil
+	}
+	for _, g := range ns.sortedModes() {
+		// los modos de otros Matchmakers se listan allí (sharding.go)
+		if m.shards.ownsMode(g.Name) {
+			res.Modes = append(res.Modes, g.toProto())
+		}
+	}
+	res.VectorClock = m.clockProto(ns)
+	return res, nil
+}
+
+func (m *matchmaker) AdminUpsertGameMode(ctx context.Context, req *pb.AdminUpsertGameModeRequest) (*pb.AdminUpdateResponse, error) {
+	g := gameModeFromProto(req.GetMode())
+	switch {
+	case g.Name == "":
+		return nil, status.Error(codes.InvalidArgument, "el modo necesita un nombre")
+	case g.TeamSize < 1:
+		return nil, status.Errorf(codes.InvalidArgument, "team_size debe ser >= 1 (recibido %d)", g.TeamSize)
+	case g.MinDuration < 0 || g.MaxDuration < g.MinDuration:
+		return nil, status.Errorf(codes.InvalidArgument, "duración inválida [%d, %d]", g.MinDuration, g.MaxDuration)
+	case g.Cooldown < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "cooldown_s debe ser >= 0 (recibido %d)", g.Cooldown)
+	case g.LobbyMax > 0 && (g.LobbyMin < 2 || g.LobbyMin > g.LobbyMax):
+		return nil, status.Errorf(codes.InvalidArgument, "sala inválida: mínimo %d, máximo %d (se requiere 2 <= mínimo <= máximo)", g.LobbyMin, g.LobbyMax)
+	case g.LobbyMax > 0 && g.LobbyWait < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "lobby_wait_s debe ser >= 0 (recibido %d)", g.LobbyWait)
+	case pb.GameMode_Scoring_name[int32(g.Scoring)] == "":
+		return nil, status.Errorf(codes.InvalidArgument, "scoring desconocido (%d)", g.Scoring)
+	case g.Scoring == pb.GameMode_TEAM_DEATHMATCH && g.isLobby():
+		return nil, status.Error(codes.InvalidArgument, "TEAM_DEATHMATCH requiere equipos: una sala (lobby_max_players > 0) es todos contra todos")
+	case g.EventMs < 0 || (g.EventMs > 0 && g.EventMs < minEventIntervalMs):
+		return nil, status.Errorf(codes.InvalidArgument, "event_interval_ms debe ser 0 o >= %d (recibido %d)", minEventIntervalMs, g.EventMs)
+	case g.ScoreProb < 0 || g.ScoreProb > 1:
+		return nil, status.Errorf(codes.InvalidArgument, "score_prob debe estar en [0, 1] (recibido %g)", g.ScoreProb)
+	case g.KillPoints < 0 || g.DeathPoints < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "kill_points y death_points deben ser >= 0 (recibidos %d y %d)", g.KillPoints, g.DeathPoints)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	msg, prev := "Modo actualizado", ""
+	if old, ok := ns.modes[g.Name]; ok {
+		prev = old.toProto().String()
+	} else {
+		msg = "Modo creado"
+	}
+	ns.modes[g.Name] = g
+	auditChange(ctx, g.Name, prev, g.toProto().String())
+
+	m.logf("[%s] %s: %s (%s, %s, %d-%d s, cooldown %d s, habilitado=%v)",
+		ns.name, msg, g.Name, g.shape(), g.Scoring, g.MinDuration, g.MaxDuration, g.Cooldown, g.Enabled)
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     msg,
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
@@ -0,0 +1,182 @@
+// matchmaker/heartbeat.go
+//
+// Stream Heartbeat con cada GameServer. El servidor envía por él sus
+// cambios de estado (los mismos mensajes que UpdateServerStatus) y, entre
+// cambio y cambio, repite el último como latido; cada uno recibe un ACK con
+// la respuesta de siempre. Por el mismo stream el Matchmaker le empuja
+// órdenes: DRAIN cuando el administrador lo drena y ABORT_MATCH cuando
+// cierra una partida suya que el servidor podría seguir jugando (expirada o
+// con la asignación fallida).
+//
+// Un latido idéntico al anterior sólo renueva el plazo de heartbeat: no
+// avanza el reloj ni se registra. UpdateServerStatus sigue disponible para
+// servidores que no conocen el stream; ésos no reciben órdenes.
+
+package main
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// órdenes pendientes por servidor; si el stream no las consume se descartan
+const controlBuffer = 16
+
+func controlKey(nsName, serverID string) string { return nsName + "/" + serverID }
+
+// pushControl encola una orden para el stream Heartbeat del servidor, si
+// tiene uno abierto.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) pushControl(ns *namespace, serverID string, msg *pb.ServerControl) {
+	ch, ok := m.controls[controlKey(ns.name, serverID)]
+	if !ok {
+		return
+	}
+	select {
+	case ch <- msg:
+	default:
+		m.logf("[%s] WARNING: orden %s para %s descartada: stream saturado", ns.name, msg.GetCommand(), serverID)
+	}
+}
+
+// abortOnServer pide al servidor que deje de jugar una partida ya cerrada.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) abortOnServer(ns *namespace, serverID, matchID, reason string) {
+	m.pushControl(ns, serverID, &pb.ServerControl{
+		Command: pb.ServerControl_ABORT_MATCH,
+		MatchId: matchID,
+		Reason:  reason,
+	})
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+              RPC: Heartbeat – latidos y órdenes en un solo stream
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) Heartbeat(stream pb.Matchmaker_HeartbeatServer) error {
+	ctx := stream.Context()
+	recv := make(chan *pb.ServerStatusUpdateRequest)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case recv <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ctl := make(chan *pb.ServerControl, controlBuffer)
+	var key string
+	defer func() { m.detachControl(key, ctl) }()
+
+	var last *pb.ServerStatusUpdateRequest
+	for {
+		select {
+		case req := <-recv:
+			res, err := m.heartbeat(ctx, req, last)
+			if err != nil {
+				return err
+			}
+			last = req
+			if key == "" && res.GetStatusCode() == pb.ServerStatusUpdateResponse_OK {
+				key = m.attachControl(req, ctl)
+			}
+			if err := stream.Send(&pb.ServerControl{Command: pb.ServerControl_ACK, Ack: res}); err != nil {
+				return err
+			}
+		case msg := <-ctl:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case err := <-errc:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-m.done:
+			return status.Error(codes.Unavailable, "Matchmaker apagándose")
+		}
+	}
+}
+
+// heartbeat aplica un mensaje del stream: si repite el anterior y el
+// servidor sigue registrado y vivo, sólo renueva su plazo.
+func (m *matchmaker) heartbeat(ctx context.Context, req, last *pb.ServerStatusUpdateRequest) (*pb.ServerStatusUpdateResponse, error) {
+	defer m.auditLocked("Heartbeat")
+	ctx, span := traceStatusUpdate(ctx, req)
+	defer span.End()
+	if last != nil && sameState(req, last) {
+		if res := m.touchServer(req); res != nil {
+			return res, nil
+		}
+	}
+	return m.UpdateServerStatus(ctx, req)
+}
+
+// sameState compara dos actualizaciones sin la latencia, que el servidor
+// mide en cada envío y varía aunque nada haya cambiado, ni la traza, ni el
+// reloj, que trae lo último que el servidor vio de este Matchmaker.
+func sameState(a, b *pb.ServerStatusUpdateRequest) bool {
+	a, b = proto.Clone(a).(*pb.ServerStatusUpdateRequest), proto.Clone(b).(*pb.ServerStatusUpdateRequest)
+	a.LatencyMs, b.LatencyMs = 0, 0
+	a.Traceparent, b.Traceparent = "", ""
+	a.Clock, b.Clock = nil, nil
+	return proto.Equal(a, b)
+}
+
+// touchServer renueva el plazo de heartbeat (y la latencia) de un servidor
+// registrado y vivo. Devuelve nil si hace falta la actualización completa.
+func (m *matchmaker) touchServer(req *pb.ServerStatusUpdateRequest) *pb.ServerStatusUpdateResponse {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return nil
+	}
+	srv, ok := ns.servers.Get(req.GetServerId())
+	if !ok || srv.Status == serverDown || srv.Draining {
+		return nil
+	}
+	srv.LastHB = m.wall.Now()
+	srv.LatencyMs = req.GetLatencyMs()
+	return &pb.ServerStatusUpdateResponse{
+		StatusCode:  pb.ServerStatusUpdateResponse_OK,
+		VectorClock: m.clockProto(ns),
+		Epoch:       m.epoch,
+	}
+}
+
+// attachControl asocia el stream al servidor del primer registro aceptado;
+// un stream nuevo del mismo servidor reemplaza al anterior.
+func (m *matchmaker) attachControl(req *pb.ServerStatusUpdateRequest, ctl chan *pb.ServerControl) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := controlKey(m.ns(req.GetNamespace()).name, req.GetServerId())
+	m.controls[key] = ctl
+	return key
+}
+
+func (m *matchmaker) detachControl(key string, ctl chan *pb.ServerControl) {
+	if key == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.controls[key] == ctl {
+		delete(m.controls, key)
+	}
+}
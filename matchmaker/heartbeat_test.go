@@ -0,0 +1,83 @@
+// matchmaker/heartbeat_test.go
+//
+// Stream Heartbeat: registra al servidor, los latidos repetidos sólo
+// renuevan su plazo y las órdenes del Matchmaker llegan por el mismo stream.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestHeartbeatStream(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := cli.Heartbeat(ctx)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	beat := &pb.ServerStatusUpdateRequest{
+		ServerId: "gs1", NewStatus: pb.ServerStatus_DISPONIBLE, Address: "127.0.0.1:60051",
+		Capacity: 1, FreeSlots: 1,
+	}
+	recv := func(want pb.ServerControl_Command) *pb.ServerControl {
+		t.Helper()
+		msg, err := stream.Recv()
+		if err != nil || msg.GetCommand() != want {
+			t.Fatalf("se esperaba %v, llegó %v (%v)", want, msg.GetCommand(), err)
+		}
+		return msg
+	}
+	send := func() *pb.ServerControl {
+		t.Helper()
+		if err := stream.Send(beat); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+		return recv(pb.ServerControl_ACK)
+	}
+
+	if ack := send(); ack.GetAck().GetStatusCode() != pb.ServerStatusUpdateResponse_OK {
+		t.Fatalf("registro: %v", ack.GetAck().GetStatusCode())
+	}
+	registered := send().GetAck().GetVectorClock().GetCounters()["Matchmaker"]
+
+	// un latido idéntico renueva el plazo sin avanzar el reloj
+	mm.mu.Lock()
+	mm.lookupNS(defaultNamespace).servers.Value("gs1").LastHB = time.Time{}
+	mm.mu.Unlock()
+	if got := send().GetAck().GetVectorClock().GetCounters()["Matchmaker"]; got != registered {
+		t.Fatalf("el latido avanzó el reloj de %d a %d", registered, got)
+	}
+	mm.mu.RLock()
+	renewed := !mm.lookupNS(defaultNamespace).servers.Value("gs1").LastHB.IsZero()
+	mm.mu.RUnlock()
+	if !renewed {
+		t.Fatal("el latido no renovó el plazo de heartbeat")
+	}
+
+	// una partida cerrada por el Matchmaker se ordena abortar
+	mm.mu.Lock()
+	ns := mm.lookupNS(defaultNamespace)
+	am := &activeMatch{ServerID: "gs1", Mode: defaultGameMode, StartedAt: time.Now()}
+	ns.matches.Put("M1", am)
+	mm.closeOrphan(ns, "M1", am, "timeout")
+	mm.mu.Unlock()
+	if msg := recv(pb.ServerControl_ABORT_MATCH); msg.GetMatchId() != "M1" || msg.GetReason() != "timeout" {
+		t.Fatalf("ABORT_MATCH = %v", msg)
+	}
+
+	// drenar un servidor libre lo retira y se le avisa
+	res, err := cli.AdminDrainServer(ctx, &pb.AdminDrainServerRequest{ServerId: "gs1"})
+	if err != nil || res.GetStatus() != pb.AdminUpdateResponse_OK {
+		t.Fatalf("AdminDrainServer: %v %v", res.GetStatus(), err)
+	}
+	if msg := recv(pb.ServerControl_DRAIN); !msg.GetRetired() {
+		t.Fatal("DRAIN de un servidor libre sin retired")
+	}
+}
@@ -0,0 +1,197 @@
+// matchmaker/leaderboard.go
+//
+// Estadísticas por jugador y clasificación. Cada namespace acumula, por
+// jugador, las partidas con resultado informado (ReportMatchResult), cuántas
+// ganó y cuánto esperó en la cola hasta ser emparejado. Las partidas que se
+// cierran sin resultado (servidor caído, abandono, terminación) no cuentan.
+// Los acumulados viajan en el snapshot, así que no dependen del recorte del
+// historial ni se pierden al conmutar al respaldo. GetLeaderboard los ordena
+// por victorias, partidas, porcentaje de victorias o espera media y los
+// pagina por posición.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultLeaderboardPage = 20
+	maxLeaderboardPage     = 100
+)
+
+type playerStats struct {
+	Matches   int
+	Wins      int
+	WaitTotal time.Duration
+	Waits     int
+}
+
+// statsFor devuelve (creándolo si no existe) el acumulado de playerID.
+// debe llamarse con m.mu bloqueado en escritura
+func (ns *namespace) statsFor(playerID string) *playerStats {
+	st, ok := ns.stats[playerID]
+	if !ok {
+		st = &playerStats{}
+		ns.stats[playerID] = st
+	}
+	return st
+}
+
+// recordWait suma la espera en cola de un jugador recién emparejado.
+// debe llamarse con m.mu bloqueado en escritura
+func (ns *namespace) recordWait(playerID string, wait time.Duration) {
+	st := ns.statsFor(playerID)
+	st.WaitTotal += wait
+	st.Waits++
+}
+
+// recordResult cuenta una partida terminada para sus jugadores; sólo las que
+// terminaron con resultado informado.
+// debe llamarse con m.mu bloqueado en escritura
+func (ns *namespace) recordResult(res *matchResult) {
+	if !res.completed() {
+		return
+	}
+	for _, pid := range res.Players {
+		st := ns.statsFor(pid)
+		st.Matches++
+		if res.won(pid) {
+			st.Wins++
+		}
+	}
+}
+
+func (st *playerStats) winRate() float64 {
+	if st.Matches == 0 {
+		return 0
+	}
+	return float64(st.Wins) / float64(st.Matches)
+}
+
+func (st *playerStats) avgWait() time.Duration {
+	if st.Waits == 0 {
+		return 0
+	}
+	return st.WaitTotal / time.Duration(st.Waits)
+}
+
+func (st *playerStats) toProto(playerID string) *pb.PlayerStats {
+	return &pb.PlayerStats{
+		PlayerId:      playerID,
+		MatchesPlayed: int32(st.Matches),
+		Wins:          int32(st.Wins),
+		WinRate:       st.winRate(),
+		AvgWaitMs:     st.avgWait().Milliseconds(),
+		TotalWaitMs:   st.WaitTotal.Milliseconds(),
+		Waits:         int32(st.Waits),
+	}
+}
+
+func playerStatsFromProto(p *pb.PlayerStats) *playerStats {
+	return &playerStats{
+		Matches:   int(p.GetMatchesPlayed()),
+		Wins:      int(p.GetWins()),
+		WaitTotal: time.Duration(p.GetTotalWaitMs()) * time.Millisecond,
+		Waits:     int(p.GetWaits()),
+	}
+}
+
+// leaderboardLess compara dos filas según el criterio pedido; los empates se
+// resuelven por ID para que la paginación sea estable.
+func leaderboardLess(by pb.LeaderboardRequest_SortBy, a, b *pb.PlayerStats) bool {
+	switch by {
+	case pb.LeaderboardRequest_MATCHES:
+		if a.MatchesPlayed != b.MatchesPlayed {
+			return a.MatchesPlayed > b.MatchesPlayed
+		}
+	case pb.LeaderboardRequest_WIN_RATE:
+		if a.WinRate != b.WinRate {
+			return a.WinRate > b.WinRate
+		}
+		if a.MatchesPlayed != b.MatchesPlayed {
+			return a.MatchesPlayed > b.MatchesPlayed
+		}
+	case pb.LeaderboardRequest_AVG_WAIT:
+		// quien nunca fue emparejado no tiene espera media: va al final
+		if (a.Waits == 0) != (b.Waits == 0) {
+			return b.Waits == 0
+		}
+		if a.AvgWaitMs != b.AvgWaitMs {
+			return a.AvgWaitMs < b.AvgWaitMs
+		}
+	default:
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.MatchesPlayed != b.MatchesPlayed {
+			return a.MatchesPlayed > b.MatchesPlayed
+		}
+	}
+	return a.PlayerId < b.PlayerId
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: GetLeaderboard – clasificación de jugadores, paginada
+───────────────────────────────────────────────────────────────────────────────*/
+
+// GetLeaderboard no modifica estado. El token de página es la posición de la
+// última fila entregada; una partida que termine entre dos páginas puede
+// mover jugadores de una a otra.
+func (m *matchmaker) GetLeaderboard(ctx context.Context, req *pb.LeaderboardRequest) (*pb.LeaderboardResponse, error) {
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size debe ser >= 0 (recibido %d)", size)
+	case size == 0:
+		size = defaultLeaderboardPage
+	case size > maxLeaderboardPage:
+		size = maxLeaderboardPage
+	}
+	var offset int
+	if tok := req.GetPageToken(); tok != "" {
+		n, err := strconv.Atoi(tok)
+		if err != nil || n <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "page_token inválido: %q", tok)
+		}
+		offset = n
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.LeaderboardResponse{}, nil
+	}
+
+	rows := make([]*pb.PlayerStats, 0, len(ns.stats))
+	for pid, st := range ns.stats {
+		rows = append(rows, st.toProto(pid))
+	}
+	sort.Slice(rows, func(i, j int) bool { return leaderboardLess(req.GetSortBy(), rows[i], rows[j]) })
+
+	out := &pb.LeaderboardResponse{TotalPlayers: int32(len(rows)), VectorClock: m.clockProto(ns)}
+	if offset >= len(rows) {
+		return out, nil
+	}
+	end := offset + size
+	if end < len(rows) {
+		out.NextPageToken = strconv.Itoa(end)
+	} else {
+		end = len(rows)
+	}
+	for i, row := range rows[offset:end] {
+		row.Rank = int32(offset + i + 1)
+		out.Entries = append(out.Entries, row)
+	}
+	return out, nil
+}
@@ -0,0 +1,102 @@
+// matchmaker/leaderboard_test.go
+//
+// Clasificación: sólo cuentan las partidas con resultado informado, cada
+// criterio ordena como se espera, la paginación sigue las posiciones y los
+// acumulados viajan en el snapshot.
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func leaderboardIDs(entries []*pb.PlayerStats) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.GetPlayerId())
+	}
+	return ids
+}
+
+// playMatch empareja a a y b y se informa la partida ganada por winner.
+func playMatch(r *failureRig, n int, a, b, winner string) {
+	r.t.Helper()
+	r.queuePair(a, b)
+	matchID := r.gs.WaitAssigned(r.t, n, time.Second)[n-1].MatchID
+	res, err := r.cli.ReportMatchResult(context.Background(), &pb.MatchResultRequest{MatchId: matchID, ServerId: r.gs.ID, WinnerId: winner})
+	if err != nil || res.GetStatusCode() != pb.MatchResultResponse_OK {
+		r.t.Fatalf("ReportMatchResult(%s): %v %v", matchID, res.GetStatusCode(), err)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport, testkit.NeverReport, testkit.NeverReport)
+	playMatch(r, 1, "A", "B", "A")
+	playMatch(r, 2, "A", "C", "C")
+
+	// una partida abandonada no suma
+	r.queuePair("B", "D")
+	matchID := r.gs.WaitAssigned(t, 3, time.Second)[2].MatchID
+	leave(t, r.cli, "B", matchID)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		by   pb.LeaderboardRequest_SortBy
+		want []string
+	}{
+		{pb.LeaderboardRequest_WINS, []string{"A", "C", "B", "D"}},
+		{pb.LeaderboardRequest_MATCHES, []string{"A", "B", "C", "D"}},
+		{pb.LeaderboardRequest_WIN_RATE, []string{"C", "A", "B", "D"}},
+	} {
+		res, err := r.cli.GetLeaderboard(ctx, &pb.LeaderboardRequest{SortBy: tc.by})
+		if err != nil {
+			t.Fatalf("GetLeaderboard(%v): %v", tc.by, err)
+		}
+		if got := leaderboardIDs(res.GetEntries()); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("por %v = %v, se esperaba %v", tc.by, got, tc.want)
+		}
+	}
+
+	// de a dos: las posiciones continúan en la segunda página
+	req := &pb.LeaderboardRequest{PageSize: 2}
+	var ranks []int32
+	for {
+		res, err := r.cli.GetLeaderboard(ctx, req)
+		if err != nil {
+			t.Fatalf("GetLeaderboard: %v", err)
+		}
+		if res.GetTotalPlayers() != 4 {
+			t.Errorf("total = %d, se esperaban 4", res.GetTotalPlayers())
+		}
+		for _, e := range res.GetEntries() {
+			ranks = append(ranks, e.GetRank())
+		}
+		if res.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+	if !reflect.DeepEqual(ranks, []int32{1, 2, 3, 4}) {
+		t.Errorf("posiciones = %v", ranks)
+	}
+	if _, err := r.cli.GetLeaderboard(ctx, &pb.LeaderboardRequest{PageToken: "x"}); err == nil {
+		t.Errorf("page_token inválido aceptado")
+	}
+
+	// el respaldo recibe los mismos acumulados
+	r.mm.mu.RLock()
+	snap := r.mm.snapshot()
+	r.mm.mu.RUnlock()
+	backup := newMatchmaker("Backup")
+	backup.restore(snap)
+	a := backup.lookupNS(defaultNamespace).stats["A"]
+	if a == nil || a.Matches != 2 || a.Wins != 1 || a.Waits != 2 {
+		t.Errorf("acumulado de A en el respaldo = %+v", a)
+	}
+}
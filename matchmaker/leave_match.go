@@ -0,0 +1,178 @@
+// matchmaker/leave_match.go
+//
+// Abandono de partidas. LeaveMatch termina en el acto la partida en curso
+// del jugador: queda en el historial como ABANDONED, el Matchmaker le pide
+// al GameServer que deje de jugarla (CancelMatch) y los demás jugadores
+// reciben PLAYER_LEFT. Según LEAVE_ACTION vuelven al frente de la cola
+// (requeue, por defecto) o ganan por abandono (forfeit: gana el primero del
+// equipo rival) y quedan IDLE.
+//
+// El abandono y el ReportMatchResult del servidor compiten por cerrar la
+// misma partida; el candado los ordena y el que llega segundo recibe
+// NOT_IN_MATCH o UNKNOWN_MATCH. Ambas respuestas llevan el reloj del
+// namespace, así que el jugador lee después el desenlace que ganó.
+
+package main
+
+import (
+	"context"
+
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/tracing"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	leaveRequeue = "requeue"
+	leaveForfeit = "forfeit"
+
+	outcomeAbandoned = "abandoned"
+)
+
+// forfeitWinner elige al ganador por abandono: el primer jugador del equipo
+// rival (la primera mitad de Players es un equipo y la segunda, el otro).
+func (am *activeMatch) forfeitWinner(leaver string) string {
+	half := len(am.Players) / 2
+	for i, pid := range am.Players {
+		if pid != leaver {
+			continue
+		}
+		if i < half {
+			return am.Players[half]
+		}
+		return am.Players[0]
+	}
+	return ""
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+               RPC: LeaveMatch – el jugador abandona su partida
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) LeaveMatch(ctx context.Context, req *pb.LeaveMatchRequest) (*pb.LeaveMatchResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	p, ok := ns.players.Get(playerID)
+	if !ok || p.Status != playerInMatch || (req.GetMatchId() != "" && req.GetMatchId() != p.MatchID) {
+		return &pb.LeaveMatchResponse{
+			StatusCode:  pb.LeaveMatchResponse_NOT_IN_MATCH,
+			Message:     "No estás en esa partida",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	matchID := p.MatchID
+	am := ns.matches.Value(matchID)
+	// antes de confirmarse, abandonar equivale a rechazarla (ready_check.go)
+	if am.Ready != nil {
+		m.failReadyCheck(ns, matchID, am, []string{playerID}, "abandonada por "+playerID, readyDeclined)
+		return &pb.LeaveMatchResponse{
+			StatusCode:  pb.LeaveMatchResponse_OK,
+			Message:     "Partida rechazada antes de empezar",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	winner := ""
+	if m.leaveForfeit {
+		winner = am.forfeitWinner(playerID)
+	}
+	detail := "abandonada por " + playerID
+	now := m.wall.Now()
+	m.closeMatch(ns, matchID, am, phaseAborted)
+	ns.releaseServer(am.ServerID)
+	m.recordMatch(ns, &matchResult{
+		MatchID:    matchID,
+		ServerID:   am.ServerID,
+		Mode:       am.Mode,
+		Players:    am.Players,
+		WinnerID:   winner,
+		Outcome:    outcomeAbandoned,
+		Duration:   now.Sub(am.StartedAt),
+		StartedAt:  am.StartedAt,
+		FinishedAt: now,
+		VC:         ns.vc.Copy(),
+		Timeline:   append(am.Timeline, m.newEvent(ns, pb.MatchEvent_ENDED, detail)),
+	})
+
+	p.Status, p.MatchID, p.LastOp = playerIdle, "", now
+	// en orden inverso para que, al reencolar al frente, conserven su orden
+	var requeued []string
+	for i := len(am.Players) - 1; i >= 0; i-- {
+		o, ok := ns.players.Get(am.Players[i])
+		if !ok || o.ID == playerID || o.MatchID != matchID {
+			continue
+		}
+		o.MatchID, o.LastOp = "", now
+		if m.leaveForfeit {
+			o.Status = playerIdle
+		} else {
+			o.Status = playerInQueue
+			o.Priority = priorityRequeued
+			o.QueuedVC = ns.vc.Copy()
+			o.QueueDeadline = m.queueDeadline(now)
+			ns.enqueue(o.ID, true)
+			requeued = append(requeued, o.ID)
+		}
+		m.notify(ns, o.ID, &pb.MatchUpdate{
+			Event:    pb.MatchUpdate_PLAYER_LEFT,
+			MatchId:  matchID,
+			WinnerId: winner,
+		})
+	}
+	m.logRequeued(ns, matchID, requeued, "abandono de "+playerID)
+
+	if srv, ok := ns.servers.Get(am.ServerID); ok {
+		go m.cancelOnServer(tracing.Detach(ctx), ns.name, srv.ID, srv.Address, matchID, detail, ns.vc.Copy())
+	}
+	m.logClockf(ns, "[%s] Jugador %s abandonó la partida %s; resto → %s", ns.name, playerID, matchID, m.leaveAction())
+	return &pb.LeaveMatchResponse{
+		StatusCode:  pb.LeaveMatchResponse_OK,
+		Message:     "Abandonaste la partida",
+		MatchId:     matchID,
+		WinnerId:    winner,
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+func (m *matchmaker) leaveAction() string {
+	if m.leaveForfeit {
+		return leaveForfeit
+	}
+	return leaveRequeue
+}
+
+// cancelOnServer avisa al GameServer que deje de jugar la partida. Si no
+// responde no se reintenta: su ReportMatchResult recibirá UNKNOWN_MATCH y
+// su próxima actualización de estado corrige sus huecos.
+func (m *matchmaker) cancelOnServer(ctx context.Context, nsName, serverID, addr, matchID, reason string, snapshot *clocks.Vector) {
+	_, timeout := m.assignSettings()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := m.dialServer(ctx, addr)
+	if err != nil {
+		m.logf("[%s] WARNING: no se pudo avisar a %s que cancele %s: %v", nsName, serverID, matchID, err)
+		return
+	}
+	defer conn.Close()
+
+	res, err := pb.NewGameServerClient(conn).CancelMatch(ctx, &pb.CancelMatchRequest{
+		MatchId:     matchID,
+		Reason:      reason,
+		VectorClock: snapshot.ToProto(),
+		Namespace:   nsName,
+	})
+	if err != nil {
+		m.logf("[%s] WARNING: CancelMatch de %s a %s falló: %v", nsName, matchID, serverID, err)
+		return
+	}
+	m.logf("[%s] Server %s canceló la partida %s: %s", nsName, serverID, matchID, res.GetStatusCode())
+}
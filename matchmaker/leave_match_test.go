@@ -0,0 +1,94 @@
+// matchmaker/leave_match_test.go
+//
+// LeaveMatch: la partida se cierra como abandonada, el GameServer recibe
+// CancelMatch y el resto vuelve a la cola o gana por abandono.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// startedMatch empareja a A y B en un servidor que nunca informa resultado
+// y devuelve el ID de la partida.
+func startedMatch(r *failureRig) string {
+	r.t.Helper()
+	r.queuePair("A", "B")
+	return r.gs.WaitAssigned(r.t, 1, time.Second)[0].MatchID
+}
+
+func leave(t *testing.T, cli pb.MatchmakerClient, playerID, matchID string) *pb.LeaveMatchResponse {
+	t.Helper()
+	res, err := cli.LeaveMatch(context.Background(), &pb.LeaveMatchRequest{PlayerId: playerID, MatchId: matchID})
+	if err != nil {
+		t.Fatalf("LeaveMatch: %v", err)
+	}
+	return res
+}
+
+func TestLeaveMatchRequeuesOthers(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	matchID := startedMatch(r)
+
+	res := leave(t, r.cli, "A", matchID)
+	if res.GetStatusCode() != pb.LeaveMatchResponse_OK || res.GetMatchId() != matchID || res.GetWinnerId() != "" {
+		t.Fatalf("LeaveMatch = %v", res)
+	}
+	if got := r.gs.WaitCancelled(t, 1, time.Second); got[0] != matchID {
+		t.Fatalf("CancelMatch de %s, se esperaba %s", got[0], matchID)
+	}
+	r.inspect(func(ns *namespace) {
+		if ns.players.Value("A").Status != playerIdle {
+			t.Errorf("A: %v, se esperaba IDLE", ns.players.Value("A").Status)
+		}
+		if !requeued(ns, "B") {
+			t.Errorf("B no volvió al frente de la cola: %v", ns.players.Value("B").Status)
+		}
+		if len(ns.history) != 1 || ns.history[0].Outcome != outcomeAbandoned {
+			t.Errorf("historial = %v", ns.history)
+		}
+		if !serverIs(ns, r.gs.ID, serverAvailable) {
+			t.Errorf("el servidor no quedó libre: %v", ns.servers.Value(r.gs.ID).Status)
+		}
+	})
+
+	// el resultado tardío del servidor ya no encuentra la partida
+	late, err := r.cli.ReportMatchResult(context.Background(), &pb.MatchResultRequest{MatchId: matchID, ServerId: r.gs.ID, WinnerId: "A"})
+	if err != nil || late.GetStatusCode() != pb.MatchResultResponse_UNKNOWN_MATCH {
+		t.Fatalf("ReportMatchResult tardío: %v %v", late.GetStatusCode(), err)
+	}
+}
+
+func TestLeaveMatchForfeit(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	r.mm.leaveForfeit = true
+	matchID := startedMatch(r)
+
+	if res := leave(t, r.cli, "B", ""); res.GetWinnerId() != "A" {
+		t.Fatalf("ganador por abandono = %q, se esperaba A", res.GetWinnerId())
+	}
+	r.inspect(func(ns *namespace) {
+		if ns.players.Value("A").Status != playerIdle || ns.queue.Len() != 0 {
+			t.Errorf("A: %v, cola %v; se esperaba IDLE y cola vacía", ns.players.Value("A").Status, ns.queue.IDs())
+		}
+		if rec := ns.history[0]; rec.MatchID != matchID || rec.WinnerID != "A" {
+			t.Errorf("historial = %+v", rec)
+		}
+	})
+}
+
+func TestLeaveMatchNotInMatch(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	if res := leave(t, r.cli, "A", ""); res.GetStatusCode() != pb.LeaveMatchResponse_NOT_IN_MATCH {
+		t.Fatalf("sin partida: %v", res.GetStatusCode())
+	}
+	startedMatch(r)
+	if res := leave(t, r.cli, "A", "otra"); res.GetStatusCode() != pb.LeaveMatchResponse_NOT_IN_MATCH {
+		t.Fatalf("partida ajena: %v", res.GetStatusCode())
+	}
+}
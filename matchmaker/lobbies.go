@@ -0,0 +1,80 @@
+// matchmaker/lobbies.go
+//
+// Salas por tiempo (estilo battle royale). Un modo con LobbyMax > 0 no
+// forma dos equipos de TeamSize: junta en una sola partida, todos contra
+// todos, a los jugadores en cola del modo (cada grupo completo). La partida
+// arranca apenas la sala se llena o, si se reunió al menos LobbyMin, cuando
+// vence la cuenta regresiva de LobbyWait segundos que empezó al alcanzarlo.
+// Si la sala vuelve a quedar bajo el mínimo (alguien sale de la cola) la
+// cuenta se reinicia.
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// isLobby indica si el modo forma salas por tiempo en vez de equipos.
+func (g *gameMode) isLobby() bool { return g.LobbyMax > 0 }
+
+// maxPartySize es el grupo más grande que admite el modo.
+func (g *gameMode) maxPartySize() int {
+	if g.isLobby() {
+		return g.LobbyMax
+	}
+	return g.TeamSize
+}
+
+// shape describe el formato de partida del modo para logs y listados.
+func (g *gameMode) shape() string {
+	if g.isLobby() {
+		return fmt.Sprintf("sala de %d-%d jugadores, espera %d s", g.LobbyMin, g.LobbyMax, g.LobbyWait)
+	}
+	return fmt.Sprintf("equipos de %d", g.TeamSize)
+}
+
+// takeLobby forma una sala del modo si está llena o si venció su cuenta
+// regresiva; si no, no modifica la cola y devuelve nil.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) takeLobby(mode *gameMode, now time.Time) []string {
+	var picked []string
+	seen := make(map[string]bool)
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if !ok || p.Mode != mode.Name || seen[pid] {
+			continue
+		}
+		unit := ns.queuedUnit(p)
+		for _, id := range unit {
+			seen[id] = true
+		}
+		if len(picked)+len(unit) <= mode.LobbyMax {
+			picked = append(picked, unit...)
+		}
+		if len(picked) == mode.LobbyMax {
+			break
+		}
+	}
+
+	if len(picked) < mode.LobbyMin {
+		delete(ns.lobbyReady, mode.Name)
+		return nil
+	}
+	if len(picked) < mode.LobbyMax {
+		since, ok := ns.lobbyReady[mode.Name]
+		if !ok {
+			since = now
+			ns.lobbyReady[mode.Name] = since
+		}
+		if now.Sub(since) < time.Duration(mode.LobbyWait)*time.Second {
+			return nil
+		}
+	}
+
+	delete(ns.lobbyReady, mode.Name)
+	for _, pid := range picked {
+		ns.removeFromQueue(pid)
+	}
+	return picked
+}
@@ -0,0 +1,58 @@
+// matchmaker/locking.go
+//
+// Qué candado protege cada parte del estado. m.mu sigue siendo el candado
+// del Matchmaker, pero las RPC de jugador más frecuentes (QueuePlayer,
+// CancelQueue, ResumeSession y GetPlayerStatus cuando no tiene que esperar)
+// lo toman en lectura y bloquean sólo a sus jugadores; el resto de las
+// transiciones (emparejamiento, servidores, grupos, salas, administración)
+// lo toman en escritura, como siempre, y ven un estado sin RPC a medias.
+//
+// Con m.mu en lectura:
+//
+//   - namespaces, modos, grupos, baneos, salas, partidas, servidores y
+//     parámetros sólo se leen: se modifican con m.mu en escritura;
+//   - jugadores, servidores, partidas y cola viven en internal/store, que
+//     bloquea por shard; los campos de un jugador se tocan con su candado
+//     de ns.playerLocks (el de todo su grupo, ver partyLockSet);
+//   - el reloj del namespace es thread-safe; merge y tick van juntos
+//     (mergeTick);
+//   - el log de eventos, las llegadas y las sesiones tienen candado propio.
+//
+// Quien lee con m.mu en lectura algo de esta lista toma el candado que
+// corresponde; quien necesita una vista coherente de todo (auditoría,
+// métricas, vistas de administración) toma m.mu en escritura. El snapshot
+// de replicación es la excepción, porque corre cada segundo: toma m.mu en
+// lectura y bloquea a todos los jugadores con playerLocks.LockAll.
+
+package main
+
+// rlockNS toma m.mu en lectura y devuelve el namespace pedido, con la
+// función que lo suelta. Si el namespace no existe lo crea con m.mu en
+// escritura y vuelve a buscarlo: restore() pudo reemplazarlo entretanto.
+func (m *matchmaker) rlockNS(name string) (*namespace, func()) {
+	for {
+		m.mu.RLock()
+		if ns := m.lookupNS(name); ns != nil {
+			return ns, m.mu.RUnlock
+		}
+		m.mu.RUnlock()
+		m.mu.Lock()
+		m.ns(name)
+		m.mu.Unlock()
+	}
+}
+
+// partyLockSet son los jugadores que hay que bloquear para operar sobre
+// playerID: todo su grupo, porque encolar o cancelar a uno mueve a todos.
+// Los grupos sólo cambian con m.mu en escritura, así que el conjunto no
+// cambia mientras se tenga m.mu en lectura.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (ns *namespace) partyLockSet(playerID string) []string {
+	ids := []string{playerID}
+	if p, ok := ns.players.Get(playerID); ok {
+		if pt, ok := ns.parties[p.PartyID]; ok {
+			ids = append(ids, pt.Members...)
+		}
+	}
+	return ids
+}
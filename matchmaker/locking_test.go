@@ -0,0 +1,69 @@
+// matchmaker/locking_test.go
+//
+// RPC de jugador concurrentes con m.mu en lectura (locking.go) mientras el
+// bucle de emparejamiento forma partidas. La auditoría corre tras cada RPC
+// con m.mu en escritura; aquí además se revisa el estado final y el orden
+// del log de eventos. Conviene correrla con -race.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestSharedLockPlayerRPCs(t *testing.T) {
+	r := newEventRig(t, true)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 25; i++ {
+				id := fmt.Sprintf("w%d-%d", w, i%5)
+				r.cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id})
+				r.cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id, Consistency: pb.Consistency_CONSISTENCY_EVENTUAL})
+				r.cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: id})
+				if i%3 == 0 {
+					r.cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: id})
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	r.gs.WaitAssigned(t, 1, time.Second)
+
+	r.mm.mu.Lock()
+	defer r.mm.mu.Unlock()
+	if problems := r.mm.checkInvariants(); len(problems) > 0 {
+		t.Fatalf("invariantes violados: %v", problems)
+	}
+	ns := r.mm.lookupNS(defaultNamespace)
+	queued := 0
+	for _, p := range ns.players.All() {
+		if p.Status == playerInQueue {
+			queued++
+		}
+	}
+	if n := ns.queue.Len(); n != queued {
+		t.Fatalf("cola con %d entradas y %d jugadores IN_QUEUE", n, queued)
+	}
+
+	// la secuencia y el reloj de los eventos avanzan juntos
+	var last int64
+	for _, ev := range r.mm.eventLog.entries {
+		c := clocks.FromProto(ev.GetClock()).Get(r.mm.selfID)
+		if c < last {
+			t.Fatalf("evento #%d con reloj %d tras uno con %d", ev.GetSequence(), c, last)
+		}
+		last = c
+	}
+}
@@ -0,0 +1,105 @@
+// matchmaker/log_tail.go
+//
+// Seguimiento en vivo del log del Matchmaker. AdminStreamLogs reenvía al
+// cliente administrador cada línea que el proceso escribe desde que se
+// suscribe —las de m.logf y las de los interceptores—, filtradas por nivel
+// mínimo y por etiqueta (el componente, "Matchmaker", o el namespace entre
+// corchetes), para seguir una demo sin entrar por SSH al contenedor. Un
+// cliente lento no frena a nadie: las líneas que no alcanza a recibir se
+// pierden y la siguiente informa cuántas fueron. Las líneas de m.logf no
+// traen nivel: se deduce del WARNING/ERROR con que empiezan.
+
+package main
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	slog "github.com/vimsent/L3/internal/log"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const logTailBuffer = 256 // líneas en espera por cliente
+
+// logFilter decide qué líneas del log recibe un cliente.
+type logFilter struct {
+	min  slog.Level
+	tags map[string]bool // en minúsculas; vacío = todas
+}
+
+func newLogFilter(req *pb.LogStreamRequest) (logFilter, error) {
+	f := logFilter{min: slog.InfoLevel}
+	if lvl := req.GetMinLevel(); lvl != "" {
+		l, err := slog.ParseLevel(lvl)
+		if err != nil {
+			return f, err
+		}
+		f.min = l
+	}
+	for _, t := range req.GetTags() {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			if f.tags == nil {
+				f.tags = map[string]bool{}
+			}
+			f.tags[t] = true
+		}
+	}
+	return f, nil
+}
+
+func (f logFilter) matches(l slog.Line) bool {
+	if l.Level < f.min {
+		return false
+	}
+	if len(f.tags) == 0 {
+		return true
+	}
+	for _, t := range l.Tags {
+		if f.tags[strings.ToLower(t)] {
+			return true
+		}
+	}
+	return false
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+              RPC: AdminStreamLogs – log del Matchmaker en vivo
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminStreamLogs(req *pb.LogStreamRequest, stream pb.Matchmaker_AdminStreamLogsServer) error {
+	filter, err := newLogFilter(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tail := slog.Subscribe(logTailBuffer)
+	defer tail.Close()
+	m.logf("Log en vivo: cliente suscrito (nivel ≥ %s, etiquetas %v)", filter.min, req.GetTags())
+
+	var reported uint64
+	for {
+		select {
+		case l := <-tail.C:
+			if !filter.matches(l) {
+				continue
+			}
+			dropped := tail.Dropped()
+			if err := stream.Send(&pb.LogLine{
+				UnixMs:  l.Time.UnixMilli(),
+				Level:   l.Level.String(),
+				Tags:    l.Tags,
+				Message: l.Message,
+				Dropped: dropped - reported,
+			}); err != nil {
+				return err
+			}
+			reported = dropped
+		case <-stream.Context().Done():
+			return nil
+		case <-m.done:
+			return nil
+		}
+	}
+}
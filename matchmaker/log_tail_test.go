@@ -0,0 +1,85 @@
+// matchmaker/log_tail_test.go
+//
+// AdminStreamLogs: las líneas de m.logf llegan con el nivel deducido del
+// mensaje y filtradas por nivel mínimo y etiqueta; un nivel desconocido se
+// rechaza.
+
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	slog "github.com/vimsent/L3/internal/log"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// recvUntil lee del stream hasta una línea que contenga text.
+func recvUntil(t *testing.T, stream pb.Matchmaker_AdminStreamLogsClient, text string) *pb.LogLine {
+	t.Helper()
+	for {
+		l, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("esperando %q: %v", text, err)
+		}
+		if strings.Contains(l.GetMessage(), text) {
+			return l
+		}
+	}
+}
+
+func TestStreamLogsFiltersByLevelAndTag(t *testing.T) {
+	log.SetOutput(slog.Writer())
+	t.Cleanup(func() { log.SetOutput(io.Discard) })
+	mm, cli := startMatchmaker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all, err := cli.AdminStreamLogs(ctx, &pb.LogStreamRequest{})
+	if err != nil {
+		t.Fatalf("AdminStreamLogs: %v", err)
+	}
+	recvUntil(t, all, "cliente suscrito")
+	warn, err := cli.AdminStreamLogs(ctx, &pb.LogStreamRequest{MinLevel: "warn", Tags: []string{"Default"}})
+	if err != nil {
+		t.Fatalf("AdminStreamLogs(warn): %v", err)
+	}
+	recvUntil(t, all, "cliente suscrito") // el segundo ya está suscrito
+
+	mm.logf("[default] WARNING: uno")
+	mm.logf("[default] dos")
+	mm.logf("[otro] WARNING: tres")
+	mm.logf("ERROR [default] cuatro")
+
+	if l := recvUntil(t, all, "dos"); l.GetLevel() != "INFO" || strings.Join(l.GetTags(), ",") != "Matchmaker,default" {
+		t.Fatalf("línea INFO = %+v", l)
+	}
+	var got []string
+	for len(got) < 2 {
+		l, err := warn.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got = append(got, l.GetLevel()+" "+l.GetMessage())
+	}
+	if want := "WARN uno|ERROR cuatro"; strings.Join(got, "|") != want {
+		t.Fatalf("filtro WARN+default recibió %q, se esperaba %q", strings.Join(got, "|"), want)
+	}
+}
+
+func TestStreamLogsRejectsUnknownLevel(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	stream, err := cli.AdminStreamLogs(context.Background(), &pb.LogStreamRequest{MinLevel: "verbose"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("nivel desconocido: %v, se esperaba InvalidArgument", err)
+	}
+}
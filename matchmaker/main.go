@@ -6,7 +6,7 @@
 // ▸ Expone todos los RPCs definidos en proto/matchmaking.proto.
 // ▸ Mantiene el estado de jugadores, servidores y partidas.
 // ▸ Aplica consistencia eventual mediante relojes vectoriales.
-// ▸ Garantiza “Read-Your-Writes” para los jugadores.
+// ▸ Garantiza “Read-Your-Writes” para los jugadores (session_guarantees.go).
 // ▸ Incluye tolerancia a fallos (timeouts - heartbeats, reintentos).
 // ▸ Usa ÚNICAMENTE librerías permitidas + gRPC/Protobuf.
 //
@@ -21,12 +21,34 @@ import (
 	"net"
 	"os"
 	"os/signal"
-	"strconv"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
-
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/authtoken"
+	"github.com/vimsent/L3/internal/chaos"
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/idgen"
+	slog "github.com/vimsent/L3/internal/log"
+	"github.com/vimsent/L3/internal/middleware"
+	"github.com/vimsent/L3/internal/ratelimit"
+	"github.com/vimsent/L3/internal/store"
+	"github.com/vimsent/L3/internal/tlsutil"
+	"github.com/vimsent/L3/internal/tracing"
+	"github.com/vimsent/L3/internal/walltime"
+	"github.com/vimsent/L3/pkg/mmclient"
 	pb "github.com/vimsent/L3/proto" // ← ajusta la ruta a tu módulo Go
 )
 
@@ -35,58 +57,75 @@ import (
 ───────────────────────────────────────────────────────────────────────────────*/
 
 const (
-	defaultPort            = 50051
-	matchCheckPeriod       = 2 * time.Second
-	serverHeartbeatTimeout = 30 * time.Second
+	defaultPort             = 50051
+	defaultMatchCheckPeriod = 2 * time.Second
+	defaultHeartbeatTimeout = 30 * time.Second
+	defaultAssignTimeout    = 10 * time.Second // plazo de AssignMatch, incluida la conexión
+	defaultNamespace        = "default"
+	maxEventLog             = 1024 // notificaciones guardadas por namespace para reanudar streams
 )
 
-// Reloj Vectorial: id → contador
-type vectorClock map[string]int
+type playerState int
 
-func (vc vectorClock) clone() vectorClock {
-	out := make(vectorClock)
-	for k, v := range vc {
-		out[k] = v
-	}
-	return out
-}
+const (
+	playerIdle playerState = iota
+	playerInQueue
+	playerInMatch
+)
 
-func (vc vectorClock) increment(id string) {
-	vc[id]++
+func (s playerState) String() string {
+	switch s {
+	case playerInQueue:
+		return "IN_QUEUE"
+	case playerInMatch:
+		return "IN_MATCH"
+	default:
+		return "IDLE"
+	}
 }
 
-func (vc vectorClock) merge(other vectorClock) {
-	for k, v := range other {
-		if cur, ok := vc[k]; !ok || v > cur {
-			vc[k] = v
-		}
+func parsePlayerState(s string) playerState {
+	switch s {
+	case "IN_QUEUE":
+		return playerInQueue
+	case "IN_MATCH":
+		return playerInMatch
+	default:
+		return playerIdle
 	}
 }
 
-func (vc vectorClock) toProto() *pb.VectorClock {
-	res := &pb.VectorClock{Counters: map[string]int32{}}
-	for k, v := range vc {
-		res.Counters[k] = int32(v)
+// prioridad en la cola; mayor valor = se empareja antes
+type queuePriority int
+
+const (
+	priorityNormal queuePriority = iota
+	priorityPremium
+	priorityRequeued // devuelto a la cola tras un fallo de asignación
+)
+
+func (p queuePriority) toProto() pb.QueuePriority {
+	switch p {
+	case priorityPremium:
+		return pb.QueuePriority_PRIORITY_PREMIUM
+	case priorityRequeued:
+		return pb.QueuePriority_PRIORITY_REQUEUED
+	default:
+		return pb.QueuePriority_PRIORITY_NORMAL
 	}
-	return res
 }
 
-func vcFromProto(p *pb.VectorClock) vectorClock {
-	out := make(vectorClock)
-	for k, v := range p.GetCounters() {
-		out[k] = int(v)
+func queuePriorityFromProto(p pb.QueuePriority) queuePriority {
+	switch p {
+	case pb.QueuePriority_PRIORITY_PREMIUM:
+		return priorityPremium
+	case pb.QueuePriority_PRIORITY_REQUEUED:
+		return priorityRequeued
+	default:
+		return priorityNormal
 	}
-	return out
 }
 
-type playerState int
-
-const (
-	playerIdle playerState = iota
-	playerInQueue
-	playerInMatch
-)
-
 type serverState int
 
 const (
@@ -97,41 +136,222 @@ const (
 )
 
 type playerInfo struct {
-	ID      string
-	Status  playerState
-	MatchID string
-	VC      vectorClock
-	LastOp  time.Time
+	ID       string
+	Status   playerState
+	Mode     string // modo de juego con el que se encoló
+	Priority queuePriority
+	Tier     playerTier // asignado por el administrador
+	Region   string     // región preferida (normalizada); vacío = cualquiera
+	PartyID  string     // grupo al que pertenece; vacío = juega solo
+	MatchID  string
+	VC       *clocks.Vector // último reloj recibido del jugador (clock_state.go)
+	VCAt     time.Time      // cuándo llegó VC
+	LastOp   time.Time
+	QueuedVC *clocks.Vector // reloj del namespace al encolarse (ver LastOp)
+	// QueueSpan es el span del QueuePlayer que lo encoló (ver match_trace.go)
+	QueueSpan tracing.SpanContext
+	// CooldownUntil es el instante desde el que puede volver a encolarse
+	// tras su última partida; cero = sin espera
+	CooldownUntil time.Time
+	// QueueDeadline es el vencimiento de su entrada en la cola; cero = sin
+	// plazo. QueueTimedOut: su última espera venció (queue_timeout.go)
+	QueueDeadline time.Time
+	QueueTimedOut bool
+	// Registered: el ID fue reclamado con RegisterPlayer (player_auth.go)
+	Registered bool
+	// LobbyCode es la sala privada en la que espera; vacío = ninguna
+	// (private_lobbies.go)
+	LobbyCode string
+	// LastHeartbeat es el último PlayerHeartbeat; cero = el cliente no late
+	// y no se vigila (player_liveness.go)
+	LastHeartbeat time.Time
+	// OnAssignFailure es lo que eligió al encolarse para cuando su partida
+	// no consiga servidor; RequeueOffer, hasta cuándo puede recuperar su
+	// lugar tras un fallo con FAILURE_ASK (assign_failure_action.go)
+	OnAssignFailure pb.AssignFailureAction
+	RequeueOffer    time.Time
 }
 
+// Las partidas en curso de cada servidor se derivan de namespace.matches.
 type gameServerInfo struct {
-	ID           string
-	Address      string
-	Status       serverState
-	CurrentMatch string
-	VC           vectorClock
-	LastHB       time.Time
+	ID        string
+	Address   string
+	Status    serverState
+	VC        *clocks.Vector // último reloj recibido del servidor (clock_state.go)
+	VCAt      time.Time      // cuándo llegó VC
+	LastHB    time.Time
+	Draining  bool   // termina sus partidas actuales y luego sale del pool
+	Region    string // normalizada; vacío = sin región declarada
+	LatencyMs uint32 // latencia estimada declarada; 0 = desconocida
+	Capacity  int    // partidas simultáneas que admite (≥ 1)
+	FreeSlots int    // huecos libres según su última actualización
+	Resynced  uint64 // época anterior desde la que se re-sincronizó (server_epoch.go)
+}
+
+type playerMatchStats struct {
+	PlayerID string
+	Score    int
+	Kills    int
+	Deaths   int
+}
+
+// scoreEvent es una eliminación de la simulación del GameServer.
+type scoreEvent struct {
+	At       time.Duration // desde el inicio de la partida
+	PlayerID string
+	VictimID string
+}
+
+// activeMatch es una partida asignada que aún no informa resultado.
+type activeMatch struct {
+	Players   []string
+	ServerID  string
+	Mode      string
+	StartedAt time.Time
+	Timeline  []matchEvent
+	Trace     tracing.SpanContext // span FormMatch (ver match_trace.go)
+	OpenSlots int               // huecos que pidió rellenar el servidor (backfill.go)
+	Ready     *readyCheck       // confirmación pendiente (ready_check.go); nil = ya asignada
+	LobbyCode string            // sala privada de la que salió; vacío = cola pública
+	Pinned    bool              // la sala eligió el servidor: no se conmuta (private_lobbies.go)
+	MatchAddr string            // dirección dedicada que asignó el servidor (server_addr.go)
+
+	// fase del ciclo de vida y cuándo entró a cada una (match_state.go)
+	Phase   matchPhase
+	PhaseAt [numPhases]time.Time
+}
+
+// matchResult es una fila de la tabla de historial de partidas.
+type matchResult struct {
+	Seq        uint64 // orden de cierre en el namespace
+	MatchID    string
+	ServerID   string
+	Mode       string
+	Players    []string
+	WinnerID   string
+	WinnerIDs  []string // equipo ganador; vacío = sólo WinnerID
+	Outcome    string   // outcomeCompleted o el motivo de closeOrphan
+	Duration   time.Duration
+	Stats      []playerMatchStats
+	Events     []scoreEvent    // eliminaciones, en orden
+	Feedback   []matchFeedback // opiniones de los jugadores
+	StartedAt  time.Time
+	FinishedAt time.Time
+	VC         *clocks.Vector
+	Timeline   []matchEvent
 }
 
 /*───────────────────────────────────────────────────────────────────────────────
                              Matchmaker struct
 ───────────────────────────────────────────────────────────────────────────────*/
 
+// namespace agrupa todo el estado de un tenant (juego o grupo de laboratorio).
+// Dos namespaces nunca comparten cola, servidores, partidas ni reloj.
+// Jugadores, servidores, partidas y cola se guardan en internal/store; qué
+// candado protege cada cosa está en locking.go.
+type namespace struct {
+	name    string
+	players *store.Map[*playerInfo]
+	servers *store.Map[*gameServerInfo]
+	queue   *store.Queue // por prioridad desc., FIFO dentro de cada nivel
+	modes   map[string]*gameMode
+	parties map[string]*party
+	banned  map[string]string // playerID → motivo del ban
+
+	lobbyReady map[string]time.Time // modo de sala → cuándo reunió el mínimo
+	// salas privadas sin arrancar, por código (private_lobbies.go)
+	privateLobbies map[string]*privateLobby
+	// revanchas pendientes, por la partida terminada (rematch.go)
+	rematches map[string]*rematchOffer
+
+	matches    *store.Map[*activeMatch] // partidas en curso por MatchID
+	history    []*matchResult           // partidas terminadas, en orden de cierre
+	historySeq uint64                   // secuencia de la última partida registrada
+	stats      map[string]*playerStats  // acumulados por jugador (leaderboard.go)
+	vc         *clocks.Vector
+
+	subs   map[string][]chan *pb.MatchUpdate // playerID → streams suscritos
+	events []playerEvent                     // log de notificaciones, en orden
+
+	removed []removedServer // retirados del pool, el más reciente al final
+
+	// actualizaciones de servidor en orden causal (server_causal.go)
+	serverUpdates *clocks.Causal
+
+	// candados por jugador de las RPC con m.mu en lectura (locking.go)
+	playerLocks *store.Locks
+}
+
+// playerEvent es una notificación ya emitida, guardada para reenviarla a un
+// stream que se reconecta con token de reanudación.
+type playerEvent struct {
+	PlayerID string
+	Update   *pb.MatchUpdate
+}
+
 type matchmaker struct {
 	pb.UnimplementedMatchmakerServer
 
 	selfID string // para el reloj
-
-	mu      sync.RWMutex
-	players map[string]*playerInfo
-	servers map[string]*gameServerInfo
-	queue   []string // FIFO de IDs de jugador
-
-	matches map[string][]string // MatchID → playerIDs
-	vc      vectorClock
+	epoch  uint64 // esta ejecución, ante los GameServers (server_epoch.go)
+
+	mu         sync.RWMutex
+	namespaces map[string]*namespace
+	vcStats    clockMetrics
+	matchIDs   *idgen.Generator
+	auditOn    bool // verifica invariantes tras cada mutación (audit.go)
+	replica    replicaState
+	metrics    *mmMetrics
+	dialCreds  credentials.TransportCredentials // hacia GameServers y respaldo
+	health     *health.Server                   // grpc.health.v1, para el healthcheck
+	auth       *authtoken.Signer                // tokens de jugador; nil = PLAYER_AUTH=off
+	queueLimit *ratelimit.Limiter               // QueuePlayer (rate_limit.go); nil = sin límite
+	scaler     *autoscaler                      // SCALE_PROVISIONER (autoscale.go); nil = sin escalado
+	shards     sharding                         // MATCHMAKER_MODES y SHARD_ROUTES (sharding.go)
+	chaos      *chaos.Injector                  // fallos inyectados (chaos.go); sin fallos por defecto
+	exit       func(code int)                   // os.Exit; las pruebas de caídas lo reemplazan
+
+	// los rechazos también salen como errores gRPC (RPC_STATUS_ERRORS, ver
+	// internal/middleware)
+	statusErrors bool
+
+	// parámetros recargables en caliente; se leen con m.mu (config_reload.go)
+	tunables
+	configPath   string            // MATCHMAKER_CONFIG; "" = sólo entorno
+	configValues map[string]string // valores vigentes, para informar cambios
+
+	// hacia GameServers; los tests lo reemplazan por una red en memoria
+	dialer func(context.Context, string) (net.Conn, error)
+
+	// "ns/serverID" → órdenes para su stream Heartbeat (heartbeat.go)
+	controls map[string]chan *pb.ServerControl
+
+	wall         walltime.Clock           // hora de pared; virtual en pruebas
+	queueSamples map[string][]queueSample // namespace → muestras de la cola
+	arrivals     map[string][]arrival     // namespace → encolados, para el simulador
+	arrivalsMu   sync.Mutex               // arrivals, con m.mu en lectura (locking.go)
+	sloSamples   map[sloKey][]sloSample   // esperas de los jugadores emparejados
+	sloStates    map[sloKey]*sloState     // última evaluación de cada SLO
+	eventLog     eventLog                 // transiciones de estado (event_log.go)
+	adminAudit   adminAuditLog            // acciones de administración (admin_audit.go)
+
+	// última sesión de cada jugador (player_sessions.go)
+	sessions   map[sessionKey]*playerSession
+	sessionsMu sync.Mutex // sessions, con m.mu en lectura (locking.go)
+	// partidas retransmitidas a suscriptores (match_relay.go)
+	relays map[relayKey]*matchRelay
+	// dirección → servidores registrados con ella en cualquier namespace
+	// (server_addr.go)
+	serverAddrs map[string][]serverRef
 
 	// canal interno para cerrar goroutines
 	done chan struct{}
+	// despierta el bucle de emparejamiento (event_matching.go)
+	wake chan struct{}
+	// se cierra al empezar el apagado ordenado (shutdown.go)
+	draining  chan struct{}
+	assigning int            // AssignMatch en curso; se lee con m.mu
+	inflight  map[string]int // "ns/matchID" → AssignMatch en curso (session_guarantees.go)
 }
 
 /*───────────────────────────────────────────────────────────────────────────────
@@ -139,14 +359,54 @@ type matchmaker struct {
 ───────────────────────────────────────────────────────────────────────────────*/
 
 func newMatchmaker(selfID string) *matchmaker {
-	return &matchmaker{
-		selfID:  selfID,
-		players: make(map[string]*playerInfo),
-		servers: make(map[string]*gameServerInfo),
-		queue:   []string{},
-		matches: make(map[string][]string),
-		vc:      make(vectorClock),
-		done:    make(chan struct{}),
+	m := &matchmaker{
+		selfID:       selfID,
+		epoch:        newEpoch(),
+		namespaces:   make(map[string]*namespace),
+		controls:     make(map[string]chan *pb.ServerControl),
+		wall:         walltime.Real,
+		queueSamples: make(map[string][]queueSample),
+		arrivals:     make(map[string][]arrival),
+		sloSamples:   make(map[sloKey][]sloSample),
+		sloStates:    make(map[sloKey]*sloState),
+		sessions:     make(map[sessionKey]*playerSession),
+		relays:       make(map[relayKey]*matchRelay),
+		serverAddrs:  make(map[string][]serverRef),
+		inflight:     make(map[string]int),
+		tunables:     defaultTunables(),
+		dialCreds:    insecure.NewCredentials(),
+		health:       health.NewServer(),
+		chaos:        chaos.New(chaos.Config{}),
+		exit:         os.Exit,
+		done:         make(chan struct{}),
+		wake:         make(chan struct{}, 1),
+		draining:     make(chan struct{}),
+	}
+	m.matchIDs = idgen.New(selfID, func() time.Time { return m.wall.Now() })
+	m.metrics = newMMMetrics(m)
+	return m
+}
+
+func newNamespace(name string) *namespace {
+	return &namespace{
+		name:    name,
+		players: new(store.Map[*playerInfo]),
+		servers: new(store.Map[*gameServerInfo]),
+		queue:   new(store.Queue),
+		modes:   defaultGameModes(),
+		parties: make(map[string]*party),
+		banned:  make(map[string]string),
+
+		lobbyReady:     make(map[string]time.Time),
+		privateLobbies: make(map[string]*privateLobby),
+		rematches:      make(map[string]*rematchOffer),
+		matches:        new(store.Map[*activeMatch]),
+		stats:          make(map[string]*playerStats),
+		vc:             clocks.New(),
+		subs:           make(map[string][]chan *pb.MatchUpdate),
+
+		serverUpdates: clocks.NewCausal(serverUpdateMaxDelay),
+		playerLocks:   new(store.Locks),
 	}
 }
 
@@ -154,9 +414,34 @@ func newMatchmaker(selfID string) *matchmaker {
                          Métodos auxiliares protegidos
 ───────────────────────────────────────────────────────────────────────────────*/
 
-// debe llamarse con m.mu bloqueado
+// ns devuelve (creándolo si no existe) el namespace pedido.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) ns(name string) *namespace {
+	if name == "" {
+		name = defaultNamespace
+	}
+	n, ok := m.namespaces[name]
+	if !ok {
+		n = newNamespace(name)
+		m.namespaces[name] = n
+		m.logf("Namespace %q creado", name)
+	}
+	return n
+}
+
+// lookupNS es la variante de sólo lectura de ns: no crea namespaces.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) lookupNS(name string) *namespace {
+	if name == "" {
+		name = defaultNamespace
+	}
+	return m.namespaces[name]
+}
+
+// nextMatchID emite un MatchID creciente con el ID del Matchmaker como
+// prefijo y la hora de creación codificada (internal/idgen).
 func (m *matchmaker) nextMatchID() string {
-	return fmt.Sprintf("M%08x", rand.Int31())
+	return m.matchIDs.Next()
 }
 
 func (m *matchmaker) logf(format string, args ...interface{}) {
@@ -164,89 +449,238 @@ func (m *matchmaker) logf(format string, args ...interface{}) {
 	log.Printf(prefix+format, args...)
 }
 
+// logClockf agrega al final de la línea el reloj del namespace, con la marca
+// de slog.WithClock, para ordenarla con cmd/logorder junto a los logs de
+// jugadores y servidores.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) logClockf(ns *namespace, format string, args ...interface{}) {
+	m.logf(format+" %s[%s]", append(args, slog.ClockField, ns.vc)...)
+}
+
 /*───────────────────────────────────────────────────────────────────────────────
                        Tarea de emparejamiento periódica
 ───────────────────────────────────────────────────────────────────────────────*/
 
 func (m *matchmaker) runMatchLoop() {
-	ticker := time.NewTicker(matchCheckPeriod)
-	defer ticker.Stop()
+	period := m.matchCheckPeriod()
+	ticker := m.wall.NewTicker(period)
+	defer func() { ticker.Stop() }()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
+			m.metrics.matchRuns.With(triggerTick).Inc()
 			m.tryCreateMatch()
 			m.detectServerTimeouts()
+			now := m.wall.Now()
+			m.sweepOrphanMatches(now)
+			m.expireQueue(now)
+			m.expirePlayerHeartbeats(now)
+			m.pruneSessions(now)
+			m.pruneRelays()
+			m.expireReadyChecks(now)
+			m.expireRematches(now)
+			m.flushServerUpdates(now)
+			m.evaluateSLOs(now)
+			m.pruneClocks(now)
+			m.autoscale(now)
+			// AdminReloadConfig pudo cambiar el período
+			if p := m.matchCheckPeriod(); p != period {
+				ticker.Stop()
+				period, ticker = p, m.wall.NewTicker(p)
+			}
+		case <-m.wake:
+			m.metrics.matchRuns.With(triggerEvent).Inc()
+			m.tryCreateMatch()
 		case <-m.done:
 			return
 		}
 	}
 }
 
-// intenta formar partidas (actualmente sólo 1v1)
+// intenta formar partidas en cada namespace
 func (m *matchmaker) tryCreateMatch() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	defer m.audit("tryCreateMatch")
 
-	for len(m.queue) >= 2 && m.availableServerCount() > 0 {
-		// elige servidor disponible
-		var srv *gameServerInfo
-		for _, s := range m.servers {
-			if s.Status == serverAvailable {
-				srv = s
-				break
-			}
+	if m.isDraining() {
+		return // el apagado traspasa la cola tal como está
+	}
+	for _, ns := range m.namespaces {
+		m.createMatches(ns)
+	}
+}
+
+// forma partidas de cada modo habilitado dentro de un namespace; antes
+// completa las salas en curso que pidieron relleno
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) createMatches(ns *namespace) {
+	now := m.wall.Now()
+	ns.sortQueue(m.policy, now)
+	// las salas privadas llenas van primero: ya eligieron servidor
+	m.startPrivateLobbies(ns)
+	m.startRematches(ns)
+	for _, mode := range ns.sortedModes() {
+		if !mode.Enabled {
+			continue
 		}
-		if srv == nil {
-			return // no disponible
+		m.backfill(ns, mode)
+		for ns.availableServerCount() > 0 {
+			var players []string
+			relax := relaxNone
+			if mode.isLobby() {
+				players = ns.takeLobby(mode, now)
+			} else {
+				players, relax = ns.takeQueued(mode, m.matchPolicy, m.policy, now)
+			}
+			if players == nil {
+				break // no hay suficientes jugadores de este modo
+			}
+			if relax != relaxNone {
+				m.metrics.matchesRelaxed.With(ns.name, mode.Name, relax.stage()).Inc()
+				m.logf("[%s] Partida de %s con restricciones relajadas (%s) por la espera de %v", ns.name, mode.Name, relax.stage(), players)
+			}
+			m.startMatch(ns, mode, players)
 		}
+	}
+}
 
-		// extrae jugadores
-		p1ID := m.queue[0]
-		p2ID := m.queue[1]
-		m.queue = m.queue[2:]
+// startMatch asigna players a un servidor disponible.
+// debe llamarse con m.mu bloqueado y con al menos un servidor disponible
+func (m *matchmaker) startMatch(ns *namespace, mode *gameMode, players []string) {
+	// elige el servidor más cercano a los jugadores
+	srv := ns.pickServer(players)
 
-		p1 := m.players[p1ID]
-		p2 := m.players[p2ID]
+	now := m.wall.Now()
+	for _, pid := range players {
+		p := ns.players.Value(pid)
+		m.recordQueueWait(ns, mode.Name, now.Sub(p.LastOp), now)
+		ns.recordWait(pid, now.Sub(p.LastOp))
+	}
+	matchID, am := m.openMatch(ns, mode, players, srv)
 
-		matchID := m.nextMatchID()
+	// con confirmación previa el servidor queda reservado hasta que todos
+	// acepten (ready_check.go)
+	if m.readyTimeout > 0 {
+		m.openReadyCheck(ns, matchID, am, now)
+		return
+	}
+	// avisa a los jugadores suscritos e intenta asignar al servidor
+	m.launchMatch(ns, matchID, am, srv, mode.toProto())
+}
 
-		// actualiza estado local
-		p1.Status, p1.MatchID = playerInMatch, matchID
-		p2.Status, p2.MatchID = playerInMatch, matchID
-		srv.Status, srv.CurrentMatch = serverBusy, matchID
-		m.matches[matchID] = []string{p1ID, p2ID}
+// openMatch registra la partida de players en srv y los pasa a IN_MATCH;
+// el llamador la lanza.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) openMatch(ns *namespace, mode *gameMode, players []string, srv *gameServerInfo) (string, *activeMatch) {
+	matchID := m.nextMatchID()
+	now := m.wall.Now()
 
-		// reloj vectorial
-		m.vc.increment(m.selfID)
+	timeline := ns.queuedEvents(players)
 
-		// intenta asignar al servidor
-		go m.dispatchAssignMatch(srv, matchID, []string{p1ID, p2ID}, m.vc.clone())
-		m.logf("Asignando match %s a server %s (%s) con jugadores %s & %s", matchID, srv.ID, srv.Address, p1ID, p2ID)
+	// actualiza estado local
+	for _, pid := range players {
+		p := ns.players.Value(pid)
+		p.Status, p.MatchID = playerInMatch, matchID
+	}
+	am := &activeMatch{
+		Players:   players,
+		ServerID:  srv.ID,
+		Mode:      mode.Name,
+		StartedAt: now,
+	}
+	am.PhaseAt[phaseCreated] = now
+	am.Trace = m.traceMatch(ns, matchID, mode.Name, srv, players)
+	ns.matches.Put(matchID, am)
+	if ns.freeSlots(srv) == 0 {
+		srv.Status = serverBusy
 	}
+
+	// reloj vectorial
+	ns.vc.Tick(m.selfID)
+	am.Timeline = append(timeline, m.newEvent(ns, pb.MatchEvent_MATCHED, srv.ID))
+	m.metrics.matchesCreated.With(ns.name, mode.Name).Inc()
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_MATCH_CREATED, PlayerIds: players, MatchId: matchID, ServerId: srv.ID, Detail: mode.Name})
+	return matchID, am
 }
 
-func (m *matchmaker) availableServerCount() int {
+func (ns *namespace) availableServerCount() int {
 	c := 0
-	for _, s := range m.servers {
-		if s.Status == serverAvailable {
+	for _, s := range ns.servers.All() {
+		if ns.assignable(s) {
 			c++
 		}
 	}
 	return c
 }
 
+// un servidor en drenaje nunca recibe partidas nuevas
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) assignable(s *gameServerInfo) bool {
+	up := s.Status == serverAvailable || s.Status == serverBusy
+	return up && !s.Draining && ns.freeSlots(s) > 0
+}
+
+// serverMatches lista, ordenadas, las partidas en curso del servidor.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) serverMatches(serverID string) []string {
+	var ids []string
+	for id, am := range ns.matches.All() {
+		if am.ServerID == serverID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// freeSlots descuenta de la capacidad las partidas que el Matchmaker ya le
+// asignó; lo que informó el servidor acota el resultado, porque puede haber
+// asignaciones que todavía no refleja.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) freeSlots(s *gameServerInfo) int {
+	free := s.Capacity - len(ns.serverMatches(s.ID))
+	if s.FreeSlots < free {
+		free = s.FreeSlots
+	}
+	if free < 0 {
+		return 0
+	}
+	return free
+}
+
+// releaseServer deshace el OCUPADO que startMatch deduce al llenar el
+// servidor cuando el cierre de una partida le devuelve un hueco; si el
+// propio servidor se declaró sin huecos, sigue OCUPADO hasta que informe.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) releaseServer(serverID string) {
+	if s, ok := ns.servers.Get(serverID); ok && s.Status == serverBusy && ns.freeSlots(s) > 0 {
+		s.Status = serverAvailable
+	}
+}
+
 // heartbeat/tiempo máximo para servidor busy
 func (m *matchmaker) detectServerTimeouts() {
-	now := time.Now()
-	for _, srv := range m.servers {
-		if srv.Status == serverDown {
-			continue
-		}
-		if now.Sub(srv.LastHB) > serverHeartbeatTimeout {
-			m.logf("Server %s marcado DOWN por timeout de heartbeat", srv.ID)
-			srv.Status = serverDown
-			m.vc.increment(m.selfID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("detectServerTimeouts")
+
+	now := m.wall.Now()
+	for _, ns := range m.namespaces {
+		for _, srv := range ns.servers.All() {
+			if srv.Status == serverDown {
+				continue
+			}
+			if now.Sub(srv.LastHB) > m.heartbeatTimeout {
+				m.logf("[%s] Server %s marcado DOWN por timeout de heartbeat", ns.name, srv.ID)
+				srv.Status = serverDown
+				ns.vc.Tick(m.selfID)
+				m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_SERVER_DOWN, ServerId: srv.ID, Detail: "timeout de heartbeat"})
+				if srv.Draining {
+					m.retireServer(ns, srv)
+				}
+			}
 		}
 	}
 }
@@ -258,83 +692,452 @@ func (m *matchmaker) detectServerTimeouts() {
 func (m *matchmaker) QueuePlayer(ctx context.Context, req *pb.PlayerInfoRequest) (*pb.QueuePlayerResponse, error) {
 	playerID := req.GetPlayerId()
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	// m.mu en lectura y el grupo del jugador bloqueado (locking.go)
+	ns, unlock := m.rlockNS(req.GetNamespace())
+	defer unlock()
 
-	m.vc.merge(vcFromProto(req.GetClock()))
-	m.vc.increment(m.selfID)
+	if m.isDraining() {
+		return nil, errDraining
+	}
+	m.mergeTick(ns, req.GetClock())
 
-	pi, ok := m.players[playerID]
-	if !ok {
-		pi = &playerInfo{ID: playerID, VC: make(vectorClock)}
-		m.players[playerID] = pi
+	modeName := req.GetGameMode()
+	if modeName == "" {
+		modeName = defaultGameMode
+	}
+	if !m.shards.ownsMode(modeName) {
+		return m.wrongShard(ns, modeName), nil
+	}
+	mode, ok := ns.modes[modeName]
+	if !ok || !mode.Enabled {
+		return &pb.QueuePlayerResponse{
+			StatusCode:  pb.QueuePlayerResponse_UNKNOWN_MODE,
+			Message:     fmt.Sprintf("Modo %q no disponible (ver ListGameModes)", modeName),
+			VectorClock: m.clockProto(ns),
+		}, nil
 	}
 
+	defer ns.playerLocks.Lock(ns.partyLockSet(playerID)...)()
+	pi, _ := ns.players.GetOrCreate(playerID, func() *playerInfo {
+		return &playerInfo{ID: playerID, VC: clocks.New()}
+	})
+	pi.sawClock(req.GetClock(), m.wall.Now())
+
 	switch pi.Status {
 	case playerInQueue:
 		return &pb.QueuePlayerResponse{
 			StatusCode:  pb.QueuePlayerResponse_ALREADY_IN_QUEUE,
 			Message:     "Ya en cola",
-			VectorClock: m.vc.toProto(),
+			VectorClock: m.clockProto(ns),
 		}, nil
 	case playerInMatch:
 		return &pb.QueuePlayerResponse{
 			StatusCode:  pb.QueuePlayerResponse_IN_MATCH,
 			Message:     "Actualmente en partida",
-			VectorClock: m.vc.toProto(),
+			VectorClock: m.clockProto(ns),
 		}, nil
 	}
 
-	// lo encolamos
-	pi.Status = playerInQueue
-	pi.MatchID = ""
-	pi.LastOp = time.Now()
-	m.queue = append(m.queue, playerID)
+	// un grupo se encola completo, y sólo a pedido del líder
+	members := []string{playerID}
+	if pt, ok := ns.parties[pi.PartyID]; ok {
+		reject := func(code pb.QueuePlayerResponse_StatusCode, msg string) (*pb.QueuePlayerResponse, error) {
+			return &pb.QueuePlayerResponse{StatusCode: code, Message: msg, VectorClock: m.clockProto(ns)}, nil
+		}
+		switch {
+		case pt.Leader != playerID:
+			return reject(pb.QueuePlayerResponse_NOT_PARTY_LEADER, fmt.Sprintf("Sólo el líder (%s) puede encolar al grupo", pt.Leader))
+		case len(pt.Members) > mode.maxPartySize():
+			return reject(pb.QueuePlayerResponse_PARTY_TOO_LARGE, fmt.Sprintf("El grupo tiene %d jugadores y %s admite hasta %d", len(pt.Members), modeName, mode.maxPartySize()))
+		case !ns.partyIdle(pt):
+			return reject(pb.QueuePlayerResponse_PARTY_NOT_READY, "Algún integrante del grupo está en cola o en partida")
+		}
+		members = pt.Members
+	}
+	if pid := ns.lobbyIn(members); pid != "" {
+		return &pb.QueuePlayerResponse{
+			StatusCode:  pb.QueuePlayerResponse_IN_LOBBY,
+			Message:     fmt.Sprintf("El jugador %s espera en la sala privada %s; sal de ella para encolarte", pid, ns.players.Value(pid).LobbyCode),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	if pid := ns.bannedIn(members); pid != "" {
+		return &pb.QueuePlayerResponse{
+			StatusCode:  pb.QueuePlayerResponse_BANNED,
+			Message:     fmt.Sprintf("El jugador %s está baneado: %s", pid, ns.banned[pid]),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	if pid, left := ns.cooldownIn(members, m.wall.Now()); pid != "" {
+		return &pb.QueuePlayerResponse{
+			StatusCode:          pb.QueuePlayerResponse_COOLDOWN,
+			Message:             fmt.Sprintf("El jugador %s acaba de jugar; podrá encolarse en %v", pid, left.Round(time.Second)),
+			VectorClock:         m.clockProto(ns),
+			CooldownRemainingMs: left.Milliseconds(),
+		}, nil
+	}
 
-	m.logf("Jugador %s encolado", playerID)
+	// lo encolamos; PRIORITY_REQUEUED queda reservada al Matchmaker, salvo
+	// para quien recupera su lugar tras un fallo de asignación
+	prio := queuePriorityFromProto(req.GetPriority())
+	if prio == priorityRequeued {
+		prio = priorityNormal
+	}
+	front := ns.takeRequeueOffer(pi, members, modeName, m.wall.Now())
+	if front {
+		prio = priorityRequeued
+	}
+	region := normalizeRegion(req.GetRegion())
+	for _, pid := range members {
+		p, ok := ns.players.Get(pid)
+		if !ok {
+			continue
+		}
+		p.Status = playerInQueue
+		p.Mode = modeName
+		p.Priority = prio
+		p.Region = region
+		p.MatchID = ""
+		p.LastOp = m.wall.Now()
+		p.QueuedVC = ns.vc.Copy()
+		p.QueueSpan = tracing.SpanContextFromContext(ctx)
+		p.QueueDeadline, p.QueueTimedOut = m.queueDeadline(p.LastOp), false
+		p.OnAssignFailure = req.GetOnAssignFailure()
+		ns.enqueue(pid, front)
+	}
+	m.recordArrival(ns, modeName, len(members), m.wall.Now())
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_QUEUED, PlayerIds: members, Detail: modeName})
+	m.wakeMatcher()
+
+	if len(members) > 1 {
+		m.logClockf(ns, "Grupo %s encolado %v (%s, %s, región %q)", pi.PartyID, members, modeName, prio.toProto(), region)
+	} else {
+		m.logClockf(ns, "Jugador %s encolado (%s, %s, región %q)", playerID, modeName, prio.toProto(), region)
+	}
+	msg := "Encolado correctamente"
+	if front {
+		msg = "Encolado al frente: recuperaste tu lugar tras el fallo de asignación"
+	}
 	return &pb.QueuePlayerResponse{
 		StatusCode:  pb.QueuePlayerResponse_OK,
-		Message:     "Encolado correctamente",
-		VectorClock: m.vc.toProto(),
+		Message:     msg,
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                 RPC: CancelQueue – jugador abandona la cola
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) CancelQueue(ctx context.Context, req *pb.CancelQueueRequest) (*pb.CancelQueueResponse, error) {
+	playerID := req.GetPlayerId()
+
+	// m.mu en lectura y el grupo del jugador bloqueado (locking.go)
+	ns, unlock := m.rlockNS(req.GetNamespace())
+	defer unlock()
+	defer ns.playerLocks.Lock(ns.partyLockSet(playerID)...)()
+
+	m.mergeTick(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok || pi.Status != playerInQueue {
+		return &pb.CancelQueueResponse{
+			StatusCode:  pb.CancelQueueResponse_NOT_IN_QUEUE,
+			Message:     "No estás en cola",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	// cualquier integrante de un grupo saca al grupo entero
+	members := []string{playerID}
+	if pt, ok := ns.parties[pi.PartyID]; ok {
+		members = pt.Members
+	}
+	for _, pid := range members {
+		if p, ok := ns.players.Get(pid); ok && p.Status == playerInQueue {
+			ns.removeFromQueue(pid)
+			p.Status = playerIdle
+			p.LastOp = m.wall.Now()
+		}
+	}
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_DEQUEUED, PlayerIds: members, Detail: "cancelada por el jugador"})
+
+	m.logf("Jugador %s abandonó la cola (junto a %v)", playerID, members)
+	return &pb.CancelQueueResponse{
+		StatusCode:  pb.CancelQueueResponse_OK,
+		Message:     "Saliste de la cola",
+		VectorClock: m.clockProto(ns),
 	}, nil
 }
 
+// enqueue inserta al jugador al final de su nivel de prioridad o, con
+// front, al principio de él, con su modo y prioridad actuales.
+// debe llamarse con m.mu bloqueado y, si es en lectura, con el jugador
+// bloqueado (locking.go)
+func (ns *namespace) enqueue(playerID string, front bool) {
+	ns.queue.Insert(ns.queueEntry(playerID), front)
+}
+
+// queueEntry es el lugar en la cola del jugador con su modo y prioridad.
+// debe llamarse con m.mu bloqueado y, si es en lectura, con el jugador
+// bloqueado (locking.go)
+func (ns *namespace) queueEntry(playerID string) store.Entry {
+	e := store.Entry{ID: playerID, Priority: int(priorityNormal)}
+	if p, ok := ns.players.Get(playerID); ok {
+		e.Mode, e.Priority = p.Mode, int(p.Priority)
+	}
+	return e
+}
+
+// debe llamarse con m.mu bloqueado y, si es en lectura, con el jugador
+// bloqueado (locking.go)
+func (ns *namespace) removeFromQueue(playerID string) {
+	ns.queue.Remove(playerID)
+}
+
 /*───────────────────────────────────────────────────────────────────────────────
                      RPC: GetPlayerStatus – estado jugador
 ───────────────────────────────────────────────────────────────────────────────*/
 
 func (m *matchmaker) GetPlayerStatus(ctx context.Context, req *pb.PlayerStatusRequest) (*pb.PlayerStatusResponse, error) {
 	playerID := req.GetPlayerId()
+	level := consistencyLevel(req.GetConsistency())
+
+	// si el estado ya cumple el nivel pedido, basta m.mu en lectura y el
+	// jugador bloqueado (locking.go); si hay que esperar, m.mu en escritura
+	ns, unlock := m.rlockNS(req.GetNamespace())
+	unlockPlayer := ns.playerLocks.Lock(playerID)
+	if m.statusSettled(ns, playerID, level, req.GetClock()) {
+		defer unlock()
+		defer unlockPlayer()
+		return m.playerStatus(ns, req, level, level), nil
+	}
+	unlockPlayer()
+	unlock()
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.vc.merge(vcFromProto(req.GetClock()))
-	pi, ok := m.players[playerID]
+	// niveles de consistencia: ver session_guarantees.go
+	ns = m.ns(req.GetNamespace())
+	honored := pb.Consistency_CONSISTENCY_EVENTUAL
+	if level != pb.Consistency_CONSISTENCY_EVENTUAL {
+		// Read-Your-Writes: el estado debe reflejar lo que el cliente ya vio
+		want := clocks.FromProto(req.GetClock()).Get(m.selfID)
+		var caughtUp bool
+		if ns, caughtUp = m.awaitClock(ctx, req.GetNamespace(), want); !caughtUp {
+			return m.retryLater(ns, playerID, want), nil
+		}
+		honored = pb.Consistency_CONSISTENCY_READ_YOUR_WRITES
+	}
+	if level == pb.Consistency_CONSISTENCY_STRONG {
+		var settled bool
+		if ns, settled = m.awaitAssign(ctx, req.GetNamespace(), playerID); settled {
+			honored = pb.Consistency_CONSISTENCY_STRONG
+		}
+	}
+	return m.playerStatus(ns, req, level, honored), nil
+}
+
+// playerStatus arma la respuesta de GetPlayerStatus con el nivel honored.
+// debe llamarse con m.mu bloqueado en escritura, o en lectura con el
+// jugador bloqueado (locking.go)
+func (m *matchmaker) playerStatus(ns *namespace, req *pb.PlayerStatusRequest, level, honored pb.Consistency) *pb.PlayerStatusResponse {
+	playerID := req.GetPlayerId()
+	m.metrics.statusReads.With(ns.name, consistencyLabel(level), consistencyLabel(honored)).Inc()
+	m.mergeClock(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+	pi, ok := ns.players.Get(playerID)
 	if !ok {
 		return &pb.PlayerStatusResponse{
 			Status:      "UNKNOWN",
-			VectorClock: m.vc.toProto(),
-		}, nil
+			VectorClock: m.clockProto(ns),
+			Consistency: honored,
+		}
 	}
 
-	var statusStr string
+	res := &pb.PlayerStatusResponse{
+		Status:      ns.statusName(pi),
+		MatchId:     pi.MatchID,
+		ServerAddr:  ns.serverAddrForMatch(pi.MatchID),
+		MatchAddr:   ns.matchAddrFor(pi.MatchID),
+		VectorClock: m.clockProto(ns),
+		Consistency: honored,
+	}
 	switch pi.Status {
-	case playerIdle:
-		statusStr = "IDLE"
 	case playerInQueue:
-		statusStr = "IN_QUEUE"
+		res.QueuePosition = ns.queuePosition(pi)
+		res.WaitMs = m.wall.Now().Sub(pi.LastOp).Milliseconds()
+		res.GameMode = pi.Mode
 	case playerInMatch:
-		statusStr = "IN_MATCH"
+		res.GameMode = pi.Mode
+	}
+	return res
+}
+
+// queuePosition devuelve el lugar de p entre los encolados de su modo (1 =
+// el próximo), en el orden en que los dejó la última vuelta del
+// emparejamiento; 0 si no está en la cola.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) queuePosition(p *playerInfo) int32 {
+	return int32(ns.queue.Position(p.ID))
+}
+
+// eventsSince devuelve los eventos del jugador que no son anteriores ni
+// iguales al reloj since (el del último evento que recibió).
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) eventsSince(playerID string, since *clocks.Vector) []*pb.MatchUpdate {
+	var out []*pb.MatchUpdate
+	for _, ev := range ns.events {
+		if ev.PlayerID != playerID {
+			continue
+		}
+		if vc := clocks.FromProto(ev.Update.GetVectorClock()); vc.HappensBefore(since) || vc.Equal(since) {
+			continue
+		}
+		out = append(out, ev.Update)
+	}
+	return out
+}
+
+// serverAddrForMatch devuelve la dirección del servidor que juega matchID.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) serverAddrForMatch(matchID string) string {
+	am, ok := ns.matches.Get(matchID)
+	if !ok {
+		return ""
+	}
+	if s, ok := ns.servers.Get(am.ServerID); ok {
+		return s.Address
 	}
+	return ""
+}
 
-	return &pb.PlayerStatusResponse{
-		Status:      statusStr,
+/*───────────────────────────────────────────────────────────────────────────────
+        RPC: ResumeSession – cliente reiniciado recupera su sesión
+───────────────────────────────────────────────────────────────────────────────*/
+
+// ResumeSession no modifica estado: sólo devuelve lo que un cliente recién
+// reiniciado necesita para continuar (estado, partida, servidor y reloj).
+func (m *matchmaker) ResumeSession(ctx context.Context, req *pb.ResumeSessionRequest) (*pb.ResumeSessionResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.ResumeSessionResponse{Status: "UNKNOWN"}, nil
+	}
+	defer ns.playerLocks.Lock(playerID)()
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		return &pb.ResumeSessionResponse{
+			Status:      "UNKNOWN",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	m.logf("[%s] Jugador %s retoma su sesión (%s)", ns.name, playerID, pi.Status)
+	return &pb.ResumeSessionResponse{
+		Found:       true,
+		Status:      ns.statusName(pi),
 		MatchId:     pi.MatchID,
-		VectorClock: m.vc.toProto(),
+		ServerAddr:  ns.serverAddrForMatch(pi.MatchID),
+		MatchAddr:   ns.matchAddrFor(pi.MatchID),
+		GameMode:    pi.Mode,
+		VectorClock: m.clockProto(ns),
 	}, nil
 }
 
+/*───────────────────────────────────────────────────────────────────────────────
+         RPC: SubscribeMatchUpdates – notificaciones push al jugador
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) SubscribeMatchUpdates(req *pb.SubscribeRequest, stream pb.Matchmaker_SubscribeMatchUpdatesServer) error {
+	playerID := req.GetPlayerId()
+	ch := make(chan *pb.MatchUpdate, 8)
+	version, _ := clientProtocol(stream.Context())
+
+	// el registro del canal y la copia de eventos perdidos ocurren bajo el
+	// mismo lock: ningún evento se pierde ni se repite entre ambos
+	m.mu.Lock()
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+	ns.subs[playerID] = append(ns.subs[playerID], ch)
+	var missed []*pb.MatchUpdate
+	if req.GetResumeFrom() != nil {
+		missed = ns.eventsSince(playerID, clocks.FromProto(req.GetResumeFrom()))
+	}
+	m.mu.Unlock()
+
+	m.logf("[%s] Jugador %s suscrito a notificaciones (%d pendientes)", ns.name, playerID, len(missed))
+	defer m.unsubscribe(ns, playerID, ch)
+
+	send := func(upd *pb.MatchUpdate) error {
+		if upd = adaptUpdate(upd, version); upd == nil {
+			return nil
+		}
+		return stream.Send(upd)
+	}
+	for _, upd := range missed {
+		if err := send(upd); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case upd := <-ch:
+			if err := send(upd); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-m.done:
+			return nil
+		}
+	}
+}
+
+func (m *matchmaker) unsubscribe(ns *namespace, playerID string, ch chan *pb.MatchUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list := ns.subs[playerID]
+	for i, c := range list {
+		if c == ch {
+			ns.subs[playerID] = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(ns.subs[playerID]) == 0 {
+		delete(ns.subs, playerID)
+	}
+}
+
+// notify registra upd en el log de eventos y lo entrega a cada stream del
+// jugador sin bloquear: si un suscriptor no consume, la notificación se
+// descarta (puede recuperarla reconectando con resume_from).
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) notify(ns *namespace, playerID string, upd *pb.MatchUpdate) {
+	upd.VectorClock = m.clockProto(ns)
+	ns.events = append(ns.events, playerEvent{PlayerID: playerID, Update: upd})
+	if len(ns.events) > maxEventLog {
+		ns.events = ns.events[len(ns.events)-maxEventLog:]
+	}
+
+	for _, ch := range ns.subs[playerID] {
+		select {
+		case ch <- upd:
+		default:
+			m.logf("[%s] Notificación a %s descartada (suscriptor lento)", ns.name, playerID)
+		}
+	}
+}
+
 /*───────────────────────────────────────────────────────────────────────────────
           RPC: UpdateServerStatus – recibe heartbeats/registro servidor
 ───────────────────────────────────────────────────────────────────────────────*/
@@ -343,37 +1146,209 @@ func (m *matchmaker) UpdateServerStatus(ctx context.Context, req *pb.ServerStatu
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.vc.merge(vcFromProto(req.GetClock()))
-	m.vc.increment(m.selfID)
+	// La dirección se valida antes de tocar el namespace: un registro
+	// rechazado no lo crea ni avanza su reloj.
+	name := req.GetNamespace()
+	if name == "" {
+		name = defaultNamespace
+	}
+	sid := req.GetServerId()
+	addr, addrErr := resolveServerAddr(ctx, req.GetAddress())
+	if addrErr != nil && req.GetNewStatus() != pb.ServerStatus_CAIDO {
+		m.logf("[%s] Servidor %s rechazado: %v", name, sid, addrErr)
+		return m.rejectServerUpdate(name, pb.ServerStatusUpdateResponse_INVALID_ADDRESS, addrErr.Error()), nil
+	}
+	if owner := m.serverNamespace(addr, name); owner != "" && req.GetNewStatus() != pb.ServerStatus_CAIDO {
+		m.logf("[%s] Servidor %s rechazado: %s ya atiende al namespace %s", name, sid, addr, owner)
+		return m.rejectServerUpdate(name, pb.ServerStatusUpdateResponse_WRONG_NAMESPACE,
+			fmt.Sprintf("%s ya está registrado en el namespace %q", addr, owner)), nil
+	}
+
+	ns := m.ns(name)
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
 
+	ready, held := m.orderServerUpdate(ns, &serverUpdate{req: req, addr: addr, addrErr: addrErr})
+	if held != nil {
+		held.Epoch = m.epoch
+		return held, nil
+	}
+	var res *pb.ServerStatusUpdateResponse
+	for i, u := range ready {
+		if r := m.applyServerStatus(ns, u); i == 0 {
+			res = r // los demás ya recibieron su respuesta al quedar retenidos
+		}
+	}
+	res.Epoch = m.epoch
+	return res, nil
+}
+
+// applyServerStatus aplica una actualización ya ordenada (server_causal.go).
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) applyServerStatus(ns *namespace, u *serverUpdate) *pb.ServerStatusUpdateResponse {
+	req, addr, addrErr := u.req, u.addr, u.addrErr
 	sid := req.GetServerId()
-	srv, ok := m.servers[sid]
-	if !ok {
+	srv, ok := ns.servers.Get(sid)
+	prev := serverDown // uno nuevo cuenta como de vuelta
+	if ok {
+		prev = srv.Status
+	} else {
 		srv = &gameServerInfo{
 			ID: sid,
-			VC: make(vectorClock),
+			VC: clocks.New(),
 		}
-		m.servers[sid] = srv
+		ns.servers.Put(sid, srv)
+		ns.forgetRemoved(sid)
 	}
 
-	// actualiza campos
-	srv.Address = req.GetAddress()
-	srv.LastHB = time.Now()
+	// actualiza campos; un CAIDO con dirección inválida conserva la anterior
+	if addrErr == nil {
+		m.indexServerAddr(ns.name, sid, srv.Address, addr)
+		srv.Address = addr
+	}
+	srv.LastHB = m.wall.Now()
+	srv.sawClock(req.GetClock(), srv.LastHB)
+	srv.Region = normalizeRegion(req.GetRegion())
+	srv.LatencyMs = req.GetLatencyMs()
+	m.noteServerEpoch(ns, srv, req.GetEpoch())
+
+	srv.Status = serverStatusFromProto(req.GetNewStatus())
+	srv.Capacity, srv.FreeSlots = int(req.GetCapacity()), int(req.GetFreeSlots())
+	if srv.Capacity == 0 {
+		// GameServer sin soporte de capacidad: una partida a la vez
+		srv.Capacity, srv.FreeSlots = 1, 1
+		if srv.Status == serverBusy {
+			srv.FreeSlots = 0
+		}
+	}
 
-	switch req.GetNewStatus() {
-	case pb.ServerStatusUpdateRequest_AVAILABLE:
-		srv.Status = serverAvailable
-	case pb.ServerStatusUpdateRequest_BUSY:
-		srv.Status = serverBusy
-	case pb.ServerStatusUpdateRequest_DOWN:
-		srv.Status = serverDown
+	m.logf("Actualización de servidor %s (%s) → %s [%d/%d libres]", sid, srv.Address, req.GetNewStatus().String(), srv.FreeSlots, srv.Capacity)
+	m.logServerTransition(ns, srv, prev, "informado por el servidor")
+	if srv.Status != serverDown {
+		m.adoptRunningMatches(ns, srv, req.GetRunningMatches())
+		m.markStarted(ns, srv, req)
 	}
 
-	m.logf("Actualización de servidor %s → %s", sid, req.GetNewStatus().String())
+	// un servidor en drenaje retira cuando terminó todas sus partidas
+	if srv.Draining && (srv.Status == serverDown || len(ns.serverMatches(sid)) == 0) {
+		m.retireServer(ns, srv)
+		return &pb.ServerStatusUpdateResponse{
+			StatusCode:  pb.ServerStatusUpdateResponse_DRAINED,
+			Message:     "Servidor drenado y retirado del pool",
+			VectorClock: m.clockProto(ns),
+		}
+	}
 
+	if ns.assignable(srv) && ns.hasWaiting() {
+		m.wakeMatcher()
+	}
 	return &pb.ServerStatusUpdateResponse{
 		StatusCode:  pb.ServerStatusUpdateResponse_OK,
-		VectorClock: m.vc.toProto(),
+		VectorClock: m.clockProto(ns),
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+          RPC: ReportMatchResult – GameServer informa fin de partida
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) ReportMatchResult(ctx context.Context, req *pb.MatchResultRequest) (*pb.MatchResultResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.noteServerClock(ns, req.GetServerId(), req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	matchID := req.GetMatchId()
+	am, ok := ns.matches.Get(matchID)
+	if !ok {
+		m.logf("Resultado de partida desconocida %s (server %s) ignorado", matchID, req.GetServerId())
+		return &pb.MatchResultResponse{
+			StatusCode:  pb.MatchResultResponse_UNKNOWN_MATCH,
+			Message:     "Partida desconocida",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	// sólo el servidor que la tiene puede cerrarla, y sólo una vez enviada
+	switch {
+	case am.Phase == phaseCreated:
+		m.rejectMatchUpdate(ns, matchID, "result", fmt.Errorf("resultado de %s en fase %s", req.GetServerId(), am.Phase))
+		return &pb.MatchResultResponse{
+			StatusCode:  pb.MatchResultResponse_NOT_ASSIGNED,
+			Message:     "La partida aún no se asignó a ningún servidor",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	case am.ServerID != req.GetServerId():
+		m.rejectMatchUpdate(ns, matchID, "result", fmt.Errorf("resultado de %s, asignada a %s", req.GetServerId(), am.ServerID))
+		return &pb.MatchResultResponse{
+			StatusCode:  pb.MatchResultResponse_NOT_OWNER,
+			Message:     fmt.Sprintf("La partida está asignada a %s", am.ServerID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	res := &matchResult{
+		MatchID:    matchID,
+		ServerID:   req.GetServerId(),
+		Mode:       am.Mode,
+		Players:    am.Players,
+		WinnerID:   req.GetWinnerId(),
+		WinnerIDs:  req.GetWinnerIds(),
+		Outcome:    outcomeCompleted,
+		Duration:   time.Duration(req.GetDurationMs()) * time.Millisecond,
+		StartedAt:  am.StartedAt,
+		FinishedAt: m.wall.Now(),
+		VC:         ns.vc.Copy(),
+		Timeline:   append(am.Timeline, m.newEvent(ns, pb.MatchEvent_ENDED, outcomeCompleted)),
+	}
+	for _, st := range req.GetPlayerStats() {
+		res.Stats = append(res.Stats, playerMatchStats{
+			PlayerID: st.GetPlayerId(),
+			Score:    int(st.GetScore()),
+			Kills:    int(st.GetKills()),
+			Deaths:   int(st.GetDeaths()),
+		})
+	}
+	res.Events = scoreEventsFromProto(req.GetEvents())
+	m.recordMatch(ns, res)
+
+	// cierra la partida: jugadores vuelven a IDLE, con la espera del modo
+	m.closeMatch(ns, matchID, am, phaseCompleted)
+	if ns.hasWaiting() {
+		m.wakeMatcher()
+	}
+	var rematchMs int64
+	if deadline := m.offerRematch(ns, matchID, am); !deadline.IsZero() {
+		rematchMs = deadline.UnixMilli()
+	}
+	ns.releaseServer(am.ServerID)
+	var cooldown time.Duration
+	if g, ok := ns.modes[am.Mode]; ok {
+		cooldown = time.Duration(g.Cooldown) * time.Second
+	}
+	for _, pid := range am.Players {
+		if p, ok := ns.players.Get(pid); ok && p.MatchID == matchID {
+			p.Status, p.MatchID = playerIdle, ""
+			p.LastOp = m.wall.Now()
+			if cooldown > 0 {
+				p.CooldownUntil = p.LastOp.Add(cooldown)
+			}
+		}
+		m.notify(ns, pid, &pb.MatchUpdate{
+			Event:                 pb.MatchUpdate_MATCH_FINISHED,
+			MatchId:               matchID,
+			WinnerId:              res.WinnerID,
+			WinnerIds:             res.WinnerIDs,
+			RematchDeadlineUnixMs: rematchMs,
+		})
+	}
+	m.logClockf(ns, "Partida %s finalizada en %v; ganador %s (%d eliminaciones)", matchID, res.Duration, res.winnerLabel(), len(res.Events))
+	return &pb.MatchResultResponse{
+		StatusCode:  pb.MatchResultResponse_OK,
+		Message:     "Resultado registrado",
+		VectorClock: m.clockProto(ns),
 	}, nil
 }
 
@@ -381,45 +1356,106 @@ func (m *matchmaker) UpdateServerStatus(ctx context.Context, req *pb.ServerStatu
                      RPC: AdminGetSystemStatus – vista global
 ───────────────────────────────────────────────────────────────────────────────*/
 
-func (m *matchmaker) AdminGetSystemStatus(ctx context.Context, _ *pb.AdminRequest) (*pb.SystemStatusResponse, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+func (m *matchmaker) AdminGetSystemStatus(ctx context.Context, req *pb.AdminRequest) (*pb.SystemStatusResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.namespaces))
+	for name := range m.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		// namespace sin actividad: vista vacía
+		return &pb.SystemStatusResponse{
+			Namespace:      req.GetNamespace(),
+			Namespaces:     names,
+			QueueRateLimit: m.rateLimitProto(),
+			MatchmakerId:   m.selfID,
+			OwnedModes:     m.shards.ownedModes(),
+		}, nil
+	}
 
-	var serverStates []*pb.ServerState
-	for _, s := range m.servers {
-		serverStates = append(serverStates, &pb.ServerState{
-			ServerId:      s.ID,
-			Status:        serverStatusProto(s.Status),
-			Address:       s.Address,
-			CurrentMatch:  s.CurrentMatch,
-			LastHeartbeat: s.LastHB.Unix(),
+	formed := ns.formedSince(m.wall.Now().Add(-m.statusWindow))
+	recent := make(map[string]int32)
+	for _, f := range formed {
+		recent[f.ServerID]++
+	}
+
+	var serverStates []*pb.ServerInfo
+	for _, s := range ns.servers.All() {
+		serverStates = append(serverStates, &pb.ServerInfo{
+			ServerId:       s.ID,
+			Status:         serverStatusProto(s.Status),
+			Address:        s.Address,
+			CurrentMatchId: strings.Join(ns.serverMatches(s.ID), ","),
+			LastHeartbeat:  s.LastHB.Unix(),
+			Draining:       s.Draining,
+			Region:         s.Region,
+			LatencyMs:      s.LatencyMs,
+			Capacity:       int32(s.Capacity),
+			FreeSlots:      int32(ns.freeSlots(s)),
+			Utilization:    ns.utilization(s),
+			MatchesRecent:  recent[s.ID],
 		})
 	}
 
 	var queueEntries []*pb.PlayerQueueEntry
-	for _, pid := range m.queue {
-		queueEntries = append(queueEntries, &pb.PlayerQueueEntry{
-			PlayerId: pid,
-		})
+	for _, pid := range ns.queue.IDs() {
+		entry := &pb.PlayerQueueEntry{PlayerId: pid}
+		if p, ok := ns.players.Get(pid); ok {
+			entry.SecondsInQueue = int64(m.wall.Since(p.LastOp).Seconds())
+			entry.Priority = p.Priority.toProto()
+			entry.Tier = p.Tier.toProto()
+			entry.Region = p.Region
+			entry.PartyId = p.PartyID
+			entry.GameMode = p.Mode
+		}
+		queueEntries = append(queueEntries, entry)
 	}
 
 	return &pb.SystemStatusResponse{
-		Servers:     serverStates,
-		PlayerQueue: queueEntries,
-		VectorClock: m.vc.toProto(),
+		Servers:        serverStates,
+		PlayerQueue:    queueEntries,
+		VectorClock:    m.clockProto(ns),
+		Namespace:      ns.name,
+		Namespaces:     names,
+		Banned:         ns.bannedProto(),
+		Modes:          m.modeStats(ns, formed),
+		WindowS:        int64(m.statusWindow.Seconds()),
+		Removed:        ns.removedProto(),
+		QueueRateLimit: m.rateLimitProto(),
+		MatchmakerId:   m.selfID,
+		OwnedModes:     m.shards.ownedModes(),
+		Matches:        ns.matchStatuses(),
 	}, nil
 }
 
-func serverStatusProto(st serverState) pb.ServerState_Status {
+func serverStatusProto(st serverState) pb.ServerStatus {
 	switch st {
 	case serverAvailable:
-		return pb.ServerState_AVAILABLE
+		return pb.ServerStatus_DISPONIBLE
 	case serverBusy:
-		return pb.ServerState_BUSY
+		return pb.ServerStatus_OCUPADO
 	case serverDown:
-		return pb.ServerState_DOWN
+		return pb.ServerStatus_CAIDO
 	default:
-		return pb.ServerState_UNKNOWN
+		return pb.ServerStatus_UNKNOWN
+	}
+}
+
+func serverStatusFromProto(st pb.ServerStatus) serverState {
+	switch st {
+	case pb.ServerStatus_DISPONIBLE:
+		return serverAvailable
+	case pb.ServerStatus_OCUPADO:
+		return serverBusy
+	case pb.ServerStatus_CAIDO:
+		return serverDown
+	default:
+		return serverUnknown
 	}
 }
 
@@ -431,102 +1467,364 @@ func (m *matchmaker) AdminUpdateServerState(ctx context.Context, req *pb.AdminSe
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.AdminUpdateResponse{
+			Status:  pb.AdminUpdateResponse_NOT_FOUND,
+			Message: "Namespace desconocido",
+		}, nil
+	}
+
 	sid := req.GetServerId()
-	srv, ok := m.servers[sid]
+	srv, ok := ns.servers.Get(sid)
 	if !ok {
 		return &pb.AdminUpdateResponse{
 			Status: pb.AdminUpdateResponse_NOT_FOUND,
 		}, nil
 	}
 
-	switch req.GetNewStatus() {
-	case pb.AdminServerUpdateRequest_FORCE_AVAILABLE:
-		srv.Status = serverAvailable
-	case pb.AdminServerUpdateRequest_FORCE_DOWN:
-		srv.Status = serverDown
+	prev := srv.Status
+	srv.Status = serverStatusFromProto(req.GetNewStatus())
+	if srv.Status == serverBusy {
+		// forzado a OCUPADO deja de recibir partidas aunque le queden huecos
+		srv.FreeSlots = 0
 	}
+	auditChange(ctx, sid, serverStatusProto(prev).String(), serverStatusProto(srv.Status).String())
 
-	m.vc.increment(m.selfID)
+	ns.vc.Tick(m.selfID)
+	m.logServerTransition(ns, srv, prev, "forzado por el administrador")
 
 	return &pb.AdminUpdateResponse{
 		Status:      pb.AdminUpdateResponse_OK,
-		VectorClock: m.vc.toProto(),
+		VectorClock: m.clockProto(ns),
 	}, nil
 }
 
+/*───────────────────────────────────────────────────────────────────────────────
+           RPC: AdminDrainServer – retiro ordenado de un servidor
+───────────────────────────────────────────────────────────────────────────────*/
+
+// AdminDrainServer marca un servidor en drenaje: termina la partida en curso
+// pero no recibe nuevas, y al liberarse se retira del pool. Permite
+// actualizar servidores sin cortar partidas en juego.
+func (m *matchmaker) AdminDrainServer(ctx context.Context, req *pb.AdminDrainServerRequest) (*pb.AdminUpdateResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.AdminUpdateResponse{
+			Status:  pb.AdminUpdateResponse_NOT_FOUND,
+			Message: "Namespace desconocido",
+		}, nil
+	}
+	m.mergeClock(ns, req.GetClock())
+
+	srv, ok := ns.servers.Get(req.GetServerId())
+	if !ok {
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_NOT_FOUND,
+			Message:     "Servidor desconocido",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	auditChange(ctx, srv.ID, fmt.Sprintf("draining=%t", srv.Draining), "draining=true")
+	srv.Draining = true
+	ns.vc.Tick(m.selfID)
+
+	// sin partidas en curso no hay nada que esperar
+	running := ns.serverMatches(srv.ID)
+	m.pushControl(ns, srv.ID, &pb.ServerControl{
+		Command: pb.ServerControl_DRAIN,
+		Retired: len(running) == 0,
+	})
+	if len(running) == 0 {
+		m.retireServer(ns, srv)
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_OK,
+			Message:     "Servidor libre: retirado del pool",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	m.logf("[%s] Server %s en drenaje; se retirará al terminar %v", ns.name, srv.ID, running)
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     "Drenando: se retirará al terminar " + strings.Join(running, ", "),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+// retireServer quita un servidor drenado del pool (ver deregister.go).
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) retireServer(ns *namespace, srv *gameServerInfo) {
+	m.removeServer(ns, srv, removedDrained)
+}
+
 /*───────────────────────────────────────────────────────────────────────────────
               Comunicación con GameServer: gRPC AssignMatch
 ───────────────────────────────────────────────────────────────────────────────*/
 
-func (m *matchmaker) dispatchAssignMatch(srv *gameServerInfo, matchID string, players []string, snapshot vectorClock) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// dispatchAssignMatch entrega la partida a srv y, si falla, a los demás
+// servidores según m.assignRetry (assign_retry.go). El llamador suma
+// m.assigning antes de lanzarla; aquí se descuenta al terminar.
+func (m *matchmaker) dispatchAssignMatch(ctx context.Context, ns *namespace, srv *gameServerInfo, matchID string, players []string, mode *pb.GameMode, snapshot *clocks.Vector) {
+	defer func() {
+		m.mu.Lock()
+		m.assigning--
+		if key := inflightKey(ns.name, matchID); m.inflight[key] <= 1 {
+			delete(m.inflight, key)
+		} else {
+			m.inflight[key]--
+		}
+		m.mu.Unlock()
+	}()
+	tried := make(map[string]bool)
+	var sent int32
+	for {
+		tried[srv.ID] = true
+		outcome, msg := m.assignWithRetries(ctx, ns, srv, matchID, players, mode, snapshot, &sent)
+		st := serverDown
+		switch outcome {
+		case assignAccepted:
+			// OK – el GameServer se encargará de actualizar su estado a BUSY internamente
+			m.markMatchLocked(ns, matchID, pb.MatchEvent_ACCEPTED, msg)
+			return
+		case assignAbandoned:
+			return
+		case assignTimedOut:
+			m.handleAssignFailure(ns, srv, matchID, players, serverDown)
+			return
+		case assignRejected:
+			st = serverBusy
+		}
+		next := m.failover(ns, srv, matchID, st, tried)
+		if next == nil {
+			m.handleAssignFailure(ns, srv, matchID, players, st)
+			return
+		}
+		srv = next
+	}
+}
+
+// dialServer conecta con un GameServer; espera a que la conexión esté lista
+// o venza ctx.
+func (m *matchmaker) dialServer(ctx context.Context, addr string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(m.dialCreds),
+		grpc.WithBlock(),
+		// un GameServer con RPC_STATUS_ERRORS responde BUSY como error
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClient, mmclient.UnaryStatusBody),
+	}
+	if m.dialer != nil {
+		opts = append(opts, grpc.WithContextDialer(m.dialer))
+	}
+	return grpc.DialContext(ctx, addr, opts...)
+}
+
+// tryAssign hace un único AssignMatch, el intento attempt de la partida,
+// con su propio plazo.
+func (m *matchmaker) tryAssign(ctx context.Context, ns *namespace, srv *gameServerInfo, matchID string, players []string, mode *pb.GameMode, snapshot *clocks.Vector, attempt int32) (assignOutcome, string) {
+	_, timeout := m.assignSettings()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, srv.Address, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := m.dialServer(ctx, srv.Address)
 	if err != nil {
 		m.logf("ERROR: no se pudo conectar a servidor %s: %v", srv.ID, err)
-		m.handleAssignFailure(srv, players)
-		return
+		return assignUnreachable, err.Error()
 	}
 	defer conn.Close()
 
 	gsc := pb.NewGameServerClient(conn)
-	_, err = gsc.AssignMatch(ctx, &pb.AssignMatchRequest{
+	m.markMatchLocked(ns, matchID, pb.MatchEvent_ASSIGNED, srv.Address)
+	res, err := gsc.AssignMatch(ctx, &pb.AssignMatchRequest{
 		MatchId:     matchID,
 		PlayerIds:   players,
-		VectorClock: snapshot.toProto(),
+		VectorClock: snapshot.ToProto(),
+		Namespace:   ns.name,
+		Mode:        mode,
+		Attempt:     attempt,
 	})
 	if err != nil {
-		m.logf("ERROR: AssignMatch a %s falló: %v", srv.ID, err)
-		m.handleAssignFailure(srv, players)
-		return
+		m.logf("ERROR: AssignMatch a %s falló (intento %d de %s): %v", srv.ID, attempt, matchID, err)
+		if status.Code(err) == codes.Unavailable {
+			return assignUnreachable, status.Convert(err).Message()
+		}
+		return assignTimedOut, err.Error()
 	}
-
-	// OK – el GameServer se encargará de actualizar su estado a BUSY internamente
+	if res.GetStatusCode() != pb.AssignMatchResponse_OK {
+		// vivo pero sin hueco: nuestra vista de sus huecos estaba atrasada
+		m.logf("Server %s rechazó la partida %s (intento %d): %s", srv.ID, matchID, attempt, res.GetMessage())
+		return assignRejected, res.GetMessage()
+	}
+	if res.GetDuplicate() {
+		m.logf("[%s] Server %s ya tenía la partida %s desde el intento %d (intento %d)", ns.name, srv.ID, matchID, res.GetOriginalAttempt(), attempt)
+	}
+	m.recordMatchAddr(ns, srv, matchID, res.GetMatchAddr())
+	return assignAccepted, res.GetMessage()
 }
 
-func (m *matchmaker) handleAssignFailure(srv *gameServerInfo, players []string) {
+// handleAssignFailure deshace una asignación que agotó reintentos y
+// servidores: el último pasa a st (DOWN si no respondió, BUSY si la
+// rechazó) y los jugadores vuelven al frente de la cola o quedan IDLE,
+// según eligieron al encolarse, o a su sala si la partida salió de una
+// sala privada.
+func (m *matchmaker) handleAssignFailure(ns *namespace, srv *gameServerInfo, matchID string, players []string, st serverState) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	defer m.audit("handleAssignFailure")
 
-	// marca DOWN
-	srv.Status = serverDown
-	m.vc.increment(m.selfID)
-
-	// devuelve jugadores a la cabeza de la cola
-	m.queue = append(players, m.queue...)
-	for _, pid := range players {
-		if p, ok := m.players[pid]; ok {
-			p.Status = playerInQueue
-			p.MatchID = ""
-		}
+	// si ya la cerró el barrido de huérfanas, sus jugadores ya se liberaron
+	am, ok := ns.matches.Get(matchID)
+	if !ok {
+		return
+	}
+	// la partida nunca empezó; si el servidor la aceptó tarde, que la suelte
+	m.closeMatch(ns, matchID, am, phaseAborted)
+	m.abortOnServer(ns, srv.ID, matchID, "asignación fallida")
+
+	prev := srv.Status
+	srv.Status = st
+	if st == serverBusy {
+		srv.FreeSlots = 0
 	}
+	ns.vc.Tick(m.selfID)
+	m.metrics.assignFailures.With(ns.name).Inc()
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_ASSIGN_FAILED, PlayerIds: players, MatchId: matchID, ServerId: srv.ID, Detail: serverStatusProto(st).String()})
+	m.logServerTransition(ns, srv, prev, "asignación fallida")
+
+	// los de una sala privada vuelven a ella, no a la cola pública
+	if am.LobbyCode != "" {
+		m.reopenLobby(ns, matchID, am)
+		return
+	}
+
+	m.releaseAfterAssignFailure(ns, matchID, players)
 }
 
 /*───────────────────────────────────────────────────────────────────────────────
                                        main
 ───────────────────────────────────────────────────────────────────────────────*/
 
+// newServer arma el servidor gRPC con la cadena de interceptores completa y
+// registra el Matchmaker y su salud. main y las pruebas lo comparten, para
+// que éstas ejerciten el mismo orden que producción.
+func (m *matchmaker) newServer(creds credentials.TransportCredentials) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.Creds(creds),
+		// los GameServers hacen ping cada KEEPALIVE_TIME (10 s como mínimo)
+		// aun sin RPC en curso; con la política por defecto (5 min) se los
+		// cortaría con GOAWAY. A la vez, los pings propios descartan los
+		// streams de clientes que murieron sin cerrar la conexión.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 5 * time.Second, PermitWithoutStream: true}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{Time: 30 * time.Second, Timeout: 10 * time.Second}),
+		// la auditoría va primero: por fuera de la recuperación de pánicos
+		grpc.ChainUnaryInterceptor(m.auditUnaryInterceptor),
+		grpc.ChainStreamInterceptor(m.auditStreamInterceptor),
+		// los fallos inyectados van al final: una RPC descartada por chaos
+		// ya pasó la autenticación y el límite de frecuencia. Los rechazos
+		// se convierten en errores gRPC por dentro de las métricas, para
+		// que cuenten con su código. La auditoría de administración va por
+		// dentro, para leer el status_code de la respuesta. Los relojes de
+		// la metadata pasan al mensaje junto al handler
+		middleware.ChainUnary("Matchmaker", m.metricsUnaryInterceptor, middleware.StatusErrors(m.statusErrors), m.passiveUnaryInterceptor, m.protocolUnaryInterceptor, m.adminAuditUnaryInterceptor, m.authUnaryInterceptor, m.sessionUnaryInterceptor, m.rateLimitUnaryInterceptor,
+			m.chaos.UnaryServerInterceptor(chaosExempt, m.chaosCrash), clockpropagation.UnaryServer(m.clockHooks())),
+		middleware.ChainStream("Matchmaker", m.passiveStreamInterceptor, m.protocolStreamInterceptor, m.authStreamInterceptor, m.sessionStreamInterceptor,
+			m.chaos.StreamServerInterceptor(chaosExempt), clockpropagation.StreamServer(m.clockHooks())),
+	)
+	pb.RegisterMatchmakerServer(srv, m)
+	healthpb.RegisterHealthServer(srv, m.health)
+	return srv
+}
+
 func main() {
 	rand.Seed(time.Now().UnixNano())
 
-	selfID := "Matchmaker"
-	port := defaultPort
-	if v := os.Getenv("MATCHMAKER_PORT"); v != "" {
-		if p, err := strconv.Atoi(v); err == nil {
-			port = p
-		}
+	cfg := config.NewReport("matchmaker")
+	// con varios Matchmakers (sharding.go) cada uno necesita el suyo
+	selfID := cfg.String("MATCHMAKER_ID", "Matchmaker")
+	port := cfg.Port("MATCHMAKER_PORT", defaultPort)
+	role := cfg.OneOf("MATCHMAKER_ROLE", rolePrimary, rolePrimary, roleBackup)
+	backupAddr := cfg.OptionalHostPort("BACKUP_ADDR")
+	sampleEvery := cfg.Duration("QUEUE_SAMPLE_INTERVAL", defaultQueueSampleInterval, 100*time.Millisecond, time.Hour)
+	metricsPort := cfg.Port("METRICS_PORT", defaultMetricsPort)
+	auditOn := cfg.OneOf("MATCHMAKER_AUDIT", "0", "0", "1") == "1"
+	statusErrors := cfg.OneOf("RPC_STATUS_ERRORS", "0", "0", "1") == "1"
+	configPath := cfg.File("MATCHMAKER_CONFIG")
+	authMode := cfg.OneOf("PLAYER_AUTH", authOff, authOff, authRequired)
+	authSecret := cfg.Secret("PLAYER_AUTH_SECRET")
+	tokenTTL := cfg.Duration("PLAYER_TOKEN_TTL", defaultTokenTTL, time.Minute, 30*24*time.Hour)
+	eventLogPath := cfg.String("EVENT_LOG_FILE", "")
+	queueLimit := readQueueRateLimit(cfg)
+	scaler := readAutoscale(cfg)
+	shards := readSharding(cfg)
+	stopCfg := readShutdown(cfg, backupAddr)
+	chaosInj := chaos.FromConfig(cfg, "CHAOS_CRASH_PROB", 0)
+	tlsFiles := cfg.TLS()
+	logFile := cfg.LogFile()
+	tracing.Configure(cfg, "Matchmaker")
+	cfg.MustValidate()
+	if err := slog.UseFile(logFile.Path, logFile.MaxBytes, logFile.Backups); err != nil {
+		log.Fatalf("FATAL: LOG_FILE: %v", err)
+	}
+	// las líneas de m.logf, que usan el paquete log estándar, van a las
+	// mismas salidas que las de slog: el archivo y AdminStreamLogs
+	log.SetOutput(slog.Writer())
+
+	// los parámetros recargables van en su propio resumen (config_reload.go)
+	tun, tunCfg := loadTunables(configPath)
+	tunCfg.MustValidate()
+
+	serverCreds, err := tlsutil.ServerCredentials(tlsFiles)
+	if err != nil {
+		log.Fatalf("FATAL: TLS del servidor: %v", err)
+	}
+	clientCreds, err := tlsutil.ClientCredentials(tlsFiles)
+	if err != nil {
+		log.Fatalf("FATAL: TLS de cliente: %v", err)
 	}
 
 	mm := newMatchmaker(selfID)
+	mm.tunables = tun
+	mm.configPath = configPath
+	mm.configValues = tunCfg.Values()
+	mm.auditOn = auditOn
+	mm.statusErrors = statusErrors
+	mm.dialCreds = clientCreds
+	if authMode == authRequired {
+		mm.auth = newPlayerSigner(authSecret, tokenTTL, mm.wall.Now)
+	}
+	mm.queueLimit = ratelimit.New(queueLimit, mm.wall.Now)
+	mm.scaler = scaler
+	mm.shards = shards
+	mm.chaos = chaosInj
+	if c := chaosInj.Config(); c.Enabled() {
+		log.Printf("WARNING: fallos inyectados en el Matchmaker: %s", c)
+	}
+	if eventLogPath != "" {
+		if err := mm.openEventLog(eventLogPath); err != nil {
+			log.Fatalf("FATAL: log de eventos %s: %v", eventLogPath, err)
+		}
+	}
+	mm.replica.passive.Store(role == roleBackup)
+	mm.updateHealth()
+	if role == rolePrimary && stopCfg.handoffFile != "" {
+		if err := mm.loadHandoff(stopCfg.handoffFile); err != nil {
+			log.Fatalf("FATAL: HANDOFF_FILE: %v", err)
+		}
+	}
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatalf("FATAL: no se puede escuchar en %d: %v", port, err)
 	}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterMatchmakerServer(grpcServer, mm)
+	grpcServer := mm.newServer(serverCreds)
+
+	// SIGHUP relee MATCHMAKER_CONFIG, como AdminReloadConfig
+	go mm.reloadOnSIGHUP()
 
 	// interrupción graceful
 	go func() {
@@ -534,14 +1832,27 @@ func main() {
 		signal.Notify(c, os.Interrupt)
 		<-c
 		log.Println("SIGINT recibido, apagando Matchmaker…")
+		mm.shutdown(stopCfg)
 		close(mm.done)
+		mm.health.Shutdown()
 		grpcServer.GracefulStop()
 	}()
 
-	// goroutine de emparejamiento
-	go mm.runMatchLoop()
+	go mm.runQueueSampler(sampleEvery)
+	go mm.serveMetrics(metricsPort)
+
+	if role == roleBackup {
+		// el respaldo sólo empareja tras ser promovido
+		go mm.watchPrimary()
+	} else {
+		// goroutine de emparejamiento
+		go mm.runMatchLoop()
+		if backupAddr != "" {
+			go mm.runReplication(backupAddr)
+		}
+	}
 
-	log.Printf("Matchmaker escuchando en :%d", port)
+	log.Printf("Matchmaker (%s) escuchando en :%d", role, port)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("FATAL: servidor gRPC se detuvo: %v", err)
 	}
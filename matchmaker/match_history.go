@@ -0,0 +1,259 @@
+// matchmaker/match_history.go
+//
+// Historial de partidas terminadas. Además de las que informan resultado se
+// guardan las que se cierran sin él (servidor caído, timeout, abortadas o
+// abandonadas por un jugador), con su desenlace. El historial viaja en cada snapshot, así que sobrevive a
+// la conmutación al respaldo, y se recorta a las MATCH_HISTORY_LIMIT más
+// recientes de cada namespace. GetMatchHistory lo pagina de la más reciente
+// a la más antigua, por jugador o para todo el namespace.
+
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultHistoryLimit = 10000
+	defaultHistoryPage  = 20
+	maxHistoryPage      = 100
+
+	outcomeCompleted = "completed"
+)
+
+// recordMatch agrega una partida terminada al historial del namespace.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) recordMatch(ns *namespace, res *matchResult) {
+	ns.historySeq++
+	res.Seq = ns.historySeq
+	ns.history = append(ns.history, res)
+	ns.recordResult(res)
+	m.logEvent(ns, &pb.StateEvent{
+		Kind:      pb.StateEvent_MATCH_ENDED,
+		PlayerIds: res.Players,
+		MatchId:   res.MatchID,
+		ServerId:  res.ServerID,
+		Detail:    outcomeProto(res.Outcome).String(),
+	})
+	if m.historyLimit > 0 && len(ns.history) > m.historyLimit {
+		drop := len(ns.history) - m.historyLimit
+		ns.history = append([]*matchResult(nil), ns.history[drop:]...)
+	}
+}
+
+// completed indica si la partida terminó con resultado informado.
+func (r *matchResult) completed() bool { return r.Outcome == outcomeCompleted }
+
+func (r *matchResult) hasPlayer(playerID string) bool {
+	for _, pid := range r.Players {
+		if pid == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// won indica si playerID ganó: figura en WinnerIDs o, si el resultado no
+// los lista (abandono, GameServer anterior), es WinnerID.
+func (r *matchResult) won(playerID string) bool {
+	if len(r.WinnerIDs) == 0 {
+		return playerID != "" && playerID == r.WinnerID
+	}
+	for _, pid := range r.WinnerIDs {
+		if pid == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// winnerLabel describe a los ganadores para los logs.
+func (r *matchResult) winnerLabel() string {
+	switch {
+	case len(r.WinnerIDs) > 1:
+		return strings.Join(r.WinnerIDs, "+")
+	case r.WinnerID == "":
+		return "ninguno (empate)"
+	}
+	return r.WinnerID
+}
+
+func (r *matchResult) toProto() *pb.MatchRecord {
+	rec := &pb.MatchRecord{
+		Sequence:         r.Seq,
+		MatchId:          r.MatchID,
+		ServerId:         r.ServerID,
+		GameMode:         r.Mode,
+		PlayerIds:        append([]string(nil), r.Players...),
+		WinnerId:         r.WinnerID,
+		WinnerIds:        append([]string(nil), r.WinnerIDs...),
+		DurationMs:       r.Duration.Milliseconds(),
+		FinishedAtUnixMs: r.FinishedAt.UnixMilli(),
+		Outcome:          outcomeProto(r.Outcome),
+		Timeline:         timelineProto(r.Timeline),
+		ScoreEvents:      scoreEventsProto(r.Events),
+	}
+	if !r.StartedAt.IsZero() {
+		rec.StartedAtUnixMs = r.StartedAt.UnixMilli()
+	}
+	for _, st := range r.Stats {
+		rec.PlayerStats = append(rec.PlayerStats, &pb.PlayerMatchStats{
+			PlayerId: st.PlayerID,
+			Score:    int32(st.Score),
+			Kills:    int32(st.Kills),
+			Deaths:   int32(st.Deaths),
+		})
+	}
+	return rec
+}
+
+// matchResultFromProto reconstruye una fila del historial de un snapshot.
+// Las opiniones de los jugadores no se replican.
+func matchResultFromProto(rec *pb.MatchRecord) *matchResult {
+	r := &matchResult{
+		Seq:        rec.GetSequence(),
+		MatchID:    rec.GetMatchId(),
+		ServerID:   rec.GetServerId(),
+		Mode:       rec.GetGameMode(),
+		Players:    rec.GetPlayerIds(),
+		WinnerID:   rec.GetWinnerId(),
+		WinnerIDs:  rec.GetWinnerIds(),
+		Events:     scoreEventsFromProto(rec.GetScoreEvents()),
+		Duration:   time.Duration(rec.GetDurationMs()) * time.Millisecond,
+		FinishedAt: time.UnixMilli(rec.GetFinishedAtUnixMs()),
+		Outcome:    outcomeFromProto(rec.GetOutcome()),
+		Timeline:   timelineFromProto(rec.GetTimeline()),
+	}
+	if ms := rec.GetStartedAtUnixMs(); ms > 0 {
+		r.StartedAt = time.UnixMilli(ms)
+	}
+	for _, st := range rec.GetPlayerStats() {
+		r.Stats = append(r.Stats, playerMatchStats{
+			PlayerID: st.GetPlayerId(),
+			Score:    int(st.GetScore()),
+			Kills:    int(st.GetKills()),
+			Deaths:   int(st.GetDeaths()),
+		})
+	}
+	return r
+}
+
+func scoreEventsProto(events []scoreEvent) []*pb.ScoreEvent {
+	var out []*pb.ScoreEvent
+	for _, e := range events {
+		out = append(out, &pb.ScoreEvent{AtMs: e.At.Milliseconds(), PlayerId: e.PlayerID, VictimId: e.VictimID})
+	}
+	return out
+}
+
+func scoreEventsFromProto(events []*pb.ScoreEvent) []scoreEvent {
+	var out []scoreEvent
+	for _, e := range events {
+		out = append(out, scoreEvent{
+			At:       time.Duration(e.GetAtMs()) * time.Millisecond,
+			PlayerID: e.GetPlayerId(),
+			VictimID: e.GetVictimId(),
+		})
+	}
+	return out
+}
+
+// Los desenlaces sin resultado usan los mismos nombres que los motivos de
+// closeOrphan (y la etiqueta de matchmaker_orphan_matches_total), más el
+// abandono de un jugador y la terminación por el administrador.
+func outcomeProto(outcome string) pb.MatchRecord_Outcome {
+	switch outcome {
+	case "server_down":
+		return pb.MatchRecord_SERVER_DOWN
+	case "timeout":
+		return pb.MatchRecord_TIMEOUT
+	case "aborted":
+		return pb.MatchRecord_ABORTED
+	case outcomeAbandoned:
+		return pb.MatchRecord_ABANDONED
+	case "deregistered":
+		return pb.MatchRecord_DEREGISTERED
+	case outcomeTerminated:
+		return pb.MatchRecord_TERMINATED
+	}
+	return pb.MatchRecord_COMPLETED
+}
+
+func outcomeFromProto(o pb.MatchRecord_Outcome) string {
+	switch o {
+	case pb.MatchRecord_SERVER_DOWN:
+		return "server_down"
+	case pb.MatchRecord_TIMEOUT:
+		return "timeout"
+	case pb.MatchRecord_ABORTED:
+		return "aborted"
+	case pb.MatchRecord_ABANDONED:
+		return outcomeAbandoned
+	case pb.MatchRecord_DEREGISTERED:
+		return "deregistered"
+	case pb.MatchRecord_TERMINATED:
+		return outcomeTerminated
+	}
+	return outcomeCompleted
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: GetMatchHistory – partidas terminadas, paginadas
+───────────────────────────────────────────────────────────────────────────────*/
+
+// GetMatchHistory no modifica estado. El token de página es la secuencia de
+// la última partida entregada, así que las que terminen entre una página y
+// la siguiente no desplazan el resultado.
+func (m *matchmaker) GetMatchHistory(ctx context.Context, req *pb.MatchHistoryRequest) (*pb.MatchHistoryResponse, error) {
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "page_size debe ser >= 0 (recibido %d)", size)
+	case size == 0:
+		size = defaultHistoryPage
+	case size > maxHistoryPage:
+		size = maxHistoryPage
+	}
+	var before uint64
+	if tok := req.GetPageToken(); tok != "" {
+		n, err := strconv.ParseUint(tok, 10, 64)
+		if err != nil || n == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "page_token inválido: %q", tok)
+		}
+		before = n
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.MatchHistoryResponse{}, nil
+	}
+
+	out := &pb.MatchHistoryResponse{VectorClock: m.clockProto(ns)}
+	playerID := req.GetPlayerId()
+	for i := len(ns.history) - 1; i >= 0; i-- {
+		res := ns.history[i]
+		if before > 0 && res.Seq >= before {
+			continue
+		}
+		if playerID != "" && !res.hasPlayer(playerID) {
+			continue
+		}
+		if len(out.Matches) == size {
+			out.NextPageToken = strconv.FormatUint(out.Matches[size-1].GetSequence(), 10)
+			break
+		}
+		out.Matches = append(out.Matches, res.toProto())
+	}
+	return out, nil
+}
@@ -0,0 +1,154 @@
+// matchmaker/match_policy.go
+//
+// Políticas de emparejamiento para los modos de equipos. La cola ya llega
+// ordenada por queuePolicy (niveles, espera, inanición); la política decide,
+// sobre ese orden, qué unidades (un jugador solo o su grupo completo) forman
+// los dos equipos de la próxima partida. Se elige con MATCH_POLICY:
+//
+//	fifo     primer hueco libre en el orden de la cola (por defecto)
+//	region   prefiere una partida con todos de la misma región, empezando
+//	         por la del primero de la cola; si ninguna región completa, fifo.
+//	         Las unidades que ya esperaron QUEUE_RELAX_AFTER valen para
+//	         cualquier región (fairness.go)
+//	random   baraja las unidades antes de repartirlas
+//
+// Un algoritmo nuevo sólo implementa matchPolicy y se registra en
+// matchPolicies; el bucle de emparejamiento no cambia. Las salas por tiempo
+// (lobbies.go) no pasan por la política.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// queueUnit es lo que una política reparte: uno o más jugadores que deben
+// caer en el mismo equipo.
+type queueUnit struct {
+	Players []string
+	Region  string     // del primer integrante; vacío = cualquiera
+	Relax   relaxLevel // según la espera (fairness.go)
+}
+
+// matchPolicy forma los dos equipos de teamSize jugadores a partir de las
+// unidades encoladas del modo, en el orden de la cola. Devuelve false si no
+// alcanza para una partida.
+type matchPolicy interface {
+	Name() string
+	FormTeams(units []queueUnit, teamSize int) ([2][]string, bool)
+}
+
+const defaultMatchPolicy = "fifo"
+
+// matchPolicies registra las políticas por el nombre usado en MATCH_POLICY.
+var matchPolicies = map[string]func() matchPolicy{
+	"fifo":   func() matchPolicy { return fifoPolicy{} },
+	"region": func() matchPolicy { return regionPolicy{} },
+	"random": func() matchPolicy { return newRandomPolicy(rand.Int63()) },
+}
+
+// matchPolicyNames lista las políticas registradas, ordenadas.
+func matchPolicyNames() []string {
+	names := make([]string, 0, len(matchPolicies))
+	for name := range matchPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newMatchPolicy crea la política registrada con ese nombre.
+func newMatchPolicy(name string) (matchPolicy, error) {
+	mk, ok := matchPolicies[name]
+	if !ok {
+		return nil, fmt.Errorf("política %q desconocida (%s)", name, strings.Join(matchPolicyNames(), ", "))
+	}
+	return mk(), nil
+}
+
+// firstFit coloca cada unidad, en orden, en el primer equipo donde quepa.
+func firstFit(units []queueUnit, teamSize int) ([2][]string, bool) {
+	var teams [2][]string
+	for _, u := range units {
+		for t := range teams {
+			if len(teams[t])+len(u.Players) <= teamSize {
+				teams[t] = append(teams[t], u.Players...)
+				break
+			}
+		}
+		if len(teams[0]) == teamSize && len(teams[1]) == teamSize {
+			return teams, true
+		}
+	}
+	return teams, false
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                  Políticas
+───────────────────────────────────────────────────────────────────────────────*/
+
+// fifoPolicy respeta el orden de la cola.
+type fifoPolicy struct{}
+
+func (fifoPolicy) Name() string { return "fifo" }
+
+func (fifoPolicy) FormTeams(units []queueUnit, teamSize int) ([2][]string, bool) {
+	return firstFit(units, teamSize)
+}
+
+// regionPolicy busca primero una partida de una sola región, probando las
+// regiones en el orden en que aparecen en la cola. Una unidad relajada
+// cuenta en todas las regiones, en su lugar de la cola.
+type regionPolicy struct{}
+
+func (regionPolicy) Name() string { return "region" }
+
+func (regionPolicy) FormTeams(units []queueUnit, teamSize int) ([2][]string, bool) {
+	var order []string
+	byRegion := make(map[string][]queueUnit)
+	for _, u := range units {
+		if u.Region == "" {
+			continue
+		}
+		if _, ok := byRegion[u.Region]; !ok {
+			order = append(order, u.Region)
+		}
+		byRegion[u.Region] = append(byRegion[u.Region], u)
+	}
+	for _, region := range order {
+		var candidates []queueUnit
+		for _, u := range units {
+			if u.Region == region || u.Relax >= relaxRegion {
+				candidates = append(candidates, u)
+			}
+		}
+		if teams, ok := firstFit(candidates, teamSize); ok {
+			return teams, true
+		}
+	}
+	return firstFit(units, teamSize)
+}
+
+// randomPolicy reparte las unidades en orden aleatorio.
+type randomPolicy struct {
+	mu  *sync.Mutex
+	rng *rand.Rand
+}
+
+func newRandomPolicy(seed int64) randomPolicy {
+	return randomPolicy{mu: &sync.Mutex{}, rng: rand.New(rand.NewSource(seed))}
+}
+
+func (randomPolicy) Name() string { return "random" }
+
+func (p randomPolicy) FormTeams(units []queueUnit, teamSize int) ([2][]string, bool) {
+	shuffled := append([]queueUnit(nil), units...)
+	p.mu.Lock()
+	p.rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	p.mu.Unlock()
+	return firstFit(shuffled, teamSize)
+}
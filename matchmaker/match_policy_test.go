@@ -0,0 +1,159 @@
+// matchmaker/match_policy_test.go
+//
+// Políticas de emparejamiento: qué equipos forma cada una sobre la misma
+// cola, que nunca separen un grupo y que takeQueued saque de la cola
+// exactamente a los elegidos.
+
+package main
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func solo(id, region string) queueUnit {
+	return queueUnit{Players: []string{id}, Region: region}
+}
+
+func TestMatchPoliciesFormTeams(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   matchPolicy
+		units    []queueUnit
+		teamSize int
+		want     [2][]string // nil = no forma partida
+	}{
+		{
+			name:     "fifo respeta el orden",
+			policy:   fifoPolicy{},
+			units:    []queueUnit{solo("A", "eu"), solo("B", "us"), solo("C", "eu")},
+			teamSize: 1,
+			want:     [2][]string{{"A"}, {"B"}},
+		},
+		{
+			name:     "fifo no alcanza",
+			policy:   fifoPolicy{},
+			units:    []queueUnit{solo("A", ""), solo("B", "")},
+			teamSize: 2,
+		},
+		{
+			name:   "fifo salta el grupo que no cabe",
+			policy: fifoPolicy{},
+			units: []queueUnit{
+				solo("A", ""),
+				{Players: []string{"P1", "P2"}},
+				solo("B", ""),
+				solo("C", ""),
+			},
+			teamSize: 2,
+			want:     [2][]string{{"A", "B"}, {"P1", "P2"}},
+		},
+		{
+			name:     "region prefiere la región del primero",
+			policy:   regionPolicy{},
+			units:    []queueUnit{solo("A", "eu"), solo("B", "us"), solo("C", "eu")},
+			teamSize: 1,
+			want:     [2][]string{{"A"}, {"C"}},
+		},
+		{
+			name:     "region prueba la siguiente región",
+			policy:   regionPolicy{},
+			units:    []queueUnit{solo("A", "eu"), solo("B", "us"), solo("C", "us")},
+			teamSize: 1,
+			want:     [2][]string{{"B"}, {"C"}},
+		},
+		{
+			name:     "region cae a fifo si ninguna completa",
+			policy:   regionPolicy{},
+			units:    []queueUnit{solo("A", "eu"), solo("B", ""), solo("C", "us")},
+			teamSize: 1,
+			want:     [2][]string{{"A"}, {"B"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.policy.FormTeams(tc.units, tc.teamSize)
+			if tc.want[0] == nil {
+				if ok {
+					t.Fatalf("formó %v, se esperaba ninguna partida", got)
+				}
+				return
+			}
+			if !ok || !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("FormTeams = %v (ok=%v), se esperaba %v", got, ok, tc.want)
+			}
+		})
+	}
+}
+
+// random debe formar equipos válidos con cualquier semilla y, con varias,
+// no elegir siempre a los mismos.
+func TestRandomPolicyKeepsPartiesTogether(t *testing.T) {
+	units := []queueUnit{
+		{Players: []string{"P1", "P2"}},
+		solo("A", ""), solo("B", ""), solo("C", ""), solo("D", ""),
+	}
+	seen := make(map[string]bool)
+	for seed := int64(1); seed <= 20; seed++ {
+		teams, ok := newRandomPolicy(seed).FormTeams(units, 2)
+		if !ok {
+			t.Fatalf("semilla %d: no formó partida", seed)
+		}
+		for _, team := range teams {
+			if len(team) != 2 {
+				t.Fatalf("semilla %d: equipo %v de tamaño %d", seed, team, len(team))
+			}
+			has1, has2 := false, false
+			for _, pid := range team {
+				has1, has2 = has1 || pid == "P1", has2 || pid == "P2"
+			}
+			if has1 != has2 {
+				t.Fatalf("semilla %d: el grupo quedó separado: %v", seed, teams)
+			}
+		}
+		picked := append(append([]string(nil), teams[0]...), teams[1]...)
+		sort.Strings(picked)
+		seen[strings.Join(picked, ",")] = true
+	}
+	if len(seen) < 2 {
+		t.Fatal("20 semillas eligieron siempre a los mismos jugadores")
+	}
+}
+
+func TestTakeQueuedRemovesOnlyPicked(t *testing.T) {
+	ns := newNamespace("default")
+	now := time.Now()
+	for i, r := range []struct{ id, region string }{{"A", "eu"}, {"B", "us"}, {"C", "eu"}} {
+		ns.players.Put(r.id, &playerInfo{
+			ID: r.id, Status: playerInQueue, Mode: defaultGameMode, Region: r.region,
+			LastOp: now.Add(time.Duration(i) * time.Second),
+		})
+		ns.enqueue(r.id, false)
+	}
+
+	picked, _ := ns.takeQueued(ns.modes[defaultGameMode], regionPolicy{}, defaultQueuePolicy(), now)
+	if !reflect.DeepEqual(picked, []string{"A", "C"}) {
+		t.Fatalf("takeQueued = %v, se esperaba [A C]", picked)
+	}
+	if !reflect.DeepEqual(ns.queue.IDs(), []string{"B"}) {
+		t.Fatalf("cola = %v, se esperaba [B]", ns.queue.IDs())
+	}
+	if again, _ := ns.takeQueued(ns.modes[defaultGameMode], fifoPolicy{}, defaultQueuePolicy(), now); again != nil {
+		t.Fatalf("con un solo jugador formó %v", again)
+	}
+}
+
+func TestNewMatchPolicy(t *testing.T) {
+	for _, name := range matchPolicyNames() {
+		p, err := newMatchPolicy(name)
+		if err != nil || p.Name() != name {
+			t.Fatalf("newMatchPolicy(%q) = %v, %v", name, p, err)
+		}
+	}
+	if _, err := newMatchPolicy("elo"); err == nil {
+		t.Fatal("una política desconocida no dio error")
+	}
+}
@@ -0,0 +1,267 @@
+// matchmaker/match_relay.go
+//
+// Retransmisión de partidas en curso. Cada GameServer publica los eventos de
+// sus partidas (los mismos SNAPSHOT, SCORE, TICK y ENDED de SpectateMatch)
+// con el stream PublishMatchEvents, y el Matchmaker los reparte a quienes se
+// suscribieron con SubscribeMatchEvents: jugadores de la partida o
+// espectadores que no necesitan llegar al GameServer. El suscriptor recibe
+// primero el último marcador publicado como SNAPSHOT y luego los eventos a
+// medida que llegan; el stream termina con ENDED.
+//
+// Sólo se aceptan eventos del servidor que tiene asignada la partida. Un
+// suscriptor lento pierde eventos en vez de frenar al servidor (cada evento
+// lleva el marcador completo), y si la partida termina sin que llegue
+// ENDED —el servidor se cayó o se abortó— el bucle de emparejamiento cierra
+// la retransmisión con el último marcador. Las retransmisiones no se
+// replican: tras un reinicio o una conmutación el GameServer vuelve a
+// publicar y los suscriptores deben volver a suscribirse.
+
+package main
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	relayBuffer = 16 // eventos en espera por suscriptor
+	// sin ningún evento publicado en este plazo, el servidor no retransmite
+	// (anterior al relay) y el suscriptor debe ir al GameServer
+	relayFirstEvent = 5 * time.Second
+)
+
+type relayKey struct {
+	Namespace string
+	MatchID   string
+}
+
+// matchRelay es la retransmisión de una partida. Se lee y modifica con m.mu
+// bloqueado.
+type matchRelay struct {
+	server string                            // servidor que publica; "" = ninguno aún
+	last   *pb.SpectateEvent                 // último evento publicado
+	subs   map[chan *pb.SpectateEvent]string // canal → suscriptor
+}
+
+// relayFor devuelve la retransmisión de la partida, creándola si no existe.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) relayFor(ns *namespace, matchID string) *matchRelay {
+	key := relayKey{ns.name, matchID}
+	r, ok := m.relays[key]
+	if !ok {
+		r = &matchRelay{subs: make(map[chan *pb.SpectateEvent]string)}
+		m.relays[key] = r
+	}
+	return r
+}
+
+// deliver entrega ev a un suscriptor sin bloquear; false si su canal está
+// lleno. ENDED siempre se entrega, descartando el evento más viejo.
+// debe llamarse con m.mu bloqueado
+func deliver(ch chan *pb.SpectateEvent, ev *pb.SpectateEvent) bool {
+	select {
+	case ch <- ev:
+		return true
+	default:
+	}
+	if ev.GetKind() != pb.SpectateEvent_ENDED {
+		return false
+	}
+	// sólo se escribe con m.mu: tras sacar uno hay lugar
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- ev
+	return false
+}
+
+// endRelay envía ENDED a los suscriptores y cierra la retransmisión.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) endRelay(key relayKey, ev *pb.SpectateEvent) {
+	r, ok := m.relays[key]
+	if !ok {
+		return
+	}
+	for ch := range r.subs {
+		deliver(ch, ev)
+		close(ch)
+	}
+	delete(m.relays, key)
+}
+
+// pruneRelays cierra las retransmisiones de partidas que ya no están en
+// curso y de las que nunca llegó ENDED, con el último marcador conocido.
+func (m *matchmaker) pruneRelays() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, r := range m.relays {
+		if ns := m.lookupNS(key.Namespace); ns != nil {
+			if _, running := ns.matches.Get(key.MatchID); running {
+				continue
+			}
+		}
+		ev := &pb.SpectateEvent{
+			Kind:       pb.SpectateEvent_ENDED,
+			MatchId:    key.MatchID,
+			GameMode:   r.last.GetGameMode(),
+			Scoreboard: r.last.GetScoreboard(),
+			Message:    "la partida terminó",
+			UnixMs:     m.wall.Now().UnixMilli(),
+		}
+		m.endRelay(key, ev)
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+        RPC: PublishMatchEvents – el GameServer publica sus partidas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) PublishMatchEvents(stream pb.Matchmaker_PublishMatchEventsServer) error {
+	res := &pb.PublishMatchEventsResponse{}
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(res)
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.relayEvent(req, res); err != nil {
+			return err
+		}
+	}
+}
+
+// relayEvent reparte un evento publicado y lo suma al resumen del stream.
+func (m *matchmaker) relayEvent(req *pb.PublishMatchEventsRequest, res *pb.PublishMatchEventsResponse) error {
+	ev := req.GetEvent()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return status.Error(codes.NotFound, "namespace desconocido")
+	}
+	key := relayKey{ns.name, ev.GetMatchId()}
+	r, relaying := m.relays[key]
+	am, running := ns.matches.Get(ev.GetMatchId())
+	switch {
+	case running && am.ServerID == req.GetServerId():
+	case relaying && r.server == req.GetServerId():
+	case ev.GetKind() == pb.SpectateEvent_ENDED:
+		// la retransmisión ya se cerró al terminar la partida
+		return nil
+	default:
+		m.metrics.relayEvents.With(ns.name, "rejected").Inc()
+		return status.Errorf(codes.FailedPrecondition, "la partida %s no está en curso en %s", ev.GetMatchId(), req.GetServerId())
+	}
+
+	r = m.relayFor(ns, ev.GetMatchId())
+	r.server, r.last = req.GetServerId(), ev
+	res.Received++
+	m.metrics.relayEvents.With(ns.name, "received").Inc()
+	if ev.GetKind() == pb.SpectateEvent_ENDED {
+		res.Delivered += int64(len(r.subs))
+		m.metrics.relayEvents.With(ns.name, "delivered").Add(float64(len(r.subs)))
+		m.endRelay(key, ev)
+		return nil
+	}
+	for ch, who := range r.subs {
+		if deliver(ch, ev) {
+			res.Delivered++
+			m.metrics.relayEvents.With(ns.name, "delivered").Inc()
+			continue
+		}
+		res.Dropped++
+		m.metrics.relayEvents.With(ns.name, "dropped").Inc()
+		m.logf("[%s] Evento de %s descartado para %s (suscriptor lento)", ns.name, ev.GetMatchId(), who)
+	}
+	return nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+      RPC: SubscribeMatchEvents – mirar una partida a través del Matchmaker
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) SubscribeMatchEvents(req *pb.SubscribeMatchEventsRequest, stream pb.Matchmaker_SubscribeMatchEventsServer) error {
+	matchID := req.GetMatchId()
+	ch := make(chan *pb.SpectateEvent, relayBuffer)
+
+	m.mu.Lock()
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		m.mu.Unlock()
+		return status.Errorf(codes.NotFound, "match %s not running", matchID)
+	}
+	if _, running := ns.matches.Get(matchID); !running {
+		m.mu.Unlock()
+		return status.Errorf(codes.NotFound, "match %s not running", matchID)
+	}
+	key := relayKey{ns.name, matchID}
+	r := m.relayFor(ns, matchID)
+	r.subs[ch] = req.GetSubscriberId()
+	var snap *pb.SpectateEvent
+	if r.last != nil {
+		snap = proto.Clone(r.last).(*pb.SpectateEvent)
+		snap.Kind, snap.PlayerId, snap.VictimId = pb.SpectateEvent_SNAPSHOT, "", ""
+	}
+	m.mu.Unlock()
+
+	m.logf("[%s] %s mira la partida %s a través del Matchmaker", ns.name, req.GetSubscriberId(), matchID)
+	defer m.unsubscribeRelay(key, ch)
+
+	if snap != nil {
+		if err := stream.Send(snap); err != nil {
+			return err
+		}
+	}
+	first := m.wall.After(relayFirstEvent)
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			// la primera vez que publica el servidor, su evento hace de SNAPSHOT
+			if snap == nil {
+				snap = ev
+				ev = proto.Clone(ev).(*pb.SpectateEvent)
+				if ev.GetKind() != pb.SpectateEvent_ENDED {
+					ev.Kind = pb.SpectateEvent_SNAPSHOT
+				}
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+			if ev.GetKind() == pb.SpectateEvent_ENDED {
+				return nil
+			}
+		case <-first:
+			if snap == nil {
+				return status.Errorf(codes.FailedPrecondition, "el servidor de la partida %s no retransmite sus eventos", matchID)
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-m.done:
+			return nil
+		}
+	}
+}
+
+// unsubscribeRelay quita al suscriptor; la retransmisión sigue mientras la
+// partida esté en curso, para entregar el marcador al próximo.
+func (m *matchmaker) unsubscribeRelay(key relayKey, ch chan *pb.SpectateEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.relays[key]; ok {
+		delete(r.subs, ch)
+	}
+}
@@ -0,0 +1,173 @@
+// matchmaker/match_relay_test.go
+//
+// Retransmisión de partidas: los eventos que publica el servidor de la
+// partida llegan a todos los suscriptores, el que llega tarde empieza por el
+// último marcador, otro servidor no puede publicar, y si la partida termina
+// sin ENDED (o el servidor nunca publica) el suscriptor no queda colgado.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// relayMatch registra una partida M1 en curso en gs1.
+func relayMatch(mm *matchmaker) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	mm.ns(defaultNamespace).matches.Put("M1", &activeMatch{ServerID: "gs1", Mode: defaultGameMode, StartedAt: time.Now()})
+}
+
+// subscribe suscribe a M1 y espera a que el Matchmaker lo registre.
+func subscribe(t *testing.T, ctx context.Context, mm *matchmaker, cli pb.MatchmakerClient, who string) pb.Matchmaker_SubscribeMatchEventsClient {
+	t.Helper()
+	mm.mu.RLock()
+	before := 0
+	if r := mm.relays[relayKey{defaultNamespace, "M1"}]; r != nil {
+		before = len(r.subs)
+	}
+	mm.mu.RUnlock()
+	stream, err := cli.SubscribeMatchEvents(ctx, &pb.SubscribeMatchEventsRequest{MatchId: "M1", SubscriberId: who})
+	if err != nil {
+		t.Fatalf("SubscribeMatchEvents: %v", err)
+	}
+	for deadline := time.Now().Add(2 * time.Second); ; time.Sleep(5 * time.Millisecond) {
+		mm.mu.RLock()
+		r := mm.relays[relayKey{defaultNamespace, "M1"}]
+		n := 0
+		if r != nil {
+			n = len(r.subs)
+		}
+		mm.mu.RUnlock()
+		if n > before {
+			return stream
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s no quedó suscrito", who)
+		}
+	}
+}
+
+func recvEvent(t *testing.T, stream pb.Matchmaker_SubscribeMatchEventsClient, want pb.SpectateEvent_Kind) *pb.SpectateEvent {
+	t.Helper()
+	ev, err := stream.Recv()
+	if err != nil || ev.GetKind() != want {
+		t.Fatalf("se esperaba %v, llegó %v (%v)", want, ev.GetKind(), err)
+	}
+	return ev
+}
+
+func board(score int32) []*pb.PlayerMatchStats {
+	return []*pb.PlayerMatchStats{{PlayerId: "A", Score: score}, {PlayerId: "B"}}
+}
+
+func TestMatchRelayFanOut(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	relayMatch(mm)
+
+	unknown, err := cli.SubscribeMatchEvents(ctx, &pb.SubscribeMatchEventsRequest{MatchId: "M9"})
+	if err != nil {
+		t.Fatalf("SubscribeMatchEvents: %v", err)
+	}
+	if _, err := unknown.Recv(); status.Code(err) != codes.NotFound {
+		t.Fatalf("partida desconocida: %v, se esperaba NotFound", err)
+	}
+
+	first := subscribe(t, ctx, mm, cli, "P1")
+	pub, err := cli.PublishMatchEvents(ctx)
+	if err != nil {
+		t.Fatalf("PublishMatchEvents: %v", err)
+	}
+	publish := func(ev *pb.SpectateEvent) {
+		t.Helper()
+		ev.MatchId = "M1"
+		if err := pub.Send(&pb.PublishMatchEventsRequest{ServerId: "gs1", Event: ev}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	publish(&pb.SpectateEvent{Kind: pb.SpectateEvent_SNAPSHOT, Scoreboard: board(0)})
+	recvEvent(t, first, pb.SpectateEvent_SNAPSHOT)
+	publish(&pb.SpectateEvent{Kind: pb.SpectateEvent_SCORE, PlayerId: "A", VictimId: "B", Scoreboard: board(100)})
+	if ev := recvEvent(t, first, pb.SpectateEvent_SCORE); ev.GetPlayerId() != "A" {
+		t.Fatalf("SCORE = %v", ev)
+	}
+
+	// el que llega tarde empieza por el último marcador
+	late := subscribe(t, ctx, mm, cli, "P2")
+	if ev := recvEvent(t, late, pb.SpectateEvent_SNAPSHOT); ev.GetScoreboard()[0].GetScore() != 100 || ev.GetPlayerId() != "" {
+		t.Fatalf("SNAPSHOT tardío = %v", ev)
+	}
+
+	// otro servidor no puede publicar la partida
+	other, err := cli.PublishMatchEvents(ctx)
+	if err != nil {
+		t.Fatalf("PublishMatchEvents: %v", err)
+	}
+	_ = other.Send(&pb.PublishMatchEventsRequest{ServerId: "gs2", Event: &pb.SpectateEvent{MatchId: "M1", Kind: pb.SpectateEvent_TICK}})
+	if _, err := other.CloseAndRecv(); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("publicación de gs2: %v, se esperaba FailedPrecondition", err)
+	}
+
+	publish(&pb.SpectateEvent{Kind: pb.SpectateEvent_ENDED, Scoreboard: board(100)})
+	recvEvent(t, first, pb.SpectateEvent_ENDED)
+	recvEvent(t, late, pb.SpectateEvent_ENDED)
+	res, err := pub.CloseAndRecv()
+	if err != nil || res.GetReceived() != 3 || res.GetDelivered() != 4 {
+		t.Fatalf("resumen = %v (%v), se esperaban 3 eventos y 4 entregas", res, err)
+	}
+}
+
+func TestMatchRelayEndsWithoutPublisher(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	wall := walltime.NewFake(time.Now())
+	mm.wall = wall
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	relayMatch(mm)
+
+	// un servidor que nunca publica: el suscriptor vuelve al GameServer
+	silent := subscribe(t, ctx, mm, cli, "P1")
+	for wall.Waiters() == 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	wall.Advance(relayFirstEvent)
+	if _, err := silent.Recv(); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("sin eventos: %v, se esperaba FailedPrecondition", err)
+	}
+
+	// la partida termina sin ENDED: se cierra con el último marcador
+	stream := subscribe(t, ctx, mm, cli, "P2")
+	pub, err := cli.PublishMatchEvents(ctx)
+	if err != nil {
+		t.Fatalf("PublishMatchEvents: %v", err)
+	}
+	if err := pub.Send(&pb.PublishMatchEventsRequest{ServerId: "gs1", Event: &pb.SpectateEvent{
+		MatchId: "M1", Kind: pb.SpectateEvent_SCORE, Scoreboard: board(100)}}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	recvEvent(t, stream, pb.SpectateEvent_SNAPSHOT)
+
+	mm.mu.Lock()
+	mm.ns(defaultNamespace).matches.Delete("M1")
+	mm.mu.Unlock()
+	mm.pruneRelays()
+	if ev := recvEvent(t, stream, pb.SpectateEvent_ENDED); ev.GetScoreboard()[0].GetScore() != 100 {
+		t.Fatalf("ENDED = %v, se esperaba el último marcador", ev)
+	}
+	mm.mu.RLock()
+	left := len(mm.relays)
+	mm.mu.RUnlock()
+	if left != 0 {
+		t.Fatalf("quedaron %d retransmisiones", left)
+	}
+}
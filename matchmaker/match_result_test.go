@@ -0,0 +1,116 @@
+// matchmaker/match_result_test.go
+//
+// Resultados de la simulación del GameServer: en TEAM_DEATHMATCH la
+// victoria cuenta para todo el equipo ganador, un empate no suma victorias,
+// el historial guarda ganadores y eliminaciones, y AdminUpsertGameMode
+// valida los parámetros de la simulación.
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func upsertMode(t *testing.T, cli pb.MatchmakerClient, mode *pb.GameMode) error {
+	t.Helper()
+	_, err := cli.AdminUpsertGameMode(context.Background(), &pb.AdminUpsertGameModeRequest{Mode: mode})
+	return err
+}
+
+func reportResult(t *testing.T, cli pb.MatchmakerClient, req *pb.MatchResultRequest) {
+	t.Helper()
+	res, err := cli.ReportMatchResult(context.Background(), req)
+	if err != nil || res.GetStatusCode() != pb.MatchResultResponse_OK {
+		t.Fatalf("ReportMatchResult(%s): %v %v", req.GetMatchId(), res.GetStatusCode(), err)
+	}
+}
+
+func TestTeamResultCountsForWholeTeam(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport, testkit.NeverReport)
+	if err := upsertMode(t, r.cli, &pb.GameMode{Name: "2v2", TeamSize: 2, Enabled: true, Scoring: pb.GameMode_TEAM_DEATHMATCH}); err != nil {
+		t.Fatalf("AdminUpsertGameMode: %v", err)
+	}
+	queue(r, "2v2", "A", "B", "C", "D")
+	matchID := r.gs.WaitAssigned(t, 1, time.Second)[0].MatchID
+	events := []*pb.ScoreEvent{
+		{AtMs: 1000, PlayerId: "A", VictimId: "C"},
+		{AtMs: 2000, PlayerId: "B", VictimId: "D"},
+	}
+	reportResult(t, r.cli, &pb.MatchResultRequest{
+		MatchId: matchID, ServerId: r.gs.ID, WinnerId: "A", WinnerIds: []string{"A", "B"}, Events: events,
+	})
+
+	// un empate 1v1 no da victoria a nadie
+	r.queuePair("E", "F")
+	draw := r.gs.WaitAssigned(t, 2, time.Second)[1].MatchID
+	reportResult(t, r.cli, &pb.MatchResultRequest{MatchId: draw, ServerId: r.gs.ID})
+
+	r.inspect(func(ns *namespace) {
+		for pid, wins := range map[string]int{"A": 1, "B": 1, "C": 0, "D": 0, "E": 0, "F": 0} {
+			if st := ns.stats[pid]; st == nil || st.Matches != 1 || st.Wins != wins {
+				t.Errorf("%s: %+v, se esperaba 1 partida y %d victorias", pid, st, wins)
+			}
+		}
+	})
+
+	res, err := r.cli.GetMatchHistory(context.Background(), &pb.MatchHistoryRequest{PlayerId: "C"})
+	if err != nil || len(res.GetMatches()) != 1 {
+		t.Fatalf("GetMatchHistory(C) = %v, %v", res.GetMatches(), err)
+	}
+	rec := res.GetMatches()[0]
+	if !reflect.DeepEqual(rec.GetWinnerIds(), []string{"A", "B"}) || rec.GetWinnerId() != "A" {
+		t.Errorf("ganadores = %v (%s), se esperaba [A B] (A)", rec.GetWinnerIds(), rec.GetWinnerId())
+	}
+	if got := rec.GetScoreEvents(); len(got) != 2 || got[1].GetPlayerId() != "B" || got[1].GetAtMs() != 2000 {
+		t.Errorf("eliminaciones = %v", got)
+	}
+}
+
+func TestUpsertGameModeValidatesSimulation(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	for _, tc := range []struct {
+		name string
+		mode *pb.GameMode
+	}{
+		{"equipos en sala", &pb.GameMode{Name: "x", TeamSize: 1, LobbyMinPlayers: 2, LobbyMaxPlayers: 4, Scoring: pb.GameMode_TEAM_DEATHMATCH}},
+		{"cadencia muy corta", &pb.GameMode{Name: "x", TeamSize: 1, EventIntervalMs: 50}},
+		{"probabilidad > 1", &pb.GameMode{Name: "x", TeamSize: 1, ScoreProb: 1.5}},
+		{"puntos negativos", &pb.GameMode{Name: "x", TeamSize: 1, KillPoints: -1}},
+		{"scoring desconocido", &pb.GameMode{Name: "x", TeamSize: 1, Scoring: 9}},
+	} {
+		if err := upsertMode(t, cli, tc.mode); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: %v, se esperaba InvalidArgument", tc.name, err)
+		}
+	}
+
+	mode := &pb.GameMode{
+		Name: "tdm", TeamSize: 2, Enabled: true, Scoring: pb.GameMode_TEAM_DEATHMATCH,
+		EventIntervalMs: 500, ScoreProb: 0.25, KillPoints: 10, DeathPoints: 5,
+	}
+	if err := upsertMode(t, cli, mode); err != nil {
+		t.Fatalf("AdminUpsertGameMode: %v", err)
+	}
+	res, err := cli.ListGameModes(context.Background(), &pb.ListGameModesRequest{})
+	if err != nil {
+		t.Fatalf("ListGameModes: %v", err)
+	}
+	for _, g := range res.GetModes() {
+		if g.GetName() == "tdm" {
+			if g.GetScoring() != mode.GetScoring() || g.GetEventIntervalMs() != 500 || g.GetScoreProb() != 0.25 ||
+				g.GetKillPoints() != 10 || g.GetDeathPoints() != 5 {
+				t.Errorf("modo guardado = %v", g)
+			}
+			return
+		}
+	}
+	t.Errorf("tdm no aparece en ListGameModes")
+}
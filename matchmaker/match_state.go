@@ -0,0 +1,176 @@
+// matchmaker/match_state.go
+//
+// Ciclo de vida de una partida como máquina de estados explícita:
+//
+//	CREATED ──► ASSIGNED ──► IN_PROGRESS ──► COMPLETED
+//	   │           │  └──────────────────────────▲
+//	   └───────────┴──────────────┴──────────► ABORTED
+//
+// CREATED al formarse (y durante el ready-check), ASSIGNED al enviar el
+// AssignMatch, IN_PROGRESS cuando el GameServer la acepta o la informa en
+// curso, COMPLETED con su resultado y ABORTED con cualquier otro cierre
+// (abandono, servidor caído, plazo vencido, asignación fallida…). ASSIGNED
+// puede pasar directo a COMPLETED porque el resultado de una partida corta
+// puede llegar antes que la respuesta al AssignMatch.
+//
+// Los hitos de la línea de tiempo (match_timeline.go) mueven la fase; una
+// actualización que la fase no admite —el resultado o el aviso de "en
+// curso" de una partida que todavía no se envió a ningún servidor, o el
+// resultado enviado por otro servidor— se rechaza, se registra y se cuenta
+// en matchmaker_match_updates_rejected_total. La fase no se replica: el
+// respaldo la reconstruye desde la línea de tiempo.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// matchPhase sigue el orden de pb.MatchPhase.
+type matchPhase int
+
+const (
+	phaseCreated matchPhase = iota
+	phaseAssigned
+	phaseInProgress
+	phaseCompleted
+	phaseAborted
+	numPhases
+)
+
+func (p matchPhase) String() string {
+	switch p {
+	case phaseCreated:
+		return "CREATED"
+	case phaseAssigned:
+		return "ASSIGNED"
+	case phaseInProgress:
+		return "IN_PROGRESS"
+	case phaseCompleted:
+		return "COMPLETED"
+	case phaseAborted:
+		return "ABORTED"
+	}
+	return fmt.Sprintf("PHASE_%d", int(p))
+}
+
+// matchTransitions son las transiciones válidas desde cada fase no terminal.
+var matchTransitions = map[matchPhase][]matchPhase{
+	phaseCreated:    {phaseAssigned, phaseAborted},
+	phaseAssigned:   {phaseInProgress, phaseCompleted, phaseAborted},
+	phaseInProgress: {phaseCompleted, phaseAborted},
+}
+
+// stagePhases son los hitos de la línea de tiempo que adelantan la fase.
+var stagePhases = map[pb.MatchEvent_Stage]matchPhase{
+	pb.MatchEvent_ASSIGNED: phaseAssigned,
+	pb.MatchEvent_ACCEPTED: phaseInProgress,
+	pb.MatchEvent_STARTED:  phaseInProgress,
+}
+
+// transition pasa la partida a la fase to y anota cuándo entró por primera
+// vez. Quedarse en la misma fase no es un error.
+func (am *activeMatch) transition(to matchPhase, at time.Time) error {
+	if am.Phase == to {
+		return nil
+	}
+	for _, next := range matchTransitions[am.Phase] {
+		if next == to {
+			am.Phase = to
+			if am.PhaseAt[to].IsZero() {
+				am.PhaseAt[to] = at
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("transición %s → %s no permitida", am.Phase, to)
+}
+
+// restorePhase reconstruye la fase y sus horas desde la línea de tiempo
+// (snapshot del primario o partida recuperada de un GameServer).
+func (am *activeMatch) restorePhase() {
+	am.Phase, am.PhaseAt = phaseCreated, [numPhases]time.Time{}
+	am.PhaseAt[phaseCreated] = am.StartedAt
+	for _, e := range am.Timeline {
+		if e.Stage == pb.MatchEvent_MATCHED {
+			am.PhaseAt[phaseCreated] = e.At
+		}
+		if to, ok := stagePhases[e.Stage]; ok && am.Phase < to {
+			// una recuperada pasa de CREATED a IN_PROGRESS sin AssignMatch
+			am.Phase = to
+			am.PhaseAt[to] = e.At
+		}
+	}
+}
+
+// advanceMatch adelanta la fase de am por el hito stage; un hito que no la
+// adelanta (un reintento de AssignMatch en una partida ya en curso) no la
+// cambia. Devuelve false, tras registrarlo, si la fase no lo admite.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) advanceMatch(ns *namespace, matchID string, am *activeMatch, stage pb.MatchEvent_Stage) bool {
+	to, ok := stagePhases[stage]
+	if !ok || am.Phase >= to {
+		return true
+	}
+	if err := am.transition(to, m.wall.Now()); err != nil {
+		m.rejectMatchUpdate(ns, matchID, "started", err)
+		return false
+	}
+	return true
+}
+
+// rejectMatchUpdate registra una actualización que la fase de la partida
+// no admite.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) rejectMatchUpdate(ns *namespace, matchID, update string, err error) {
+	m.metrics.matchRejects.With(ns.name, update).Inc()
+	m.logf("[%s] Actualización %s de la partida %s rechazada: %v", ns.name, update, matchID, err)
+}
+
+// closeMatch saca la partida de las activas en su fase terminal: COMPLETED
+// con resultado, ABORTED en otro caso.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) closeMatch(ns *namespace, matchID string, am *activeMatch, to matchPhase) {
+	if err := am.transition(to, m.wall.Now()); err != nil {
+		m.logf("[%s] Partida %s cerrada desde %s: %v", ns.name, matchID, am.Phase, err)
+	}
+	ns.matches.Delete(matchID)
+}
+
+// matchStatuses arma la vista de las partidas activas para
+// AdminGetSystemStatus, ordenadas por id.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) matchStatuses() []*pb.MatchStatus {
+	ids := make([]string, 0, ns.matches.Len())
+	for id := range ns.matches.All() {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]*pb.MatchStatus, 0, len(ids))
+	for _, id := range ids {
+		am := ns.matches.Value(id)
+		out = append(out, &pb.MatchStatus{
+			MatchId:          id,
+			GameMode:         am.Mode,
+			ServerId:         am.ServerID,
+			PlayerIds:        am.Players,
+			Phase:            pb.MatchPhase(am.Phase),
+			CreatedUnixMs:    am.phaseUnixMs(phaseCreated),
+			AssignedUnixMs:   am.phaseUnixMs(phaseAssigned),
+			InProgressUnixMs: am.phaseUnixMs(phaseInProgress),
+		})
+	}
+	return out
+}
+
+// phaseUnixMs es la hora en que la partida entró a p; 0 si no llegó.
+func (am *activeMatch) phaseUnixMs(p matchPhase) int64 {
+	if am.PhaseAt[p].IsZero() {
+		return 0
+	}
+	return am.PhaseAt[p].UnixMilli()
+}
@@ -0,0 +1,112 @@
+// matchmaker/match_state_test.go
+//
+// Ciclo de vida de las partidas: sólo las transiciones del diagrama son
+// válidas, el resultado de una partida sin asignar o enviado por otro
+// servidor se rechaza, y AdminGetSystemStatus muestra la fase de cada
+// partida activa con la hora de entrada a cada una.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestMatchPhaseTransitions(t *testing.T) {
+	cases := []struct {
+		from, to matchPhase
+		ok       bool
+	}{
+		{phaseCreated, phaseAssigned, true},
+		{phaseCreated, phaseAborted, true},
+		{phaseCreated, phaseInProgress, false},
+		{phaseCreated, phaseCompleted, false},
+		{phaseAssigned, phaseInProgress, true},
+		{phaseAssigned, phaseCompleted, true},
+		{phaseAssigned, phaseAssigned, true},
+		{phaseInProgress, phaseAssigned, false},
+		{phaseInProgress, phaseCompleted, true},
+		{phaseCompleted, phaseAborted, false},
+		{phaseAborted, phaseInProgress, false},
+	}
+	at := time.UnixMilli(1000)
+	for _, c := range cases {
+		am := &activeMatch{Phase: c.from}
+		err := am.transition(c.to, at)
+		if (err == nil) != c.ok {
+			t.Errorf("%s → %s: error %v, se esperaba válida=%v", c.from, c.to, err, c.ok)
+			continue
+		}
+		if err != nil && am.Phase != c.from {
+			t.Errorf("%s → %s rechazada pero la fase pasó a %s", c.from, c.to, am.Phase)
+		}
+		if err == nil && c.from != c.to && !am.PhaseAt[c.to].Equal(at) {
+			t.Errorf("%s → %s: hora de entrada %v", c.from, c.to, am.PhaseAt[c.to])
+		}
+	}
+}
+
+// matchPhaseOf devuelve la fila de AdminGetSystemStatus de matchID.
+func matchPhaseOf(t *testing.T, cli pb.MatchmakerClient, matchID string) *pb.MatchStatus {
+	t.Helper()
+	res, err := cli.AdminGetSystemStatus(context.Background(), &pb.AdminRequest{})
+	if err != nil {
+		t.Fatalf("AdminGetSystemStatus: %v", err)
+	}
+	for _, mt := range res.GetMatches() {
+		if mt.GetMatchId() == matchID {
+			return mt
+		}
+	}
+	return nil
+}
+
+func TestMatchPhaseRejectsIllegalResults(t *testing.T) {
+	// con confirmación previa la partida queda en CREATED hasta que la
+	// acepten; el servidor no informa resultados por su cuenta
+	r := newFailureRig(t, testkit.NeverReport)
+	r.mm.mu.Lock()
+	r.mm.readyTimeout = time.Minute
+	r.mm.mu.Unlock()
+	r.queuePair("A", "B")
+	var matchID string
+	r.inspect(func(ns *namespace) { matchID = ns.players.Value("A").MatchID })
+
+	mt := matchPhaseOf(t, r.cli, matchID)
+	if mt.GetPhase() != pb.MatchPhase_MATCH_PHASE_CREATED || mt.GetCreatedUnixMs() == 0 || mt.GetAssignedUnixMs() != 0 {
+		t.Fatalf("en ready-check: %v, se esperaba CREATED sin hora de asignación", mt)
+	}
+	res, err := r.cli.ReportMatchResult(context.Background(), &pb.MatchResultRequest{MatchId: matchID, ServerId: r.gs.ID, WinnerId: "A"})
+	if err != nil || res.GetStatusCode() != pb.MatchResultResponse_NOT_ASSIGNED {
+		t.Fatalf("resultado sin asignar: %v %v, se esperaba NOT_ASSIGNED", res.GetStatusCode(), err)
+	}
+
+	acceptMatch(t, r.cli, "A", matchID, true)
+	acceptMatch(t, r.cli, "B", matchID, true)
+	r.gs.WaitAssigned(t, 1, time.Second)
+
+	res, err = r.cli.ReportMatchResult(context.Background(), &pb.MatchResultRequest{MatchId: matchID, ServerId: "otro", WinnerId: "A"})
+	if err != nil || res.GetStatusCode() != pb.MatchResultResponse_NOT_OWNER {
+		t.Fatalf("resultado de otro servidor: %v %v, se esperaba NOT_OWNER", res.GetStatusCode(), err)
+	}
+	mt = matchPhaseOf(t, r.cli, matchID)
+	if mt.GetPhase() == pb.MatchPhase_MATCH_PHASE_CREATED || mt.GetAssignedUnixMs() < mt.GetCreatedUnixMs() {
+		t.Fatalf("asignada: %v, se esperaba ASSIGNED o IN_PROGRESS", mt)
+	}
+	r.inspect(func(ns *namespace) {
+		for _, pid := range []string{"A", "B"} {
+			if p := ns.players.Value(pid); p.Status != playerInMatch {
+				t.Errorf("%s: %s tras los resultados rechazados, se esperaba en partida", pid, p.Status)
+			}
+		}
+	})
+
+	reportResult(t, r.cli, &pb.MatchResultRequest{MatchId: matchID, ServerId: r.gs.ID, WinnerId: "A"})
+	if mt := matchPhaseOf(t, r.cli, matchID); mt != nil {
+		t.Errorf("la partida cerrada sigue activa: %v", mt)
+	}
+}
@@ -0,0 +1,182 @@
+// matchmaker/match_timeline.go
+//
+// Línea de tiempo de cada partida: cuándo entró a la cola cada jugador,
+// cuándo se formó la partida, cuándo se envió y aceptó el AssignMatch,
+// cuándo el GameServer la informó en curso y cuándo terminó. Cada hito
+// guarda la hora de pared y el reloj del namespace, de modo que sirve tanto
+// para desglosar la latencia de punta a punta como para razonar sobre el
+// orden causal. La línea de tiempo viaja con la partida (activa o en el
+// historial) y se replica con ella.
+//
+// El GameServer informa su nuevo estado antes de responder al AssignMatch,
+// así que STARTED suele preceder a ACCEPTED en hora de pared.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+type matchEvent struct {
+	Stage    pb.MatchEvent_Stage
+	At       time.Time
+	VC       *clocks.Vector
+	PlayerID string // sólo en QUEUED
+	Detail   string
+}
+
+func (e matchEvent) toProto() *pb.MatchEvent {
+	out := &pb.MatchEvent{
+		Stage:    e.Stage,
+		UnixMs:   e.At.UnixMilli(),
+		PlayerId: e.PlayerID,
+		Detail:   e.Detail,
+	}
+	if e.VC != nil {
+		out.VectorClock = e.VC.ToProto()
+	}
+	return out
+}
+
+func timelineProto(events []matchEvent) []*pb.MatchEvent {
+	out := make([]*pb.MatchEvent, 0, len(events))
+	for _, e := range events {
+		out = append(out, e.toProto())
+	}
+	return out
+}
+
+func timelineFromProto(events []*pb.MatchEvent) []matchEvent {
+	var out []matchEvent
+	for _, e := range events {
+		out = append(out, matchEvent{
+			Stage:    e.GetStage(),
+			At:       time.UnixMilli(e.GetUnixMs()),
+			VC:       clocks.FromProto(e.GetVectorClock()),
+			PlayerID: e.GetPlayerId(),
+			Detail:   e.GetDetail(),
+		})
+	}
+	return out
+}
+
+// newEvent fotografía la hora y el reloj actuales del namespace.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) newEvent(ns *namespace, stage pb.MatchEvent_Stage, detail string) matchEvent {
+	return matchEvent{Stage: stage, At: m.wall.Now(), VC: ns.vc.Copy(), Detail: detail}
+}
+
+// markMatch agrega un hito a una partida en curso y adelanta su fase
+// (match_state.go); una partida ya cerrada o desconocida se ignora, y un
+// hito que su fase no admite se rechaza. Con once, el hito se agrega sólo
+// la primera vez.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) markMatch(ns *namespace, matchID string, stage pb.MatchEvent_Stage, detail string, once bool) {
+	am, ok := ns.matches.Get(matchID)
+	if !ok {
+		return
+	}
+	if once {
+		for _, e := range am.Timeline {
+			if e.Stage == stage {
+				return
+			}
+		}
+	}
+	if !m.advanceMatch(ns, matchID, am, stage) {
+		return
+	}
+	am.Timeline = append(am.Timeline, m.newEvent(ns, stage, detail))
+}
+
+// markMatchLocked es markMatch para quien no tiene m.mu (el envío de
+// AssignMatch corre en su propia goroutine).
+func (m *matchmaker) markMatchLocked(ns *namespace, matchID string, stage pb.MatchEvent_Stage, detail string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.markMatch(ns, matchID, stage, detail, false)
+}
+
+// markStarted registra STARTED en las partidas de srv que la actualización
+// declara en curso (match_id o running_matches).
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) markStarted(ns *namespace, srv *gameServerInfo, req *pb.ServerStatusUpdateRequest) {
+	ids := []string{req.GetMatchId()}
+	for _, rm := range req.GetRunningMatches() {
+		ids = append(ids, rm.GetMatchId())
+	}
+	for _, id := range ids {
+		if am, ok := ns.matches.Get(id); ok && am.ServerID == srv.ID {
+			m.markMatch(ns, id, pb.MatchEvent_STARTED, srv.ID, true)
+		}
+	}
+}
+
+// queuedEvents arma los hitos QUEUED de los jugadores de una partida a
+// partir de cuándo se encolaron.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) queuedEvents(players []string) []matchEvent {
+	var out []matchEvent
+	for _, pid := range players {
+		p, ok := ns.players.Get(pid)
+		if !ok {
+			continue
+		}
+		out = append(out, matchEvent{Stage: pb.MatchEvent_QUEUED, At: p.LastOp, VC: p.QueuedVC, PlayerID: pid})
+	}
+	return out
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: GetMatchTimeline – hitos de una partida
+───────────────────────────────────────────────────────────────────────────────*/
+
+// GetMatchTimeline no modifica estado. Busca la partida entre las activas y
+// en el historial.
+func (m *matchmaker) GetMatchTimeline(ctx context.Context, req *pb.MatchTimelineRequest) (*pb.MatchTimelineResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return &pb.MatchTimelineResponse{}, nil
+	}
+	out := &pb.MatchTimelineResponse{MatchId: req.GetMatchId(), VectorClock: m.clockProto(ns)}
+
+	var events []matchEvent
+	if am, ok := ns.matches.Get(req.GetMatchId()); ok {
+		out.GameMode, out.ServerId, events = am.Mode, am.ServerID, am.Timeline
+		out.ServerAddr = ns.serverAddrForMatch(req.GetMatchId())
+	} else if res := ns.findMatch(req.GetMatchId()); res != nil {
+		out.GameMode, out.ServerId, events = res.Mode, res.ServerID, res.Timeline
+	} else {
+		return out, nil
+	}
+	out.Found = true
+	out.Events = timelineProto(events)
+	sort.SliceStable(out.Events, func(i, j int) bool {
+		a, b := out.Events[i], out.Events[j]
+		if a.GetStage() != b.GetStage() {
+			return a.GetStage() < b.GetStage()
+		}
+		return a.GetUnixMs() < b.GetUnixMs()
+	})
+	return out, nil
+}
+
+// findMatch busca una partida terminada en el historial, con o sin
+// resultado.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) findMatch(matchID string) *matchResult {
+	for i := len(ns.history) - 1; i >= 0; i-- {
+		if ns.history[i].MatchID == matchID {
+			return ns.history[i]
+		}
+	}
+	return nil
+}
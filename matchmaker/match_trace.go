@@ -0,0 +1,76 @@
+// matchmaker/match_trace.go
+//
+// Traza de punta a punta de una partida (ver internal/tracing). Los
+// interceptores abren un span por RPC; aquí se unen los que pertenecen a la
+// misma partida aunque ocurran en goroutines y procesos distintos:
+//
+//	QueuePlayer ─▶ FormMatch ─▶ AssignMatch ─▶ (GameServer) ─▶ ServerStatusUpdate
+//	                                                       └─▶ ReportMatchResult
+//
+// FormMatch es hijo del QueuePlayer del primer jugador y enlaza los de los
+// demás. El GameServer continúa la traza que recibe en AssignMatch; sus
+// actualizaciones por el stream Heartbeat la traen en el campo traceparent.
+
+package main
+
+import (
+	"context"
+
+	"github.com/vimsent/L3/internal/tracing"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// traceMatch registra el span FormMatch de una partida recién formada y
+// devuelve su contexto, padre de las llamadas al GameServer.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) traceMatch(ns *namespace, matchID, mode string, srv *gameServerInfo, players []string) tracing.SpanContext {
+	if !tracing.Enabled() {
+		return tracing.SpanContext{}
+	}
+	var parent tracing.SpanContext
+	var links []tracing.SpanContext
+	seen := make(map[tracing.SpanID]bool) // un grupo comparte el span de su líder
+	for _, pid := range players {
+		p, ok := ns.players.Get(pid)
+		if !ok || !p.QueueSpan.IsValid() || seen[p.QueueSpan.SpanID] {
+			continue
+		}
+		seen[p.QueueSpan.SpanID] = true
+		if !parent.IsValid() {
+			parent = p.QueueSpan
+		} else {
+			links = append(links, p.QueueSpan)
+		}
+	}
+	_, span := tracing.StartLinked(tracing.WithParent(context.Background(), parent), "FormMatch", links,
+		tracing.String("match.id", matchID),
+		tracing.String("match.mode", mode),
+		tracing.String("match.namespace", ns.name),
+		tracing.String("server.id", srv.ID),
+		tracing.Strings("match.players", players),
+	)
+	span.End()
+	return span.SpanContext()
+}
+
+// matchContext devuelve un contexto sin plazo con la traza de la partida.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) matchContext(matchID string) context.Context {
+	if am, ok := ns.matches.Get(matchID); ok {
+		return tracing.WithParent(context.Background(), am.Trace)
+	}
+	return context.Background()
+}
+
+// traceStatusUpdate abre el span de una actualización recibida por el
+// stream Heartbeat que trae la traza de su partida; si no la trae devuelve
+// ctx y un span nil, que no registra nada.
+func traceStatusUpdate(ctx context.Context, req *pb.ServerStatusUpdateRequest) (context.Context, *tracing.Span) {
+	if req.GetTraceparent() == "" {
+		return ctx, nil
+	}
+	return tracing.Start(tracing.FromTraceparent(ctx, req.GetTraceparent()), "ServerStatusUpdate",
+		tracing.String("server.id", req.GetServerId()),
+		tracing.String("server.status", req.GetNewStatus().String()),
+		tracing.String("match.id", req.GetMatchId()))
+}
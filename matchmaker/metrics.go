@@ -0,0 +1,198 @@
+// matchmaker/metrics.go
+//
+// Endpoint HTTP /metrics en formato Prometheus: largo de cola, espera en
+// cola y equidad (fairness.go), partidas creadas, fallos de asignación, partidas huérfanas, cumplimiento de los SLO
+// de espera, servidores por estado y latencia de cada RPC unaria. Los gauges
+// se recalculan desde el estado en cada scrape.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/metrics"
+)
+
+const defaultMetricsPort = 2112
+
+type mmMetrics struct {
+	reg *metrics.Registry
+
+	queueLength    *metrics.Gauge
+	queueWait      *metrics.Histogram
+	oldestWait     *metrics.Gauge
+	matchesRelaxed *metrics.Counter
+	servers        *metrics.Gauge
+	matchesCreated *metrics.Counter
+	backfilled     *metrics.Counter
+	assignFailures *metrics.Counter
+	assignRetries  *metrics.Counter
+	orphanMatches  *metrics.Counter
+	sloCompliance  *metrics.Gauge
+	sloBurnRate    *metrics.Gauge
+	sloAlerts      *metrics.Counter
+	rywRetries     *metrics.Counter
+	statusReads    *metrics.Counter
+	configReloads  *metrics.Counter
+	authRejects    *metrics.Counter
+	rateLimited    *metrics.Counter
+	autoscale      *metrics.Counter
+	queueTimeouts  *metrics.Counter
+	playerLost     *metrics.Counter
+	protocol       *metrics.Counter
+	readyChecks    *metrics.Counter
+	serverReorder  *metrics.Counter
+	sessions       *metrics.Counter
+	relayEvents    *metrics.Counter
+	relaySubs      *metrics.Gauge
+	serverResyncs  *metrics.Counter
+	matchRejects   *metrics.Counter
+	rematches      *metrics.Counter
+	matchRuns      *metrics.Counter
+	rpcDuration    *metrics.Histogram
+}
+
+func newMMMetrics(m *matchmaker) *mmMetrics {
+	reg := metrics.NewRegistry()
+	mt := &mmMetrics{
+		reg: reg,
+		queueLength: reg.NewGauge("matchmaker_queue_length",
+			"Jugadores esperando en la cola.", "namespace"),
+		queueWait: reg.NewHistogram("matchmaker_queue_wait_seconds",
+			"Espera en cola de los jugadores emparejados.", queueWaitBuckets, "namespace", "mode"),
+		oldestWait: reg.NewGauge("matchmaker_queue_oldest_wait_seconds",
+			"Espera del jugador que más lleva en la cola.", "namespace", "mode"),
+		matchesRelaxed: reg.NewCounter("matchmaker_matches_relaxed_total",
+			"Partidas formadas relajando la política por la espera: region (QUEUE_RELAX_AFTER) o max_wait (QUEUE_MAX_WAIT).", "namespace", "mode", "stage"),
+		servers: reg.NewGauge("matchmaker_servers",
+			"Servidores de partida registrados por estado.", "namespace", "state"),
+		matchesCreated: reg.NewCounter("matchmaker_matches_created_total",
+			"Partidas formadas por el bucle de emparejamiento.", "namespace", "mode"),
+		backfilled: reg.NewCounter("matchmaker_backfilled_players_total",
+			"Jugadores de la cola sumados a salas en curso (RequestBackfill).", "namespace", "mode"),
+		assignFailures: reg.NewCounter("matchmaker_assignment_failures_total",
+			"Asignaciones abandonadas tras agotar reintentos y servidores (los jugadores vuelven a la cola).", "namespace"),
+		assignRetries: reg.NewCounter("matchmaker_assignment_retries_total",
+			"Reintentos de AssignMatch en el mismo servidor (retry) o en otro (failover).", "namespace", "kind"),
+		orphanMatches: reg.NewCounter("matchmaker_orphaned_matches_total",
+			"Partidas cerradas sin resultado (servidor caído, expiradas o abortadas por el servidor).", "namespace", "reason"),
+		sloCompliance: reg.NewGauge("matchmaker_slo_compliance_ratio",
+			"Fracción de jugadores emparejados dentro del umbral del SLO.", "namespace", "mode"),
+		sloBurnRate: reg.NewGauge("matchmaker_slo_burn_rate",
+			"Consumo del presupuesto de error del SLO (1 = al ritmo permitido).", "namespace", "mode"),
+		sloAlerts: reg.NewCounter("matchmaker_slo_alerts_total",
+			"Veces que un SLO de espera pasó a estar en riesgo.", "namespace", "mode"),
+		rywRetries: reg.NewCounter("matchmaker_ryw_retry_later_total",
+			"GetPlayerStatus respondidos RETRY_LATER por no reflejar las escrituras del cliente.", "namespace"),
+		statusReads: reg.NewCounter("matchmaker_status_reads_total",
+			"GetPlayerStatus respondidos por consistencia pedida y cumplida (STRONG cumple read_your_writes si no pudo esperar a la asignación).", "namespace", "requested", "honored"),
+		configReloads: reg.NewCounter("matchmaker_config_reloads_total",
+			"Recargas de MATCHMAKER_CONFIG (SIGHUP o AdminReloadConfig) por resultado.", "result"),
+		authRejects: reg.NewCounter("matchmaker_player_auth_rejections_total",
+			"RPC de jugador y registros rechazados por el token (PLAYER_AUTH=required).", "reason"),
+		rateLimited: reg.NewCounter("matchmaker_rate_limited_total",
+			"QueuePlayer rechazados por el límite de frecuencia, por bucket (player o global).", "scope"),
+		autoscale: reg.NewCounter("matchmaker_autoscale_total",
+			"Acciones del escalado automático: up, down, timeout (pedido sin registrarse) o error.", "action"),
+		queueTimeouts: reg.NewCounter("matchmaker_queue_timeouts_total",
+			"Jugadores sacados de la cola por vencer QUEUE_TIMEOUT.", "namespace", "mode"),
+		playerLost: reg.NewCounter("matchmaker_player_heartbeat_lost_total",
+			"Jugadores sacados de la cola o de su sala privada por dejar de enviar PlayerHeartbeat.", "namespace"),
+		protocol: reg.NewCounter("matchmaker_rpc_protocol_total",
+			"RPC por versión de protocolo del cliente (x-protocol-version) y resultado: accepted o rejected.", "version", "result"),
+		readyChecks: reg.NewCounter("matchmaker_ready_checks_total",
+			"Confirmaciones previas cerradas: passed, declined, timeout o server_lost.", "namespace", "result"),
+		serverReorder: reg.NewCounter("matchmaker_server_updates_reordered_total",
+			"Actualizaciones de servidor fuera de orden causal: buffered (retenida), stale (descartada por vieja) o forced (entregada al vencer la espera).", "namespace", "outcome"),
+		sessions: reg.NewCounter("matchmaker_session_conflicts_total",
+			"RPC de jugador con una sesión en conflicto: rejected (otra sesión viva), fenced (reemplazó a la viva) o stale (de una sesión reemplazada).", "namespace", "outcome"),
+		relayEvents: reg.NewCounter("matchmaker_relay_events_total",
+			"Eventos de partida retransmitidos (PublishMatchEvents): received, delivered (copias a suscriptores), dropped (suscriptor lento) o rejected (servidor sin la partida).", "namespace", "outcome"),
+		relaySubs: reg.NewGauge("matchmaker_relay_subscribers",
+			"Suscriptores mirando partidas a través del Matchmaker (SubscribeMatchEvents).", "namespace"),
+		serverResyncs: reg.NewCounter("matchmaker_server_resyncs_total",
+			"Actualizaciones de GameServers que traían la época de una ejecución anterior del Matchmaker (re-sincronización).", "namespace"),
+		matchRejects: reg.NewCounter("matchmaker_match_updates_rejected_total",
+			"Actualizaciones de partida que su fase no admite (match_state.go): result (resultado sin asignar o de otro servidor) o started (en curso antes del AssignMatch).", "namespace", "update"),
+		rematches: reg.NewCounter("matchmaker_rematches_total",
+			"Revanchas cerradas (rematch.go): affinity (en el mismo servidor), moved (en otro), cancelled o expired.", "namespace", "result"),
+		matchRuns: reg.NewCounter("matchmaker_match_runs_total",
+			"Vueltas del bucle de emparejamiento por origen: tick (MATCH_CHECK_PERIOD) o event (MATCH_TRIGGER=event).", "trigger"),
+		rpcDuration: reg.NewHistogram("matchmaker_rpc_duration_seconds",
+			"Latencia de las RPC unarias.", metrics.DefaultBuckets, "method", "code"),
+	}
+	reg.OnScrape(func() { mt.collectState(m) })
+	return mt
+}
+
+// collectState recalcula los gauges que dependen del estado en memoria.
+func (mt *mmMetrics) collectState(m *matchmaker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mt.queueLength.Reset()
+	mt.oldestWait.Reset()
+	mt.servers.Reset()
+	mt.relaySubs.Reset()
+	now := m.wall.Now()
+	for _, ns := range m.namespaces {
+		mt.queueLength.Set(float64(ns.queue.Len()), ns.name)
+		for mode, wait := range ns.oldestWaits(now) {
+			mt.oldestWait.Set(wait.Seconds(), ns.name, mode)
+		}
+
+		byState := make(map[string]int)
+		for _, s := range ns.servers.All() {
+			byState[serverStatusProto(s.Status).String()]++
+		}
+		for st, n := range byState {
+			mt.servers.Set(float64(n), ns.name, st)
+		}
+	}
+	subs := make(map[string]int)
+	for k, r := range m.relays {
+		subs[k.Namespace] += len(r.subs)
+	}
+	for ns, n := range subs {
+		mt.relaySubs.Set(float64(n), ns)
+	}
+
+	mt.sloCompliance.Reset()
+	mt.sloBurnRate.Reset()
+	for k, st := range m.sloStates {
+		mt.sloCompliance.Set(st.Compliance, k.Namespace, k.Mode)
+		mt.sloBurnRate.Set(st.BurnRate, k.Namespace, k.Mode)
+	}
+}
+
+// metricsUnaryInterceptor mide la latencia de cada RPC unaria.
+func (m *matchmaker) metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.metrics.rpcDuration.Observe(time.Since(start).Seconds(), path.Base(info.FullMethod), status.Code(err).String())
+	return resp, err
+}
+
+// serveMetrics expone /metrics; un error aquí no detiene el Matchmaker.
+func (m *matchmaker) serveMetrics(port int) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.metrics.reg)
+	srv := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux}
+
+	go func() {
+		<-m.done
+		srv.Close()
+	}()
+
+	m.logf("Métricas Prometheus en :%d/metrics", port)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		m.logf("ERROR: endpoint de métricas detenido: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+// matchmaker/moderation.go
+//
+// Moderación de jugadores desde el cliente administrador. AdminKickPlayer
+// saca a un jugador de la cola (con todo su grupo, que sólo se empareja
+// completo), de su sala privada o del registro de su partida en curso.
+// AdminBanPlayer además lo saca de su grupo y lo agrega a la lista de
+// baneados del namespace, que QueuePlayer consulta antes de encolar; con
+// lift=true levanta el ban.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// kickPlayer saca a pi de la cola, de su sala privada o de su partida y le
+// avisa. Devuelve una descripción de lo hecho, o "" si el jugador estaba
+// inactivo.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) kickPlayer(ns *namespace, pi *playerInfo) string {
+	switch pi.Status {
+	case playerInQueue:
+		members := []string{pi.ID}
+		if pt, ok := ns.parties[pi.PartyID]; ok {
+			members = pt.Members
+		}
+		for _, pid := range members {
+			if p, ok := ns.players.Get(pid); ok && p.Status == playerInQueue {
+				ns.removeFromQueue(pid)
+				p.Status = playerIdle
+				p.LastOp = m.wall.Now()
+				m.notify(ns, pid, &pb.MatchUpdate{Event: pb.MatchUpdate_REMOVED})
+			}
+		}
+		m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_DEQUEUED, PlayerIds: members, Detail: "expulsado por el administrador"})
+		if len(members) > 1 {
+			return fmt.Sprintf("sacado de la cola junto a su grupo %v", members)
+		}
+		return "sacado de la cola"
+
+	case playerInMatch:
+		matchID := pi.MatchID
+		if am, ok := ns.matches.Get(matchID); ok {
+			for i, pid := range am.Players {
+				if pid == pi.ID {
+					am.Players = append(am.Players[:i:i], am.Players[i+1:]...)
+					break
+				}
+			}
+		}
+		pi.Status, pi.MatchID = playerIdle, ""
+		pi.LastOp = m.wall.Now()
+		m.notify(ns, pi.ID, &pb.MatchUpdate{Event: pb.MatchUpdate_REMOVED, MatchId: matchID})
+		return "sacado de la partida " + matchID
+	}
+	if code := pi.LobbyCode; code != "" {
+		ns.leaveLobby(pi)
+		pi.LastOp = m.wall.Now()
+		m.notify(ns, pi.ID, &pb.MatchUpdate{Event: pb.MatchUpdate_REMOVED})
+		return "sacado de la sala privada " + code
+	}
+	return ""
+}
+
+// bannedIn devuelve el primer jugador baneado de la lista, o "".
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) bannedIn(players []string) string {
+	for _, pid := range players {
+		if _, ok := ns.banned[pid]; ok {
+			return pid
+		}
+	}
+	return ""
+}
+
+// bannedProto lista los baneados ordenados por ID.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) bannedProto() []*pb.BannedPlayer {
+	out := make([]*pb.BannedPlayer, 0, len(ns.banned))
+	for pid, reason := range ns.banned {
+		out = append(out, &pb.BannedPlayer{PlayerId: pid, Reason: reason})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].PlayerId < out[j].PlayerId })
+	return out
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+          RPC: AdminKickPlayer / AdminBanPlayer – moderación de jugadores
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminKickPlayer(ctx context.Context, req *pb.AdminPlayerActionRequest) (*pb.AdminUpdateResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_NOT_FOUND,
+			Message:     fmt.Sprintf("Jugador %s desconocido", playerID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	prev := pi.Status
+	what := m.kickPlayer(ns, pi)
+	if what == "" {
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_NOT_FOUND,
+			Message:     fmt.Sprintf("Jugador %s no está en cola ni en partida", playerID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	auditChange(ctx, playerID, prev.String(), pi.Status.String())
+
+	m.logf("[%s] Jugador %s expulsado: %s (motivo: %q)", ns.name, playerID, what, req.GetReason())
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     fmt.Sprintf("Jugador %s %s", playerID, what),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+func (m *matchmaker) AdminBanPlayer(ctx context.Context, req *pb.AdminPlayerActionRequest) (*pb.AdminUpdateResponse, error) {
+	playerID := req.GetPlayerId()
+	if playerID == "" {
+		return &pb.AdminUpdateResponse{
+			Status:  pb.AdminUpdateResponse_NOT_FOUND,
+			Message: "Falta el ID de jugador",
+		}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	if req.GetLift() {
+		if _, ok := ns.banned[playerID]; !ok {
+			return &pb.AdminUpdateResponse{
+				Status:      pb.AdminUpdateResponse_NOT_FOUND,
+				Message:     fmt.Sprintf("Jugador %s no está baneado", playerID),
+				VectorClock: m.clockProto(ns),
+			}, nil
+		}
+		auditChange(ctx, playerID, "baneado: "+ns.banned[playerID], "")
+		delete(ns.banned, playerID)
+		m.logf("[%s] Ban de %s levantado", ns.name, playerID)
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_OK,
+			Message:     "Ban levantado",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	// como el nivel, el ban puede aplicarse antes de que el jugador se conecte
+	prev := ""
+	if reason, ok := ns.banned[playerID]; ok {
+		prev = "baneado: " + reason
+	}
+	ns.banned[playerID] = req.GetReason()
+	auditChange(ctx, playerID, prev, "baneado: "+req.GetReason())
+	msg := "Jugador baneado"
+	if pi, ok := ns.players.Get(playerID); ok {
+		if what := m.kickPlayer(ns, pi); what != "" {
+			msg += " y " + what
+		}
+		if pi.PartyID != "" {
+			partyID := pi.PartyID
+			ns.leaveParty(pi)
+			msg += "; salió del grupo " + partyID
+		}
+	}
+
+	m.logf("[%s] %s: %s (motivo: %q)", ns.name, msg, playerID, req.GetReason())
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     msg,
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
@@ -0,0 +1,168 @@
+// matchmaker/orphan_matches.go
+//
+// Barrido de partidas huérfanas. Si un GameServer se cae a mitad de partida
+// nunca llega su ReportMatchResult: la partida y el estado IN_MATCH de sus
+// jugadores quedarían para siempre. En cada vuelta del bucle de
+// emparejamiento se cierran las partidas cuyo servidor está DOWN (o ya no
+// existe) y las que superan MATCH_TIMEOUT; sus jugadores vuelven a IDLE o,
+// con ORPHAN_ACTION=requeue, a la cola con prioridad REQUEUED.
+//
+// Un GameServer que se reinicia y encuentra en su archivo de estado
+// partidas que ya no puede terminar las cierra de inmediato con AbortMatch,
+// sin esperar al barrido.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	orphanIdle    = "idle"
+	orphanRequeue = "requeue"
+)
+
+type orphanPolicy struct {
+	timeout time.Duration // duración máxima de una partida
+	requeue bool          // true: los jugadores vuelven a la cola
+}
+
+func defaultOrphanPolicy() orphanPolicy {
+	return orphanPolicy{timeout: 5 * time.Minute}
+}
+
+// limit es el tiempo que puede durar una partida del modo dado: el timeout
+// global o, si es mayor, el doble de la pista de duración del modo.
+func (op orphanPolicy) limit(mode *gameMode) time.Duration {
+	if mode != nil {
+		if hint := 2 * time.Duration(mode.MaxDuration) * time.Second; hint > op.timeout {
+			return hint
+		}
+	}
+	return op.timeout
+}
+
+// sweepOrphanMatches cierra las partidas huérfanas de todos los namespaces.
+func (m *matchmaker) sweepOrphanMatches(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("sweepOrphanMatches")
+
+	for _, ns := range m.namespaces {
+		for matchID, am := range ns.matches.All() {
+			reason := ""
+			srv, ok := ns.servers.Get(am.ServerID)
+			switch {
+			case !ok || srv.Status == serverDown:
+				reason = "server_down"
+			case now.Sub(am.StartedAt) > m.orphans.limit(ns.modes[am.Mode]):
+				reason = "timeout"
+			default:
+				continue
+			}
+			m.closeOrphan(ns, matchID, am, reason)
+		}
+	}
+}
+
+// closeOrphan elimina la partida y libera a sus jugadores según la política.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) closeOrphan(ns *namespace, matchID string, am *activeMatch, reason string) {
+	m.closeMatch(ns, matchID, am, phaseAborted)
+	ns.releaseServer(am.ServerID)
+	m.abortOnServer(ns, am.ServerID, matchID, reason)
+	ns.vc.Tick(m.selfID)
+	m.metrics.orphanMatches.With(ns.name, reason).Inc()
+	now := m.wall.Now()
+	m.recordMatch(ns, &matchResult{
+		MatchID:    matchID,
+		ServerID:   am.ServerID,
+		Mode:       am.Mode,
+		Players:    am.Players,
+		Outcome:    reason,
+		Duration:   now.Sub(am.StartedAt),
+		StartedAt:  am.StartedAt,
+		FinishedAt: now,
+		VC:         ns.vc.Copy(),
+		Timeline:   append(am.Timeline, m.newEvent(ns, pb.MatchEvent_ENDED, reason)),
+	})
+
+	// en orden inverso para que, al reencolar al frente, conserven su orden
+	var requeued []string
+	for i := len(am.Players) - 1; i >= 0; i-- {
+		p, ok := ns.players.Get(am.Players[i])
+		if !ok || p.MatchID != matchID {
+			continue
+		}
+		p.MatchID = ""
+		p.LastOp = m.wall.Now()
+		if m.orphans.requeue {
+			p.Status = playerInQueue
+			p.Priority = priorityRequeued
+			p.QueuedVC = ns.vc.Copy()
+			p.QueueDeadline = m.queueDeadline(p.LastOp)
+			ns.enqueue(p.ID, true)
+			requeued = append(requeued, p.ID)
+		} else {
+			p.Status = playerIdle
+		}
+		m.notify(ns, p.ID, &pb.MatchUpdate{
+			Event:   pb.MatchUpdate_MATCH_ABORTED,
+			MatchId: matchID,
+		})
+	}
+	m.logRequeued(ns, matchID, requeued, "partida cerrada sin resultado: "+reason)
+
+	m.logf("[%s] Partida %s cerrada sin resultado (%s, server %s); jugadores %v → %s",
+		ns.name, matchID, reason, am.ServerID, am.Players, m.orphans.action())
+}
+
+func (op orphanPolicy) action() string {
+	if op.requeue {
+		return orphanRequeue
+	}
+	return orphanIdle
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+         RPC: AbortMatch – el GameServer perdió la partida al reiniciarse
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AbortMatch(ctx context.Context, req *pb.AbortMatchRequest) (*pb.AbortMatchResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.noteServerClock(ns, req.GetServerId(), req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	matchID := req.GetMatchId()
+	am, ok := ns.matches.Get(matchID)
+	switch {
+	case !ok:
+		return &pb.AbortMatchResponse{
+			StatusCode:  pb.AbortMatchResponse_UNKNOWN_MATCH,
+			Message:     "Partida desconocida o ya cerrada",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	case am.ServerID != req.GetServerId():
+		return &pb.AbortMatchResponse{
+			StatusCode:  pb.AbortMatchResponse_NOT_OWNER,
+			Message:     fmt.Sprintf("La partida está asignada a %s", am.ServerID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	m.logf("[%s] Server %s aborta la partida %s: %s", ns.name, am.ServerID, matchID, req.GetReason())
+	m.closeOrphan(ns, matchID, am, "aborted")
+	return &pb.AbortMatchResponse{
+		StatusCode:  pb.AbortMatchResponse_OK,
+		Message:     "Partida abortada",
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
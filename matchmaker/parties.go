@@ -0,0 +1,201 @@
+// matchmaker/parties.go
+//
+// Grupos de jugadores (parties). Un jugador crea el grupo y comparte su ID;
+// los demás se unen con JoinParty. Cuando el líder se encola, se encola todo
+// el grupo con el mismo modo, prioridad y región, y takeQueued lo coloca
+// completo en un mismo equipo. Cancelar la cola desde cualquier integrante
+// saca al grupo entero.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const maxPartySize = 5
+
+type party struct {
+	ID      string
+	Leader  string
+	Members []string // el líder primero, luego por orden de llegada
+}
+
+// idle indica si ningún integrante está en cola ni en partida.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) partyIdle(pt *party) bool {
+	for _, pid := range pt.Members {
+		if p, ok := ns.players.Get(pid); ok && p.Status != playerIdle {
+			return false
+		}
+	}
+	return true
+}
+
+// queuedUnit devuelve los jugadores que deben emparejarse juntos con p: su
+// grupo completo o sólo él.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) queuedUnit(p *playerInfo) []string {
+	pt, ok := ns.parties[p.PartyID]
+	if !ok {
+		return []string{p.ID}
+	}
+	var unit []string
+	for _, pid := range pt.Members {
+		if q, ok := ns.players.Get(pid); ok && q.Status == playerInQueue && q.Mode == p.Mode {
+			unit = append(unit, pid)
+		}
+	}
+	return unit
+}
+
+// leaveParty saca a pi de su grupo; si queda vacío lo disuelve y devuelve
+// nil, si sale el líder lo reemplaza el integrante más antiguo.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) leaveParty(pi *playerInfo) *party {
+	pt, ok := ns.parties[pi.PartyID]
+	pi.PartyID = ""
+	if !ok {
+		return nil
+	}
+	for i, pid := range pt.Members {
+		if pid == pi.ID {
+			pt.Members = append(pt.Members[:i], pt.Members[i+1:]...)
+			break
+		}
+	}
+	if len(pt.Members) == 0 {
+		delete(ns.parties, pt.ID)
+		return nil
+	}
+	if pt.Leader == pi.ID {
+		pt.Leader = pt.Members[0]
+	}
+	return pt
+}
+
+func (ns *namespace) newPartyID() string {
+	for {
+		id := fmt.Sprintf("G%06x", rand.Intn(1<<24))
+		if _, taken := ns.parties[id]; !taken {
+			return id
+		}
+	}
+}
+
+func (m *matchmaker) partyResponse(ns *namespace, code pb.PartyResponse_StatusCode, msg string, pt *party) *pb.PartyResponse {
+	res := &pb.PartyResponse{StatusCode: code, Message: msg, VectorClock: m.clockProto(ns)}
+	if pt != nil {
+		res.PartyId = pt.ID
+		res.LeaderId = pt.Leader
+		res.MemberIds = append([]string(nil), pt.Members...)
+	}
+	return res
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+           RPC: CreateParty / JoinParty / LeaveParty – grupos de jugadores
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) CreateParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	pi.sawClock(req.GetClock(), m.wall.Now())
+	if pt, ok := ns.parties[pi.PartyID]; ok {
+		return m.partyResponse(ns, pb.PartyResponse_ALREADY_IN_PARTY, "Ya estás en un grupo", pt), nil
+	}
+	if pi.Status != playerIdle {
+		return m.partyResponse(ns, pb.PartyResponse_PARTY_BUSY, "Sal de la cola o termina tu partida antes de crear un grupo", nil), nil
+	}
+
+	pt := &party{ID: ns.newPartyID(), Leader: playerID, Members: []string{playerID}}
+	ns.parties[pt.ID] = pt
+	pi.PartyID = pt.ID
+
+	m.logf("[%s] Grupo %s creado por %s", ns.name, pt.ID, playerID)
+	return m.partyResponse(ns, pb.PartyResponse_OK, "Grupo creado; comparte su ID", pt), nil
+}
+
+func (m *matchmaker) JoinParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	pi.sawClock(req.GetClock(), m.wall.Now())
+	if cur, ok := ns.parties[pi.PartyID]; ok {
+		return m.partyResponse(ns, pb.PartyResponse_ALREADY_IN_PARTY, "Ya estás en un grupo", cur), nil
+	}
+	pt, ok := ns.parties[req.GetPartyId()]
+	if !ok {
+		return m.partyResponse(ns, pb.PartyResponse_PARTY_NOT_FOUND, "Grupo inexistente", nil), nil
+	}
+	if len(pt.Members) >= maxPartySize {
+		return m.partyResponse(ns, pb.PartyResponse_PARTY_FULL, fmt.Sprintf("El grupo ya tiene %d integrantes", maxPartySize), pt), nil
+	}
+	if pi.Status != playerIdle || !ns.partyIdle(pt) {
+		return m.partyResponse(ns, pb.PartyResponse_PARTY_BUSY, "El grupo o tú están en cola o en partida", pt), nil
+	}
+
+	pt.Members = append(pt.Members, playerID)
+	pi.PartyID = pt.ID
+
+	m.logf("[%s] Jugador %s se unió al grupo %s %v", ns.name, playerID, pt.ID, pt.Members)
+	return m.partyResponse(ns, pb.PartyResponse_OK, "Te uniste al grupo", pt), nil
+}
+
+func (m *matchmaker) LeaveParty(ctx context.Context, req *pb.PartyRequest) (*pb.PartyResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	m.notePlayerClock(ns, playerID, req.GetClock())
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok || pi.PartyID == "" {
+		return m.partyResponse(ns, pb.PartyResponse_NOT_IN_PARTY, "No estás en un grupo", nil), nil
+	}
+	pt := ns.parties[pi.PartyID]
+	if !ns.partyIdle(pt) {
+		return m.partyResponse(ns, pb.PartyResponse_PARTY_BUSY, "El grupo está en cola o en partida", pt), nil
+	}
+
+	partyID := pt.ID
+	if pt = ns.leaveParty(pi); pt == nil {
+		m.logf("[%s] Grupo %s disuelto", ns.name, partyID)
+		return m.partyResponse(ns, pb.PartyResponse_OK, "Saliste del grupo; quedó disuelto", nil), nil
+	}
+
+	m.logf("[%s] Jugador %s dejó el grupo %s; líder %s", ns.name, playerID, pt.ID, pt.Leader)
+	return m.partyResponse(ns, pb.PartyResponse_OK, "Saliste del grupo", pt), nil
+}
@@ -0,0 +1,232 @@
+// matchmaker/player_auth.go
+//
+// Autenticación de jugadores (PLAYER_AUTH=required). Sin ella cualquiera
+// puede encolarse, cancelar o abandonar partidas en nombre de otro jugador
+// con sólo conocer su ID.
+//
+// RegisterPlayer reclama un ID en su namespace (el primero que lo registra
+// se lo queda) y devuelve un token firmado con HMAC (internal/authtoken).
+// El cliente lo envía en la metadata "x-player-token" y los interceptores de
+// este archivo lo exigen en cada RPC de jugador, comprobando que el token sea
+// del jugador y namespace de la petición. Un ID ya reclamado sólo se vuelve a
+// registrar presentando un token suyo, aunque esté vencido: así se renueva.
+//
+// La clave sale de PLAYER_AUTH_SECRET; el respaldo necesita la misma para
+// aceptar los tokens del primario. Sin ella se genera una al arrancar y los
+// tokens dejan de valer con cada reinicio.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/authtoken"
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// Valores de PLAYER_AUTH.
+const (
+	authOff      = "off"
+	authRequired = "required"
+)
+
+const defaultTokenTTL = 24 * time.Hour
+
+// playerMethods son las RPC que actúan en nombre de un jugador y exigen su
+// token. ListGameModes y las consultas de historial son públicas.
+var playerMethods = map[string]bool{
+	pb.Matchmaker_QueuePlayer_FullMethodName:           true,
+	pb.Matchmaker_CancelQueue_FullMethodName:           true,
+	pb.Matchmaker_LeaveMatch_FullMethodName:            true,
+	pb.Matchmaker_AcceptMatch_FullMethodName:           true,
+	pb.Matchmaker_RequestRematch_FullMethodName:        true,
+	pb.Matchmaker_GetPlayerStatus_FullMethodName:       true,
+	pb.Matchmaker_ResumeSession_FullMethodName:         true,
+	pb.Matchmaker_PlayerHeartbeat_FullMethodName:       true,
+	pb.Matchmaker_SubmitMatchFeedback_FullMethodName:   true,
+	pb.Matchmaker_CreateParty_FullMethodName:           true,
+	pb.Matchmaker_JoinParty_FullMethodName:             true,
+	pb.Matchmaker_LeaveParty_FullMethodName:            true,
+	pb.Matchmaker_CreateLobby_FullMethodName:           true,
+	pb.Matchmaker_JoinLobby_FullMethodName:             true,
+	pb.Matchmaker_LeaveLobby_FullMethodName:            true,
+	pb.Matchmaker_SubscribeMatchUpdates_FullMethodName: true,
+}
+
+// playerRequest lo cumplen las peticiones de todas las playerMethods.
+type playerRequest interface {
+	GetPlayerId() string
+	GetNamespace() string
+}
+
+// newPlayerSigner crea el firmante de tokens; sin secreto usa una clave
+// aleatoria.
+func newPlayerSigner(secret string, ttl time.Duration, now func() time.Time) *authtoken.Signer {
+	key := []byte(secret)
+	if secret == "" {
+		var err error
+		if key, err = authtoken.NewKey(); err != nil {
+			log.Fatalf("FATAL: no se pudo generar la clave de tokens: %v", err)
+		}
+		log.Println("WARNING: PLAYER_AUTH_SECRET no definido; se usa una clave aleatoria y los tokens no sobreviven a un reinicio ni a la conmutación al respaldo")
+	}
+	return authtoken.NewSigner(key, ttl, now)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                              RPC: RegisterPlayer
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) RegisterPlayer(ctx context.Context, req *pb.RegisterPlayerRequest) (*pb.RegisterPlayerResponse, error) {
+	playerID := req.GetPlayerId()
+	if m.auth == nil {
+		return &pb.RegisterPlayerResponse{
+			StatusCode: pb.RegisterPlayerResponse_AUTH_DISABLED,
+			Message:    "El Matchmaker no exige tokens (PLAYER_AUTH=off)",
+		}, nil
+	}
+	if strings.TrimSpace(playerID) == "" {
+		return &pb.RegisterPlayerResponse{
+			StatusCode: pb.RegisterPlayerResponse_INVALID_PLAYER,
+			Message:    "player_id vacío",
+		}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+
+	pi, ok := ns.players.Get(playerID)
+	renew := ok && pi.Registered
+	if renew {
+		if _, err := m.verifyOwner(ctx, ns.name, playerID, true); err != nil {
+			m.metrics.authRejects.With("already_registered").Inc()
+			m.logf("[%s] WARNING: registro de %s rechazado: el ID ya está registrado (%v)", ns.name, playerID, status.Convert(err).Message())
+			return &pb.RegisterPlayerResponse{
+				StatusCode:  pb.RegisterPlayerResponse_ALREADY_REGISTERED,
+				Message:     "El ID ya está registrado; para renovarlo envía su token anterior",
+				VectorClock: m.clockProto(ns),
+			}, nil
+		}
+	}
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	pi.Registered = true
+	pi.sawClock(req.GetClock(), m.wall.Now())
+	ns.vc.Tick(m.selfID)
+
+	token, claims := m.auth.Issue(ns.name, playerID)
+	if renew {
+		m.logf("[%s] Jugador %s renovó su token (vence %s)", ns.name, playerID, claims.ExpiresAt.Format(time.RFC3339))
+	} else {
+		m.logf("[%s] Jugador %s registrado (token vence %s)", ns.name, playerID, claims.ExpiresAt.Format(time.RFC3339))
+	}
+	return &pb.RegisterPlayerResponse{
+		StatusCode:      pb.RegisterPlayerResponse_OK,
+		Token:           token,
+		ExpiresAtUnixMs: claims.ExpiresAt.UnixMilli(),
+		Message:         "Registrado",
+		VectorClock:     m.clockProto(ns),
+	}, nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                  Interceptores
+───────────────────────────────────────────────────────────────────────────────*/
+
+// authUnaryInterceptor exige el token del jugador en las RPC de jugador.
+func (m *matchmaker) authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if m.auth != nil && playerMethods[info.FullMethod] {
+		if err := m.checkPlayerToken(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor hace lo mismo con SubscribeMatchUpdates: el jugador
+// llega en el primer mensaje, así que se comprueba al recibirlo.
+func (m *matchmaker) authStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if m.auth == nil || !playerMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	return handler(srv, &authServerStream{ServerStream: ss, m: m})
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	m       *matchmaker
+	checked bool
+}
+
+func (s *authServerStream) RecvMsg(msg interface{}) error {
+	if err := s.ServerStream.RecvMsg(msg); err != nil || s.checked {
+		return err
+	}
+	s.checked = true
+	return s.m.checkPlayerToken(s.Context(), msg)
+}
+
+// checkPlayerToken valida el token de la metadata contra el jugador y el
+// namespace de req.
+func (m *matchmaker) checkPlayerToken(ctx context.Context, req interface{}) error {
+	pr, ok := req.(playerRequest)
+	if !ok {
+		return status.Errorf(codes.Internal, "%T no identifica al jugador", req)
+	}
+	ns := pr.GetNamespace()
+	if ns == "" {
+		ns = defaultNamespace
+	}
+	reason, err := m.verifyOwner(ctx, ns, pr.GetPlayerId(), false)
+	if err != nil {
+		m.metrics.authRejects.With(reason).Inc()
+	}
+	return err
+}
+
+// verifyOwner comprueba que la metadata de ctx traiga un token de playerID
+// en ns; allowExpired acepta uno vencido (para renovarlo). Si no, devuelve
+// el motivo para las métricas y el error para el cliente.
+func (m *matchmaker) verifyOwner(ctx context.Context, ns, playerID string, allowExpired bool) (string, error) {
+	token := incomingToken(ctx)
+	if token == "" {
+		return "missing", status.Errorf(codes.Unauthenticated, "falta el token de jugador (%s); regístrate con RegisterPlayer", authtoken.Header)
+	}
+	claims, err := m.auth.Verify(token)
+	switch {
+	case errors.Is(err, authtoken.ErrExpired) && allowExpired:
+	case errors.Is(err, authtoken.ErrExpired):
+		return "expired", status.Error(codes.Unauthenticated, "token de jugador vencido; renuévalo con RegisterPlayer")
+	case err != nil:
+		return "invalid", status.Errorf(codes.Unauthenticated, "token de jugador inválido: %v", err)
+	}
+	if claims.PlayerID != playerID || claims.Namespace != ns {
+		return "mismatch", status.Errorf(codes.PermissionDenied, "el token es de %s/%s, no de %s/%s",
+			claims.Namespace, claims.PlayerID, ns, playerID)
+	}
+	return "", nil
+}
+
+// incomingToken devuelve el token de jugador de la metadata entrante.
+func incomingToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(authtoken.Header); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
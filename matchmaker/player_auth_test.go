@@ -0,0 +1,132 @@
+// matchmaker/player_auth_test.go
+//
+// PLAYER_AUTH=required: RegisterPlayer reclama el ID, las RPC de jugador
+// exigen un token propio y vigente, y un token vencido sirve para renovar.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/authtoken"
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// startAuthMatchmaker es startMatchmaker con tokens de una hora sobre un
+// reloj virtual.
+func startAuthMatchmaker(t *testing.T) (*walltime.Fake, pb.MatchmakerClient) {
+	t.Helper()
+	mm, cli := startMatchmaker(t)
+	wall := walltime.NewFake(time.Now())
+	mm.auth = newPlayerSigner("secreto", time.Hour, wall.Now)
+	return wall, cli
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), authtoken.Header, token)
+}
+
+func register(t *testing.T, ctx context.Context, cli pb.MatchmakerClient, playerID string) *pb.RegisterPlayerResponse {
+	t.Helper()
+	res, err := cli.RegisterPlayer(ctx, &pb.RegisterPlayerRequest{PlayerId: playerID})
+	if err != nil {
+		t.Fatalf("RegisterPlayer(%s): %v", playerID, err)
+	}
+	return res
+}
+
+func queueCode(ctx context.Context, cli pb.MatchmakerClient, playerID string) codes.Code {
+	_, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: playerID})
+	return status.Code(err)
+}
+
+func TestRegisterPlayerClaimsID(t *testing.T) {
+	_, cli := startAuthMatchmaker(t)
+
+	first := register(t, context.Background(), cli, "P1")
+	if first.GetStatusCode() != pb.RegisterPlayerResponse_OK || first.GetToken() == "" {
+		t.Fatalf("primer registro = %v", first)
+	}
+	// otro cliente sin el token no puede quedarse con el ID
+	if res := register(t, context.Background(), cli, "P1"); res.GetStatusCode() != pb.RegisterPlayerResponse_ALREADY_REGISTERED {
+		t.Fatalf("registro ajeno = %v", res)
+	}
+	// ni con el token de otro jugador
+	other := register(t, context.Background(), cli, "P2")
+	if res := register(t, withToken(other.GetToken()), cli, "P1"); res.GetStatusCode() != pb.RegisterPlayerResponse_ALREADY_REGISTERED {
+		t.Fatalf("registro con token de P2 = %v", res)
+	}
+	if res := register(t, withToken(first.GetToken()), cli, "P1"); res.GetStatusCode() != pb.RegisterPlayerResponse_OK {
+		t.Fatalf("renovación = %v", res)
+	}
+}
+
+func TestPlayerRPCsRequireOwnToken(t *testing.T) {
+	_, cli := startAuthMatchmaker(t)
+	p1 := register(t, context.Background(), cli, "P1").GetToken()
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"sin token", context.Background(), codes.Unauthenticated},
+		{"token adulterado", withToken(p1 + "x"), codes.Unauthenticated},
+		{"token de otro jugador", withToken(p1), codes.PermissionDenied},
+	}
+	for _, c := range cases {
+		if got := queueCode(c.ctx, cli, "P2"); got != c.want {
+			t.Errorf("QueuePlayer(P2) %s = %v, se esperaba %v", c.name, got, c.want)
+		}
+	}
+	if got := queueCode(withToken(p1), cli, "P1"); got != codes.OK {
+		t.Fatalf("QueuePlayer(P1) con su token = %v", got)
+	}
+
+	// el stream se valida con su primer mensaje
+	stream, err := cli.SubscribeMatchUpdates(context.Background(), &pb.SubscribeRequest{PlayerId: "P1"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("SubscribeMatchUpdates sin token: %v", err)
+	}
+	// las consultas públicas no lo necesitan
+	if _, err := cli.ListGameModes(context.Background(), &pb.ListGameModesRequest{}); err != nil {
+		t.Fatalf("ListGameModes sin token: %v", err)
+	}
+}
+
+func TestExpiredTokenRenews(t *testing.T) {
+	wall, cli := startAuthMatchmaker(t)
+	old := register(t, context.Background(), cli, "P1").GetToken()
+
+	wall.Advance(2 * time.Hour)
+	if got := queueCode(withToken(old), cli, "P1"); got != codes.Unauthenticated {
+		t.Fatalf("QueuePlayer con token vencido = %v", got)
+	}
+	res := register(t, withToken(old), cli, "P1")
+	if res.GetStatusCode() != pb.RegisterPlayerResponse_OK {
+		t.Fatalf("renovación con token vencido = %v", res)
+	}
+	if got := queueCode(withToken(res.GetToken()), cli, "P1"); got != codes.OK {
+		t.Fatalf("QueuePlayer con token renovado = %v", got)
+	}
+}
+
+func TestRegisterPlayerWithoutAuth(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	if res := register(t, context.Background(), cli, "P1"); res.GetStatusCode() != pb.RegisterPlayerResponse_AUTH_DISABLED {
+		t.Fatalf("RegisterPlayer con PLAYER_AUTH=off = %v", res)
+	}
+	if got := queueCode(context.Background(), cli, "P1"); got != codes.OK {
+		t.Fatalf("QueuePlayer sin token con PLAYER_AUTH=off = %v", got)
+	}
+}
@@ -0,0 +1,142 @@
+// matchmaker/player_liveness.go
+//
+// Latidos de los clientes de jugador. Un jugador que se encola y cierra la
+// terminal quedaría IN_QUEUE hasta QUEUE_TIMEOUT (o para siempre, sin él) y
+// acabaría emparejado con rivales que esperan a alguien que no está. El
+// cliente envía PlayerHeartbeat al arrancar y luego cada interval_ms (la
+// respuesta fija la cadencia: un tercio de PLAYER_HEARTBEAT_TIMEOUT). En
+// cada vuelta del bucle de emparejamiento, quien esté en la cola o en una
+// sala privada y lleve PLAYER_HEARTBEAT_TIMEOUT sin latir sale de ella y
+// queda IDLE; su grupo sale de la cola con él, porque sólo se empareja
+// completo. Todos reciben HEARTBEAT_LOST.
+//
+// Sólo se vigila a quien latió alguna vez: los clientes que no implementan
+// el latido (loadgen, la pasarela, versiones anteriores) no se ven
+// afectados. Las partidas en curso tampoco: de sus jugadores se ocupa el
+// GameServer (desconexiones y LeaveMatch). El último latido viaja en los
+// snapshots y el respaldo promovido da a todos un plazo completo.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultPlayerTimeout = 15 * time.Second
+
+	// cadencia sugerida cuando PLAYER_HEARTBEAT_TIMEOUT=0 (no se vigila,
+	// pero el latido sigue sirviendo para volver a vigilar tras una recarga)
+	defaultPlayerHeartbeatInterval = 5 * time.Second
+	minPlayerHeartbeatInterval     = time.Second
+)
+
+// playerHeartbeatInterval es la cadencia que se pide a los clientes.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) playerHeartbeatInterval() time.Duration {
+	if m.playerTimeout <= 0 {
+		return defaultPlayerHeartbeatInterval
+	}
+	if d := m.playerTimeout / 3; d > minPlayerHeartbeatInterval {
+		return d
+	}
+	return minPlayerHeartbeatInterval
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                RPC: PlayerHeartbeat – el cliente sigue vivo
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) PlayerHeartbeat(ctx context.Context, req *pb.PlayerHeartbeatRequest) (*pb.PlayerHeartbeatResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// un latido no cambia el estado: no avanza el reloj del Matchmaker
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+
+	now := m.wall.Now()
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	pi.sawClock(req.GetClock(), now)
+	pi.LastHeartbeat = now
+
+	return &pb.PlayerHeartbeatResponse{
+		Status:      ns.statusName(pi),
+		IntervalMs:  m.playerHeartbeatInterval().Milliseconds(),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
+
+// expirePlayerHeartbeats saca de la cola y de las salas privadas, en cada
+// namespace, a los jugadores cuyo cliente dejó de latir.
+func (m *matchmaker) expirePlayerHeartbeats(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("expirePlayerHeartbeats")
+
+	if m.playerTimeout <= 0 || m.isDraining() {
+		return // al apagarse, la cola se traspasa tal como está
+	}
+	for _, ns := range m.namespaces {
+		m.expireNamespaceHeartbeats(ns, now)
+	}
+}
+
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) expireNamespaceHeartbeats(ns *namespace, now time.Time) {
+	var lost []string
+	for _, p := range ns.players.All() {
+		waiting := p.Status == playerInQueue || p.LobbyCode != ""
+		if waiting && !p.LastHeartbeat.IsZero() && now.Sub(p.LastHeartbeat) >= m.playerTimeout {
+			lost = append(lost, p.ID)
+		}
+	}
+	for _, pid := range lost {
+		p := ns.players.Value(pid)
+		if code := p.LobbyCode; code != "" {
+			ns.leaveLobby(p)
+			p.LastOp = now
+			ns.vc.Tick(m.selfID)
+			m.notify(ns, pid, &pb.MatchUpdate{Event: pb.MatchUpdate_HEARTBEAT_LOST})
+			m.metrics.playerLost.With(ns.name).Inc()
+			m.logf("[%s] Sin latidos de %s: sale de la sala privada %s", ns.name, pid, code)
+			continue
+		}
+		if p.Status != playerInQueue {
+			continue // ya salió con su grupo
+		}
+		members := []string{pid}
+		if pt, ok := ns.parties[p.PartyID]; ok {
+			members = pt.Members
+		}
+		var out []string
+		for _, mid := range members {
+			q, ok := ns.players.Get(mid)
+			if !ok || q.Status != playerInQueue {
+				continue
+			}
+			ns.removeFromQueue(mid)
+			q.Status = playerIdle
+			q.QueueDeadline = time.Time{}
+			q.LastOp = now
+			out = append(out, mid)
+		}
+		ns.vc.Tick(m.selfID)
+		for _, mid := range out {
+			m.notify(ns, mid, &pb.MatchUpdate{Event: pb.MatchUpdate_HEARTBEAT_LOST})
+		}
+		m.metrics.playerLost.With(ns.name).Inc()
+		m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_DEQUEUED, PlayerIds: out, Detail: "sin latidos de " + pid})
+		m.logf("[%s] Sin latidos de %s desde hace %v: %v sale de la cola", ns.name, pid, now.Sub(p.LastHeartbeat).Round(time.Second), out)
+	}
+}
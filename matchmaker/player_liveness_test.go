@@ -0,0 +1,90 @@
+// matchmaker/player_liveness_test.go
+//
+// PLAYER_HEARTBEAT_TIMEOUT: quien latió y deja de latir sale de la cola con
+// su grupo, o de su sala privada; quien nunca latió no se vigila y un latido
+// reciente renueva el plazo.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func heartbeat(t *testing.T, cli pb.MatchmakerClient, id string) *pb.PlayerHeartbeatResponse {
+	t.Helper()
+	res, err := cli.PlayerHeartbeat(context.Background(), &pb.PlayerHeartbeatRequest{PlayerId: id})
+	if err != nil {
+		t.Fatalf("PlayerHeartbeat(%s): %v", id, err)
+	}
+	return res
+}
+
+func TestLostHeartbeatLeavesQueue(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx := context.Background()
+	mm.mu.Lock()
+	mm.playerTimeout = 30 * time.Second
+	mm.ns("").modes["2v2"] = &gameMode{Name: "2v2", TeamSize: 2, MinDuration: 10, MaxDuration: 20, Enabled: true}
+	mm.mu.Unlock()
+
+	if res := heartbeat(t, cli, "A"); res.GetIntervalMs() != 10000 || res.GetStatus() != "IDLE" {
+		t.Fatalf("PlayerHeartbeat(A) = %v", res)
+	}
+	heartbeat(t, cli, "B")
+	pt, err := cli.CreateParty(ctx, &pb.PartyRequest{PlayerId: "B"})
+	if err != nil {
+		t.Fatalf("CreateParty: %v", err)
+	}
+	if _, err := cli.JoinParty(ctx, &pb.PartyRequest{PlayerId: "C", PartyId: pt.GetPartyId()}); err != nil {
+		t.Fatalf("JoinParty: %v", err)
+	}
+	for _, req := range []*pb.PlayerInfoRequest{{PlayerId: "A", GameMode: "2v2"}, {PlayerId: "B", GameMode: "2v2"}, {PlayerId: "D", GameMode: "2v2"}} {
+		if res, err := cli.QueuePlayer(ctx, req); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+			t.Fatalf("QueuePlayer(%s): %v %v", req.GetPlayerId(), res.GetStatusCode(), err)
+		}
+	}
+
+	// A sigue latiendo; B no: sale de la cola con C, que nunca latió
+	mm.mu.Lock()
+	mm.ns("").players.Value("A").LastHeartbeat = time.Now().Add(20 * time.Second)
+	mm.mu.Unlock()
+	mm.expirePlayerHeartbeats(time.Now().Add(35 * time.Second))
+
+	for id, want := range map[string]string{"A": "IN_QUEUE", "B": "IDLE", "C": "IDLE", "D": "IN_QUEUE"} {
+		if got := playerStatus(t, cli, id); got != want {
+			t.Errorf("%s: estado %s, se esperaba %s", id, got, want)
+		}
+	}
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	if q := mm.lookupNS("").queue.IDs(); len(q) != 2 {
+		t.Fatalf("cola tras vencer los latidos = %v", q)
+	}
+}
+
+func TestLostHeartbeatLeavesLobby(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	mm.mu.Lock()
+	mm.playerTimeout = 30 * time.Second
+	mm.mu.Unlock()
+
+	heartbeat(t, cli, "A")
+	lb := createLobby(t, cli, &pb.CreateLobbyRequest{PlayerId: "A"})
+
+	mm.expirePlayerHeartbeats(time.Now().Add(10 * time.Second))
+	if res := joinLobby(t, cli, "B", lb.GetCode()); res.GetStatusCode() != pb.LobbyResponse_OK {
+		t.Fatalf("la sala se disolvió antes de tiempo: %v", res.GetStatusCode())
+	}
+	if _, err := cli.LeaveLobby(context.Background(), &pb.LobbyRequest{PlayerId: "B"}); err != nil {
+		t.Fatalf("LeaveLobby(B): %v", err)
+	}
+
+	mm.expirePlayerHeartbeats(time.Now().Add(time.Minute))
+	if res := joinLobby(t, cli, "B", lb.GetCode()); res.GetStatusCode() != pb.LobbyResponse_LOBBY_NOT_FOUND {
+		t.Fatalf("la sala de un jugador sin latidos sigue abierta: %v", res.GetStatusCode())
+	}
+}
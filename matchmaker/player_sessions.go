@@ -0,0 +1,264 @@
+// matchmaker/player_sessions.go
+//
+// Sesiones de jugador. Dos clientes con el mismo PLAYER_ID compartían en
+// silencio el estado: uno cancelaba la cola del otro, los dos recibían sus
+// notificaciones, etc. Ahora cada proceso cliente envía un ID de sesión
+// propio en la metadata "x-session-id" (pkg/mmclient.NewSessionID) y el
+// Matchmaker recuerda, por jugador, la última sesión vista.
+//
+// Si llega una RPC de jugador con otra sesión mientras la anterior sigue
+// viva (la vio hace menos de SESSION_TTL), hay un conflicto. SESSION_CONFLICT
+// decide:
+//
+//	reject   la sesión nueva se rechaza hasta que la anterior expire (por
+//	         defecto); un cliente reiniciado espera SESSION_TTL
+//	fence    la sesión nueva reemplaza a la anterior, que queda vetada:
+//	         sus RPC siguientes se rechazan
+//
+// QueuePlayer responde SESSION_CONFLICT; las demás RPC de jugador fallan con
+// FAILED_PRECONDITION. Una sesión que expira también queda vetada, para que
+// un cliente pausado no vuelva a quitarle el jugador a quien lo reemplazó.
+// Los clientes que no envían sesión (anteriores a este cambio, la pasarela
+// HTTP) no se comprueban. Las sesiones no se replican: tras una conmutación
+// la primera RPC de cada jugador vuelve a reclamarlo.
+//
+// AdminListSessions muestra las sesiones conocidas.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// Valores de SESSION_CONFLICT.
+const (
+	sessionReject = "reject"
+	sessionFence  = "fence"
+)
+
+const (
+	defaultSessionTTL = 30 * time.Second
+	// una sesión sin RPC durante este plazo se olvida, con sus vetadas
+	sessionRetention  = 10 * time.Minute
+	maxFencedSessions = 8
+)
+
+// sessionKey identifica al jugador de una sesión.
+type sessionKey struct {
+	Namespace string
+	Player    string
+}
+
+// playerSession es la última sesión vista de un jugador.
+type playerSession struct {
+	ID        string
+	Peer      string
+	StartedAt time.Time
+	LastSeen  time.Time
+	RPCs      int64
+	Conflicts int
+	Fenced    []string // sesiones reemplazadas, la más reciente al final
+}
+
+func (s *playerSession) fenced(id string) bool {
+	for _, f := range s.Fenced {
+		if f == id {
+			return true
+		}
+	}
+	return false
+}
+
+// replace pone la sesión id en lugar de la vigente, que queda vetada.
+func (s *playerSession) replace(id string, now time.Time) {
+	s.Fenced = append(s.Fenced, s.ID)
+	if len(s.Fenced) > maxFencedSessions {
+		s.Fenced = s.Fenced[len(s.Fenced)-maxFencedSessions:]
+	}
+	s.ID, s.StartedAt, s.RPCs = id, now, 0
+}
+
+// shortSession abrevia un ID de sesión para los mensajes.
+func shortSession(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// claimSession registra la RPC de playerID con la sesión de ctx. Devuelve
+// "" si puede seguir o el motivo del conflicto.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) claimSession(ctx context.Context, nsName, playerID string, now time.Time) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := mmclient.SessionOf(md)
+	if id == "" || playerID == "" {
+		return ""
+	}
+	m.sessionsMu.Lock()
+	defer m.sessionsMu.Unlock()
+	k := sessionKey{nsName, playerID}
+	s, ok := m.sessions[k]
+	switch {
+	case !ok:
+		s = &playerSession{ID: id, StartedAt: now}
+		m.sessions[k] = s
+	case s.ID == id:
+	case s.fenced(id):
+		m.metrics.sessions.With(nsName, "stale").Inc()
+		return fmt.Sprintf("la sesión %s de %s fue reemplazada por la sesión %s", shortSession(id), playerID, shortSession(s.ID))
+	case now.Sub(s.LastSeen) >= m.sessionTTL:
+		m.logf("[%s] Jugador %s: la sesión %s expiró; la reemplaza %s", nsName, playerID, shortSession(s.ID), shortSession(id))
+		s.replace(id, now)
+	case m.sessionFence:
+		m.metrics.sessions.With(nsName, "fenced").Inc()
+		m.logf("[%s] WARNING: jugador %s con dos sesiones; %s (%s) reemplaza a %s (%s)",
+			nsName, playerID, shortSession(id), incomingPeer(ctx), shortSession(s.ID), s.Peer)
+		s.replace(id, now)
+		s.Conflicts++
+	default:
+		s.Conflicts++
+		m.metrics.sessions.With(nsName, "rejected").Inc()
+		m.logf("[%s] WARNING: jugador %s con dos sesiones; se rechaza %s (%s), sigue %s (%s)",
+			nsName, playerID, shortSession(id), incomingPeer(ctx), shortSession(s.ID), s.Peer)
+		return fmt.Sprintf("el jugador %s ya tiene una sesión activa (%s, desde %s, vista hace %v)",
+			playerID, shortSession(s.ID), s.Peer, now.Sub(s.LastSeen).Round(time.Second))
+	}
+	s.LastSeen = now
+	s.Peer = incomingPeer(ctx)
+	s.RPCs++
+	return ""
+}
+
+// incomingPeer devuelve la dirección del cliente de ctx, o "?".
+func incomingPeer(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "?"
+}
+
+// pruneSessions olvida las sesiones sin RPC desde hace sessionRetention.
+func (m *matchmaker) pruneSessions(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, s := range m.sessions {
+		if now.Sub(s.LastSeen) > sessionRetention {
+			delete(m.sessions, k)
+		}
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                                  Interceptores
+───────────────────────────────────────────────────────────────────────────────*/
+
+// checkSession aplica claimSession a una RPC de jugador.
+func (m *matchmaker) checkSession(ctx context.Context, req interface{}) string {
+	pr, ok := req.(playerRequest)
+	if !ok {
+		return ""
+	}
+	nsName := pr.GetNamespace()
+	if nsName == "" {
+		nsName = defaultNamespace
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.claimSession(ctx, nsName, pr.GetPlayerId(), m.wall.Now())
+}
+
+// sessionUnaryInterceptor rechaza las RPC de jugador de una sesión en
+// conflicto: QueuePlayer con SESSION_CONFLICT, el resto con
+// FAILED_PRECONDITION.
+func (m *matchmaker) sessionUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !playerMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	msg := m.checkSession(ctx, req)
+	if msg == "" {
+		return handler(ctx, req)
+	}
+	if info.FullMethod == pb.Matchmaker_QueuePlayer_FullMethodName {
+		res := &pb.QueuePlayerResponse{StatusCode: pb.QueuePlayerResponse_SESSION_CONFLICT, Message: msg}
+		m.mu.RLock()
+		if ns := m.lookupNS(req.(playerRequest).GetNamespace()); ns != nil {
+			res.VectorClock = m.clockProto(ns)
+		}
+		m.mu.RUnlock()
+		return res, nil
+	}
+	return nil, status.Error(codes.FailedPrecondition, "SESSION_CONFLICT: "+msg)
+}
+
+// sessionStreamInterceptor hace lo mismo con SubscribeMatchUpdates, al
+// recibir su primer mensaje.
+func (m *matchmaker) sessionStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !playerMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	return handler(srv, &sessionServerStream{ServerStream: ss, m: m})
+}
+
+type sessionServerStream struct {
+	grpc.ServerStream
+	m       *matchmaker
+	checked bool
+}
+
+func (s *sessionServerStream) RecvMsg(msg interface{}) error {
+	if err := s.ServerStream.RecvMsg(msg); err != nil || s.checked {
+		return err
+	}
+	s.checked = true
+	if conflict := s.m.checkSession(s.Context(), msg); conflict != "" {
+		return status.Error(codes.FailedPrecondition, "SESSION_CONFLICT: "+conflict)
+	}
+	return nil
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                 RPC: AdminListSessions – sesiones de jugador
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminListSessions(ctx context.Context, req *pb.AdminListSessionsRequest) (*pb.AdminListSessionsResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+
+	now := m.wall.Now()
+	res := &pb.AdminListSessionsResponse{}
+	for k, s := range m.sessions {
+		if k.Namespace != ns.name || (req.GetPlayerId() != "" && k.Player != req.GetPlayerId()) {
+			continue
+		}
+		res.Sessions = append(res.Sessions, &pb.PlayerSession{
+			PlayerId:        k.Player,
+			SessionId:       s.ID,
+			Peer:            s.Peer,
+			StartedAtUnixMs: s.StartedAt.UnixMilli(),
+			LastSeenUnixMs:  s.LastSeen.UnixMilli(),
+			Live:            now.Sub(s.LastSeen) < m.sessionTTL,
+			Rpcs:            s.RPCs,
+			Conflicts:       int32(s.Conflicts),
+			Fenced:          append([]string(nil), s.Fenced...),
+		})
+	}
+	sort.Slice(res.Sessions, func(i, j int) bool { return res.Sessions[i].PlayerId < res.Sessions[j].PlayerId })
+	res.VectorClock = m.clockProto(ns)
+	return res, nil
+}
@@ -0,0 +1,88 @@
+// matchmaker/player_sessions_test.go
+//
+// Sesiones de jugador: con SESSION_CONFLICT=reject un segundo cliente con
+// el mismo ID no puede actuar mientras el primero siga vivo, pero sí cuando
+// expira, y el primero queda vetado; con fence el nuevo reemplaza al vivo.
+// AdminListSessions muestra el resultado.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/walltime"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func withSession(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, mmclient.SessionHeader, id)
+}
+
+func TestSessionConflictReject(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	wall := walltime.NewFake(time.Now())
+	mm.wall = wall
+	ctx := context.Background()
+	first, second := withSession(ctx, "session-one"), withSession(ctx, "session-two")
+
+	if res, err := cli.QueuePlayer(first, &pb.PlayerInfoRequest{PlayerId: "P1"}); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+		t.Fatalf("QueuePlayer(primera sesión): %v %v", res, err)
+	}
+	res, err := cli.QueuePlayer(second, &pb.PlayerInfoRequest{PlayerId: "P1"})
+	if err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_SESSION_CONFLICT {
+		t.Fatalf("QueuePlayer(segunda sesión): %v %v, se esperaba SESSION_CONFLICT", res, err)
+	}
+	if _, err := cli.CancelQueue(second, &pb.CancelQueueRequest{PlayerId: "P1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("CancelQueue(segunda sesión): %v, se esperaba FAILED_PRECONDITION", err)
+	}
+	// sin sesión (clientes anteriores) no se comprueba; otro jugador tampoco choca
+	if _, err := cli.GetPlayerStatus(ctx, &pb.PlayerStatusRequest{PlayerId: "P1"}); err != nil {
+		t.Fatalf("GetPlayerStatus sin sesión: %v", err)
+	}
+	if _, err := cli.GetPlayerStatus(second, &pb.PlayerStatusRequest{PlayerId: "P2"}); err != nil {
+		t.Fatalf("GetPlayerStatus(P2): %v", err)
+	}
+
+	// la primera deja de verse: la segunda toma el jugador y la primera queda vetada
+	wall.Advance(defaultSessionTTL)
+	if _, err := cli.CancelQueue(second, &pb.CancelQueueRequest{PlayerId: "P1"}); err != nil {
+		t.Fatalf("CancelQueue tras expirar la primera sesión: %v", err)
+	}
+	if res, _ := cli.QueuePlayer(first, &pb.PlayerInfoRequest{PlayerId: "P1"}); res.GetStatusCode() != pb.QueuePlayerResponse_SESSION_CONFLICT {
+		t.Fatalf("la sesión reemplazada volvió a encolar: %v", res)
+	}
+
+	list, err := cli.AdminListSessions(ctx, &pb.AdminListSessionsRequest{PlayerId: "P1"})
+	if err != nil || len(list.GetSessions()) != 1 {
+		t.Fatalf("AdminListSessions: %v %v", list, err)
+	}
+	s := list.GetSessions()[0]
+	if s.GetSessionId() != "session-two" || !s.GetLive() || s.GetConflicts() != 2 || len(s.GetFenced()) != 1 || s.GetFenced()[0] != "session-one" {
+		t.Fatalf("sesión de P1 = %v", s)
+	}
+}
+
+func TestSessionConflictFence(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	mm.mu.Lock()
+	mm.sessionFence = true
+	mm.mu.Unlock()
+	first, second := withSession(context.Background(), "old"), withSession(context.Background(), "new")
+
+	if res, err := cli.QueuePlayer(first, &pb.PlayerInfoRequest{PlayerId: "P1"}); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+		t.Fatalf("QueuePlayer(primera sesión): %v %v", res, err)
+	}
+	if res, err := cli.CancelQueue(second, &pb.CancelQueueRequest{PlayerId: "P1"}); err != nil || res.GetStatusCode() != pb.CancelQueueResponse_OK {
+		t.Fatalf("CancelQueue(sesión nueva): %v %v", res, err)
+	}
+	if _, err := cli.GetPlayerStatus(first, &pb.PlayerStatusRequest{PlayerId: "P1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("la sesión reemplazada sigue activa: %v", err)
+	}
+}
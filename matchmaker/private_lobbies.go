@@ -0,0 +1,342 @@
+// matchmaker/private_lobbies.go
+//
+// Salas privadas. Un jugador crea una sala para un modo con CreateLobby,
+// opcionalmente fijada a un GameServer, y comparte el código que recibe; los
+// demás entran con JoinLobby. La sala tiene lugar para los dos equipos del
+// modo (o para el máximo de sala de un modo de sala por tiempo) y, en cuanto
+// se llena, la partida arranca sin pasar por la cola pública: el orden de
+// llegada arma los equipos (la primera mitad es el equipo 1) y no hay
+// confirmación previa (ready_check.go), porque todos eligieron entrar. Si el
+// servidor elegido está ocupado o caído, la sala llena espera y arranca en la
+// primera vuelta de emparejamiento en que tenga un hueco, antes que las
+// partidas de la cola. Una partida de sala fijada no se conmuta a otro
+// servidor, y si la asignación falla los jugadores vuelven a la sala, no a la
+// cola. Mientras espera en una sala, un jugador no puede encolarse; LeaveLobby
+// lo saca y, si sale el dueño, lo reemplaza el más antiguo.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	lobbyCodeLen      = 6
+	lobbyCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // sin 0/O ni 1/I
+)
+
+type privateLobby struct {
+	Code      string
+	Mode      string
+	ServerID  string   // servidor elegido; vacío = el mejor disponible al llenarse
+	Members   []string // el dueño primero, luego por orden de llegada
+	CreatedAt int64    // unix ms; ordena el arranque de las salas llenas
+}
+
+// lobbyCapacity es cuántos jugadores llenan una sala privada del modo.
+func (g *gameMode) lobbyCapacity() int {
+	if g.isLobby() {
+		return g.LobbyMax
+	}
+	return 2 * g.TeamSize
+}
+
+// lobbyIn devuelve el primer jugador de la lista que espera en una sala
+// privada, o "".
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) lobbyIn(players []string) string {
+	for _, pid := range players {
+		if p, ok := ns.players.Get(pid); ok && p.LobbyCode != "" {
+			return pid
+		}
+	}
+	return ""
+}
+
+// leaveLobby saca a pi de su sala; si queda vacía la disuelve y devuelve
+// nil.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) leaveLobby(pi *playerInfo) *privateLobby {
+	lb, ok := ns.privateLobbies[pi.LobbyCode]
+	pi.LobbyCode = ""
+	if !ok {
+		return nil
+	}
+	for i, pid := range lb.Members {
+		if pid == pi.ID {
+			lb.Members = append(lb.Members[:i], lb.Members[i+1:]...)
+			break
+		}
+	}
+	if len(lb.Members) == 0 {
+		delete(ns.privateLobbies, lb.Code)
+		return nil
+	}
+	return lb
+}
+
+func (ns *namespace) newLobbyCode() string {
+	b := make([]byte, lobbyCodeLen)
+	for {
+		for i := range b {
+			b[i] = lobbyCodeAlphabet[rand.Intn(len(lobbyCodeAlphabet))]
+		}
+		if _, taken := ns.privateLobbies[string(b)]; !taken {
+			return string(b)
+		}
+	}
+}
+
+// lobbyServer devuelve el servidor en que puede arrancar la sala ahora: el
+// elegido si tiene un hueco o, sin elección, el mejor asignable. nil = aún
+// no.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) lobbyServer(lb *privateLobby) *gameServerInfo {
+	if lb.ServerID == "" {
+		return ns.pickServer(lb.Members)
+	}
+	if s, ok := ns.servers.Get(lb.ServerID); ok && ns.assignable(s) {
+		return s
+	}
+	return nil
+}
+
+// lobbyBusy explica por qué pi no puede entrar en una sala, o "".
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) lobbyBusy(ns *namespace, pi *playerInfo) string {
+	switch {
+	case pi.Status != playerIdle:
+		return "Sal de la cola o termina tu partida antes de entrar en una sala"
+	case ns.bannedIn([]string{pi.ID}) != "":
+		return "Estás baneado: " + ns.banned[pi.ID]
+	}
+	if pid, left := ns.cooldownIn([]string{pi.ID}, m.wall.Now()); pid != "" {
+		return fmt.Sprintf("Acabas de jugar; podrás entrar en %v", left.Round(1e9))
+	}
+	return ""
+}
+
+// fullPrivateLobbies lista las salas llenas de modos habilitados, de la más
+// antigua a la más nueva.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) fullPrivateLobbies() []*privateLobby {
+	var full []*privateLobby
+	for _, lb := range ns.privateLobbies {
+		if g, ok := ns.modes[lb.Mode]; ok && g.Enabled && len(lb.Members) >= g.lobbyCapacity() {
+			full = append(full, lb)
+		}
+	}
+	sort.Slice(full, func(i, j int) bool { return full[i].CreatedAt < full[j].CreatedAt })
+	return full
+}
+
+// startPrivateLobbies arranca las salas llenas que ya tienen servidor, de
+// la más antigua a la más nueva.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) startPrivateLobbies(ns *namespace) {
+	for _, lb := range ns.fullPrivateLobbies() {
+		if srv := ns.lobbyServer(lb); srv != nil {
+			m.startLobby(ns, lb, srv)
+		}
+	}
+}
+
+// startLobby lanza la partida de una sala llena en srv y disuelve la sala.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) startLobby(ns *namespace, lb *privateLobby, srv *gameServerInfo) string {
+	delete(ns.privateLobbies, lb.Code)
+	for _, pid := range lb.Members {
+		if p, ok := ns.players.Get(pid); ok {
+			p.LobbyCode = ""
+		}
+	}
+	mode := ns.modes[lb.Mode]
+	matchID, am := m.openMatch(ns, mode, lb.Members, srv)
+	am.LobbyCode, am.Pinned = lb.Code, lb.ServerID != ""
+	m.logf("[%s] Sala privada %s llena: partida %s en %s", ns.name, lb.Code, matchID, srv.ID)
+	m.launchMatch(ns, matchID, am, srv, mode.toProto())
+	return matchID
+}
+
+// reopenLobby devuelve a su sala a los jugadores de una partida de sala
+// privada cuya asignación falló; la sala vuelve a esperar su servidor.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) reopenLobby(ns *namespace, matchID string, am *activeMatch) {
+	code := am.LobbyCode
+	if _, taken := ns.privateLobbies[code]; taken {
+		code = ns.newLobbyCode()
+	}
+	now := m.wall.Now()
+	lb := &privateLobby{Code: code, Mode: am.Mode, CreatedAt: now.UnixMilli()}
+	if am.Pinned {
+		lb.ServerID = am.ServerID
+	}
+	for _, pid := range am.Players {
+		p, ok := ns.players.Get(pid)
+		if !ok || p.MatchID != matchID {
+			continue
+		}
+		p.Status, p.MatchID, p.LobbyCode = playerIdle, "", code
+		p.LastOp = now
+		lb.Members = append(lb.Members, pid)
+		m.notify(ns, pid, &pb.MatchUpdate{Event: pb.MatchUpdate_MATCH_ABORTED, MatchId: matchID})
+	}
+	if len(lb.Members) == 0 {
+		return
+	}
+	ns.privateLobbies[code] = lb
+	m.logf("[%s] Partida %s sin asignar: %v vuelven a la sala privada %s", ns.name, matchID, lb.Members, code)
+}
+
+func (m *matchmaker) lobbyResponse(ns *namespace, code pb.LobbyResponse_StatusCode, msg string, lb *privateLobby) *pb.LobbyResponse {
+	res := &pb.LobbyResponse{StatusCode: code, Message: msg, VectorClock: m.clockProto(ns)}
+	if lb != nil {
+		res.Code = lb.Code
+		res.GameMode = lb.Mode
+		res.ServerId = lb.ServerID
+		res.OwnerId = lb.Members[0]
+		res.MemberIds = append([]string(nil), lb.Members...)
+		if g, ok := ns.modes[lb.Mode]; ok {
+			res.Capacity = int32(g.lobbyCapacity())
+		}
+	}
+	return res
+}
+
+// joinedLobby anota a pi en lb, con la hora y el reloj de llegada como
+// hito QUEUED de la partida.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) joinedLobby(ns *namespace, pi *playerInfo, lb *privateLobby) {
+	lb.Members = append(lb.Members, pi.ID)
+	pi.LobbyCode = lb.Code
+	pi.LastOp = m.wall.Now()
+	pi.QueuedVC = ns.vc.Copy()
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+        RPC: CreateLobby / JoinLobby / LeaveLobby – salas privadas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) CreateLobby(ctx context.Context, req *pb.CreateLobbyRequest) (*pb.LobbyResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	pi.sawClock(req.GetClock(), m.wall.Now())
+	if cur, ok := ns.privateLobbies[pi.LobbyCode]; ok {
+		return m.lobbyResponse(ns, pb.LobbyResponse_ALREADY_IN_LOBBY, "Ya estás en una sala", cur), nil
+	}
+	if why := m.lobbyBusy(ns, pi); why != "" {
+		return m.lobbyResponse(ns, pb.LobbyResponse_PLAYER_BUSY, why, nil), nil
+	}
+	modeName := req.GetGameMode()
+	if modeName == "" {
+		modeName = defaultGameMode
+	}
+	if g, ok := ns.modes[modeName]; !ok || !g.Enabled || !m.shards.ownsMode(modeName) {
+		return m.lobbyResponse(ns, pb.LobbyResponse_UNKNOWN_MODE, fmt.Sprintf("Modo %q no disponible en este Matchmaker", modeName), nil), nil
+	}
+	if id := req.GetServerId(); id != "" {
+		if s, ok := ns.servers.Get(id); !ok || s.Status == serverDown || s.Draining {
+			return m.lobbyResponse(ns, pb.LobbyResponse_UNKNOWN_SERVER, fmt.Sprintf("Servidor %q inexistente, caído o drenando", id), nil), nil
+		}
+	}
+
+	lb := &privateLobby{Code: ns.newLobbyCode(), Mode: modeName, ServerID: req.GetServerId(), CreatedAt: m.wall.Now().UnixMilli()}
+	ns.privateLobbies[lb.Code] = lb
+	m.joinedLobby(ns, pi, lb)
+
+	m.logf("[%s] Sala privada %s (%s, servidor %q) creada por %s", ns.name, lb.Code, lb.Mode, lb.ServerID, playerID)
+	return m.lobbyResponse(ns, pb.LobbyResponse_OK, "Sala creada; comparte su código", lb), nil
+}
+
+func (m *matchmaker) JoinLobby(ctx context.Context, req *pb.LobbyRequest) (*pb.LobbyResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	pi.sawClock(req.GetClock(), m.wall.Now())
+	if cur, ok := ns.privateLobbies[pi.LobbyCode]; ok {
+		return m.lobbyResponse(ns, pb.LobbyResponse_ALREADY_IN_LOBBY, "Ya estás en una sala", cur), nil
+	}
+	lb, ok := ns.privateLobbies[strings.ToUpper(strings.TrimSpace(req.GetCode()))]
+	if !ok {
+		return m.lobbyResponse(ns, pb.LobbyResponse_LOBBY_NOT_FOUND, "Sala inexistente o ya arrancada", nil), nil
+	}
+	mode := ns.modes[lb.Mode]
+	if len(lb.Members) >= mode.lobbyCapacity() {
+		return m.lobbyResponse(ns, pb.LobbyResponse_LOBBY_FULL, fmt.Sprintf("La sala ya tiene %d jugadores", len(lb.Members)), lb), nil
+	}
+	if why := m.lobbyBusy(ns, pi); why != "" {
+		return m.lobbyResponse(ns, pb.LobbyResponse_PLAYER_BUSY, why, lb), nil
+	}
+
+	m.joinedLobby(ns, pi, lb)
+	m.logf("[%s] Jugador %s entró en la sala privada %s (%d/%d)", ns.name, playerID, lb.Code, len(lb.Members), mode.lobbyCapacity())
+	if len(lb.Members) < mode.lobbyCapacity() {
+		return m.lobbyResponse(ns, pb.LobbyResponse_OK, "Entraste en la sala", lb), nil
+	}
+
+	// llena: arranca ya si su servidor tiene hueco; si no, en la siguiente
+	// vuelta de emparejamiento que lo encuentre libre
+	if srv := ns.lobbyServer(lb); srv != nil && mode.Enabled && !m.isDraining() {
+		res := m.lobbyResponse(ns, pb.LobbyResponse_OK, "Sala llena: la partida arranca", lb)
+		res.MatchId = m.startLobby(ns, lb, srv)
+		res.VectorClock = m.clockProto(ns)
+		return res, nil
+	}
+	return m.lobbyResponse(ns, pb.LobbyResponse_OK, "Sala llena: arrancará cuando su servidor tenga un hueco", lb), nil
+}
+
+func (m *matchmaker) LeaveLobby(ctx context.Context, req *pb.LobbyRequest) (*pb.LobbyResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	m.notePlayerClock(ns, playerID, req.GetClock())
+
+	pi, ok := ns.players.Get(playerID)
+	if !ok || pi.LobbyCode == "" {
+		return m.lobbyResponse(ns, pb.LobbyResponse_NOT_IN_LOBBY, "No estás en una sala", nil), nil
+	}
+	code := pi.LobbyCode
+	lb := ns.leaveLobby(pi)
+	if lb == nil {
+		m.logf("[%s] Sala privada %s disuelta", ns.name, code)
+		return m.lobbyResponse(ns, pb.LobbyResponse_OK, "Saliste de la sala; quedó disuelta", nil), nil
+	}
+	m.logf("[%s] Jugador %s dejó la sala privada %s; dueño %s", ns.name, playerID, code, lb.Members[0])
+	return m.lobbyResponse(ns, pb.LobbyResponse_OK, "Saliste de la sala", lb), nil
+}
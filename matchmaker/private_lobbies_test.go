@@ -0,0 +1,134 @@
+// matchmaker/private_lobbies_test.go
+//
+// Salas privadas: arranque al llenarse en el servidor elegido, bloqueo de
+// la cola pública mientras se espera en una sala, traspaso del dueño y
+// vuelta a la sala cuando la asignación fijada falla.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func createLobby(t *testing.T, cli pb.MatchmakerClient, req *pb.CreateLobbyRequest) *pb.LobbyResponse {
+	t.Helper()
+	res, err := cli.CreateLobby(context.Background(), req)
+	if err != nil || res.GetStatusCode() != pb.LobbyResponse_OK {
+		t.Fatalf("CreateLobby(%s): %v %q %v", req.GetPlayerId(), res.GetStatusCode(), res.GetMessage(), err)
+	}
+	return res
+}
+
+func joinLobby(t *testing.T, cli pb.MatchmakerClient, playerID, code string) *pb.LobbyResponse {
+	t.Helper()
+	res, err := cli.JoinLobby(context.Background(), &pb.LobbyRequest{PlayerId: playerID, Code: code})
+	if err != nil {
+		t.Fatalf("JoinLobby(%s): %v", playerID, err)
+	}
+	return res
+}
+
+// La sala llena arranca en el servidor elegido aunque haya otro libre antes
+// en el orden, y sin pasar por la cola.
+func TestFullLobbyStartsOnPinnedServer(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	gs2 := r.addServer(testkit.NeverReport)
+
+	lb := createLobby(t, r.cli, &pb.CreateLobbyRequest{PlayerId: "A", ServerId: gs2.ID})
+	if lb.GetCapacity() != 2 || lb.GetOwnerId() != "A" || len(lb.GetCode()) != lobbyCodeLen {
+		t.Fatalf("sala creada = %+v", lb)
+	}
+	res := joinLobby(t, r.cli, "B", lb.GetCode())
+	if res.GetStatusCode() != pb.LobbyResponse_OK || res.GetMatchId() == "" {
+		t.Fatalf("la sala llena no arrancó: %v %q", res.GetStatusCode(), res.GetMessage())
+	}
+
+	if got := gs2.WaitAssigned(t, 1, time.Second)[0].MatchID; got != res.GetMatchId() {
+		t.Fatalf("gs2 recibió %s, se esperaba %s", got, res.GetMatchId())
+	}
+	r.inspect(func(ns *namespace) {
+		am := ns.matches.Value(res.GetMatchId())
+		if am == nil || am.ServerID != gs2.ID || !am.Pinned || am.LobbyCode != lb.GetCode() {
+			t.Fatalf("partida de la sala = %+v", am)
+		}
+		if len(ns.privateLobbies) != 0 || ns.players.Value("A").LobbyCode != "" {
+			t.Fatalf("la sala sigue abierta: %v", ns.privateLobbies)
+		}
+	})
+	if n := len(r.gs.Assigned()); n != 0 {
+		t.Fatalf("gs1 recibió %d partidas", n)
+	}
+}
+
+// Mientras espera en una sala, el jugador no puede encolarse; al salir el
+// dueño lo reemplaza el más antiguo y la sala vacía se disuelve.
+func TestLobbyBlocksQueueAndTransfersOwner(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	if err := upsertMode(t, cli, &pb.GameMode{Name: "2v2", TeamSize: 2, Enabled: true}); err != nil {
+		t.Fatalf("AdminUpsertGameMode: %v", err)
+	}
+	lb := createLobby(t, cli, &pb.CreateLobbyRequest{PlayerId: "A", GameMode: "2v2"})
+	if res := joinLobby(t, cli, "B", lb.GetCode()); res.GetStatusCode() != pb.LobbyResponse_OK || res.GetCapacity() != 4 {
+		t.Fatalf("JoinLobby(B): %v cap=%d", res.GetStatusCode(), res.GetCapacity())
+	}
+
+	q, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: "B", GameMode: "2v2"})
+	if err != nil || q.GetStatusCode() != pb.QueuePlayerResponse_IN_LOBBY {
+		t.Fatalf("QueuePlayer desde una sala: %v %v", q.GetStatusCode(), err)
+	}
+	if res, _ := cli.CreateLobby(context.Background(), &pb.CreateLobbyRequest{PlayerId: "B"}); res.GetStatusCode() != pb.LobbyResponse_ALREADY_IN_LOBBY {
+		t.Fatalf("CreateLobby desde una sala: %v", res.GetStatusCode())
+	}
+
+	res, err := cli.LeaveLobby(context.Background(), &pb.LobbyRequest{PlayerId: "A"})
+	if err != nil || res.GetOwnerId() != "B" || len(res.GetMemberIds()) != 1 {
+		t.Fatalf("LeaveLobby(A): dueño %q, integrantes %v, %v", res.GetOwnerId(), res.GetMemberIds(), err)
+	}
+	if res, _ := cli.LeaveLobby(context.Background(), &pb.LobbyRequest{PlayerId: "B"}); res.GetStatusCode() != pb.LobbyResponse_OK || res.GetCode() != "" {
+		t.Fatalf("LeaveLobby(B): %v sala %q", res.GetStatusCode(), res.GetCode())
+	}
+	if res := joinLobby(t, cli, "C", lb.GetCode()); res.GetStatusCode() != pb.LobbyResponse_LOBBY_NOT_FOUND {
+		t.Fatalf("JoinLobby a una sala disuelta: %v", res.GetStatusCode())
+	}
+	if q, _ := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: "B", GameMode: "2v2"}); q.GetStatusCode() != pb.QueuePlayerResponse_OK {
+		t.Fatalf("QueuePlayer tras salir de la sala: %v", q.GetStatusCode())
+	}
+}
+
+// Una partida fijada cuya asignación falla no se conmuta al otro servidor:
+// sus jugadores vuelven a la sala, que arranca cuando el elegido vuelve.
+func TestFailedPinnedAssignReopensLobby(t *testing.T) {
+	r := newFailureRig(t, testkit.Reject, testkit.NeverReport)
+	gs2 := r.addServer(testkit.NeverReport)
+
+	lb := createLobby(t, r.cli, &pb.CreateLobbyRequest{PlayerId: "A", ServerId: r.gs.ID})
+	joinLobby(t, r.cli, "B", lb.GetCode())
+	r.gs.WaitAssigned(t, 1, time.Second)
+
+	r.waitFor("jugadores de vuelta en la sala", func(ns *namespace) bool {
+		back, ok := ns.privateLobbies[lb.GetCode()]
+		return ok && ns.matches.Len() == 0 && back.ServerID == r.gs.ID && len(back.Members) == 2 &&
+			ns.players.Value("A").Status == playerIdle && ns.players.Value("B").LobbyCode == lb.GetCode()
+	})
+	if n := len(gs2.Assigned()); n != 0 {
+		t.Fatalf("la partida fijada se conmutó a gs2 (%d asignaciones)", n)
+	}
+
+	r.mm.tryCreateMatch()
+	r.inspect(func(ns *namespace) {
+		if ns.matches.Len() != 0 {
+			t.Fatalf("la sala arrancó con su servidor OCUPADO")
+		}
+	})
+	r.register()
+	r.mm.tryCreateMatch()
+	r.gs.WaitAssigned(t, 2, time.Second)
+	r.waitFor("sala arrancada en gs1", func(ns *namespace) bool {
+		return len(ns.privateLobbies) == 0 && ns.players.Value("A").Status == playerInMatch
+	})
+}
@@ -0,0 +1,159 @@
+// matchmaker/protocol_version.go
+//
+// Negociación de versión del protocolo, para desplegar cambios del .proto
+// sin actualizar a la vez los cuatro binarios. Cada cliente declara la
+// versión que habla en la metadata "x-protocol-version" (pkg/mmclient la
+// agrega a todas las RPC); un cliente anterior a la negociación no la envía
+// y cuenta como versión 1. El Matchmaker:
+//
+//   - rechaza con FAILED_PRECONDITION a los clientes por debajo de
+//     MIN_PROTOCOL_VERSION (recargable: se sube cuando ya no quedan
+//     clientes viejos, lo que muestra matchmaker_rpc_protocol_total);
+//   - atiende a los más nuevos que él: los campos que no conoce se ignoran;
+//   - adapta lo que envía a los más viejos que él: las notificaciones que
+//     no existían en su versión llegan como la más parecida que sí conocen,
+//     o no llegan si ese cliente no las esperaba;
+//   - informa con GetServerInfo su versión, la mínima y las capacidades
+//     activas, para que el cliente se adapte (p. ej. no enviar latidos a un
+//     Matchmaker que no los entiende).
+//
+// La replicación, el traspaso y la salud no se filtran: los usan otros
+// Matchmakers y herramientas (cmd/migrate) que no pasan por pkg/mmclient.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// legacyEvent dice cómo ve una notificación un cliente anterior a la
+// versión since que la introdujo: como el evento as o, con drop, nada.
+type legacyEvent struct {
+	since int
+	as    pb.MatchUpdate_Event
+	drop  bool
+}
+
+// legacyEvents traduce las notificaciones nuevas para los clientes viejos,
+// que descartarían en silencio un evento desconocido.
+var legacyEvents = map[pb.MatchUpdate_Event]legacyEvent{
+	pb.MatchUpdate_HEARTBEAT_LOST: {since: 2, as: pb.MatchUpdate_REMOVED},
+	// sin on_assign_failure siempre vuelven a la cola, como antes: en
+	// silencio, que es lo que esperan
+	pb.MatchUpdate_ASSIGN_FAILED: {since: 3, drop: true},
+}
+
+// clientProtocol devuelve la versión que declara el cliente de ctx.
+func clientProtocol(ctx context.Context) (int, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return mmclient.ProtocolVersionOf(md)
+}
+
+// adaptUpdate devuelve upd tal como debe verlo un cliente de la versión v,
+// o nil si ese cliente no debe recibirlo.
+func adaptUpdate(upd *pb.MatchUpdate, v int) *pb.MatchUpdate {
+	ev, ok := legacyEvents[upd.GetEvent()]
+	if !ok || v >= ev.since {
+		return upd
+	}
+	if ev.drop {
+		return nil
+	}
+	out := proto.Clone(upd).(*pb.MatchUpdate)
+	out.Event = ev.as
+	return out
+}
+
+// features lista las capacidades activas del Matchmaker.
+// debe llamarse con m.mu bloqueado (lectura o escritura)
+func (m *matchmaker) features() []string {
+	out := []string{"parties", "private_lobbies", "player_heartbeat", "backfill", "match_history", "leaderboard", "assign_failure_action", "player_sessions", "match_relay"}
+	if m.readyTimeout > 0 {
+		out = append(out, "ready_check")
+	}
+	if m.auth != nil {
+		out = append(out, "player_auth")
+	}
+	if m.queueLimit != nil {
+		out = append(out, "rate_limit")
+	}
+	if m.statusErrors {
+		out = append(out, "status_errors")
+	}
+	if m.rematchWindow > 0 {
+		out = append(out, "rematch")
+	}
+	sort.Strings(out)
+	return out
+}
+
+// checkProtocol rechaza las RPC de clientes por debajo de la versión mínima.
+func (m *matchmaker) checkProtocol(ctx context.Context, fullMethod string) error {
+	switch fullMethod {
+	case pb.Matchmaker_ReplicateState_FullMethodName, pb.Matchmaker_TransferState_FullMethodName:
+		return nil
+	}
+	if isHealthMethod(fullMethod) {
+		return nil
+	}
+	v, err := clientProtocol(ctx)
+	if err != nil {
+		m.metrics.protocol.With("invalid", "rejected").Inc()
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	m.mu.RLock()
+	min := m.minProtocol
+	m.mu.RUnlock()
+	// GetServerInfo siempre responde: es como el cliente se entera del mínimo
+	if v < min && fullMethod != pb.Matchmaker_GetServerInfo_FullMethodName {
+		m.metrics.protocol.With(strconv.Itoa(v), "rejected").Inc()
+		return status.Errorf(codes.FailedPrecondition,
+			"protocolo v%d no admitido: este Matchmaker atiende v%d a v%d; actualiza el cliente", v, min, mmclient.ProtocolVersion)
+	}
+	m.metrics.protocol.With(strconv.Itoa(v), "accepted").Inc()
+	return nil
+}
+
+func (m *matchmaker) protocolUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := m.checkProtocol(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (m *matchmaker) protocolStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := m.checkProtocol(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+               RPC: GetServerInfo – versión y capacidades activas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) GetServerInfo(ctx context.Context, req *pb.ServerInfoRequest) (*pb.ServerInfoResponse, error) {
+	v, _ := clientProtocol(ctx) // el interceptor ya la validó
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return &pb.ServerInfoResponse{
+		ProtocolVersion:       mmclient.ProtocolVersion,
+		MinProtocolVersion:    int32(m.minProtocol),
+		ClientProtocolVersion: int32(v),
+		Features:              m.features(),
+		MatchmakerId:          m.selfID,
+	}, nil
+}
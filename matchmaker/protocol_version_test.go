@@ -0,0 +1,96 @@
+// matchmaker/protocol_version_test.go
+//
+// Negociación de versión: GetServerInfo informa versiones y capacidades,
+// MIN_PROTOCOL_VERSION rechaza a los clientes viejos (salvo GetServerInfo)
+// y las notificaciones nuevas llegan traducidas a los clientes de v1.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func withProtocol(v string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), mmclient.ProtocolHeader, v)
+}
+
+func TestGetServerInfoReportsVersions(t *testing.T) {
+	_, cli := startMatchmaker(t)
+
+	res, err := cli.GetServerInfo(context.Background(), &pb.ServerInfoRequest{})
+	if err != nil {
+		t.Fatalf("GetServerInfo: %v", err)
+	}
+	if res.GetProtocolVersion() != mmclient.ProtocolVersion || res.GetMinProtocolVersion() != mmclient.LegacyProtocolVersion ||
+		res.GetClientProtocolVersion() != mmclient.LegacyProtocolVersion || res.GetMatchmakerId() != "Matchmaker" {
+		t.Fatalf("GetServerInfo sin versión = %v", res)
+	}
+	if !contains(res.GetFeatures(), "player_heartbeat") || contains(res.GetFeatures(), "ready_check") {
+		t.Fatalf("capacidades = %v", res.GetFeatures())
+	}
+
+	res, err = cli.GetServerInfo(withProtocol("2"), &pb.ServerInfoRequest{})
+	if err != nil || res.GetClientProtocolVersion() != 2 {
+		t.Fatalf("GetServerInfo con v2: %v %v", res.GetClientProtocolVersion(), err)
+	}
+}
+
+func TestMinProtocolVersionRejectsOldClients(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	mm.mu.Lock()
+	mm.minProtocol = 2
+	mm.mu.Unlock()
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"sin versión", context.Background(), codes.FailedPrecondition},
+		{"inválida", withProtocol("dos"), codes.InvalidArgument},
+		{"v2", withProtocol("2"), codes.OK},
+		{"más nueva", withProtocol("9"), codes.OK},
+	} {
+		_, err := cli.QueuePlayer(tc.ctx, &pb.PlayerInfoRequest{PlayerId: "A-" + tc.name})
+		if got := status.Code(err); got != tc.code {
+			t.Errorf("%s: QueuePlayer = %v, se esperaba %v", tc.name, got, tc.code)
+		}
+	}
+
+	res, err := cli.GetServerInfo(context.Background(), &pb.ServerInfoRequest{})
+	if err != nil || res.GetMinProtocolVersion() != 2 {
+		t.Fatalf("GetServerInfo de un cliente viejo: %v %v", res, err)
+	}
+}
+
+func TestAdaptUpdateForLegacyClients(t *testing.T) {
+	upd := &pb.MatchUpdate{Event: pb.MatchUpdate_HEARTBEAT_LOST}
+	if got := adaptUpdate(upd, mmclient.LegacyProtocolVersion).GetEvent(); got != pb.MatchUpdate_REMOVED {
+		t.Fatalf("v1 recibe %v", got)
+	}
+	if got := adaptUpdate(upd, mmclient.ProtocolVersion); got != upd {
+		t.Fatalf("v%d recibe %v", mmclient.ProtocolVersion, got.GetEvent())
+	}
+	if upd.GetEvent() != pb.MatchUpdate_HEARTBEAT_LOST {
+		t.Fatalf("la adaptación modificó el evento original")
+	}
+	found := &pb.MatchUpdate{Event: pb.MatchUpdate_MATCH_FOUND}
+	if adaptUpdate(found, mmclient.LegacyProtocolVersion) != found {
+		t.Fatalf("un evento conocido por v1 no debe copiarse")
+	}
+	failed := &pb.MatchUpdate{Event: pb.MatchUpdate_ASSIGN_FAILED}
+	if adaptUpdate(failed, 2) != nil || adaptUpdate(failed, 3) != failed {
+		t.Fatalf("ASSIGN_FAILED: v2 recibe %v, v3 %v", adaptUpdate(failed, 2), adaptUpdate(failed, 3))
+	}
+	if got := adaptUpdate(upd, 2); got != upd {
+		t.Fatalf("v2 ya conoce HEARTBEAT_LOST y recibe %v", got.GetEvent())
+	}
+}
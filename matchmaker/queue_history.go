@@ -0,0 +1,131 @@
+// matchmaker/queue_history.go
+//
+// Muestreo periódico de la cola de emparejamiento. Cada
+// QUEUE_SAMPLE_INTERVAL se registra, por namespace, el largo de la cola y el
+// tiempo de espera promedio y máximo de los jugadores encolados.
+// AdminExportQueueHistory entrega las muestras como CSV para análisis
+// offline (gráficos del informe).
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultQueueSampleInterval = 5 * time.Second
+	maxQueueSamples            = 17280 // 24 h a la resolución por defecto
+	csvRowsPerChunk            = 256
+)
+
+type queueSample struct {
+	At       time.Time
+	QueueLen int
+	AvgWait  time.Duration
+	MaxWait  time.Duration
+}
+
+// runQueueSampler toma una muestra de cada namespace cada interval.
+// Las muestras viven fuera de los namespaces para sobrevivir a restore().
+func (m *matchmaker) runQueueSampler(interval time.Duration) {
+	ticker := m.wall.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C():
+			m.sampleQueues(now)
+		case <-m.done:
+			return
+		}
+	}
+}
+
+func (m *matchmaker) sampleQueues(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, ns := range m.namespaces {
+		s := queueSample{At: now, QueueLen: ns.queue.Len()}
+		var total time.Duration
+		for _, pid := range ns.queue.IDs() {
+			p, ok := ns.players.Get(pid)
+			if !ok {
+				continue
+			}
+			wait := now.Sub(p.LastOp)
+			total += wait
+			if wait > s.MaxWait {
+				s.MaxWait = wait
+			}
+		}
+		if s.QueueLen > 0 {
+			s.AvgWait = total / time.Duration(s.QueueLen)
+		}
+
+		samples := append(m.queueSamples[name], s)
+		if len(samples) > maxQueueSamples {
+			samples = samples[len(samples)-maxQueueSamples:]
+		}
+		m.queueSamples[name] = samples
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+         RPC: AdminExportQueueHistory – muestras de la cola en CSV
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminExportQueueHistory(req *pb.QueueHistoryRequest, stream pb.Matchmaker_AdminExportQueueHistoryServer) error {
+	name := req.GetNamespace()
+	if name == "" {
+		name = defaultNamespace
+	}
+
+	// copiamos para no mantener el lock mientras se envía
+	m.mu.RLock()
+	samples := append([]queueSample(nil), m.queueSamples[name]...)
+	m.mu.RUnlock()
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"unix_ms", "namespace", "queue_length", "avg_wait_ms", "max_wait_ms"})
+
+	for i, s := range samples {
+		w.Write([]string{
+			strconv.FormatInt(s.At.UnixMilli(), 10),
+			name,
+			strconv.Itoa(s.QueueLen),
+			strconv.FormatInt(s.AvgWait.Milliseconds(), 10),
+			strconv.FormatInt(s.MaxWait.Milliseconds(), 10),
+		})
+		if (i+1)%csvRowsPerChunk == 0 {
+			if err := flushChunk(w, &buf, stream); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flushChunk(w, &buf, stream); err != nil {
+		return err
+	}
+
+	m.logf("[%s] Historial de cola exportado (%d muestras)", name, len(samples))
+	return nil
+}
+
+func flushChunk(w *csv.Writer, buf *bytes.Buffer, stream pb.Matchmaker_AdminExportQueueHistoryServer) error {
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	chunk := &pb.CsvChunk{Data: append([]byte(nil), buf.Bytes()...)}
+	buf.Reset()
+	return stream.Send(chunk)
+}
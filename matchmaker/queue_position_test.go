@@ -0,0 +1,41 @@
+// matchmaker/queue_position_test.go
+//
+// GetPlayerStatus informa a los encolados su lugar entre los de su modo, la
+// espera y el modo, para el panel en vivo del jugador.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestStatusQueuePosition(t *testing.T) {
+	_, cli := startMatchmaker(t)
+	ctx := context.Background()
+
+	for _, pid := range []string{"P1", "P2", "P3"} {
+		if res, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: pid}); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+			t.Fatalf("QueuePlayer(%s) = %v (%v)", pid, res, err)
+		}
+	}
+	for i, pid := range []string{"P1", "P2", "P3"} {
+		res := statusAt(t, cli, pid, pb.Consistency_CONSISTENCY_EVENTUAL)
+		if res.GetQueuePosition() != int32(i+1) || res.GetGameMode() != "1v1" || res.GetWaitMs() < 0 {
+			t.Fatalf("estado de %s = %v, se esperaba el puesto %d en 1v1", pid, res, i+1)
+		}
+	}
+
+	// al salir P1, los demás avanzan
+	if _, err := cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: "P1"}); err != nil {
+		t.Fatalf("CancelQueue: %v", err)
+	}
+	if res := statusAt(t, cli, "P3", pb.Consistency_CONSISTENCY_EVENTUAL); res.GetQueuePosition() != 2 {
+		t.Fatalf("puesto de P3 = %d, se esperaba 2", res.GetQueuePosition())
+	}
+	if res := statusAt(t, cli, "P1", pb.Consistency_CONSISTENCY_EVENTUAL); res.GetQueuePosition() != 0 || res.GetWaitMs() != 0 {
+		t.Fatalf("P1 fuera de la cola = %v, se esperaba sin puesto ni espera", res)
+	}
+}
@@ -0,0 +1,181 @@
+// matchmaker/queue_tiers.go
+//
+// Niveles de jugador (NORMAL / VIP / TESTER) y política de orden de la cola.
+//
+// ▸ Cada nivel tiene un peso configurable (QUEUE_TIER_WEIGHTS); PREMIUM suma
+//   premiumBonus al peso del nivel.
+// ▸ La espera también suma peso: un punto cada QUEUE_AGING, así un jugador
+//   NORMAL termina superando a los recién llegados de niveles altos.
+// ▸ Protección contra inanición: quien espera más de QUEUE_MAX_WAIT pasa al
+//   frente, por orden de espera, sin importar su nivel. Desde
+//   QUEUE_RELAX_AFTER, y del todo desde QUEUE_MAX_WAIT, la política de
+//   emparejamiento además se relaja para él (fairness.go).
+// ▸ Los jugadores REQUEUED (fallo de asignación) siguen yendo primero.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+type playerTier int
+
+const (
+	tierNormal playerTier = iota
+	tierVIP
+	tierTester
+)
+
+const premiumBonus = 1.0
+
+func (t playerTier) toProto() pb.PlayerTier {
+	switch t {
+	case tierVIP:
+		return pb.PlayerTier_TIER_VIP
+	case tierTester:
+		return pb.PlayerTier_TIER_TESTER
+	default:
+		return pb.PlayerTier_TIER_NORMAL
+	}
+}
+
+func playerTierFromProto(t pb.PlayerTier) playerTier {
+	switch t {
+	case pb.PlayerTier_TIER_VIP:
+		return tierVIP
+	case pb.PlayerTier_TIER_TESTER:
+		return tierTester
+	default:
+		return tierNormal
+	}
+}
+
+// nombre usado en QUEUE_TIER_WEIGHTS ("normal", "vip", "tester")
+func (t playerTier) key() string {
+	return strings.ToLower(strings.TrimPrefix(t.toProto().String(), "TIER_"))
+}
+
+// queuePolicy decide el orden en que se emparejan los jugadores encolados.
+type queuePolicy struct {
+	weights    map[playerTier]float64
+	aging      time.Duration // espera que equivale a +1 de peso
+	maxWait    time.Duration // a partir de aquí, el jugador va al frente
+	relaxAfter time.Duration // a partir de aquí se relaja la política; 0 = nunca
+}
+
+const defaultTierWeights = "normal=1,vip=2,tester=4"
+
+func defaultQueuePolicy() queuePolicy {
+	return queuePolicy{
+		weights:    map[playerTier]float64{tierNormal: 1, tierVIP: 2, tierTester: 4},
+		aging:      30 * time.Second,
+		maxWait:    2 * time.Minute,
+		relaxAfter: defaultRelaxAfter,
+	}
+}
+
+// tierWeights convierte el mapa leído de la configuración.
+func tierWeights(raw map[string]float64) map[playerTier]float64 {
+	out := make(map[playerTier]float64)
+	for _, t := range []playerTier{tierNormal, tierVIP, tierTester} {
+		out[t] = raw[t.key()]
+	}
+	return out
+}
+
+// class agrupa a los jugadores en bandas estrictas: REQUEUED, luego los que
+// superaron maxWait, luego el resto ordenado por score.
+func (qp queuePolicy) class(p *playerInfo, wait time.Duration) int {
+	switch {
+	case p.Priority == priorityRequeued:
+		return 2
+	case wait >= qp.maxWait:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (qp queuePolicy) score(p *playerInfo, wait time.Duration) float64 {
+	s := qp.weights[p.Tier]
+	if p.Priority == priorityPremium {
+		s += premiumBonus
+	}
+	if qp.aging > 0 {
+		s += wait.Seconds() / qp.aging.Seconds()
+	}
+	return s
+}
+
+// sortQueue reordena la cola según la política. El orden es estable: a igual
+// clase y score se respeta el orden previo (FIFO).
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) sortQueue(qp queuePolicy, now time.Time) {
+	type key struct {
+		class int
+		score float64
+		wait  time.Duration
+	}
+	keys := make(map[string]key, ns.queue.Len())
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if !ok {
+			continue
+		}
+		wait := now.Sub(p.LastOp)
+		keys[pid] = key{qp.class(p, wait), qp.score(p, wait), wait}
+	}
+	ns.queue.SortStable(func(x, y string) bool {
+		a, b := keys[x], keys[y]
+		if a.class != b.class {
+			return a.class > b.class
+		}
+		if a.class == 1 {
+			return a.wait > b.wait // inanición: el que más espera primero
+		}
+		return a.score > b.score
+	})
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+               RPC: AdminSetPlayerTier – nivel de un jugador
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminSetPlayerTier(ctx context.Context, req *pb.AdminSetPlayerTierRequest) (*pb.AdminUpdateResponse, error) {
+	playerID := req.GetPlayerId()
+	if playerID == "" {
+		return &pb.AdminUpdateResponse{
+			Status:  pb.AdminUpdateResponse_NOT_FOUND,
+			Message: "Falta el ID de jugador",
+		}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	// el nivel puede asignarse antes de que el jugador se conecte
+	pi, ok := ns.players.Get(playerID)
+	if !ok {
+		pi = &playerInfo{ID: playerID, VC: clocks.New()}
+		ns.players.Put(playerID, pi)
+	}
+	prev := pi.Tier
+	pi.Tier = playerTierFromProto(req.GetTier())
+	auditChange(ctx, playerID, prev.toProto().String(), pi.Tier.toProto().String())
+
+	m.logf("[%s] Jugador %s ahora es %s", ns.name, playerID, req.GetTier())
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     "Nivel actualizado",
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
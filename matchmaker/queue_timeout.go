@@ -0,0 +1,96 @@
+// matchmaker/queue_timeout.go
+//
+// Plazo de espera en la cola. Cada entrada recibe al encolarse (o al volver
+// a la cola tras una asignación fallida o un abandono) un vencimiento a
+// QUEUE_TIMEOUT; QUEUE_MAX_WAIT sólo la adelanta en la cola, así que sin este
+// plazo un jugador sin rivales esperaría para siempre. En cada vuelta del
+// bucle de emparejamiento las entradas vencidas salen de la cola, junto con
+// su grupo: el jugador queda libre pero GetPlayerStatus informa
+// QUEUE_TIMED_OUT hasta que vuelva a encolarse, y quien esté suscrito recibe
+// la notificación QUEUE_TIMED_OUT. Cambiar QUEUE_TIMEOUT en caliente sólo
+// afecta a las entradas nuevas. El vencimiento viaja en los snapshots.
+
+package main
+
+import (
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultQueueTimeout = 10 * time.Minute
+
+	// estado informado de un jugador libre cuya última espera venció
+	statusQueueTimedOut = "QUEUE_TIMED_OUT"
+)
+
+// queueDeadline es el vencimiento de una entrada encolada en now; cero si
+// QUEUE_TIMEOUT es 0.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) queueDeadline(now time.Time) time.Time {
+	if m.queueTimeout <= 0 {
+		return time.Time{}
+	}
+	return now.Add(m.queueTimeout)
+}
+
+// statusName es el estado que ve el jugador.
+func (p *playerInfo) statusName() string {
+	if p.Status == playerIdle && p.QueueTimedOut {
+		return statusQueueTimedOut
+	}
+	return p.Status.String()
+}
+
+// expireQueue saca de la cola, en cada namespace, las entradas vencidas.
+func (m *matchmaker) expireQueue(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("expireQueue")
+
+	for _, ns := range m.namespaces {
+		m.expireNamespaceQueue(ns, now)
+	}
+}
+
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) expireNamespaceQueue(ns *namespace, now time.Time) {
+	var expired []string
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if ok && !p.QueueDeadline.IsZero() && !now.Before(p.QueueDeadline) {
+			expired = append(expired, pid)
+		}
+	}
+	for _, pid := range expired {
+		p := ns.players.Value(pid)
+		if p.Status != playerInQueue {
+			continue // ya salió con su grupo
+		}
+		// el grupo se encoló junto: sale junto
+		members := []string{pid}
+		if pt, ok := ns.parties[p.PartyID]; ok {
+			members = pt.Members
+		}
+		var out []string
+		for _, mid := range members {
+			q, ok := ns.players.Get(mid)
+			if !ok || q.Status != playerInQueue {
+				continue
+			}
+			ns.removeFromQueue(mid)
+			q.Status, q.QueueTimedOut = playerIdle, true
+			q.QueueDeadline = time.Time{}
+			q.LastOp = now
+			out = append(out, mid)
+		}
+		ns.vc.Tick(m.selfID)
+		for _, mid := range out {
+			m.notify(ns, mid, &pb.MatchUpdate{Event: pb.MatchUpdate_QUEUE_TIMED_OUT})
+		}
+		m.metrics.queueTimeouts.With(ns.name, p.Mode).Add(float64(len(out)))
+		m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_PLAYER_DEQUEUED, PlayerIds: out, Detail: "espera vencida"})
+		m.logf("[%s] Espera vencida: %v sale de la cola (%s)", ns.name, out, p.Mode)
+	}
+}
@@ -0,0 +1,114 @@
+// matchmaker/queue_timeout_test.go
+//
+// QUEUE_TIMEOUT: una entrada vencida sale de la cola con todo su grupo,
+// GetPlayerStatus informa QUEUE_TIMED_OUT hasta que vuelve a encolarse y el
+// suscriptor recibe la notificación. Con QUEUE_TIMEOUT=0 nadie vence.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func playerStatus(t *testing.T, cli pb.MatchmakerClient, id string) string {
+	t.Helper()
+	res, err := cli.GetPlayerStatus(context.Background(), &pb.PlayerStatusRequest{PlayerId: id})
+	if err != nil {
+		t.Fatalf("GetPlayerStatus(%s): %v", id, err)
+	}
+	return res.GetStatus()
+}
+
+func TestQueueTimeoutExpiresEntries(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx := context.Background()
+	mm.mu.Lock()
+	mm.queueTimeout = time.Minute
+	mm.ns("").modes["2v2"] = &gameMode{Name: "2v2", TeamSize: 2, MinDuration: 10, MaxDuration: 20, Enabled: true}
+	mm.mu.Unlock()
+
+	if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "A"}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	pt, err := cli.CreateParty(ctx, &pb.PartyRequest{PlayerId: "B"})
+	if err != nil {
+		t.Fatalf("CreateParty: %v", err)
+	}
+	if _, err := cli.JoinParty(ctx, &pb.PartyRequest{PlayerId: "C", PartyId: pt.GetPartyId()}); err != nil {
+		t.Fatalf("JoinParty: %v", err)
+	}
+	// el grupo se encola en 2v2, donde cabe entero
+	if res, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "B", GameMode: "2v2"}); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+		t.Fatalf("QueuePlayer(B): %v %v", res, err)
+	}
+
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := cli.SubscribeMatchUpdates(sctx, &pb.SubscribeRequest{PlayerId: "C"})
+	if err != nil {
+		t.Fatalf("SubscribeMatchUpdates: %v", err)
+	}
+	// la suscripción se registra al llegar al servidor
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		mm.mu.RLock()
+		n := len(mm.lookupNS("").subs["C"])
+		mm.mu.RUnlock()
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("la suscripción de C no se registró")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mm.expireQueue(mm.wall.Now().Add(30 * time.Second))
+	for _, id := range []string{"A", "B", "C"} {
+		if got := playerStatus(t, cli, id); got != "IN_QUEUE" {
+			t.Fatalf("%s antes del plazo: estado %s, se esperaba IN_QUEUE", id, got)
+		}
+	}
+
+	mm.expireQueue(mm.wall.Now().Add(2 * time.Minute))
+	for _, id := range []string{"A", "B", "C"} {
+		if got := playerStatus(t, cli, id); got != statusQueueTimedOut {
+			t.Errorf("%s tras el plazo: estado %s, se esperaba %s", id, got, statusQueueTimedOut)
+		}
+	}
+	upd, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if upd.GetEvent() != pb.MatchUpdate_QUEUE_TIMED_OUT {
+		t.Errorf("notificación %v, se esperaba QUEUE_TIMED_OUT", upd.GetEvent())
+	}
+
+	// al volver a encolarse recibe un plazo nuevo
+	if _, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "A"}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	mm.expireQueue(mm.wall.Now().Add(30 * time.Second))
+	if got := playerStatus(t, cli, "A"); got != "IN_QUEUE" {
+		t.Errorf("A reencolado: estado %s, se esperaba IN_QUEUE", got)
+	}
+}
+
+func TestQueueTimeoutDisabled(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	mm.mu.Lock()
+	mm.queueTimeout = 0
+	mm.mu.Unlock()
+
+	if _, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: "A"}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	mm.expireQueue(mm.wall.Now().Add(24 * time.Hour))
+	if got := playerStatus(t, cli, "A"); got != "IN_QUEUE" {
+		t.Errorf("con QUEUE_TIMEOUT=0: estado %s, se esperaba IN_QUEUE", got)
+	}
+}
@@ -0,0 +1,95 @@
+// matchmaker/rate_limit.go
+//
+// Límite de frecuencia de QueuePlayer (internal/ratelimit). Un cliente que
+// se encola en bucle compite por el candado con todo el Matchmaker; el
+// interceptor de este archivo le da a cada jugador un token bucket
+// (QUEUE_RATE por segundo, hasta QUEUE_BURST seguidos) y otro común a todos
+// (QUEUE_GLOBAL_RATE / QUEUE_GLOBAL_BURST). Una petición que no tiene ficha
+// se rechaza con RESOURCE_EXHAUSTED antes de tocar el estado, con un
+// RetryInfo que indica cuándo reintentar. Va después de la autenticación,
+// así que con PLAYER_AUTH=required nadie gasta las fichas de otro jugador.
+// Los contadores se ven en AdminGetSystemStatus y en /metrics.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/ratelimit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultQueueRate        = 1.0
+	defaultQueueBurst       = 5
+	defaultQueueGlobalRate  = 500.0
+	defaultQueueGlobalBurst = 1000
+)
+
+// readQueueRateLimit lee los límites de QueuePlayer; un ritmo de 0 desactiva
+// ese nivel.
+func readQueueRateLimit(cfg *config.Report) ratelimit.Config {
+	return ratelimit.Config{
+		KeyRate:     cfg.Float("QUEUE_RATE", defaultQueueRate, 0, 1000),
+		KeyBurst:    cfg.Int("QUEUE_BURST", defaultQueueBurst, 1, 1000),
+		GlobalRate:  cfg.Float("QUEUE_GLOBAL_RATE", defaultQueueGlobalRate, 0, 1e6),
+		GlobalBurst: cfg.Int("QUEUE_GLOBAL_BURST", defaultQueueGlobalBurst, 1, 1e6),
+	}
+}
+
+// rateLimitUnaryInterceptor aplica m.queueLimit a QueuePlayer.
+func (m *matchmaker) rateLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if m.queueLimit == nil || info.FullMethod != pb.Matchmaker_QueuePlayer_FullMethodName {
+		return handler(ctx, req)
+	}
+	r := req.(*pb.PlayerInfoRequest)
+	ns := r.GetNamespace()
+	if ns == "" {
+		ns = defaultNamespace
+	}
+	res := m.queueLimit.Allow(ns + "/" + r.GetPlayerId())
+	if res.OK {
+		return handler(ctx, req)
+	}
+
+	scope := "player"
+	if res.Global {
+		scope = "global"
+	}
+	m.metrics.rateLimited.With(scope).Inc()
+	st, err := status.New(codes.ResourceExhausted,
+		fmt.Sprintf("demasiadas peticiones de QueuePlayer; reintenta en %v", res.Wait.Round(time.Millisecond))).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(res.Wait)})
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, "demasiadas peticiones de QueuePlayer")
+	}
+	return nil, st.Err()
+}
+
+// rateLimitProto resume el limitador para AdminGetSystemStatus.
+func (m *matchmaker) rateLimitProto() *pb.RateLimitStats {
+	if m.queueLimit == nil {
+		return nil
+	}
+	cfg, st := m.queueLimit.Config(), m.queueLimit.Stats()
+	return &pb.RateLimitStats{
+		PlayerRate:     cfg.KeyRate,
+		PlayerBurst:    int32(cfg.KeyBurst),
+		GlobalRate:     cfg.GlobalRate,
+		GlobalBurst:    int32(cfg.GlobalBurst),
+		Allowed:        st.Allowed,
+		RejectedPlayer: st.RejectedKey,
+		RejectedGlobal: st.RejectedGlobal,
+		ActivePlayers:  int32(st.ActiveKeys),
+		GlobalTokens:   st.GlobalTokens,
+	}
+}
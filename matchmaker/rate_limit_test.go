@@ -0,0 +1,95 @@
+// matchmaker/rate_limit_test.go
+//
+// Límite de QueuePlayer: agotada la ráfaga de un jugador se rechaza con
+// RESOURCE_EXHAUSTED y RetryInfo sin afectar a los demás; el bucket global
+// frena a todos. El tiempo es virtual.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/ratelimit"
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// queueRetry encola a id y devuelve la espera sugerida si fue rechazado.
+func queueRetry(t *testing.T, cli pb.MatchmakerClient, id string) (time.Duration, bool) {
+	t.Helper()
+	_, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id})
+	if err == nil {
+		return 0, false
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("%s: QueuePlayer: %v", id, err)
+	}
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	t.Fatalf("%s: rechazo sin RetryInfo: %v", id, err)
+	return 0, true
+}
+
+func TestQueueRateLimitPerPlayer(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	wall := walltime.NewFake(time.Now())
+	mm.queueLimit = ratelimit.New(ratelimit.Config{KeyRate: 1, KeyBurst: 2}, wall.Now)
+
+	// la cancelación no cuenta; sólo QueuePlayer
+	for i := 0; i < 2; i++ {
+		if _, limited := queueRetry(t, cli, "A"); limited {
+			t.Fatalf("intento %d rechazado dentro de la ráfaga", i+1)
+		}
+		cli.CancelQueue(context.Background(), &pb.CancelQueueRequest{PlayerId: "A"})
+	}
+	wait, limited := queueRetry(t, cli, "A")
+	if !limited || wait <= 0 || wait > time.Second {
+		t.Fatalf("tercer intento: limitado=%v espera=%v; se esperaba rechazo con espera ≤ 1s", limited, wait)
+	}
+	if _, limited := queueRetry(t, cli, "B"); limited {
+		t.Fatalf("B rechazado por las peticiones de A")
+	}
+
+	wall.Advance(wait)
+	if _, limited := queueRetry(t, cli, "A"); limited {
+		t.Fatalf("A sigue rechazado tras esperar %v", wait)
+	}
+
+	res, err := cli.AdminGetSystemStatus(context.Background(), &pb.AdminRequest{})
+	if err != nil {
+		t.Fatalf("AdminGetSystemStatus: %v", err)
+	}
+	rl := res.GetQueueRateLimit()
+	if rl.GetAllowed() != 4 || rl.GetRejectedPlayer() != 1 || rl.GetRejectedGlobal() != 0 {
+		t.Errorf("estadísticas = %v; se esperaban 4 aceptados y 1 rechazo por jugador", rl)
+	}
+}
+
+func TestQueueRateLimitGlobal(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	wall := walltime.NewFake(time.Now())
+	mm.queueLimit = ratelimit.New(ratelimit.Config{GlobalRate: 2, GlobalBurst: 2}, wall.Now)
+
+	for _, id := range []string{"A", "B"} {
+		if _, limited := queueRetry(t, cli, id); limited {
+			t.Fatalf("%s rechazado dentro de la ráfaga global", id)
+		}
+	}
+	wait, limited := queueRetry(t, cli, "C")
+	if !limited || wait != 500*time.Millisecond {
+		t.Fatalf("C: limitado=%v espera=%v; se esperaba rechazo con 500ms", limited, wait)
+	}
+	if rl := mm.rateLimitProto(); rl.GetRejectedGlobal() != 1 {
+		t.Errorf("rechazos globales = %d, se esperaba 1", rl.GetRejectedGlobal())
+	}
+}
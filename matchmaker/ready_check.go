@@ -0,0 +1,276 @@
+// matchmaker/ready_check.go
+//
+// Confirmación previa (ready-check). Con READY_CHECK_TIMEOUT > 0, una partida
+// recién formada no se asigna al servidor de inmediato: sus jugadores reciben
+// READY_CHECK con el plazo para confirmar y el servidor queda reservado.
+// Cada uno responde con AcceptMatch; cuando todos aceptan, la partida sigue
+// como siempre (MATCH_FOUND y AssignMatch). Si alguno rechaza, abandona con
+// LeaveMatch o no responde a tiempo, la partida se descarta: quienes
+// aceptaron vuelven al frente de la cola y quienes no, quedan libres con una
+// espera de READY_CHECK_PENALTY antes de poder encolarse otra vez. Los
+// compañeros de grupo de quien no aceptó también quedan libres, sin espera,
+// porque el grupo se encola junto. Mientras dura la confirmación,
+// GetPlayerStatus informa READY_CHECK.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultReadyCheckTimeout = 0 // sin confirmación previa
+	defaultReadyCheckPenalty = time.Minute
+	maxReadyCheckTimeout     = 5 * time.Minute
+
+	// estado informado de un jugador con una confirmación pendiente
+	statusReadyCheck = "READY_CHECK"
+)
+
+// resultados de la métrica matchmaker_ready_checks_total
+const (
+	readyPassed   = "passed"
+	readyDeclined = "declined"
+	readyTimeout  = "timeout"
+	readyLost     = "server_lost"
+)
+
+// readyCheck es la confirmación pendiente de una partida.
+type readyCheck struct {
+	Deadline time.Time
+	Accepted map[string]bool
+}
+
+// pendingIDs lista, en el orden de la partida, quienes aún no aceptaron.
+func (rc *readyCheck) pendingIDs(players []string) []string {
+	var out []string
+	for _, pid := range players {
+		if !rc.Accepted[pid] {
+			out = append(out, pid)
+		}
+	}
+	return out
+}
+
+// acceptedIDs lista, ordenados, quienes ya aceptaron.
+func (rc *readyCheck) acceptedIDs() []string {
+	out := make([]string, 0, len(rc.Accepted))
+	for pid := range rc.Accepted {
+		out = append(out, pid)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// statusName es el estado que ve el jugador p.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) statusName(p *playerInfo) string {
+	if p.Status == playerInMatch {
+		if am, ok := ns.matches.Get(p.MatchID); ok && am.Ready != nil {
+			return statusReadyCheck
+		}
+	}
+	return p.statusName()
+}
+
+// openReadyCheck abre la confirmación de una partida recién formada.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) openReadyCheck(ns *namespace, matchID string, am *activeMatch, now time.Time) {
+	am.Ready = &readyCheck{Deadline: now.Add(m.readyTimeout), Accepted: map[string]bool{}}
+	for _, pid := range am.Players {
+		m.notify(ns, pid, &pb.MatchUpdate{
+			Event:               pb.MatchUpdate_READY_CHECK,
+			MatchId:             matchID,
+			ReadyDeadlineUnixMs: am.Ready.Deadline.UnixMilli(),
+		})
+	}
+	m.logClockf(ns, "[%s] Match %s (%s) esperando confirmación de %v hasta %s", ns.name, matchID, am.Mode, am.Players, am.Ready.Deadline.Format("15:04:05"))
+}
+
+// launchMatch avisa MATCH_FOUND y envía la partida al servidor.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) launchMatch(ns *namespace, matchID string, am *activeMatch, srv *gameServerInfo, mode *pb.GameMode) {
+	for _, pid := range am.Players {
+		m.notify(ns, pid, &pb.MatchUpdate{
+			Event:      pb.MatchUpdate_MATCH_FOUND,
+			MatchId:    matchID,
+			ServerAddr: srv.Address,
+		})
+	}
+	m.assigning++
+	m.inflight[inflightKey(ns.name, matchID)]++
+	go m.dispatchAssignMatch(ns.matchContext(matchID), ns, srv, matchID, am.Players, mode, ns.vc.Copy())
+	m.logClockf(ns, "[%s] Asignando match %s (%s) a server %s (%s, región %q) con jugadores %v", ns.name, matchID, am.Mode, srv.ID, srv.Address, srv.Region, am.Players)
+}
+
+// passReadyCheck cierra una confirmación aceptada por todos y asigna la
+// partida.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) passReadyCheck(ns *namespace, matchID string, am *activeMatch) {
+	srv, ok := ns.servers.Get(am.ServerID)
+	if !ok || srv.Status == serverDown {
+		m.failReadyCheck(ns, matchID, am, nil, "servidor perdido", readyLost)
+		return
+	}
+	mode := &pb.GameMode{Name: am.Mode}
+	if g, ok := ns.modes[am.Mode]; ok {
+		mode = g.toProto()
+	}
+	waited := m.wall.Now().Sub(am.StartedAt)
+	am.Ready = nil
+	// la duración de la partida cuenta desde que se asigna
+	am.StartedAt = m.wall.Now()
+	ns.vc.Tick(m.selfID)
+	am.Timeline = append(am.Timeline, m.newEvent(ns, pb.MatchEvent_READY, fmt.Sprintf("confirmada en %v", waited.Round(time.Millisecond))))
+	m.metrics.readyChecks.With(ns.name, readyPassed).Inc()
+	m.launchMatch(ns, matchID, am, srv, mode)
+}
+
+// failReadyCheck descarta la partida: declined no aceptaron y quedan libres
+// con la penalización; los demás que ya aceptaron vuelven al frente de la
+// cola. Sin declined (servidor perdido) vuelven todos a la cola.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) failReadyCheck(ns *namespace, matchID string, am *activeMatch, declined []string, reason, result string) {
+	now := m.wall.Now()
+	m.closeMatch(ns, matchID, am, phaseAborted)
+	ns.releaseServer(am.ServerID)
+	ns.vc.Tick(m.selfID)
+
+	out := map[string]bool{}
+	for _, pid := range declined {
+		out[pid] = true
+		// su grupo se encoló con él: tampoco vuelve a la cola
+		if p, ok := ns.players.Get(pid); ok {
+			if pt, ok := ns.parties[p.PartyID]; ok {
+				for _, mid := range pt.Members {
+					if !out[mid] {
+						out[mid] = false
+					}
+				}
+			}
+		}
+	}
+
+	// en orden inverso para que, al reencolar al frente, conserven su orden
+	var requeued []string
+	for i := len(am.Players) - 1; i >= 0; i-- {
+		p, ok := ns.players.Get(am.Players[i])
+		if !ok || p.MatchID != matchID {
+			continue
+		}
+		p.MatchID = ""
+		penalized, stays := out[p.ID]
+		if stays {
+			p.Status, p.LastOp = playerIdle, now
+			if penalized && m.readyPenalty > 0 && now.Add(m.readyPenalty).After(p.CooldownUntil) {
+				p.CooldownUntil = now.Add(m.readyPenalty)
+			}
+			continue
+		}
+		p.Status = playerInQueue
+		p.Priority = priorityRequeued
+		p.QueueDeadline = m.queueDeadline(now)
+		ns.enqueue(p.ID, true)
+		requeued = append(requeued, p.ID)
+	}
+	back := map[string]bool{}
+	for _, pid := range requeued {
+		back[pid] = true
+	}
+	for _, pid := range am.Players {
+		m.notify(ns, pid, &pb.MatchUpdate{
+			Event:    pb.MatchUpdate_READY_CHECK_FAILED,
+			MatchId:  matchID,
+			Requeued: back[pid],
+		})
+	}
+
+	m.metrics.readyChecks.With(ns.name, result).Inc()
+	m.logEvent(ns, &pb.StateEvent{Kind: pb.StateEvent_READY_CHECK_FAILED, PlayerIds: am.Players, MatchId: matchID, ServerId: am.ServerID, Detail: reason})
+	m.logRequeued(ns, matchID, requeued, "confirmación fallida")
+	m.logClockf(ns, "[%s] Match %s descartado (%s); vuelven a la cola %v", ns.name, matchID, reason, requeued)
+}
+
+// expireReadyChecks descarta, en cada namespace, las confirmaciones vencidas.
+func (m *matchmaker) expireReadyChecks(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("expireReadyChecks")
+
+	for _, ns := range m.namespaces {
+		var expired []string
+		for id, am := range ns.matches.All() {
+			if am.Ready != nil && !now.Before(am.Ready.Deadline) {
+				expired = append(expired, id)
+			}
+		}
+		sort.Strings(expired)
+		for _, id := range expired {
+			am := ns.matches.Value(id)
+			missing := am.Ready.pendingIDs(am.Players)
+			m.failReadyCheck(ns, id, am, missing, fmt.Sprintf("sin confirmar: %v", missing), readyTimeout)
+		}
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: AcceptMatch – acepta o rechaza una partida pendiente
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AcceptMatch(ctx context.Context, req *pb.AcceptMatchRequest) (*pb.AcceptMatchResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	p, ok := ns.players.Get(playerID)
+	am := ns.matches.Value(req.GetMatchId())
+	if !ok || am == nil || am.Ready == nil || p.MatchID != req.GetMatchId() {
+		return &pb.AcceptMatchResponse{
+			StatusCode:  pb.AcceptMatchResponse_NOT_PENDING,
+			Message:     "No tienes esa partida pendiente de confirmar",
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+	matchID := p.MatchID
+
+	if !req.GetAccept() {
+		m.failReadyCheck(ns, matchID, am, []string{playerID}, "rechazada por "+playerID, readyDeclined)
+		msg := "Partida rechazada"
+		if m.readyPenalty > 0 {
+			msg = fmt.Sprintf("Partida rechazada; podrás volver a encolarte en %v", m.readyPenalty)
+		}
+		return &pb.AcceptMatchResponse{
+			StatusCode:  pb.AcceptMatchResponse_OK,
+			Message:     msg,
+			Players:     int32(len(am.Players)),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	am.Ready.Accepted[playerID] = true
+	accepted := len(am.Ready.Accepted)
+	m.logClockf(ns, "[%s] %s acepta el match %s (%d/%d)", ns.name, playerID, matchID, accepted, len(am.Players))
+	msg := fmt.Sprintf("Aceptada; esperando al resto (%d/%d)", accepted, len(am.Players))
+	if accepted == len(am.Players) {
+		m.passReadyCheck(ns, matchID, am)
+		msg = "Aceptada; todos confirmaron"
+	}
+	return &pb.AcceptMatchResponse{
+		StatusCode:  pb.AcceptMatchResponse_OK,
+		Message:     msg,
+		Accepted:    int32(accepted),
+		Players:     int32(len(am.Players)),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
@@ -0,0 +1,122 @@
+// matchmaker/ready_check_test.go
+//
+// READY_CHECK_TIMEOUT: la partida formada espera a que todos la acepten
+// antes de asignarse; un rechazo o el vencimiento del plazo la descartan,
+// devuelven a la cola a quienes aceptaron y penalizan a los demás.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// newReadyRig es un failureRig con confirmación previa de un minuto y una
+// partida A-B pendiente; devuelve su ID.
+func newReadyRig(t *testing.T) (*failureRig, string) {
+	t.Helper()
+	r := newFailureRig(t, testkit.Accept)
+	r.mm.mu.Lock()
+	r.mm.readyTimeout = time.Minute
+	r.mm.readyPenalty = 30 * time.Second
+	r.mm.mu.Unlock()
+	r.queuePair("A", "B")
+
+	var matchID string
+	r.inspect(func(ns *namespace) { matchID = ns.players.Value("A").MatchID })
+	if matchID == "" {
+		t.Fatal("no se formó la partida")
+	}
+	for _, id := range []string{"A", "B"} {
+		if got := playerStatus(t, r.cli, id); got != statusReadyCheck {
+			t.Fatalf("%s: estado %s, se esperaba %s", id, got, statusReadyCheck)
+		}
+	}
+	return r, matchID
+}
+
+func acceptMatch(t *testing.T, cli pb.MatchmakerClient, player, matchID string, accept bool) *pb.AcceptMatchResponse {
+	t.Helper()
+	res, err := cli.AcceptMatch(context.Background(), &pb.AcceptMatchRequest{PlayerId: player, MatchId: matchID, Accept: accept})
+	if err != nil {
+		t.Fatalf("AcceptMatch(%s): %v", player, err)
+	}
+	return res
+}
+
+func TestReadyCheckAllAcceptAssigns(t *testing.T) {
+	r, matchID := newReadyRig(t)
+
+	if res := acceptMatch(t, r.cli, "A", matchID, true); res.GetAccepted() != 1 || res.GetPlayers() != 2 {
+		t.Fatalf("A acepta: %d/%d, se esperaba 1/2", res.GetAccepted(), res.GetPlayers())
+	}
+	if n := len(r.gs.Assigned()); n != 0 {
+		t.Fatalf("asignada antes de que todos acepten (%d)", n)
+	}
+	acceptMatch(t, r.cli, "B", matchID, true)
+
+	got := r.gs.WaitAssigned(t, 1, time.Second)
+	if got[0].MatchID != matchID {
+		t.Errorf("asignada %s, se esperaba %s", got[0].MatchID, matchID)
+	}
+	if res := acceptMatch(t, r.cli, "A", matchID, true); res.GetStatusCode() != pb.AcceptMatchResponse_NOT_PENDING {
+		t.Errorf("aceptar una partida ya confirmada: %v, se esperaba NOT_PENDING", res.GetStatusCode())
+	}
+}
+
+func TestReadyCheckDeclineRequeuesOthers(t *testing.T) {
+	r, matchID := newReadyRig(t)
+
+	acceptMatch(t, r.cli, "A", matchID, true)
+	acceptMatch(t, r.cli, "B", matchID, false)
+
+	r.inspect(func(ns *namespace) {
+		if !requeued(ns, "A") {
+			t.Errorf("A aceptó y no volvió al frente de la cola (%s)", ns.players.Value("A").Status)
+		}
+		if b := ns.players.Value("B"); b.Status != playerIdle || !b.CooldownUntil.After(r.mm.wall.Now()) {
+			t.Errorf("B rechazó: estado %s, espera hasta %v", b.Status, b.CooldownUntil)
+		}
+		if !serverIs(ns, r.gs.ID, serverAvailable) {
+			t.Errorf("el servidor reservado no se liberó")
+		}
+	})
+	if n := len(r.gs.Assigned()); n != 0 {
+		t.Errorf("la partida rechazada llegó al servidor (%d)", n)
+	}
+	// la espera impide encolarse de inmediato
+	res, err := r.cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: "B"})
+	if err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	if res.GetStatusCode() == pb.QueuePlayerResponse_OK {
+		t.Errorf("B se encoló durante la penalización")
+	}
+}
+
+func TestReadyCheckExpires(t *testing.T) {
+	r, matchID := newReadyRig(t)
+
+	acceptMatch(t, r.cli, "B", matchID, true)
+	r.mm.expireReadyChecks(r.mm.wall.Now().Add(30 * time.Second))
+	if got := playerStatus(t, r.cli, "A"); got != statusReadyCheck {
+		t.Fatalf("antes del plazo: estado %s, se esperaba %s", got, statusReadyCheck)
+	}
+
+	r.mm.expireReadyChecks(r.mm.wall.Now().Add(2 * time.Minute))
+	r.inspect(func(ns *namespace) {
+		if !requeued(ns, "B") {
+			t.Errorf("B aceptó y no volvió a la cola (%s)", ns.players.Value("B").Status)
+		}
+		if a := ns.players.Value("A"); a.Status != playerIdle || a.CooldownUntil.IsZero() {
+			t.Errorf("A no respondió: estado %s, espera hasta %v", a.Status, a.CooldownUntil)
+		}
+	})
+	if res := acceptMatch(t, r.cli, "A", matchID, true); res.GetStatusCode() != pb.AcceptMatchResponse_NOT_PENDING {
+		t.Errorf("aceptar una partida vencida: %v, se esperaba NOT_PENDING", res.GetStatusCode())
+	}
+}
@@ -0,0 +1,59 @@
+// matchmaker/recovered_matches.go
+//
+// Re-registro de GameServers tras un reinicio del Matchmaker. Cada
+// actualización de estado trae las partidas que el servidor tiene en curso;
+// las que el Matchmaker no conoce (las perdió al reiniciarse sin respaldo)
+// se reconstruyen para que su resultado se acepte y sus jugadores no queden
+// libres para otra partida mientras tanto.
+
+package main
+
+import (
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// adoptRunningMatches reconstruye las partidas en curso desconocidas que
+// declara srv. Un jugador que ya está en otra partida o en cola no se toca.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) adoptRunningMatches(ns *namespace, srv *gameServerInfo, running []*pb.RunningMatch) {
+	for _, rm := range running {
+		matchID := rm.GetMatchId()
+		if _, known := ns.matches.Get(matchID); known || matchID == "" || ns.findResult(matchID) != nil {
+			continue
+		}
+
+		am := &activeMatch{
+			ServerID:  srv.ID,
+			Mode:      rm.GetGameMode(),
+			StartedAt: time.UnixMilli(rm.GetStartedAtUnixMs()),
+			MatchAddr: resolveMatchAddr(srv.Address, rm.GetMatchAddr()),
+		}
+		if rm.GetStartedAtUnixMs() == 0 {
+			am.StartedAt = m.wall.Now()
+		}
+		am.Timeline = []matchEvent{{
+			Stage:  pb.MatchEvent_STARTED,
+			At:     am.StartedAt,
+			VC:     ns.vc.Copy(),
+			Detail: "recuperada",
+		}}
+		am.restorePhase()
+		for _, pid := range rm.GetPlayerIds() {
+			p, ok := ns.players.Get(pid)
+			if !ok {
+				p = &playerInfo{ID: pid, VC: clocks.New()}
+				ns.players.Put(pid, p)
+			}
+			if p.Status != playerIdle || p.LobbyCode != "" {
+				continue
+			}
+			p.Status, p.MatchID, p.Mode = playerInMatch, matchID, am.Mode
+			am.Players = append(am.Players, pid)
+		}
+		ns.matches.Put(matchID, am)
+		m.logf("[%s] Partida %s recuperada desde el server %s con jugadores %v", ns.name, matchID, srv.ID, am.Players)
+	}
+}
@@ -0,0 +1,63 @@
+// matchmaker/regions.go
+//
+// Selección de servidor según región y latencia. Los GameServers declaran su
+// región y una latencia estimada en UpdateServerStatus; los jugadores, una
+// región preferida en QueuePlayer. Al formar una partida se elige, entre los
+// servidores asignables, el que comparte región con más jugadores y, a
+// igualdad, el de menor latencia conocida.
+
+package main
+
+import (
+	"math"
+	"strings"
+)
+
+// normalizeRegion compara regiones sin distinguir mayúsculas ni espacios.
+func normalizeRegion(r string) string {
+	return strings.ToLower(strings.TrimSpace(r))
+}
+
+// latencia efectiva para ordenar: desconocida va al final
+func (s *gameServerInfo) latencyRank() uint32 {
+	if s.LatencyMs == 0 {
+		return math.MaxUint32
+	}
+	return s.LatencyMs
+}
+
+// pickServer elige el mejor servidor asignable para los jugadores dados, o
+// nil si no hay ninguno.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) pickServer(players []string) *gameServerInfo {
+	var best *gameServerInfo
+	bestLocal := -1
+	for _, s := range ns.servers.All() {
+		if !ns.assignable(s) {
+			continue
+		}
+		local := 0
+		if s.Region != "" {
+			for _, pid := range players {
+				if p, ok := ns.players.Get(pid); ok && p.Region == s.Region {
+					local++
+				}
+			}
+		}
+		if best == nil || closer(s, local, best, bestLocal) {
+			best, bestLocal = s, local
+		}
+	}
+	return best
+}
+
+// closer indica si a (con localA jugadores de su región) es preferible a b.
+func closer(a *gameServerInfo, localA int, b *gameServerInfo, localB int) bool {
+	if localA != localB {
+		return localA > localB
+	}
+	if a.latencyRank() != b.latencyRank() {
+		return a.latencyRank() < b.latencyRank()
+	}
+	return a.ID < b.ID // desempate determinista
+}
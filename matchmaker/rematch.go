@@ -0,0 +1,248 @@
+// matchmaker/rematch.go
+//
+// Revanchas. Al registrarse el resultado de una partida, sus jugadores
+// tienen REMATCH_WINDOW para pedir la revancha con RequestRematch (el plazo
+// llega en MATCH_FINISHED). Cada pedido avisa a los que faltan con
+// REMATCH_OFFERED; cuando la pidieron todos, la partida nueva —mismo modo,
+// mismos jugadores en el mismo orden, así que los mismos equipos— arranca
+// sin pasar por la cola ni por la confirmación previa, y sin la espera del
+// modo tras jugar, porque todos la eligieron.
+//
+// El servidor se elige por afinidad: el de la partida anterior, que conserva
+// su estado en caliente, si tiene un hueco; si no, el mejor asignable. Sin
+// ninguno libre, la revancha espera y arranca en la primera vuelta de
+// emparejamiento en que haya hueco, antes que la cola, como una sala
+// privada llena. Si vence el plazo, o si alguno entra en la cola, en otra
+// partida o en una sala antes de que arranque, se cancela con
+// REMATCH_CANCELLED para quienes la pidieron.
+//
+// Las ofertas no se replican: duran segundos y, tras una conmutación, a los
+// jugadores les basta con volver a encolarse.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const defaultRematchWindow = 30 * time.Second
+
+// Resultados de matchmaker_rematches_total.
+const (
+	rematchAffinity  = "affinity"  // arrancó en el servidor de la partida anterior
+	rematchMoved     = "moved"     // arrancó en otro servidor
+	rematchCancelled = "cancelled" // alguien dejó de estar disponible
+	rematchExpired   = "expired"   // venció el plazo
+)
+
+// rematchOffer es la revancha pendiente de una partida terminada.
+type rematchOffer struct {
+	Mode     string
+	ServerID string   // servidor de la partida terminada: afinidad
+	Players  []string // en el orden de la partida, que arma los equipos
+	Deadline time.Time
+	Accepted map[string]bool
+}
+
+func (o *rematchOffer) ready() bool { return len(o.Accepted) == len(o.Players) }
+
+// offerRematch abre la revancha de una partida con resultado; devuelve su
+// plazo, o cero si no hay revancha.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) offerRematch(ns *namespace, matchID string, am *activeMatch) time.Time {
+	if m.rematchWindow <= 0 || len(am.Players) < 2 {
+		return time.Time{}
+	}
+	offer := &rematchOffer{
+		Mode:     am.Mode,
+		ServerID: am.ServerID,
+		Players:  am.Players,
+		Deadline: m.wall.Now().Add(m.rematchWindow),
+		Accepted: map[string]bool{},
+	}
+	ns.rematches[matchID] = offer
+	return offer.Deadline
+}
+
+// affinityServer elige servidor para una partida con afinidad: preferred si
+// puede recibirla ahora y, si no, el mejor asignable para los jugadores.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) affinityServer(players []string, preferred string) *gameServerInfo {
+	if s, ok := ns.servers.Get(preferred); ok && ns.assignable(s) {
+		return s
+	}
+	return ns.pickServer(players)
+}
+
+// rematchBusy explica por qué pid no puede jugar la revancha, o "".
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) rematchBusy(pid string) string {
+	p, ok := ns.players.Get(pid)
+	switch {
+	case !ok || p.Status != playerIdle:
+		return "está en la cola o en otra partida"
+	case p.LobbyCode != "":
+		return "está en una sala privada"
+	case ns.bannedIn([]string{pid}) != "":
+		return "está baneado"
+	}
+	return ""
+}
+
+// tryStartRematch arranca una revancha que ya pidieron todos si hay
+// servidor, y la cancela si alguno dejó de estar disponible o su modo ya no
+// se juega aquí. Devuelve la partida nueva, o "" si no arrancó.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) tryStartRematch(ns *namespace, matchID string, offer *rematchOffer) string {
+	for _, pid := range offer.Players {
+		if why := ns.rematchBusy(pid); why != "" {
+			m.cancelRematch(ns, matchID, offer, pid+" "+why, rematchCancelled)
+			return ""
+		}
+	}
+	mode, ok := ns.modes[offer.Mode]
+	if !ok || !mode.Enabled || !m.shards.ownsMode(offer.Mode) {
+		m.cancelRematch(ns, matchID, offer, "el modo "+offer.Mode+" ya no está disponible", rematchCancelled)
+		return ""
+	}
+	if m.isDraining() {
+		return ""
+	}
+	srv := ns.affinityServer(offer.Players, offer.ServerID)
+	if srv == nil {
+		return ""
+	}
+
+	delete(ns.rematches, matchID)
+	rematchID, am := m.openMatch(ns, mode, offer.Players, srv)
+	result := rematchAffinity
+	if srv.ID != offer.ServerID {
+		result = rematchMoved
+	}
+	m.metrics.rematches.With(ns.name, result).Inc()
+	m.logf("[%s] Revancha de %s: partida %s en %s (antes en %s)", ns.name, matchID, rematchID, srv.ID, offer.ServerID)
+	m.launchMatch(ns, rematchID, am, srv, mode.toProto())
+	return rematchID
+}
+
+// cancelRematch descarta la revancha y avisa a quienes la pidieron.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) cancelRematch(ns *namespace, matchID string, offer *rematchOffer, reason, result string) {
+	delete(ns.rematches, matchID)
+	for _, pid := range offer.Players {
+		if offer.Accepted[pid] {
+			m.notify(ns, pid, &pb.MatchUpdate{Event: pb.MatchUpdate_REMATCH_CANCELLED, MatchId: matchID})
+		}
+	}
+	m.metrics.rematches.With(ns.name, result).Inc()
+	m.logf("[%s] Revancha de %s cancelada: %s", ns.name, matchID, reason)
+}
+
+// startRematches arranca las revanchas que ya pidieron todos, de la que
+// vence antes a la que vence después.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) startRematches(ns *namespace) {
+	var ids []string
+	for id, offer := range ns.rematches {
+		if offer.ready() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ns.rematches[ids[i]].Deadline.Before(ns.rematches[ids[j]].Deadline) })
+	for _, id := range ids {
+		m.tryStartRematch(ns, id, ns.rematches[id])
+	}
+}
+
+// expireRematches descarta, en cada namespace, las revanchas vencidas.
+func (m *matchmaker) expireRematches(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, ns := range m.namespaces {
+		for id, offer := range ns.rematches {
+			if !now.Before(offer.Deadline) {
+				m.cancelRematch(ns, id, offer, "plazo vencido", rematchExpired)
+			}
+		}
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+             RPC: RequestRematch – pide la revancha de una partida
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) RequestRematch(ctx context.Context, req *pb.RematchRequest) (*pb.RematchResponse, error) {
+	playerID := req.GetPlayerId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	m.notePlayerClock(ns, playerID, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	matchID := req.GetMatchId()
+	offer, ok := ns.rematches[matchID]
+	if !ok || !m.wall.Now().Before(offer.Deadline) {
+		return m.rematchResponse(ns, pb.RematchResponse_NO_OFFER, "La partida no tiene revancha pendiente (desconocida, sin revancha o con el plazo vencido)", nil), nil
+	}
+	player := false
+	for _, pid := range offer.Players {
+		player = player || pid == playerID
+	}
+	if !player {
+		return m.rematchResponse(ns, pb.RematchResponse_NOT_A_PLAYER, "No jugaste esa partida", offer), nil
+	}
+	if why := ns.rematchBusy(playerID); why != "" {
+		return m.rematchResponse(ns, pb.RematchResponse_PLAYER_BUSY, "No puedes jugar la revancha: "+why, offer), nil
+	}
+
+	if !offer.Accepted[playerID] {
+		offer.Accepted[playerID] = true
+		// el pedido hace de hito QUEUED de la partida nueva
+		pi := ns.players.Value(playerID)
+		pi.LastOp = m.wall.Now()
+		pi.QueuedVC = ns.vc.Copy()
+		for _, pid := range offer.Players {
+			if !offer.Accepted[pid] {
+				m.notify(ns, pid, &pb.MatchUpdate{
+					Event:                 pb.MatchUpdate_REMATCH_OFFERED,
+					MatchId:               matchID,
+					RematchDeadlineUnixMs: offer.Deadline.UnixMilli(),
+				})
+			}
+		}
+		m.logClockf(ns, "[%s] %s pide la revancha de %s (%d/%d)", ns.name, playerID, matchID, len(offer.Accepted), len(offer.Players))
+	}
+	if !offer.ready() {
+		return m.rematchResponse(ns, pb.RematchResponse_OK, fmt.Sprintf("Revancha pedida; esperando al resto (%d/%d)", len(offer.Accepted), len(offer.Players)), offer), nil
+	}
+
+	rematchID := m.tryStartRematch(ns, matchID, offer)
+	switch {
+	case rematchID != "":
+		res := m.rematchResponse(ns, pb.RematchResponse_OK, "Todos la pidieron: la revancha arranca", offer)
+		res.RematchId, res.ServerId = rematchID, ns.matches.Value(rematchID).ServerID
+		return res, nil
+	case ns.rematches[matchID] == nil:
+		return m.rematchResponse(ns, pb.RematchResponse_PLAYER_BUSY, "La revancha se canceló: no todos siguen disponibles", offer), nil
+	}
+	return m.rematchResponse(ns, pb.RematchResponse_OK, "Todos la pidieron; arrancará cuando haya un servidor libre", offer), nil
+}
+
+func (m *matchmaker) rematchResponse(ns *namespace, code pb.RematchResponse_StatusCode, msg string, offer *rematchOffer) *pb.RematchResponse {
+	res := &pb.RematchResponse{StatusCode: code, Message: msg, VectorClock: m.clockProto(ns)}
+	if offer != nil {
+		res.Accepted = int32(len(offer.Accepted))
+		res.Players = int32(len(offer.Players))
+		res.DeadlineUnixMs = offer.Deadline.UnixMilli()
+	}
+	return res
+}
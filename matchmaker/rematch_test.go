@@ -0,0 +1,96 @@
+// matchmaker/rematch_test.go
+//
+// Revanchas: cuando la piden todos los jugadores de la partida terminada,
+// la nueva arranca en el servidor de la anterior aunque haya otro mejor
+// según pickServer; un jugador ajeno, uno ocupado o un plazo vencido no
+// la arrancan.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func requestRematch(t *testing.T, cli pb.MatchmakerClient, player, matchID string) *pb.RematchResponse {
+	t.Helper()
+	res, err := cli.RequestRematch(context.Background(), &pb.RematchRequest{PlayerId: player, MatchId: matchID})
+	if err != nil {
+		t.Fatalf("RequestRematch(%s): %v", player, err)
+	}
+	return res
+}
+
+func TestRematchPrefersSameServer(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport, testkit.NeverReport)
+	gs2 := r.addServer(testkit.NeverReport, testkit.NeverReport)
+
+	// A-B ocupa gs1, así que C-D va a gs2
+	r.queuePair("A", "B")
+	first := r.gs.WaitAssigned(t, 1, time.Second)[0].MatchID
+	r.queuePair("C", "D")
+	second := gs2.WaitAssigned(t, 1, time.Second)[0].MatchID
+	reportResult(t, r.cli, &pb.MatchResultRequest{MatchId: first, ServerId: r.gs.ID, WinnerId: "A"})
+	reportResult(t, r.cli, &pb.MatchResultRequest{MatchId: second, ServerId: gs2.ID, WinnerId: "C"})
+
+	// con gs1 libre, pickServer elegiría gs1; la afinidad lleva a gs2
+	if res := requestRematch(t, r.cli, "C", second); res.GetStatusCode() != pb.RematchResponse_OK || res.GetAccepted() != 1 || res.GetRematchId() != "" {
+		t.Fatalf("C pide la revancha: %v", res)
+	}
+	res := requestRematch(t, r.cli, "D", second)
+	if res.GetStatusCode() != pb.RematchResponse_OK || res.GetRematchId() == "" || res.GetServerId() != gs2.ID {
+		t.Fatalf("D pide la revancha: %v, se esperaba que arranque en %s", res, gs2.ID)
+	}
+	got := gs2.WaitAssigned(t, 2, time.Second)[1]
+	if got.MatchID != res.GetRematchId() {
+		t.Errorf("gs2 recibió %s, se esperaba la revancha %s", got.MatchID, res.GetRematchId())
+	}
+	r.inspect(func(ns *namespace) {
+		for _, pid := range []string{"C", "D"} {
+			if p := ns.players.Value(pid); p.Status != playerInMatch || p.MatchID != res.GetRematchId() {
+				t.Errorf("%s: %s en %q, se esperaba en la revancha", pid, p.Status, p.MatchID)
+			}
+		}
+		if _, open := ns.rematches[second]; open {
+			t.Errorf("la oferta sigue abierta tras arrancar")
+		}
+	})
+	if n := len(r.gs.Assigned()); n != 1 {
+		t.Errorf("gs1 recibió %d partidas, se esperaba sólo la primera", n)
+	}
+}
+
+func TestRematchRejections(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	r.queuePair("A", "B")
+	matchID := r.gs.WaitAssigned(t, 1, time.Second)[0].MatchID
+
+	if res := requestRematch(t, r.cli, "A", matchID); res.GetStatusCode() != pb.RematchResponse_NO_OFFER {
+		t.Errorf("revancha de una partida en curso: %v, se esperaba NO_OFFER", res.GetStatusCode())
+	}
+	reportResult(t, r.cli, &pb.MatchResultRequest{MatchId: matchID, ServerId: r.gs.ID, WinnerId: "A"})
+
+	if res := requestRematch(t, r.cli, "Z", matchID); res.GetStatusCode() != pb.RematchResponse_NOT_A_PLAYER {
+		t.Errorf("revancha de un jugador ajeno: %v, se esperaba NOT_A_PLAYER", res.GetStatusCode())
+	}
+	requestRematch(t, r.cli, "A", matchID)
+	if _, err := r.cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: "B"}); err != nil {
+		t.Fatalf("QueuePlayer(B): %v", err)
+	}
+	if res := requestRematch(t, r.cli, "B", matchID); res.GetStatusCode() != pb.RematchResponse_PLAYER_BUSY {
+		t.Errorf("revancha desde la cola: %v, se esperaba PLAYER_BUSY", res.GetStatusCode())
+	}
+
+	// el plazo vence con la oferta a medias
+	r.mm.expireRematches(r.mm.wall.Now().Add(time.Hour))
+	if res := requestRematch(t, r.cli, "A", matchID); res.GetStatusCode() != pb.RematchResponse_NO_OFFER {
+		t.Errorf("revancha vencida: %v, se esperaba NO_OFFER", res.GetStatusCode())
+	}
+	if n := len(r.gs.Assigned()); n != 1 {
+		t.Errorf("el servidor recibió %d partidas, se esperaba sólo la primera", n)
+	}
+}
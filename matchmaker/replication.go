@@ -0,0 +1,658 @@
+// matchmaker/replication.go
+//
+// Replicación primario/respaldo del Matchmaker.
+//
+// ▸ El primario abre un stream ReplicateState hacia BACKUP_ADDR y envía un
+//   snapshot completo (jugadores, cola, servidores, partidas, revanchas,
+//   salas, sesiones, retransmisiones y reloj de cada namespace) cada
+//   replicationInterval. Cada snapshot sirve de heartbeat. Se copia con m.mu
+//   en lectura y se serializa sin candados.
+// ▸ El log de notificaciones también se replica: tras la conmutación, los
+//   jugadores reanudan su stream con resume_from sin perder eventos.
+// ▸ También viaja el último MatchID emitido, para que el respaldo promovido
+//   no repita IDs aunque su reloj de pared vaya atrasado.
+// ▸ El respaldo arranca pasivo: rechaza toda RPC salvo ReplicateState y el
+//   servicio de salud (que informa NOT_SERVING), y sustituye su estado con
+//   cada snapshot recibido.
+// ▸ Si el respaldo deja de recibir snapshots durante failoverTimeout, se
+//   promueve a primario y arranca el bucle de emparejamiento. Un primario
+//   que se apaga en orden le traspasa su estado final y lo promueve en el
+//   acto (shutdown.go).
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	rolePrimary = "primary"
+	roleBackup  = "backup"
+
+	replicationInterval = 1 * time.Second
+	failoverTimeout     = 5 * time.Second
+)
+
+// replicaState guarda el estado de replicación del proceso.
+type replicaState struct {
+	passive   atomic.Bool  // true mientras sea respaldo no promovido
+	lastSync  atomic.Int64 // unix nanos del último snapshot recibido
+	sequence  atomic.Uint64
+	conflicts atomic.Uint64 // snapshots concurrentes con el estado local
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                         Snapshot ⇄ estado en memoria
+───────────────────────────────────────────────────────────────────────────────*/
+
+// stateCapture es un snapshot a medio armar. captureState ya copió lo que
+// puede cambiar; el historial y el log de notificaciones, lo más voluminoso,
+// sólo crecen por el final y sus entradas no cambian una vez escritas, así
+// que se guardan las porciones vigentes y finish las convierte sin candados.
+type stateCapture struct {
+	snap    *pb.StateSnapshot
+	history [][]*matchResult // por namespace, en el orden de snap.Namespaces
+	events  [][]playerEvent
+}
+
+// snapshot copia todo el estado replicable.
+// debe llamarse con m.mu bloqueado (lectura o escritura) y sin jugadores
+// bloqueados
+func (m *matchmaker) snapshot() *pb.StateSnapshot {
+	return m.captureState().finish()
+}
+
+// replicaSnapshot es snapshot para quien no tiene m.mu: lo toma en lectura
+// sólo mientras copia, así que no detiene las RPC que sólo leen, y convierte
+// el historial después de soltarlo.
+func (m *matchmaker) replicaSnapshot() *pb.StateSnapshot {
+	m.mu.RLock()
+	c := m.captureState()
+	m.mu.RUnlock()
+	return c.finish()
+}
+
+// captureState copia el estado replicable salvo el contenido del historial
+// y del log de notificaciones. Con m.mu en lectura, las RPC de jugador
+// siguen pudiendo correr: bloquea a todos los jugadores de cada namespace
+// (y las sesiones) para no ver ninguna a medias (locking.go).
+// debe llamarse con m.mu bloqueado (lectura o escritura) y sin jugadores
+// bloqueados
+func (m *matchmaker) captureState() *stateCapture {
+	c := &stateCapture{snap: &pb.StateSnapshot{PrimaryId: m.selfID, LastMatchId: m.matchIDs.Last()}}
+	m.sessionsMu.Lock()
+	defer m.sessionsMu.Unlock()
+	for _, ns := range m.namespaces {
+		unlockPlayers := ns.playerLocks.LockAll()
+		nsSnap := &pb.NamespaceSnapshot{
+			Name:            ns.name,
+			Queue:           ns.queue.IDs(),
+			VectorClock:     ns.vc.ToProto(),
+			ClockTombstones: ns.vc.TombstonesToProto(),
+		}
+		for _, p := range ns.players.All() {
+			var cooldownMs, deadlineMs, heartbeatMs, offerMs int64
+			if !p.CooldownUntil.IsZero() {
+				cooldownMs = p.CooldownUntil.UnixMilli()
+			}
+			if !p.QueueDeadline.IsZero() {
+				deadlineMs = p.QueueDeadline.UnixMilli()
+			}
+			if !p.LastHeartbeat.IsZero() {
+				heartbeatMs = p.LastHeartbeat.UnixMilli()
+			}
+			if !p.RequeueOffer.IsZero() {
+				offerMs = p.RequeueOffer.UnixMilli()
+			}
+			nsSnap.Players = append(nsSnap.Players, &pb.PlayerSnapshot{
+				PlayerId:            p.ID,
+				Status:              p.Status.String(),
+				MatchId:             p.MatchID,
+				LastOpUnixMs:        p.LastOp.UnixMilli(),
+				GameMode:            p.Mode,
+				Priority:            p.Priority.toProto(),
+				Tier:                p.Tier.toProto(),
+				Region:              p.Region,
+				PartyId:             p.PartyID,
+				CooldownUntilUnixMs: cooldownMs,
+				Registered:          p.Registered,
+				QueueDeadlineUnixMs: deadlineMs,
+				QueueTimedOut:       p.QueueTimedOut,
+				LastHeartbeatUnixMs: heartbeatMs,
+				OnAssignFailure:     p.OnAssignFailure,
+				RequeueOfferUnixMs:  offerMs,
+			})
+		}
+		for _, s := range ns.servers.All() {
+			nsSnap.Servers = append(nsSnap.Servers, &pb.ServerSnapshot{
+				ServerId:            s.ID,
+				Address:             s.Address,
+				Status:              serverStatusProto(s.Status),
+				CurrentMatchId:      strings.Join(ns.serverMatches(s.ID), ","),
+				LastHeartbeatUnixMs: s.LastHB.UnixMilli(),
+				Draining:            s.Draining,
+				Region:              s.Region,
+				LatencyMs:           s.LatencyMs,
+				Capacity:            int32(s.Capacity),
+				FreeSlots:           int32(s.FreeSlots),
+			})
+		}
+		for id, am := range ns.matches.All() {
+			ms := &pb.MatchSnapshot{
+				MatchId:         id,
+				PlayerIds:       append([]string(nil), am.Players...),
+				ServerId:        am.ServerID,
+				GameMode:        am.Mode,
+				StartedAtUnixMs: am.StartedAt.UnixMilli(),
+				Timeline:        timelineProto(am.Timeline),
+				OpenSlots:       int32(am.OpenSlots),
+				LobbyCode:       am.LobbyCode,
+				Pinned:          am.Pinned,
+				MatchAddr:       am.MatchAddr,
+			}
+			if am.Ready != nil {
+				ms.ReadyDeadlineUnixMs = am.Ready.Deadline.UnixMilli()
+				ms.ReadyAccepted = am.Ready.acceptedIDs()
+			}
+			nsSnap.Matches = append(nsSnap.Matches, ms)
+		}
+		for _, g := range ns.sortedModes() {
+			nsSnap.Modes = append(nsSnap.Modes, g.toProto())
+		}
+		for _, pt := range ns.parties {
+			nsSnap.Parties = append(nsSnap.Parties, &pb.PartySnapshot{
+				PartyId:   pt.ID,
+				LeaderId:  pt.Leader,
+				MemberIds: append([]string(nil), pt.Members...),
+			})
+		}
+		for _, lb := range ns.privateLobbies {
+			nsSnap.Lobbies = append(nsSnap.Lobbies, &pb.LobbySnapshot{
+				Code:            lb.Code,
+				GameMode:        lb.Mode,
+				ServerId:        lb.ServerID,
+				MemberIds:       append([]string(nil), lb.Members...),
+				CreatedAtUnixMs: lb.CreatedAt,
+			})
+		}
+		nsSnap.Banned = ns.bannedProto()
+		for pid, st := range ns.stats {
+			nsSnap.Stats = append(nsSnap.Stats, st.toProto(pid))
+		}
+		for id, o := range ns.rematches {
+			accepted := make([]string, 0, len(o.Accepted))
+			for pid := range o.Accepted {
+				accepted = append(accepted, pid)
+			}
+			sort.Strings(accepted)
+			nsSnap.Rematches = append(nsSnap.Rematches, &pb.RematchSnapshot{
+				MatchId:        id,
+				GameMode:       o.Mode,
+				ServerId:       o.ServerID,
+				PlayerIds:      append([]string(nil), o.Players...),
+				DeadlineUnixMs: o.Deadline.UnixMilli(),
+				AcceptedIds:    accepted,
+			})
+		}
+		if len(ns.lobbyReady) > 0 {
+			nsSnap.LobbyReadyUnixMs = make(map[string]int64, len(ns.lobbyReady))
+			for mode, since := range ns.lobbyReady {
+				nsSnap.LobbyReadyUnixMs[mode] = since.UnixMilli()
+			}
+		}
+		for _, r := range ns.removed {
+			nsSnap.Removed = append(nsSnap.Removed, &pb.RemovedServer{
+				ServerId:        r.ID,
+				Address:         r.Address,
+				Reason:          r.Reason,
+				RemovedAtUnixMs: r.RemovedAt.UnixMilli(),
+			})
+		}
+		for k, sess := range m.sessions {
+			if k.Namespace != ns.name {
+				continue
+			}
+			nsSnap.Sessions = append(nsSnap.Sessions, &pb.PlayerSession{
+				PlayerId:        k.Player,
+				SessionId:       sess.ID,
+				Peer:            sess.Peer,
+				StartedAtUnixMs: sess.StartedAt.UnixMilli(),
+				LastSeenUnixMs:  sess.LastSeen.UnixMilli(),
+				Rpcs:            sess.RPCs,
+				Conflicts:       int32(sess.Conflicts),
+				Fenced:          append([]string(nil), sess.Fenced...),
+			})
+		}
+		for k, r := range m.relays {
+			if k.Namespace != ns.name {
+				continue
+			}
+			nsSnap.Relays = append(nsSnap.Relays, &pb.RelaySnapshot{MatchId: k.MatchID, ServerId: r.server, Last: r.last})
+		}
+		unlockPlayers()
+
+		c.snap.Namespaces = append(c.snap.Namespaces, nsSnap)
+		c.history = append(c.history, ns.history)
+		c.events = append(c.events, ns.events)
+	}
+	return c
+}
+
+// finish convierte el historial y las notificaciones capturados y devuelve
+// el snapshot completo. No necesita candados.
+func (c *stateCapture) finish() *pb.StateSnapshot {
+	for i, nsSnap := range c.snap.Namespaces {
+		nsSnap.History = make([]*pb.MatchRecord, 0, len(c.history[i]))
+		for _, res := range c.history[i] {
+			nsSnap.History = append(nsSnap.History, res.toProto())
+		}
+		nsSnap.Events = make([]*pb.PlayerEvent, 0, len(c.events[i]))
+		for _, ev := range c.events[i] {
+			nsSnap.Events = append(nsSnap.Events, &pb.PlayerEvent{PlayerId: ev.PlayerID, Update: ev.Update})
+		}
+	}
+	return c.snap
+}
+
+// restore reemplaza el estado local por el de un snapshot.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) restore(snap *pb.StateSnapshot) {
+	namespaces := make(map[string]*namespace, len(snap.GetNamespaces()))
+	sessions := make(map[sessionKey]*playerSession)
+	relays := make(map[relayKey]*matchRelay)
+	for _, nsSnap := range snap.GetNamespaces() {
+		ns := newNamespace(nsSnap.GetName())
+		ns.vc = clocks.FromProto(nsSnap.GetVectorClock())
+		ns.vc.RestoreTombstones(nsSnap.GetClockTombstones())
+		for _, p := range nsSnap.GetPlayers() {
+			pi := &playerInfo{
+				ID:         p.GetPlayerId(),
+				Status:     parsePlayerState(p.GetStatus()),
+				Mode:       p.GetGameMode(),
+				Priority:   queuePriorityFromProto(p.GetPriority()),
+				Tier:       playerTierFromProto(p.GetTier()),
+				Region:     p.GetRegion(),
+				PartyID:    p.GetPartyId(),
+				MatchID:    p.GetMatchId(),
+				VC:         clocks.New(),
+				LastOp:     time.UnixMilli(p.GetLastOpUnixMs()),
+				Registered: p.GetRegistered(),
+			}
+			if ms := p.GetCooldownUntilUnixMs(); ms > 0 {
+				pi.CooldownUntil = time.UnixMilli(ms)
+			}
+			if ms := p.GetQueueDeadlineUnixMs(); ms > 0 {
+				pi.QueueDeadline = time.UnixMilli(ms)
+			}
+			pi.QueueTimedOut = p.GetQueueTimedOut()
+			if ms := p.GetLastHeartbeatUnixMs(); ms > 0 {
+				pi.LastHeartbeat = time.UnixMilli(ms)
+			}
+			pi.OnAssignFailure = p.GetOnAssignFailure()
+			if ms := p.GetRequeueOfferUnixMs(); ms > 0 {
+				pi.RequeueOffer = time.UnixMilli(ms)
+			}
+			ns.players.Put(pi.ID, pi)
+		}
+		for _, pid := range nsSnap.GetQueue() {
+			ns.queue.Append(ns.queueEntry(pid))
+		}
+		for _, s := range nsSnap.GetServers() {
+			ns.servers.Put(s.GetServerId(), &gameServerInfo{
+				ID:        s.GetServerId(),
+				Address:   s.GetAddress(),
+				Status:    serverStatusFromProto(s.GetStatus()),
+				VC:        clocks.New(),
+				LastHB:    time.UnixMilli(s.GetLastHeartbeatUnixMs()),
+				Draining:  s.GetDraining(),
+				Region:    s.GetRegion(),
+				LatencyMs: s.GetLatencyMs(),
+				Capacity:  int(s.GetCapacity()),
+				FreeSlots: int(s.GetFreeSlots()),
+			})
+		}
+		for _, g := range nsSnap.GetModes() {
+			ns.modes[g.GetName()] = gameModeFromProto(g)
+		}
+		for _, pt := range nsSnap.GetParties() {
+			ns.parties[pt.GetPartyId()] = &party{
+				ID:      pt.GetPartyId(),
+				Leader:  pt.GetLeaderId(),
+				Members: pt.GetMemberIds(),
+			}
+		}
+		for _, lb := range nsSnap.GetLobbies() {
+			ns.privateLobbies[lb.GetCode()] = &privateLobby{
+				Code:      lb.GetCode(),
+				Mode:      lb.GetGameMode(),
+				ServerID:  lb.GetServerId(),
+				Members:   lb.GetMemberIds(),
+				CreatedAt: lb.GetCreatedAtUnixMs(),
+			}
+			for _, pid := range lb.GetMemberIds() {
+				if p, ok := ns.players.Get(pid); ok {
+					p.LobbyCode = lb.GetCode()
+				}
+			}
+		}
+		for _, b := range nsSnap.GetBanned() {
+			ns.banned[b.GetPlayerId()] = b.GetReason()
+		}
+		for _, ev := range nsSnap.GetEvents() {
+			ns.events = append(ns.events, playerEvent{PlayerID: ev.GetPlayerId(), Update: ev.GetUpdate()})
+		}
+		for _, o := range nsSnap.GetRematches() {
+			offer := &rematchOffer{
+				Mode:     o.GetGameMode(),
+				ServerID: o.GetServerId(),
+				Players:  o.GetPlayerIds(),
+				Deadline: time.UnixMilli(o.GetDeadlineUnixMs()),
+				Accepted: make(map[string]bool, len(o.GetAcceptedIds())),
+			}
+			for _, pid := range o.GetAcceptedIds() {
+				offer.Accepted[pid] = true
+			}
+			ns.rematches[o.GetMatchId()] = offer
+		}
+		for mode, ms := range nsSnap.GetLobbyReadyUnixMs() {
+			ns.lobbyReady[mode] = time.UnixMilli(ms)
+		}
+		for _, r := range nsSnap.GetRemoved() {
+			ns.removed = append(ns.removed, removedServer{
+				ID:        r.GetServerId(),
+				Address:   r.GetAddress(),
+				Reason:    r.GetReason(),
+				RemovedAt: time.UnixMilli(r.GetRemovedAtUnixMs()),
+			})
+		}
+		for _, ps := range nsSnap.GetSessions() {
+			sessions[sessionKey{ns.name, ps.GetPlayerId()}] = &playerSession{
+				ID:        ps.GetSessionId(),
+				Peer:      ps.GetPeer(),
+				StartedAt: time.UnixMilli(ps.GetStartedAtUnixMs()),
+				LastSeen:  time.UnixMilli(ps.GetLastSeenUnixMs()),
+				RPCs:      ps.GetRpcs(),
+				Conflicts: int(ps.GetConflicts()),
+				Fenced:    ps.GetFenced(),
+			}
+		}
+		for _, rs := range nsSnap.GetRelays() {
+			key := relayKey{ns.name, rs.GetMatchId()}
+			r := &matchRelay{server: rs.GetServerId(), last: rs.GetLast(), subs: make(map[chan *pb.SpectateEvent]string)}
+			if old, ok := m.relays[key]; ok {
+				r.subs = old.subs // espectadores ya conectados a este proceso
+			}
+			relays[key] = r
+		}
+		for _, rec := range nsSnap.GetHistory() {
+			ns.history = append(ns.history, matchResultFromProto(rec))
+			ns.historySeq = rec.GetSequence()
+		}
+		for _, st := range nsSnap.GetStats() {
+			ns.stats[st.GetPlayerId()] = playerStatsFromProto(st)
+		}
+		for _, mt := range nsSnap.GetMatches() {
+			am := &activeMatch{
+				Players:   mt.GetPlayerIds(),
+				ServerID:  mt.GetServerId(),
+				Mode:      mt.GetGameMode(),
+				StartedAt: time.UnixMilli(mt.GetStartedAtUnixMs()),
+				Timeline:  timelineFromProto(mt.GetTimeline()),
+				OpenSlots: int(mt.GetOpenSlots()),
+				LobbyCode: mt.GetLobbyCode(),
+				Pinned:    mt.GetPinned(),
+				MatchAddr: mt.GetMatchAddr(),
+			}
+			if mt.GetReadyDeadlineUnixMs() != 0 {
+				am.Ready = &readyCheck{Deadline: time.UnixMilli(mt.GetReadyDeadlineUnixMs()), Accepted: map[string]bool{}}
+				for _, pid := range mt.GetReadyAccepted() {
+					am.Ready.Accepted[pid] = true
+				}
+			}
+			am.restorePhase()
+			ns.matches.Put(mt.GetMatchId(), am)
+		}
+		namespaces[ns.name] = ns
+	}
+	m.namespaces = namespaces
+	m.sessions = sessions
+	m.relays = relays
+	m.reindexServerAddrs()
+	m.matchIDs.Observe(snap.GetLastMatchId())
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                      Primario: envía snapshots al respaldo
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) runReplication(backupAddr string) {
+	for {
+		err := m.streamSnapshots(backupAddr)
+		select {
+		case <-m.done:
+			return
+		case <-m.draining:
+			return
+		default:
+		}
+		m.logf("Replicación hacia %s interrumpida: %v (reintentando)", backupAddr, err)
+
+		select {
+		case <-m.done:
+			return
+		case <-m.draining:
+			return
+		case <-m.wall.After(replicationInterval):
+		}
+	}
+}
+
+func (m *matchmaker) streamSnapshots(backupAddr string) error {
+	conn, err := grpc.Dial(backupAddr, grpc.WithTransportCredentials(m.dialCreds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := pb.NewMatchmakerClient(conn).ReplicateState(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := m.wall.NewTicker(replicationInterval)
+	defer ticker.Stop()
+	for {
+		snap := m.replicaSnapshot()
+		snap.Sequence = m.replica.sequence.Add(1)
+
+		if err := stream.Send(snap); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C():
+		case <-m.done:
+			_, err := stream.CloseAndRecv()
+			return err
+		case <-m.draining:
+			// el estado final viaja con TransferState
+			_, err := stream.CloseAndRecv()
+			return err
+		}
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                   Respaldo: recibe snapshots y detecta caídas
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) ReplicateState(stream pb.Matchmaker_ReplicateStateServer) error {
+	var last uint64
+	for {
+		snap, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&pb.ReplicationAck{LastSequence: last})
+		}
+		if err != nil {
+			return err
+		}
+		if !m.replica.passive.Load() {
+			// ya fuimos promovidos: no aceptamos estado de un primario antiguo
+			return status.Error(codes.FailedPrecondition, "este Matchmaker ya es primario")
+		}
+
+		m.mu.Lock()
+		m.detectSnapshotConflicts(snap)
+		m.restore(snap)
+		m.mu.Unlock()
+
+		last = snap.GetSequence()
+		if m.replica.lastSync.Swap(m.wall.Now().UnixNano()) == 0 {
+			m.logf("Recibiendo estado del primario %s", snap.GetPrimaryId())
+		}
+	}
+}
+
+// detectSnapshotConflicts compara el reloj de cada namespace del snapshot
+// con el local. Un respaldo pasivo sólo avanza con snapshots, así que un
+// reloj concurrente indica que el estado local no proviene de este primario
+// (dos primarios a la vez, o uno reiniciado que perdió su estado) y uno
+// anterior, que el primario retrocedió. En ambos casos se impone el
+// snapshot, como siempre, pero queda registrado.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) detectSnapshotConflicts(snap *pb.StateSnapshot) {
+	for _, nsSnap := range snap.GetNamespaces() {
+		local, ok := m.namespaces[nsSnap.GetName()]
+		if !ok {
+			continue
+		}
+		in := clocks.FromProto(nsSnap.GetVectorClock())
+		in.RestoreTombstones(nsSnap.GetClockTombstones())
+		switch {
+		case in.Concurrent(local.vc):
+			m.replica.conflicts.Add(1)
+			m.logf("WARN [%s] Snapshot de %s concurrente con el estado local (%s vs %s): ¿dos primarios?",
+				local.name, snap.GetPrimaryId(), in, local.vc)
+		case in.HappensBefore(local.vc):
+			m.replica.conflicts.Add(1)
+			m.logf("WARN [%s] Snapshot de %s anterior al estado local (%s vs %s): ¿primario reiniciado?",
+				local.name, snap.GetPrimaryId(), in, local.vc)
+		}
+	}
+}
+
+// watchPrimary promueve este respaldo a primario si el primario deja de
+// enviar snapshots durante failoverTimeout.
+func (m *matchmaker) watchPrimary() {
+	ticker := m.wall.NewTicker(failoverTimeout / 5)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			if !m.replica.passive.Load() {
+				return // promovido por un traspaso
+			}
+			last := m.replica.lastSync.Load()
+			if last == 0 || m.wall.Since(time.Unix(0, last)) < failoverTimeout {
+				continue
+			}
+			m.promote(fmt.Sprintf("Primario sin heartbeats por %v", failoverTimeout))
+			return
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// promote convierte este respaldo en primario; reason encabeza el log. Sólo
+// la primera llamada tiene efecto.
+func (m *matchmaker) promote(reason string) {
+	m.mu.Lock()
+	if !m.replica.passive.Load() {
+		m.mu.Unlock()
+		return
+	}
+	m.resetHeartbeats()
+	m.replica.passive.Store(false)
+	m.mu.Unlock()
+
+	m.updateHealth()
+	m.logf("%s: este respaldo asume como PRIMARIO", reason)
+	go m.runMatchLoop()
+}
+
+// resetHeartbeats da a los servidores y a los clientes de jugador que laten
+// un período de gracia completo tras tomar un estado ajeno: no alcanzaron a
+// reportarse con nosotros y no deben declararse caídos por eso.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) resetHeartbeats() {
+	now := m.wall.Now()
+	for _, ns := range m.namespaces {
+		for _, s := range ns.servers.All() {
+			s.LastHB = now
+		}
+		for _, p := range ns.players.All() {
+			if !p.LastHeartbeat.IsZero() {
+				p.LastHeartbeat = now
+			}
+		}
+		ns.vc.Tick(m.selfID)
+	}
+}
+
+// updateHealth publica en grpc.health.v1 si el servicio Matchmaker atiende:
+// un respaldo pasivo responde NOT_SERVING hasta ser promovido.
+func (m *matchmaker) updateHealth() {
+	st := healthpb.HealthCheckResponse_SERVING
+	if m.replica.passive.Load() {
+		st = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	m.health.SetServingStatus(pb.Matchmaker_ServiceDesc.ServiceName, st)
+}
+
+// isHealthMethod indica si la RPC es del servicio de salud, que responde
+// también en un respaldo pasivo.
+func isHealthMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
+
+// passiveUnaryInterceptor rechaza las RPCs unarias mientras el proceso sea
+// un respaldo pasivo, para que los clientes no lean estado a medio replicar;
+// sólo deja pasar la salud y el traspaso.
+func (m *matchmaker) passiveUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if m.replica.passive.Load() && info.FullMethod != pb.Matchmaker_TransferState_FullMethodName && !isHealthMethod(info.FullMethod) {
+		return nil, status.Error(codes.Unavailable, "Matchmaker de respaldo: aún no es primario")
+	}
+	return handler(ctx, req)
+}
+
+// passiveStreamInterceptor hace lo mismo para streams, dejando pasar sólo la
+// replicación y el log en vivo (para ver por qué el respaldo no se promueve).
+func (m *matchmaker) passiveStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	switch info.FullMethod {
+	case pb.Matchmaker_ReplicateState_FullMethodName, pb.Matchmaker_AdminStreamLogs_FullMethodName:
+		return handler(srv, ss)
+	}
+	if m.replica.passive.Load() && !isHealthMethod(info.FullMethod) {
+		return status.Error(codes.Unavailable, "Matchmaker de respaldo: aún no es primario")
+	}
+	return handler(srv, ss)
+}
@@ -0,0 +1,229 @@
+// matchmaker/replication_test.go
+//
+// Replicación primario/respaldo: un snapshot restaurado en otro Matchmaker
+// conserva todo el estado (también revanchas, salas por mínimo, servidores
+// retirados, sesiones y retransmisiones) y vuelve a producir el mismo
+// snapshot; copiarlo no necesita m.mu en escritura ni choca con las RPC de
+// jugador; y el respaldo, que rechaza las RPC mientras es pasivo, se
+// promueve sólo tras failoverTimeout sin snapshots.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/walltime"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// sortSnapshot ordena las listas que salen de mapas, para comparar snapshots.
+func sortSnapshot(snap *pb.StateSnapshot) {
+	for _, ns := range snap.GetNamespaces() {
+		sort.Slice(ns.Players, func(i, j int) bool { return ns.Players[i].GetPlayerId() < ns.Players[j].GetPlayerId() })
+		sort.Slice(ns.Servers, func(i, j int) bool { return ns.Servers[i].GetServerId() < ns.Servers[j].GetServerId() })
+		sort.Slice(ns.Matches, func(i, j int) bool { return ns.Matches[i].GetMatchId() < ns.Matches[j].GetMatchId() })
+		sort.Slice(ns.Parties, func(i, j int) bool { return ns.Parties[i].GetPartyId() < ns.Parties[j].GetPartyId() })
+		sort.Slice(ns.Lobbies, func(i, j int) bool { return ns.Lobbies[i].GetCode() < ns.Lobbies[j].GetCode() })
+		sort.Slice(ns.Banned, func(i, j int) bool { return ns.Banned[i].GetPlayerId() < ns.Banned[j].GetPlayerId() })
+		sort.Slice(ns.Stats, func(i, j int) bool { return ns.Stats[i].GetPlayerId() < ns.Stats[j].GetPlayerId() })
+		sort.Slice(ns.Rematches, func(i, j int) bool { return ns.Rematches[i].GetMatchId() < ns.Rematches[j].GetMatchId() })
+		sort.Slice(ns.Sessions, func(i, j int) bool { return ns.Sessions[i].GetPlayerId() < ns.Sessions[j].GetPlayerId() })
+		sort.Slice(ns.Relays, func(i, j int) bool { return ns.Relays[i].GetMatchId() < ns.Relays[j].GetMatchId() })
+	}
+	sort.Slice(snap.Namespaces, func(i, j int) bool { return snap.Namespaces[i].GetName() < snap.Namespaces[j].GetName() })
+}
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	queueAll(t, cli, "P1", "P2", "P3")
+
+	t0 := time.UnixMilli(time.Now().UnixMilli())
+	mm.mu.Lock()
+	ns := mm.ns("")
+	ns.rematches["M0"] = &rematchOffer{
+		Mode:     "1v1",
+		ServerID: "gs1",
+		Players:  []string{"A", "B"},
+		Deadline: t0.Add(30 * time.Second),
+		Accepted: map[string]bool{"A": true},
+	}
+	ns.lobbyReady["4p"] = t0
+	ns.banned["X"] = "trampa"
+	ns.removed = append(ns.removed,
+		removedServer{ID: "gs8", Address: "10.0.0.8:50051", Reason: "shutdown", RemovedAt: t0},
+		removedServer{ID: "gs9", Address: "10.0.0.9:50051", Reason: "drained", RemovedAt: t0.Add(time.Second)})
+	mm.sessions[sessionKey{ns.name, "P1"}] = &playerSession{
+		ID: "s-nueva", Peer: "10.0.0.1:4000", StartedAt: t0, LastSeen: t0.Add(time.Minute),
+		RPCs: 7, Conflicts: 1, Fenced: []string{"s-vieja"},
+	}
+	mm.relays[relayKey{ns.name, "M1"}] = &matchRelay{
+		server: "gs1",
+		last:   &pb.SpectateEvent{Kind: pb.SpectateEvent_SCORE, MatchId: "M1", PlayerId: "A", VictimId: "B"},
+		subs:   make(map[chan *pb.SpectateEvent]string),
+	}
+	mm.recordMatch(ns, &matchResult{MatchID: "M0", ServerID: "gs1", Mode: "1v1", Players: []string{"A", "B"},
+		WinnerID: "A", Outcome: outcomeCompleted, StartedAt: t0, FinishedAt: t0.Add(time.Minute)})
+	mm.mu.Unlock()
+
+	snap := snapshotOf(mm)
+	backup := newMatchmaker("Backup")
+	backup.mu.Lock()
+	backup.restore(snap)
+	backup.mu.Unlock()
+
+	backup.mu.RLock()
+	bns := backup.lookupNS("")
+	if o := bns.rematches["M0"]; o == nil || o.ServerID != "gs1" || !o.Deadline.Equal(t0.Add(30*time.Second)) || !o.Accepted["A"] || o.Accepted["B"] {
+		t.Errorf("revancha en el respaldo = %+v", o)
+	}
+	if got := bns.lobbyReady["4p"]; !got.Equal(t0) {
+		t.Errorf("lobbyReady[4p] = %v, se esperaba %v", got, t0)
+	}
+	if len(bns.removed) != 2 || bns.removed[0].ID != "gs8" || bns.removed[1].Reason != "drained" {
+		t.Errorf("retirados en el respaldo = %+v", bns.removed)
+	}
+	if s := backup.sessions[sessionKey{bns.name, "P1"}]; s == nil || s.ID != "s-nueva" || s.RPCs != 7 || !s.fenced("s-vieja") {
+		t.Errorf("sesión de P1 en el respaldo = %+v", s)
+	}
+	if r := backup.relays[relayKey{bns.name, "M1"}]; r == nil || r.server != "gs1" || r.last.GetVictimId() != "B" || r.subs == nil {
+		t.Errorf("retransmisión de M1 en el respaldo = %+v", r)
+	}
+	backup.mu.RUnlock()
+
+	again := snapshotOf(backup)
+	again.PrimaryId = snap.GetPrimaryId()
+	sortSnapshot(snap)
+	sortSnapshot(again)
+	if !proto.Equal(snap, again) {
+		t.Errorf("el snapshot del respaldo difiere del original:\noriginal: %v\nrespaldo: %v", snap, again)
+	}
+}
+
+// replicaSnapshot copia con m.mu en lectura: convive con otro lector y con
+// RPC de jugador en curso (correr con -race).
+func TestReplicaSnapshotSharesLock(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	queueAll(t, cli, "P0")
+
+	mm.mu.RLock()
+	done := make(chan *pb.StateSnapshot, 1)
+	go func() { done <- mm.replicaSnapshot() }()
+	select {
+	case snap := <-done:
+		if len(snap.GetNamespaces()) != 1 {
+			t.Errorf("snapshot = %v", snap)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("replicaSnapshot esperó a un lector: tomó m.mu en escritura")
+	}
+	mm.mu.RUnlock()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := fmt.Sprintf("Q%d", i)
+			for j := 0; j < 50; j++ {
+				cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: id, GameMode: "1v1"})
+				cli.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: id})
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		snap := mm.replicaSnapshot()
+		ns := snap.GetNamespaces()[0]
+		queued := 0
+		for _, p := range ns.GetPlayers() {
+			if p.GetStatus() == playerInQueue.String() {
+				queued++
+			}
+		}
+		if queued != len(ns.GetQueue()) {
+			t.Fatalf("snapshot a medias: %d jugadores IN_QUEUE y %d en la cola", queued, len(ns.GetQueue()))
+		}
+	}
+	wg.Wait()
+}
+
+// awaitPromotion espera (en tiempo real) a que watchPrimary procese los
+// pulsos ya emitidos y dice si el respaldo se promovió.
+func awaitPromotion(mm *matchmaker, d time.Duration) bool {
+	deadline := time.Now().Add(d)
+	for time.Now().Before(deadline) {
+		if !mm.replica.passive.Load() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return !mm.replica.passive.Load()
+}
+
+func TestWatchPrimaryPromotesAfterFailoverTimeout(t *testing.T) {
+	wall := walltime.NewFake(time.Now())
+	mm, cli := startMatchmaker(t)
+	mm.wall = wall
+	mm.replica.passive.Store(true)
+	mm.updateHealth()
+	go mm.watchPrimary()
+	for wall.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	step := failoverTimeout / 5
+
+	// sin ningún snapshot recibido no hay primario del que sospechar
+	for i := 0; i < 10; i++ {
+		wall.Advance(step)
+	}
+	if awaitPromotion(mm, 50*time.Millisecond) {
+		t.Fatal("respaldo promovido sin haber recibido nunca un snapshot")
+	}
+
+	// snapshots regulares lo mantienen pasivo aunque pase más de failoverTimeout
+	for i := 0; i < 10; i++ {
+		mm.replica.lastSync.Store(wall.Now().UnixNano())
+		wall.Advance(step)
+	}
+	if awaitPromotion(mm, 50*time.Millisecond) {
+		t.Fatal("respaldo promovido con el primario enviando snapshots")
+	}
+	// pasivo, el respaldo rechaza las RPC de jugador (passiveUnaryInterceptor)
+	queue := &pb.PlayerInfoRequest{PlayerId: "P1", GameMode: "1v1"}
+	if _, err := cli.QueuePlayer(context.Background(), queue); status.Code(err) != codes.Unavailable {
+		t.Fatalf("QueuePlayer en el respaldo pasivo: %v, se esperaba Unavailable", err)
+	}
+
+	// el primario calla: tras failoverTimeout el respaldo asume
+	for i := 0; i < 6; i++ {
+		wall.Advance(step)
+	}
+	if !awaitPromotion(mm, 2*time.Second) {
+		t.Fatal("respaldo sin promover tras failoverTimeout sin snapshots")
+	}
+	// promote publica la salud justo después de dejar de ser pasivo
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		res, err := mm.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: pb.Matchmaker_ServiceDesc.ServiceName})
+		if err == nil && res.GetStatus() == healthpb.HealthCheckResponse_SERVING {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("salud tras la promoción = %v, %v; se esperaba SERVING", res, err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, err := cli.QueuePlayer(context.Background(), queue); err != nil {
+		t.Fatalf("QueuePlayer tras la promoción: %v", err)
+	}
+}
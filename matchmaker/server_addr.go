@@ -0,0 +1,197 @@
+// matchmaker/server_addr.go
+//
+// Validación de la dirección que anuncia un GameServer. El Matchmaker marca
+// esa dirección para enviar AssignMatch, así que debe ser alcanzable desde
+// aquí:
+//
+//   ▸ host vacío o no especificado (":60051", "0.0.0.0:60051", "[::]:60051")
+//     → se completa con la IP de la conexión gRPC entrante (peer).
+//   ▸ loopback anunciado por un peer remoto, multicast o puerto inválido
+//     → se rechaza (INVALID_ADDRESS).
+//   ▸ una dirección que ya atiende a otro namespace → se rechaza
+//     (WRONG_NAMESPACE): un GameServer pertenece a un solo pool, o recibiría
+//     partidas de dos namespaces aislados. El índice m.serverAddrs evita
+//     recorrer todos los servidores en cada actualización.
+//
+// También la dirección dedicada que un GameServer asigna a cada partida
+// (AssignMatchResponse.match_addr, MATCH_PORT_RANGE del servidor): sin host
+// se completa con el de la dirección del servidor, y es la que
+// GetPlayerStatus y ResumeSession dan a los jugadores para conectarse.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// resolveServerAddr devuelve la dirección a marcar para el GameServer o un
+// error explicando por qué la anunciada no sirve.
+func resolveServerAddr(ctx context.Context, advertised string) (string, error) {
+	host, portStr, err := net.SplitHostPort(advertised)
+	if err != nil {
+		return "", fmt.Errorf("dirección %q inválida: %v", advertised, err)
+	}
+	if port, err := strconv.Atoi(portStr); err != nil || port < 1 || port > 65535 {
+		return "", fmt.Errorf("puerto %q fuera de 1-65535", portStr)
+	}
+
+	peerIP, havePeer := peerAddr(ctx)
+
+	ip, perr := netip.ParseAddr(host)
+	literal := perr == nil
+	switch {
+	case host == "" || (literal && ip.IsUnspecified()):
+		if !havePeer {
+			return "", fmt.Errorf("dirección %q sin host y sin IP de origen para completarla", advertised)
+		}
+		return net.JoinHostPort(peerIP.String(), portStr), nil
+	case literal && ip.IsMulticast():
+		return "", fmt.Errorf("dirección %q es multicast", advertised)
+	case (host == "localhost" || (literal && ip.IsLoopback())) && havePeer && !peerIP.IsLoopback():
+		return "", fmt.Errorf("dirección %q es loopback pero el servidor conecta desde %s; define ADVERTISE_ADDR", advertised, peerIP)
+	}
+	return advertised, nil
+}
+
+// resolveMatchAddr completa con el host de serverAddr una dirección de
+// partida anunciada sin host. Vacía si el servidor no asignó ninguna.
+func resolveMatchAddr(serverAddr, advertised string) string {
+	if advertised == "" {
+		return ""
+	}
+	host, port, err := net.SplitHostPort(advertised)
+	if err != nil {
+		return ""
+	}
+	if ip, perr := netip.ParseAddr(host); host != "" && (perr != nil || !ip.IsUnspecified()) {
+		return advertised
+	}
+	srvHost, _, err := net.SplitHostPort(serverAddr)
+	if err != nil {
+		return ""
+	}
+	return net.JoinHostPort(srvHost, port)
+}
+
+// recordMatchAddr guarda con la partida la dirección dedicada que srv le
+// asignó al aceptarla.
+func (m *matchmaker) recordMatchAddr(ns *namespace, srv *gameServerInfo, matchID, advertised string) {
+	if advertised == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	am, ok := ns.matches.Get(matchID)
+	if !ok || am.ServerID != srv.ID {
+		return
+	}
+	am.MatchAddr = resolveMatchAddr(srv.Address, advertised)
+	ns.vc.Tick(m.selfID)
+	m.logf("[%s] Partida %s en %s (server %s)", ns.name, matchID, am.MatchAddr, srv.ID)
+}
+
+// matchAddrFor devuelve dónde se conectan los jugadores de matchID: su
+// dirección dedicada o, si el servidor no le asignó una, la del servidor.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) matchAddrFor(matchID string) string {
+	if am, ok := ns.matches.Get(matchID); ok && am.MatchAddr != "" {
+		return am.MatchAddr
+	}
+	return ns.serverAddrForMatch(matchID)
+}
+
+// peerAddr extrae la IP de la conexión entrante, si es TCP.
+func peerAddr(ctx context.Context) (netip.Addr, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return netip.Addr{}, false
+	}
+	ap, err := netip.ParseAddrPort(p.Addr.String())
+	if err != nil {
+		return netip.Addr{}, false // bufconn, unix socket, etc.
+	}
+	return ap.Addr().Unmap(), true
+}
+
+// serverRef identifica un servidor registrado.
+type serverRef struct{ ns, id string }
+
+// serverNamespace devuelve el namespace, distinto de except, en el que addr
+// está registrada y no caída; "" si no hay ninguno.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) serverNamespace(addr, except string) string {
+	if addr == "" {
+		return ""
+	}
+	// casi siempre un solo servidor por dirección; el estado se mira aquí
+	// porque cambia por muchos caminos y el índice sólo sigue registros
+	for _, ref := range m.serverAddrs[addr] {
+		if ref.ns == except {
+			continue
+		}
+		if ns := m.namespaces[ref.ns]; ns != nil {
+			if s, ok := ns.servers.Get(ref.id); ok && s.Status != serverDown {
+				return ref.ns
+			}
+		}
+	}
+	return ""
+}
+
+// indexServerAddr mueve el servidor id de ns en el índice de direcciones,
+// de old a addr; addr vacía lo quita.
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) indexServerAddr(ns, id, old, addr string) {
+	if old == addr {
+		return
+	}
+	ref := serverRef{ns, id}
+	if old != "" {
+		refs := m.serverAddrs[old]
+		for i, r := range refs {
+			if r == ref {
+				refs = append(refs[:i:i], refs[i+1:]...)
+				break
+			}
+		}
+		if len(refs) == 0 {
+			delete(m.serverAddrs, old)
+		} else {
+			m.serverAddrs[old] = refs
+		}
+	}
+	if addr != "" {
+		m.serverAddrs[addr] = append(m.serverAddrs[addr], ref)
+	}
+}
+
+// reindexServerAddrs reconstruye el índice de direcciones tras reemplazar
+// los namespaces (restore).
+// debe llamarse con m.mu bloqueado en escritura
+func (m *matchmaker) reindexServerAddrs() {
+	m.serverAddrs = make(map[string][]serverRef)
+	for _, ns := range m.namespaces {
+		for id, s := range ns.servers.All() {
+			m.indexServerAddr(ns.name, id, "", s.Address)
+		}
+	}
+}
+
+// rejectServerUpdate arma la respuesta a un UpdateServerStatus rechazado.
+// Lleva el reloj del namespace tal como está, o ninguno si aún no existe.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) rejectServerUpdate(name string, code pb.ServerStatusUpdateResponse_StatusCode, msg string) *pb.ServerStatusUpdateResponse {
+	res := &pb.ServerStatusUpdateResponse{StatusCode: code, Message: msg, Epoch: m.epoch}
+	if ns := m.lookupNS(name); ns != nil {
+		res.VectorClock = m.clockProto(ns)
+	}
+	return res
+}
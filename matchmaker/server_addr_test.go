@@ -0,0 +1,182 @@
+// matchmaker/server_addr_test.go
+//
+// Dirección dedicada por partida: la que el GameServer responde en
+// AssignMatch llega a los jugadores por GetPlayerStatus, completada con el
+// host del servidor si no trae uno; sin ella se usa la del servidor. Y la
+// dirección de un GameServer atiende a un solo namespace, aunque cambie de
+// dirección o se dé de baja; un registro rechazado no crea el namespace ni
+// avanza su reloj.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestResolveMatchAddr(t *testing.T) {
+	for _, c := range []struct{ server, advertised, want string }{
+		{"10.0.0.5:60051", "", ""},
+		{"10.0.0.5:60051", ":61000", "10.0.0.5:61000"},
+		{"10.0.0.5:60051", "0.0.0.0:61000", "10.0.0.5:61000"},
+		{"10.0.0.5:60051", "gameserver1:61000", "gameserver1:61000"},
+		{"[fd00::5]:60051", ":61001", "[fd00::5]:61001"},
+		{"10.0.0.5:60051", "61000", ""},
+	} {
+		if got := resolveMatchAddr(c.server, c.advertised); got != c.want {
+			t.Errorf("resolveMatchAddr(%q, %q) = %q, se esperaba %q", c.server, c.advertised, got, c.want)
+		}
+	}
+}
+
+func TestPlayerStatusCarriesMatchAddr(t *testing.T) {
+	for _, c := range []struct{ advertised, want string }{
+		{":61003", "gs1:61003"},
+		{"", "gs1:60051"}, // sin puerto dedicado: la del servidor
+	} {
+		r := newFailureRig(t, testkit.Delay)
+		r.gs.Delay = 10 * time.Millisecond // acepta sin informar resultado
+		r.gs.MatchAddr = c.advertised
+		r.queuePair("A", "B")
+		r.gs.WaitAssigned(t, 1, time.Second)
+		r.waitFor("partida aceptada", func(ns *namespace) bool {
+			for _, am := range ns.matches.All() {
+				for _, ev := range am.Timeline {
+					if ev.Stage == pb.MatchEvent_ACCEPTED {
+						return true
+					}
+				}
+			}
+			return false
+		})
+
+		res, err := r.cli.GetPlayerStatus(context.Background(), &pb.PlayerStatusRequest{PlayerId: "A"})
+		if err != nil {
+			t.Fatalf("GetPlayerStatus: %v", err)
+		}
+		if res.GetStatus() != "IN_MATCH" || res.GetMatchAddr() != c.want || res.GetServerAddr() != "gs1:60051" {
+			t.Fatalf("match_addr %q: %s en %q (servidor %q), se esperaba %q",
+				c.advertised, res.GetStatus(), res.GetMatchAddr(), res.GetServerAddr(), c.want)
+		}
+	}
+}
+
+func TestServerAddrBelongsToOneNamespace(t *testing.T) {
+	r := newFailureRig(t)
+	register := func(ns, id, addr string, st pb.ServerStatus) pb.ServerStatusUpdateResponse_StatusCode {
+		t.Helper()
+		res, err := r.cli.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+			Namespace: ns, ServerId: id, NewStatus: st, Address: addr,
+		})
+		if err != nil {
+			t.Fatalf("UpdateServerStatus: %v", err)
+		}
+		return res.GetStatusCode()
+	}
+
+	if got := register("lab2", "gs1", r.gs.Addr, pb.ServerStatus_DISPONIBLE); got != pb.ServerStatusUpdateResponse_WRONG_NAMESPACE {
+		t.Fatalf("la dirección de un servidor de default se registró en lab2: %v", got)
+	}
+	if got := register("lab2", "gs9", "gs9:60059", pb.ServerStatus_DISPONIBLE); got != pb.ServerStatusUpdateResponse_OK {
+		t.Fatalf("otra dirección en lab2: %v", got)
+	}
+	// caído en default, el servidor puede pasar a otro namespace
+	register(defaultNamespace, r.gs.ID, r.gs.Addr, pb.ServerStatus_CAIDO)
+	if got := register("lab2", "gs1", r.gs.Addr, pb.ServerStatus_DISPONIBLE); got != pb.ServerStatusUpdateResponse_OK {
+		t.Fatalf("tras caer en default: %v", got)
+	}
+}
+
+// El índice de direcciones sigue los cambios de dirección, las bajas y
+// restore: una dirección liberada vuelve a estar disponible para otro
+// namespace.
+func TestServerAddrIndexFollowsRegistrations(t *testing.T) {
+	r := newFailureRig(t)
+	register := func(ns, addr string) pb.ServerStatusUpdateResponse_StatusCode {
+		t.Helper()
+		res, err := r.cli.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+			Namespace: ns, ServerId: "gs7", NewStatus: pb.ServerStatus_DISPONIBLE, Address: addr,
+		})
+		if err != nil {
+			t.Fatalf("UpdateServerStatus: %v", err)
+		}
+		return res.GetStatusCode()
+	}
+
+	register("lab2", "gs7:60057")
+	register("lab2", "gs7:60058") // cambia de dirección
+	if got := register("lab3", "gs7:60057"); got != pb.ServerStatusUpdateResponse_OK {
+		t.Fatalf("dirección abandonada en lab3: %v", got)
+	}
+	if got := register("lab4", "gs7:60058"); got != pb.ServerStatusUpdateResponse_WRONG_NAMESPACE {
+		t.Fatalf("dirección nueva de lab2 en lab4: %v", got)
+	}
+
+	// restore reconstruye el índice
+	r.mm.mu.Lock()
+	r.mm.restore(r.mm.snapshot())
+	r.mm.mu.Unlock()
+	if got := register("lab4", "gs7:60058"); got != pb.ServerStatusUpdateResponse_WRONG_NAMESPACE {
+		t.Fatalf("tras restore, dirección de lab2 en lab4: %v", got)
+	}
+
+	if _, err := r.cli.DeregisterServer(context.Background(), &pb.DeregisterServerRequest{Namespace: "lab2", ServerId: "gs7"}); err != nil {
+		t.Fatalf("DeregisterServer: %v", err)
+	}
+	if got := register("lab4", "gs7:60058"); got != pb.ServerStatusUpdateResponse_OK {
+		t.Fatalf("tras la baja en lab2: %v", got)
+	}
+	r.mm.mu.RLock()
+	defer r.mm.mu.RUnlock()
+	if refs := r.mm.serverAddrs["gs7:60058"]; len(refs) != 1 || refs[0] != (serverRef{"lab4", "gs7"}) {
+		t.Fatalf("índice de gs7:60058 = %v", refs)
+	}
+}
+
+func TestRejectedServerUpdateLeavesNamespace(t *testing.T) {
+	r := newFailureRig(t)
+	update := func(ns, addr string) pb.ServerStatusUpdateResponse_StatusCode {
+		t.Helper()
+		res, err := r.cli.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+			Namespace: ns, ServerId: "gs7", NewStatus: pb.ServerStatus_DISPONIBLE, Address: addr,
+			Clock: &pb.VectorClock{Counters: map[string]int32{"gs7": 9}},
+		})
+		if err != nil {
+			t.Fatalf("UpdateServerStatus: %v", err)
+		}
+		return res.GetStatusCode()
+	}
+	exists := func(ns string) bool {
+		r.mm.mu.RLock()
+		defer r.mm.mu.RUnlock()
+		_, ok := r.mm.namespaces[ns]
+		return ok
+	}
+
+	if got := update("lab2", r.gs.Addr); got != pb.ServerStatusUpdateResponse_WRONG_NAMESPACE {
+		t.Fatalf("dirección de default en lab2: %v", got)
+	}
+	if got := update("lab3", "sin-puerto"); got != pb.ServerStatusUpdateResponse_INVALID_ADDRESS {
+		t.Fatalf("dirección inválida en lab3: %v", got)
+	}
+	if exists("lab2") || exists("lab3") {
+		t.Fatal("un registro rechazado creó su namespace")
+	}
+
+	// en un namespace que ya existe, el rechazo no toca su reloj
+	var before *clocks.Vector
+	r.inspect(func(ns *namespace) { before = ns.vc.Copy() })
+	if got := update(defaultNamespace, "127.0.0.1:0"); got != pb.ServerStatusUpdateResponse_INVALID_ADDRESS {
+		t.Fatalf("dirección inválida en default: %v", got)
+	}
+	r.inspect(func(ns *namespace) {
+		if !ns.vc.Equal(before) {
+			t.Fatalf("el rechazo avanzó el reloj: %v → %v", before, ns.vc)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// matchmaker/server_causal.go
+//
+// Orden causal de las actualizaciones de estado de los GameServers. Cada
+// GameServer avanza su componente del reloj en cada cambio de estado, así
+// que dos actualizaciones que llegan desordenadas (un reintento atrasado, un
+// UpdateServerStatus que se cruza con el stream Heartbeat, dos avisos de
+// partidas distintas en paralelo) se reconocen por el reloj. ns.serverUpdates
+// (clocks.Causal) retiene la que se adelantó hasta que llega la anterior, y
+// la que llega después de una más nueva se descarta en vez de pisarla. Una
+// retenida más de serverUpdateMaxDelay se aplica igual: la anterior se dio
+// por perdida.
+//
+// Sólo cuenta el componente del propio servidor: los GameServers no se
+// comunican entre sí, y el resto de su reloj es lo que ya les respondió este
+// Matchmaker. Una actualización retenida o vieja renueva el plazo de
+// heartbeat (el servidor está vivo) pero no toca su estado; una que repite la
+// última aplicada (un latido) se aplica como siempre. Las que llegan sin
+// reloj, de servidores anteriores a este cambio, no se ordenan.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// cuánto se retiene una actualización que espera a su predecesora
+const serverUpdateMaxDelay = 2 * time.Second
+
+// serverUpdate es una actualización recibida, con la dirección ya resuelta.
+type serverUpdate struct {
+	req     *pb.ServerStatusUpdateRequest
+	addr    string
+	addrErr error
+}
+
+// orderServerUpdate pasa u por el buffer causal del namespace. Devuelve las
+// actualizaciones a aplicar, en orden (u primero si le tocaba), o, si u no
+// se aplica ahora, la respuesta para su emisor.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) orderServerUpdate(ns *namespace, u *serverUpdate) ([]*serverUpdate, *pb.ServerStatusUpdateResponse) {
+	sid := u.req.GetServerId()
+	own, ok := u.req.GetClock().GetCounters()[sid]
+	if !ok {
+		return []*serverUpdate{u}, nil
+	}
+	ev := clocks.Event{
+		Sender:  sid,
+		Clock:   clocks.FromProto(&pb.VectorClock{Counters: map[string]int32{sid: own}}),
+		Payload: u,
+		At:      m.wall.Now(),
+	}
+	outcome, ready := ns.serverUpdates.Receive(ev)
+	switch outcome {
+	case clocks.Delivered:
+		return serverUpdates(ready), nil
+	case clocks.Duplicate:
+		return []*serverUpdate{u}, nil
+	}
+
+	m.metrics.serverReorder.With(ns.name, outcome.String()).Inc()
+	detail := "retenida hasta recibir la anterior"
+	if outcome == clocks.Stale {
+		detail = "descartada: ya se aplicó una más nueva"
+	}
+	m.logf("[%s] Actualización %d de %s (%s) fuera de orden: %s", ns.name, own, sid, u.req.GetNewStatus(), detail)
+	if srv, ok := ns.servers.Get(sid); ok {
+		srv.LastHB = m.wall.Now()
+	}
+	return nil, &pb.ServerStatusUpdateResponse{
+		StatusCode:  pb.ServerStatusUpdateResponse_OK,
+		Message:     fmt.Sprintf("Actualización %s", detail),
+		VectorClock: m.clockProto(ns),
+	}
+}
+
+// flushServerUpdates aplica las actualizaciones retenidas más de
+// serverUpdateMaxDelay y las que eso destrabe.
+func (m *matchmaker) flushServerUpdates(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	defer m.audit("flushServerUpdates")
+
+	for _, ns := range m.namespaces {
+		ready, forced := ns.serverUpdates.Expire(now)
+		if forced == 0 {
+			continue
+		}
+		m.metrics.serverReorder.With(ns.name, "forced").Add(float64(forced))
+		m.logf("[%s] %d actualizaciones de servidor aplicadas sin su predecesora (espera de %v vencida)", ns.name, forced, serverUpdateMaxDelay)
+		for _, u := range serverUpdates(ready) {
+			m.applyServerStatus(ns, u)
+		}
+	}
+}
+
+func serverUpdates(evs []clocks.Event) []*serverUpdate {
+	out := make([]*serverUpdate, len(evs))
+	for i, ev := range evs {
+		out[i] = ev.Payload.(*serverUpdate)
+	}
+	return out
+}
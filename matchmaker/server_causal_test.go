@@ -0,0 +1,91 @@
+// matchmaker/server_causal_test.go
+//
+// Orden causal de UpdateServerStatus: una actualización adelantada espera a
+// la anterior, una vieja no pisa a una más nueva, una retenida se aplica al
+// vencer su espera y un servidor reiniciado vuelve a empezar en 1.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+// sendStatus envía el estado st de gs1 con su componente del reloj en n.
+func sendStatus(t *testing.T, cli pb.MatchmakerClient, n int32, st pb.ServerStatus) {
+	t.Helper()
+	_, err := cli.UpdateServerStatus(context.Background(), &pb.ServerStatusUpdateRequest{
+		ServerId: "gs1", NewStatus: st, Address: "127.0.0.1:1",
+		Clock: &pb.VectorClock{Counters: map[string]int32{"gs1": n}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateServerStatus(%d, %s): %v", n, st, err)
+	}
+}
+
+func gs1Status(mm *matchmaker) serverState {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	return mm.ns("").servers.Value("gs1").Status
+}
+
+func TestServerUpdatesApplyInCausalOrder(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	sendStatus(t, cli, 1, pb.ServerStatus_DISPONIBLE)
+
+	// 3 llega antes que 2: espera
+	sendStatus(t, cli, 3, pb.ServerStatus_DISPONIBLE)
+	if st := gs1Status(mm); st != serverAvailable {
+		t.Fatalf("estado tras la 3 adelantada = %v, se esperaba DISPONIBLE sin cambios", st)
+	}
+	sendStatus(t, cli, 2, pb.ServerStatus_OCUPADO)
+	if st := gs1Status(mm); st != serverAvailable {
+		t.Errorf("estado tras la 2 y la 3 = %v, se esperaba el de la 3 (DISPONIBLE)", st)
+	}
+
+	// la 2 repetida llega tarde: no pisa a la 3
+	sendStatus(t, cli, 2, pb.ServerStatus_OCUPADO)
+	if st := gs1Status(mm); st != serverAvailable {
+		t.Errorf("una actualización vieja pisó el estado: %v", st)
+	}
+	// la última aplicada, repetida como latido, se aplica igual
+	sendStatus(t, cli, 3, pb.ServerStatus_DISPONIBLE)
+	if st := gs1Status(mm); st != serverAvailable {
+		t.Errorf("estado tras repetir la 3 = %v", st)
+	}
+}
+
+func TestServerUpdateAppliedWhenPredecessorIsLost(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	sendStatus(t, cli, 1, pb.ServerStatus_DISPONIBLE)
+	sendStatus(t, cli, 3, pb.ServerStatus_OCUPADO) // la 2 nunca llega
+
+	mm.flushServerUpdates(time.Now())
+	if st := gs1Status(mm); st != serverAvailable {
+		t.Fatalf("la 3 se aplicó antes de vencer su espera: %v", st)
+	}
+	mm.flushServerUpdates(time.Now().Add(serverUpdateMaxDelay))
+	if st := gs1Status(mm); st != serverBusy {
+		t.Errorf("estado tras vencer la espera = %v, se esperaba OCUPADO", st)
+	}
+}
+
+func TestRestartedServerStartsOver(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	sendStatus(t, cli, 1, pb.ServerStatus_DISPONIBLE)
+	sendStatus(t, cli, 2, pb.ServerStatus_OCUPADO)
+	sendStatus(t, cli, 3, pb.ServerStatus_OCUPADO)
+
+	// el proceso se reinició: su reloj vuelve a 1
+	sendStatus(t, cli, 1, pb.ServerStatus_DISPONIBLE)
+	if st := gs1Status(mm); st != serverAvailable {
+		t.Errorf("estado tras el reinicio = %v, se esperaba DISPONIBLE", st)
+	}
+	sendStatus(t, cli, 2, pb.ServerStatus_OCUPADO)
+	if st := gs1Status(mm); st != serverBusy {
+		t.Errorf("estado tras la 2 de la nueva encarnación = %v, se esperaba OCUPADO", st)
+	}
+}
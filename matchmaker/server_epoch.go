@@ -0,0 +1,43 @@
+// matchmaker/server_epoch.go
+//
+// Re-sincronización de GameServers tras un reinicio del Matchmaker. Un
+// Matchmaker que arranca sin estado (o un respaldo promovido con un snapshot
+// atrasado) no sabe qué servidores tenía ni qué partidas corrían en ellos,
+// mientras los GameServers creen seguir registrados. Cada ejecución elige al
+// arrancar una época aleatoria que viaja en cada respuesta a
+// UpdateServerStatus y Heartbeat; el servidor recuerda la última y la
+// devuelve en sus actualizaciones. Cuando ve una época nueva se re-registra
+// en el acto con su estado completo —huecos, partidas en curso (que se
+// adoptan, ver recovered_matches.go) y resultados pendientes— sin depender
+// de haber notado la conexión caída.
+//
+// Una actualización con la época de otra ejecución es la de un servidor que
+// vuelve a sincronizarse: se aplica como siempre y se cuenta en
+// matchmaker_server_resyncs_total.
+
+package main
+
+import "math/rand"
+
+// newEpoch elige la época de esta ejecución; nunca 0, que es "sin época".
+func newEpoch() uint64 {
+	for {
+		if e := rand.Uint64(); e != 0 {
+			return e
+		}
+	}
+}
+
+// noteServerEpoch registra a un servidor que trae la época de otra
+// ejecución del Matchmaker. Hasta ver la nueva puede mandar varias
+// actualizaciones con la vieja; se cuenta una vez.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) noteServerEpoch(ns *namespace, srv *gameServerInfo, epoch uint64) {
+	if epoch == 0 || epoch == m.epoch || epoch == srv.Resynced {
+		return
+	}
+	srv.Resynced = epoch
+	m.metrics.serverResyncs.With(ns.name).Inc()
+	m.logf("[%s] Servidor %s vuelve de una ejecución anterior del Matchmaker (época %016x): re-sincroniza su estado",
+		ns.name, srv.ID, epoch)
+}
@@ -0,0 +1,51 @@
+// matchmaker/server_epoch_test.go
+//
+// Épocas: cada ejecución del Matchmaker tiene la suya y la informa en cada
+// respuesta a los GameServers; un servidor que trae la de otra ejecución se
+// re-sincroniza y sus partidas en curso se adoptan.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestServerEpochResync(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx := context.Background()
+	if mm.epoch == 0 || newMatchmaker("Matchmaker").epoch == mm.epoch {
+		t.Fatalf("época %x: debe ser distinta de 0 y de la de otra ejecución", mm.epoch)
+	}
+
+	// el servidor viene de una ejecución anterior con una partida en curso
+	res, err := cli.UpdateServerStatus(ctx, &pb.ServerStatusUpdateRequest{
+		ServerId: "gs1", NewStatus: pb.ServerStatus_OCUPADO, Address: "127.0.0.1:60051",
+		Capacity: 1, Epoch: mm.epoch + 1,
+		RunningMatches: []*pb.RunningMatch{{MatchId: "M1", PlayerIds: []string{"A", "B"}, GameMode: defaultGameMode}},
+	})
+	if err != nil || res.GetEpoch() != mm.epoch {
+		t.Fatalf("UpdateServerStatus: época %x (%v), se esperaba %x", res.GetEpoch(), err, mm.epoch)
+	}
+	mm.mu.RLock()
+	_, adopted := mm.lookupNS(defaultNamespace).matches.Get("M1")
+	mm.mu.RUnlock()
+	if !adopted {
+		t.Fatal("la partida en curso del servidor no se adoptó")
+	}
+
+	// el ACK del stream Heartbeat también la informa
+	stream, err := cli.Heartbeat(ctx)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	defer stream.CloseSend()
+	if err := stream.Send(&pb.ServerStatusUpdateRequest{ServerId: "gs2", NewStatus: pb.ServerStatus_DISPONIBLE, Address: "127.0.0.1:60052"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if ack, err := stream.Recv(); err != nil || ack.GetAck().GetEpoch() != mm.epoch {
+		t.Fatalf("ACK: %v (%v), se esperaba la época %x", ack, err, mm.epoch)
+	}
+}
@@ -0,0 +1,151 @@
+// matchmaker/session_guarantees.go
+//
+// Niveles de consistencia de GetPlayerStatus, que el cliente elige en cada
+// lectura (consistency) y la respuesta confirma.
+//
+// ▸ READ_YOUR_WRITES (por defecto). Cada respuesta lleva el reloj del
+//   namespace, y el componente propio del Matchmaker en el reloj del cliente
+//   dice hasta qué escritura confirmada llegó a ver. Si el pedido trae ese
+//   componente por delante del estado local (un respaldo promovido al que no
+//   le llegó el último snapshot, o un Matchmaker reiniciado sin su estado),
+//   responder sería servir un estado que no refleja las escrituras del
+//   cliente: se espera hasta RYW_TIMEOUT a que el reloj local lo alcance y,
+//   si no, se responde RETRY_LATER.
+// ▸ EVENTUAL. Responde enseguida con el estado local, aunque esté atrasado.
+// ▸ STRONG. Como READ_YOUR_WRITES y, si la partida del jugador tiene un
+//   AssignMatch en curso, espera hasta STRONG_READ_TIMEOUT a que termine:
+//   la respuesta ya dice si el servidor la aceptó o si el jugador volvió a
+//   la cola. Si no termina a tiempo responde igual, con consistency =
+//   READ_YOUR_WRITES.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultRYWTimeout = 500 * time.Millisecond
+	rywPollInterval   = 10 * time.Millisecond
+
+	statusRetryLater = "RETRY_LATER"
+
+	defaultStrongTimeout = 5 * time.Second
+)
+
+// consistencyLevel normaliza el nivel pedido.
+func consistencyLevel(c pb.Consistency) pb.Consistency {
+	if c == pb.Consistency_CONSISTENCY_DEFAULT {
+		return pb.Consistency_CONSISTENCY_READ_YOUR_WRITES
+	}
+	return c
+}
+
+// consistencyLabel es la etiqueta de matchmaker_status_reads_total.
+func consistencyLabel(c pb.Consistency) string {
+	return strings.ToLower(strings.TrimPrefix(c.String(), "CONSISTENCY_"))
+}
+
+// inflightKey identifica una partida con AssignMatch en curso.
+func inflightKey(ns, matchID string) string { return ns + "/" + matchID }
+
+// awaitAssign espera a que la partida de playerID no tenga un AssignMatch
+// en curso, soltando m.mu entre sondeos, como awaitClock. Devuelve el
+// namespace vigente y si la asignación terminó a tiempo.
+// debe llamarse con m.mu bloqueado; vuelve con m.mu bloqueado
+func (m *matchmaker) awaitAssign(ctx context.Context, nsName, playerID string) (*namespace, bool) {
+	pending := func() (*namespace, bool) {
+		ns := m.ns(nsName)
+		pi, ok := ns.players.Get(playerID)
+		return ns, ok && pi.MatchID != "" && m.inflight[inflightKey(ns.name, pi.MatchID)] > 0
+	}
+	ns, busy := pending()
+	if !busy {
+		return ns, true
+	}
+
+	deadline := m.wall.Now().Add(m.strongTimeout)
+	for {
+		if ctx.Err() != nil || !m.wall.Now().Before(deadline) {
+			return ns, false
+		}
+		m.mu.Unlock()
+		select {
+		case <-m.wall.After(rywPollInterval):
+		case <-ctx.Done():
+		}
+		m.mu.Lock()
+
+		if ns, busy = pending(); !busy {
+			return ns, true
+		}
+	}
+}
+
+// statusSettled indica si GetPlayerStatus puede responder ya en el nivel
+// level: el reloj local alcanzó lo que el cliente vio y, con STRONG, la
+// partida del jugador no tiene un AssignMatch en curso. Si no, responde
+// por el camino que espera (awaitClock, awaitAssign).
+// debe llamarse con m.mu bloqueado en escritura, o en lectura con el
+// jugador bloqueado (locking.go)
+func (m *matchmaker) statusSettled(ns *namespace, playerID string, level pb.Consistency, c *pb.VectorClock) bool {
+	if level == pb.Consistency_CONSISTENCY_EVENTUAL {
+		return true
+	}
+	if want := clocks.FromProto(c).Get(m.selfID); m.rywTimeout > 0 && ns.vc.Get(m.selfID) < want {
+		return false
+	}
+	if level == pb.Consistency_CONSISTENCY_STRONG {
+		pi, ok := ns.players.Get(playerID)
+		return !ok || pi.MatchID == "" || m.inflight[inflightKey(ns.name, pi.MatchID)] == 0
+	}
+	return true
+}
+
+// awaitClock espera a que el componente propio del reloj del namespace
+// llegue a want, soltando m.mu entre sondeos. Devuelve el namespace vigente
+// (un snapshot restaurado puede reemplazarlo) y si lo alcanzó a tiempo.
+// debe llamarse con m.mu bloqueado; vuelve con m.mu bloqueado
+func (m *matchmaker) awaitClock(ctx context.Context, nsName string, want int64) (*namespace, bool) {
+	ns := m.ns(nsName)
+	if m.rywTimeout <= 0 || ns.vc.Get(m.selfID) >= want {
+		return ns, true
+	}
+
+	deadline := m.wall.Now().Add(m.rywTimeout)
+	for {
+		m.mu.Unlock()
+		select {
+		case <-m.wall.After(rywPollInterval):
+		case <-ctx.Done():
+		}
+		m.mu.Lock()
+
+		ns = m.ns(nsName)
+		if ns.vc.Get(m.selfID) >= want {
+			return ns, true
+		}
+		if ctx.Err() != nil || m.wall.Now().After(deadline) {
+			return ns, false
+		}
+	}
+}
+
+// retryLater es la respuesta a un GetPlayerStatus que no puede garantizar
+// Read-Your-Writes.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) retryLater(ns *namespace, playerID string, want int64) *pb.PlayerStatusResponse {
+	m.metrics.rywRetries.With(ns.name).Inc()
+	m.logf("[%s] GetPlayerStatus de %s: el cliente vio %s=%d y el estado local va en %d; RETRY_LATER",
+		ns.name, playerID, m.selfID, want, ns.vc.Get(m.selfID))
+	return &pb.PlayerStatusResponse{
+		Status:       statusRetryLater,
+		VectorClock:  m.clockProto(ns),
+		RetryAfterMs: m.rywTimeout.Milliseconds(),
+	}
+}
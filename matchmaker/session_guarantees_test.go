@@ -0,0 +1,60 @@
+// matchmaker/session_guarantees_test.go
+//
+// Niveles de consistencia de GetPlayerStatus: EVENTUAL responde enseguida
+// con la partida recién formada; STRONG espera a que su AssignMatch termine
+// y ve al jugador de vuelta en la cola; si la asignación no termina dentro
+// de STRONG_READ_TIMEOUT, responde con READ_YOUR_WRITES.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func statusAt(t *testing.T, cli pb.MatchmakerClient, playerID string, level pb.Consistency) *pb.PlayerStatusResponse {
+	t.Helper()
+	res, err := cli.GetPlayerStatus(context.Background(), &pb.PlayerStatusRequest{PlayerId: playerID, Consistency: level})
+	if err != nil {
+		t.Fatalf("GetPlayerStatus(%s, %v): %v", playerID, level, err)
+	}
+	return res
+}
+
+func TestStrongReadWaitsForAssignment(t *testing.T) {
+	r := newFailureRig(t, testkit.Delay)
+	r.gs.Delay = time.Second // más que assignTimeout: la asignación vence
+
+	r.queuePair("A", "B")
+	if res := statusAt(t, r.cli, "A", pb.Consistency_CONSISTENCY_EVENTUAL); res.GetStatus() != "IN_MATCH" ||
+		res.GetConsistency() != pb.Consistency_CONSISTENCY_EVENTUAL {
+		t.Fatalf("EVENTUAL = %s (%v), se esperaba IN_MATCH", res.GetStatus(), res.GetConsistency())
+	}
+	res := statusAt(t, r.cli, "A", pb.Consistency_CONSISTENCY_STRONG)
+	if res.GetStatus() != "IN_QUEUE" || res.GetConsistency() != pb.Consistency_CONSISTENCY_STRONG {
+		t.Fatalf("STRONG = %s (%v), se esperaba IN_QUEUE tras la asignación fallida", res.GetStatus(), res.GetConsistency())
+	}
+	if res := statusAt(t, r.cli, "A", pb.Consistency_CONSISTENCY_DEFAULT); res.GetConsistency() != pb.Consistency_CONSISTENCY_READ_YOUR_WRITES {
+		t.Fatalf("sin nivel se cumplió %v, se esperaba READ_YOUR_WRITES", res.GetConsistency())
+	}
+}
+
+func TestStrongReadDegradesOnTimeout(t *testing.T) {
+	r := newFailureRig(t, testkit.Delay)
+	r.gs.Delay = time.Second
+	r.mm.mu.Lock()
+	r.mm.assignTimeout = 2 * time.Second
+	r.mm.strongTimeout = 20 * time.Millisecond
+	r.mm.mu.Unlock()
+
+	r.queuePair("A", "B")
+	res := statusAt(t, r.cli, "A", pb.Consistency_CONSISTENCY_STRONG)
+	if res.GetStatus() != "IN_MATCH" || res.GetConsistency() != pb.Consistency_CONSISTENCY_READ_YOUR_WRITES {
+		t.Fatalf("STRONG con la asignación en curso = %s (%v), se esperaba IN_MATCH con READ_YOUR_WRITES",
+			res.GetStatus(), res.GetConsistency())
+	}
+}
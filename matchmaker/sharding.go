@@ -0,0 +1,82 @@
+// matchmaker/sharding.go
+//
+// Reparto de modos entre Matchmakers. Con MATCHMAKER_MODES="2v2,5v5" este
+// Matchmaker sólo atiende esos modos: ListGameModes y AdminGetSystemStatus
+// muestran sólo ésos y QueuePlayer rechaza los demás con WRONG_SHARD,
+// indicando en shard_addr el Matchmaker que los atiende según SHARD_ROUTES
+// (internal/shard), la tabla de rutas que comparten jugadores, GameServers y
+// el cliente administrador. Sin MATCHMAKER_MODES atiende todos, como
+// siempre. Conviene darle a cada shard su propio MATCHMAKER_ID para que los
+// IDs de partida y los componentes del reloj no se confundan entre shards;
+// un primario y su respaldo comparten el mismo.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vimsent/L3/internal/config"
+	"github.com/vimsent/L3/internal/shard"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// sharding es la parte de la tabla de rutas que atañe a este Matchmaker.
+type sharding struct {
+	modes  map[string]bool // modos propios; nil = todos
+	routes *shard.Routes   // para indicar el dueño de los ajenos; nil = sin tabla
+}
+
+// readSharding lee MATCHMAKER_MODES y SHARD_ROUTES.
+func readSharding(cfg *config.Report) sharding {
+	var s sharding
+	cfg.Spec("MATCHMAKER_MODES", "", func(raw string) error {
+		s.modes = nil
+		for _, mode := range strings.Split(raw, ",") {
+			if mode = strings.TrimSpace(mode); mode == "" {
+				continue
+			}
+			if s.modes == nil {
+				s.modes = map[string]bool{}
+			}
+			s.modes[mode] = true
+		}
+		return nil
+	})
+	s.routes = shard.FromConfig(cfg)
+	return s
+}
+
+// ownsMode indica si este Matchmaker atiende mode.
+func (s sharding) ownsMode(mode string) bool {
+	return s.modes == nil || s.modes[mode]
+}
+
+// ownedModes lista, ordenados, los modos propios; nil = todos.
+func (s sharding) ownedModes() []string {
+	if s.modes == nil {
+		return nil
+	}
+	out := make([]string, 0, len(s.modes))
+	for mode := range s.modes {
+		out = append(out, mode)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// wrongShard es la respuesta de QueuePlayer para un modo ajeno.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) wrongShard(ns *namespace, mode string) *pb.QueuePlayerResponse {
+	res := &pb.QueuePlayerResponse{
+		StatusCode:  pb.QueuePlayerResponse_WRONG_SHARD,
+		Message:     fmt.Sprintf("El modo %q lo atiende otro Matchmaker", mode),
+		ShardAddr:   m.shards.routes.Addr(mode, ""),
+		VectorClock: m.clockProto(ns),
+	}
+	if res.ShardAddr != "" {
+		res.Message += " (" + res.ShardAddr + ")"
+	}
+	return res
+}
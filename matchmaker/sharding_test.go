@@ -0,0 +1,56 @@
+// matchmaker/sharding_test.go
+//
+// MATCHMAKER_MODES: un Matchmaker que atiende sólo algunos modos rechaza
+// los demás con WRONG_SHARD, indicando el dueño según SHARD_ROUTES, y sólo
+// lista los propios.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vimsent/L3/internal/shard"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestShardRejectsForeignModes(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx := context.Background()
+	routes, err := shard.Parse("1v1=mm-a:50051; 2v2=mm-b:50051")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	mm.mu.Lock()
+	mm.shards = sharding{modes: map[string]bool{"2v2": true}, routes: routes}
+	mm.ns("").modes["2v2"] = &gameMode{Name: "2v2", TeamSize: 2, MinDuration: 10, MaxDuration: 20, Enabled: true}
+	mm.mu.Unlock()
+
+	res, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "A", GameMode: "1v1"})
+	if err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	if res.GetStatusCode() != pb.QueuePlayerResponse_WRONG_SHARD || res.GetShardAddr() != "mm-a:50051" {
+		t.Errorf("modo ajeno: %v %q, se esperaba WRONG_SHARD hacia mm-a:50051", res.GetStatusCode(), res.GetShardAddr())
+	}
+	if got := playerStatus(t, cli, "A"); got == "IN_QUEUE" {
+		t.Errorf("A quedó en cola con un modo ajeno")
+	}
+
+	res, err = cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "A", GameMode: "2v2"})
+	if err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+		t.Fatalf("modo propio: %v %v", res.GetStatusCode(), err)
+	}
+
+	modes, err := cli.ListGameModes(ctx, &pb.ListGameModesRequest{})
+	if err != nil {
+		t.Fatalf("ListGameModes: %v", err)
+	}
+	if len(modes.GetModes()) != 1 || modes.GetModes()[0].GetName() != "2v2" {
+		t.Errorf("ListGameModes = %v, se esperaba sólo 2v2", modes.GetModes())
+	}
+	st := adminStatus(t, cli)
+	if len(st.GetOwnedModes()) != 1 || len(st.GetModes()) != 1 || st.GetModes()[0].GetGameMode() != "2v2" {
+		t.Errorf("AdminGetSystemStatus: propios %v, modos %v", st.GetOwnedModes(), st.GetModes())
+	}
+}
@@ -0,0 +1,300 @@
+// matchmaker/shutdown.go
+//
+// Apagado ordenado con traspaso de la cola. Al recibir SIGINT el Matchmaker
+// ya no corta en seco:
+//
+//  1. deja de aceptar QueuePlayer (responde UNAVAILABLE, así los clientes
+//     con varias direcciones pasan al siguiente), de formar partidas y de
+//     replicar, y el servicio de salud pasa a NOT_SERVING;
+//  2. espera hasta DRAIN_TIMEOUT a que terminen los AssignMatch en curso,
+//     para que el estado traspasado no tenga entregas a medias;
+//  3. envía su último snapshot con TransferState a HANDOFF_ADDR (por defecto
+//     BACKUP_ADDR). Un respaldo pasivo lo adopta completo y asume como
+//     primario en el acto, sin esperar failoverTimeout; un Matchmaker activo
+//     suma a su cola los jugadores en cola que no tenga ocupados;
+//  4. si no hay a quién traspasar o el traspaso falla, guarda el snapshot en
+//     HANDOFF_FILE; un primario que arranca con ese archivo lo restaura y
+//     lo borra;
+//  5. sólo entonces cierra el servidor gRPC.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/config"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultDrainTimeout = 10 * time.Second
+	handoffTimeout      = 10 * time.Second // plazo de TransferState
+	drainPoll           = 50 * time.Millisecond
+)
+
+// errDraining es la respuesta a QueuePlayer durante el apagado.
+var errDraining = status.Error(codes.Unavailable, "Matchmaker apagándose: encólate en otro")
+
+// shutdownSettings son los parámetros del apagado.
+type shutdownSettings struct {
+	handoffAddr  string        // a quién traspasar; "" = nadie
+	handoffFile  string        // dónde guardar el estado si no hay traspaso
+	drainTimeout time.Duration // espera máxima por los AssignMatch en curso
+}
+
+func readShutdown(cfg *config.Report, backupAddr string) shutdownSettings {
+	s := shutdownSettings{
+		handoffAddr:  cfg.OptionalHostPort("HANDOFF_ADDR"),
+		handoffFile:  cfg.String("HANDOFF_FILE", ""),
+		drainTimeout: cfg.Duration("DRAIN_TIMEOUT", defaultDrainTimeout, 0, 5*time.Minute),
+	}
+	if s.handoffAddr == "" {
+		s.handoffAddr = backupAddr
+	}
+	return s
+}
+
+// isDraining indica si empezó el apagado.
+func (m *matchmaker) isDraining() bool {
+	select {
+	case <-m.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdown ejecuta los pasos 1 a 4; al volver, el llamador cierra m.done y
+// el servidor gRPC.
+func (m *matchmaker) shutdown(s shutdownSettings) {
+	// bajo el lock: ningún QueuePlayer ni tryCreateMatch queda a medias
+	m.mu.Lock()
+	close(m.draining)
+	m.mu.Unlock()
+	m.health.SetServingStatus(pb.Matchmaker_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	if !m.waitAssigns(s.drainTimeout) {
+		m.logf("WARN Apagado: siguen AssignMatch en curso tras %v; se traspasan como están", s.drainTimeout)
+	}
+
+	if m.replica.passive.Load() {
+		return // un respaldo pasivo no tiene estado propio que traspasar
+	}
+	m.mu.Lock()
+	snap := m.snapshot()
+	queued := 0
+	for _, ns := range m.namespaces {
+		queued += ns.queue.Len()
+	}
+	m.mu.Unlock()
+	snap.Sequence = m.replica.sequence.Add(1)
+
+	if s.handoffAddr != "" {
+		res, err := m.transferState(s.handoffAddr, snap)
+		if err == nil {
+			m.logf("Apagado: estado traspasado a %s (%d en cola): %s", s.handoffAddr, queued, res.GetMessage())
+			return
+		}
+		m.logf("WARN Apagado: traspaso a %s fallido: %v", s.handoffAddr, err)
+	}
+	if s.handoffFile == "" {
+		m.logf("WARN Apagado: sin HANDOFF_ADDR ni HANDOFF_FILE; %d jugadores en cola quedan sin Matchmaker", queued)
+		return
+	}
+	if err := saveHandoff(s.handoffFile, snap); err != nil {
+		m.logf("WARN Apagado: no pude guardar el estado en %s: %v", s.handoffFile, err)
+		return
+	}
+	m.logf("Apagado: estado guardado en %s (%d en cola)", s.handoffFile, queued)
+}
+
+// waitAssigns espera hasta limit a que no queden AssignMatch en curso.
+func (m *matchmaker) waitAssigns(limit time.Duration) bool {
+	deadline := m.wall.Now().Add(limit)
+	for {
+		m.mu.RLock()
+		n := m.assigning
+		m.mu.RUnlock()
+		if n == 0 {
+			return true
+		}
+		if !m.wall.Now().Before(deadline) {
+			return false
+		}
+		<-m.wall.After(drainPoll)
+	}
+}
+
+func (m *matchmaker) transferState(addr string, snap *pb.StateSnapshot) (*pb.TransferStateResponse, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(m.dialCreds))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handoffTimeout)
+	defer cancel()
+	return pb.NewMatchmakerClient(conn).TransferState(ctx, snap)
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+            RPC: TransferState – estado de un Matchmaker que se apaga
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) TransferState(ctx context.Context, snap *pb.StateSnapshot) (*pb.TransferStateResponse, error) {
+	if m.isDraining() {
+		return nil, status.Error(codes.Unavailable, "este Matchmaker también se está apagando")
+	}
+	if m.replica.passive.Load() {
+		m.mu.Lock()
+		m.detectSnapshotConflicts(snap)
+		m.restore(snap)
+		m.mu.Unlock()
+		m.replica.lastSync.Store(m.wall.Now().UnixNano())
+		m.promote(fmt.Sprintf("Traspaso de %s", snap.GetPrimaryId()))
+		return &pb.TransferStateResponse{Adopted: true, Message: "estado adoptado; este respaldo es ahora primario"}, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := m.importQueue(snap)
+	m.logf("Traspaso de %s: %d jugadores en cola sumados", snap.GetPrimaryId(), n)
+	return &pb.TransferStateResponse{QueuedImported: int32(n), Message: fmt.Sprintf("%d jugadores en cola sumados", n)}, nil
+}
+
+// importQueue suma a la cola local los jugadores en cola de snap que aquí
+// no existen o están libres, en el orden de snap y conservando su espera.
+// Un modo que no existe aquí se crea con la definición de snap, y uno que
+// este Matchmaker no atiende (MATCHMAKER_MODES) se descarta. Los grupos
+// entran si su ID está libre; si no, sus integrantes siguen solos. Partidas
+// en curso, servidores e historial no se traspasan: los servidores se
+// vuelven a registrar solos y sus partidas terminan en el Matchmaker que se
+// apagó o quedan huérfanas.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) importQueue(snap *pb.StateSnapshot) int {
+	imported := 0
+	for _, nsSnap := range snap.GetNamespaces() {
+		ns := m.ns(nsSnap.GetName())
+		m.mergeClock(ns, nsSnap.GetVectorClock())
+		ns.vc.Tick(m.selfID)
+
+		players := make(map[string]*pb.PlayerSnapshot, len(nsSnap.GetPlayers()))
+		for _, p := range nsSnap.GetPlayers() {
+			players[p.GetPlayerId()] = p
+		}
+		modes := make(map[string]*pb.GameMode, len(nsSnap.GetModes()))
+		for _, g := range nsSnap.GetModes() {
+			modes[g.GetName()] = g
+		}
+		parties := make(map[string]*pb.PartySnapshot, len(nsSnap.GetParties()))
+		for _, pt := range nsSnap.GetParties() {
+			parties[pt.GetPartyId()] = pt
+		}
+		ours := map[string]bool{} // grupos creados por este traspaso
+
+		for _, pid := range nsSnap.GetQueue() {
+			p := players[pid]
+			if p == nil || parsePlayerState(p.GetStatus()) != playerInQueue || !m.shards.ownsMode(p.GetGameMode()) {
+				continue
+			}
+			if local, ok := ns.players.Get(pid); ok && local.Status != playerIdle {
+				continue
+			}
+			if _, ok := ns.modes[p.GetGameMode()]; !ok {
+				g, ok := modes[p.GetGameMode()]
+				if !ok {
+					continue
+				}
+				ns.modes[g.GetName()] = gameModeFromProto(g)
+			}
+			pi := &playerInfo{
+				ID:         pid,
+				Status:     playerInQueue,
+				Mode:       p.GetGameMode(),
+				Priority:   queuePriorityFromProto(p.GetPriority()),
+				Tier:       playerTierFromProto(p.GetTier()),
+				Region:     p.GetRegion(),
+				VC:         clocks.New(),
+				LastOp:     time.UnixMilli(p.GetLastOpUnixMs()),
+				QueuedVC:   ns.vc.Copy(),
+				Registered: p.GetRegistered(),
+			}
+			pi.OnAssignFailure = p.GetOnAssignFailure()
+			if local, ok := ns.players.Get(pid); ok {
+				pi.Tier, pi.Registered = local.Tier, local.Registered || pi.Registered
+			}
+			if ms := p.GetQueueDeadlineUnixMs(); ms > 0 {
+				pi.QueueDeadline = time.UnixMilli(ms)
+			}
+			if pt := parties[p.GetPartyId()]; pt != nil {
+				if _, taken := ns.parties[pt.GetPartyId()]; !taken {
+					ns.parties[pt.GetPartyId()] = &party{ID: pt.GetPartyId(), Leader: pt.GetLeaderId(), Members: pt.GetMemberIds()}
+					ours[pt.GetPartyId()] = true
+				}
+				if ours[pt.GetPartyId()] {
+					pi.PartyID = pt.GetPartyId()
+				}
+			}
+			ns.players.Put(pid, pi)
+			ns.enqueue(pid, false)
+			imported++
+		}
+	}
+	return imported
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+                          HANDOFF_FILE: estado en disco
+───────────────────────────────────────────────────────────────────────────────*/
+
+// saveHandoff escribe snap en path; el rename evita dejar un archivo a
+// medio escribir.
+func saveHandoff(path string, snap *pb.StateSnapshot) error {
+	data, err := proto.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadHandoff restaura el estado guardado por un apagado anterior y borra el
+// archivo, para no restaurarlo dos veces.
+func (m *matchmaker) loadHandoff(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	snap := &pb.StateSnapshot{}
+	if err := proto.Unmarshal(data, snap); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	m.mu.Lock()
+	m.restore(snap)
+	m.resetHeartbeats()
+	queued := 0
+	for _, ns := range m.namespaces {
+		queued += ns.queue.Len()
+	}
+	m.mu.Unlock()
+	m.logf("Estado de %s restaurado desde %s (%d en cola)", snap.GetPrimaryId(), path, queued)
+	return os.Remove(path)
+}
@@ -0,0 +1,103 @@
+// matchmaker/shutdown_test.go
+//
+// Apagado ordenado: TransferState promueve a un respaldo pasivo con el
+// estado completo o suma la cola a un Matchmaker activo, y sin destino el
+// estado queda en HANDOFF_FILE para el próximo arranque.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func queueAll(t *testing.T, cli pb.MatchmakerClient, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		res, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: id, GameMode: "1v1"})
+		if err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+			t.Fatalf("QueuePlayer(%s) = %v, %v", id, res, err)
+		}
+	}
+}
+
+func snapshotOf(mm *matchmaker) *pb.StateSnapshot {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+	return mm.snapshot()
+}
+
+func TestTransferStatePromotesPassiveBackup(t *testing.T) {
+	primary, cli := startMatchmaker(t)
+	queueAll(t, cli, "P1")
+
+	backup, bcli := startMatchmaker(t)
+	backup.replica.passive.Store(true)
+	res, err := bcli.TransferState(context.Background(), snapshotOf(primary))
+	if err != nil {
+		t.Fatalf("TransferState: %v", err)
+	}
+	if !res.GetAdopted() {
+		t.Errorf("el respaldo no adoptó el estado: %v", res)
+	}
+	if backup.replica.passive.Load() {
+		t.Errorf("el respaldo sigue pasivo tras el traspaso")
+	}
+	if got := playerStatus(t, bcli, "P1"); got != "IN_QUEUE" {
+		t.Errorf("P1 en el respaldo = %s, se esperaba IN_QUEUE", got)
+	}
+}
+
+func TestTransferStateMergesQueueIntoActive(t *testing.T) {
+	old, cli := startMatchmaker(t)
+	queueAll(t, cli, "P1", "P2")
+
+	peer, pcli := startMatchmaker(t)
+	queueAll(t, pcli, "P2", "P9")
+	res, err := pcli.TransferState(context.Background(), snapshotOf(old))
+	if err != nil {
+		t.Fatalf("TransferState: %v", err)
+	}
+	if res.GetAdopted() || res.GetQueuedImported() != 1 {
+		t.Errorf("TransferState = %v, se esperaba sumar sólo a P1", res)
+	}
+	peer.mu.RLock()
+	queue := peer.ns("").queue.IDs()
+	peer.mu.RUnlock()
+	if want := []string{"P2", "P9", "P1"}; !reflect.DeepEqual(queue, want) {
+		t.Errorf("cola = %v, se esperaba %v", queue, want)
+	}
+}
+
+func TestShutdownSavesHandoffFile(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	queueAll(t, cli, "P1", "P2")
+	path := filepath.Join(t.TempDir(), "handoff.pb")
+
+	mm.shutdown(shutdownSettings{handoffFile: path})
+	_, err := cli.QueuePlayer(context.Background(), &pb.PlayerInfoRequest{PlayerId: "P3", GameMode: "1v1"})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("QueuePlayer durante el apagado: %v, se esperaba UNAVAILABLE", err)
+	}
+
+	next, ncli := startMatchmaker(t)
+	if err := next.loadHandoff(path); err != nil {
+		t.Fatalf("loadHandoff: %v", err)
+	}
+	for _, id := range []string{"P1", "P2"} {
+		if got := playerStatus(t, ncli, id); got != "IN_QUEUE" {
+			t.Errorf("%s tras el arranque = %s, se esperaba IN_QUEUE", id, got)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("HANDOFF_FILE no se borró tras restaurarlo: %v", err)
+	}
+}
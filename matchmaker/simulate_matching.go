@@ -0,0 +1,221 @@
+// matchmaker/simulate_matching.go
+//
+// Ensayo del emparejamiento (AdminSimulateMatching). Corre una vuelta de
+// createMatches sobre una copia del namespace —cola, cuentas regresivas de
+// las salas y partidas en curso— con la política vigente, y devuelve las
+// partidas que formaría y, para cada jugador en cola que quedaría fuera, el
+// motivo: faltan jugadores, la política no lo ubicó (región, tamaño del
+// grupo), la sala espera su cuenta regresiva o no quedan servidores. Sirve
+// para depurar MATCH_POLICY, regiones y relajación sin tocar el estado: no
+// abre partidas, no notifica a nadie y no cuenta métricas.
+//
+// La copia es superficial: jugadores, grupos, salas y servidores se
+// comparten y sólo se leen. La política random se ensaya con otra semilla,
+// así que muestra un reparto posible, no el que hará el bucle.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vimsent/L3/internal/store"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// dryRunCopy copia lo que el emparejamiento modifica: la cola, las cuentas
+// regresivas de las salas y las partidas en curso (que ocupan huecos).
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) dryRunCopy() *namespace {
+	sim := *ns
+	sim.queue = ns.queue.Clone()
+	sim.lobbyReady = make(map[string]time.Time, len(ns.lobbyReady))
+	for mode, since := range ns.lobbyReady {
+		sim.lobbyReady[mode] = since
+	}
+	sim.matches = new(store.Map[*activeMatch])
+	for id, am := range ns.matches.All() {
+		sim.matches.Put(id, am)
+	}
+	return &sim
+}
+
+// simulateMatching ensaya una vuelta del bucle en ns y lista las partidas y
+// los omitidos del modo only ("" = todos). Se ensayan todos los modos,
+// porque comparten los servidores.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) simulateMatching(ns *namespace, only string) *pb.SimulateMatchingResponse {
+	now := m.wall.Now()
+	policy, err := newMatchPolicy(m.matchPolicy.Name())
+	if err != nil {
+		policy = m.matchPolicy
+	}
+	res := &pb.SimulateMatchingResponse{Policy: policy.Name()}
+
+	sim := ns.dryRunCopy()
+	sim.sortQueue(m.policy, now)
+	order := sim.queue.IDs()
+	reasons := make(map[string]string) // jugador → motivo ya conocido
+
+	// reserve ocupa un hueco de srv como lo haría openMatch
+	reserve := func(srv *gameServerInfo, mode string) {
+		sim.matches.Put(fmt.Sprintf("dry-run-%d", sim.matches.Len()), &activeMatch{ServerID: srv.ID, Mode: mode})
+	}
+	add := func(sm *pb.SimulatedMatch) {
+		if only == "" || sm.GameMode == only {
+			res.Matches = append(res.Matches, sm)
+		}
+	}
+
+	if m.isDraining() {
+		for _, pid := range order {
+			reasons[pid] = "el Matchmaker se está apagando: no forma partidas"
+		}
+	} else {
+		for _, lb := range sim.fullPrivateLobbies() {
+			if srv := sim.lobbyServer(lb); srv != nil {
+				reserve(srv, lb.Mode)
+				add(&pb.SimulatedMatch{Kind: pb.SimulatedMatch_PRIVATE_LOBBY, GameMode: lb.Mode, PlayerIds: lb.Members, ServerId: srv.ID})
+			}
+		}
+		for _, mode := range sim.sortedModes() {
+			if !mode.Enabled {
+				continue
+			}
+			if mode.isLobby() {
+				for _, matchID := range sim.openMatches(mode.Name) {
+					am := sim.matches.Value(matchID)
+					if _, ok := sim.servers.Get(am.ServerID); !ok {
+						continue
+					}
+					if players := sim.takeBackfill(mode, am.OpenSlots); players != nil {
+						add(&pb.SimulatedMatch{Kind: pb.SimulatedMatch_BACKFILL, GameMode: mode.Name, PlayerIds: players, ServerId: am.ServerID, MatchId: matchID})
+					}
+				}
+			}
+			// a diferencia del bucle, sigue formando sin servidores libres
+			// para saber a quiénes deja fuera sólo la falta de servidores
+			for {
+				kind := pb.SimulatedMatch_TEAMS
+				var players []string
+				relax := relaxNone
+				if mode.isLobby() {
+					kind, players = pb.SimulatedMatch_LOBBY, sim.takeLobby(mode, now)
+				} else {
+					players, relax = sim.takeQueued(mode, policy, m.policy, now)
+				}
+				if players == nil {
+					break
+				}
+				srv := sim.pickServer(players)
+				if srv == nil {
+					for _, pid := range players {
+						reasons[pid] = "formaría partida, pero no quedan servidores libres"
+					}
+					continue
+				}
+				reserve(srv, mode.Name)
+				sm := &pb.SimulatedMatch{Kind: kind, GameMode: mode.Name, PlayerIds: players, ServerId: srv.ID}
+				if relax != relaxNone {
+					sm.Relaxed = relax.stage()
+				}
+				add(sm)
+			}
+		}
+	}
+
+	waiting := make(map[string]bool, sim.queue.Len())
+	for _, pid := range sim.queue.IDs() {
+		waiting[pid] = true
+	}
+	for _, pid := range order {
+		p, ok := ns.players.Get(pid)
+		if !ok || (only != "" && p.Mode != only) {
+			continue
+		}
+		reason, known := reasons[pid]
+		if !known {
+			if !waiting[pid] {
+				continue // entra en una de las partidas
+			}
+			reason = m.skipReason(sim, policy, p, now)
+		}
+		res.Skipped = append(res.Skipped, &pb.SkippedPlayer{
+			PlayerId: pid,
+			GameMode: p.Mode,
+			Region:   p.Region,
+			WaitMs:   now.Sub(p.LastOp).Milliseconds(),
+			Reason:   reason,
+		})
+	}
+	res.FreeServers = int32(sim.availableServerCount())
+	return res
+}
+
+// skipReason explica por qué p sigue en la cola del ensayo sim.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) skipReason(sim *namespace, policy matchPolicy, p *playerInfo, now time.Time) string {
+	mode, ok := sim.modes[p.Mode]
+	switch {
+	case !ok:
+		return fmt.Sprintf("el modo %s ya no existe", p.Mode)
+	case !mode.Enabled:
+		return fmt.Sprintf("el modo %s está deshabilitado", p.Mode)
+	}
+	units := sim.queuedUnits(mode, m.policy, now)
+	queued := 0
+	var unit queueUnit
+	for _, u := range units {
+		queued += len(u.Players)
+		for _, pid := range u.Players {
+			if pid == p.ID {
+				unit = u
+			}
+		}
+	}
+
+	if mode.isLobby() {
+		if queued < mode.LobbyMin {
+			return fmt.Sprintf("la sala reúne %d de los %d jugadores mínimos", queued, mode.LobbyMin)
+		}
+		if since, ok := sim.lobbyReady[mode.Name]; ok {
+			left := since.Add(time.Duration(mode.LobbyWait) * time.Second).Sub(now)
+			return fmt.Sprintf("la sala arranca en %v (cuenta regresiva de %d s)", left.Round(time.Second), mode.LobbyWait)
+		}
+		return fmt.Sprintf("su grupo de %d no cabe en la sala (máximo %d)", len(unit.Players), mode.LobbyMax)
+	}
+
+	need := 2 * mode.TeamSize
+	if queued < need {
+		return fmt.Sprintf("faltan jugadores: %d en cola de %d necesarios", queued, need)
+	}
+	if _, regional := policy.(regionPolicy); regional && unit.Region != "" && unit.Relax == relaxNone {
+		wait := now.Sub(p.LastOp)
+		next := m.policy.relaxAfter
+		if next == 0 {
+			next = m.policy.maxWait
+		}
+		return fmt.Sprintf("la política region no reúne %d jugadores de %s; se relaja en %v",
+			need, unit.Region, (next - wait).Round(time.Second))
+	}
+	return fmt.Sprintf("los grupos en cola no completan dos equipos de %d (su grupo: %d)", mode.TeamSize, len(unit.Players))
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+        RPC: AdminSimulateMatching – ensayo del emparejamiento sin efectos
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminSimulateMatching(ctx context.Context, req *pb.SimulateMatchingRequest) (*pb.SimulateMatchingResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+
+	res := m.simulateMatching(ns, req.GetGameMode())
+	m.logf("[%s] Ensayo de emparejamiento (%s): %d partidas, %d jugadores fuera",
+		ns.name, res.GetPolicy(), len(res.GetMatches()), len(res.GetSkipped()))
+	res.VectorClock = m.clockProto(ns)
+	return res, nil
+}
@@ -0,0 +1,62 @@
+// matchmaker/simulate_matching_test.go
+//
+// Ensayo del emparejamiento: AdminSimulateMatching devuelve las partidas que
+// formaría la vuelta siguiente y por qué quedan fuera los demás, sin tocar
+// la cola ni abrir partidas.
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestSimulateMatching(t *testing.T) {
+	mm, cli := startMatchmaker(t)
+	ctx := context.Background()
+
+	mm.mu.Lock()
+	ns := mm.ns(defaultNamespace)
+	ns.servers.Put("gs1", &gameServerInfo{ID: "gs1", Status: serverAvailable, Capacity: 1, FreeSlots: 1, LastHB: mm.wall.Now()})
+	mm.mu.Unlock()
+	for _, pid := range []string{"P1", "P2", "P3", "P4", "P5"} {
+		if res, err := cli.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: pid}); err != nil || res.GetStatusCode() != pb.QueuePlayerResponse_OK {
+			t.Fatalf("QueuePlayer(%s) = %v (%v)", pid, res, err)
+		}
+	}
+
+	res, err := cli.AdminSimulateMatching(ctx, &pb.SimulateMatchingRequest{})
+	if err != nil {
+		t.Fatalf("AdminSimulateMatching: %v", err)
+	}
+	if len(res.GetMatches()) != 1 || strings.Join(res.GetMatches()[0].GetPlayerIds(), ",") != "P1,P2" || res.GetMatches()[0].GetServerId() != "gs1" {
+		t.Fatalf("partidas = %v, se esperaba P1 contra P2 en gs1", res.GetMatches())
+	}
+	reasons := make(map[string]string)
+	for _, s := range res.GetSkipped() {
+		reasons[s.GetPlayerId()] = s.GetReason()
+	}
+	// P3 y P4 formarían otra partida, pero gs1 tiene un solo hueco
+	for _, pid := range []string{"P3", "P4"} {
+		if !strings.Contains(reasons[pid], "servidores") {
+			t.Fatalf("motivo de %s = %q, se esperaba falta de servidores", pid, reasons[pid])
+		}
+	}
+	if !strings.Contains(reasons["P5"], "faltan jugadores") {
+		t.Fatalf("motivo de P5 = %q, se esperaba falta de jugadores", reasons["P5"])
+	}
+	if res.GetFreeServers() != 0 || res.GetPolicy() != "fifo" {
+		t.Fatalf("respuesta = %v", res)
+	}
+
+	// el ensayo no tocó el estado
+	mm.mu.RLock()
+	queued, matches := ns.queue.Len(), ns.matches.Len()
+	mm.mu.RUnlock()
+	if queued != 5 || matches != 0 {
+		t.Fatalf("tras el ensayo: %d en cola y %d partidas, se esperaban 5 y 0", queued, matches)
+	}
+}
@@ -0,0 +1,217 @@
+// matchmaker/slo.go
+//
+// Objetivos de servicio (SLO) sobre la espera en cola. MATCH_SLOS define, por
+// modo, qué fracción de los jugadores debe encontrar partida dentro de un
+// umbral, p.ej. "1v1=95@60s,*=90@2m" ("*" cubre los modos sin entrada
+// propia). En cada vuelta del bucle de emparejamiento se evalúa el
+// cumplimiento sobre la ventana SLO_WINDOW: cuentan las esperas de los
+// jugadores emparejados y, como incumplimientos, los que siguen encolados
+// más allá del umbral. La tasa de consumo (burn rate) compara los
+// incumplimientos con el presupuesto de error del objetivo; con burn rate
+// ≥ SLO_BURN_ALERT el SLO queda en riesgo y se registra un WARN.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const (
+	sloAnyMode       = "*"
+	defaultSLOWindow = time.Hour
+	defaultBurnAlert = 2.0
+	sloMinSamples    = 10 // esperas mínimas en la ventana antes de alertar
+)
+
+type sloTarget struct {
+	Target    float64       // fracción de jugadores, p.ej. 0.95
+	Threshold time.Duration // espera máxima en cola
+}
+
+type sloPolicy struct {
+	targets   map[string]sloTarget // modo → objetivo
+	window    time.Duration
+	burnAlert float64
+}
+
+func defaultSLOPolicy() sloPolicy {
+	return sloPolicy{
+		targets:   make(map[string]sloTarget),
+		window:    defaultSLOWindow,
+		burnAlert: defaultBurnAlert,
+	}
+}
+
+// parseSLOs interpreta "modo=porcentaje@umbral,...". El "%" es opcional.
+func parseSLOs(raw string) (map[string]sloTarget, error) {
+	out := make(map[string]sloTarget)
+	if strings.TrimSpace(raw) == "" {
+		return out, nil
+	}
+	for _, part := range strings.Split(raw, ",") {
+		mode, spec, ok := strings.Cut(strings.TrimSpace(part), "=")
+		pct, thr, ok2 := strings.Cut(spec, "@")
+		mode = strings.TrimSpace(mode)
+		if !ok || !ok2 || mode == "" {
+			return nil, fmt.Errorf("%q no tiene forma modo=porcentaje@umbral", part)
+		}
+		p, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(pct), "%"), 64)
+		if err != nil || p <= 0 || p >= 100 {
+			return nil, fmt.Errorf("porcentaje inválido en %q (debe estar en (0, 100))", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(thr))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("umbral inválido en %q", part)
+		}
+		out[mode] = sloTarget{Target: p / 100, Threshold: d}
+	}
+	return out, nil
+}
+
+// forMode devuelve el objetivo aplicable a un modo, si lo hay.
+func (sp sloPolicy) forMode(mode string) (sloTarget, bool) {
+	if t, ok := sp.targets[mode]; ok {
+		return t, true
+	}
+	t, ok := sp.targets[sloAnyMode]
+	return t, ok
+}
+
+// sloKey identifica un SLO: un modo dentro de un namespace.
+type sloKey struct {
+	Namespace string
+	Mode      string
+}
+
+type sloSample struct {
+	At   time.Time
+	Wait time.Duration
+}
+
+// sloState es la última evaluación de un SLO.
+type sloState struct {
+	Target     sloTarget
+	Matched    int // jugadores emparejados dentro de la ventana
+	Good       int // … de ellos, dentro del umbral
+	Overdue    int // encolados que ya superaron el umbral
+	Compliance float64
+	BurnRate   float64
+	AtRisk     bool
+}
+
+// recordQueueWait guarda la espera de un jugador recién emparejado.
+// Las muestras viven fuera de los namespaces para sobrevivir a restore().
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) recordQueueWait(ns *namespace, mode string, wait time.Duration, now time.Time) {
+	m.metrics.queueWait.Observe(wait.Seconds(), ns.name, mode)
+	if _, ok := m.slo.forMode(mode); !ok {
+		return
+	}
+	k := sloKey{ns.name, mode}
+	m.sloSamples[k] = append(m.sloSamples[k], sloSample{At: now, Wait: wait})
+}
+
+// evaluateSLOs recalcula el cumplimiento de cada SLO y avisa de los cambios
+// de estado (en riesgo / recuperado).
+func (m *matchmaker) evaluateSLOs(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.slo.targets) == 0 {
+		return
+	}
+
+	for _, ns := range m.namespaces {
+		overdue := make(map[string]int)
+		for _, pid := range ns.queue.IDs() {
+			p, ok := ns.players.Get(pid)
+			if !ok {
+				continue
+			}
+			if t, ok := m.slo.forMode(p.Mode); ok && now.Sub(p.LastOp) > t.Threshold {
+				overdue[p.Mode]++
+			}
+		}
+
+		for _, mode := range ns.sortedModes() {
+			t, ok := m.slo.forMode(mode.Name)
+			if !ok {
+				continue
+			}
+			k := sloKey{ns.name, mode.Name}
+
+			samples := m.sloSamples[k]
+			cut := 0
+			for cut < len(samples) && now.Sub(samples[cut].At) > m.slo.window {
+				cut++
+			}
+			samples = samples[cut:]
+			m.sloSamples[k] = samples
+
+			st := &sloState{Target: t, Matched: len(samples), Overdue: overdue[mode.Name], Compliance: 1}
+			for _, s := range samples {
+				if s.Wait <= t.Threshold {
+					st.Good++
+				}
+			}
+			if total := st.Matched + st.Overdue; total > 0 {
+				st.Compliance = float64(st.Good) / float64(total)
+				st.BurnRate = (1 - st.Compliance) / (1 - t.Target)
+				st.AtRisk = total >= sloMinSamples && st.BurnRate >= m.slo.burnAlert
+			}
+
+			prev := m.sloStates[k]
+			switch {
+			case st.AtRisk && (prev == nil || !prev.AtRisk):
+				m.metrics.sloAlerts.With(ns.name, mode.Name).Inc()
+				m.logf("[%s] WARN: SLO del modo %s en riesgo: %.1f%% dentro de %v (objetivo %.1f%%), burn rate %.2f, %d encolados fuera de plazo",
+					ns.name, mode.Name, st.Compliance*100, t.Threshold, t.Target*100, st.BurnRate, st.Overdue)
+			case !st.AtRisk && prev != nil && prev.AtRisk:
+				m.logf("[%s] SLO del modo %s recuperado: %.1f%% dentro de %v, burn rate %.2f",
+					ns.name, mode.Name, st.Compliance*100, t.Threshold, st.BurnRate)
+			}
+			m.sloStates[k] = st
+		}
+	}
+}
+
+/*───────────────────────────────────────────────────────────────────────────────
+            RPC: AdminGetSLOStatus – cumplimiento de los SLO de espera
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminGetSLOStatus(ctx context.Context, req *pb.AdminRequest) (*pb.SLOStatusResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := &pb.SLOStatusResponse{WindowMs: m.slo.window.Milliseconds()}
+	ns := m.lookupNS(req.GetNamespace())
+	if ns == nil {
+		return res, nil
+	}
+
+	for k, st := range m.sloStates {
+		if k.Namespace != ns.name {
+			continue
+		}
+		res.Slos = append(res.Slos, &pb.SLOStatus{
+			Mode:            k.Mode,
+			Target:          st.Target.Target,
+			ThresholdMs:     st.Target.Threshold.Milliseconds(),
+			Matched:         int32(st.Matched),
+			WithinThreshold: int32(st.Good),
+			Overdue:         int32(st.Overdue),
+			Compliance:      st.Compliance,
+			BurnRate:        st.BurnRate,
+			AtRisk:          st.AtRisk,
+		})
+	}
+	sort.Slice(res.Slos, func(i, j int) bool { return res.Slos[i].Mode < res.Slos[j].Mode })
+	res.VectorClock = m.clockProto(ns)
+	return res, nil
+}
@@ -0,0 +1,92 @@
+// matchmaker/status_errors_test.go
+//
+// Rechazos como errores gRPC (RPC_STATUS_ERRORS): un cliente cualquiera ve
+// el código que corresponde al status_code, con la respuesta de detalle, y
+// un cliente de pkg/mmclient sigue recibiendo la respuesta con error nil.
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/vimsent/L3/internal/middleware"
+	"github.com/vimsent/L3/pkg/mmclient"
+	pb "github.com/vimsent/L3/proto"
+)
+
+// startStatusErrors levanta un Matchmaker que convierte los rechazos y
+// devuelve un cliente sin interceptores y otro de mmclient.
+func startStatusErrors(t *testing.T) (raw, wrapped pb.MatchmakerClient) {
+	t.Helper()
+	mm := newMatchmaker("Matchmaker")
+	mm.statusErrors = true
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(middleware.StatusErrors(mm.statusErrors)))
+	pb.RegisterMatchmakerServer(srv, mm)
+	go srv.Serve(lis)
+
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+	rawConn, err := grpc.NewClient("passthrough:///bufnet", dialer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("no se pudo conectar al Matchmaker: %v", err)
+	}
+	mmConn, err := grpc.NewClient("passthrough:///bufnet", append(mmclient.DialOptions(mmclient.Options{}), dialer)...)
+	if err != nil {
+		t.Fatalf("no se pudo conectar al Matchmaker: %v", err)
+	}
+	t.Cleanup(func() {
+		close(mm.done)
+		rawConn.Close()
+		mmConn.Close()
+		srv.Stop()
+	})
+	return pb.NewMatchmakerClient(rawConn), pb.NewMatchmakerClient(mmConn)
+}
+
+func TestStatusErrors(t *testing.T) {
+	raw, wrapped := startStatusErrors(t)
+	ctx := context.Background()
+
+	if _, err := raw.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "P1"}); err != nil {
+		t.Fatalf("QueuePlayer: %v", err)
+	}
+	_, err := raw.QueuePlayer(ctx, &pb.PlayerInfoRequest{PlayerId: "P1"})
+	st := status.Convert(err)
+	if st.Code() != codes.AlreadyExists {
+		t.Fatalf("segundo QueuePlayer: %v, se esperaba AlreadyExists", err)
+	}
+	var body *pb.QueuePlayerResponse
+	for _, d := range st.Details() {
+		body, _ = d.(*pb.QueuePlayerResponse)
+	}
+	if body.GetStatusCode() != pb.QueuePlayerResponse_ALREADY_IN_QUEUE {
+		t.Fatalf("detalle = %v, se esperaba la respuesta ALREADY_IN_QUEUE", st.Details())
+	}
+
+	// el cliente de mmclient recibe la respuesta como siempre
+	res, err := wrapped.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: "P9"})
+	if err != nil || res.GetStatusCode() != pb.CancelQueueResponse_NOT_IN_QUEUE {
+		t.Fatalf("CancelQueue con mmclient = %v (%v), se esperaba NOT_IN_QUEUE sin error", res, err)
+	}
+	if _, err := raw.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: "P9"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("CancelQueue sin mmclient: %v, se esperaba NotFound", err)
+	}
+	if res, err := wrapped.CancelQueue(ctx, &pb.CancelQueueRequest{PlayerId: "P1"}); err != nil || res.GetStatusCode() != pb.CancelQueueResponse_OK {
+		t.Fatalf("CancelQueue = %v (%v)", res, err)
+	}
+
+	// las respuestas de administración llevan el enum en status
+	if _, err := raw.AdminKickPlayer(ctx, &pb.AdminPlayerActionRequest{PlayerId: "P9"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("AdminKickPlayer: %v, se esperaba NotFound", err)
+	}
+}
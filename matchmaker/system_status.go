@@ -0,0 +1,144 @@
+// matchmaker/system_status.go
+//
+// Desglose de AdminGetSystemStatus: por cada modo de juego, su cola (cuántos
+// esperan y cuánto llevan), las partidas formadas en la ventana STATUS_WINDOW
+// y la espera que tuvieron sus jugadores; por cada servidor, la fracción de
+// su capacidad en uso y las partidas que recibió en la misma ventana.
+//
+// Las partidas de la ventana salen de las activas y del historial, así que
+// con un MATCH_HISTORY_LIMIT muy bajo la cuenta puede quedarse corta.
+
+package main
+
+import (
+	"time"
+
+	pb "github.com/vimsent/L3/proto"
+)
+
+const defaultStatusWindow = 5 * time.Minute
+
+// formedMatch es una partida formada dentro de la ventana, activa o ya
+// terminada.
+type formedMatch struct {
+	ServerID string
+	Mode     string
+	Timeline []matchEvent
+}
+
+// matchedWaits devuelve la espera de cada jugador de la partida, de su
+// QUEUED al MATCHED. Sin MATCHED (historial anterior a las líneas de tiempo)
+// no hay esperas.
+func (f formedMatch) matchedWaits() []time.Duration {
+	var matched time.Time
+	for _, e := range f.Timeline {
+		if e.Stage == pb.MatchEvent_MATCHED {
+			matched = e.At
+		}
+	}
+	if matched.IsZero() {
+		return nil
+	}
+	var waits []time.Duration
+	for _, e := range f.Timeline {
+		if e.Stage == pb.MatchEvent_QUEUED && !e.At.IsZero() && !e.At.After(matched) {
+			waits = append(waits, matched.Sub(e.At))
+		}
+	}
+	return waits
+}
+
+// formedSince lista las partidas que empezaron desde cutoff.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) formedSince(cutoff time.Time) []formedMatch {
+	var out []formedMatch
+	for _, am := range ns.matches.All() {
+		if !am.StartedAt.Before(cutoff) {
+			out = append(out, formedMatch{am.ServerID, am.Mode, am.Timeline})
+		}
+	}
+	// el historial va en orden de cierre: una partida cerrada antes de
+	// cutoff empezó antes de cutoff
+	for i := len(ns.history) - 1; i >= 0; i-- {
+		res := ns.history[i]
+		if res.FinishedAt.Before(cutoff) {
+			break
+		}
+		if !res.StartedAt.Before(cutoff) {
+			out = append(out, formedMatch{res.ServerID, res.Mode, res.Timeline})
+		}
+	}
+	return out
+}
+
+// modeStats resume la cola y el ritmo de emparejamiento de cada modo.
+// debe llamarse con m.mu bloqueado
+func (m *matchmaker) modeStats(ns *namespace, formed []formedMatch) []*pb.ModeQueueStats {
+	out := make([]*pb.ModeQueueStats, 0, len(ns.modes))
+	byMode := make(map[string]*pb.ModeQueueStats, len(ns.modes))
+	for _, g := range ns.sortedModes() {
+		if !m.shards.ownsMode(g.Name) {
+			continue // lo atiende otro Matchmaker (sharding.go)
+		}
+		st := &pb.ModeQueueStats{GameMode: g.Name, Enabled: g.Enabled}
+		byMode[g.Name] = st
+		out = append(out, st)
+	}
+	stats := func(mode string) *pb.ModeQueueStats {
+		st, ok := byMode[mode]
+		if !ok {
+			// modo borrado con jugadores o partidas aún en él
+			st = &pb.ModeQueueStats{GameMode: mode}
+			byMode[mode] = st
+			out = append(out, st)
+		}
+		return st
+	}
+
+	queuedTotal := make(map[string]time.Duration)
+	for _, pid := range ns.queue.IDs() {
+		p, ok := ns.players.Get(pid)
+		if !ok {
+			continue
+		}
+		st := stats(p.Mode)
+		wait := m.wall.Since(p.LastOp)
+		st.Queued++
+		queuedTotal[p.Mode] += wait
+		if ms := wait.Milliseconds(); ms > st.MaxWaitMs {
+			st.MaxWaitMs = ms
+		}
+	}
+	for _, am := range ns.matches.All() {
+		stats(am.Mode).ActiveMatches++
+	}
+
+	matchedTotal := make(map[string]time.Duration)
+	matchedCount := make(map[string]int)
+	for _, f := range formed {
+		stats(f.Mode).MatchesRecent++
+		for _, w := range f.matchedWaits() {
+			matchedTotal[f.Mode] += w
+			matchedCount[f.Mode]++
+		}
+	}
+
+	for mode, st := range byMode {
+		if st.Queued > 0 {
+			st.AvgWaitMs = (queuedTotal[mode] / time.Duration(st.Queued)).Milliseconds()
+		}
+		if n := matchedCount[mode]; n > 0 {
+			st.AvgMatchedWaitMs = (matchedTotal[mode] / time.Duration(n)).Milliseconds()
+		}
+	}
+	return out
+}
+
+// utilization es la fracción de la capacidad del servidor en uso.
+// debe llamarse con m.mu bloqueado
+func (ns *namespace) utilization(s *gameServerInfo) float64 {
+	if s.Capacity <= 0 {
+		return 0
+	}
+	return float64(s.Capacity-ns.freeSlots(s)) / float64(s.Capacity)
+}
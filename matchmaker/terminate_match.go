@@ -0,0 +1,85 @@
+// matchmaker/terminate_match.go
+//
+// Terminación forzada de una partida desde el cliente administrador, p.ej.
+// una que quedó colgada en un servidor que sigue latiendo. AdminTerminateMatch
+// la cierra en el acto: queda en el historial como TERMINATED con el motivo
+// en su línea de tiempo, el GameServer recibe CancelMatch (igual que en un
+// abandono) y sus jugadores vuelven a IDLE con REMOVED, sin la espera del
+// modo. El hueco del servidor se libera de inmediato.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vimsent/L3/internal/tracing"
+	pb "github.com/vimsent/L3/proto"
+)
+
+const outcomeTerminated = "terminated"
+
+/*───────────────────────────────────────────────────────────────────────────────
+                 RPC: AdminTerminateMatch – el administrador la cierra
+───────────────────────────────────────────────────────────────────────────────*/
+
+func (m *matchmaker) AdminTerminateMatch(ctx context.Context, req *pb.AdminTerminateMatchRequest) (*pb.AdminUpdateResponse, error) {
+	matchID := req.GetMatchId()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns := m.ns(req.GetNamespace())
+	m.mergeClock(ns, req.GetClock())
+	ns.vc.Tick(m.selfID)
+
+	am, ok := ns.matches.Get(matchID)
+	if !ok {
+		return &pb.AdminUpdateResponse{
+			Status:      pb.AdminUpdateResponse_NOT_FOUND,
+			Message:     fmt.Sprintf("Partida %s desconocida o ya cerrada", matchID),
+			VectorClock: m.clockProto(ns),
+		}, nil
+	}
+
+	detail := "terminada por el administrador"
+	if r := req.GetReason(); r != "" {
+		detail += ": " + r
+	}
+	now := m.wall.Now()
+	prev := am.Phase
+	m.closeMatch(ns, matchID, am, phaseAborted)
+	auditChange(ctx, matchID, prev.String(), am.Phase.String())
+	ns.releaseServer(am.ServerID)
+	m.recordMatch(ns, &matchResult{
+		MatchID:    matchID,
+		ServerID:   am.ServerID,
+		Mode:       am.Mode,
+		Players:    am.Players,
+		Outcome:    outcomeTerminated,
+		Duration:   now.Sub(am.StartedAt),
+		StartedAt:  am.StartedAt,
+		FinishedAt: now,
+		VC:         ns.vc.Copy(),
+		Timeline:   append(am.Timeline, m.newEvent(ns, pb.MatchEvent_ENDED, detail)),
+	})
+
+	for _, pid := range am.Players {
+		p, ok := ns.players.Get(pid)
+		if !ok || p.MatchID != matchID {
+			continue
+		}
+		p.Status, p.MatchID, p.LastOp = playerIdle, "", now
+		m.notify(ns, pid, &pb.MatchUpdate{Event: pb.MatchUpdate_REMOVED, MatchId: matchID})
+	}
+
+	if srv, ok := ns.servers.Get(am.ServerID); ok {
+		go m.cancelOnServer(tracing.Detach(ctx), ns.name, srv.ID, srv.Address, matchID, detail, ns.vc.Copy())
+	}
+	m.logClockf(ns, "[%s] Partida %s %s (server %s); jugadores %v → IDLE", ns.name, matchID, detail, am.ServerID, am.Players)
+	return &pb.AdminUpdateResponse{
+		Status:      pb.AdminUpdateResponse_OK,
+		Message:     fmt.Sprintf("Partida %s terminada; %d jugadores liberados", matchID, len(am.Players)),
+		VectorClock: m.clockProto(ns),
+	}, nil
+}
@@ -0,0 +1,54 @@
+// matchmaker/terminate_match_test.go
+//
+// AdminTerminateMatch: la partida se cierra como TERMINATED, el GameServer
+// recibe CancelMatch, los jugadores quedan IDLE y el servidor vuelve a
+// tener su hueco.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/testkit"
+	pb "github.com/vimsent/L3/proto"
+)
+
+func TestAdminTerminateMatch(t *testing.T) {
+	r := newFailureRig(t, testkit.NeverReport)
+	matchID := startedMatch(r)
+
+	res, err := r.cli.AdminTerminateMatch(context.Background(), &pb.AdminTerminateMatchRequest{MatchId: matchID, Reason: "colgada"})
+	if err != nil || res.GetStatus() != pb.AdminUpdateResponse_OK {
+		t.Fatalf("AdminTerminateMatch = %v, %v", res, err)
+	}
+	if got := r.gs.WaitCancelled(t, 1, time.Second); got[0] != matchID {
+		t.Fatalf("CancelMatch de %s, se esperaba %s", got[0], matchID)
+	}
+	r.inspect(func(ns *namespace) {
+		if _, ok := ns.matches.Get(matchID); ok {
+			t.Errorf("la partida %s sigue en curso", matchID)
+		}
+		for _, pid := range []string{"A", "B"} {
+			if p := ns.players.Value(pid); p.Status != playerIdle || p.MatchID != "" {
+				t.Errorf("%s: %v en %q, se esperaba IDLE", pid, p.Status, p.MatchID)
+			}
+		}
+		if ns.freeSlots(ns.servers.Value(r.gs.ID)) != 1 {
+			t.Errorf("el servidor no recuperó su hueco")
+		}
+		rec := ns.history[len(ns.history)-1].toProto()
+		if rec.GetOutcome() != pb.MatchRecord_TERMINATED {
+			t.Errorf("desenlace = %v, se esperaba TERMINATED", rec.GetOutcome())
+		}
+		if d := rec.GetTimeline()[len(rec.GetTimeline())-1].GetDetail(); d != "terminada por el administrador: colgada" {
+			t.Errorf("detalle del cierre = %q", d)
+		}
+	})
+
+	res, err = r.cli.AdminTerminateMatch(context.Background(), &pb.AdminTerminateMatchRequest{MatchId: matchID})
+	if err != nil || res.GetStatus() != pb.AdminUpdateResponse_NOT_FOUND {
+		t.Errorf("segunda terminación = %v, %v; se esperaba NOT_FOUND", res, err)
+	}
+}
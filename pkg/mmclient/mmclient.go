@@ -0,0 +1,336 @@
+// Package mmclient reúne el comportamiento transversal de los clientes gRPC
+// del Matchmaker (jugador, cliente administrador y GameServer), para que
+// todos lo tengan por construcción y no por copia:
+//
+//   - token de autenticación en la metadata ("authorization: Bearer …");
+//   - token de jugador ("x-player-token", ver RegisterPlayer), renovado una
+//     vez con Reauth si el Matchmaker responde UNAUTHENTICATED;
+//   - un ID de petición por RPC ("x-request-id"), el mismo en cada reintento;
+//   - la versión del protocolo que habla el cliente ("x-protocol-version",
+//     ver ProtocolVersion y GetServerInfo);
+//   - el ID de sesión del proceso ("x-session-id", ver NewSessionID), con
+//     el que el Matchmaker detecta dos clientes con el mismo jugador;
+//   - quién administra ("x-admin-id"), que el Matchmaker anota en su
+//     auditoría de las RPC Admin*;
+//   - el reloj vectorial local en la metadata, fusionado con el que
+//     devuelva el servidor en la cabecera (ver internal/clockpropagation;
+//     ClockCodec elige la serialización);
+//   - reintentos con espera exponencial de las RPC unarias que fallan con
+//     UNAVAILABLE (p.ej. durante la conmutación al respaldo);
+//   - con varias direcciones o un SRV, las RPC van al Matchmaker que
+//     responde SERVING (ver internal/discovery);
+//   - un span por llamada, propagado en la metadata "traceparent" (ver
+//     internal/tracing); los reintentos quedan dentro del mismo span;
+//   - si el servidor devuelve un rechazo como error gRPC con la respuesta
+//     de detalle (RPC_STATUS_ERRORS, ver internal/middleware), se restaura
+//     la respuesta y la RPC devuelve error nil, así que el llamador lee
+//     status_code igual que con un servidor que no convierte.
+//
+// Uso:
+//
+//	conn, err := mmclient.Dial(addr, mmclient.Options{
+//		Creds:    creds,
+//		Token:    token,
+//		ClientID: playerID,
+//		Clock:    localClock,
+//		Retries:  2,
+//	}, grpc.WithBlock())
+package mmclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vimsent/L3/internal/authtoken"
+	"github.com/vimsent/L3/internal/clockpropagation"
+	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/discovery"
+	slog "github.com/vimsent/L3/internal/log"
+	"github.com/vimsent/L3/internal/tracing"
+)
+
+// Claves de metadata que agrega el cliente.
+const (
+	AuthHeader        = "authorization"
+	PlayerTokenHeader = authtoken.Header
+	RequestIDHeader   = "x-request-id"
+	ClockHeader       = "x-vector-clock" // con clockpropagation.Text
+	ProtocolHeader    = "x-protocol-version"
+	SessionHeader     = "x-session-id"
+	AdminHeader       = "x-admin-id"
+)
+
+// Versiones del protocolo (ver ServerInfoRequest en el .proto).
+const (
+	// ProtocolVersion es la que habla este cliente; se sube con cada cambio
+	// del .proto que un extremo antiguo no entendería. v2: latidos del
+	// jugador (HEARTBEAT_LOST); v3: on_assign_failure (ASSIGN_FAILED).
+	ProtocolVersion = 3
+	// LegacyProtocolVersion es la de los clientes anteriores a la
+	// negociación, que no envían ProtocolHeader.
+	LegacyProtocolVersion = 1
+)
+
+// ProtocolVersionOf devuelve la versión que declara la metadata md, o
+// LegacyProtocolVersion si no declara ninguna.
+func ProtocolVersionOf(md metadata.MD) (int, error) {
+	v := md.Get(ProtocolHeader)
+	if len(v) == 0 {
+		return LegacyProtocolVersion, nil
+	}
+	n, err := strconv.Atoi(v[0])
+	if err != nil || n < LegacyProtocolVersion {
+		return 0, fmt.Errorf("%s inválida: %q", ProtocolHeader, v[0])
+	}
+	return n, nil
+}
+
+const (
+	defaultBackoff = 100 * time.Millisecond
+	maxBackoff     = 2 * time.Second
+)
+
+// Options configura el paquete de opciones de conexión. Los campos en cero
+// desactivan la característica correspondiente.
+type Options struct {
+	Creds    credentials.TransportCredentials // nil = sin cifrar
+	Token    string                           // "" = sin autenticación
+	ClientID string                           // prefijo de los IDs de petición
+	Clock    *clocks.Vector                   // reloj local a propagar; nil = no
+	Retries  int                              // reintentos ante UNAVAILABLE
+	Backoff  time.Duration                    // espera inicial; 0 = 100ms
+	// SessionID identifica al proceso cliente de un jugador; "" = sin
+	// sesión (administración, GameServers, pasarelas).
+	SessionID string
+	// AdminID identifica al administrador en la auditoría del Matchmaker;
+	// "" = no se declara.
+	AdminID string
+	// ClockCodec serializa Clock en la metadata; nil = clockpropagation.Text,
+	// la que entienden los servidores anteriores.
+	ClockCodec clockpropagation.Codec
+
+	// PlayerToken devuelve el token de jugador vigente; nil o "" = ninguno.
+	// Se consulta en cada intento, así que un token renovado vale enseguida.
+	PlayerToken func() string
+	// Reauth renueva el token tras un UNAUTHENTICATED; la RPC se repite una
+	// vez si no devuelve error. nil = no se renueva.
+	Reauth func(ctx context.Context) error
+}
+
+// reauthKey marca el contexto de Reauth: sus RPC no vuelven a renovar.
+type reauthKey struct{}
+
+// client guarda el estado compartido por los interceptores de una conexión.
+type client struct {
+	opts  Options
+	nonce string // distingue los IDs de dos procesos con el mismo ClientID
+	seq   atomic.Uint64
+}
+
+// DialOptions devuelve las opciones de grpc.Dial que implementan Options.
+// Los interceptores se encadenan, así que pueden combinarse con otros.
+func DialOptions(o Options) []grpc.DialOption {
+	creds := o.Creds
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	if o.ClientID == "" {
+		o.ClientID = "client"
+	}
+	if o.Backoff <= 0 {
+		o.Backoff = defaultBackoff
+	}
+	c := &client{opts: o, nonce: newNonce()}
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClient, c.unary, UnaryStatusBody),
+		grpc.WithChainStreamInterceptor(tracing.StreamClient, c.stream),
+	}
+}
+
+// Dial conecta con el Matchmaker de addr (una dirección, semillas o un SRV;
+// ver internal/discovery) aplicando Options y, después, las opciones extra.
+func Dial(addr string, o Options, extra ...grpc.DialOption) (*grpc.ClientConn, error) {
+	target, opts, err := discovery.Target(addr)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(DialOptions(o), opts...)
+	return grpc.Dial(target, append(opts, extra...)...)
+}
+
+// RequestID devuelve el ID de petición saliente de ctx, si lo tiene.
+func RequestID(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if v := md.Get(RequestIDHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// WithRequestID fija el ID de petición de las RPC hechas con ctx, en lugar
+// del que generaría el cliente.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestIDHeader, id)
+}
+
+// WithPlayerToken fija el token de jugador de las RPC hechas con ctx, en
+// lugar del de Options.PlayerToken (p.ej. un cliente con varios jugadores).
+func WithPlayerToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, PlayerTokenHeader, token)
+}
+
+// outgoing agrega a ctx la metadata de una RPC. El ID se genera una sola vez
+// y se conserva en los reintentos.
+func (c *client) outgoing(ctx context.Context) context.Context {
+	kv := []string{ProtocolHeader, strconv.Itoa(ProtocolVersion)}
+	if c.opts.Token != "" {
+		kv = append(kv, AuthHeader, "Bearer "+c.opts.Token)
+	}
+	if RequestID(ctx) == "" {
+		kv = append(kv, RequestIDHeader, fmt.Sprintf("%s-%s-%d", c.opts.ClientID, c.nonce, c.seq.Add(1)))
+	}
+	if c.opts.Clock != nil {
+		codec := c.opts.ClockCodec
+		if codec == nil {
+			codec = clockpropagation.Text
+		}
+		kv = append(kv, codec.Key(), codec.Encode(c.opts.Clock))
+	}
+	if c.opts.SessionID != "" {
+		kv = append(kv, SessionHeader, c.opts.SessionID)
+	}
+	if c.opts.AdminID != "" {
+		kv = append(kv, AdminHeader, c.opts.AdminID)
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
+// withPlayerToken agrega el token vigente de Options.PlayerToken, salvo que
+// ctx ya traiga uno.
+func (c *client) withPlayerToken(ctx context.Context) context.Context {
+	if c.opts.PlayerToken == nil {
+		return ctx
+	}
+	if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(PlayerTokenHeader)) > 0 {
+		return ctx
+	}
+	return WithPlayerToken(ctx, c.opts.PlayerToken())
+}
+
+// mergeHeader fusiona el reloj que el servidor devuelva en la cabecera.
+func (c *client) mergeHeader(md metadata.MD) {
+	if c.opts.Clock == nil {
+		return
+	}
+	if remote, _ := clockpropagation.FromMetadata(md); remote != nil {
+		c.opts.Clock.Merge(remote)
+	}
+}
+
+func (c *client) unary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = c.outgoing(ctx)
+	wait := c.opts.Backoff
+	reauthed := c.opts.Reauth == nil || ctx.Value(reauthKey{}) != nil
+
+	for attempt := 0; ; attempt++ {
+		var header metadata.MD
+		err := invoker(c.withPlayerToken(ctx), method, req, reply, cc, append(opts, grpc.Header(&header))...)
+		c.mergeHeader(header)
+		if status.Code(err) == codes.Unauthenticated && !reauthed {
+			reauthed = true
+			// sin la metadata de esta RPC: la de renovación lleva la suya
+			rctx := context.WithValue(metadata.NewOutgoingContext(ctx, nil), reauthKey{}, true)
+			if rerr := c.opts.Reauth(rctx); rerr != nil {
+				slog.Warn("[mmclient] %s (%s): no se pudo renovar el token de jugador: %v", path.Base(method), RequestID(ctx), rerr)
+				return err
+			}
+			attempt-- // la repetición no cuenta como reintento
+			continue
+		}
+		if status.Code(err) != codes.Unavailable || attempt >= c.opts.Retries {
+			return err
+		}
+
+		slog.Warn("[mmclient] %s (%s): %v; reintento %d/%d en %v",
+			path.Base(method), RequestID(ctx), status.Convert(err).Message(), attempt+1, c.opts.Retries, wait)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+		if wait *= 2; wait > maxBackoff {
+			wait = maxBackoff
+		}
+	}
+}
+
+// UnaryStatusBody restaura la respuesta de un rechazo que el servidor
+// devolvió como error gRPC: si el estado trae de detalle un mensaje del tipo
+// de reply, lo copia en reply y devuelve nil. Los demás errores pasan tal
+// cual. DialOptions ya lo incluye; sirve suelto para conexiones propias
+// (p.ej. del Matchmaker a los GameServers).
+func UnaryStatusBody(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	out, ok := reply.(proto.Message)
+	if err == nil || !ok {
+		return err
+	}
+	for _, d := range status.Convert(err).Details() {
+		if body, ok := d.(proto.Message); ok && body.ProtoReflect().Descriptor() == out.ProtoReflect().Descriptor() {
+			proto.Reset(out)
+			proto.Merge(out, body)
+			return nil
+		}
+	}
+	return err
+}
+
+// stream agrega la metadata; los streams no se reintentan aquí porque cada
+// llamador sabe cómo reanudarlos.
+func (c *client) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.withPlayerToken(c.outgoing(ctx)), desc, cc, method, opts...)
+}
+
+// SessionOf devuelve el ID de sesión de la metadata md, o "".
+func SessionOf(md metadata.MD) string {
+	if v := md.Get(SessionHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// NewSessionID genera un ID de sesión para un proceso cliente. Se crea una
+// vez al arrancar y se comparte entre sus conexiones.
+func NewSessionID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+func newNonce() string {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano()&0xffffff)
+	}
+	return hex.EncodeToString(b)
+}
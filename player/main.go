@@ -1,9 +1,11 @@
 // player/main.go
 //
 // Aplicación de consola que representa a un jugador.
-// Permite: unirse a la cola de emparejamiento y consultar su estado.
+// Permite: elegir un modo de juego, unirse a la cola de emparejamiento (solo o
+// en grupo), consultar su estado, calificar la última partida jugada, ver la
+// clasificación del namespace y mirar como espectador una partida en curso.
 //
-// Librerías externas permitidas: google.golang.org/grpc y credentials/insecure
+// Librerías externas permitidas: google.golang.org/grpc y sus credenciales
 // (tal como exige el enunciado para usar gRPC).
 
 package main
@@ -11,20 +13,33 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"math/rand"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/vimsent/L3/internal/clockpropagation"
 	"github.com/vimsent/L3/internal/clocks"
+	"github.com/vimsent/L3/internal/config"
 	slog "github.com/vimsent/L3/internal/log"
+	"github.com/vimsent/L3/internal/shard"
+	"github.com/vimsent/L3/internal/tlsutil"
+	"github.com/vimsent/L3/internal/tracing"
+	"github.com/vimsent/L3/pkg/mmclient"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 
 	// Cambia esta ruta al paquete que generó protoc.
 	matchmakingpb "github.com/vimsent/L3/proto"
@@ -34,48 +49,182 @@ import (
 const (
 	menuJoinQueue   = "1"
 	menuGetStatus   = "2"
-	menuExit        = "3"
+	menuCancelQueue = "3"
+	menuRateMatch   = "4"
+	menuParty       = "5"
+	menuHistory     = "6"
+	menuTimeline    = "7"
+	menuLeaveMatch  = "8"
+	menuSpectate    = "9"
+	menuLeaderboard = "10"
+	menuReadyCheck  = "11"
+	menuLobby       = "12"
+	menuRematch     = "13"
+	menuExit        = "14"
 	defaultGameMode = "1v1"
 )
 
+// Cadencia de PlayerHeartbeat hasta que el Matchmaker pida otra.
+const defaultHeartbeatInterval = 5 * time.Second
+
 var localClock *clocks.Vector
 
+// namespace (tenant) del Matchmaker en el que juega este jugador.
+var namespace string
+
+// prioridad solicitada al encolarse (PRIORITY=premium para jugadores premium).
+var priority matchmakingpb.QueuePriority
+
+// región preferida (REGION); el Matchmaker elige servidores cercanos.
+var region string
+
+// qué hacer si la partida no consigue servidor (ON_ASSIGN_FAILURE).
+var onAssignFailure matchmakingpb.AssignFailureAction
+
+// consistencia pedida al consultar el estado (STATUS_CONSISTENCY).
+var statusConsistency = matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES
+
+// última partida finalizada (MatchID), para las opciones de calificarla y
+// de pedir la revancha.
+var lastFinished atomic.Value
+
+// partida formada que espera confirmación (MatchID), para la opción de
+// aceptarla o rechazarla.
+var pendingMatch atomic.Value
+
+// capacidades que anunció el Matchmaker con GetServerInfo; nil = no se
+// pudo consultar y se asumen todas.
+var serverFeatures map[string]bool
+
 func main() {
+	scriptFlag := flag.String("script", "", "archivo de acciones a ejecutar sin menú (ver script.go)")
+	actionsFlag := flag.String("actions", "", `acciones separadas por ";", p. ej. "queue; wait-for-match 1m; quit"`)
+	flag.Parse()
+
 	// ──────────────────────────────────────────────────────────────────────────────
 	// 1. Configuración inicial ─ ID de jugador y dirección del Matchmaker
 	// ──────────────────────────────────────────────────────────────────────────────
-	playerID := os.Getenv("PLAYER_ID")
-	if playerID == "" {
+	cfg := config.NewReport("player")
+	playerID := cfg.StringOr("PLAYER_ID", func() string {
 		// Asignamos ID determinista con prefijo Player + número aleatorio.
 		rand.Seed(time.Now().UnixNano())
-		playerID = fmt.Sprintf("Player%d", rand.Intn(10000))
+		return fmt.Sprintf("Player%d", rand.Intn(10000))
+	})
+	matchmakerAddr := cfg.Matchmaker("MATCHMAKER_ADDR", "localhost:50051")
+	namespace = cfg.String("NAMESPACE", "default")
+	if cfg.OneOf("PRIORITY", "normal", "normal", "premium") == "premium" {
+		priority = matchmakingpb.QueuePriority_PRIORITY_PREMIUM
+	}
+	region = cfg.String("REGION", "")
+	switch cfg.OneOf("ON_ASSIGN_FAILURE", "requeue", "requeue", "ask", "idle") {
+	case "ask":
+		onAssignFailure = matchmakingpb.AssignFailureAction_FAILURE_ASK
+	case "idle":
+		onAssignFailure = matchmakingpb.AssignFailureAction_FAILURE_IDLE
+	}
+	switch cfg.OneOf("STATUS_CONSISTENCY", "read_your_writes", "eventual", "read_your_writes", "strong") {
+	case "eventual":
+		statusConsistency = matchmakingpb.Consistency_CONSISTENCY_EVENTUAL
+	case "strong":
+		statusConsistency = matchmakingpb.Consistency_CONSISTENCY_STRONG
+	}
+	cachedStatus.ttl = cfg.Duration("STATUS_CACHE_TTL", defaultStatusCacheTTL, 0, time.Minute)
+	tokenFile := cfg.StringOr("TOKEN_FILE", func() string { return defaultTokenFile(playerID) })
+	tlsFiles := cfg.TLS()
+	clientCfg := cfg.Client()
+	logFile := cfg.LogFile()
+	scriptPath := cfg.String("PLAYER_SCRIPT", "")
+	actions := cfg.String("PLAYER_ACTIONS", "")
+	ui := cfg.OneOf("PLAYER_UI", "auto", "auto", "tui", "menu")
+	clockCodec := clockpropagation.ByName(cfg.OneOf("CLOCK_CODEC", "text", "text", "binary"))
+	routes := shard.FromConfig(cfg)
+	tracing.Configure(cfg, "Player")
+	cfg.MustValidate()
+	// las opciones de la línea de comandos mandan sobre el entorno
+	if *scriptFlag != "" || *actionsFlag != "" {
+		scriptPath, actions = *scriptFlag, *actionsFlag
+	}
+	scripted := scriptPath != "" || actions != ""
+	var script []scriptStep
+	if scripted {
+		steps, err := loadScript(scriptPath, actions)
+		if err != nil {
+			log.Printf("[Player %s] Guion inválido: %v", playerID, err)
+			os.Exit(2)
+		}
+		script = steps
+		scriptEvents = make(chan *matchmakingpb.MatchUpdate, 16)
+	}
+	if logFile.Path != "" {
+		if err := slog.UseFile(logFile.Path, logFile.MaxBytes, logFile.Backups); err != nil {
+			log.Fatalf("[Player %s] LOG_FILE: %v", playerID, err)
+		}
+		log.SetOutput(slog.Writer())
 	}
-	go func() {
-		localClock = clocks.New(playerID)
-		slog.Info("Clock inicial %s", localClock.String())
-	}()
 
-	matchmakerAddr := os.Getenv("MATCHMAKER_ADDR")
-	if matchmakerAddr == "" {
-		matchmakerAddr = "localhost:50051"
+	creds, err := tlsutil.ClientCredentials(tlsFiles)
+	if err != nil {
+		log.Fatalf("[Player %s] TLS: %v", playerID, err)
 	}
 
+	localClock = clocks.New(playerID)
+	slog.WithClock(localClock).Info("Clock inicial")
+
 	log.Printf("[Player %s] Iniciando. Matchmaker: %s\n", playerID, matchmakerAddr)
 
 	// ──────────────────────────────────────────────────────────────────────────────
-	// 2. Conexión gRPC (insegura para laboratorio, sin TLS)
+	// 2. Conexión gRPC (TLS si se configuró TLS_CA/TLS_CERT; si no, sin cifrar).
+	//    mmclient agrega tokens, ID de petición y reloj, reintenta UNAVAILABLE
+	//    y renueva el token de jugador ante UNAUTHENTICATED.
 	// ──────────────────────────────────────────────────────────────────────────────
-	conn, err := grpc.Dial(
-		matchmakerAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(), // Espera la conexión (útil al arrancar todo con Docker Compose)
-	)
+	tokens := loadTokenStore(tokenFile)
+	// una sesión por proceso, la misma en todos los Matchmakers: otro
+	// cliente con este PLAYER_ID recibe SESSION_CONFLICT
+	session := mmclient.NewSessionID()
+	var client matchmakingpb.MatchmakerClient
+	var conns []*grpc.ClientConn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	dial := func(addr string) (matchmakingpb.MatchmakerClient, error) {
+		conn, err := mmclient.Dial(addr, mmclient.Options{
+			Creds:       creds,
+			Token:       clientCfg.Token,
+			ClientID:    playerID,
+			Clock:       localClock,
+			ClockCodec:  clockCodec,
+			Retries:     clientCfg.Retries,
+			PlayerToken: tokens.Get,
+			SessionID:   session,
+			Reauth: func(ctx context.Context) error {
+				return tokens.register(ctx, client, playerID)
+			},
+		}, grpc.WithBlock()) // Espera la conexión (útil al arrancar todo con Docker Compose)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", addr, err)
+		}
+		conns = append(conns, conn)
+		return matchmakingpb.NewMatchmakerClient(conn), nil
+	}
+
+	// Con SHARD_ROUTES hay un Matchmaker por grupo de modos: el cliente
+	// encamina cada RPC al que corresponde (internal/shard).
+	var shards []matchmakingpb.MatchmakerClient
+	if routes == nil {
+		client, err = dial(matchmakerAddr)
+		shards = []matchmakingpb.MatchmakerClient{client}
+	} else {
+		var router *shard.Client
+		if router, err = shard.NewClient(routes, matchmakerAddr, dial); err == nil {
+			client, shards = router, router.Shards()
+			log.Printf("[Player %s] %d Matchmakers según SHARD_ROUTES\n", playerID, len(shards))
+		}
+	}
 	if err != nil {
 		log.Fatalf("[Player %s] No se pudo conectar al Matchmaker: %v", playerID, err)
 	}
-	defer conn.Close()
-
-	client := matchmakingpb.NewMatchmakerClient(conn)
 
 	// Contexto raiz con cancelación al recibir SIGINT/SIGTERM.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -83,82 +232,375 @@ func main() {
 
 	go handleOSSignals(cancel)
 
+	// Registro: reclama el ID o renueva el token guardado en TOKEN_FILE.
+	if err := tokens.register(ctx, client, playerID); errors.Is(err, errAlreadyRegistered) {
+		log.Fatalf("[Player %s] %v", playerID, err)
+	} else if err != nil && !errors.Is(err, errAuthDisabled) {
+		slog.Warn("No se pudo registrar al jugador: %v", err)
+	}
+
+	// Si el proceso se reinició a mitad de una sesión, la recuperamos.
+	resumeSession(ctx, client, playerID)
+
+	// Versión del protocolo: se adapta a un Matchmaker más viejo.
+	negotiate(ctx, client, playerID)
+
+	if onAssignFailure != matchmakingpb.AssignFailureAction_FAILURE_REQUEUE && !hasFeature("assign_failure_action") {
+		slog.Warn("El Matchmaker no admite ON_ASSIGN_FAILURE: si tu partida no consigue servidor, volverás a la cola")
+	}
+
+	// Latidos: sin ellos, el Matchmaker saca de la cola a este jugador.
+	if hasFeature("player_heartbeat") {
+		go sendHeartbeats(ctx, client, playerID)
+	}
+
+	// Notificaciones push de partidas (evita tener que consultar el estado);
+	// llegan por el Matchmaker que emparejó al jugador.
+	for _, sh := range shards {
+		go watchMatchUpdates(ctx, sh, tokens, playerID)
+	}
+
+	if scripted {
+		os.Exit(runScript(ctx, client, playerID, script))
+	}
+
 	// ──────────────────────────────────────────────────────────────────────────────
-	// 3. Bucle de menú interactivo
+	// 3. Panel en vivo (tui.go) o bucle de menú interactivo
 	// ──────────────────────────────────────────────────────────────────────────────
 	reader := bufio.NewReader(os.Stdin)
+	if ui == "tui" || (ui == "auto" && isTerminal()) {
+		err := runTUI(ctx, client, reader, creds, playerID)
+		if err == nil {
+			return
+		}
+		slog.Warn("No se pudo abrir el panel, se usa el menú: %v", err)
+	}
 	for {
 		printMenu()
 		fmt.Print("> ")
 
 		input, _ := reader.ReadString('\n')
-		choice := strings.TrimSpace(input)
-
-		switch choice {
-		case menuJoinQueue:
-			if err := queuePlayer(ctx, client, playerID); err != nil {
-
-				log.Printf("[Player %s] Error al unirse a la cola: %v\n", playerID, err)
-			}
-		case menuGetStatus:
-			if err := getPlayerStatus(ctx, client, playerID); err != nil {
-				log.Printf("[Player %s] Error al consultar estado: %v\n", playerID, err)
-			}
-		case menuExit:
-			log.Printf("[Player %s] Saliendo...\n", playerID)
+		if !menuOption(ctx, client, reader, creds, playerID, strings.TrimSpace(input)) {
 			return
-		default:
-			fmt.Println("Opción inválida. Intenta nuevamente.")
 		}
 	}
 }
 
+// menuOption ejecuta la opción choice del menú; devuelve false con la de
+// salir.
+func menuOption(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader,
+	creds credentials.TransportCredentials, playerID, choice string) bool {
+	switch choice {
+	case menuJoinQueue:
+		mode := chooseGameMode(ctx, client, reader)
+		if _, err := queuePlayer(ctx, client, playerID, mode); err != nil {
+			log.Printf("[Player %s] Error al unirse a la cola: %v\n", playerID, err)
+		}
+	case menuGetStatus:
+		if err := getPlayerStatus(ctx, client, playerID); err != nil {
+			log.Printf("[Player %s] Error al consultar estado: %v\n", playerID, err)
+		}
+	case menuCancelQueue:
+		if err := cancelQueue(ctx, client, playerID); err != nil {
+			log.Printf("[Player %s] Error al salir de la cola: %v\n", playerID, err)
+		}
+	case menuRateMatch:
+		if err := rateLastMatch(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error al enviar la opinión: %v\n", playerID, err)
+		}
+	case menuParty:
+		if err := partyMenu(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error en la gestión del grupo: %v\n", playerID, err)
+		}
+	case menuHistory:
+		if err := showMatchHistory(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error al consultar el historial: %v\n", playerID, err)
+		}
+	case menuTimeline:
+		if err := showMatchTimeline(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error al consultar la línea de tiempo: %v\n", playerID, err)
+		}
+	case menuLeaveMatch:
+		if err := leaveMatch(ctx, client, playerID); err != nil {
+			log.Printf("[Player %s] Error al abandonar la partida: %v\n", playerID, err)
+		}
+	case menuSpectate:
+		if err := spectateMatch(ctx, client, reader, creds, playerID); err != nil {
+			log.Printf("[Player %s] Error al mirar la partida: %v\n", playerID, err)
+		}
+	case menuLeaderboard:
+		if err := showLeaderboard(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error al consultar la clasificación: %v\n", playerID, err)
+		}
+	case menuReadyCheck:
+		if err := answerReadyCheck(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error al responder la partida: %v\n", playerID, err)
+		}
+	case menuLobby:
+		if err := lobbyMenu(ctx, client, reader, playerID); err != nil {
+			log.Printf("[Player %s] Error en la gestión de la sala privada: %v\n", playerID, err)
+		}
+	case menuRematch:
+		if err := requestRematch(ctx, client, playerID); err != nil {
+			log.Printf("[Player %s] Error al pedir la revancha: %v\n", playerID, err)
+		}
+	case menuExit:
+		log.Printf("[Player %s] Saliendo...\n", playerID)
+		return false
+	default:
+		fmt.Println("Opción inválida. Intenta nuevamente.")
+	}
+	return true
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Funciones de negocio
 // ──────────────────────────────────────────────────────────────────────────────
 
-// queuePlayer realiza llamada RPC QueuePlayer.
-func queuePlayer(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) error {
+// chooseGameMode lista los modos habilitados del namespace y pide uno al
+// usuario. Si el listado falla o el usuario no elige, usa el modo por defecto.
+func chooseGameMode(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader) string {
+	res, err := client.ListGameModes(ctx, &matchmakingpb.ListGameModesRequest{Namespace: namespace})
+	if err != nil {
+		slog.Warn("No se pudo obtener la lista de modos: %v", err)
+		return defaultGameMode
+	}
+
+	fmt.Println("Modos disponibles:")
+	for _, m := range res.GetModes() {
+		if !m.GetEnabled() {
+			continue
+		}
+		if m.GetLobbyMaxPlayers() > 0 {
+			fmt.Printf("  • %-8s sala de %d-%d jugadores (arranca llena o %d s tras reunir %d)",
+				m.GetName(), m.GetLobbyMinPlayers(), m.GetLobbyMaxPlayers(), m.GetLobbyWaitS(), m.GetLobbyMinPlayers())
+		} else {
+			fmt.Printf("  • %-8s equipos de %d", m.GetName(), m.GetTeamSize())
+			if m.GetScoring() == matchmakingpb.GameMode_TEAM_DEATHMATCH {
+				fmt.Print(" (gana el equipo con más eliminaciones)")
+			}
+		}
+		if m.GetMaxDurationS() > 0 {
+			fmt.Printf(" • %d-%d s", m.GetMinDurationS(), m.GetMaxDurationS())
+		}
+		if m.GetCooldownS() > 0 {
+			fmt.Printf(" • espera %d s tras jugar", m.GetCooldownS())
+		}
+		fmt.Println()
+	}
+	fmt.Printf("Modo [%s]: ", defaultGameMode)
+
+	input, _ := reader.ReadString('\n')
+	if mode := strings.TrimSpace(input); mode != "" {
+		return mode
+	}
+	return defaultGameMode
+}
+
+// queuePlayer realiza llamada RPC QueuePlayer. Devuelve la respuesta, o nil
+// si el límite de frecuencia la rechazó.
+func queuePlayer(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID, mode string) (*matchmakingpb.QueuePlayerResponse, error) {
 
 	req := &matchmakingpb.PlayerInfoRequest{
-		PlayerId: playerID,
-		GameMode: defaultGameMode,
+		PlayerId:        playerID,
+		GameMode:        mode,
+		Namespace:       namespace,
+		Priority:        priority,
+		Region:          region,
+		OnAssignFailure: onAssignFailure,
 	}
 	go func() {
 		localClock.Tick(playerID)
 	}()
-	req.Clock = clocksToProto(localClock)
+	req.Clock = localClock.ToProto()
 
 	start := time.Now()
 	res, err := client.QueuePlayer(ctx, req)
+	if wait, limited := retryAfter(err); limited {
+		log.Printf("[Player %s] ⏳ Demasiados intentos de encolarse; reintenta en %v\n", playerID, wait.Round(100*time.Millisecond))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	slog.WithClock(localClock).Info("[Player %s] QueuePlayer ➜ status=%s • msg=%q • t=%s",
+		playerID, res.GetStatusCode(), res.GetMessage(), time.Since(start))
+	switch res.GetStatusCode() {
+	case matchmakingpb.QueuePlayerResponse_COOLDOWN:
+		wait := time.Duration(res.GetCooldownRemainingMs()) * time.Millisecond
+		log.Printf("[Player %s] ⏳ Podrás volver a la cola en %v\n", playerID, wait.Round(time.Second))
+	case matchmakingpb.QueuePlayerResponse_SESSION_CONFLICT:
+		log.Printf("[Player %s] ⚠️  Otro cliente usa este PLAYER_ID: %s\n", playerID, res.GetMessage())
+	}
+	return res, nil
+}
+
+// retryAfter reconoce el rechazo del límite de frecuencia del Matchmaker
+// (RESOURCE_EXHAUSTED) y devuelve la espera que sugiere su RetryInfo.
+func retryAfter(err error) (time.Duration, bool) {
+	st := status.Convert(err)
+	if err == nil || st.Code() != codes.ResourceExhausted {
+		return 0, false
+	}
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return time.Second, true
+}
+
+// cancelQueue realiza llamada RPC CancelQueue.
+func cancelQueue(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) error {
+	req := &matchmakingpb.CancelQueueRequest{
+		PlayerId:  playerID,
+		Namespace: namespace,
+	}
+	localClock.Tick(playerID)
+	req.Clock = localClock.ToProto()
+
+	res, err := client.CancelQueue(ctx, req)
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	slog.WithClock(localClock).Info("[Player %s] CancelQueue ➜ status=%s • msg=%q",
+		playerID, res.GetStatusCode(), res.GetMessage())
+	return nil
+}
+
+// leaveMatch realiza llamada RPC LeaveMatch sobre la partida en curso.
+func leaveMatch(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) error {
+	req := &matchmakingpb.LeaveMatchRequest{
+		PlayerId:  playerID,
+		Namespace: namespace,
+	}
+	localClock.Tick(playerID)
+	req.Clock = localClock.ToProto()
+
+	res, err := client.LeaveMatch(ctx, req)
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	slog.WithClock(localClock).Info("[Player %s] LeaveMatch ➜ status=%s • match=%s • msg=%q",
+		playerID, res.GetStatusCode(), res.GetMatchId(), res.GetMessage())
+	return nil
+}
+
+// answerReadyCheck pregunta si aceptar la partida pendiente y responde con
+// AcceptMatch.
+func answerReadyCheck(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	matchID, _ := pendingMatch.Load().(string)
+	if matchID == "" {
+		fmt.Println("No tienes ninguna partida pendiente de confirmar.")
+		return nil
+	}
+	fmt.Printf("Partida %s\n", matchID)
+	fmt.Print("¿Aceptarla? (S/n): ")
+	input, _ := reader.ReadString('\n')
+	accept := !strings.EqualFold(strings.TrimSpace(input), "n")
+	return acceptMatch(ctx, client, playerID, matchID, accept)
+}
+
+// acceptMatch realiza llamada RPC AcceptMatch.
+func acceptMatch(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID, matchID string, accept bool) error {
+	localClock.Tick(playerID)
+	res, err := client.AcceptMatch(ctx, &matchmakingpb.AcceptMatchRequest{
+		PlayerId:  playerID,
+		MatchId:   matchID,
+		Accept:    accept,
+		Clock:     localClock.ToProto(),
+		Namespace: namespace,
+	})
 	if err != nil {
 		return err
 	}
-	localClock.Merge(protoToClocks(res.GetClock()))
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+	pendingMatch.CompareAndSwap(matchID, "")
 
-	log.Printf("[Player %s] QueuePlayer ➜ status=%s • msg=%q • t=%s\n",
-		playerID, res.GetStatus(), res.GetMessage(), time.Since(start))
+	slog.WithClock(localClock).Info("[Player %s] AcceptMatch ➜ status=%s • match=%s • msg=%q",
+		playerID, res.GetStatusCode(), matchID, res.GetMessage())
+	return nil
+}
+
+// requestRematch pide la revancha de la última partida terminada con
+// RequestRematch; arranca cuando la piden todos y llega como MATCH_FOUND.
+func requestRematch(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) error {
+	if !hasFeature("rematch") {
+		fmt.Println("Este Matchmaker no admite revanchas.")
+		return nil
+	}
+	matchID, _ := lastFinished.Load().(string)
+	if matchID == "" {
+		fmt.Println("Aún no terminaste ninguna partida.")
+		return nil
+	}
+	localClock.Tick(playerID)
+	res, err := client.RequestRematch(ctx, &matchmakingpb.RematchRequest{
+		PlayerId:  playerID,
+		MatchId:   matchID,
+		Clock:     localClock.ToProto(),
+		Namespace: namespace,
+	})
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	clog := slog.WithClock(localClock)
+	clog.Info("[Player %s] RequestRematch ➜ status=%s • match=%s • %d/%d • msg=%q",
+		playerID, res.GetStatusCode(), matchID, res.GetAccepted(), res.GetPlayers(), res.GetMessage())
+	if id := res.GetRematchId(); id != "" {
+		clog.Info("[Player %s] Revancha %s en el servidor %s", playerID, id, res.GetServerId())
+	}
 	return nil
 }
 
 // getPlayerStatus realiza llamada RPC GetPlayerStatus.
 func getPlayerStatus(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) error {
 	req := &matchmakingpb.PlayerStatusRequest{
-		PlayerId: playerID,
+		PlayerId:    playerID,
+		Namespace:   namespace,
+		Consistency: statusConsistency,
 	}
 
 	start := time.Now()
+	if res, at, ok := cachedStatus.get(start); ok {
+		logPlayerStatus(playerID, res, fmt.Sprintf("caché de hace %v", start.Sub(at).Round(time.Millisecond)))
+		return nil
+	}
 	localClock.Tick(playerID)
-	req.Clock = clocksToProto(localClock)
+	req.Clock = localClock.ToProto()
 
 	res, err := client.GetPlayerStatus(ctx, req)
 	if err != nil {
 		return err
 	}
-	localClock.Merge(protoToClocks(res.GetClock()))
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+	cachedStatus.put(res, time.Now())
 
-	// Formateamos salida legible.
-	state := res.GetState()
+	if res.GetStatus() == "RETRY_LATER" {
+		log.Printf("[Player %s] ⏳ El Matchmaker aún no refleja tus últimas operaciones; reintenta en %v\n",
+			playerID, time.Duration(res.GetRetryAfterMs())*time.Millisecond)
+		return nil
+	}
+	// un Matchmaker anterior responde DEFAULT: no sabe de niveles
+	if got := res.GetConsistency(); got != statusConsistency && got != matchmakingpb.Consistency_CONSISTENCY_DEFAULT {
+		log.Printf("[Player %s] El Matchmaker respondió con consistencia %s en vez de %s\n", playerID, got, statusConsistency)
+	}
+	logPlayerStatus(playerID, res, "t="+time.Since(start).String())
+	return nil
+}
+
+// logPlayerStatus muestra una respuesta de GetPlayerStatus; origin dice de
+// dónde salió (la latencia de la RPC o la edad de la caché).
+func logPlayerStatus(playerID string, res *matchmakingpb.PlayerStatusResponse, origin string) {
+	state := res.GetStatus()
 	matchID := res.GetMatchId()
 	serverAddr := res.GetServerAddr()
 
@@ -166,11 +608,650 @@ func getPlayerStatus(ctx context.Context, client matchmakingpb.MatchmakerClient,
 	sb.WriteString(fmt.Sprintf("[Player %s] Estado actual: %s", playerID, state))
 	if state == "IN_MATCH" {
 		sb.WriteString(fmt.Sprintf(" • MatchID=%s • GameServer=%s", matchID, serverAddr))
+		// con puertos dedicados, la partida tiene su propia dirección
+		if addr := res.GetMatchAddr(); addr != "" && addr != serverAddr {
+			sb.WriteString(fmt.Sprintf(" • Conectar a %s", addr))
+		}
+	}
+	log.Printf("%s • %s\n", sb.String(), origin)
+}
+
+// rateLastMatch pide nota (1-5), si hubo lag y un comentario opcional para
+// la última partida finalizada y los envía con SubmitMatchFeedback.
+func rateLastMatch(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	matchID, _ := lastFinished.Load().(string)
+	if matchID == "" {
+		fmt.Println("Aún no has terminado ninguna partida.")
+		return nil
+	}
+
+	fmt.Printf("Partida %s\n", matchID)
+	fmt.Print("Nota (1-5): ")
+	input, _ := reader.ReadString('\n')
+	rating, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		fmt.Println("Nota inválida.")
+		return nil
+	}
+	fmt.Print("¿Hubo lag? (s/N): ")
+	input, _ = reader.ReadString('\n')
+	lag := strings.EqualFold(strings.TrimSpace(input), "s")
+	fmt.Print("Comentario (opcional): ")
+	input, _ = reader.ReadString('\n')
+
+	localClock.Tick(playerID)
+	res, err := client.SubmitMatchFeedback(ctx, &matchmakingpb.MatchFeedbackRequest{
+		PlayerId:  playerID,
+		MatchId:   matchID,
+		Rating:    int32(rating),
+		Lag:       lag,
+		Comment:   strings.TrimSpace(input),
+		Clock:     localClock.ToProto(),
+		Namespace: namespace,
+	})
+	if err != nil {
+		return err
 	}
-	log.Printf("%s • t=%s\n", sb.String(), time.Since(start))
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	log.Printf("[Player %s] SubmitMatchFeedback ➜ status=%s • msg=%q\n",
+		playerID, res.GetStatusCode(), res.GetMessage())
 	return nil
 }
 
+// showMatchHistory lista las partidas terminadas del jugador, de la más
+// reciente a la más antigua, pidiendo confirmación antes de cada página.
+func showMatchHistory(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	req := &matchmakingpb.MatchHistoryRequest{PlayerId: playerID, Namespace: namespace, PageSize: 10}
+	for {
+		res, err := client.GetMatchHistory(ctx, req)
+		if err != nil {
+			return err
+		}
+		localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+		if len(res.GetMatches()) == 0 && req.PageToken == "" {
+			fmt.Println("Aún no has terminado ninguna partida.")
+			return nil
+		}
+		for _, mt := range res.GetMatches() {
+			result := "abandonada (" + strings.ToLower(mt.GetOutcome().String()) + ")"
+			switch {
+			case mt.GetOutcome() != matchmakingpb.MatchRecord_COMPLETED:
+			case isWinner(playerID, mt.GetWinnerId(), mt.GetWinnerIds()):
+				result = "🏆 victoria"
+			case mt.GetWinnerId() == "":
+				result = "empate"
+			default:
+				result = "derrota (ganó " + winnerLabel(mt.GetWinnerId(), mt.GetWinnerIds()) + ")"
+			}
+			fmt.Printf("  %s • %s (%s) • %v • %s • rivales/compañeros: %s\n",
+				time.UnixMilli(mt.GetFinishedAtUnixMs()).Format("2006-01-02 15:04"), mt.GetMatchId(), mt.GetGameMode(),
+				(time.Duration(mt.GetDurationMs()) * time.Millisecond).Round(time.Second), result,
+				strings.Join(others(mt.GetPlayerIds(), playerID), ", "))
+		}
+		if res.GetNextPageToken() == "" {
+			return nil
+		}
+		fmt.Print("¿Ver más? (s/N): ")
+		input, _ := reader.ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(input), "s") {
+			return nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+// leaderboardOrders son los criterios que ofrecen los clientes, en el orden
+// del menú.
+var leaderboardOrders = []matchmakingpb.LeaderboardRequest_SortBy{
+	matchmakingpb.LeaderboardRequest_WINS,
+	matchmakingpb.LeaderboardRequest_MATCHES,
+	matchmakingpb.LeaderboardRequest_WIN_RATE,
+	matchmakingpb.LeaderboardRequest_AVG_WAIT,
+}
+
+// showLeaderboard muestra la clasificación del namespace por el criterio
+// elegido, resaltando la fila del propio jugador.
+func showLeaderboard(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	fmt.Print("Ordenar por: 1) victorias  2) partidas  3) % de victorias  4) espera media [1]: ")
+	input, _ := reader.ReadString('\n')
+	req := &matchmakingpb.LeaderboardRequest{Namespace: namespace, PageSize: 10}
+	if n, err := strconv.Atoi(strings.TrimSpace(input)); err == nil && n >= 1 && n <= len(leaderboardOrders) {
+		req.SortBy = leaderboardOrders[n-1]
+	}
+	for {
+		res, err := client.GetLeaderboard(ctx, req)
+		if err != nil {
+			return err
+		}
+		localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+		if res.GetTotalPlayers() == 0 {
+			fmt.Println("Aún no hay jugadores en la clasificación.")
+			return nil
+		}
+		for _, e := range res.GetEntries() {
+			mark := "  "
+			if e.GetPlayerId() == playerID {
+				mark = "➤ "
+			}
+			fmt.Printf("%s#%-3d %-12s • %3d victorias en %3d partidas (%3.0f%%) • espera media %v\n",
+				mark, e.GetRank(), e.GetPlayerId(), e.GetWins(), e.GetMatchesPlayed(), e.GetWinRate()*100,
+				(time.Duration(e.GetAvgWaitMs()) * time.Millisecond).Round(100*time.Millisecond))
+		}
+		if res.GetNextPageToken() == "" {
+			return nil
+		}
+		fmt.Print("¿Ver más? (s/N): ")
+		input, _ := reader.ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(input), "s") {
+			return nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+// showMatchTimeline muestra los hitos de una partida (por defecto, la última
+// terminada) con el tiempo transcurrido desde que el jugador se encoló.
+func showMatchTimeline(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	last, _ := lastFinished.Load().(string)
+	fmt.Printf("ID de la partida [%s]: ", last)
+	input, _ := reader.ReadString('\n')
+	matchID := strings.TrimSpace(input)
+	if matchID == "" {
+		matchID = last
+	}
+	if matchID == "" {
+		fmt.Println("Aún no has terminado ninguna partida.")
+		return nil
+	}
+
+	res, err := client.GetMatchTimeline(ctx, &matchmakingpb.MatchTimelineRequest{MatchId: matchID, Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+	if !res.GetFound() {
+		fmt.Println("Partida desconocida.")
+		return nil
+	}
+
+	var origin int64
+	for _, ev := range res.GetEvents() {
+		if origin == 0 || (ev.GetStage() == matchmakingpb.MatchEvent_QUEUED && ev.GetPlayerId() == playerID) {
+			origin = ev.GetUnixMs()
+		}
+	}
+	fmt.Printf("Partida %s (%s):\n", res.GetMatchId(), res.GetGameMode())
+	for _, ev := range res.GetEvents() {
+		if ev.GetStage() == matchmakingpb.MatchEvent_QUEUED && ev.GetPlayerId() != playerID {
+			continue
+		}
+		fmt.Printf("  %-8s +%v\n", ev.GetStage(), time.Duration(ev.GetUnixMs()-origin)*time.Millisecond)
+	}
+	return nil
+}
+
+// spectateMatch pide el ID de una partida en curso y muestra sus eventos
+// hasta que termina. Si el Matchmaker retransmite partidas (match_relay) los
+// recibe a través de él con SubscribeMatchEvents, y un jugador en partida
+// mira la suya; si no, o si el servidor de la partida no los publica, obtiene
+// la dirección del GameServer y se conecta con SpectateMatch. Fuera de su
+// partida, sólo se permite sin cola.
+func spectateMatch(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader,
+	creds credentials.TransportCredentials, playerID string) error {
+	st, err := client.GetPlayerStatus(ctx, &matchmakingpb.PlayerStatusRequest{PlayerId: playerID, Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(st.GetVectorClock()))
+	relay := hasFeature("match_relay")
+	var matchID string
+	switch s := st.GetStatus(); {
+	case s == "IN_MATCH" && relay:
+		matchID = st.GetMatchId()
+	case s != "IDLE" && s != "QUEUE_TIMED_OUT":
+		fmt.Printf("Sólo puedes mirar partidas sin estar en cola ni jugando (estado: %s).\n", st.GetStatus())
+		return nil
+	default:
+		fmt.Print("ID de la partida: ")
+		input, _ := reader.ReadString('\n')
+		if matchID = strings.TrimSpace(input); matchID == "" {
+			return nil
+		}
+	}
+
+	if relay {
+		stream, err := client.SubscribeMatchEvents(ctx, &matchmakingpb.SubscribeMatchEventsRequest{
+			MatchId: matchID, Namespace: namespace, SubscriberId: playerID})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Mirando la partida %s a través del Matchmaker (termina con la partida)…\n", matchID)
+		if err := watchMatch(stream); status.Code(err) != codes.FailedPrecondition {
+			return err
+		}
+		fmt.Println("El servidor de la partida no la retransmite; me conecto a él.")
+	}
+
+	res, err := client.GetMatchTimeline(ctx, &matchmakingpb.MatchTimelineRequest{MatchId: matchID, Namespace: namespace})
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+	if res.GetServerAddr() == "" {
+		fmt.Println("La partida no está en curso.")
+		return nil
+	}
+
+	conn, err := grpc.Dial(res.GetServerAddr(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stream, err := matchmakingpb.NewGameServerClient(conn).SpectateMatch(ctx,
+		&matchmakingpb.SpectateRequest{MatchId: matchID, SpectatorId: playerID})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Mirando la partida %s en %s (termina con la partida)…\n", matchID, res.GetServerAddr())
+	return watchMatch(stream)
+}
+
+// watchMatch muestra los eventos de un stream de SpectateMatch o
+// SubscribeMatchEvents hasta ENDED.
+func watchMatch(stream interface {
+	Recv() (*matchmakingpb.SpectateEvent, error)
+}) error {
+	for {
+		ev, err := stream.Recv()
+		if status.Code(err) == codes.NotFound {
+			fmt.Println("La partida ya no está en curso.")
+			return nil
+		}
+		if errors.Is(err, io.EOF) {
+			fmt.Println("La retransmisión terminó.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		remaining := time.Duration(ev.GetRemainingMs()) * time.Millisecond
+		switch ev.GetKind() {
+		case matchmakingpb.SpectateEvent_SNAPSHOT:
+			fmt.Printf("  %s • quedan %v\n", ev.GetGameMode(), remaining.Round(time.Second))
+			printScoreboard(ev.GetScoreboard())
+		case matchmakingpb.SpectateEvent_SCORE:
+			fmt.Printf("  [%v] %s eliminó a %s\n", remaining.Round(time.Second), ev.GetPlayerId(), ev.GetVictimId())
+		case matchmakingpb.SpectateEvent_TICK:
+			fmt.Printf("  [%v] …\n", remaining.Round(time.Second))
+		case matchmakingpb.SpectateEvent_ENDED:
+			fmt.Printf("  Fin: %s. Marcador final:\n", ev.GetMessage())
+			printScoreboard(ev.GetScoreboard())
+			return nil
+		}
+	}
+}
+
+// printScoreboard muestra el marcador de SpectateMatch.
+func printScoreboard(board []*matchmakingpb.PlayerMatchStats) {
+	for _, st := range board {
+		fmt.Printf("    %-12s %6d pts  %3d/%d\n", st.GetPlayerId(), st.GetScore(), st.GetKills(), st.GetDeaths())
+	}
+}
+
+// others devuelve los IDs distintos de self.
+func others(ids []string, self string) []string {
+	var out []string
+	for _, id := range ids {
+		if id != self {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// isWinner indica si self ganó: está en el equipo ganador o, si el
+// resultado no lo lista, es el ganador.
+func isWinner(self, winner string, winners []string) bool {
+	if len(winners) == 0 {
+		return winner != "" && winner == self
+	}
+	for _, id := range winners {
+		if id == self {
+			return true
+		}
+	}
+	return false
+}
+
+// winnerLabel describe al ganador o al equipo ganador.
+func winnerLabel(winner string, winners []string) string {
+	switch {
+	case len(winners) > 1:
+		return strings.Join(winners, "+")
+	case winner == "":
+		return "empate"
+	}
+	return winner
+}
+
+// partyMenu crea un grupo, se une a uno existente o lo abandona. El líder
+// encola al grupo completo con la opción de unirse a la cola.
+func partyMenu(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	fmt.Print("Grupo: (c)rear, (u)nirse, (s)alir: ")
+	input, _ := reader.ReadString('\n')
+
+	req := &matchmakingpb.PartyRequest{PlayerId: playerID, Namespace: namespace}
+	localClock.Tick(playerID)
+	req.Clock = localClock.ToProto()
+
+	var (
+		res *matchmakingpb.PartyResponse
+		err error
+	)
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "c":
+		res, err = client.CreateParty(ctx, req)
+	case "u":
+		fmt.Print("ID del grupo: ")
+		id, _ := reader.ReadString('\n')
+		req.PartyId = strings.TrimSpace(id)
+		res, err = client.JoinParty(ctx, req)
+	case "s":
+		res, err = client.LeaveParty(ctx, req)
+	default:
+		fmt.Println("Opción inválida.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	log.Printf("[Player %s] Grupo ➜ status=%s • msg=%q\n", playerID, res.GetStatusCode(), res.GetMessage())
+	if res.GetPartyId() != "" {
+		log.Printf("[Player %s] Grupo %s • líder=%s • integrantes=%v\n",
+			playerID, res.GetPartyId(), res.GetLeaderId(), res.GetMemberIds())
+	}
+	return nil
+}
+
+// lobbyMenu crea una sala privada (con un modo y, opcionalmente, un
+// GameServer), entra en una por su código o la abandona. La partida arranca
+// sola cuando la sala se llena y llega como MATCH_FOUND.
+func lobbyMenu(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader, playerID string) error {
+	if !hasFeature("private_lobbies") {
+		fmt.Println("Este Matchmaker no admite salas privadas.")
+		return nil
+	}
+	fmt.Print("Sala privada: (c)rear, (u)nirse, (s)alir: ")
+	input, _ := reader.ReadString('\n')
+
+	var (
+		res *matchmakingpb.LobbyResponse
+		err error
+	)
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "c":
+		mode := chooseGameMode(ctx, client, reader)
+		fmt.Print("ID del GameServer (Enter = el mejor disponible): ")
+		srv, _ := reader.ReadString('\n')
+		localClock.Tick(playerID)
+		res, err = client.CreateLobby(ctx, &matchmakingpb.CreateLobbyRequest{
+			PlayerId:  playerID,
+			GameMode:  mode,
+			ServerId:  strings.TrimSpace(srv),
+			Clock:     localClock.ToProto(),
+			Namespace: namespace,
+		})
+	case "u":
+		fmt.Print("Código de la sala: ")
+		code, _ := reader.ReadString('\n')
+		localClock.Tick(playerID)
+		res, err = client.JoinLobby(ctx, &matchmakingpb.LobbyRequest{
+			PlayerId:  playerID,
+			Code:      strings.TrimSpace(code),
+			Clock:     localClock.ToProto(),
+			Namespace: namespace,
+		})
+	case "s":
+		localClock.Tick(playerID)
+		res, err = client.LeaveLobby(ctx, &matchmakingpb.LobbyRequest{
+			PlayerId:  playerID,
+			Clock:     localClock.ToProto(),
+			Namespace: namespace,
+		})
+	default:
+		fmt.Println("Opción inválida.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	log.Printf("[Player %s] Sala ➜ status=%s • msg=%q\n", playerID, res.GetStatusCode(), res.GetMessage())
+	if res.GetCode() != "" {
+		srv := res.GetServerId()
+		if srv == "" {
+			srv = "cualquiera"
+		}
+		log.Printf("[Player %s] Sala %s • modo=%s • servidor=%s • dueño=%s • %d/%d %v\n",
+			playerID, res.GetCode(), res.GetGameMode(), srv, res.GetOwnerId(),
+			len(res.GetMemberIds()), res.GetCapacity(), res.GetMemberIds())
+	}
+	if res.GetMatchId() != "" {
+		log.Printf("[Player %s] Sala llena: partida %s\n", playerID, res.GetMatchId())
+	}
+	return nil
+}
+
+// resumeSession consulta ResumeSession al arrancar. Si el Matchmaker ya
+// conocía al jugador, reconstruye el reloj local a partir del último reloj
+// conocido y muestra la partida o cola en curso.
+func resumeSession(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) {
+	res, err := client.ResumeSession(ctx, &matchmakingpb.ResumeSessionRequest{
+		PlayerId:  playerID,
+		Namespace: namespace,
+	})
+	if err != nil {
+		slog.Warn("No se pudo recuperar la sesión: %v", err)
+		return
+	}
+	if !res.GetFound() {
+		return
+	}
+
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+	slog.WithClock(localClock).Info("Sesión recuperada")
+
+	switch res.GetStatus() {
+	case "IN_MATCH":
+		log.Printf("[Player %s] Sesión retomada: en partida %s (%s) • GameServer=%s • Conectar a %s\n",
+			playerID, res.GetMatchId(), res.GetGameMode(), res.GetServerAddr(), res.GetMatchAddr())
+	case "IN_QUEUE":
+		log.Printf("[Player %s] Sesión retomada: en cola (%s)\n", playerID, res.GetGameMode())
+	case "READY_CHECK":
+		pendingMatch.Store(res.GetMatchId())
+		log.Printf("[Player %s] Sesión retomada: partida %s pendiente de confirmar (opción %s)\n",
+			playerID, res.GetMatchId(), menuReadyCheck)
+	}
+}
+
+// negotiate consulta GetServerInfo y guarda las capacidades del Matchmaker.
+// Uno anterior a la negociación no la implementa: se asume el protocolo v1,
+// sin latidos ni salas privadas. Termina el proceso si el Matchmaker ya no
+// atiende la versión de este cliente.
+func negotiate(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) {
+	res, err := client.GetServerInfo(ctx, &matchmakingpb.ServerInfoRequest{Namespace: namespace})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		serverFeatures = map[string]bool{}
+		log.Printf("[Player %s] Matchmaker anterior a la negociación de versión: sin latidos ni salas privadas\n", playerID)
+		return
+	case err != nil:
+		slog.Warn("No se pudo consultar la versión del Matchmaker: %v", err)
+		return
+	case res.GetMinProtocolVersion() > mmclient.ProtocolVersion:
+		log.Fatalf("[Player %s] El Matchmaker exige el protocolo v%d o posterior y este cliente habla v%d; actualízalo",
+			playerID, res.GetMinProtocolVersion(), mmclient.ProtocolVersion)
+	}
+	serverFeatures = make(map[string]bool, len(res.GetFeatures()))
+	for _, f := range res.GetFeatures() {
+		serverFeatures[f] = true
+	}
+	if v := res.GetProtocolVersion(); v != mmclient.ProtocolVersion {
+		log.Printf("[Player %s] Matchmaker %s con protocolo v%d (este cliente: v%d)\n",
+			playerID, res.GetMatchmakerId(), v, mmclient.ProtocolVersion)
+	}
+}
+
+func hasFeature(name string) bool {
+	return serverFeatures == nil || serverFeatures[name]
+}
+
+// sendHeartbeats envía PlayerHeartbeat al arrancar y luego con la cadencia
+// que pide el Matchmaker, hasta que se cancele ctx. Un latido fallido se
+// reintenta en el siguiente turno.
+func sendHeartbeats(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) {
+	interval := defaultHeartbeatInterval
+	for {
+		res, err := client.PlayerHeartbeat(ctx, &matchmakingpb.PlayerHeartbeatRequest{
+			PlayerId:  playerID,
+			Clock:     localClock.ToProto(),
+			Namespace: namespace,
+		})
+		if err == nil {
+			localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+			if ms := res.GetIntervalMs(); ms > 0 {
+				interval = time.Duration(ms) * time.Millisecond
+			}
+		} else if ctx.Err() == nil {
+			slog.Debug("Latido no enviado: %v", err)
+		}
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchMatchUpdates mantiene abierta la suscripción SubscribeMatchUpdates e
+// imprime cada notificación. Si el stream se corta, reintenta cada 2 s
+// enviando el reloj del último evento recibido como token de reanudación,
+// para que el Matchmaker reenvíe lo que se perdió mientras tanto.
+func watchMatchUpdates(ctx context.Context, client matchmakingpb.MatchmakerClient, tokens *tokenStore, playerID string) {
+	var lastEvent *matchmakingpb.VectorClock
+	for ctx.Err() == nil {
+		req := &matchmakingpb.SubscribeRequest{
+			PlayerId:   playerID,
+			Namespace:  namespace,
+			Clock:      localClock.ToProto(),
+			ResumeFrom: lastEvent,
+		}
+		stream, err := client.SubscribeMatchUpdates(ctx, req)
+		if err == nil {
+			if lastEvent == nil {
+				// aún sin eventos: reanudaremos desde lo que ya conocíamos
+				lastEvent = req.Clock
+			}
+			for {
+				upd, rerr := stream.Recv()
+				if rerr != nil {
+					err = rerr
+					break
+				}
+				lastEvent = upd.GetVectorClock()
+				localClock.Merge(clocks.FromProto(lastEvent))
+				clog := slog.WithClock(localClock)
+				switch upd.GetEvent() {
+				case matchmakingpb.MatchUpdate_MATCH_FOUND:
+					clog.Info("[Player %s] 🔔 ¡Partida encontrada! MatchID=%s • GameServer=%s",
+						playerID, upd.GetMatchId(), upd.GetServerAddr())
+				case matchmakingpb.MatchUpdate_MATCH_FINISHED:
+					clog.Info("[Player %s] 🔔 Partida %s finalizada • Ganador=%s • califícala con la opción %s",
+						playerID, upd.GetMatchId(), winnerLabel(upd.GetWinnerId(), upd.GetWinnerIds()), menuRateMatch)
+					lastFinished.Store(upd.GetMatchId())
+					if deadline := upd.GetRematchDeadlineUnixMs(); deadline > 0 {
+						clog.Info("[Player %s] 🔔 Pide la revancha con la opción %s antes de las %s",
+							playerID, menuRematch, time.UnixMilli(deadline).Format("15:04:05"))
+					}
+				case matchmakingpb.MatchUpdate_REMATCH_OFFERED:
+					clog.Info("[Player %s] 🔔 Te piden la revancha de %s: acéptala con la opción %s antes de las %s",
+						playerID, upd.GetMatchId(), menuRematch, time.UnixMilli(upd.GetRematchDeadlineUnixMs()).Format("15:04:05"))
+				case matchmakingpb.MatchUpdate_REMATCH_CANCELLED:
+					clog.Info("[Player %s] 🔔 La revancha de %s se canceló (plazo vencido o alguien ya no está disponible)",
+						playerID, upd.GetMatchId())
+				case matchmakingpb.MatchUpdate_MATCH_ABORTED:
+					clog.Info("[Player %s] 🔔 Partida %s cancelada (servidor caído o expirada); consulta tu estado",
+						playerID, upd.GetMatchId())
+				case matchmakingpb.MatchUpdate_PLAYER_LEFT:
+					if upd.GetWinnerId() != "" {
+						clog.Info("[Player %s] 🔔 Un jugador abandonó la partida %s • Ganador por abandono=%s",
+							playerID, upd.GetMatchId(), upd.GetWinnerId())
+					} else {
+						clog.Info("[Player %s] 🔔 Un jugador abandonó la partida %s; vuelves al frente de la cola",
+							playerID, upd.GetMatchId())
+					}
+				case matchmakingpb.MatchUpdate_REMOVED:
+					clog.Info("[Player %s] 🔔 El administrador te sacó de la cola o de la partida %s",
+						playerID, upd.GetMatchId())
+				case matchmakingpb.MatchUpdate_QUEUE_TIMED_OUT:
+					clog.Info("[Player %s] 🔔 Tu espera en la cola venció sin encontrar partida; vuelve a encolarte con la opción %s",
+						playerID, menuJoinQueue)
+				case matchmakingpb.MatchUpdate_HEARTBEAT_LOST:
+					clog.Info("[Player %s] 🔔 El Matchmaker dejó de recibir tus latidos (o los de tu grupo) y te sacó de la cola o de la sala; vuelve con la opción %s",
+						playerID, menuJoinQueue)
+				case matchmakingpb.MatchUpdate_READY_CHECK:
+					pendingMatch.Store(upd.GetMatchId())
+					clog.Info("[Player %s] 🔔 Partida %s lista: acéptala con la opción %s antes de las %s",
+						playerID, upd.GetMatchId(), menuReadyCheck,
+						time.UnixMilli(upd.GetReadyDeadlineUnixMs()).Format("15:04:05"))
+				case matchmakingpb.MatchUpdate_ASSIGN_FAILED:
+					switch deadline := upd.GetRequeueOfferDeadlineUnixMs(); {
+					case upd.GetRequeued():
+						clog.Info("[Player %s] 🔔 Ningún servidor aceptó la partida %s; vuelves al frente de la cola",
+							playerID, upd.GetMatchId())
+					case deadline > 0:
+						clog.Info("[Player %s] 🔔 Ningún servidor aceptó la partida %s; vuelve con la opción %s antes de las %s para recuperar tu lugar",
+							playerID, upd.GetMatchId(), menuJoinQueue, time.UnixMilli(deadline).Format("15:04:05"))
+					default:
+						clog.Info("[Player %s] 🔔 Ningún servidor aceptó la partida %s; sales de la cola",
+							playerID, upd.GetMatchId())
+					}
+				case matchmakingpb.MatchUpdate_READY_CHECK_FAILED:
+					pendingMatch.CompareAndSwap(upd.GetMatchId(), "")
+					if upd.GetRequeued() {
+						clog.Info("[Player %s] 🔔 La partida %s no se confirmó; vuelves al frente de la cola",
+							playerID, upd.GetMatchId())
+					} else {
+						clog.Info("[Player %s] 🔔 La partida %s no se confirmó; sales de la cola",
+							playerID, upd.GetMatchId())
+					}
+				}
+				if scriptEvents != nil {
+					select {
+					case scriptEvents <- upd:
+					default:
+					}
+				}
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Debug("Suscripción a notificaciones interrumpida: %v", err)
+		if status.Code(err) == codes.Unauthenticated {
+			// mmclient sólo renueva el token en las RPC unarias
+			if rerr := tokens.register(ctx, client, playerID); rerr != nil {
+				slog.Warn("No se pudo renovar el token de jugador: %v", rerr)
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Utilidades
 // ──────────────────────────────────────────────────────────────────────────────
@@ -180,6 +1261,17 @@ func printMenu() {
 	fmt.Println("═════════ Menú Jugador ═════════")
 	fmt.Printf("%s) Unirse a la cola de emparejamiento\n", menuJoinQueue)
 	fmt.Printf("%s) Consultar estado\n", menuGetStatus)
+	fmt.Printf("%s) Salir de la cola\n", menuCancelQueue)
+	fmt.Printf("%s) Calificar última partida\n", menuRateMatch)
+	fmt.Printf("%s) Grupo (crear / unirse / salir)\n", menuParty)
+	fmt.Printf("%s) Historial de partidas\n", menuHistory)
+	fmt.Printf("%s) Línea de tiempo de una partida\n", menuTimeline)
+	fmt.Printf("%s) Abandonar la partida en curso\n", menuLeaveMatch)
+	fmt.Printf("%s) Ver una partida en curso (espectador)\n", menuSpectate)
+	fmt.Printf("%s) Clasificación\n", menuLeaderboard)
+	fmt.Printf("%s) Aceptar / rechazar la partida encontrada\n", menuReadyCheck)
+	fmt.Printf("%s) Sala privada (crear / unirse / salir)\n", menuLobby)
+	fmt.Printf("%s) Pedir la revancha de la última partida\n", menuRematch)
 	fmt.Printf("%s) Salir\n", menuExit)
 	fmt.Println("════════════════════════════════")
 }
@@ -189,6 +1281,7 @@ func handleOSSignals(cancel context.CancelFunc) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, os.Kill)
 	<-c
+	restoreTerminal()
 	log.Println("Señal de cierre recibida: terminando proceso…")
 	cancel()
 	time.Sleep(500 * time.Millisecond) // Breve espera para limpieza.
@@ -0,0 +1,220 @@
+// player/script.go
+//
+// Modo guionado. Con --script archivo (o PLAYER_SCRIPT) el jugador no
+// muestra el menú: ejecuta en orden las acciones del archivo, una por línea,
+// y termina. Con --actions "queue; wait-for-match 1m; quit" (o
+// PLAYER_ACTIONS) las acciones van en la misma línea, separadas por ";".
+// Así se pueden lanzar escenarios con varios jugadores sin nadie frente al
+// menú. Acciones:
+//
+//	queue [modo]             se encola (modo por defecto: 1v1)
+//	wait-for-match [plazo]   espera MATCH_FOUND (plazo por defecto: 2m);
+//	                         acepta la confirmación previa si la hay
+//	sleep <duración>         espera, p. ej. "sleep 5s"
+//	status                   muestra el estado en el Matchmaker
+//	quit                     termina sin ejecutar el resto
+//
+// Las líneas vacías y las que empiezan con "#" se ignoran. El guion se
+// valida completo antes de empezar. El proceso termina con código 0 si todas
+// las acciones salieron bien, 1 si alguna falló (se detiene en ella) y 2 si
+// el guion es inválido.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	matchmakingpb "github.com/vimsent/L3/proto"
+)
+
+const (
+	defaultMatchWait = 2 * time.Minute
+	// cada cuánto wait-for-match consulta el estado, por si la notificación
+	// llegó antes de suscribirse
+	scriptStatusPoll = 2 * time.Second
+)
+
+// scriptEvents recibe las notificaciones de watchMatchUpdates en el modo
+// guionado; nil en el modo interactivo.
+var scriptEvents chan *matchmakingpb.MatchUpdate
+
+// scriptStep es una acción del guion.
+type scriptStep struct {
+	Line   int
+	Action string
+	Arg    string        // modo de queue
+	Wait   time.Duration // plazo de wait-for-match o duración de sleep
+}
+
+func (s scriptStep) String() string {
+	if s.Arg != "" {
+		return s.Action + " " + s.Arg
+	}
+	if s.Wait > 0 {
+		return fmt.Sprintf("%s %v", s.Action, s.Wait)
+	}
+	return s.Action
+}
+
+// parseScript interpreta las acciones de text; sep separa las acciones
+// ("\n" para un archivo, ";" para --actions).
+func parseScript(text, sep string) ([]scriptStep, error) {
+	var steps []scriptStep
+	for i, raw := range strings.Split(text, sep) {
+		fields := strings.Fields(raw)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		st := scriptStep{Line: i + 1, Action: strings.ToLower(fields[0])}
+		args := fields[1:]
+		var err error
+		switch st.Action {
+		case "queue":
+			st.Arg = defaultGameMode
+			if len(args) > 0 {
+				st.Arg = args[0]
+			}
+		case "wait-for-match":
+			st.Wait = defaultMatchWait
+			if len(args) > 0 {
+				st.Wait, err = time.ParseDuration(args[0])
+			}
+		case "sleep":
+			if len(args) == 0 {
+				err = fmt.Errorf("falta la duración")
+			} else {
+				st.Wait, err = time.ParseDuration(args[0])
+			}
+		case "status", "quit":
+		default:
+			err = fmt.Errorf("acción desconocida %q (queue, wait-for-match, sleep, status, quit)", fields[0])
+		}
+		if err == nil && len(args) > 1 {
+			err = fmt.Errorf("sobran argumentos: %v", args[1:])
+		}
+		if err == nil && st.Wait < 0 {
+			err = fmt.Errorf("duración negativa")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("acción %d: %v", st.Line, err)
+		}
+		steps = append(steps, st)
+	}
+	return steps, nil
+}
+
+// loadScript lee el guion de path o, si path es vacío, de actions.
+func loadScript(path, actions string) ([]scriptStep, error) {
+	if path == "" {
+		return parseScript(actions, ";")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseScript(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+}
+
+// runScript ejecuta steps y devuelve el código de salida del proceso.
+func runScript(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string, steps []scriptStep) int {
+	log.Printf("[Player %s] Guion de %d acciones", playerID, len(steps))
+	for i, st := range steps {
+		log.Printf("[Player %s] ▶ %d/%d: %s", playerID, i+1, len(steps), st)
+		var err error
+		switch st.Action {
+		case "queue":
+			err = scriptQueue(ctx, client, playerID, st.Arg)
+		case "wait-for-match":
+			err = waitForMatch(ctx, client, playerID, st.Wait)
+		case "sleep":
+			select {
+			case <-time.After(st.Wait):
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		case "status":
+			err = getPlayerStatus(ctx, client, playerID)
+		case "quit":
+			log.Printf("[Player %s] Guion terminado (quit)", playerID)
+			return 0
+		}
+		if err != nil {
+			log.Printf("[Player %s] ✖ %s falló: %v", playerID, st, err)
+			return 1
+		}
+	}
+	log.Printf("[Player %s] Guion terminado", playerID)
+	return 0
+}
+
+// scriptQueue encola al jugador; descarta antes las notificaciones viejas
+// para que wait-for-match sólo vea las de esta espera.
+func scriptQueue(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID, mode string) error {
+	for len(scriptEvents) > 0 {
+		<-scriptEvents
+	}
+	res, err := queuePlayer(ctx, client, playerID, mode)
+	switch {
+	case err != nil:
+		return err
+	case res == nil:
+		return fmt.Errorf("rechazado por el límite de frecuencia")
+	case res.GetStatusCode() != matchmakingpb.QueuePlayerResponse_OK &&
+		res.GetStatusCode() != matchmakingpb.QueuePlayerResponse_ALREADY_IN_QUEUE:
+		return fmt.Errorf("%s: %s", res.GetStatusCode(), res.GetMessage())
+	}
+	return nil
+}
+
+// waitForMatch espera MATCH_FOUND o, consultando el estado, que el
+// Matchmaker ya lo tenga en partida.
+func waitForMatch(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string, limit time.Duration) error {
+	deadline := time.NewTimer(limit)
+	defer deadline.Stop()
+	poll := time.NewTicker(scriptStatusPoll)
+	defer poll.Stop()
+	for {
+		select {
+		case upd := <-scriptEvents:
+			switch upd.GetEvent() {
+			case matchmakingpb.MatchUpdate_MATCH_FOUND:
+				return nil
+			case matchmakingpb.MatchUpdate_READY_CHECK:
+				if err := acceptMatch(ctx, client, playerID, upd.GetMatchId(), true); err != nil {
+					return err
+				}
+			case matchmakingpb.MatchUpdate_QUEUE_TIMED_OUT, matchmakingpb.MatchUpdate_REMOVED, matchmakingpb.MatchUpdate_HEARTBEAT_LOST:
+				return fmt.Errorf("sin partida: %s", upd.GetEvent())
+			case matchmakingpb.MatchUpdate_ASSIGN_FAILED:
+				if !upd.GetRequeued() {
+					return fmt.Errorf("sin partida: %s (ON_ASSIGN_FAILURE)", upd.GetEvent())
+				}
+			}
+		case <-poll.C:
+			res, err := client.GetPlayerStatus(ctx, &matchmakingpb.PlayerStatusRequest{PlayerId: playerID, Namespace: namespace})
+			if err != nil {
+				continue
+			}
+			switch res.GetStatus() {
+			case "IN_MATCH":
+				return nil
+			case "READY_CHECK":
+				// por si la notificación se perdió; aceptar dos veces no hace daño
+				if err := acceptMatch(ctx, client, playerID, res.GetMatchId(), true); err != nil {
+					return err
+				}
+			case "IDLE", "QUEUE_TIMED_OUT":
+				return fmt.Errorf("sin partida: el jugador no está en cola (%s)", res.GetStatus())
+			}
+		case <-deadline.C:
+			return fmt.Errorf("sin partida tras %v", limit)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
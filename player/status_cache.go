@@ -0,0 +1,66 @@
+// player/status_cache.go
+//
+// Caché local del estado del jugador. Guarda la última PlayerStatusResponse
+// con una copia del reloj local tras recibirla y responde las consultas
+// repetidas (la opción de ver el estado y el panel en vivo) sin ir al
+// Matchmaker mientras la respuesta tenga menos de STATUS_CACHE_TTL y el
+// reloj local siga igual. Que el reloj avance —una acción propia, una
+// notificación push o cualquier respuesta que traiga un reloj más nuevo del
+// Matchmaker, latidos incluidos— indica que el estado pudo cambiar, y la
+// consulta siguiente vuelve a la red.
+//
+// El lugar en la cola cambia aunque nada le llegue al jugador, así que el
+// TTL es corto: por defecto la mitad del refresco del panel, para que cada
+// vuelta del panel muestre un lugar recién consultado y la caché sólo
+// absorba las consultas repetidas dentro de una misma vuelta. Con STATUS_CONSISTENCY=strong no se usa la caché: una
+// lectura fuerte tiene que pasar por el Matchmaker. STATUS_CACHE_TTL=0 la
+// desactiva.
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	matchmakingpb "github.com/vimsent/L3/proto"
+)
+
+const defaultStatusCacheTTL = panelRefresh / 2
+
+type statusCache struct {
+	mu   sync.Mutex
+	ttl  time.Duration // 0 = sin caché
+	res  *matchmakingpb.PlayerStatusResponse
+	seen *clocks.Vector // reloj local al guardarla
+	at   time.Time
+}
+
+// cachedStatus es la caché del jugador de este proceso.
+var cachedStatus = &statusCache{ttl: defaultStatusCacheTTL}
+
+// get devuelve la respuesta guardada y cuándo llegó, si sigue vigente.
+func (c *statusCache) get(now time.Time) (*matchmakingpb.PlayerStatusResponse, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.res == nil || c.ttl <= 0 || statusConsistency == matchmakingpb.Consistency_CONSISTENCY_STRONG {
+		return nil, time.Time{}, false
+	}
+	// el reloj local sólo avanza: si no es igual, vio algo posterior
+	if now.Sub(c.at) >= c.ttl || !localClock.Equal(c.seen) {
+		c.res = nil
+		return nil, time.Time{}, false
+	}
+	return c.res, c.at, true
+}
+
+// put guarda res, ya fusionado su reloj en el local. RETRY_LATER no se
+// guarda: no es un estado.
+func (c *statusCache) put(res *matchmakingpb.PlayerStatusResponse, now time.Time) {
+	if res.GetStatus() == "RETRY_LATER" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.res, c.seen, c.at = res, localClock.Copy(), now
+}
@@ -0,0 +1,107 @@
+// player/status_cache_test.go
+//
+// Caché del estado: responde sólo si la respuesta es más nueva que el TTL y
+// el reloj local no se movió desde que se guardó; STATUS_CONSISTENCY=strong
+// y STATUS_CACHE_TTL=0 la saltan, y RETRY_LATER no se guarda. El TTL por
+// defecto queda por debajo del refresco del panel.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	matchmakingpb "github.com/vimsent/L3/proto"
+)
+
+// withPlayerState fija el reloj local y la consistencia del proceso
+// mientras dura el test.
+func withPlayerState(t *testing.T, c matchmakingpb.Consistency) {
+	t.Helper()
+	prevClock, prevCons := localClock, statusConsistency
+	localClock, statusConsistency = clocks.New("P1"), c
+	t.Cleanup(func() { localClock, statusConsistency = prevClock, prevCons })
+}
+
+func TestStatusCacheGet(t *testing.T) {
+	const ttl = 500 * time.Millisecond
+	t0 := time.Now()
+	inQueue := &matchmakingpb.PlayerStatusResponse{Status: "IN_QUEUE"}
+
+	cases := []struct {
+		name        string
+		consistency matchmakingpb.Consistency
+		ttl         time.Duration
+		put         *matchmakingpb.PlayerStatusResponse
+		after       func() // entre put y get
+		at          time.Duration
+		hit         bool
+	}{
+		{"vacía", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl, nil, nil, 0, false},
+		{"vigente", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl, inQueue, nil, ttl / 2, true},
+		{"eventual", matchmakingpb.Consistency_CONSISTENCY_EVENTUAL, ttl, inQueue, nil, 0, true},
+		{"vencida justo en el TTL", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl, inQueue, nil, ttl, false},
+		{"acción propia", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl, inQueue,
+			func() { localClock.Tick("P1") }, 0, false},
+		{"reloj más nuevo del Matchmaker", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl, inQueue,
+			func() { localClock.Merge(clocks.FromProto(&matchmakingpb.VectorClock{Counters: map[string]int32{"MM": 3}})) }, 0, false},
+		{"reloj repetido", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl, inQueue,
+			func() { localClock.Merge(clocks.New()) }, 0, true},
+		{"strong", matchmakingpb.Consistency_CONSISTENCY_STRONG, ttl, inQueue, nil, 0, false},
+		{"sin caché", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, 0, inQueue, nil, 0, false},
+		{"RETRY_LATER", matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES, ttl,
+			&matchmakingpb.PlayerStatusResponse{Status: "RETRY_LATER"}, nil, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			withPlayerState(t, tc.consistency)
+			localClock.Tick("P1")
+			c := &statusCache{ttl: tc.ttl}
+			if tc.put != nil {
+				c.put(tc.put, t0)
+			}
+			if tc.after != nil {
+				tc.after()
+			}
+			res, at, ok := c.get(t0.Add(tc.at))
+			if ok != tc.hit {
+				t.Fatalf("get: acierto = %v, se esperaba %v", ok, tc.hit)
+			}
+			if ok && (res != tc.put || !at.Equal(t0)) {
+				t.Fatalf("get = %v de %v, se esperaba %v de %v", res, at, tc.put, t0)
+			}
+			if !ok && res != nil {
+				t.Fatalf("get falló pero devolvió %v", res)
+			}
+		})
+	}
+}
+
+// Un fallo por TTL o por reloj descarta la entrada: no vuelve a servirse
+// aunque el reloj regrese al valor guardado (p.ej. tras restaurar el local).
+func TestStatusCacheMissDropsEntry(t *testing.T) {
+	withPlayerState(t, matchmakingpb.Consistency_CONSISTENCY_READ_YOUR_WRITES)
+	t0 := time.Now()
+	c := &statusCache{ttl: time.Second}
+	c.put(&matchmakingpb.PlayerStatusResponse{Status: "IDLE"}, t0)
+
+	saved := localClock
+	localClock = saved.Copy()
+	localClock.Tick("P1")
+	if _, _, ok := c.get(t0); ok {
+		t.Fatal("acierto con el reloj local adelantado")
+	}
+	localClock = saved
+	if _, _, ok := c.get(t0); ok {
+		t.Fatal("la entrada sobrevivió a un fallo")
+	}
+}
+
+// El panel consulta cada panelRefresh: con un TTL mayor, vueltas enteras
+// mostrarían el lugar en la cola de la anterior.
+func TestStatusCacheDefaultTTLBelowPanelRefresh(t *testing.T) {
+	if defaultStatusCacheTTL <= 0 || defaultStatusCacheTTL >= panelRefresh {
+		t.Fatalf("defaultStatusCacheTTL = %v, se esperaba entre 0 y el refresco del panel (%v)", defaultStatusCacheTTL, panelRefresh)
+	}
+}
@@ -0,0 +1,116 @@
+// player/token.go
+//
+// Token de jugador (PLAYER_AUTH=required en el Matchmaker). Al arrancar el
+// jugador se registra con RegisterPlayer: la primera vez reclama su ID y
+// después renueva el token presentando el anterior. El token se guarda en
+// TOKEN_FILE para que un reinicio del cliente no pierda el ID; mmclient lo
+// envía en cada RPC y, si el Matchmaker responde UNAUTHENTICATED (token
+// vencido o clave rotada), vuelve a registrarse y repite la llamada.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	slog "github.com/vimsent/L3/internal/log"
+
+	matchmakingpb "github.com/vimsent/L3/proto"
+)
+
+// Errores de register que el llamador distingue.
+var (
+	errAuthDisabled      = errors.New("el Matchmaker no exige tokens")
+	errAlreadyRegistered = errors.New("el ID ya está registrado por otro jugador")
+)
+
+// tokenStore guarda el token vigente en memoria y en disco.
+type tokenStore struct {
+	path string
+
+	mu    sync.Mutex
+	token string
+}
+
+// savedToken es el formato de TOKEN_FILE; el vencimiento es informativo.
+type savedToken struct {
+	Token           string `json:"token"`
+	ExpiresAtUnixMs int64  `json:"expires_at_unix_ms"`
+}
+
+// defaultTokenFile es la ruta por defecto de TOKEN_FILE para un jugador.
+func defaultTokenFile(playerID string) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("l3-player-%s-%s.token", namespace, playerID))
+}
+
+// loadTokenStore lee el token guardado en path, si lo hay.
+func loadTokenStore(path string) *tokenStore {
+	s := &tokenStore{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("No se pudo leer el token de %s: %v", path, err)
+		}
+		return s
+	}
+	var saved savedToken
+	if err := json.Unmarshal(data, &saved); err != nil {
+		slog.Warn("Token de %s ilegible; se pedirá uno nuevo: %v", path, err)
+		return s
+	}
+	s.token = saved.Token
+	return s
+}
+
+// Get devuelve el token vigente; "" si aún no hay.
+func (s *tokenStore) Get() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+func (s *tokenStore) set(token string, expires time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	data, _ := json.Marshal(savedToken{Token: token, ExpiresAtUnixMs: expires.UnixMilli()})
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		slog.Warn("No se pudo guardar el token en %s: %v", s.path, err)
+	}
+}
+
+// register pide un token al Matchmaker (o renueva el actual, que mmclient
+// envía en la metadata) y lo guarda.
+func (s *tokenStore) register(ctx context.Context, client matchmakingpb.MatchmakerClient, playerID string) error {
+	res, err := client.RegisterPlayer(ctx, &matchmakingpb.RegisterPlayerRequest{
+		PlayerId:  playerID,
+		Namespace: namespace,
+		Clock:     localClock.ToProto(),
+	})
+	if err != nil {
+		return err
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+
+	switch res.GetStatusCode() {
+	case matchmakingpb.RegisterPlayerResponse_OK:
+		expires := time.UnixMilli(res.GetExpiresAtUnixMs())
+		s.set(res.GetToken(), expires)
+		log.Printf("[Player %s] Token de jugador obtenido (vence %s)\n", playerID, expires.Format("2006-01-02 15:04"))
+		return nil
+	case matchmakingpb.RegisterPlayerResponse_AUTH_DISABLED:
+		return errAuthDisabled
+	case matchmakingpb.RegisterPlayerResponse_ALREADY_REGISTERED:
+		return fmt.Errorf("%w (usa otro PLAYER_ID o su TOKEN_FILE)", errAlreadyRegistered)
+	default:
+		return fmt.Errorf("%s: %s", res.GetStatusCode(), res.GetMessage())
+	}
+}
@@ -0,0 +1,338 @@
+// player/tui.go
+//
+// Panel en vivo del jugador. Cuando la consola es una terminal (o con
+// PLAYER_UI=tui) reemplaza al menú numerado: cada segundo consulta
+// GetPlayerStatus y redibuja el estado, el lugar en la cola, la espera, la
+// partida y la dirección del GameServer, junto con las últimas líneas del
+// log (las notificaciones push incluidas). Las teclas actúan sin Enter:
+//
+//	q  encolarse con el modo elegido      m  cambiar de modo
+//	c  salir de la cola                   a / r  aceptar / rechazar la partida
+//	l  abandonar la partida               v  pedir la revancha de la última
+//	o  una opción del menú completo       x  salir
+//
+// Sin dependencias nuevas: la terminal pasa a modo cbreak con stty y el
+// panel se dibuja con secuencias ANSI en la pantalla alternativa. Con "o"
+// la terminal vuelve a su estado para usar el menú de siempre y, tras la
+// opción, se retoma el panel.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vimsent/L3/internal/clocks"
+	slog "github.com/vimsent/L3/internal/log"
+
+	"google.golang.org/grpc/credentials"
+
+	matchmakingpb "github.com/vimsent/L3/proto"
+)
+
+// Líneas de log que muestra el panel.
+const tuiLogLines = 8
+
+// Cada cuánto el panel consulta GetPlayerStatus y se redibuja.
+const panelRefresh = time.Second
+
+var (
+	termMu    sync.Mutex
+	termSaved string // estado de stty a restaurar; vacío = terminal intacta
+)
+
+// isTerminal dice si la entrada y la salida estándar son una terminal.
+func isTerminal() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		fi, err := f.Stat()
+		if err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// stty ejecuta stty sobre la terminal de la entrada estándar.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// enterPanel pone la terminal en modo cbreak (teclas sin Enter ni eco) y
+// abre la pantalla alternativa.
+func enterPanel() error {
+	termMu.Lock()
+	defer termMu.Unlock()
+	saved, err := stty("-g")
+	if err != nil {
+		return fmt.Errorf("stty: %v", err)
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return fmt.Errorf("stty: %v", err)
+	}
+	termSaved = saved
+	slog.SetConsole(false)
+	fmt.Print("\033[?1049h\033[?25l")
+	return nil
+}
+
+// restoreTerminal deshace enterPanel; sin panel abierto no hace nada.
+func restoreTerminal() {
+	termMu.Lock()
+	defer termMu.Unlock()
+	if termSaved == "" {
+		return
+	}
+	fmt.Print("\033[?25h\033[?1049l")
+	stty(termSaved)
+	termSaved = ""
+	slog.SetConsole(true)
+}
+
+// panel es lo que muestra la pantalla.
+type panel struct {
+	playerID string
+	mode     string   // modo con el que encola "q"
+	modes    []string // modos habilitados del namespace, para "m"
+	status   *matchmakingpb.PlayerStatusResponse
+	polledAt time.Time
+	pollErr  error
+	lines    []string // últimas líneas del log
+}
+
+// runTUI muestra el panel hasta que el jugador sale. Devuelve error, sin
+// haber tocado la terminal, si no pudo ponerla en modo cbreak.
+func runTUI(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader,
+	creds credentials.TransportCredentials, playerID string) error {
+	p := &panel{playerID: playerID, mode: defaultGameMode}
+	if res, err := client.ListGameModes(ctx, &matchmakingpb.ListGameModesRequest{Namespace: namespace}); err == nil {
+		for _, m := range res.GetModes() {
+			if m.GetEnabled() {
+				p.modes = append(p.modes, m.GetName())
+			}
+		}
+	}
+
+	// el log (también el del paquete estándar) llega al panel y no a la consola
+	tail := slog.Subscribe(64)
+	defer tail.Close()
+	log.SetOutput(slog.Writer())
+	if err := enterPanel(); err != nil {
+		return err
+	}
+	defer restoreTerminal()
+
+	// las teclas se leen de a una; la siguiente espera a que se atienda la
+	// anterior, porque "o" usa el mismo reader para el menú
+	keys := make(chan byte)
+	next := make(chan struct{})
+	go func() {
+		defer close(keys)
+		for {
+			b, err := reader.ReadByte()
+			if err != nil {
+				return
+			}
+			select {
+			case keys <- b:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case <-next:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	tick := time.NewTicker(panelRefresh)
+	defer tick.Stop()
+	p.poll(ctx, client)
+	p.draw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case l, ok := <-tail.C:
+			if !ok {
+				continue
+			}
+			p.addLine(l)
+		case <-tick.C:
+			p.poll(ctx, client)
+		case b, ok := <-keys:
+			if !ok || b == 'x' || b == 'X' || b == 4 { // 4 = Ctrl-D
+				restoreTerminal()
+				log.Printf("[Player %s] Saliendo...\n", playerID)
+				return nil
+			}
+			if !p.handleKey(ctx, client, reader, creds, b) {
+				return nil
+			}
+			p.poll(ctx, client)
+			next <- struct{}{}
+		}
+		p.draw()
+	}
+}
+
+// handleKey atiende una tecla; devuelve false si el jugador salió desde el
+// menú completo.
+func (p *panel) handleKey(ctx context.Context, client matchmakingpb.MatchmakerClient, reader *bufio.Reader,
+	creds credentials.TransportCredentials, key byte) bool {
+	var err error
+	switch key {
+	case 'q', 'Q':
+		_, err = queuePlayer(ctx, client, p.playerID, p.mode)
+	case 'c', 'C':
+		err = cancelQueue(ctx, client, p.playerID)
+	case 'm', 'M':
+		p.mode = p.nextMode()
+	case 'a', 'A', 'r', 'R':
+		matchID, _ := pendingMatch.Load().(string)
+		if matchID == "" {
+			log.Printf("[Player %s] No tienes ninguna partida pendiente de confirmar\n", p.playerID)
+			return true
+		}
+		err = acceptMatch(ctx, client, p.playerID, matchID, key == 'a' || key == 'A')
+	case 'l', 'L':
+		err = leaveMatch(ctx, client, p.playerID)
+	case 'v', 'V':
+		err = requestRematch(ctx, client, p.playerID)
+	case 'o', 'O':
+		restoreTerminal()
+		printMenu()
+		fmt.Print("> ")
+		input, _ := reader.ReadString('\n')
+		if !menuOption(ctx, client, reader, creds, p.playerID, strings.TrimSpace(input)) {
+			return false
+		}
+		if err := enterPanel(); err != nil {
+			slog.Warn("No se pudo volver al panel: %v", err)
+			return false
+		}
+	}
+	if err != nil {
+		log.Printf("[Player %s] Error: %v\n", p.playerID, err)
+	}
+	return true
+}
+
+// nextMode devuelve el modo que sigue al elegido en la lista del namespace.
+func (p *panel) nextMode() string {
+	for i, m := range p.modes {
+		if m == p.mode {
+			return p.modes[(i+1)%len(p.modes)]
+		}
+	}
+	if len(p.modes) > 0 {
+		return p.modes[0]
+	}
+	return p.mode
+}
+
+// poll consulta el estado, de la caché si sigue vigente (status_cache.go).
+// No avanza el reloj local: es una lectura que se repite cada segundo, no
+// una acción del jugador.
+func (p *panel) poll(ctx context.Context, client matchmakingpb.MatchmakerClient) {
+	if res, at, ok := cachedStatus.get(time.Now()); ok {
+		p.status, p.polledAt, p.pollErr = res, at, nil
+		return
+	}
+	pctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	res, err := client.GetPlayerStatus(pctx, &matchmakingpb.PlayerStatusRequest{
+		PlayerId:    p.playerID,
+		Namespace:   namespace,
+		Clock:       localClock.ToProto(),
+		Consistency: statusConsistency,
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			p.pollErr = err
+		}
+		return
+	}
+	localClock.Merge(clocks.FromProto(res.GetVectorClock()))
+	cachedStatus.put(res, time.Now())
+	p.pollErr = nil
+	if res.GetStatus() == "RETRY_LATER" {
+		return // se conserva el último estado hasta que el Matchmaker se ponga al día
+	}
+	p.status, p.polledAt = res, time.Now()
+}
+
+// addLine guarda una línea del log para el panel.
+func (p *panel) addLine(l slog.Line) {
+	text := l.Message
+	if l.Level != slog.InfoLevel {
+		text = l.Level.String() + " " + text
+	}
+	p.lines = append(p.lines, l.Time.Format("15:04:05")+" "+text)
+	if len(p.lines) > tuiLogLines {
+		p.lines = p.lines[len(p.lines)-tuiLogLines:]
+	}
+}
+
+// draw redibuja la pantalla completa.
+func (p *panel) draw() {
+	var sb strings.Builder
+	sb.WriteString("\033[H\033[2J")
+	fmt.Fprintf(&sb, "═════════ Jugador %s • namespace %s ═════════\n\n", p.playerID, namespace)
+
+	st := p.status
+	state, queue, match, server := "(consultando…)", "—", "—", "—"
+	if st != nil {
+		state = st.GetStatus()
+		if mode := st.GetGameMode(); mode != "" {
+			state += " (" + mode + ")"
+		}
+	}
+	switch st.GetStatus() {
+	case "IN_QUEUE":
+		// la espera avanza entre consulta y consulta
+		wait := time.Duration(st.GetWaitMs())*time.Millisecond + time.Since(p.polledAt)
+		queue = "esperando " + wait.Round(time.Second).String()
+		if pos := st.GetQueuePosition(); pos > 0 {
+			queue = fmt.Sprintf("puesto %d de su modo • %s", pos, queue)
+		}
+	case "READY_CHECK":
+		match = st.GetMatchId() + " • confírmala con a (aceptar) o r (rechazar)"
+	case "IN_MATCH":
+		match = st.GetMatchId()
+		server = st.GetServerAddr()
+		// con puertos dedicados, la partida tiene su propia dirección
+		if addr := st.GetMatchAddr(); addr != "" && addr != server {
+			server += " • conectar a " + addr
+		}
+	}
+	fmt.Fprintf(&sb, "  Estado:    %s\n", state)
+	fmt.Fprintf(&sb, "  Cola:      %s\n", queue)
+	fmt.Fprintf(&sb, "  Partida:   %s\n", match)
+	fmt.Fprintf(&sb, "  Servidor:  %s\n", server)
+	if p.pollErr != nil {
+		fmt.Fprintf(&sb, "  ⚠️  Sin respuesta del Matchmaker: %v\n", p.pollErr)
+	}
+
+	sb.WriteString("\n───────── Últimos eventos ─────────\n")
+	for _, l := range p.lines {
+		fmt.Fprintf(&sb, "  %s\n", l)
+	}
+	for i := len(p.lines); i < tuiLogLines; i++ {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("───────────────────────────────────\n")
+	fmt.Fprintf(&sb, "[q] encolarse (%s)  [m] cambiar modo  [c] salir de la cola  [a/r] aceptar/rechazar\n", p.mode)
+	sb.WriteString("[l] abandonar la partida  [v] revancha  [o] menú completo  [x] salir\n")
+	fmt.Print(sb.String())
+}
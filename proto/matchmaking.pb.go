@@ -9,7 +9,6 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,131 +22,10568 @@ const (
 )
 
 // ─────────────── ENUMS ───────────────
-type PlayerState int32
+// Estado de un GameServer tal como lo ven el propio servidor, el Matchmaker
+// y el cliente administrador.
+type ServerStatus int32
 
 const (
-	PlayerState_PLAYER_STATE_IDLE     PlayerState = 0
-	PlayerState_PLAYER_STATE_IN_QUEUE PlayerState = 1
-	PlayerState_PLAYER_STATE_IN_MATCH PlayerState = 2
+	ServerStatus_UNKNOWN    ServerStatus = 0
+	ServerStatus_DISPONIBLE ServerStatus = 1
+	ServerStatus_OCUPADO    ServerStatus = 2
+	ServerStatus_CAIDO      ServerStatus = 3
 )
 
-// Enum value maps for PlayerState.
+// Enum value maps for ServerStatus.
 var (
-	PlayerState_name = map[int32]string{
-		0: "PLAYER_STATE_IDLE",
-		1: "PLAYER_STATE_IN_QUEUE",
-		2: "PLAYER_STATE_IN_MATCH",
+	ServerStatus_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "DISPONIBLE",
+		2: "OCUPADO",
+		3: "CAIDO",
 	}
-	PlayerState_value = map[string]int32{
-		"PLAYER_STATE_IDLE":     0,
-		"PLAYER_STATE_IN_QUEUE": 1,
-		"PLAYER_STATE_IN_MATCH": 2,
+	ServerStatus_value = map[string]int32{
+		"UNKNOWN":    0,
+		"DISPONIBLE": 1,
+		"OCUPADO":    2,
+		"CAIDO":      3,
 	}
 )
 
-func (x PlayerState) Enum() *PlayerState {
-	p := new(PlayerState)
+func (x ServerStatus) Enum() *ServerStatus {
+	p := new(ServerStatus)
 	*p = x
 	return p
 }
 
-func (x PlayerState) String() string {
+func (x ServerStatus) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (PlayerState) Descriptor() protoreflect.EnumDescriptor {
+func (ServerStatus) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_matchmaking_proto_enumTypes[0].Descriptor()
 }
 
-func (PlayerState) Type() protoreflect.EnumType {
+func (ServerStatus) Type() protoreflect.EnumType {
 	return &file_proto_matchmaking_proto_enumTypes[0]
 }
 
-func (x PlayerState) Number() protoreflect.EnumNumber {
+func (x ServerStatus) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use PlayerState.Descriptor instead.
-func (PlayerState) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use ServerStatus.Descriptor instead.
+func (ServerStatus) EnumDescriptor() ([]byte, []int) {
 	return file_proto_matchmaking_proto_rawDescGZIP(), []int{0}
 }
 
-type ServerState int32
+// Prioridad en la cola: el emparejamiento toma primero los niveles más
+// altos y, dentro de un nivel, respeta el orden de llegada.
+type QueuePriority int32
 
 const (
-	ServerState_SERVER_STATE_UNKNOWN   ServerState = 0
-	ServerState_SERVER_STATE_AVAILABLE ServerState = 1
-	ServerState_SERVER_STATE_BUSY      ServerState = 2
-	ServerState_SERVER_STATE_DOWN      ServerState = 3
+	QueuePriority_PRIORITY_NORMAL   QueuePriority = 0
+	QueuePriority_PRIORITY_PREMIUM  QueuePriority = 1
+	QueuePriority_PRIORITY_REQUEUED QueuePriority = 2 // sólo la asigna el Matchmaker tras un fallo de asignación
 )
 
-// Enum value maps for ServerState.
+// Enum value maps for QueuePriority.
 var (
-	ServerState_name = map[int32]string{
-		0: "SERVER_STATE_UNKNOWN",
-		1: "SERVER_STATE_AVAILABLE",
-		2: "SERVER_STATE_BUSY",
-		3: "SERVER_STATE_DOWN",
+	QueuePriority_name = map[int32]string{
+		0: "PRIORITY_NORMAL",
+		1: "PRIORITY_PREMIUM",
+		2: "PRIORITY_REQUEUED",
 	}
-	ServerState_value = map[string]int32{
-		"SERVER_STATE_UNKNOWN":   0,
-		"SERVER_STATE_AVAILABLE": 1,
-		"SERVER_STATE_BUSY":      2,
-		"SERVER_STATE_DOWN":      3,
+	QueuePriority_value = map[string]int32{
+		"PRIORITY_NORMAL":   0,
+		"PRIORITY_PREMIUM":  1,
+		"PRIORITY_REQUEUED": 2,
 	}
 )
 
-func (x ServerState) Enum() *ServerState {
-	p := new(ServerState)
+func (x QueuePriority) Enum() *QueuePriority {
+	p := new(QueuePriority)
 	*p = x
 	return p
 }
 
-func (x ServerState) String() string {
+func (x QueuePriority) String() string {
 	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (ServerState) Descriptor() protoreflect.EnumDescriptor {
+func (QueuePriority) Descriptor() protoreflect.EnumDescriptor {
 	return file_proto_matchmaking_proto_enumTypes[1].Descriptor()
 }
 
-func (ServerState) Type() protoreflect.EnumType {
+func (QueuePriority) Type() protoreflect.EnumType {
 	return &file_proto_matchmaking_proto_enumTypes[1]
 }
 
-func (x ServerState) Number() protoreflect.EnumNumber {
+func (x QueuePriority) Number() protoreflect.EnumNumber {
 	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use ServerState.Descriptor instead.
-func (ServerState) EnumDescriptor() ([]byte, []int) {
+// Deprecated: Use QueuePriority.Descriptor instead.
+func (QueuePriority) EnumDescriptor() ([]byte, []int) {
 	return file_proto_matchmaking_proto_rawDescGZIP(), []int{1}
 }
 
+// Nivel de un jugador, asignado por el administrador. Define su peso en la
+// cola (ver QUEUE_TIER_WEIGHTS en el Matchmaker).
+type PlayerTier int32
+
+const (
+	PlayerTier_TIER_NORMAL PlayerTier = 0
+	PlayerTier_TIER_VIP    PlayerTier = 1
+	PlayerTier_TIER_TESTER PlayerTier = 2
+)
+
+// Enum value maps for PlayerTier.
+var (
+	PlayerTier_name = map[int32]string{
+		0: "TIER_NORMAL",
+		1: "TIER_VIP",
+		2: "TIER_TESTER",
+	}
+	PlayerTier_value = map[string]int32{
+		"TIER_NORMAL": 0,
+		"TIER_VIP":    1,
+		"TIER_TESTER": 2,
+	}
+)
+
+func (x PlayerTier) Enum() *PlayerTier {
+	p := new(PlayerTier)
+	*p = x
+	return p
+}
+
+func (x PlayerTier) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlayerTier) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[2].Descriptor()
+}
+
+func (PlayerTier) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[2]
+}
+
+func (x PlayerTier) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlayerTier.Descriptor instead.
+func (PlayerTier) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{2}
+}
+
+// Qué hacer con un jugador cuando la asignación de su partida falla en
+// todos los servidores. Se elige al encolarse y vale para todo el grupo.
+type AssignFailureAction int32
+
+const (
+	AssignFailureAction_FAILURE_REQUEUE AssignFailureAction = 0 // vuelve solo al frente de la cola (por defecto)
+	AssignFailureAction_FAILURE_ASK     AssignFailureAction = 1 // queda IDLE; con QueuePlayer antes del plazo recupera su lugar
+	AssignFailureAction_FAILURE_IDLE    AssignFailureAction = 2 // queda IDLE
+)
+
+// Enum value maps for AssignFailureAction.
+var (
+	AssignFailureAction_name = map[int32]string{
+		0: "FAILURE_REQUEUE",
+		1: "FAILURE_ASK",
+		2: "FAILURE_IDLE",
+	}
+	AssignFailureAction_value = map[string]int32{
+		"FAILURE_REQUEUE": 0,
+		"FAILURE_ASK":     1,
+		"FAILURE_IDLE":    2,
+	}
+)
+
+func (x AssignFailureAction) Enum() *AssignFailureAction {
+	p := new(AssignFailureAction)
+	*p = x
+	return p
+}
+
+func (x AssignFailureAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignFailureAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[3].Descriptor()
+}
+
+func (AssignFailureAction) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[3]
+}
+
+func (x AssignFailureAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignFailureAction.Descriptor instead.
+func (AssignFailureAction) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{3}
+}
+
+// Consistencia de una lectura de GetPlayerStatus.
+type Consistency int32
+
+const (
+	Consistency_CONSISTENCY_DEFAULT          Consistency = 0 // como READ_YOUR_WRITES
+	Consistency_CONSISTENCY_EVENTUAL         Consistency = 1 // el estado local, sin esperar
+	Consistency_CONSISTENCY_READ_YOUR_WRITES Consistency = 2 // refleja todo lo que el reloj del cliente ya vio
+	Consistency_CONSISTENCY_STRONG           Consistency = 3 // además, sin AssignMatch en curso para su partida
+)
+
+// Enum value maps for Consistency.
+var (
+	Consistency_name = map[int32]string{
+		0: "CONSISTENCY_DEFAULT",
+		1: "CONSISTENCY_EVENTUAL",
+		2: "CONSISTENCY_READ_YOUR_WRITES",
+		3: "CONSISTENCY_STRONG",
+	}
+	Consistency_value = map[string]int32{
+		"CONSISTENCY_DEFAULT":          0,
+		"CONSISTENCY_EVENTUAL":         1,
+		"CONSISTENCY_READ_YOUR_WRITES": 2,
+		"CONSISTENCY_STRONG":           3,
+	}
+)
+
+func (x Consistency) Enum() *Consistency {
+	p := new(Consistency)
+	*p = x
+	return p
+}
+
+func (x Consistency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Consistency) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[4].Descriptor()
+}
+
+func (Consistency) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[4]
+}
+
+func (x Consistency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Consistency.Descriptor instead.
+func (Consistency) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{4}
+}
+
+// Fase de una partida en el Matchmaker. Avanza en este orden; ABORTED
+// se alcanza desde cualquier fase no terminal y ASSIGNED puede pasar
+// directo a COMPLETED si el resultado llega antes del aviso de inicio.
+type MatchPhase int32
+
+const (
+	MatchPhase_MATCH_PHASE_CREATED     MatchPhase = 0 // formada; en ready-check o antes del AssignMatch
+	MatchPhase_MATCH_PHASE_ASSIGNED    MatchPhase = 1 // AssignMatch enviado al GameServer
+	MatchPhase_MATCH_PHASE_IN_PROGRESS MatchPhase = 2 // el GameServer la aceptó o la informó en curso
+	MatchPhase_MATCH_PHASE_COMPLETED   MatchPhase = 3 // resultado registrado
+	MatchPhase_MATCH_PHASE_ABORTED     MatchPhase = 4 // cerrada sin resultado
+)
+
+// Enum value maps for MatchPhase.
+var (
+	MatchPhase_name = map[int32]string{
+		0: "MATCH_PHASE_CREATED",
+		1: "MATCH_PHASE_ASSIGNED",
+		2: "MATCH_PHASE_IN_PROGRESS",
+		3: "MATCH_PHASE_COMPLETED",
+		4: "MATCH_PHASE_ABORTED",
+	}
+	MatchPhase_value = map[string]int32{
+		"MATCH_PHASE_CREATED":     0,
+		"MATCH_PHASE_ASSIGNED":    1,
+		"MATCH_PHASE_IN_PROGRESS": 2,
+		"MATCH_PHASE_COMPLETED":   3,
+		"MATCH_PHASE_ABORTED":     4,
+	}
+)
+
+func (x MatchPhase) Enum() *MatchPhase {
+	p := new(MatchPhase)
+	*p = x
+	return p
+}
+
+func (x MatchPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[5].Descriptor()
+}
+
+func (MatchPhase) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[5]
+}
+
+func (x MatchPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchPhase.Descriptor instead.
+func (MatchPhase) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{5}
+}
+
+// Simulación en el GameServer: cada event_interval_ms (0 = 1000) hay una
+// eliminación con probabilidad score_prob (0 = 0.6), que suma kill_points
+// (0 = 100) al que elimina y resta death_points (0 = 25) al eliminado.
+// scoring decide el ganador.
+type GameMode_Scoring int32
+
+const (
+	GameMode_DEATHMATCH      GameMode_Scoring = 0 // gana el jugador de mayor puntaje
+	GameMode_TEAM_DEATHMATCH GameMode_Scoring = 1 // gana el equipo con más eliminaciones
+)
+
+// Enum value maps for GameMode_Scoring.
+var (
+	GameMode_Scoring_name = map[int32]string{
+		0: "DEATHMATCH",
+		1: "TEAM_DEATHMATCH",
+	}
+	GameMode_Scoring_value = map[string]int32{
+		"DEATHMATCH":      0,
+		"TEAM_DEATHMATCH": 1,
+	}
+)
+
+func (x GameMode_Scoring) Enum() *GameMode_Scoring {
+	p := new(GameMode_Scoring)
+	*p = x
+	return p
+}
+
+func (x GameMode_Scoring) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameMode_Scoring) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[6].Descriptor()
+}
+
+func (GameMode_Scoring) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[6]
+}
+
+func (x GameMode_Scoring) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameMode_Scoring.Descriptor instead.
+func (GameMode_Scoring) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type QueuePlayerResponse_StatusCode int32
+
+const (
+	QueuePlayerResponse_OK               QueuePlayerResponse_StatusCode = 0
+	QueuePlayerResponse_ALREADY_IN_QUEUE QueuePlayerResponse_StatusCode = 1
+	QueuePlayerResponse_IN_MATCH         QueuePlayerResponse_StatusCode = 2
+	QueuePlayerResponse_UNKNOWN_MODE     QueuePlayerResponse_StatusCode = 3  // modo inexistente o deshabilitado
+	QueuePlayerResponse_NOT_PARTY_LEADER QueuePlayerResponse_StatusCode = 4  // sólo el líder encola a su grupo
+	QueuePlayerResponse_PARTY_TOO_LARGE  QueuePlayerResponse_StatusCode = 5  // el grupo no cabe en un equipo del modo
+	QueuePlayerResponse_PARTY_NOT_READY  QueuePlayerResponse_StatusCode = 6  // algún integrante está en cola o en partida
+	QueuePlayerResponse_BANNED           QueuePlayerResponse_StatusCode = 7  // el jugador (o alguien de su grupo) está baneado
+	QueuePlayerResponse_COOLDOWN         QueuePlayerResponse_StatusCode = 8  // el jugador (o alguien de su grupo) acaba de jugar
+	QueuePlayerResponse_WRONG_SHARD      QueuePlayerResponse_StatusCode = 9  // el modo lo atiende otro Matchmaker (MATCHMAKER_MODES)
+	QueuePlayerResponse_IN_LOBBY         QueuePlayerResponse_StatusCode = 10 // el jugador (o alguien de su grupo) está en una sala privada
+	QueuePlayerResponse_SESSION_CONFLICT QueuePlayerResponse_StatusCode = 11 // otra sesión viva usa este player_id (SESSION_CONFLICT) o esta fue reemplazada
+)
+
+// Enum value maps for QueuePlayerResponse_StatusCode.
+var (
+	QueuePlayerResponse_StatusCode_name = map[int32]string{
+		0:  "OK",
+		1:  "ALREADY_IN_QUEUE",
+		2:  "IN_MATCH",
+		3:  "UNKNOWN_MODE",
+		4:  "NOT_PARTY_LEADER",
+		5:  "PARTY_TOO_LARGE",
+		6:  "PARTY_NOT_READY",
+		7:  "BANNED",
+		8:  "COOLDOWN",
+		9:  "WRONG_SHARD",
+		10: "IN_LOBBY",
+		11: "SESSION_CONFLICT",
+	}
+	QueuePlayerResponse_StatusCode_value = map[string]int32{
+		"OK":               0,
+		"ALREADY_IN_QUEUE": 1,
+		"IN_MATCH":         2,
+		"UNKNOWN_MODE":     3,
+		"NOT_PARTY_LEADER": 4,
+		"PARTY_TOO_LARGE":  5,
+		"PARTY_NOT_READY":  6,
+		"BANNED":           7,
+		"COOLDOWN":         8,
+		"WRONG_SHARD":      9,
+		"IN_LOBBY":         10,
+		"SESSION_CONFLICT": 11,
+	}
+)
+
+func (x QueuePlayerResponse_StatusCode) Enum() *QueuePlayerResponse_StatusCode {
+	p := new(QueuePlayerResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x QueuePlayerResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QueuePlayerResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[7].Descriptor()
+}
+
+func (QueuePlayerResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[7]
+}
+
+func (x QueuePlayerResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QueuePlayerResponse_StatusCode.Descriptor instead.
+func (QueuePlayerResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type CancelQueueResponse_StatusCode int32
+
+const (
+	CancelQueueResponse_OK           CancelQueueResponse_StatusCode = 0
+	CancelQueueResponse_NOT_IN_QUEUE CancelQueueResponse_StatusCode = 1
+)
+
+// Enum value maps for CancelQueueResponse_StatusCode.
+var (
+	CancelQueueResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "NOT_IN_QUEUE",
+	}
+	CancelQueueResponse_StatusCode_value = map[string]int32{
+		"OK":           0,
+		"NOT_IN_QUEUE": 1,
+	}
+)
+
+func (x CancelQueueResponse_StatusCode) Enum() *CancelQueueResponse_StatusCode {
+	p := new(CancelQueueResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x CancelQueueResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancelQueueResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[8].Descriptor()
+}
+
+func (CancelQueueResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[8]
+}
+
+func (x CancelQueueResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancelQueueResponse_StatusCode.Descriptor instead.
+func (CancelQueueResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type LeaveMatchResponse_StatusCode int32
+
+const (
+	LeaveMatchResponse_OK           LeaveMatchResponse_StatusCode = 0
+	LeaveMatchResponse_NOT_IN_MATCH LeaveMatchResponse_StatusCode = 1 // no está en partida (o ya terminó)
+)
+
+// Enum value maps for LeaveMatchResponse_StatusCode.
+var (
+	LeaveMatchResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "NOT_IN_MATCH",
+	}
+	LeaveMatchResponse_StatusCode_value = map[string]int32{
+		"OK":           0,
+		"NOT_IN_MATCH": 1,
+	}
+)
+
+func (x LeaveMatchResponse_StatusCode) Enum() *LeaveMatchResponse_StatusCode {
+	p := new(LeaveMatchResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x LeaveMatchResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaveMatchResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[9].Descriptor()
+}
+
+func (LeaveMatchResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[9]
+}
+
+func (x LeaveMatchResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaveMatchResponse_StatusCode.Descriptor instead.
+func (LeaveMatchResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type RegisterPlayerResponse_StatusCode int32
+
+const (
+	RegisterPlayerResponse_OK                 RegisterPlayerResponse_StatusCode = 0
+	RegisterPlayerResponse_ALREADY_REGISTERED RegisterPlayerResponse_StatusCode = 1 // el ID ya es de otro cliente (sin token válido)
+	RegisterPlayerResponse_AUTH_DISABLED      RegisterPlayerResponse_StatusCode = 2 // el Matchmaker no exige tokens
+	RegisterPlayerResponse_INVALID_PLAYER     RegisterPlayerResponse_StatusCode = 3
+)
+
+// Enum value maps for RegisterPlayerResponse_StatusCode.
+var (
+	RegisterPlayerResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "ALREADY_REGISTERED",
+		2: "AUTH_DISABLED",
+		3: "INVALID_PLAYER",
+	}
+	RegisterPlayerResponse_StatusCode_value = map[string]int32{
+		"OK":                 0,
+		"ALREADY_REGISTERED": 1,
+		"AUTH_DISABLED":      2,
+		"INVALID_PLAYER":     3,
+	}
+)
+
+func (x RegisterPlayerResponse_StatusCode) Enum() *RegisterPlayerResponse_StatusCode {
+	p := new(RegisterPlayerResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x RegisterPlayerResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegisterPlayerResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[10].Descriptor()
+}
+
+func (RegisterPlayerResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[10]
+}
+
+func (x RegisterPlayerResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegisterPlayerResponse_StatusCode.Descriptor instead.
+func (RegisterPlayerResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{17, 0}
+}
+
+type MatchUpdate_Event int32
+
+const (
+	MatchUpdate_MATCH_FOUND        MatchUpdate_Event = 0
+	MatchUpdate_MATCH_FINISHED     MatchUpdate_Event = 1
+	MatchUpdate_MATCH_ABORTED      MatchUpdate_Event = 2  // servidor caído o partida expirada
+	MatchUpdate_REMOVED            MatchUpdate_Event = 3  // el administrador sacó al jugador de la cola o la partida
+	MatchUpdate_PLAYER_LEFT        MatchUpdate_Event = 4  // otro jugador abandonó la partida
+	MatchUpdate_QUEUE_TIMED_OUT    MatchUpdate_Event = 5  // superó QUEUE_TIMEOUT en la cola y salió de ella
+	MatchUpdate_READY_CHECK        MatchUpdate_Event = 6  // partida formada: confirmar con AcceptMatch antes del plazo
+	MatchUpdate_READY_CHECK_FAILED MatchUpdate_Event = 7  // alguien rechazó o no respondió; la partida no se juega
+	MatchUpdate_HEARTBEAT_LOST     MatchUpdate_Event = 8  // el cliente dejó de latir y salió de la cola
+	MatchUpdate_ASSIGN_FAILED      MatchUpdate_Event = 9  // ningún servidor aceptó la partida (ver on_assign_failure)
+	MatchUpdate_REMATCH_OFFERED    MatchUpdate_Event = 10 // otro jugador de la partida terminada pidió la revancha
+	MatchUpdate_REMATCH_CANCELLED  MatchUpdate_Event = 11 // la revancha venció o alguien dejó de estar disponible
+)
+
+// Enum value maps for MatchUpdate_Event.
+var (
+	MatchUpdate_Event_name = map[int32]string{
+		0:  "MATCH_FOUND",
+		1:  "MATCH_FINISHED",
+		2:  "MATCH_ABORTED",
+		3:  "REMOVED",
+		4:  "PLAYER_LEFT",
+		5:  "QUEUE_TIMED_OUT",
+		6:  "READY_CHECK",
+		7:  "READY_CHECK_FAILED",
+		8:  "HEARTBEAT_LOST",
+		9:  "ASSIGN_FAILED",
+		10: "REMATCH_OFFERED",
+		11: "REMATCH_CANCELLED",
+	}
+	MatchUpdate_Event_value = map[string]int32{
+		"MATCH_FOUND":        0,
+		"MATCH_FINISHED":     1,
+		"MATCH_ABORTED":      2,
+		"REMOVED":            3,
+		"PLAYER_LEFT":        4,
+		"QUEUE_TIMED_OUT":    5,
+		"READY_CHECK":        6,
+		"READY_CHECK_FAILED": 7,
+		"HEARTBEAT_LOST":     8,
+		"ASSIGN_FAILED":      9,
+		"REMATCH_OFFERED":    10,
+		"REMATCH_CANCELLED":  11,
+	}
+)
+
+func (x MatchUpdate_Event) Enum() *MatchUpdate_Event {
+	p := new(MatchUpdate_Event)
+	*p = x
+	return p
+}
+
+func (x MatchUpdate_Event) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchUpdate_Event) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[11].Descriptor()
+}
+
+func (MatchUpdate_Event) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[11]
+}
+
+func (x MatchUpdate_Event) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchUpdate_Event.Descriptor instead.
+func (MatchUpdate_Event) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{21, 0}
+}
+
+type AcceptMatchResponse_StatusCode int32
+
+const (
+	AcceptMatchResponse_OK          AcceptMatchResponse_StatusCode = 0
+	AcceptMatchResponse_NOT_PENDING AcceptMatchResponse_StatusCode = 1 // la partida no espera la confirmación de este jugador
+)
+
+// Enum value maps for AcceptMatchResponse_StatusCode.
+var (
+	AcceptMatchResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "NOT_PENDING",
+	}
+	AcceptMatchResponse_StatusCode_value = map[string]int32{
+		"OK":          0,
+		"NOT_PENDING": 1,
+	}
+)
+
+func (x AcceptMatchResponse_StatusCode) Enum() *AcceptMatchResponse_StatusCode {
+	p := new(AcceptMatchResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x AcceptMatchResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AcceptMatchResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[12].Descriptor()
+}
+
+func (AcceptMatchResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[12]
+}
+
+func (x AcceptMatchResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AcceptMatchResponse_StatusCode.Descriptor instead.
+func (AcceptMatchResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{23, 0}
+}
+
+type RematchResponse_StatusCode int32
+
+const (
+	RematchResponse_OK           RematchResponse_StatusCode = 0
+	RematchResponse_NO_OFFER     RematchResponse_StatusCode = 1 // partida desconocida, sin revancha o con el plazo vencido
+	RematchResponse_NOT_A_PLAYER RematchResponse_StatusCode = 2 // el jugador no estuvo en esa partida
+	RematchResponse_PLAYER_BUSY  RematchResponse_StatusCode = 3 // en cola, en otra partida, en una sala o baneado
+)
+
+// Enum value maps for RematchResponse_StatusCode.
+var (
+	RematchResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "NO_OFFER",
+		2: "NOT_A_PLAYER",
+		3: "PLAYER_BUSY",
+	}
+	RematchResponse_StatusCode_value = map[string]int32{
+		"OK":           0,
+		"NO_OFFER":     1,
+		"NOT_A_PLAYER": 2,
+		"PLAYER_BUSY":  3,
+	}
+)
+
+func (x RematchResponse_StatusCode) Enum() *RematchResponse_StatusCode {
+	p := new(RematchResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x RematchResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RematchResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[13].Descriptor()
+}
+
+func (RematchResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[13]
+}
+
+func (x RematchResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RematchResponse_StatusCode.Descriptor instead.
+func (RematchResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{25, 0}
+}
+
+type AssignMatchResponse_StatusCode int32
+
+const (
+	AssignMatchResponse_OK   AssignMatchResponse_StatusCode = 0
+	AssignMatchResponse_BUSY AssignMatchResponse_StatusCode = 1
+)
+
+// Enum value maps for AssignMatchResponse_StatusCode.
+var (
+	AssignMatchResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "BUSY",
+	}
+	AssignMatchResponse_StatusCode_value = map[string]int32{
+		"OK":   0,
+		"BUSY": 1,
+	}
+)
+
+func (x AssignMatchResponse_StatusCode) Enum() *AssignMatchResponse_StatusCode {
+	p := new(AssignMatchResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x AssignMatchResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignMatchResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[14].Descriptor()
+}
+
+func (AssignMatchResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[14]
+}
+
+func (x AssignMatchResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignMatchResponse_StatusCode.Descriptor instead.
+func (AssignMatchResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{27, 0}
+}
+
+type CancelMatchResponse_StatusCode int32
+
+const (
+	CancelMatchResponse_OK            CancelMatchResponse_StatusCode = 0
+	CancelMatchResponse_UNKNOWN_MATCH CancelMatchResponse_StatusCode = 1 // ya terminó o nunca la recibió
+)
+
+// Enum value maps for CancelMatchResponse_StatusCode.
+var (
+	CancelMatchResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "UNKNOWN_MATCH",
+	}
+	CancelMatchResponse_StatusCode_value = map[string]int32{
+		"OK":            0,
+		"UNKNOWN_MATCH": 1,
+	}
+)
+
+func (x CancelMatchResponse_StatusCode) Enum() *CancelMatchResponse_StatusCode {
+	p := new(CancelMatchResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x CancelMatchResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CancelMatchResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[15].Descriptor()
+}
+
+func (CancelMatchResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[15]
+}
+
+func (x CancelMatchResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CancelMatchResponse_StatusCode.Descriptor instead.
+func (CancelMatchResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{29, 0}
+}
+
+type SpectateEvent_Kind int32
+
+const (
+	SpectateEvent_SNAPSHOT SpectateEvent_Kind = 0 // primer evento: marcador completo al conectarse
+	SpectateEvent_SCORE    SpectateEvent_Kind = 1 // player_id eliminó a victim_id
+	SpectateEvent_TICK     SpectateEvent_Kind = 2 // sólo actualiza el tiempo restante
+	SpectateEvent_ENDED    SpectateEvent_Kind = 3 // la partida terminó o fue abortada; cierra el stream
+)
+
+// Enum value maps for SpectateEvent_Kind.
+var (
+	SpectateEvent_Kind_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "SCORE",
+		2: "TICK",
+		3: "ENDED",
+	}
+	SpectateEvent_Kind_value = map[string]int32{
+		"SNAPSHOT": 0,
+		"SCORE":    1,
+		"TICK":     2,
+		"ENDED":    3,
+	}
+)
+
+func (x SpectateEvent_Kind) Enum() *SpectateEvent_Kind {
+	p := new(SpectateEvent_Kind)
+	*p = x
+	return p
+}
+
+func (x SpectateEvent_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SpectateEvent_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[16].Descriptor()
+}
+
+func (SpectateEvent_Kind) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[16]
+}
+
+func (x SpectateEvent_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SpectateEvent_Kind.Descriptor instead.
+func (SpectateEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{31, 0}
+}
+
+type ServerStatusUpdateResponse_StatusCode int32
+
+const (
+	ServerStatusUpdateResponse_OK              ServerStatusUpdateResponse_StatusCode = 0
+	ServerStatusUpdateResponse_DRAINED         ServerStatusUpdateResponse_StatusCode = 1 // el servidor fue drenado y retirado del pool: puede apagarse
+	ServerStatusUpdateResponse_INVALID_ADDRESS ServerStatusUpdateResponse_StatusCode = 2 // la dirección anunciada no es alcanzable; no se registró
+	ServerStatusUpdateResponse_WRONG_NAMESPACE ServerStatusUpdateResponse_StatusCode = 3 // la dirección ya atiende a otro namespace; no se registró
+)
+
+// Enum value maps for ServerStatusUpdateResponse_StatusCode.
+var (
+	ServerStatusUpdateResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "DRAINED",
+		2: "INVALID_ADDRESS",
+		3: "WRONG_NAMESPACE",
+	}
+	ServerStatusUpdateResponse_StatusCode_value = map[string]int32{
+		"OK":              0,
+		"DRAINED":         1,
+		"INVALID_ADDRESS": 2,
+		"WRONG_NAMESPACE": 3,
+	}
+)
+
+func (x ServerStatusUpdateResponse_StatusCode) Enum() *ServerStatusUpdateResponse_StatusCode {
+	p := new(ServerStatusUpdateResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x ServerStatusUpdateResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerStatusUpdateResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[17].Descriptor()
+}
+
+func (ServerStatusUpdateResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[17]
+}
+
+func (x ServerStatusUpdateResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerStatusUpdateResponse_StatusCode.Descriptor instead.
+func (ServerStatusUpdateResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{37, 0}
+}
+
+type ServerControl_Command int32
+
+const (
+	ServerControl_ACK         ServerControl_Command = 0 // respuesta a un latido, en ack
+	ServerControl_DRAIN       ServerControl_Command = 1 // el administrador drenó el servidor
+	ServerControl_ABORT_MATCH ServerControl_Command = 2 // la partida match_id se cerró: dejar de jugarla
+	ServerControl_BACKFILL    ServerControl_Command = 3 // se suman player_ids a la partida match_id
+	ServerControl_CHAOS       ServerControl_Command = 4 // nueva configuración de fallos inyectados, en chaos
+)
+
+// Enum value maps for ServerControl_Command.
+var (
+	ServerControl_Command_name = map[int32]string{
+		0: "ACK",
+		1: "DRAIN",
+		2: "ABORT_MATCH",
+		3: "BACKFILL",
+		4: "CHAOS",
+	}
+	ServerControl_Command_value = map[string]int32{
+		"ACK":         0,
+		"DRAIN":       1,
+		"ABORT_MATCH": 2,
+		"BACKFILL":    3,
+		"CHAOS":       4,
+	}
+)
+
+func (x ServerControl_Command) Enum() *ServerControl_Command {
+	p := new(ServerControl_Command)
+	*p = x
+	return p
+}
+
+func (x ServerControl_Command) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ServerControl_Command) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[18].Descriptor()
+}
+
+func (ServerControl_Command) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[18]
+}
+
+func (x ServerControl_Command) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ServerControl_Command.Descriptor instead.
+func (ServerControl_Command) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{38, 0}
+}
+
+type MatchResultResponse_StatusCode int32
+
+const (
+	MatchResultResponse_OK            MatchResultResponse_StatusCode = 0
+	MatchResultResponse_UNKNOWN_MATCH MatchResultResponse_StatusCode = 1
+	MatchResultResponse_NOT_ASSIGNED  MatchResultResponse_StatusCode = 2 // la partida aún no se envió a ningún servidor
+	MatchResultResponse_NOT_OWNER     MatchResultResponse_StatusCode = 3 // asignada a otro servidor
+)
+
+// Enum value maps for MatchResultResponse_StatusCode.
+var (
+	MatchResultResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "UNKNOWN_MATCH",
+		2: "NOT_ASSIGNED",
+		3: "NOT_OWNER",
+	}
+	MatchResultResponse_StatusCode_value = map[string]int32{
+		"OK":            0,
+		"UNKNOWN_MATCH": 1,
+		"NOT_ASSIGNED":  2,
+		"NOT_OWNER":     3,
+	}
+)
+
+func (x MatchResultResponse_StatusCode) Enum() *MatchResultResponse_StatusCode {
+	p := new(MatchResultResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x MatchResultResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchResultResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[19].Descriptor()
+}
+
+func (MatchResultResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[19]
+}
+
+func (x MatchResultResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchResultResponse_StatusCode.Descriptor instead.
+func (MatchResultResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{42, 0}
+}
+
+type MatchRecord_Outcome int32
+
+const (
+	MatchRecord_COMPLETED    MatchRecord_Outcome = 0 // el GameServer informó el resultado
+	MatchRecord_SERVER_DOWN  MatchRecord_Outcome = 1 // el servidor cayó a mitad de partida
+	MatchRecord_TIMEOUT      MatchRecord_Outcome = 2 // superó MATCH_TIMEOUT sin resultado
+	MatchRecord_ABORTED      MatchRecord_Outcome = 3 // el GameServer la abortó al reiniciarse
+	MatchRecord_ABANDONED    MatchRecord_Outcome = 4 // un jugador la abandonó (LeaveMatch)
+	MatchRecord_DEREGISTERED MatchRecord_Outcome = 5 // el servidor se dio de baja (DeregisterServer)
+	MatchRecord_TERMINATED   MatchRecord_Outcome = 6 // el administrador la terminó (AdminTerminateMatch)
+)
+
+// Enum value maps for MatchRecord_Outcome.
+var (
+	MatchRecord_Outcome_name = map[int32]string{
+		0: "COMPLETED",
+		1: "SERVER_DOWN",
+		2: "TIMEOUT",
+		3: "ABORTED",
+		4: "ABANDONED",
+		5: "DEREGISTERED",
+		6: "TERMINATED",
+	}
+	MatchRecord_Outcome_value = map[string]int32{
+		"COMPLETED":    0,
+		"SERVER_DOWN":  1,
+		"TIMEOUT":      2,
+		"ABORTED":      3,
+		"ABANDONED":    4,
+		"DEREGISTERED": 5,
+		"TERMINATED":   6,
+	}
+)
+
+func (x MatchRecord_Outcome) Enum() *MatchRecord_Outcome {
+	p := new(MatchRecord_Outcome)
+	*p = x
+	return p
+}
+
+func (x MatchRecord_Outcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchRecord_Outcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[20].Descriptor()
+}
+
+func (MatchRecord_Outcome) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[20]
+}
+
+func (x MatchRecord_Outcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchRecord_Outcome.Descriptor instead.
+func (MatchRecord_Outcome) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{43, 0}
+}
+
+type MatchEvent_Stage int32
+
+const (
+	MatchEvent_QUEUED     MatchEvent_Stage = 0 // un jugador entró a la cola (uno por jugador)
+	MatchEvent_MATCHED    MatchEvent_Stage = 1 // el Matchmaker formó la partida
+	MatchEvent_ASSIGNED   MatchEvent_Stage = 2 // AssignMatch enviado al GameServer
+	MatchEvent_ACCEPTED   MatchEvent_Stage = 3 // el GameServer respondió OK
+	MatchEvent_STARTED    MatchEvent_Stage = 4 // el GameServer informó la partida en curso
+	MatchEvent_ENDED      MatchEvent_Stage = 5 // resultado informado o cierre sin él (ver detail)
+	MatchEvent_BACKFILLED MatchEvent_Stage = 6 // se sumaron jugadores de la cola (en detail)
+	MatchEvent_READY      MatchEvent_Stage = 7 // todos los jugadores aceptaron el ready-check
+)
+
+// Enum value maps for MatchEvent_Stage.
+var (
+	MatchEvent_Stage_name = map[int32]string{
+		0: "QUEUED",
+		1: "MATCHED",
+		2: "ASSIGNED",
+		3: "ACCEPTED",
+		4: "STARTED",
+		5: "ENDED",
+		6: "BACKFILLED",
+		7: "READY",
+	}
+	MatchEvent_Stage_value = map[string]int32{
+		"QUEUED":     0,
+		"MATCHED":    1,
+		"ASSIGNED":   2,
+		"ACCEPTED":   3,
+		"STARTED":    4,
+		"ENDED":      5,
+		"BACKFILLED": 6,
+		"READY":      7,
+	}
+)
+
+func (x MatchEvent_Stage) Enum() *MatchEvent_Stage {
+	p := new(MatchEvent_Stage)
+	*p = x
+	return p
+}
+
+func (x MatchEvent_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchEvent_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[21].Descriptor()
+}
+
+func (MatchEvent_Stage) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[21]
+}
+
+func (x MatchEvent_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchEvent_Stage.Descriptor instead.
+func (MatchEvent_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{44, 0}
+}
+
+type LeaderboardRequest_SortBy int32
+
+const (
+	LeaderboardRequest_WINS     LeaderboardRequest_SortBy = 0 // victorias; desempata por partidas jugadas
+	LeaderboardRequest_MATCHES  LeaderboardRequest_SortBy = 1
+	LeaderboardRequest_WIN_RATE LeaderboardRequest_SortBy = 2
+	LeaderboardRequest_AVG_WAIT LeaderboardRequest_SortBy = 3 // de menor a mayor espera
+)
+
+// Enum value maps for LeaderboardRequest_SortBy.
+var (
+	LeaderboardRequest_SortBy_name = map[int32]string{
+		0: "WINS",
+		1: "MATCHES",
+		2: "WIN_RATE",
+		3: "AVG_WAIT",
+	}
+	LeaderboardRequest_SortBy_value = map[string]int32{
+		"WINS":     0,
+		"MATCHES":  1,
+		"WIN_RATE": 2,
+		"AVG_WAIT": 3,
+	}
+)
+
+func (x LeaderboardRequest_SortBy) Enum() *LeaderboardRequest_SortBy {
+	p := new(LeaderboardRequest_SortBy)
+	*p = x
+	return p
+}
+
+func (x LeaderboardRequest_SortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LeaderboardRequest_SortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[22].Descriptor()
+}
+
+func (LeaderboardRequest_SortBy) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[22]
+}
+
+func (x LeaderboardRequest_SortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LeaderboardRequest_SortBy.Descriptor instead.
+func (LeaderboardRequest_SortBy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{50, 0}
+}
+
+type AbortMatchResponse_StatusCode int32
+
+const (
+	AbortMatchResponse_OK            AbortMatchResponse_StatusCode = 0
+	AbortMatchResponse_UNKNOWN_MATCH AbortMatchResponse_StatusCode = 1 // ya cerrada o nunca asignada
+	AbortMatchResponse_NOT_OWNER     AbortMatchResponse_StatusCode = 2 // asignada a otro servidor
+)
+
+// Enum value maps for AbortMatchResponse_StatusCode.
+var (
+	AbortMatchResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "UNKNOWN_MATCH",
+		2: "NOT_OWNER",
+	}
+	AbortMatchResponse_StatusCode_value = map[string]int32{
+		"OK":            0,
+		"UNKNOWN_MATCH": 1,
+		"NOT_OWNER":     2,
+	}
+)
+
+func (x AbortMatchResponse_StatusCode) Enum() *AbortMatchResponse_StatusCode {
+	p := new(AbortMatchResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x AbortMatchResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AbortMatchResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[23].Descriptor()
+}
+
+func (AbortMatchResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[23]
+}
+
+func (x AbortMatchResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AbortMatchResponse_StatusCode.Descriptor instead.
+func (AbortMatchResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{53, 0}
+}
+
+type RequestBackfillResponse_StatusCode int32
+
+const (
+	RequestBackfillResponse_OK            RequestBackfillResponse_StatusCode = 0
+	RequestBackfillResponse_UNKNOWN_MATCH RequestBackfillResponse_StatusCode = 1 // ya cerrada o nunca asignada
+	RequestBackfillResponse_NOT_OWNER     RequestBackfillResponse_StatusCode = 2 // asignada a otro servidor
+	RequestBackfillResponse_NOT_LOBBY     RequestBackfillResponse_StatusCode = 3 // el modo de la partida es por equipos
+)
+
+// Enum value maps for RequestBackfillResponse_StatusCode.
+var (
+	RequestBackfillResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "UNKNOWN_MATCH",
+		2: "NOT_OWNER",
+		3: "NOT_LOBBY",
+	}
+	RequestBackfillResponse_StatusCode_value = map[string]int32{
+		"OK":            0,
+		"UNKNOWN_MATCH": 1,
+		"NOT_OWNER":     2,
+		"NOT_LOBBY":     3,
+	}
+)
+
+func (x RequestBackfillResponse_StatusCode) Enum() *RequestBackfillResponse_StatusCode {
+	p := new(RequestBackfillResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x RequestBackfillResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RequestBackfillResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[24].Descriptor()
+}
+
+func (RequestBackfillResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[24]
+}
+
+func (x RequestBackfillResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RequestBackfillResponse_StatusCode.Descriptor instead.
+func (RequestBackfillResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{55, 0}
+}
+
+type DeregisterServerResponse_StatusCode int32
+
+const (
+	DeregisterServerResponse_OK             DeregisterServerResponse_StatusCode = 0
+	DeregisterServerResponse_UNKNOWN_SERVER DeregisterServerResponse_StatusCode = 1 // no estaba registrado (o ya se dio de baja)
+)
+
+// Enum value maps for DeregisterServerResponse_StatusCode.
+var (
+	DeregisterServerResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "UNKNOWN_SERVER",
+	}
+	DeregisterServerResponse_StatusCode_value = map[string]int32{
+		"OK":             0,
+		"UNKNOWN_SERVER": 1,
+	}
+)
+
+func (x DeregisterServerResponse_StatusCode) Enum() *DeregisterServerResponse_StatusCode {
+	p := new(DeregisterServerResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x DeregisterServerResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeregisterServerResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[25].Descriptor()
+}
+
+func (DeregisterServerResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[25]
+}
+
+func (x DeregisterServerResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeregisterServerResponse_StatusCode.Descriptor instead.
+func (DeregisterServerResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{57, 0}
+}
+
+type MatchFeedbackResponse_StatusCode int32
+
+const (
+	MatchFeedbackResponse_OK             MatchFeedbackResponse_StatusCode = 0
+	MatchFeedbackResponse_UNKNOWN_MATCH  MatchFeedbackResponse_StatusCode = 1 // partida inexistente o aún en curso
+	MatchFeedbackResponse_NOT_A_PLAYER   MatchFeedbackResponse_StatusCode = 2 // el jugador no participó
+	MatchFeedbackResponse_INVALID_RATING MatchFeedbackResponse_StatusCode = 3
+	MatchFeedbackResponse_DUPLICATE      MatchFeedbackResponse_StatusCode = 4 // ya calificó esta partida
+)
+
+// Enum value maps for MatchFeedbackResponse_StatusCode.
+var (
+	MatchFeedbackResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "UNKNOWN_MATCH",
+		2: "NOT_A_PLAYER",
+		3: "INVALID_RATING",
+		4: "DUPLICATE",
+	}
+	MatchFeedbackResponse_StatusCode_value = map[string]int32{
+		"OK":             0,
+		"UNKNOWN_MATCH":  1,
+		"NOT_A_PLAYER":   2,
+		"INVALID_RATING": 3,
+		"DUPLICATE":      4,
+	}
+)
+
+func (x MatchFeedbackResponse_StatusCode) Enum() *MatchFeedbackResponse_StatusCode {
+	p := new(MatchFeedbackResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x MatchFeedbackResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MatchFeedbackResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[26].Descriptor()
+}
+
+func (MatchFeedbackResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[26]
+}
+
+func (x MatchFeedbackResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MatchFeedbackResponse_StatusCode.Descriptor instead.
+func (MatchFeedbackResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{59, 0}
+}
+
+type PartyResponse_StatusCode int32
+
+const (
+	PartyResponse_OK               PartyResponse_StatusCode = 0
+	PartyResponse_ALREADY_IN_PARTY PartyResponse_StatusCode = 1
+	PartyResponse_PARTY_NOT_FOUND  PartyResponse_StatusCode = 2
+	PartyResponse_PARTY_FULL       PartyResponse_StatusCode = 3
+	PartyResponse_PARTY_BUSY       PartyResponse_StatusCode = 4 // el grupo está en cola o en partida
+	PartyResponse_NOT_IN_PARTY     PartyResponse_StatusCode = 5
+)
+
+// Enum value maps for PartyResponse_StatusCode.
+var (
+	PartyResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "ALREADY_IN_PARTY",
+		2: "PARTY_NOT_FOUND",
+		3: "PARTY_FULL",
+		4: "PARTY_BUSY",
+		5: "NOT_IN_PARTY",
+	}
+	PartyResponse_StatusCode_value = map[string]int32{
+		"OK":               0,
+		"ALREADY_IN_PARTY": 1,
+		"PARTY_NOT_FOUND":  2,
+		"PARTY_FULL":       3,
+		"PARTY_BUSY":       4,
+		"NOT_IN_PARTY":     5,
+	}
+)
+
+func (x PartyResponse_StatusCode) Enum() *PartyResponse_StatusCode {
+	p := new(PartyResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x PartyResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PartyResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[27].Descriptor()
+}
+
+func (PartyResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[27]
+}
+
+func (x PartyResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PartyResponse_StatusCode.Descriptor instead.
+func (PartyResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{61, 0}
+}
+
+type LobbyResponse_StatusCode int32
+
+const (
+	LobbyResponse_OK               LobbyResponse_StatusCode = 0
+	LobbyResponse_ALREADY_IN_LOBBY LobbyResponse_StatusCode = 1
+	LobbyResponse_LOBBY_NOT_FOUND  LobbyResponse_StatusCode = 2
+	LobbyResponse_LOBBY_FULL       LobbyResponse_StatusCode = 3
+	LobbyResponse_PLAYER_BUSY      LobbyResponse_StatusCode = 4 // en cola, en partida, baneado o en espera tras jugar
+	LobbyResponse_UNKNOWN_MODE     LobbyResponse_StatusCode = 5 // modo inexistente, deshabilitado o de otro Matchmaker
+	LobbyResponse_UNKNOWN_SERVER   LobbyResponse_StatusCode = 6 // el servidor elegido no existe, está caído o drenando
+	LobbyResponse_NOT_IN_LOBBY     LobbyResponse_StatusCode = 7
+)
+
+// Enum value maps for LobbyResponse_StatusCode.
+var (
+	LobbyResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "ALREADY_IN_LOBBY",
+		2: "LOBBY_NOT_FOUND",
+		3: "LOBBY_FULL",
+		4: "PLAYER_BUSY",
+		5: "UNKNOWN_MODE",
+		6: "UNKNOWN_SERVER",
+		7: "NOT_IN_LOBBY",
+	}
+	LobbyResponse_StatusCode_value = map[string]int32{
+		"OK":               0,
+		"ALREADY_IN_LOBBY": 1,
+		"LOBBY_NOT_FOUND":  2,
+		"LOBBY_FULL":       3,
+		"PLAYER_BUSY":      4,
+		"UNKNOWN_MODE":     5,
+		"UNKNOWN_SERVER":   6,
+		"NOT_IN_LOBBY":     7,
+	}
+)
+
+func (x LobbyResponse_StatusCode) Enum() *LobbyResponse_StatusCode {
+	p := new(LobbyResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x LobbyResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LobbyResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[28].Descriptor()
+}
+
+func (LobbyResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[28]
+}
+
+func (x LobbyResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LobbyResponse_StatusCode.Descriptor instead.
+func (LobbyResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{64, 0}
+}
+
+type EntityClock_Kind int32
+
+const (
+	EntityClock_PLAYER EntityClock_Kind = 0
+	EntityClock_SERVER EntityClock_Kind = 1
+)
+
+// Enum value maps for EntityClock_Kind.
+var (
+	EntityClock_Kind_name = map[int32]string{
+		0: "PLAYER",
+		1: "SERVER",
+	}
+	EntityClock_Kind_value = map[string]int32{
+		"PLAYER": 0,
+		"SERVER": 1,
+	}
+)
+
+func (x EntityClock_Kind) Enum() *EntityClock_Kind {
+	p := new(EntityClock_Kind)
+	*p = x
+	return p
+}
+
+func (x EntityClock_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntityClock_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[29].Descriptor()
+}
+
+func (EntityClock_Kind) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[29]
+}
+
+func (x EntityClock_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntityClock_Kind.Descriptor instead.
+func (EntityClock_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{77, 0}
+}
+
+type ReloadConfigResponse_StatusCode int32
+
+const (
+	ReloadConfigResponse_OK        ReloadConfigResponse_StatusCode = 0 // aplicada (puede no haber cambios)
+	ReloadConfigResponse_INVALID   ReloadConfigResponse_StatusCode = 1 // el archivo tiene errores: sigue la configuración anterior
+	ReloadConfigResponse_NO_CONFIG ReloadConfigResponse_StatusCode = 2 // el Matchmaker se lanzó sin MATCHMAKER_CONFIG
+)
+
+// Enum value maps for ReloadConfigResponse_StatusCode.
+var (
+	ReloadConfigResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "INVALID",
+		2: "NO_CONFIG",
+	}
+	ReloadConfigResponse_StatusCode_value = map[string]int32{
+		"OK":        0,
+		"INVALID":   1,
+		"NO_CONFIG": 2,
+	}
+)
+
+func (x ReloadConfigResponse_StatusCode) Enum() *ReloadConfigResponse_StatusCode {
+	p := new(ReloadConfigResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x ReloadConfigResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReloadConfigResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[30].Descriptor()
+}
+
+func (ReloadConfigResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[30]
+}
+
+func (x ReloadConfigResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReloadConfigResponse_StatusCode.Descriptor instead.
+func (ReloadConfigResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{88, 0}
+}
+
+type StateEvent_Kind int32
+
+const (
+	StateEvent_PLAYER_QUEUED      StateEvent_Kind = 0
+	StateEvent_PLAYER_REQUEUED    StateEvent_Kind = 1 // devueltos al frente de la cola por el Matchmaker
+	StateEvent_PLAYER_DEQUEUED    StateEvent_Kind = 2 // salieron de la cola sin partida (cancelación, expulsión)
+	StateEvent_MATCH_CREATED      StateEvent_Kind = 3
+	StateEvent_MATCH_REASSIGNED   StateEvent_Kind = 4 // la partida pasó a otro servidor (failover)
+	StateEvent_MATCH_BACKFILLED   StateEvent_Kind = 5 // jugadores sumados a una sala en curso
+	StateEvent_ASSIGN_FAILED      StateEvent_Kind = 6 // agotó reintentos y servidores
+	StateEvent_MATCH_ENDED        StateEvent_Kind = 7 // detail = desenlace, como en MatchRecord
+	StateEvent_SERVER_UP          StateEvent_Kind = 8 // registrado o de vuelta tras estar CAIDO
+	StateEvent_SERVER_DOWN        StateEvent_Kind = 9
+	StateEvent_SERVER_REMOVED     StateEvent_Kind = 10 // dado de baja o drenado y retirado del pool
+	StateEvent_READY_CHECK_FAILED StateEvent_Kind = 11 // partida descartada antes de asignarse; detail = motivo
+)
+
+// Enum value maps for StateEvent_Kind.
+var (
+	StateEvent_Kind_name = map[int32]string{
+		0:  "PLAYER_QUEUED",
+		1:  "PLAYER_REQUEUED",
+		2:  "PLAYER_DEQUEUED",
+		3:  "MATCH_CREATED",
+		4:  "MATCH_REASSIGNED",
+		5:  "MATCH_BACKFILLED",
+		6:  "ASSIGN_FAILED",
+		7:  "MATCH_ENDED",
+		8:  "SERVER_UP",
+		9:  "SERVER_DOWN",
+		10: "SERVER_REMOVED",
+		11: "READY_CHECK_FAILED",
+	}
+	StateEvent_Kind_value = map[string]int32{
+		"PLAYER_QUEUED":      0,
+		"PLAYER_REQUEUED":    1,
+		"PLAYER_DEQUEUED":    2,
+		"MATCH_CREATED":      3,
+		"MATCH_REASSIGNED":   4,
+		"MATCH_BACKFILLED":   5,
+		"ASSIGN_FAILED":      6,
+		"MATCH_ENDED":        7,
+		"SERVER_UP":          8,
+		"SERVER_DOWN":        9,
+		"SERVER_REMOVED":     10,
+		"READY_CHECK_FAILED": 11,
+	}
+)
+
+func (x StateEvent_Kind) Enum() *StateEvent_Kind {
+	p := new(StateEvent_Kind)
+	*p = x
+	return p
+}
+
+func (x StateEvent_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StateEvent_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[31].Descriptor()
+}
+
+func (StateEvent_Kind) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[31]
+}
+
+func (x StateEvent_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StateEvent_Kind.Descriptor instead.
+func (StateEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{96, 0}
+}
+
+type SimulatedMatch_Kind int32
+
+const (
+	SimulatedMatch_TEAMS         SimulatedMatch_Kind = 0 // dos equipos de la cola
+	SimulatedMatch_LOBBY         SimulatedMatch_Kind = 1 // sala por tiempo
+	SimulatedMatch_PRIVATE_LOBBY SimulatedMatch_Kind = 2 // sala privada llena
+	SimulatedMatch_BACKFILL      SimulatedMatch_Kind = 3 // relleno de una partida en curso
+)
+
+// Enum value maps for SimulatedMatch_Kind.
+var (
+	SimulatedMatch_Kind_name = map[int32]string{
+		0: "TEAMS",
+		1: "LOBBY",
+		2: "PRIVATE_LOBBY",
+		3: "BACKFILL",
+	}
+	SimulatedMatch_Kind_value = map[string]int32{
+		"TEAMS":         0,
+		"LOBBY":         1,
+		"PRIVATE_LOBBY": 2,
+		"BACKFILL":      3,
+	}
+)
+
+func (x SimulatedMatch_Kind) Enum() *SimulatedMatch_Kind {
+	p := new(SimulatedMatch_Kind)
+	*p = x
+	return p
+}
+
+func (x SimulatedMatch_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SimulatedMatch_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[32].Descriptor()
+}
+
+func (SimulatedMatch_Kind) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[32]
+}
+
+func (x SimulatedMatch_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SimulatedMatch_Kind.Descriptor instead.
+func (SimulatedMatch_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{111, 0}
+}
+
+type AdminUpdateResponse_StatusCode int32
+
+const (
+	AdminUpdateResponse_OK        AdminUpdateResponse_StatusCode = 0
+	AdminUpdateResponse_NOT_FOUND AdminUpdateResponse_StatusCode = 1
+)
+
+// Enum value maps for AdminUpdateResponse_StatusCode.
+var (
+	AdminUpdateResponse_StatusCode_name = map[int32]string{
+		0: "OK",
+		1: "NOT_FOUND",
+	}
+	AdminUpdateResponse_StatusCode_value = map[string]int32{
+		"OK":        0,
+		"NOT_FOUND": 1,
+	}
+)
+
+func (x AdminUpdateResponse_StatusCode) Enum() *AdminUpdateResponse_StatusCode {
+	p := new(AdminUpdateResponse_StatusCode)
+	*p = x
+	return p
+}
+
+func (x AdminUpdateResponse_StatusCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AdminUpdateResponse_StatusCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_matchmaking_proto_enumTypes[33].Descriptor()
+}
+
+func (AdminUpdateResponse_StatusCode) Type() protoreflect.EnumType {
+	return &file_proto_matchmaking_proto_enumTypes[33]
+}
+
+func (x AdminUpdateResponse_StatusCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AdminUpdateResponse_StatusCode.Descriptor instead.
+func (AdminUpdateResponse_StatusCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{114, 0}
+}
+
 // ──────────── UTILIDADES ─────────────
 type VectorClock struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Cada posición corresponde a la “vista” causal de una entidad.
-	Entries       []uint32 `protobuf:"varint,1,rep,packed,name=entries,proto3" json:"entries,omitempty"`
+	// id de entidad → contador causal de esa entidad.
+	Counters      map[string]int32 `protobuf:"bytes,1,rep,name=counters,proto3" json:"counters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VectorClock) Reset() {
+	*x = VectorClock{}
+	mi := &file_proto_matchmaking_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VectorClock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorClock) ProtoMessage() {}
+
+func (x *VectorClock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorClock.ProtoReflect.Descriptor instead.
+func (*VectorClock) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VectorClock) GetCounters() map[string]int32 {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+// Modo de juego registrado en el Matchmaker. Una partida enfrenta a dos
+// equipos de team_size jugadores.
+type GameMode struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. "1v1"
+	TeamSize     int32                  `protobuf:"varint,2,opt,name=team_size,json=teamSize,proto3" json:"team_size,omitempty"`
+	MinDurationS int32                  `protobuf:"varint,3,opt,name=min_duration_s,json=minDurationS,proto3" json:"min_duration_s,omitempty"` // pista para el GameServer; 0 = libre
+	MaxDurationS int32                  `protobuf:"varint,4,opt,name=max_duration_s,json=maxDurationS,proto3" json:"max_duration_s,omitempty"`
+	Enabled      bool                   `protobuf:"varint,5,opt,name=enabled,proto3" json:"enabled,omitempty"`                      // deshabilitado: no acepta jugadores nuevos
+	CooldownS    int32                  `protobuf:"varint,6,opt,name=cooldown_s,json=cooldownS,proto3" json:"cooldown_s,omitempty"` // espera tras terminar una partida; 0 = sin espera
+	// Sala por tiempo (todos contra todos): con lobby_max_players > 0 la
+	// partida arranca al llenarse o lobby_wait_s después de reunir
+	// lobby_min_players; team_size se ignora.
+	LobbyMinPlayers int32            `protobuf:"varint,7,opt,name=lobby_min_players,json=lobbyMinPlayers,proto3" json:"lobby_min_players,omitempty"`
+	LobbyMaxPlayers int32            `protobuf:"varint,8,opt,name=lobby_max_players,json=lobbyMaxPlayers,proto3" json:"lobby_max_players,omitempty"`
+	LobbyWaitS      int32            `protobuf:"varint,9,opt,name=lobby_wait_s,json=lobbyWaitS,proto3" json:"lobby_wait_s,omitempty"`
+	Scoring         GameMode_Scoring `protobuf:"varint,10,opt,name=scoring,proto3,enum=matchmaking.GameMode_Scoring" json:"scoring,omitempty"`
+	EventIntervalMs int32            `protobuf:"varint,11,opt,name=event_interval_ms,json=eventIntervalMs,proto3" json:"event_interval_ms,omitempty"`
+	ScoreProb       float32          `protobuf:"fixed32,12,opt,name=score_prob,json=scoreProb,proto3" json:"score_prob,omitempty"`
+	KillPoints      int32            `protobuf:"varint,13,opt,name=kill_points,json=killPoints,proto3" json:"kill_points,omitempty"`
+	DeathPoints     int32            `protobuf:"varint,14,opt,name=death_points,json=deathPoints,proto3" json:"death_points,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GameMode) Reset() {
+	*x = GameMode{}
+	mi := &file_proto_matchmaking_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameMode) ProtoMessage() {}
+
+func (x *GameMode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameMode.ProtoReflect.Descriptor instead.
+func (*GameMode) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GameMode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GameMode) GetTeamSize() int32 {
+	if x != nil {
+		return x.TeamSize
+	}
+	return 0
+}
+
+func (x *GameMode) GetMinDurationS() int32 {
+	if x != nil {
+		return x.MinDurationS
+	}
+	return 0
+}
+
+func (x *GameMode) GetMaxDurationS() int32 {
+	if x != nil {
+		return x.MaxDurationS
+	}
+	return 0
+}
+
+func (x *GameMode) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GameMode) GetCooldownS() int32 {
+	if x != nil {
+		return x.CooldownS
+	}
+	return 0
+}
+
+func (x *GameMode) GetLobbyMinPlayers() int32 {
+	if x != nil {
+		return x.LobbyMinPlayers
+	}
+	return 0
+}
+
+func (x *GameMode) GetLobbyMaxPlayers() int32 {
+	if x != nil {
+		return x.LobbyMaxPlayers
+	}
+	return 0
+}
+
+func (x *GameMode) GetLobbyWaitS() int32 {
+	if x != nil {
+		return x.LobbyWaitS
+	}
+	return 0
+}
+
+func (x *GameMode) GetScoring() GameMode_Scoring {
+	if x != nil {
+		return x.Scoring
+	}
+	return GameMode_DEATHMATCH
+}
+
+func (x *GameMode) GetEventIntervalMs() int32 {
+	if x != nil {
+		return x.EventIntervalMs
+	}
+	return 0
+}
+
+func (x *GameMode) GetScoreProb() float32 {
+	if x != nil {
+		return x.ScoreProb
+	}
+	return 0
+}
+
+func (x *GameMode) GetKillPoints() int32 {
+	if x != nil {
+		return x.KillPoints
+	}
+	return 0
+}
+
+func (x *GameMode) GetDeathPoints() int32 {
+	if x != nil {
+		return x.DeathPoints
+	}
+	return 0
+}
+
+// ─────────── MENSAJES JUGADOR ─────────
+type PlayerInfoRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId        string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	GameMode        string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"` // nombre de un GameMode; vacío = "1v1"
+	Clock           *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace       string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`                                                                            // tenant aislado; vacío = "default"
+	Priority        QueuePriority          `protobuf:"varint,5,opt,name=priority,proto3,enum=matchmaking.QueuePriority" json:"priority,omitempty"`                                              // NORMAL o PREMIUM
+	Region          string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`                                                                                  // región preferida; vacío = cualquiera
+	OnAssignFailure AssignFailureAction    `protobuf:"varint,7,opt,name=on_assign_failure,json=onAssignFailure,proto3,enum=matchmaking.AssignFailureAction" json:"on_assign_failure,omitempty"` // también para su grupo
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlayerInfoRequest) Reset() {
+	*x = PlayerInfoRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerInfoRequest) ProtoMessage() {}
+
+func (x *PlayerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerInfoRequest.ProtoReflect.Descriptor instead.
+func (*PlayerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlayerInfoRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerInfoRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *PlayerInfoRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *PlayerInfoRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PlayerInfoRequest) GetPriority() QueuePriority {
+	if x != nil {
+		return x.Priority
+	}
+	return QueuePriority_PRIORITY_NORMAL
+}
+
+func (x *PlayerInfoRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PlayerInfoRequest) GetOnAssignFailure() AssignFailureAction {
+	if x != nil {
+		return x.OnAssignFailure
+	}
+	return AssignFailureAction_FAILURE_REQUEUE
+}
+
+type QueuePlayerResponse struct {
+	state               protoimpl.MessageState         `protogen:"open.v1"`
+	StatusCode          QueuePlayerResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.QueuePlayerResponse_StatusCode" json:"status_code,omitempty"`
+	Message             string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock         *VectorClock                   `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	CooldownRemainingMs int64                          `protobuf:"varint,4,opt,name=cooldown_remaining_ms,json=cooldownRemainingMs,proto3" json:"cooldown_remaining_ms,omitempty"` // con COOLDOWN: espera pendiente
+	ShardAddr           string                         `protobuf:"bytes,5,opt,name=shard_addr,json=shardAddr,proto3" json:"shard_addr,omitempty"`                                  // con WRONG_SHARD: dirección del dueño, si se conoce
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *QueuePlayerResponse) Reset() {
+	*x = QueuePlayerResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueuePlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueuePlayerResponse) ProtoMessage() {}
+
+func (x *QueuePlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueuePlayerResponse.ProtoReflect.Descriptor instead.
+func (*QueuePlayerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QueuePlayerResponse) GetStatusCode() QueuePlayerResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return QueuePlayerResponse_OK
+}
+
+func (x *QueuePlayerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *QueuePlayerResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *QueuePlayerResponse) GetCooldownRemainingMs() int64 {
+	if x != nil {
+		return x.CooldownRemainingMs
+	}
+	return 0
+}
+
+func (x *QueuePlayerResponse) GetShardAddr() string {
+	if x != nil {
+		return x.ShardAddr
+	}
+	return ""
+}
+
+type CancelQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,2,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelQueueRequest) Reset() {
+	*x = CancelQueueRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueueRequest) ProtoMessage() {}
+
+func (x *CancelQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueueRequest.ProtoReflect.Descriptor instead.
+func (*CancelQueueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelQueueRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *CancelQueueRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *CancelQueueRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CancelQueueResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	StatusCode    CancelQueueResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.CancelQueueResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock   *VectorClock                   `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelQueueResponse) Reset() {
+	*x = CancelQueueResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueueResponse) ProtoMessage() {}
+
+func (x *CancelQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueueResponse.ProtoReflect.Descriptor instead.
+func (*CancelQueueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelQueueResponse) GetStatusCode() CancelQueueResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return CancelQueueResponse_OK
+}
+
+func (x *CancelQueueResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelQueueResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type LeaveMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"` // opcional; si no es su partida en curso, NOT_IN_MATCH
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveMatchRequest) Reset() {
+	*x = LeaveMatchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveMatchRequest) ProtoMessage() {}
+
+func (x *LeaveMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveMatchRequest.ProtoReflect.Descriptor instead.
+func (*LeaveMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LeaveMatchRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LeaveMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *LeaveMatchRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *LeaveMatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type LeaveMatchResponse struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	StatusCode    LeaveMatchResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.LeaveMatchResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MatchId       string                        `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	WinnerId      string                        `protobuf:"bytes,4,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // con LEAVE_ACTION=forfeit
+	VectorClock   *VectorClock                  `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveMatchResponse) Reset() {
+	*x = LeaveMatchResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveMatchResponse) ProtoMessage() {}
+
+func (x *LeaveMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveMatchResponse.ProtoReflect.Descriptor instead.
+func (*LeaveMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LeaveMatchResponse) GetStatusCode() LeaveMatchResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return LeaveMatchResponse_OK
+}
+
+func (x *LeaveMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LeaveMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *LeaveMatchResponse) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *LeaveMatchResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type PlayerStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,2,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Consistency   Consistency            `protobuf:"varint,4,opt,name=consistency,proto3,enum=matchmaking.Consistency" json:"consistency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStatusRequest) Reset() {
+	*x = PlayerStatusRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStatusRequest) ProtoMessage() {}
+
+func (x *PlayerStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStatusRequest.ProtoReflect.Descriptor instead.
+func (*PlayerStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PlayerStatusRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerStatusRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *PlayerStatusRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PlayerStatusRequest) GetConsistency() Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return Consistency_CONSISTENCY_DEFAULT
+}
+
+type PlayerStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDLE | IN_QUEUE | IN_MATCH | QUEUE_TIMED_OUT (salió de la cola por
+	// QUEUE_TIMEOUT; por lo demás, como IDLE) | READY_CHECK (partida formada
+	// que espera su AcceptMatch) | UNKNOWN | RETRY_LATER (el Matchmaker aún no
+	// refleja escrituras que el reloj del cliente ya vio)
+	Status       string       `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	MatchId      string       `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerAddr   string       `protobuf:"bytes,3,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"`
+	VectorClock  *VectorClock `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	RetryAfterMs int64        `protobuf:"varint,5,opt,name=retry_after_ms,json=retryAfterMs,proto3" json:"retry_after_ms,omitempty"` // con RETRY_LATER
+	// IN_MATCH: dónde conectarse a la partida; el puerto dedicado que asignó
+	// el GameServer o, si no usa uno, su propia dirección
+	MatchAddr string `protobuf:"bytes,6,opt,name=match_addr,json=matchAddr,proto3" json:"match_addr,omitempty"`
+	// la consistencia que se cumplió: la pedida o, si STRONG no pudo esperar
+	// a la asignación en curso, READ_YOUR_WRITES; DEFAULT con RETRY_LATER
+	Consistency Consistency `protobuf:"varint,7,opt,name=consistency,proto3,enum=matchmaking.Consistency" json:"consistency,omitempty"`
+	// IN_QUEUE: su lugar entre los encolados de su modo, en el orden de la
+	// última vuelta del emparejamiento (1 = el próximo), y cuánto lleva
+	// esperando
+	QueuePosition int32  `protobuf:"varint,8,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	WaitMs        int64  `protobuf:"varint,9,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+	GameMode      string `protobuf:"bytes,10,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"` // IN_QUEUE, READY_CHECK o IN_MATCH
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStatusResponse) Reset() {
+	*x = PlayerStatusResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStatusResponse) ProtoMessage() {}
+
+func (x *PlayerStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStatusResponse.ProtoReflect.Descriptor instead.
+func (*PlayerStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PlayerStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PlayerStatusResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *PlayerStatusResponse) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+func (x *PlayerStatusResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *PlayerStatusResponse) GetRetryAfterMs() int64 {
+	if x != nil {
+		return x.RetryAfterMs
+	}
+	return 0
+}
+
+func (x *PlayerStatusResponse) GetMatchAddr() string {
+	if x != nil {
+		return x.MatchAddr
+	}
+	return ""
+}
+
+func (x *PlayerStatusResponse) GetConsistency() Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return Consistency_CONSISTENCY_DEFAULT
+}
+
+func (x *PlayerStatusResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *PlayerStatusResponse) GetWaitMs() int64 {
+	if x != nil {
+		return x.WaitMs
+	}
+	return 0
+}
+
+func (x *PlayerStatusResponse) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+type ResumeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeSessionRequest) Reset() {
+	*x = ResumeSessionRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeSessionRequest) ProtoMessage() {}
+
+func (x *ResumeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeSessionRequest.ProtoReflect.Descriptor instead.
+func (*ResumeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResumeSessionRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ResumeSessionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Estado necesario para que un cliente reiniciado retome su sesión.
+type ResumeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`  // false = el Matchmaker no conoce al jugador
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // IDLE | IN_QUEUE | IN_MATCH | QUEUE_TIMED_OUT | READY_CHECK | UNKNOWN
+	MatchId       string                 `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerAddr    string                 `protobuf:"bytes,4,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"`
+	GameMode      string                 `protobuf:"bytes,5,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"` // último reloj conocido (incluye la entrada del jugador)
+	MatchAddr     string                 `protobuf:"bytes,7,opt,name=match_addr,json=matchAddr,proto3" json:"match_addr,omitempty"`       // como en PlayerStatusResponse
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeSessionResponse) Reset() {
+	*x = ResumeSessionResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeSessionResponse) ProtoMessage() {}
+
+func (x *ResumeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeSessionResponse.ProtoReflect.Descriptor instead.
+func (*ResumeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResumeSessionResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *ResumeSessionResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResumeSessionResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ResumeSessionResponse) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+func (x *ResumeSessionResponse) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *ResumeSessionResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *ResumeSessionResponse) GetMatchAddr() string {
+	if x != nil {
+		return x.MatchAddr
+	}
+	return ""
+}
+
+// Negociación de versión. Cada cliente envía la versión del protocolo que
+// habla en la metadata "x-protocol-version" de todas sus RPC (pkg/mmclient);
+// sin ella se asume 1. Versiones:
+//
+//	1: clientes anteriores a la negociación;
+//	2: GetServerInfo, PlayerHeartbeat y salas privadas.
+//
+// El Matchmaker rechaza con FAILED_PRECONDITION las versiones menores que
+// MIN_PROTOCOL_VERSION y adapta lo que envía a las anteriores a la suya.
+type ServerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerInfoRequest) Reset() {
+	*x = ServerInfoRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfoRequest) ProtoMessage() {}
+
+func (x *ServerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*ServerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ServerInfoRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ServerInfoResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ProtocolVersion       int32                  `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`                     // la que habla el Matchmaker
+	MinProtocolVersion    int32                  `protobuf:"varint,2,opt,name=min_protocol_version,json=minProtocolVersion,proto3" json:"min_protocol_version,omitempty"`          // la menor que atiende
+	ClientProtocolVersion int32                  `protobuf:"varint,3,opt,name=client_protocol_version,json=clientProtocolVersion,proto3" json:"client_protocol_version,omitempty"` // la que entendió de este cliente
+	Features              []string               `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`                                                           // capacidades activas, ordenadas
+	MatchmakerId          string                 `protobuf:"bytes,5,opt,name=matchmaker_id,json=matchmakerId,proto3" json:"matchmaker_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ServerInfoResponse) Reset() {
+	*x = ServerInfoResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfoResponse) ProtoMessage() {}
+
+func (x *ServerInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*ServerInfoResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ServerInfoResponse) GetProtocolVersion() int32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerInfoResponse) GetMinProtocolVersion() int32 {
+	if x != nil {
+		return x.MinProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerInfoResponse) GetClientProtocolVersion() int32 {
+	if x != nil {
+		return x.ClientProtocolVersion
+	}
+	return 0
+}
+
+func (x *ServerInfoResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *ServerInfoResponse) GetMatchmakerId() string {
+	if x != nil {
+		return x.MatchmakerId
+	}
+	return ""
+}
+
+// Latido del cliente de jugador. Quien envió alguno y deja de enviarlos
+// durante PLAYER_HEARTBEAT_TIMEOUT sale de la cola (HEARTBEAT_LOST).
+type PlayerHeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,2,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerHeartbeatRequest) Reset() {
+	*x = PlayerHeartbeatRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerHeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerHeartbeatRequest) ProtoMessage() {}
+
+func (x *PlayerHeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerHeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*PlayerHeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlayerHeartbeatRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerHeartbeatRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *PlayerHeartbeatRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type PlayerHeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                            // estado actual del jugador (como GetPlayerStatus)
+	IntervalMs    int64                  `protobuf:"varint,2,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"` // cada cuánto enviar el próximo latido
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerHeartbeatResponse) Reset() {
+	*x = PlayerHeartbeatResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerHeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerHeartbeatResponse) ProtoMessage() {}
+
+func (x *PlayerHeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerHeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*PlayerHeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PlayerHeartbeatResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PlayerHeartbeatResponse) GetIntervalMs() int64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *PlayerHeartbeatResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Registro del jugador (PLAYER_AUTH=required en el Matchmaker): el primer
+// registro de un ID lo reclama y devuelve un token firmado que el cliente
+// envía en la metadata "x-player-token" de las RPC de jugador. Para renovarlo
+// (aun vencido) se registra de nuevo enviando el token anterior.
+type RegisterPlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterPlayerRequest) Reset() {
+	*x = RegisterPlayerRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPlayerRequest) ProtoMessage() {}
+
+func (x *RegisterPlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPlayerRequest.ProtoReflect.Descriptor instead.
+func (*RegisterPlayerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RegisterPlayerRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *RegisterPlayerRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RegisterPlayerRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type RegisterPlayerResponse struct {
+	state           protoimpl.MessageState            `protogen:"open.v1"`
+	StatusCode      RegisterPlayerResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.RegisterPlayerResponse_StatusCode" json:"status_code,omitempty"`
+	Token           string                            `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAtUnixMs int64                             `protobuf:"varint,3,opt,name=expires_at_unix_ms,json=expiresAtUnixMs,proto3" json:"expires_at_unix_ms,omitempty"`
+	Message         string                            `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock     *VectorClock                      `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RegisterPlayerResponse) Reset() {
+	*x = RegisterPlayerResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterPlayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterPlayerResponse) ProtoMessage() {}
+
+func (x *RegisterPlayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterPlayerResponse.ProtoReflect.Descriptor instead.
+func (*RegisterPlayerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RegisterPlayerResponse) GetStatusCode() RegisterPlayerResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return RegisterPlayerResponse_OK
+}
+
+func (x *RegisterPlayerResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RegisterPlayerResponse) GetExpiresAtUnixMs() int64 {
+	if x != nil {
+		return x.ExpiresAtUnixMs
+	}
+	return 0
+}
+
+func (x *RegisterPlayerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RegisterPlayerResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type ListGameModesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGameModesRequest) Reset() {
+	*x = ListGameModesRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGameModesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGameModesRequest) ProtoMessage() {}
+
+func (x *ListGameModesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGameModesRequest.ProtoReflect.Descriptor instead.
+func (*ListGameModesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListGameModesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListGameModesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Modes         []*GameMode            `protobuf:"bytes,1,rep,name=modes,proto3" json:"modes,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGameModesResponse) Reset() {
+	*x = ListGameModesResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGameModesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGameModesResponse) ProtoMessage() {}
+
+func (x *ListGameModesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGameModesResponse.ProtoReflect.Descriptor instead.
+func (*ListGameModesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListGameModesResponse) GetModes() []*GameMode {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+func (x *ListGameModesResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId  string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Clock     *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	// Token de reanudación: reloj del último MatchUpdate recibido. Si se
+	// envía, el Matchmaker reenvía primero los eventos posteriores perdidos.
+	ResumeFrom    *VectorClock `protobuf:"bytes,4,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubscribeRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetResumeFrom() *VectorClock {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return nil
+}
+
+type MatchUpdate struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Event                      MatchUpdate_Event      `protobuf:"varint,1,opt,name=event,proto3,enum=matchmaking.MatchUpdate_Event" json:"event,omitempty"`
+	MatchId                    string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerAddr                 string                 `protobuf:"bytes,3,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"`
+	WinnerId                   string                 `protobuf:"bytes,4,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // en MATCH_FINISHED y PLAYER_LEFT (si hubo ganador)
+	VectorClock                *VectorClock           `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	ReadyDeadlineUnixMs        int64                  `protobuf:"varint,6,opt,name=ready_deadline_unix_ms,json=readyDeadlineUnixMs,proto3" json:"ready_deadline_unix_ms,omitempty"`                        // en READY_CHECK
+	Requeued                   bool                   `protobuf:"varint,7,opt,name=requeued,proto3" json:"requeued,omitempty"`                                                                             // en READY_CHECK_FAILED y ASSIGN_FAILED: volvió al frente de la cola
+	WinnerIds                  []string               `protobuf:"bytes,8,rep,name=winner_ids,json=winnerIds,proto3" json:"winner_ids,omitempty"`                                                           // en MATCH_FINISHED: todo el equipo ganador
+	RequeueOfferDeadlineUnixMs int64                  `protobuf:"varint,9,opt,name=requeue_offer_deadline_unix_ms,json=requeueOfferDeadlineUnixMs,proto3" json:"requeue_offer_deadline_unix_ms,omitempty"` // en ASSIGN_FAILED con FAILURE_ASK
+	RematchDeadlineUnixMs      int64                  `protobuf:"varint,10,opt,name=rematch_deadline_unix_ms,json=rematchDeadlineUnixMs,proto3" json:"rematch_deadline_unix_ms,omitempty"`                 // en MATCH_FINISHED y REMATCH_OFFERED; 0 = sin revancha
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *MatchUpdate) Reset() {
+	*x = MatchUpdate{}
+	mi := &file_proto_matchmaking_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchUpdate) ProtoMessage() {}
+
+func (x *MatchUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchUpdate.ProtoReflect.Descriptor instead.
+func (*MatchUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MatchUpdate) GetEvent() MatchUpdate_Event {
+	if x != nil {
+		return x.Event
+	}
+	return MatchUpdate_MATCH_FOUND
+}
+
+func (x *MatchUpdate) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *MatchUpdate) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *MatchUpdate) GetReadyDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.ReadyDeadlineUnixMs
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetRequeued() bool {
+	if x != nil {
+		return x.Requeued
+	}
+	return false
+}
+
+func (x *MatchUpdate) GetWinnerIds() []string {
+	if x != nil {
+		return x.WinnerIds
+	}
+	return nil
+}
+
+func (x *MatchUpdate) GetRequeueOfferDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.RequeueOfferDeadlineUnixMs
+	}
+	return 0
+}
+
+func (x *MatchUpdate) GetRematchDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.RematchDeadlineUnixMs
+	}
+	return 0
+}
+
+// Respuesta del jugador al READY_CHECK de una partida.
+type AcceptMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"` // false = rechazar (queda penalizado)
+	Clock         *VectorClock           `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptMatchRequest) Reset() {
+	*x = AcceptMatchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptMatchRequest) ProtoMessage() {}
+
+func (x *AcceptMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptMatchRequest.ProtoReflect.Descriptor instead.
+func (*AcceptMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AcceptMatchRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *AcceptMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AcceptMatchRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+func (x *AcceptMatchRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AcceptMatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type AcceptMatchResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	StatusCode    AcceptMatchResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.AcceptMatchResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Accepted      int32                          `protobuf:"varint,3,opt,name=accepted,proto3" json:"accepted,omitempty"` // jugadores que ya aceptaron
+	Players       int32                          `protobuf:"varint,4,opt,name=players,proto3" json:"players,omitempty"`
+	VectorClock   *VectorClock                   `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptMatchResponse) Reset() {
+	*x = AcceptMatchResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptMatchResponse) ProtoMessage() {}
+
+func (x *AcceptMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptMatchResponse.ProtoReflect.Descriptor instead.
+func (*AcceptMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AcceptMatchResponse) GetStatusCode() AcceptMatchResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return AcceptMatchResponse_OK
+}
+
+func (x *AcceptMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AcceptMatchResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *AcceptMatchResponse) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *AcceptMatchResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Pedido de revancha de una partida terminada. match_id es la terminada;
+// cuando la piden todos sus jugadores arranca una nueva, preferentemente en
+// el mismo GameServer.
+type RematchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RematchRequest) Reset() {
+	*x = RematchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchRequest) ProtoMessage() {}
+
+func (x *RematchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchRequest.ProtoReflect.Descriptor instead.
+func (*RematchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RematchRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *RematchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RematchRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *RematchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type RematchResponse struct {
+	state          protoimpl.MessageState     `protogen:"open.v1"`
+	StatusCode     RematchResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.RematchResponse_StatusCode" json:"status_code,omitempty"`
+	Message        string                     `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Accepted       int32                      `protobuf:"varint,3,opt,name=accepted,proto3" json:"accepted,omitempty"` // jugadores que ya la pidieron
+	Players        int32                      `protobuf:"varint,4,opt,name=players,proto3" json:"players,omitempty"`
+	DeadlineUnixMs int64                      `protobuf:"varint,5,opt,name=deadline_unix_ms,json=deadlineUnixMs,proto3" json:"deadline_unix_ms,omitempty"`
+	RematchId      string                     `protobuf:"bytes,6,opt,name=rematch_id,json=rematchId,proto3" json:"rematch_id,omitempty"` // partida de revancha, si ya arrancó
+	ServerId       string                     `protobuf:"bytes,7,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`    // su servidor
+	VectorClock    *VectorClock               `protobuf:"bytes,8,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RematchResponse) Reset() {
+	*x = RematchResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RematchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchResponse) ProtoMessage() {}
+
+func (x *RematchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchResponse.ProtoReflect.Descriptor instead.
+func (*RematchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RematchResponse) GetStatusCode() RematchResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return RematchResponse_OK
+}
+
+func (x *RematchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RematchResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *RematchResponse) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *RematchResponse) GetDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.DeadlineUnixMs
+	}
+	return 0
+}
+
+func (x *RematchResponse) GetRematchId() string {
+	if x != nil {
+		return x.RematchId
+	}
+	return ""
+}
+
+func (x *RematchResponse) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RematchResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// ───────────── MENSAJES SERVER ────────
+type AssignMatchRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MatchId     string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	PlayerIds   []string               `protobuf:"bytes,2,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"` // primera mitad = equipo 1, segunda = equipo 2
+	VectorClock *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	Namespace   string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Mode        *GameMode              `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	// intento de entrega de la partida (desde 1, cuenta reintentos y
+	// conmutaciones); match_id + attempt es la clave de idempotencia
+	Attempt       int32 `protobuf:"varint,6,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignMatchRequest) Reset() {
+	*x = AssignMatchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignMatchRequest) ProtoMessage() {}
+
+func (x *AssignMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignMatchRequest.ProtoReflect.Descriptor instead.
+func (*AssignMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AssignMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AssignMatchRequest) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *AssignMatchRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *AssignMatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AssignMatchRequest) GetMode() *GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return nil
+}
+
+func (x *AssignMatchRequest) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+type AssignMatchResponse struct {
+	state       protoimpl.MessageState         `protogen:"open.v1"`
+	StatusCode  AssignMatchResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.AssignMatchResponse_StatusCode" json:"status_code,omitempty"`
+	Message     string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock *VectorClock                   `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	// la partida ya estaba aceptada: es la respuesta original, del intento
+	// original_attempt
+	Duplicate       bool  `protobuf:"varint,4,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	OriginalAttempt int32 `protobuf:"varint,5,opt,name=original_attempt,json=originalAttempt,proto3" json:"original_attempt,omitempty"`
+	// host:puerto dedicado a la partida (MATCH_PORT_RANGE del GameServer);
+	// vacío = los jugadores usan la dirección del servidor. Sin host, el
+	// Matchmaker lo completa con el de la dirección del servidor.
+	MatchAddr     string `protobuf:"bytes,6,opt,name=match_addr,json=matchAddr,proto3" json:"match_addr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignMatchResponse) Reset() {
+	*x = AssignMatchResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignMatchResponse) ProtoMessage() {}
+
+func (x *AssignMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignMatchResponse.ProtoReflect.Descriptor instead.
+func (*AssignMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AssignMatchResponse) GetStatusCode() AssignMatchResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return AssignMatchResponse_OK
+}
+
+func (x *AssignMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssignMatchResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *AssignMatchResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+func (x *AssignMatchResponse) GetOriginalAttempt() int32 {
+	if x != nil {
+		return x.OriginalAttempt
+	}
+	return 0
+}
+
+func (x *AssignMatchResponse) GetMatchAddr() string {
+	if x != nil {
+		return x.MatchAddr
+	}
+	return ""
+}
+
+// El Matchmaker terminó la partida antes de tiempo (un jugador la abandonó).
+type CancelMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMatchRequest) Reset() {
+	*x = CancelMatchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMatchRequest) ProtoMessage() {}
+
+func (x *CancelMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMatchRequest.ProtoReflect.Descriptor instead.
+func (*CancelMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CancelMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *CancelMatchRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelMatchRequest) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *CancelMatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type CancelMatchResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	StatusCode    CancelMatchResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.CancelMatchResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMatchResponse) Reset() {
+	*x = CancelMatchResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMatchResponse) ProtoMessage() {}
+
+func (x *CancelMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMatchResponse.ProtoReflect.Descriptor instead.
+func (*CancelMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CancelMatchResponse) GetStatusCode() CancelMatchResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return CancelMatchResponse_OK
+}
+
+func (x *CancelMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Un espectador pide ver una partida en curso del GameServer.
+type SpectateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	SpectatorId   string                 `protobuf:"bytes,2,opt,name=spectator_id,json=spectatorId,proto3" json:"spectator_id,omitempty"` // sólo para los logs del servidor
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateRequest) Reset() {
+	*x = SpectateRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateRequest) ProtoMessage() {}
+
+func (x *SpectateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateRequest.ProtoReflect.Descriptor instead.
+func (*SpectateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SpectateRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *SpectateRequest) GetSpectatorId() string {
+	if x != nil {
+		return x.SpectatorId
+	}
+	return ""
+}
+
+// Evento simulado de una partida, emitido periódicamente a los espectadores.
+type SpectateEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          SpectateEvent_Kind     `protobuf:"varint,1,opt,name=kind,proto3,enum=matchmaking.SpectateEvent_Kind" json:"kind,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	GameMode      string                 `protobuf:"bytes,3,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	RemainingMs   int64                  `protobuf:"varint,4,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,5,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	VictimId      string                 `protobuf:"bytes,6,opt,name=victim_id,json=victimId,proto3" json:"victim_id,omitempty"`
+	Scoreboard    []*PlayerMatchStats    `protobuf:"bytes,7,rep,name=scoreboard,proto3" json:"scoreboard,omitempty"` // marcador tras el evento
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	UnixMs        int64                  `protobuf:"varint,9,opt,name=unix_ms,json=unixMs,proto3" json:"unix_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateEvent) Reset() {
+	*x = SpectateEvent{}
+	mi := &file_proto_matchmaking_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateEvent) ProtoMessage() {}
+
+func (x *SpectateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateEvent.ProtoReflect.Descriptor instead.
+func (*SpectateEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SpectateEvent) GetKind() SpectateEvent_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return SpectateEvent_SNAPSHOT
+}
+
+func (x *SpectateEvent) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *SpectateEvent) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *SpectateEvent) GetRemainingMs() int64 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+func (x *SpectateEvent) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *SpectateEvent) GetVictimId() string {
+	if x != nil {
+		return x.VictimId
+	}
+	return ""
+}
+
+func (x *SpectateEvent) GetScoreboard() []*PlayerMatchStats {
+	if x != nil {
+		return x.Scoreboard
+	}
+	return nil
+}
+
+func (x *SpectateEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SpectateEvent) GetUnixMs() int64 {
+	if x != nil {
+		return x.UnixMs
+	}
+	return 0
+}
+
+// Evento de una partida que el GameServer publica en el Matchmaker para
+// retransmitirlo a los suscriptores (PublishMatchEvents).
+type PublishMatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Event         *SpectateEvent         `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishMatchEventsRequest) Reset() {
+	*x = PublishMatchEventsRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishMatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishMatchEventsRequest) ProtoMessage() {}
+
+func (x *PublishMatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishMatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*PublishMatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PublishMatchEventsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PublishMatchEventsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *PublishMatchEventsRequest) GetEvent() *SpectateEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// Resumen del stream al cerrarlo.
+type PublishMatchEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Received      int64                  `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`   // eventos aceptados
+	Delivered     int64                  `protobuf:"varint,2,opt,name=delivered,proto3" json:"delivered,omitempty"` // copias entregadas a suscriptores
+	Dropped       int64                  `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`     // copias descartadas por suscriptores lentos
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishMatchEventsResponse) Reset() {
+	*x = PublishMatchEventsResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishMatchEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishMatchEventsResponse) ProtoMessage() {}
+
+func (x *PublishMatchEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishMatchEventsResponse.ProtoReflect.Descriptor instead.
+func (*PublishMatchEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PublishMatchEventsResponse) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *PublishMatchEventsResponse) GetDelivered() int64 {
+	if x != nil {
+		return x.Delivered
+	}
+	return 0
+}
+
+func (x *PublishMatchEventsResponse) GetDropped() int64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+// Un jugador o espectador mira una partida a través del Matchmaker.
+type SubscribeMatchEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	SubscriberId  string                 `protobuf:"bytes,3,opt,name=subscriber_id,json=subscriberId,proto3" json:"subscriber_id,omitempty"` // sólo para los logs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeMatchEventsRequest) Reset() {
+	*x = SubscribeMatchEventsRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeMatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMatchEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeMatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeMatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubscribeMatchEventsRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *SubscribeMatchEventsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SubscribeMatchEventsRequest) GetSubscriberId() string {
+	if x != nil {
+		return x.SubscriberId
+	}
+	return ""
+}
+
+type ServerStatusUpdateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServerId       string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	NewStatus      ServerStatus           `protobuf:"varint,2,opt,name=new_status,json=newStatus,proto3,enum=matchmaking.ServerStatus" json:"new_status,omitempty"`
+	Address        string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // host:port de GameServer
+	MatchId        string                 `protobuf:"bytes,4,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Clock          *VectorClock           `protobuf:"bytes,5,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace      string                 `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Region         string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`                                        // etiqueta de región (p.ej. "sa-east")
+	LatencyMs      uint32                 `protobuf:"varint,8,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`                // latencia estimada; 0 = desconocida
+	Capacity       int32                  `protobuf:"varint,9,opt,name=capacity,proto3" json:"capacity,omitempty"`                                   // partidas simultáneas; 0 = 1 (servidor antiguo)
+	FreeSlots      int32                  `protobuf:"varint,10,opt,name=free_slots,json=freeSlots,proto3" json:"free_slots,omitempty"`               // huecos libres al enviar la actualización
+	RunningMatches []*RunningMatch        `protobuf:"bytes,11,rep,name=running_matches,json=runningMatches,proto3" json:"running_matches,omitempty"` // partidas en curso del servidor
+	// contexto W3C de la traza de match_id; por el stream Heartbeat, cuya
+	// metadata es la de su apertura (ver internal/tracing)
+	Traceparent string `protobuf:"bytes,12,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	// época del Matchmaker en la última respuesta que recibió el servidor;
+	// 0 = ninguna todavía
+	Epoch         uint64 `protobuf:"varint,13,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatusUpdateRequest) Reset() {
+	*x = ServerStatusUpdateRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatusUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatusUpdateRequest) ProtoMessage() {}
+
+func (x *ServerStatusUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatusUpdateRequest.ProtoReflect.Descriptor instead.
+func (*ServerStatusUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ServerStatusUpdateRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetNewStatus() ServerStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ServerStatus_UNKNOWN
+}
+
+func (x *ServerStatusUpdateRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *ServerStatusUpdateRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetLatencyMs() uint32 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ServerStatusUpdateRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ServerStatusUpdateRequest) GetFreeSlots() int32 {
+	if x != nil {
+		return x.FreeSlots
+	}
+	return 0
+}
+
+func (x *ServerStatusUpdateRequest) GetRunningMatches() []*RunningMatch {
+	if x != nil {
+		return x.RunningMatches
+	}
+	return nil
+}
+
+func (x *ServerStatusUpdateRequest) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateRequest) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Partida en curso declarada por el GameServer; si el Matchmaker se reinició
+// y la perdió, la reconstruye a partir de esto.
+type RunningMatch struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MatchId         string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	PlayerIds       []string               `protobuf:"bytes,2,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	GameMode        string                 `protobuf:"bytes,3,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	StartedAtUnixMs int64                  `protobuf:"varint,4,opt,name=started_at_unix_ms,json=startedAtUnixMs,proto3" json:"started_at_unix_ms,omitempty"`
+	EndsAtUnixMs    int64                  `protobuf:"varint,5,opt,name=ends_at_unix_ms,json=endsAtUnixMs,proto3" json:"ends_at_unix_ms,omitempty"` // fin previsto por la simulación
+	MatchAddr       string                 `protobuf:"bytes,6,opt,name=match_addr,json=matchAddr,proto3" json:"match_addr,omitempty"`               // como en AssignMatchResponse
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RunningMatch) Reset() {
+	*x = RunningMatch{}
+	mi := &file_proto_matchmaking_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunningMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunningMatch) ProtoMessage() {}
+
+func (x *RunningMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunningMatch.ProtoReflect.Descriptor instead.
+func (*RunningMatch) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RunningMatch) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RunningMatch) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *RunningMatch) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *RunningMatch) GetStartedAtUnixMs() int64 {
+	if x != nil {
+		return x.StartedAtUnixMs
+	}
+	return 0
+}
+
+func (x *RunningMatch) GetEndsAtUnixMs() int64 {
+	if x != nil {
+		return x.EndsAtUnixMs
+	}
+	return 0
+}
+
+func (x *RunningMatch) GetMatchAddr() string {
+	if x != nil {
+		return x.MatchAddr
+	}
+	return ""
+}
+
+type ServerStatusUpdateResponse struct {
+	state       protoimpl.MessageState                `protogen:"open.v1"`
+	StatusCode  ServerStatusUpdateResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.ServerStatusUpdateResponse_StatusCode" json:"status_code,omitempty"`
+	Message     string                                `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock *VectorClock                          `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	// identifica la ejecución del Matchmaker: cambia al reiniciarse o al
+	// promoverse el respaldo, y el servidor que lo nota se re-sincroniza
+	Epoch         uint64 `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatusUpdateResponse) Reset() {
+	*x = ServerStatusUpdateResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatusUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatusUpdateResponse) ProtoMessage() {}
+
+func (x *ServerStatusUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatusUpdateResponse.ProtoReflect.Descriptor instead.
+func (*ServerStatusUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ServerStatusUpdateResponse) GetStatusCode() ServerStatusUpdateResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ServerStatusUpdateResponse_OK
+}
+
+func (x *ServerStatusUpdateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ServerStatusUpdateResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *ServerStatusUpdateResponse) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// Mensaje del Matchmaker al GameServer por el stream Heartbeat: la
+// respuesta a cada latido o una orden que el Matchmaker inicia.
+type ServerControl struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Command       ServerControl_Command       `protobuf:"varint,1,opt,name=command,proto3,enum=matchmaking.ServerControl_Command" json:"command,omitempty"`
+	Ack           *ServerStatusUpdateResponse `protobuf:"bytes,2,opt,name=ack,proto3" json:"ack,omitempty"`                        // en ACK
+	MatchId       string                      `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"` // en ABORT_MATCH y BACKFILL
+	Reason        string                      `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Retired       bool                        `protobuf:"varint,5,opt,name=retired,proto3" json:"retired,omitempty"`                     // en DRAIN: ya salió del pool, puede apagarse
+	PlayerIds     []string                    `protobuf:"bytes,6,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"` // en BACKFILL
+	Chaos         *ChaosConfig                `protobuf:"bytes,7,opt,name=chaos,proto3" json:"chaos,omitempty"`                          // en CHAOS
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerControl) Reset() {
+	*x = ServerControl{}
+	mi := &file_proto_matchmaking_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerControl) ProtoMessage() {}
+
+func (x *ServerControl) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerControl.ProtoReflect.Descriptor instead.
+func (*ServerControl) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ServerControl) GetCommand() ServerControl_Command {
+	if x != nil {
+		return x.Command
+	}
+	return ServerControl_ACK
+}
+
+func (x *ServerControl) GetAck() *ServerStatusUpdateResponse {
+	if x != nil {
+		return x.Ack
+	}
+	return nil
+}
+
+func (x *ServerControl) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *ServerControl) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ServerControl) GetRetired() bool {
+	if x != nil {
+		return x.Retired
+	}
+	return false
+}
+
+func (x *ServerControl) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *ServerControl) GetChaos() *ChaosConfig {
+	if x != nil {
+		return x.Chaos
+	}
+	return nil
+}
+
+type PlayerMatchStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Score         int32                  `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Kills         int32                  `protobuf:"varint,3,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths        int32                  `protobuf:"varint,4,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerMatchStats) Reset() {
+	*x = PlayerMatchStats{}
+	mi := &file_proto_matchmaking_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerMatchStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerMatchStats) ProtoMessage() {}
+
+func (x *PlayerMatchStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerMatchStats.ProtoReflect.Descriptor instead.
+func (*PlayerMatchStats) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PlayerMatchStats) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerMatchStats) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlayerMatchStats) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *PlayerMatchStats) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+// Eliminación durante la simulación de una partida.
+type ScoreEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AtMs          int64                  `protobuf:"varint,1,opt,name=at_ms,json=atMs,proto3" json:"at_ms,omitempty"`            // desde el inicio de la partida
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // el que eliminó
+	VictimId      string                 `protobuf:"bytes,3,opt,name=victim_id,json=victimId,proto3" json:"victim_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreEvent) Reset() {
+	*x = ScoreEvent{}
+	mi := &file_proto_matchmaking_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreEvent) ProtoMessage() {}
+
+func (x *ScoreEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreEvent.ProtoReflect.Descriptor instead.
+func (*ScoreEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ScoreEvent) GetAtMs() int64 {
+	if x != nil {
+		return x.AtMs
+	}
+	return 0
+}
+
+func (x *ScoreEvent) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ScoreEvent) GetVictimId() string {
+	if x != nil {
+		return x.VictimId
+	}
+	return ""
+}
+
+type MatchResultRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,3,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // mejor puntaje entre los ganadores
+	DurationMs    int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	PlayerStats   []*PlayerMatchStats    `protobuf:"bytes,5,rep,name=player_stats,json=playerStats,proto3" json:"player_stats,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,6,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WinnerIds     []string               `protobuf:"bytes,8,rep,name=winner_ids,json=winnerIds,proto3" json:"winner_ids,omitempty"` // equipo ganador; en DEATHMATCH sólo winner_id
+	Events        []*ScoreEvent          `protobuf:"bytes,9,rep,name=events,proto3" json:"events,omitempty"`                        // eliminaciones en orden
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResultRequest) Reset() {
+	*x = MatchResultRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResultRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResultRequest) ProtoMessage() {}
+
+func (x *MatchResultRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResultRequest.ProtoReflect.Descriptor instead.
+func (*MatchResultRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MatchResultRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchResultRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MatchResultRequest) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *MatchResultRequest) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MatchResultRequest) GetPlayerStats() []*PlayerMatchStats {
+	if x != nil {
+		return x.PlayerStats
+	}
+	return nil
+}
+
+func (x *MatchResultRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *MatchResultRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *MatchResultRequest) GetWinnerIds() []string {
+	if x != nil {
+		return x.WinnerIds
+	}
+	return nil
+}
+
+func (x *MatchResultRequest) GetEvents() []*ScoreEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type MatchResultResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	StatusCode    MatchResultResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.MatchResultResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock   *VectorClock                   `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchResultResponse) Reset() {
+	*x = MatchResultResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchResultResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchResultResponse) ProtoMessage() {}
+
+func (x *MatchResultResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchResultResponse.ProtoReflect.Descriptor instead.
+func (*MatchResultResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MatchResultResponse) GetStatusCode() MatchResultResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return MatchResultResponse_OK
+}
+
+func (x *MatchResultResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MatchResultResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Partida terminada, tal como la guarda el historial del Matchmaker.
+type MatchRecord struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Sequence         uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // orden de cierre en el namespace
+	MatchId          string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerId         string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	GameMode         string                 `protobuf:"bytes,4,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	PlayerIds        []string               `protobuf:"bytes,5,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	WinnerId         string                 `protobuf:"bytes,6,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // sólo en COMPLETED
+	DurationMs       int64                  `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	StartedAtUnixMs  int64                  `protobuf:"varint,8,opt,name=started_at_unix_ms,json=startedAtUnixMs,proto3" json:"started_at_unix_ms,omitempty"`
+	FinishedAtUnixMs int64                  `protobuf:"varint,9,opt,name=finished_at_unix_ms,json=finishedAtUnixMs,proto3" json:"finished_at_unix_ms,omitempty"`
+	Outcome          MatchRecord_Outcome    `protobuf:"varint,10,opt,name=outcome,proto3,enum=matchmaking.MatchRecord_Outcome" json:"outcome,omitempty"`
+	PlayerStats      []*PlayerMatchStats    `protobuf:"bytes,11,rep,name=player_stats,json=playerStats,proto3" json:"player_stats,omitempty"`
+	Timeline         []*MatchEvent          `protobuf:"bytes,12,rep,name=timeline,proto3" json:"timeline,omitempty"`
+	WinnerIds        []string               `protobuf:"bytes,13,rep,name=winner_ids,json=winnerIds,proto3" json:"winner_ids,omitempty"` // sólo en COMPLETED
+	ScoreEvents      []*ScoreEvent          `protobuf:"bytes,14,rep,name=score_events,json=scoreEvents,proto3" json:"score_events,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MatchRecord) Reset() {
+	*x = MatchRecord{}
+	mi := &file_proto_matchmaking_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRecord) ProtoMessage() {}
+
+func (x *MatchRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRecord.ProtoReflect.Descriptor instead.
+func (*MatchRecord) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MatchRecord) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *MatchRecord) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetStartedAtUnixMs() int64 {
+	if x != nil {
+		return x.StartedAtUnixMs
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetFinishedAtUnixMs() int64 {
+	if x != nil {
+		return x.FinishedAtUnixMs
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetOutcome() MatchRecord_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return MatchRecord_COMPLETED
+}
+
+func (x *MatchRecord) GetPlayerStats() []*PlayerMatchStats {
+	if x != nil {
+		return x.PlayerStats
+	}
+	return nil
+}
+
+func (x *MatchRecord) GetTimeline() []*MatchEvent {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
+}
+
+func (x *MatchRecord) GetWinnerIds() []string {
+	if x != nil {
+		return x.WinnerIds
+	}
+	return nil
+}
+
+func (x *MatchRecord) GetScoreEvents() []*ScoreEvent {
+	if x != nil {
+		return x.ScoreEvents
+	}
+	return nil
+}
+
+// Hito en la vida de una partida, con la hora de pared y el reloj del
+// namespace en ese momento.
+type MatchEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stage         MatchEvent_Stage       `protobuf:"varint,1,opt,name=stage,proto3,enum=matchmaking.MatchEvent_Stage" json:"stage,omitempty"`
+	UnixMs        int64                  `protobuf:"varint,2,opt,name=unix_ms,json=unixMs,proto3" json:"unix_ms,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,4,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // sólo en QUEUED
+	Detail        string                 `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchEvent) Reset() {
+	*x = MatchEvent{}
+	mi := &file_proto_matchmaking_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchEvent) ProtoMessage() {}
+
+func (x *MatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchEvent.ProtoReflect.Descriptor instead.
+func (*MatchEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MatchEvent) GetStage() MatchEvent_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return MatchEvent_QUEUED
+}
+
+func (x *MatchEvent) GetUnixMs() int64 {
+	if x != nil {
+		return x.UnixMs
+	}
+	return 0
+}
+
+func (x *MatchEvent) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *MatchEvent) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MatchEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type MatchTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchTimelineRequest) Reset() {
+	*x = MatchTimelineRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchTimelineRequest) ProtoMessage() {}
+
+func (x *MatchTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchTimelineRequest.ProtoReflect.Descriptor instead.
+func (*MatchTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MatchTimelineRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *MatchTimelineRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+type MatchTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Found         bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	GameMode      string                 `protobuf:"bytes,3,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	ServerId      string                 `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Events        []*MatchEvent          `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"` // por etapa; dentro de QUEUED, por hora
+	VectorClock   *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	ServerAddr    string                 `protobuf:"bytes,7,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"` // sólo en curso: para SpectateMatch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchTimelineResponse) Reset() {
+	*x = MatchTimelineResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchTimelineResponse) ProtoMessage() {}
+
+func (x *MatchTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchTimelineResponse.ProtoReflect.Descriptor instead.
+func (*MatchTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MatchTimelineResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *MatchTimelineResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchTimelineResponse) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *MatchTimelineResponse) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MatchTimelineResponse) GetEvents() []*MatchEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *MatchTimelineResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *MatchTimelineResponse) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+type MatchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`    // vacío = todas las partidas del namespace
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 = 20; máximo 100
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token de la página anterior
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchHistoryRequest) Reset() {
+	*x = MatchHistoryRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryRequest) ProtoMessage() {}
+
+func (x *MatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*MatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MatchHistoryRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *MatchHistoryRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MatchHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *MatchHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type MatchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*MatchRecord         `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`                                    // de la más reciente a la más antigua
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // vacío = no hay más
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchHistoryResponse) Reset() {
+	*x = MatchHistoryResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryResponse) ProtoMessage() {}
+
+func (x *MatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*MatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *MatchHistoryResponse) GetMatches() []*MatchRecord {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *MatchHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *MatchHistoryResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Acumulado de un jugador: partidas con resultado informado y esperas en
+// cola hasta ser emparejado.
+type PlayerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	MatchesPlayed int32                  `protobuf:"varint,2,opt,name=matches_played,json=matchesPlayed,proto3" json:"matches_played,omitempty"`
+	Wins          int32                  `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	WinRate       float64                `protobuf:"fixed64,4,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"` // wins / matches_played; 0 sin partidas
+	AvgWaitMs     int64                  `protobuf:"varint,5,opt,name=avg_wait_ms,json=avgWaitMs,proto3" json:"avg_wait_ms,omitempty"`
+	TotalWaitMs   int64                  `protobuf:"varint,6,opt,name=total_wait_ms,json=totalWaitMs,proto3" json:"total_wait_ms,omitempty"`
+	Waits         int32                  `protobuf:"varint,7,opt,name=waits,proto3" json:"waits,omitempty"` // emparejamientos promediados en avg_wait_ms
+	Rank          int32                  `protobuf:"varint,8,opt,name=rank,proto3" json:"rank,omitempty"`   // posición en la clasificación, desde 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerStats) Reset() {
+	*x = PlayerStats{}
+	mi := &file_proto_matchmaking_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerStats) ProtoMessage() {}
+
+func (x *PlayerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStats.ProtoReflect.Descriptor instead.
+func (*PlayerStats) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PlayerStats) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerStats) GetMatchesPlayed() int32 {
+	if x != nil {
+		return x.MatchesPlayed
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetAvgWaitMs() int64 {
+	if x != nil {
+		return x.AvgWaitMs
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetTotalWaitMs() int64 {
+	if x != nil {
+		return x.TotalWaitMs
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetWaits() int32 {
+	if x != nil {
+		return x.Waits
+	}
+	return 0
+}
+
+func (x *PlayerStats) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type LeaderboardRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Namespace     string                    `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	SortBy        LeaderboardRequest_SortBy `protobuf:"varint,2,opt,name=sort_by,json=sortBy,proto3,enum=matchmaking.LeaderboardRequest_SortBy" json:"sort_by,omitempty"`
+	PageSize      int32                     `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 = 20; máximo 100
+	PageToken     string                    `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token de la página anterior
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *LeaderboardRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetSortBy() LeaderboardRequest_SortBy {
+	if x != nil {
+		return x.SortBy
+	}
+	return LeaderboardRequest_WINS
+}
+
+func (x *LeaderboardRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *LeaderboardRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type LeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*PlayerStats         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // vacío = no hay más
+	TotalPlayers  int32                  `protobuf:"varint,3,opt,name=total_players,json=totalPlayers,proto3" json:"total_players,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardResponse) Reset() {
+	*x = LeaderboardResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponse) ProtoMessage() {}
+
+func (x *LeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *LeaderboardResponse) GetEntries() []*PlayerStats {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *LeaderboardResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetTotalPlayers() int32 {
+	if x != nil {
+		return x.TotalPlayers
+	}
+	return 0
+}
+
+func (x *LeaderboardResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// El GameServer se reinició y perdió la partida (ver su archivo de estado).
+type AbortMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortMatchRequest) Reset() {
+	*x = AbortMatchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMatchRequest) ProtoMessage() {}
+
+func (x *AbortMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMatchRequest.ProtoReflect.Descriptor instead.
+func (*AbortMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AbortMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AbortMatchRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *AbortMatchRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AbortMatchRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AbortMatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type AbortMatchResponse struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	StatusCode    AbortMatchResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.AbortMatchResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock   *VectorClock                  `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortMatchResponse) Reset() {
+	*x = AbortMatchResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortMatchResponse) ProtoMessage() {}
+
+func (x *AbortMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortMatchResponse.ProtoReflect.Descriptor instead.
+func (*AbortMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *AbortMatchResponse) GetStatusCode() AbortMatchResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return AbortMatchResponse_OK
+}
+
+func (x *AbortMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AbortMatchResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// El GameServer pide completar una sala en curso con jugadores de la cola:
+// open_slots es cuántos le faltan (0 deja de pedir) y departed_player_ids
+// los que se desconectaron, que salen de la partida y liberan su lugar.
+// Sólo vale para salas (lobby_max_players > 0): en los modos por equipos
+// un jugador nuevo desarmaría los equipos.
+type RequestBackfillRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ServerId          string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Namespace         string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	MatchId           string                 `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	OpenSlots         int32                  `protobuf:"varint,4,opt,name=open_slots,json=openSlots,proto3" json:"open_slots,omitempty"`
+	DepartedPlayerIds []string               `protobuf:"bytes,5,rep,name=departed_player_ids,json=departedPlayerIds,proto3" json:"departed_player_ids,omitempty"`
+	Clock             *VectorClock           `protobuf:"bytes,6,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RequestBackfillRequest) Reset() {
+	*x = RequestBackfillRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestBackfillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestBackfillRequest) ProtoMessage() {}
+
+func (x *RequestBackfillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestBackfillRequest.ProtoReflect.Descriptor instead.
+func (*RequestBackfillRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RequestBackfillRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RequestBackfillRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RequestBackfillRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RequestBackfillRequest) GetOpenSlots() int32 {
+	if x != nil {
+		return x.OpenSlots
+	}
+	return 0
+}
+
+func (x *RequestBackfillRequest) GetDepartedPlayerIds() []string {
+	if x != nil {
+		return x.DepartedPlayerIds
+	}
+	return nil
+}
+
+func (x *RequestBackfillRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type RequestBackfillResponse struct {
+	state         protoimpl.MessageState             `protogen:"open.v1"`
+	StatusCode    RequestBackfillResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.RequestBackfillResponse_StatusCode" json:"status_code,omitempty"`
+	OpenSlots     int32                              `protobuf:"varint,2,opt,name=open_slots,json=openSlots,proto3" json:"open_slots,omitempty"` // huecos aceptados (acotados por el tamaño de la sala)
+	Message       string                             `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock   *VectorClock                       `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestBackfillResponse) Reset() {
+	*x = RequestBackfillResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestBackfillResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestBackfillResponse) ProtoMessage() {}
+
+func (x *RequestBackfillResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestBackfillResponse.ProtoReflect.Descriptor instead.
+func (*RequestBackfillResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RequestBackfillResponse) GetStatusCode() RequestBackfillResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return RequestBackfillResponse_OK
+}
+
+func (x *RequestBackfillResponse) GetOpenSlots() int32 {
+	if x != nil {
+		return x.OpenSlots
+	}
+	return 0
+}
+
+func (x *RequestBackfillResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestBackfillResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// El GameServer se apaga: el Matchmaker lo quita del pool (en vez de dejarlo
+// CAIDO para siempre) y cierra sin resultado sus partidas en curso.
+type DeregisterServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // p.ej. "shutdown"
+	Clock         *VectorClock           `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterServerRequest) Reset() {
+	*x = DeregisterServerRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterServerRequest) ProtoMessage() {}
+
+func (x *DeregisterServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterServerRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterServerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeregisterServerRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DeregisterServerRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeregisterServerRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeregisterServerRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type DeregisterServerResponse struct {
+	state          protoimpl.MessageState              `protogen:"open.v1"`
+	StatusCode     DeregisterServerResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.DeregisterServerResponse_StatusCode" json:"status_code,omitempty"`
+	AbortedMatches int32                               `protobuf:"varint,2,opt,name=aborted_matches,json=abortedMatches,proto3" json:"aborted_matches,omitempty"` // partidas en curso cerradas sin resultado
+	Message        string                              `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock    *VectorClock                        `protobuf:"bytes,4,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeregisterServerResponse) Reset() {
+	*x = DeregisterServerResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterServerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterServerResponse) ProtoMessage() {}
+
+func (x *DeregisterServerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterServerResponse.ProtoReflect.Descriptor instead.
+func (*DeregisterServerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeregisterServerResponse) GetStatusCode() DeregisterServerResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return DeregisterServerResponse_OK
+}
+
+func (x *DeregisterServerResponse) GetAbortedMatches() int32 {
+	if x != nil {
+		return x.AbortedMatches
+	}
+	return 0
+}
+
+func (x *DeregisterServerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeregisterServerResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Opinión de un jugador sobre una partida ya finalizada.
+type MatchFeedbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Rating        int32                  `protobuf:"varint,3,opt,name=rating,proto3" json:"rating,omitempty"`  // 1-5
+	Lag           bool                   `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`        // el jugador reporta lag
+	Comment       string                 `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"` // opcional
+	Clock         *VectorClock           `protobuf:"bytes,6,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchFeedbackRequest) Reset() {
+	*x = MatchFeedbackRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchFeedbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchFeedbackRequest) ProtoMessage() {}
+
+func (x *MatchFeedbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchFeedbackRequest.ProtoReflect.Descriptor instead.
+func (*MatchFeedbackRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *MatchFeedbackRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MatchFeedbackRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchFeedbackRequest) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *MatchFeedbackRequest) GetLag() bool {
+	if x != nil {
+		return x.Lag
+	}
+	return false
+}
+
+func (x *MatchFeedbackRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *MatchFeedbackRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *MatchFeedbackRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type MatchFeedbackResponse struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	StatusCode    MatchFeedbackResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.MatchFeedbackResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                           `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock   *VectorClock                     `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchFeedbackResponse) Reset() {
+	*x = MatchFeedbackResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchFeedbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchFeedbackResponse) ProtoMessage() {}
+
+func (x *MatchFeedbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchFeedbackResponse.ProtoReflect.Descriptor instead.
+func (*MatchFeedbackResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *MatchFeedbackResponse) GetStatusCode() MatchFeedbackResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return MatchFeedbackResponse_OK
+}
+
+func (x *MatchFeedbackResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MatchFeedbackResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Grupos: se encolan juntos y siempre caen en el mismo equipo.
+// CreateParty sólo usa player_id; JoinParty además party_id.
+type PartyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	PartyId       string                 `protobuf:"bytes,2,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartyRequest) Reset() {
+	*x = PartyRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartyRequest) ProtoMessage() {}
+
+func (x *PartyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartyRequest.ProtoReflect.Descriptor instead.
+func (*PartyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *PartyRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PartyRequest) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+func (x *PartyRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *PartyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type PartyResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	StatusCode    PartyResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.PartyResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	PartyId       string                   `protobuf:"bytes,3,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	LeaderId      string                   `protobuf:"bytes,4,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	MemberIds     []string                 `protobuf:"bytes,5,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
+	VectorClock   *VectorClock             `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartyResponse) Reset() {
+	*x = PartyResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartyResponse) ProtoMessage() {}
+
+func (x *PartyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartyResponse.ProtoReflect.Descriptor instead.
+func (*PartyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PartyResponse) GetStatusCode() PartyResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return PartyResponse_OK
+}
+
+func (x *PartyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PartyResponse) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+func (x *PartyResponse) GetLeaderId() string {
+	if x != nil {
+		return x.LeaderId
+	}
+	return ""
+}
+
+func (x *PartyResponse) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *PartyResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Salas privadas: un jugador crea la sala para un modo, fijada a un
+// GameServer, y comparte su código; los demás entran con JoinLobby. Al
+// llenarse, la partida arranca en ese servidor sin pasar por la cola.
+type CreateLobbyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	GameMode      string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"` // vacío = "1v1"
+	ServerId      string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // GameServer elegido; vacío = el mejor disponible al llenarse
+	Clock         *VectorClock           `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLobbyRequest) Reset() {
+	*x = CreateLobbyRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLobbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLobbyRequest) ProtoMessage() {}
+
+func (x *CreateLobbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLobbyRequest.ProtoReflect.Descriptor instead.
+func (*CreateLobbyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateLobbyRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *CreateLobbyRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *CreateLobbyRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *CreateLobbyRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *CreateLobbyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// JoinLobby usa player_id y code; LeaveLobby sólo player_id.
+type LobbyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LobbyRequest) Reset() {
+	*x = LobbyRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LobbyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LobbyRequest) ProtoMessage() {}
+
+func (x *LobbyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LobbyRequest.ProtoReflect.Descriptor instead.
+func (*LobbyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *LobbyRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LobbyRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LobbyRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *LobbyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type LobbyResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	StatusCode    LobbyResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.LobbyResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Code          string                   `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	GameMode      string                   `protobuf:"bytes,4,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	ServerId      string                   `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	OwnerId       string                   `protobuf:"bytes,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	MemberIds     []string                 `protobuf:"bytes,7,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"` // el dueño primero, luego por orden de llegada
+	Capacity      int32                    `protobuf:"varint,8,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	MatchId       string                   `protobuf:"bytes,9,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"` // la sala se llenó y arrancó esta partida
+	VectorClock   *VectorClock             `protobuf:"bytes,10,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LobbyResponse) Reset() {
+	*x = LobbyResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LobbyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LobbyResponse) ProtoMessage() {}
+
+func (x *LobbyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LobbyResponse.ProtoReflect.Descriptor instead.
+func (*LobbyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *LobbyResponse) GetStatusCode() LobbyResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return LobbyResponse_OK
+}
+
+func (x *LobbyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LobbyResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LobbyResponse) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *LobbyResponse) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *LobbyResponse) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *LobbyResponse) GetMemberIds() []string {
+	if x != nil {
+		return x.MemberIds
+	}
+	return nil
+}
+
+func (x *LobbyResponse) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *LobbyResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *LobbyResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type PingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingRequest) Reset() {
+	*x = PingRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingRequest) ProtoMessage() {}
+
+func (x *PingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
+func (*PingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *PingRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+type PingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alive         bool                   `protobuf:"varint,1,opt,name=alive,proto3" json:"alive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PingResponse) Reset() {
+	*x = PingResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PingResponse) ProtoMessage() {}
+
+func (x *PingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
+func (*PingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *PingResponse) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+// ──────────── MENSAJES ADMIN ──────────
+type AdminRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"` // ámbito de la consulta; vacío = "default"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminRequest) Reset() {
+	*x = AdminRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminRequest) ProtoMessage() {}
+
+func (x *AdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminRequest.ProtoReflect.Descriptor instead.
+func (*AdminRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *AdminRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ServerInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ServerId       string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Status         ServerStatus           `protobuf:"varint,2,opt,name=status,proto3,enum=matchmaking.ServerStatus" json:"status,omitempty"`
+	Address        string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	CurrentMatchId string                 `protobuf:"bytes,4,opt,name=current_match_id,json=currentMatchId,proto3" json:"current_match_id,omitempty"` // partidas en curso, separadas por coma
+	LastHeartbeat  int64                  `protobuf:"varint,5,opt,name=last_heartbeat,json=lastHeartbeat,proto3" json:"last_heartbeat,omitempty"`     // unix seconds
+	Draining       bool                   `protobuf:"varint,6,opt,name=draining,proto3" json:"draining,omitempty"`                                    // no recibe nuevas partidas
+	Region         string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	LatencyMs      uint32                 `protobuf:"varint,8,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Capacity       int32                  `protobuf:"varint,9,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	FreeSlots      int32                  `protobuf:"varint,10,opt,name=free_slots,json=freeSlots,proto3" json:"free_slots,omitempty"`
+	Utilization    float64                `protobuf:"fixed64,11,opt,name=utilization,proto3" json:"utilization,omitempty"`                         // huecos ocupados / capacidad, ahora
+	MatchesRecent  int32                  `protobuf:"varint,12,opt,name=matches_recent,json=matchesRecent,proto3" json:"matches_recent,omitempty"` // partidas asignadas en la ventana
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ServerInfo) Reset() {
+	*x = ServerInfo{}
+	mi := &file_proto_matchmaking_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerInfo) ProtoMessage() {}
+
+func (x *ServerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerInfo.ProtoReflect.Descriptor instead.
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ServerInfo) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerInfo) GetStatus() ServerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ServerStatus_UNKNOWN
+}
+
+func (x *ServerInfo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ServerInfo) GetCurrentMatchId() string {
+	if x != nil {
+		return x.CurrentMatchId
+	}
+	return ""
+}
+
+func (x *ServerInfo) GetLastHeartbeat() int64 {
+	if x != nil {
+		return x.LastHeartbeat
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *ServerInfo) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ServerInfo) GetLatencyMs() uint32 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetFreeSlots() int32 {
+	if x != nil {
+		return x.FreeSlots
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+func (x *ServerInfo) GetMatchesRecent() int32 {
+	if x != nil {
+		return x.MatchesRecent
+	}
+	return 0
+}
+
+type PlayerQueueEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId       string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	SecondsInQueue int64                  `protobuf:"varint,2,opt,name=seconds_in_queue,json=secondsInQueue,proto3" json:"seconds_in_queue,omitempty"`
+	Priority       QueuePriority          `protobuf:"varint,3,opt,name=priority,proto3,enum=matchmaking.QueuePriority" json:"priority,omitempty"`
+	Tier           PlayerTier             `protobuf:"varint,4,opt,name=tier,proto3,enum=matchmaking.PlayerTier" json:"tier,omitempty"`
+	Region         string                 `protobuf:"bytes,5,opt,name=region,proto3" json:"region,omitempty"`
+	PartyId        string                 `protobuf:"bytes,6,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	GameMode       string                 `protobuf:"bytes,7,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlayerQueueEntry) Reset() {
+	*x = PlayerQueueEntry{}
+	mi := &file_proto_matchmaking_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerQueueEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerQueueEntry) ProtoMessage() {}
+
+func (x *PlayerQueueEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerQueueEntry.ProtoReflect.Descriptor instead.
+func (*PlayerQueueEntry) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *PlayerQueueEntry) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerQueueEntry) GetSecondsInQueue() int64 {
+	if x != nil {
+		return x.SecondsInQueue
+	}
+	return 0
+}
+
+func (x *PlayerQueueEntry) GetPriority() QueuePriority {
+	if x != nil {
+		return x.Priority
+	}
+	return QueuePriority_PRIORITY_NORMAL
+}
+
+func (x *PlayerQueueEntry) GetTier() PlayerTier {
+	if x != nil {
+		return x.Tier
+	}
+	return PlayerTier_TIER_NORMAL
+}
+
+func (x *PlayerQueueEntry) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PlayerQueueEntry) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+func (x *PlayerQueueEntry) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+// Cola y ritmo de emparejamiento de un modo de juego.
+type ModeQueueStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	GameMode         string                 `protobuf:"bytes,1,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	Enabled          bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Queued           int32                  `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	AvgWaitMs        int64                  `protobuf:"varint,4,opt,name=avg_wait_ms,json=avgWaitMs,proto3" json:"avg_wait_ms,omitempty"` // espera media de los que siguen en cola
+	MaxWaitMs        int64                  `protobuf:"varint,5,opt,name=max_wait_ms,json=maxWaitMs,proto3" json:"max_wait_ms,omitempty"`
+	MatchesRecent    int32                  `protobuf:"varint,6,opt,name=matches_recent,json=matchesRecent,proto3" json:"matches_recent,omitempty"`              // partidas formadas en la ventana
+	AvgMatchedWaitMs int64                  `protobuf:"varint,7,opt,name=avg_matched_wait_ms,json=avgMatchedWaitMs,proto3" json:"avg_matched_wait_ms,omitempty"` // espera media de sus jugadores
+	ActiveMatches    int32                  `protobuf:"varint,8,opt,name=active_matches,json=activeMatches,proto3" json:"active_matches,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ModeQueueStats) Reset() {
+	*x = ModeQueueStats{}
+	mi := &file_proto_matchmaking_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeQueueStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeQueueStats) ProtoMessage() {}
+
+func (x *ModeQueueStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeQueueStats.ProtoReflect.Descriptor instead.
+func (*ModeQueueStats) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ModeQueueStats) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *ModeQueueStats) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ModeQueueStats) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+func (x *ModeQueueStats) GetAvgWaitMs() int64 {
+	if x != nil {
+		return x.AvgWaitMs
+	}
+	return 0
+}
+
+func (x *ModeQueueStats) GetMaxWaitMs() int64 {
+	if x != nil {
+		return x.MaxWaitMs
+	}
+	return 0
+}
+
+func (x *ModeQueueStats) GetMatchesRecent() int32 {
+	if x != nil {
+		return x.MatchesRecent
+	}
+	return 0
+}
+
+func (x *ModeQueueStats) GetAvgMatchedWaitMs() int64 {
+	if x != nil {
+		return x.AvgMatchedWaitMs
+	}
+	return 0
+}
+
+func (x *ModeQueueStats) GetActiveMatches() int32 {
+	if x != nil {
+		return x.ActiveMatches
+	}
+	return 0
+}
+
+type SystemStatusResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Servers     []*ServerInfo          `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	PlayerQueue []*PlayerQueueEntry    `protobuf:"bytes,2,rep,name=player_queue,json=playerQueue,proto3" json:"player_queue,omitempty"`
+	VectorClock *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	Namespace   string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Namespaces  []string               `protobuf:"bytes,5,rep,name=namespaces,proto3" json:"namespaces,omitempty"` // todos los namespaces conocidos
+	Banned      []*BannedPlayer        `protobuf:"bytes,6,rep,name=banned,proto3" json:"banned,omitempty"`
+	Modes       []*ModeQueueStats      `protobuf:"bytes,7,rep,name=modes,proto3" json:"modes,omitempty"`
+	WindowS     int64                  `protobuf:"varint,8,opt,name=window_s,json=windowS,proto3" json:"window_s,omitempty"` // ventana de los *_recent
+	// Servidores retirados del pool (dados de baja o drenados), los más
+	// recientes primero; a diferencia de los CAIDO, ya no figuran en servers.
+	Removed        []*RemovedServer `protobuf:"bytes,9,rep,name=removed,proto3" json:"removed,omitempty"`
+	QueueRateLimit *RateLimitStats  `protobuf:"bytes,10,opt,name=queue_rate_limit,json=queueRateLimit,proto3" json:"queue_rate_limit,omitempty"` // común a todos los namespaces
+	MatchmakerId   string           `protobuf:"bytes,11,opt,name=matchmaker_id,json=matchmakerId,proto3" json:"matchmaker_id,omitempty"`
+	// Modos que atiende este Matchmaker (MATCHMAKER_MODES); vacío = todos.
+	OwnedModes    []string       `protobuf:"bytes,12,rep,name=owned_modes,json=ownedModes,proto3" json:"owned_modes,omitempty"`
+	Matches       []*MatchStatus `protobuf:"bytes,13,rep,name=matches,proto3" json:"matches,omitempty"` // partidas activas, por id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemStatusResponse) Reset() {
+	*x = SystemStatusResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStatusResponse) ProtoMessage() {}
+
+func (x *SystemStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStatusResponse.ProtoReflect.Descriptor instead.
+func (*SystemStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SystemStatusResponse) GetServers() []*ServerInfo {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetPlayerQueue() []*PlayerQueueEntry {
+	if x != nil {
+		return x.PlayerQueue
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SystemStatusResponse) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetBanned() []*BannedPlayer {
+	if x != nil {
+		return x.Banned
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetModes() []*ModeQueueStats {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetWindowS() int64 {
+	if x != nil {
+		return x.WindowS
+	}
+	return 0
+}
+
+func (x *SystemStatusResponse) GetRemoved() []*RemovedServer {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetQueueRateLimit() *RateLimitStats {
+	if x != nil {
+		return x.QueueRateLimit
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetMatchmakerId() string {
+	if x != nil {
+		return x.MatchmakerId
+	}
+	return ""
+}
+
+func (x *SystemStatusResponse) GetOwnedModes() []string {
+	if x != nil {
+		return x.OwnedModes
+	}
+	return nil
+}
+
+func (x *SystemStatusResponse) GetMatches() []*MatchStatus {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// Partida activa con su fase y cuándo entró a cada una (0 = aún no).
+type MatchStatus struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MatchId          string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	GameMode         string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	ServerId         string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	PlayerIds        []string               `protobuf:"bytes,4,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	Phase            MatchPhase             `protobuf:"varint,5,opt,name=phase,proto3,enum=matchmaking.MatchPhase" json:"phase,omitempty"`
+	CreatedUnixMs    int64                  `protobuf:"varint,6,opt,name=created_unix_ms,json=createdUnixMs,proto3" json:"created_unix_ms,omitempty"`
+	AssignedUnixMs   int64                  `protobuf:"varint,7,opt,name=assigned_unix_ms,json=assignedUnixMs,proto3" json:"assigned_unix_ms,omitempty"`
+	InProgressUnixMs int64                  `protobuf:"varint,8,opt,name=in_progress_unix_ms,json=inProgressUnixMs,proto3" json:"in_progress_unix_ms,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MatchStatus) Reset() {
+	*x = MatchStatus{}
+	mi := &file_proto_matchmaking_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchStatus) ProtoMessage() {}
+
+func (x *MatchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchStatus.ProtoReflect.Descriptor instead.
+func (*MatchStatus) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *MatchStatus) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchStatus) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *MatchStatus) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MatchStatus) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *MatchStatus) GetPhase() MatchPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return MatchPhase_MATCH_PHASE_CREATED
+}
+
+func (x *MatchStatus) GetCreatedUnixMs() int64 {
+	if x != nil {
+		return x.CreatedUnixMs
+	}
+	return 0
+}
+
+func (x *MatchStatus) GetAssignedUnixMs() int64 {
+	if x != nil {
+		return x.AssignedUnixMs
+	}
+	return 0
+}
+
+func (x *MatchStatus) GetInProgressUnixMs() int64 {
+	if x != nil {
+		return x.InProgressUnixMs
+	}
+	return 0
+}
+
+// Limitador de QueuePlayer: un token bucket por jugador y uno global.
+type RateLimitStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerRate     float64                `protobuf:"fixed64,1,opt,name=player_rate,json=playerRate,proto3" json:"player_rate,omitempty"` // QueuePlayer por segundo y jugador; 0 = sin límite
+	PlayerBurst    int32                  `protobuf:"varint,2,opt,name=player_burst,json=playerBurst,proto3" json:"player_burst,omitempty"`
+	GlobalRate     float64                `protobuf:"fixed64,3,opt,name=global_rate,json=globalRate,proto3" json:"global_rate,omitempty"` // entre todos los jugadores; 0 = sin límite
+	GlobalBurst    int32                  `protobuf:"varint,4,opt,name=global_burst,json=globalBurst,proto3" json:"global_burst,omitempty"`
+	Allowed        uint64                 `protobuf:"varint,5,opt,name=allowed,proto3" json:"allowed,omitempty"` // desde el arranque
+	RejectedPlayer uint64                 `protobuf:"varint,6,opt,name=rejected_player,json=rejectedPlayer,proto3" json:"rejected_player,omitempty"`
+	RejectedGlobal uint64                 `protobuf:"varint,7,opt,name=rejected_global,json=rejectedGlobal,proto3" json:"rejected_global,omitempty"`
+	ActivePlayers  int32                  `protobuf:"varint,8,opt,name=active_players,json=activePlayers,proto3" json:"active_players,omitempty"` // jugadores con el bucket aún no lleno
+	GlobalTokens   float64                `protobuf:"fixed64,9,opt,name=global_tokens,json=globalTokens,proto3" json:"global_tokens,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RateLimitStats) Reset() {
+	*x = RateLimitStats{}
+	mi := &file_proto_matchmaking_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitStats) ProtoMessage() {}
+
+func (x *RateLimitStats) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitStats.ProtoReflect.Descriptor instead.
+func (*RateLimitStats) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RateLimitStats) GetPlayerRate() float64 {
+	if x != nil {
+		return x.PlayerRate
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetPlayerBurst() int32 {
+	if x != nil {
+		return x.PlayerBurst
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetGlobalRate() float64 {
+	if x != nil {
+		return x.GlobalRate
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetGlobalBurst() int32 {
+	if x != nil {
+		return x.GlobalBurst
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetAllowed() uint64 {
+	if x != nil {
+		return x.Allowed
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetRejectedPlayer() uint64 {
+	if x != nil {
+		return x.RejectedPlayer
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetRejectedGlobal() uint64 {
+	if x != nil {
+		return x.RejectedGlobal
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetActivePlayers() int32 {
+	if x != nil {
+		return x.ActivePlayers
+	}
+	return 0
+}
+
+func (x *RateLimitStats) GetGlobalTokens() float64 {
+	if x != nil {
+		return x.GlobalTokens
+	}
+	return 0
+}
+
+type RemovedServer struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServerId        string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Address         string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Reason          string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // "shutdown", "drained", …
+	RemovedAtUnixMs int64                  `protobuf:"varint,4,opt,name=removed_at_unix_ms,json=removedAtUnixMs,proto3" json:"removed_at_unix_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RemovedServer) Reset() {
+	*x = RemovedServer{}
+	mi := &file_proto_matchmaking_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovedServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovedServer) ProtoMessage() {}
+
+func (x *RemovedServer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovedServer.ProtoReflect.Descriptor instead.
+func (*RemovedServer) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RemovedServer) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RemovedServer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RemovedServer) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RemovedServer) GetRemovedAtUnixMs() int64 {
+	if x != nil {
+		return x.RemovedAtUnixMs
+	}
+	return 0
+}
+
+type BannedPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannedPlayer) Reset() {
+	*x = BannedPlayer{}
+	mi := &file_proto_matchmaking_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannedPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannedPlayer) ProtoMessage() {}
+
+func (x *BannedPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannedPlayer.ProtoReflect.Descriptor instead.
+func (*BannedPlayer) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *BannedPlayer) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *BannedPlayer) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ClockMetricsResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Entries             int32                  `protobuf:"varint,1,opt,name=entries,proto3" json:"entries,omitempty"` // componentes del reloj del Matchmaker
+	Merges              uint64                 `protobuf:"varint,2,opt,name=merges,proto3" json:"merges,omitempty"`
+	AvgMergeMicros      float64                `protobuf:"fixed64,3,opt,name=avg_merge_micros,json=avgMergeMicros,proto3" json:"avg_merge_micros,omitempty"`
+	MaxMergeMicros      float64                `protobuf:"fixed64,4,opt,name=max_merge_micros,json=maxMergeMicros,proto3" json:"max_merge_micros,omitempty"`
+	Serializations      uint64                 `protobuf:"varint,5,opt,name=serializations,proto3" json:"serializations,omitempty"`
+	AvgSerializedBytes  float64                `protobuf:"fixed64,6,opt,name=avg_serialized_bytes,json=avgSerializedBytes,proto3" json:"avg_serialized_bytes,omitempty"`
+	LastSerializedBytes int32                  `protobuf:"varint,7,opt,name=last_serialized_bytes,json=lastSerializedBytes,proto3" json:"last_serialized_bytes,omitempty"`
+	VectorClock         *VectorClock           `protobuf:"bytes,8,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	PrunedEntries       uint64                 `protobuf:"varint,9,opt,name=pruned_entries,json=prunedEntries,proto3" json:"pruned_entries,omitempty"`           // componentes podados por CLOCK_TTL
+	Tombstones          int32                  `protobuf:"varint,10,opt,name=tombstones,proto3" json:"tombstones,omitempty"`                                     // lápidas retenidas en el namespace
+	ReplicaConflicts    uint64                 `protobuf:"varint,11,opt,name=replica_conflicts,json=replicaConflicts,proto3" json:"replica_conflicts,omitempty"` // snapshots concurrentes o atrasados (respaldo)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ClockMetricsResponse) Reset() {
+	*x = ClockMetricsResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockMetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockMetricsResponse) ProtoMessage() {}
+
+func (x *ClockMetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockMetricsResponse.ProtoReflect.Descriptor instead.
+func (*ClockMetricsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ClockMetricsResponse) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetMerges() uint64 {
+	if x != nil {
+		return x.Merges
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetAvgMergeMicros() float64 {
+	if x != nil {
+		return x.AvgMergeMicros
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetMaxMergeMicros() float64 {
+	if x != nil {
+		return x.MaxMergeMicros
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetSerializations() uint64 {
+	if x != nil {
+		return x.Serializations
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetAvgSerializedBytes() float64 {
+	if x != nil {
+		return x.AvgSerializedBytes
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetLastSerializedBytes() int32 {
+	if x != nil {
+		return x.LastSerializedBytes
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *ClockMetricsResponse) GetPrunedEntries() uint64 {
+	if x != nil {
+		return x.PrunedEntries
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetTombstones() int32 {
+	if x != nil {
+		return x.Tombstones
+	}
+	return 0
+}
+
+func (x *ClockMetricsResponse) GetReplicaConflicts() uint64 {
+	if x != nil {
+		return x.ReplicaConflicts
+	}
+	return 0
+}
+
+// Último reloj que el Matchmaker recibió de un jugador o servidor.
+type EntityClock struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Kind           EntityClock_Kind       `protobuf:"varint,1,opt,name=kind,proto3,enum=matchmaking.EntityClock_Kind" json:"kind,omitempty"`
+	Id             string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Clock          *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	LastSeenUnixMs int64                  `protobuf:"varint,4,opt,name=last_seen_unix_ms,json=lastSeenUnixMs,proto3" json:"last_seen_unix_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EntityClock) Reset() {
+	*x = EntityClock{}
+	mi := &file_proto_matchmaking_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityClock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityClock) ProtoMessage() {}
+
+func (x *EntityClock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityClock.ProtoReflect.Descriptor instead.
+func (*EntityClock) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *EntityClock) GetKind() EntityClock_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return EntityClock_PLAYER
+}
+
+func (x *EntityClock) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EntityClock) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *EntityClock) GetLastSeenUnixMs() int64 {
+	if x != nil {
+		return x.LastSeenUnixMs
+	}
+	return 0
+}
+
+type ClockStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchmakerId  string                 `protobuf:"bytes,1,opt,name=matchmaker_id,json=matchmakerId,proto3" json:"matchmaker_id,omitempty"` // su componente en vector_clock
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`    // vista fusionada del namespace
+	Tombstones    *VectorClock           `protobuf:"bytes,3,opt,name=tombstones,proto3" json:"tombstones,omitempty"`                         // componentes podados → último valor
+	Entities      []*EntityClock         `protobuf:"bytes,4,rep,name=entities,proto3" json:"entities,omitempty"`                             // sólo los que enviaron reloj
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClockStateResponse) Reset() {
+	*x = ClockStateResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockStateResponse) ProtoMessage() {}
+
+func (x *ClockStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockStateResponse.ProtoReflect.Descriptor instead.
+func (*ClockStateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ClockStateResponse) GetMatchmakerId() string {
+	if x != nil {
+		return x.MatchmakerId
+	}
+	return ""
+}
+
+func (x *ClockStateResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+func (x *ClockStateResponse) GetTombstones() *VectorClock {
+	if x != nil {
+		return x.Tombstones
+	}
+	return nil
+}
+
+func (x *ClockStateResponse) GetEntities() []*EntityClock {
+	if x != nil {
+		return x.Entities
+	}
+	return nil
+}
+
+// Confiabilidad de un servidor según el historial y las opiniones.
+type ServerReliability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Matches       int32                  `protobuf:"varint,2,opt,name=matches,proto3" json:"matches,omitempty"` // partidas finalizadas
+	FeedbackCount int32                  `protobuf:"varint,3,opt,name=feedback_count,json=feedbackCount,proto3" json:"feedback_count,omitempty"`
+	AvgRating     float64                `protobuf:"fixed64,4,opt,name=avg_rating,json=avgRating,proto3" json:"avg_rating,omitempty"`
+	LagReports    int32                  `protobuf:"varint,5,opt,name=lag_reports,json=lagReports,proto3" json:"lag_reports,omitempty"`
+	LagRatio      float64                `protobuf:"fixed64,6,opt,name=lag_ratio,json=lagRatio,proto3" json:"lag_ratio,omitempty"` // lag_reports / feedback_count
+	Laggy         bool                   `protobuf:"varint,7,opt,name=laggy,proto3" json:"laggy,omitempty"`                        // lag crónico: revisar este servidor
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerReliability) Reset() {
+	*x = ServerReliability{}
+	mi := &file_proto_matchmaking_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerReliability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerReliability) ProtoMessage() {}
+
+func (x *ServerReliability) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerReliability.ProtoReflect.Descriptor instead.
+func (*ServerReliability) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ServerReliability) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ServerReliability) GetMatches() int32 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *ServerReliability) GetFeedbackCount() int32 {
+	if x != nil {
+		return x.FeedbackCount
+	}
+	return 0
+}
+
+func (x *ServerReliability) GetAvgRating() float64 {
+	if x != nil {
+		return x.AvgRating
+	}
+	return 0
+}
+
+func (x *ServerReliability) GetLagReports() int32 {
+	if x != nil {
+		return x.LagReports
+	}
+	return 0
+}
+
+func (x *ServerReliability) GetLagRatio() float64 {
+	if x != nil {
+		return x.LagRatio
+	}
+	return 0
+}
+
+func (x *ServerReliability) GetLaggy() bool {
+	if x != nil {
+		return x.Laggy
+	}
+	return false
+}
+
+type ReliabilityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Servers       []*ServerReliability   `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReliabilityResponse) Reset() {
+	*x = ReliabilityResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReliabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReliabilityResponse) ProtoMessage() {}
+
+func (x *ReliabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReliabilityResponse.ProtoReflect.Descriptor instead.
+func (*ReliabilityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ReliabilityResponse) GetServers() []*ServerReliability {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *ReliabilityResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type SLOStatus struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Mode            string                 `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Target          float64                `protobuf:"fixed64,2,opt,name=target,proto3" json:"target,omitempty"`                                         // fracción de jugadores, p.ej. 0.95
+	ThresholdMs     int64                  `protobuf:"varint,3,opt,name=threshold_ms,json=thresholdMs,proto3" json:"threshold_ms,omitempty"`             // espera máxima en cola
+	Matched         int32                  `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`                                        // emparejados dentro de la ventana
+	WithinThreshold int32                  `protobuf:"varint,5,opt,name=within_threshold,json=withinThreshold,proto3" json:"within_threshold,omitempty"` // … de ellos, a tiempo
+	Overdue         int32                  `protobuf:"varint,6,opt,name=overdue,proto3" json:"overdue,omitempty"`                                        // encolados que ya superaron el umbral
+	Compliance      float64                `protobuf:"fixed64,7,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	BurnRate        float64                `protobuf:"fixed64,8,opt,name=burn_rate,json=burnRate,proto3" json:"burn_rate,omitempty"` // 1 = se consume el presupuesto de error al ritmo permitido
+	AtRisk          bool                   `protobuf:"varint,9,opt,name=at_risk,json=atRisk,proto3" json:"at_risk,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SLOStatus) Reset() {
+	*x = SLOStatus{}
+	mi := &file_proto_matchmaking_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOStatus) ProtoMessage() {}
+
+func (x *SLOStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOStatus.ProtoReflect.Descriptor instead.
+func (*SLOStatus) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *SLOStatus) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SLOStatus) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetThresholdMs() int64 {
+	if x != nil {
+		return x.ThresholdMs
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetWithinThreshold() int32 {
+	if x != nil {
+		return x.WithinThreshold
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetOverdue() int32 {
+	if x != nil {
+		return x.Overdue
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetCompliance() float64 {
+	if x != nil {
+		return x.Compliance
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetBurnRate() float64 {
+	if x != nil {
+		return x.BurnRate
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetAtRisk() bool {
+	if x != nil {
+		return x.AtRisk
+	}
+	return false
+}
+
+type SLOStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slos          []*SLOStatus           `protobuf:"bytes,1,rep,name=slos,proto3" json:"slos,omitempty"`
+	WindowMs      int64                  `protobuf:"varint,2,opt,name=window_ms,json=windowMs,proto3" json:"window_ms,omitempty"`
+	VectorClock   *VectorClock           `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOStatusResponse) Reset() {
+	*x = SLOStatusResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOStatusResponse) ProtoMessage() {}
+
+func (x *SLOStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOStatusResponse.ProtoReflect.Descriptor instead.
+func (*SLOStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *SLOStatusResponse) GetSlos() []*SLOStatus {
+	if x != nil {
+		return x.Slos
+	}
+	return nil
+}
+
+func (x *SLOStatusResponse) GetWindowMs() int64 {
+	if x != nil {
+		return x.WindowMs
+	}
+	return 0
+}
+
+func (x *SLOStatusResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// Escenario hipotético para el simulador de capacidad. Los campos en cero
+// conservan el valor actual del namespace.
+type WhatIfRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Namespace      string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	GameMode       string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`                     // modo a proyectar; "" = todos
+	ExtraServers   int32                  `protobuf:"varint,3,opt,name=extra_servers,json=extraServers,proto3" json:"extra_servers,omitempty"`        // servidores a sumar (negativo = quitar)
+	TeamSize       int32                  `protobuf:"varint,4,opt,name=team_size,json=teamSize,proto3" json:"team_size,omitempty"`                    // tamaño de equipo del modo proyectado
+	DurationFactor float64                `protobuf:"fixed64,5,opt,name=duration_factor,json=durationFactor,proto3" json:"duration_factor,omitempty"` // 0.5 = partidas el doble de rápidas
+	WindowMs       int64                  `protobuf:"varint,6,opt,name=window_ms,json=windowMs,proto3" json:"window_ms,omitempty"`                    // historial de llegadas a reproducir; 0 = todo
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WhatIfRequest) Reset() {
+	*x = WhatIfRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhatIfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatIfRequest) ProtoMessage() {}
+
+func (x *WhatIfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatIfRequest.ProtoReflect.Descriptor instead.
+func (*WhatIfRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *WhatIfRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WhatIfRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *WhatIfRequest) GetExtraServers() int32 {
+	if x != nil {
+		return x.ExtraServers
+	}
+	return 0
+}
+
+func (x *WhatIfRequest) GetTeamSize() int32 {
+	if x != nil {
+		return x.TeamSize
+	}
+	return 0
+}
+
+func (x *WhatIfRequest) GetDurationFactor() float64 {
+	if x != nil {
+		return x.DurationFactor
+	}
+	return 0
+}
+
+func (x *WhatIfRequest) GetWindowMs() int64 {
+	if x != nil {
+		return x.WindowMs
+	}
+	return 0
+}
+
+type WaitProjection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	P50Ms         int64                  `protobuf:"varint,1,opt,name=p50_ms,json=p50Ms,proto3" json:"p50_ms,omitempty"`
+	P90Ms         int64                  `protobuf:"varint,2,opt,name=p90_ms,json=p90Ms,proto3" json:"p90_ms,omitempty"`
+	P99Ms         int64                  `protobuf:"varint,3,opt,name=p99_ms,json=p99Ms,proto3" json:"p99_ms,omitempty"`
+	Matched       int32                  `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`          // jugadores emparejados en la simulación
+	Unmatched     int32                  `protobuf:"varint,5,opt,name=unmatched,proto3" json:"unmatched,omitempty"`      // quedaron en cola al agotarse las llegadas
+	Utilization   float64                `protobuf:"fixed64,6,opt,name=utilization,proto3" json:"utilization,omitempty"` // fracción de huecos de servidor ocupados
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitProjection) Reset() {
+	*x = WaitProjection{}
+	mi := &file_proto_matchmaking_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitProjection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitProjection) ProtoMessage() {}
+
+func (x *WaitProjection) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitProjection.ProtoReflect.Descriptor instead.
+func (*WaitProjection) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *WaitProjection) GetP50Ms() int64 {
+	if x != nil {
+		return x.P50Ms
+	}
+	return 0
+}
+
+func (x *WaitProjection) GetP90Ms() int64 {
+	if x != nil {
+		return x.P90Ms
+	}
+	return 0
+}
+
+func (x *WaitProjection) GetP99Ms() int64 {
+	if x != nil {
+		return x.P99Ms
+	}
+	return 0
+}
+
+func (x *WaitProjection) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *WaitProjection) GetUnmatched() int32 {
+	if x != nil {
+		return x.Unmatched
+	}
+	return 0
+}
+
+func (x *WaitProjection) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+type WhatIfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Arrivals      int32                  `protobuf:"varint,1,opt,name=arrivals,proto3" json:"arrivals,omitempty"`                         // jugadores del historial reproducidos
+	WindowMs      int64                  `protobuf:"varint,2,opt,name=window_ms,json=windowMs,proto3" json:"window_ms,omitempty"`         // tramo de historial cubierto
+	Servers       int32                  `protobuf:"varint,3,opt,name=servers,proto3" json:"servers,omitempty"`                           // servidores en el escenario
+	Slots         int32                  `protobuf:"varint,4,opt,name=slots,proto3" json:"slots,omitempty"`                               // partidas simultáneas en el escenario
+	AvgMatchMs    int64                  `protobuf:"varint,5,opt,name=avg_match_ms,json=avgMatchMs,proto3" json:"avg_match_ms,omitempty"` // duración media simulada
+	Current       *WaitProjection        `protobuf:"bytes,6,opt,name=current,proto3" json:"current,omitempty"`                            // con los parámetros actuales
+	Projected     *WaitProjection        `protobuf:"bytes,7,opt,name=projected,proto3" json:"projected,omitempty"`                        // con los del escenario
+	VectorClock   *VectorClock           `protobuf:"bytes,8,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhatIfResponse) Reset() {
+	*x = WhatIfResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhatIfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatIfResponse) ProtoMessage() {}
+
+func (x *WhatIfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatIfResponse.ProtoReflect.Descriptor instead.
+func (*WhatIfResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *WhatIfResponse) GetArrivals() int32 {
+	if x != nil {
+		return x.Arrivals
+	}
+	return 0
+}
+
+func (x *WhatIfResponse) GetWindowMs() int64 {
+	if x != nil {
+		return x.WindowMs
+	}
+	return 0
+}
+
+func (x *WhatIfResponse) GetServers() int32 {
+	if x != nil {
+		return x.Servers
+	}
+	return 0
+}
+
+func (x *WhatIfResponse) GetSlots() int32 {
+	if x != nil {
+		return x.Slots
+	}
+	return 0
+}
+
+func (x *WhatIfResponse) GetAvgMatchMs() int64 {
+	if x != nil {
+		return x.AvgMatchMs
+	}
+	return 0
+}
+
+func (x *WhatIfResponse) GetCurrent() *WaitProjection {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *WhatIfResponse) GetProjected() *WaitProjection {
+	if x != nil {
+		return x.Projected
+	}
+	return nil
+}
+
+func (x *WhatIfResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// AdminReloadConfig relee MATCHMAKER_CONFIG y aplica los parámetros
+// recargables sin reiniciar ni vaciar la cola.
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{86}
+}
+
+type ConfigChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	OldValue      string                 `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_proto_matchmaking_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ConfigChange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+type ReloadConfigResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	StatusCode    ReloadConfigResponse_StatusCode `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3,enum=matchmaking.ReloadConfigResponse_StatusCode" json:"status_code,omitempty"`
+	Message       string                          `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Path          string                          `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Changes       []*ConfigChange                 `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"` // parámetros que cambiaron
+	Errors        []string                        `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`   // con INVALID, qué corregir
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ReloadConfigResponse) GetStatusCode() ReloadConfigResponse_StatusCode {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ReloadConfigResponse_OK
+}
+
+func (x *ReloadConfigResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReloadConfigResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ReloadConfigResponse) GetChanges() []*ConfigChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ReloadConfigResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type AdminServerUpdateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	NewStatus     ServerStatus           `protobuf:"varint,2,opt,name=new_status,json=newStatus,proto3,enum=matchmaking.ServerStatus" json:"new_status,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminServerUpdateRequest) Reset() {
+	*x = AdminServerUpdateRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminServerUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminServerUpdateRequest) ProtoMessage() {}
+
+func (x *AdminServerUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminServerUpdateRequest.ProtoReflect.Descriptor instead.
+func (*AdminServerUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *AdminServerUpdateRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *AdminServerUpdateRequest) GetNewStatus() ServerStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return ServerStatus_UNKNOWN
+}
+
+func (x *AdminServerUpdateRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AdminServerUpdateRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type QueueHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueueHistoryRequest) Reset() {
+	*x = QueueHistoryRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueueHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueHistoryRequest) ProtoMessage() {}
+
+func (x *QueueHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueHistoryRequest.ProtoReflect.Descriptor instead.
+func (*QueueHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *QueueHistoryRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Trozo de un CSV con columnas
+// unix_ms,namespace,queue_length,avg_wait_ms,max_wait_ms
+// El primer trozo incluye la cabecera.
+type CsvChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CsvChunk) Reset() {
+	*x = CsvChunk{}
+	mi := &file_proto_matchmaking_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CsvChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CsvChunk) ProtoMessage() {}
+
+func (x *CsvChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CsvChunk.ProtoReflect.Descriptor instead.
+func (*CsvChunk) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *CsvChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AdminUpsertGameModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          *GameMode              `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,2,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUpsertGameModeRequest) Reset() {
+	*x = AdminUpsertGameModeRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUpsertGameModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUpsertGameModeRequest) ProtoMessage() {}
+
+func (x *AdminUpsertGameModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUpsertGameModeRequest.ProtoReflect.Descriptor instead.
+func (*AdminUpsertGameModeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *AdminUpsertGameModeRequest) GetMode() *GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return nil
+}
+
+func (x *AdminUpsertGameModeRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AdminUpsertGameModeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type AdminSetPlayerTierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Tier          PlayerTier             `protobuf:"varint,2,opt,name=tier,proto3,enum=matchmaking.PlayerTier" json:"tier,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminSetPlayerTierRequest) Reset() {
+	*x = AdminSetPlayerTierRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminSetPlayerTierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSetPlayerTierRequest) ProtoMessage() {}
+
+func (x *AdminSetPlayerTierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSetPlayerTierRequest.ProtoReflect.Descriptor instead.
+func (*AdminSetPlayerTierRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *AdminSetPlayerTierRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *AdminSetPlayerTierRequest) GetTier() PlayerTier {
+	if x != nil {
+		return x.Tier
+	}
+	return PlayerTier_TIER_NORMAL
+}
+
+func (x *AdminSetPlayerTierRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AdminSetPlayerTierRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type AdminPlayerActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Lift          bool                   `protobuf:"varint,5,opt,name=lift,proto3" json:"lift,omitempty"` // sólo AdminBanPlayer: levanta el ban
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminPlayerActionRequest) Reset() {
+	*x = AdminPlayerActionRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminPlayerActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminPlayerActionRequest) ProtoMessage() {}
+
+func (x *AdminPlayerActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminPlayerActionRequest.ProtoReflect.Descriptor instead.
+func (*AdminPlayerActionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *AdminPlayerActionRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *AdminPlayerActionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AdminPlayerActionRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AdminPlayerActionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AdminPlayerActionRequest) GetLift() bool {
+	if x != nil {
+		return x.Lift
+	}
+	return false
+}
+
+// Termina una partida en curso: el GameServer la suelta (CancelMatch) y sus
+// jugadores quedan IDLE.
+type AdminTerminateMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminTerminateMatchRequest) Reset() {
+	*x = AdminTerminateMatchRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminTerminateMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminTerminateMatchRequest) ProtoMessage() {}
+
+func (x *AdminTerminateMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminTerminateMatchRequest.ProtoReflect.Descriptor instead.
+func (*AdminTerminateMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *AdminTerminateMatchRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *AdminTerminateMatchRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AdminTerminateMatchRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AdminTerminateMatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Transición de estado del Matchmaker, tal como quedó en su log de eventos.
+type StateEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // creciente en todo el Matchmaker
+	UnixMs        int64                  `protobuf:"varint,2,opt,name=unix_ms,json=unixMs,proto3" json:"unix_ms,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Kind          StateEvent_Kind        `protobuf:"varint,4,opt,name=kind,proto3,enum=matchmaking.StateEvent_Kind" json:"kind,omitempty"`
+	PlayerIds     []string               `protobuf:"bytes,5,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	MatchId       string                 `protobuf:"bytes,6,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,7,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Detail        string                 `protobuf:"bytes,8,opt,name=detail,proto3" json:"detail,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,9,opt,name=clock,proto3" json:"clock,omitempty"` // reloj del namespace tras la transición
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateEvent) Reset() {
+	*x = StateEvent{}
+	mi := &file_proto_matchmaking_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateEvent) ProtoMessage() {}
+
+func (x *StateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateEvent.ProtoReflect.Descriptor instead.
+func (*StateEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *StateEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *StateEvent) GetUnixMs() int64 {
+	if x != nil {
+		return x.UnixMs
+	}
+	return 0
+}
+
+func (x *StateEvent) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *StateEvent) GetKind() StateEvent_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return StateEvent_PLAYER_QUEUED
+}
+
+func (x *StateEvent) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *StateEvent) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *StateEvent) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *StateEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *StateEvent) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type EventLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                                  // vacío = todos
+	EntityId      string                 `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`                    // jugador, partida o servidor; vacío = todos
+	SinceUnixMs   int64                  `protobuf:"varint,3,opt,name=since_unix_ms,json=sinceUnixMs,proto3" json:"since_unix_ms,omitempty"`        // 0 = desde el principio
+	UntilUnixMs   int64                  `protobuf:"varint,4,opt,name=until_unix_ms,json=untilUnixMs,proto3" json:"until_unix_ms,omitempty"`        // 0 = hasta ahora
+	Kinds         []StateEvent_Kind      `protobuf:"varint,5,rep,packed,name=kinds,proto3,enum=matchmaking.StateEvent_Kind" json:"kinds,omitempty"` // vacío = todos
+	AfterSeq      uint64                 `protobuf:"varint,6,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`                   // para paginar: last_sequence de la respuesta anterior
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`                                         // 0 = 100; máximo 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventLogRequest) Reset() {
+	*x = EventLogRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventLogRequest) ProtoMessage() {}
+
+func (x *EventLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventLogRequest.ProtoReflect.Descriptor instead.
+func (*EventLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *EventLogRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *EventLogRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *EventLogRequest) GetSinceUnixMs() int64 {
+	if x != nil {
+		return x.SinceUnixMs
+	}
+	return 0
+}
+
+func (x *EventLogRequest) GetUntilUnixMs() int64 {
+	if x != nil {
+		return x.UntilUnixMs
+	}
+	return 0
+}
+
+func (x *EventLogRequest) GetKinds() []StateEvent_Kind {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *EventLogRequest) GetAfterSeq() uint64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+func (x *EventLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type EventLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*StateEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`                                  // del más antiguo al más reciente
+	LastSequence  uint64                 `protobuf:"varint,2,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"` // del último evento devuelto
+	More          bool                   `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`                                     // quedan eventos que cumplen el filtro
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventLogResponse) Reset() {
+	*x = EventLogResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventLogResponse) ProtoMessage() {}
+
+func (x *EventLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventLogResponse.ProtoReflect.Descriptor instead.
+func (*EventLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *EventLogResponse) GetEvents() []*StateEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *EventLogResponse) GetLastSequence() uint64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+func (x *EventLogResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+// Auditoría de administración: cada RPC Admin* que cambia estado, con quién
+// la hizo, el valor anterior y el nuevo (AdminGetAuditLog).
+type AdminAuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"` // creciente en todo el Matchmaker
+	UnixMs        int64                  `protobuf:"varint,2,opt,name=unix_ms,json=unixMs,proto3" json:"unix_ms,omitempty"`
+	AdminId       string                 `protobuf:"bytes,3,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"` // CN del certificado con mTLS; si no, el de "x-admin-id"
+	Peer          string                 `protobuf:"bytes,4,opt,name=peer,proto3" json:"peer,omitempty"`                      // dirección del cliente
+	RequestId     string                 `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Method        string                 `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"` // p. ej. "AdminUpdateServerState"
+	Namespace     string                 `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Target        string                 `protobuf:"bytes,8,opt,name=target,proto3" json:"target,omitempty"`                                    // servidor, jugador, modo, partida…
+	PreviousValue string                 `protobuf:"bytes,9,opt,name=previous_value,json=previousValue,proto3" json:"previous_value,omitempty"` // vacío = no existía o no aplica
+	NewValue      string                 `protobuf:"bytes,10,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	Result        string                 `protobuf:"bytes,11,opt,name=result,proto3" json:"result,omitempty"` // status de la respuesta o código gRPC del error
+	Message       string                 `protobuf:"bytes,12,opt,name=message,proto3" json:"message,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,13,opt,name=clock,proto3" json:"clock,omitempty"` // reloj del namespace al terminar la RPC
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminAuditEntry) Reset() {
+	*x = AdminAuditEntry{}
+	mi := &file_proto_matchmaking_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminAuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminAuditEntry) ProtoMessage() {}
+
+func (x *AdminAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminAuditEntry.ProtoReflect.Descriptor instead.
+func (*AdminAuditEntry) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *AdminAuditEntry) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AdminAuditEntry) GetUnixMs() int64 {
+	if x != nil {
+		return x.UnixMs
+	}
+	return 0
+}
+
+func (x *AdminAuditEntry) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetPreviousValue() string {
+	if x != nil {
+		return x.PreviousValue
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AdminAuditEntry) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type AuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                           // vacío = todos
+	AdminId       string                 `protobuf:"bytes,2,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`                // vacío = todos
+	Target        string                 `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`                                 // vacío = todos
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`                                 // vacío = todos
+	SinceUnixMs   int64                  `protobuf:"varint,5,opt,name=since_unix_ms,json=sinceUnixMs,proto3" json:"since_unix_ms,omitempty"` // 0 = desde el principio
+	UntilUnixMs   int64                  `protobuf:"varint,6,opt,name=until_unix_ms,json=untilUnixMs,proto3" json:"until_unix_ms,omitempty"` // 0 = hasta ahora
+	AfterSeq      uint64                 `protobuf:"varint,7,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`            // para paginar: last_sequence de la respuesta anterior
+	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`                                  // 0 = 100; máximo 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogRequest) Reset() {
+	*x = AuditLogRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogRequest) ProtoMessage() {}
+
+func (x *AuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogRequest.ProtoReflect.Descriptor instead.
+func (*AuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *AuditLogRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AuditLogRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *AuditLogRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditLogRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditLogRequest) GetSinceUnixMs() int64 {
+	if x != nil {
+		return x.SinceUnixMs
+	}
+	return 0
+}
+
+func (x *AuditLogRequest) GetUntilUnixMs() int64 {
+	if x != nil {
+		return x.UntilUnixMs
+	}
+	return 0
+}
+
+func (x *AuditLogRequest) GetAfterSeq() uint64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+func (x *AuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AdminAuditEntry     `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`                                // de la más antigua a la más reciente
+	LastSequence  uint64                 `protobuf:"varint,2,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"` // de la última entrada devuelta
+	More          bool                   `protobuf:"varint,3,opt,name=more,proto3" json:"more,omitempty"`                                     // quedan entradas que cumplen el filtro
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditLogResponse) Reset() {
+	*x = AuditLogResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditLogResponse) ProtoMessage() {}
+
+func (x *AuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditLogResponse.ProtoReflect.Descriptor instead.
+func (*AuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *AuditLogResponse) GetEntries() []*AdminAuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AuditLogResponse) GetLastSequence() uint64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+func (x *AuditLogResponse) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+// Seguimiento en vivo del log del Matchmaker (AdminStreamLogs).
+type LogStreamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinLevel      string                 `protobuf:"bytes,1,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"` // DEBUG | INFO | WARN | ERROR; vacío = INFO
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`                         // componente o namespace ("Matchmaker", "default"); vacío = todas
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStreamRequest) Reset() {
+	*x = LogStreamRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStreamRequest) ProtoMessage() {}
+
+func (x *LogStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStreamRequest.ProtoReflect.Descriptor instead.
+func (*LogStreamRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *LogStreamRequest) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+func (x *LogStreamRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type LogLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UnixMs        int64                  `protobuf:"varint,1,opt,name=unix_ms,json=unixMs,proto3" json:"unix_ms,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"` // DEBUG | INFO | WARN | ERROR
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`   // etiquetas entre corchetes al inicio de la línea
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Dropped       uint64                 `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"` // líneas perdidas (cliente lento) antes de ésta
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_proto_matchmaking_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *LogLine) GetUnixMs() int64 {
+	if x != nil {
+		return x.UnixMs
+	}
+	return 0
+}
+
+func (x *LogLine) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogLine) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *LogLine) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LogLine) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type AdminDrainServerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Clock         *VectorClock           `protobuf:"bytes,2,opt,name=clock,proto3" json:"clock,omitempty"`
+	Namespace     string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminDrainServerRequest) Reset() {
+	*x = AdminDrainServerRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDrainServerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDrainServerRequest) ProtoMessage() {}
+
+func (x *AdminDrainServerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDrainServerRequest.ProtoReflect.Descriptor instead.
+func (*AdminDrainServerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *AdminDrainServerRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *AdminDrainServerRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *AdminDrainServerRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Fallos inyectados para probar la tolerancia a fallos (internal/chaos).
+// Las probabilidades van de 0 a 1; todo en 0 = sin fallos.
+type ChaosConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DropProb      float64                `protobuf:"fixed64,1,opt,name=drop_prob,json=dropProb,proto3" json:"drop_prob,omitempty"`    // la RPC entrante se descarta con UNAVAILABLE
+	DelayProb     float64                `protobuf:"fixed64,2,opt,name=delay_prob,json=delayProb,proto3" json:"delay_prob,omitempty"` // la respuesta se demora hasta max_delay_ms
+	MaxDelayMs    int64                  `protobuf:"varint,3,opt,name=max_delay_ms,json=maxDelayMs,proto3" json:"max_delay_ms,omitempty"`
+	ClockProb     float64                `protobuf:"fixed64,4,opt,name=clock_prob,json=clockProb,proto3" json:"clock_prob,omitempty"` // el reloj vectorial de la respuesta llega adelantado
+	CrashProb     float64                `protobuf:"fixed64,5,opt,name=crash_prob,json=crashProb,proto3" json:"crash_prob,omitempty"` // el proceso se cae (ver el punto de caída de cada componente)
+	Seed          int64                  `protobuf:"varint,6,opt,name=seed,proto3" json:"seed,omitempty"`                             // != 0: reinicia la secuencia pseudoaleatoria con esta semilla
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChaosConfig) Reset() {
+	*x = ChaosConfig{}
+	mi := &file_proto_matchmaking_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChaosConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChaosConfig) ProtoMessage() {}
+
+func (x *ChaosConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChaosConfig.ProtoReflect.Descriptor instead.
+func (*ChaosConfig) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ChaosConfig) GetDropProb() float64 {
+	if x != nil {
+		return x.DropProb
+	}
+	return 0
+}
+
+func (x *ChaosConfig) GetDelayProb() float64 {
+	if x != nil {
+		return x.DelayProb
+	}
+	return 0
+}
+
+func (x *ChaosConfig) GetMaxDelayMs() int64 {
+	if x != nil {
+		return x.MaxDelayMs
+	}
+	return 0
+}
+
+func (x *ChaosConfig) GetClockProb() float64 {
+	if x != nil {
+		return x.ClockProb
+	}
+	return 0
+}
+
+func (x *ChaosConfig) GetCrashProb() float64 {
+	if x != nil {
+		return x.CrashProb
+	}
+	return 0
+}
+
+func (x *ChaosConfig) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type AdminSetChaosRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// "" = el Matchmaker; un ID de servidor = ese GameServer; "*" = todos
+	// los GameServers del namespace con stream Heartbeat abierto
+	Target        string       `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Config        *ChaosConfig `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	Clock         *VectorClock `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminSetChaosRequest) Reset() {
+	*x = AdminSetChaosRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminSetChaosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSetChaosRequest) ProtoMessage() {}
+
+func (x *AdminSetChaosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSetChaosRequest.ProtoReflect.Descriptor instead.
+func (*AdminSetChaosRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *AdminSetChaosRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AdminSetChaosRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AdminSetChaosRequest) GetConfig() *ChaosConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *AdminSetChaosRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+// Sesiones de jugador: cada proceso cliente envía un ID de sesión propio en
+// la metadata "x-session-id" (pkg/mmclient).
+type AdminListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // vacío = todos
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminListSessionsRequest) Reset() {
+	*x = AdminListSessionsRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListSessionsRequest) ProtoMessage() {}
+
+func (x *AdminListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*AdminListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *AdminListSessionsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AdminListSessionsRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *AdminListSessionsRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type PlayerSession struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId        string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	SessionId       string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Peer            string                 `protobuf:"bytes,3,opt,name=peer,proto3" json:"peer,omitempty"` // dirección del cliente en su última RPC
+	StartedAtUnixMs int64                  `protobuf:"varint,4,opt,name=started_at_unix_ms,json=startedAtUnixMs,proto3" json:"started_at_unix_ms,omitempty"`
+	LastSeenUnixMs  int64                  `protobuf:"varint,5,opt,name=last_seen_unix_ms,json=lastSeenUnixMs,proto3" json:"last_seen_unix_ms,omitempty"`
+	Live            bool                   `protobuf:"varint,6,opt,name=live,proto3" json:"live,omitempty"` // vista dentro de SESSION_TTL
+	Rpcs            int64                  `protobuf:"varint,7,opt,name=rpcs,proto3" json:"rpcs,omitempty"`
+	Conflicts       int32                  `protobuf:"varint,8,opt,name=conflicts,proto3" json:"conflicts,omitempty"` // del jugador: RPC rechazadas y sesiones reemplazadas
+	Fenced          []string               `protobuf:"bytes,9,rep,name=fenced,proto3" json:"fenced,omitempty"`        // sesiones anteriores reemplazadas (SESSION_CONFLICT=fence)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PlayerSession) Reset() {
+	*x = PlayerSession{}
+	mi := &file_proto_matchmaking_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSession) ProtoMessage() {}
+
+func (x *PlayerSession) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSession.ProtoReflect.Descriptor instead.
+func (*PlayerSession) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *PlayerSession) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *PlayerSession) GetStartedAtUnixMs() int64 {
+	if x != nil {
+		return x.StartedAtUnixMs
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetLastSeenUnixMs() int64 {
+	if x != nil {
+		return x.LastSeenUnixMs
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetLive() bool {
+	if x != nil {
+		return x.Live
+	}
+	return false
+}
+
+func (x *PlayerSession) GetRpcs() int64 {
+	if x != nil {
+		return x.Rpcs
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetConflicts() int32 {
+	if x != nil {
+		return x.Conflicts
+	}
+	return 0
+}
+
+func (x *PlayerSession) GetFenced() []string {
+	if x != nil {
+		return x.Fenced
+	}
+	return nil
+}
+
+type AdminListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*PlayerSession       `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"` // por jugador
+	VectorClock   *VectorClock           `protobuf:"bytes,2,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminListSessionsResponse) Reset() {
+	*x = AdminListSessionsResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminListSessionsResponse) ProtoMessage() {}
+
+func (x *AdminListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*AdminListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *AdminListSessionsResponse) GetSessions() []*PlayerSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *AdminListSessionsResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+// AdminSimulateMatching ejecuta una vuelta del bucle de emparejamiento
+// sobre una copia del estado: no forma partidas ni toca la cola.
+type SimulateMatchingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	GameMode      string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"` // vacío = todos
+	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulateMatchingRequest) Reset() {
+	*x = SimulateMatchingRequest{}
+	mi := &file_proto_matchmaking_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulateMatchingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulateMatchingRequest) ProtoMessage() {}
+
+func (x *SimulateMatchingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulateMatchingRequest.ProtoReflect.Descriptor instead.
+func (*SimulateMatchingRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *SimulateMatchingRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SimulateMatchingRequest) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *SimulateMatchingRequest) GetClock() *VectorClock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+type SimulatedMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          SimulatedMatch_Kind    `protobuf:"varint,1,opt,name=kind,proto3,enum=matchmaking.SimulatedMatch_Kind" json:"kind,omitempty"`
+	GameMode      string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	PlayerIds     []string               `protobuf:"bytes,3,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"` // en TEAMS, el equipo 1 seguido del 2
+	ServerId      string                 `protobuf:"bytes,4,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	MatchId       string                 `protobuf:"bytes,5,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"` // sólo BACKFILL: la partida que se rellena
+	Relaxed       string                 `protobuf:"bytes,6,opt,name=relaxed,proto3" json:"relaxed,omitempty"`                // etapa de relajación (region, max_wait); vacío = ninguna
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimulatedMatch) Reset() {
+	*x = SimulatedMatch{}
+	mi := &file_proto_matchmaking_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulatedMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulatedMatch) ProtoMessage() {}
+
+func (x *SimulatedMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulatedMatch.ProtoReflect.Descriptor instead.
+func (*SimulatedMatch) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *SimulatedMatch) GetKind() SimulatedMatch_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return SimulatedMatch_TEAMS
+}
+
+func (x *SimulatedMatch) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *SimulatedMatch) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
+	}
+	return nil
+}
+
+func (x *SimulatedMatch) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *SimulatedMatch) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *SimulatedMatch) GetRelaxed() string {
+	if x != nil {
+		return x.Relaxed
+	}
+	return ""
+}
+
+type SkippedPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	GameMode      string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	Region        string                 `protobuf:"bytes,3,opt,name=region,proto3" json:"region,omitempty"`
+	WaitMs        int64                  `protobuf:"varint,4,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VectorClock) Reset() {
-	*x = VectorClock{}
-	mi := &file_proto_matchmaking_proto_msgTypes[0]
+func (x *SkippedPlayer) Reset() {
+	*x = SkippedPlayer{}
+	mi := &file_proto_matchmaking_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VectorClock) String() string {
+func (x *SkippedPlayer) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VectorClock) ProtoMessage() {}
+func (*SkippedPlayer) ProtoMessage() {}
 
-func (x *VectorClock) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[0]
+func (x *SkippedPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -158,43 +10594,72 @@ func (x *VectorClock) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VectorClock.ProtoReflect.Descriptor instead.
-func (*VectorClock) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use SkippedPlayer.ProtoReflect.Descriptor instead.
+func (*SkippedPlayer) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{112}
 }
 
-func (x *VectorClock) GetEntries() []uint32 {
+func (x *SkippedPlayer) GetPlayerId() string {
 	if x != nil {
-		return x.Entries
+		return x.PlayerId
 	}
-	return nil
+	return ""
 }
 
-// ─────────── MENSAJES JUGADOR ─────────
-type PlayerInfoRequest struct {
+func (x *SkippedPlayer) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *SkippedPlayer) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *SkippedPlayer) GetWaitMs() int64 {
+	if x != nil {
+		return x.WaitMs
+	}
+	return 0
+}
+
+func (x *SkippedPlayer) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SimulateMatchingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	GameMode      string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"` // e.g. "1v1"
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	Policy        string                 `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`                               // MATCH_POLICY vigente
+	Matches       []*SimulatedMatch      `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`                             // en el orden en que se formarían
+	Skipped       []*SkippedPlayer       `protobuf:"bytes,3,rep,name=skipped,proto3" json:"skipped,omitempty"`                             // en cola sin partida, en el orden de la cola
+	FreeServers   int32                  `protobuf:"varint,4,opt,name=free_servers,json=freeServers,proto3" json:"free_servers,omitempty"` // servidores asignables que quedarían
+	VectorClock   *VectorClock           `protobuf:"bytes,5,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlayerInfoRequest) Reset() {
-	*x = PlayerInfoRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[1]
+func (x *SimulateMatchingResponse) Reset() {
+	*x = SimulateMatchingResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerInfoRequest) String() string {
+func (x *SimulateMatchingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerInfoRequest) ProtoMessage() {}
+func (*SimulateMatchingResponse) ProtoMessage() {}
 
-func (x *PlayerInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[1]
+func (x *SimulateMatchingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,56 +10670,70 @@ func (x *PlayerInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerInfoRequest.ProtoReflect.Descriptor instead.
-func (*PlayerInfoRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SimulateMatchingResponse.ProtoReflect.Descriptor instead.
+func (*SimulateMatchingResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{113}
 }
 
-func (x *PlayerInfoRequest) GetPlayerId() string {
+func (x *SimulateMatchingResponse) GetPolicy() string {
 	if x != nil {
-		return x.PlayerId
+		return x.Policy
 	}
 	return ""
 }
 
-func (x *PlayerInfoRequest) GetGameMode() string {
+func (x *SimulateMatchingResponse) GetMatches() []*SimulatedMatch {
 	if x != nil {
-		return x.GameMode
+		return x.Matches
 	}
-	return ""
+	return nil
 }
 
-func (x *PlayerInfoRequest) GetClock() *VectorClock {
+func (x *SimulateMatchingResponse) GetSkipped() []*SkippedPlayer {
 	if x != nil {
-		return x.Clock
+		return x.Skipped
 	}
 	return nil
 }
 
-type QueuePlayerResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+func (x *SimulateMatchingResponse) GetFreeServers() int32 {
+	if x != nil {
+		return x.FreeServers
+	}
+	return 0
+}
+
+func (x *SimulateMatchingResponse) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
+}
+
+type AdminUpdateResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Status        AdminUpdateResponse_StatusCode `protobuf:"varint,1,opt,name=status,proto3,enum=matchmaking.AdminUpdateResponse_StatusCode" json:"status,omitempty"`
+	Message       string                         `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	VectorClock   *VectorClock                   `protobuf:"bytes,3,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueuePlayerResponse) Reset() {
-	*x = QueuePlayerResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[2]
+func (x *AdminUpdateResponse) Reset() {
+	*x = AdminUpdateResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueuePlayerResponse) String() string {
+func (x *AdminUpdateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueuePlayerResponse) ProtoMessage() {}
+func (*AdminUpdateResponse) ProtoMessage() {}
 
-func (x *QueuePlayerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[2]
+func (x *AdminUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -265,55 +10744,228 @@ func (x *QueuePlayerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueuePlayerResponse.ProtoReflect.Descriptor instead.
-func (*QueuePlayerResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use AdminUpdateResponse.ProtoReflect.Descriptor instead.
+func (*AdminUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *QueuePlayerResponse) GetSuccess() bool {
+func (x *AdminUpdateResponse) GetStatus() AdminUpdateResponse_StatusCode {
 	if x != nil {
-		return x.Success
+		return x.Status
 	}
-	return false
+	return AdminUpdateResponse_OK
 }
 
-func (x *QueuePlayerResponse) GetMessage() string {
+func (x *AdminUpdateResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *QueuePlayerResponse) GetClock() *VectorClock {
+func (x *AdminUpdateResponse) GetVectorClock() *VectorClock {
 	if x != nil {
-		return x.Clock
+		return x.VectorClock
 	}
 	return nil
 }
 
-type PlayerStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,2,opt,name=clock,proto3" json:"clock,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ───────── MENSAJES REPLICACIÓN ─────────
+type PlayerSnapshot struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId            string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Status              string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // IDLE | IN_QUEUE | IN_MATCH
+	MatchId             string                 `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	LastOpUnixMs        int64                  `protobuf:"varint,4,opt,name=last_op_unix_ms,json=lastOpUnixMs,proto3" json:"last_op_unix_ms,omitempty"`
+	GameMode            string                 `protobuf:"bytes,5,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	Priority            QueuePriority          `protobuf:"varint,6,opt,name=priority,proto3,enum=matchmaking.QueuePriority" json:"priority,omitempty"`
+	Tier                PlayerTier             `protobuf:"varint,7,opt,name=tier,proto3,enum=matchmaking.PlayerTier" json:"tier,omitempty"`
+	Region              string                 `protobuf:"bytes,8,opt,name=region,proto3" json:"region,omitempty"`
+	PartyId             string                 `protobuf:"bytes,9,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	CooldownUntilUnixMs int64                  `protobuf:"varint,10,opt,name=cooldown_until_unix_ms,json=cooldownUntilUnixMs,proto3" json:"cooldown_until_unix_ms,omitempty"`
+	Registered          bool                   `protobuf:"varint,11,opt,name=registered,proto3" json:"registered,omitempty"`                                                  // ID reclamado con RegisterPlayer
+	QueueDeadlineUnixMs int64                  `protobuf:"varint,12,opt,name=queue_deadline_unix_ms,json=queueDeadlineUnixMs,proto3" json:"queue_deadline_unix_ms,omitempty"` // IN_QUEUE: vence la espera; 0 = sin plazo
+	QueueTimedOut       bool                   `protobuf:"varint,13,opt,name=queue_timed_out,json=queueTimedOut,proto3" json:"queue_timed_out,omitempty"`                     // IDLE tras vencer su espera en la cola
+	LastHeartbeatUnixMs int64                  `protobuf:"varint,14,opt,name=last_heartbeat_unix_ms,json=lastHeartbeatUnixMs,proto3" json:"last_heartbeat_unix_ms,omitempty"` // último PlayerHeartbeat; 0 = el cliente no late
+	OnAssignFailure     AssignFailureAction    `protobuf:"varint,15,opt,name=on_assign_failure,json=onAssignFailure,proto3,enum=matchmaking.AssignFailureAction" json:"on_assign_failure,omitempty"`
+	RequeueOfferUnixMs  int64                  `protobuf:"varint,16,opt,name=requeue_offer_unix_ms,json=requeueOfferUnixMs,proto3" json:"requeue_offer_unix_ms,omitempty"` // FAILURE_ASK: puede recuperar su lugar hasta entonces; 0 = no
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PlayerSnapshot) Reset() {
+	*x = PlayerSnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerStatusRequest) Reset() {
-	*x = PlayerStatusRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[3]
+func (x *PlayerSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerSnapshot) ProtoMessage() {}
+
+func (x *PlayerSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerSnapshot.ProtoReflect.Descriptor instead.
+func (*PlayerSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *PlayerSnapshot) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerSnapshot) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PlayerSnapshot) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *PlayerSnapshot) GetLastOpUnixMs() int64 {
+	if x != nil {
+		return x.LastOpUnixMs
+	}
+	return 0
+}
+
+func (x *PlayerSnapshot) GetGameMode() string {
+	if x != nil {
+		return x.GameMode
+	}
+	return ""
+}
+
+func (x *PlayerSnapshot) GetPriority() QueuePriority {
+	if x != nil {
+		return x.Priority
+	}
+	return QueuePriority_PRIORITY_NORMAL
+}
+
+func (x *PlayerSnapshot) GetTier() PlayerTier {
+	if x != nil {
+		return x.Tier
+	}
+	return PlayerTier_TIER_NORMAL
+}
+
+func (x *PlayerSnapshot) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *PlayerSnapshot) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+func (x *PlayerSnapshot) GetCooldownUntilUnixMs() int64 {
+	if x != nil {
+		return x.CooldownUntilUnixMs
+	}
+	return 0
+}
+
+func (x *PlayerSnapshot) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
+	}
+	return false
+}
+
+func (x *PlayerSnapshot) GetQueueDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.QueueDeadlineUnixMs
+	}
+	return 0
+}
+
+func (x *PlayerSnapshot) GetQueueTimedOut() bool {
+	if x != nil {
+		return x.QueueTimedOut
+	}
+	return false
+}
+
+func (x *PlayerSnapshot) GetLastHeartbeatUnixMs() int64 {
+	if x != nil {
+		return x.LastHeartbeatUnixMs
+	}
+	return 0
+}
+
+func (x *PlayerSnapshot) GetOnAssignFailure() AssignFailureAction {
+	if x != nil {
+		return x.OnAssignFailure
+	}
+	return AssignFailureAction_FAILURE_REQUEUE
+}
+
+func (x *PlayerSnapshot) GetRequeueOfferUnixMs() int64 {
+	if x != nil {
+		return x.RequeueOfferUnixMs
+	}
+	return 0
+}
+
+type ServerSnapshot struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ServerId            string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Address             string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Status              ServerStatus           `protobuf:"varint,3,opt,name=status,proto3,enum=matchmaking.ServerStatus" json:"status,omitempty"`
+	CurrentMatchId      string                 `protobuf:"bytes,4,opt,name=current_match_id,json=currentMatchId,proto3" json:"current_match_id,omitempty"`
+	LastHeartbeatUnixMs int64                  `protobuf:"varint,5,opt,name=last_heartbeat_unix_ms,json=lastHeartbeatUnixMs,proto3" json:"last_heartbeat_unix_ms,omitempty"`
+	Draining            bool                   `protobuf:"varint,6,opt,name=draining,proto3" json:"draining,omitempty"`
+	Region              string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	LatencyMs           uint32                 `protobuf:"varint,8,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	Capacity            int32                  `protobuf:"varint,9,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	FreeSlots           int32                  `protobuf:"varint,10,opt,name=free_slots,json=freeSlots,proto3" json:"free_slots,omitempty"` // último valor informado
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ServerSnapshot) Reset() {
+	*x = ServerSnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerStatusRequest) String() string {
+func (x *ServerSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerStatusRequest) ProtoMessage() {}
+func (*ServerSnapshot) ProtoMessage() {}
 
-func (x *PlayerStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[3]
+func (x *ServerSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,50 +10976,114 @@ func (x *PlayerStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerStatusRequest.ProtoReflect.Descriptor instead.
-func (*PlayerStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ServerSnapshot.ProtoReflect.Descriptor instead.
+func (*ServerSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{116}
 }
 
-func (x *PlayerStatusRequest) GetPlayerId() string {
+func (x *ServerSnapshot) GetServerId() string {
 	if x != nil {
-		return x.PlayerId
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *PlayerStatusRequest) GetClock() *VectorClock {
+func (x *ServerSnapshot) GetAddress() string {
 	if x != nil {
-		return x.Clock
+		return x.Address
 	}
-	return nil
+	return ""
 }
 
-type PlayerStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         PlayerState            `protobuf:"varint,1,opt,name=state,proto3,enum=matchmaking.PlayerState" json:"state,omitempty"`
-	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	ServerAddr    string                 `protobuf:"bytes,3,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ServerSnapshot) GetStatus() ServerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ServerStatus_UNKNOWN
+}
+
+func (x *ServerSnapshot) GetCurrentMatchId() string {
+	if x != nil {
+		return x.CurrentMatchId
+	}
+	return ""
+}
+
+func (x *ServerSnapshot) GetLastHeartbeatUnixMs() int64 {
+	if x != nil {
+		return x.LastHeartbeatUnixMs
+	}
+	return 0
+}
+
+func (x *ServerSnapshot) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *ServerSnapshot) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ServerSnapshot) GetLatencyMs() uint32 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *ServerSnapshot) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
 }
 
-func (x *PlayerStatusResponse) Reset() {
-	*x = PlayerStatusResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[4]
+func (x *ServerSnapshot) GetFreeSlots() int32 {
+	if x != nil {
+		return x.FreeSlots
+	}
+	return 0
+}
+
+type MatchSnapshot struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	MatchId             string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	PlayerIds           []string               `protobuf:"bytes,2,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
+	ServerId            string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	GameMode            string                 `protobuf:"bytes,4,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	StartedAtUnixMs     int64                  `protobuf:"varint,5,opt,name=started_at_unix_ms,json=startedAtUnixMs,proto3" json:"started_at_unix_ms,omitempty"`
+	Timeline            []*MatchEvent          `protobuf:"bytes,6,rep,name=timeline,proto3" json:"timeline,omitempty"`
+	OpenSlots           int32                  `protobuf:"varint,7,opt,name=open_slots,json=openSlots,proto3" json:"open_slots,omitempty"`                                   // huecos pendientes de relleno
+	ReadyDeadlineUnixMs int64                  `protobuf:"varint,8,opt,name=ready_deadline_unix_ms,json=readyDeadlineUnixMs,proto3" json:"ready_deadline_unix_ms,omitempty"` // 0 = sin ready-check pendiente
+	ReadyAccepted       []string               `protobuf:"bytes,9,rep,name=ready_accepted,json=readyAccepted,proto3" json:"ready_accepted,omitempty"`
+	LobbyCode           string                 `protobuf:"bytes,10,opt,name=lobby_code,json=lobbyCode,proto3" json:"lobby_code,omitempty"` // salió de una sala privada
+	Pinned              bool                   `protobuf:"varint,11,opt,name=pinned,proto3" json:"pinned,omitempty"`                       // la sala eligió el servidor: no se conmuta
+	MatchAddr           string                 `protobuf:"bytes,12,opt,name=match_addr,json=matchAddr,proto3" json:"match_addr,omitempty"` // dirección dedicada a la partida; vacío = la del servidor
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *MatchSnapshot) Reset() {
+	*x = MatchSnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlayerStatusResponse) String() string {
+func (x *MatchSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerStatusResponse) ProtoMessage() {}
+func (*MatchSnapshot) ProtoMessage() {}
 
-func (x *PlayerStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[4]
+func (x *MatchSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -378,124 +11094,119 @@ func (x *PlayerStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerStatusResponse.ProtoReflect.Descriptor instead.
-func (*PlayerStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use MatchSnapshot.ProtoReflect.Descriptor instead.
+func (*MatchSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{117}
 }
 
-func (x *PlayerStatusResponse) GetState() PlayerState {
+func (x *MatchSnapshot) GetMatchId() string {
 	if x != nil {
-		return x.State
+		return x.MatchId
 	}
-	return PlayerState_PLAYER_STATE_IDLE
+	return ""
 }
 
-func (x *PlayerStatusResponse) GetMatchId() string {
+func (x *MatchSnapshot) GetPlayerIds() []string {
 	if x != nil {
-		return x.MatchId
+		return x.PlayerIds
 	}
-	return ""
+	return nil
 }
 
-func (x *PlayerStatusResponse) GetServerAddr() string {
+func (x *MatchSnapshot) GetServerId() string {
 	if x != nil {
-		return x.ServerAddr
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *PlayerStatusResponse) GetClock() *VectorClock {
+func (x *MatchSnapshot) GetGameMode() string {
 	if x != nil {
-		return x.Clock
+		return x.GameMode
 	}
-	return nil
+	return ""
 }
 
-// ───────────── MENSAJES SERVER ────────
-type AssignMatchRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	PlayerIds     []string               `protobuf:"bytes,2,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MatchSnapshot) GetStartedAtUnixMs() int64 {
+	if x != nil {
+		return x.StartedAtUnixMs
+	}
+	return 0
 }
 
-func (x *AssignMatchRequest) Reset() {
-	*x = AssignMatchRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *MatchSnapshot) GetTimeline() []*MatchEvent {
+	if x != nil {
+		return x.Timeline
+	}
+	return nil
 }
 
-func (x *AssignMatchRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *MatchSnapshot) GetOpenSlots() int32 {
+	if x != nil {
+		return x.OpenSlots
+	}
+	return 0
 }
 
-func (*AssignMatchRequest) ProtoMessage() {}
-
-func (x *AssignMatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[5]
+func (x *MatchSnapshot) GetReadyDeadlineUnixMs() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ReadyDeadlineUnixMs
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use AssignMatchRequest.ProtoReflect.Descriptor instead.
-func (*AssignMatchRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{5}
+func (x *MatchSnapshot) GetReadyAccepted() []string {
+	if x != nil {
+		return x.ReadyAccepted
+	}
+	return nil
 }
 
-func (x *AssignMatchRequest) GetMatchId() string {
+func (x *MatchSnapshot) GetLobbyCode() string {
 	if x != nil {
-		return x.MatchId
+		return x.LobbyCode
 	}
 	return ""
 }
 
-func (x *AssignMatchRequest) GetPlayerIds() []string {
+func (x *MatchSnapshot) GetPinned() bool {
 	if x != nil {
-		return x.PlayerIds
+		return x.Pinned
 	}
-	return nil
+	return false
 }
 
-func (x *AssignMatchRequest) GetClock() *VectorClock {
+func (x *MatchSnapshot) GetMatchAddr() string {
 	if x != nil {
-		return x.Clock
+		return x.MatchAddr
 	}
-	return nil
+	return ""
 }
 
-type AssignMatchResponse struct {
+type PartySnapshot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	PartyId       string                 `protobuf:"bytes,1,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	LeaderId      string                 `protobuf:"bytes,2,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	MemberIds     []string               `protobuf:"bytes,3,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignMatchResponse) Reset() {
-	*x = AssignMatchResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[6]
+func (x *PartySnapshot) Reset() {
+	*x = PartySnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignMatchResponse) String() string {
+func (x *PartySnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignMatchResponse) ProtoMessage() {}
+func (*PartySnapshot) ProtoMessage() {}
 
-func (x *AssignMatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[6]
+func (x *PartySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,57 +11217,58 @@ func (x *AssignMatchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignMatchResponse.ProtoReflect.Descriptor instead.
-func (*AssignMatchResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use PartySnapshot.ProtoReflect.Descriptor instead.
+func (*PartySnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{118}
 }
 
-func (x *AssignMatchResponse) GetSuccess() bool {
+func (x *PartySnapshot) GetPartyId() string {
 	if x != nil {
-		return x.Success
+		return x.PartyId
 	}
-	return false
+	return ""
 }
 
-func (x *AssignMatchResponse) GetMessage() string {
+func (x *PartySnapshot) GetLeaderId() string {
 	if x != nil {
-		return x.Message
+		return x.LeaderId
 	}
 	return ""
 }
 
-func (x *AssignMatchResponse) GetClock() *VectorClock {
+func (x *PartySnapshot) GetMemberIds() []string {
 	if x != nil {
-		return x.Clock
+		return x.MemberIds
 	}
 	return nil
 }
 
-type ServerStatusUpdateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	NewState      ServerState            `protobuf:"varint,2,opt,name=new_state,json=newState,proto3,enum=matchmaking.ServerState" json:"new_state,omitempty"`
-	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"` // host:port de GameServer
-	Clock         *VectorClock           `protobuf:"bytes,4,opt,name=clock,proto3" json:"clock,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type LobbySnapshot struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Code            string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	GameMode        string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	ServerId        string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	MemberIds       []string               `protobuf:"bytes,4,rep,name=member_ids,json=memberIds,proto3" json:"member_ids,omitempty"` // el dueño primero
+	CreatedAtUnixMs int64                  `protobuf:"varint,5,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *ServerStatusUpdateRequest) Reset() {
-	*x = ServerStatusUpdateRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[7]
+func (x *LobbySnapshot) Reset() {
+	*x = LobbySnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerStatusUpdateRequest) String() string {
+func (x *LobbySnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerStatusUpdateRequest) ProtoMessage() {}
+func (*LobbySnapshot) ProtoMessage() {}
 
-func (x *ServerStatusUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[7]
+func (x *LobbySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,63 +11279,74 @@ func (x *ServerStatusUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerStatusUpdateRequest.ProtoReflect.Descriptor instead.
-func (*ServerStatusUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use LobbySnapshot.ProtoReflect.Descriptor instead.
+func (*LobbySnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *ServerStatusUpdateRequest) GetServerId() string {
+func (x *LobbySnapshot) GetCode() string {
 	if x != nil {
-		return x.ServerId
+		return x.Code
 	}
 	return ""
 }
 
-func (x *ServerStatusUpdateRequest) GetNewState() ServerState {
+func (x *LobbySnapshot) GetGameMode() string {
 	if x != nil {
-		return x.NewState
+		return x.GameMode
 	}
-	return ServerState_SERVER_STATE_UNKNOWN
+	return ""
 }
 
-func (x *ServerStatusUpdateRequest) GetAddress() string {
+func (x *LobbySnapshot) GetServerId() string {
 	if x != nil {
-		return x.Address
+		return x.ServerId
 	}
 	return ""
 }
 
-func (x *ServerStatusUpdateRequest) GetClock() *VectorClock {
+func (x *LobbySnapshot) GetMemberIds() []string {
 	if x != nil {
-		return x.Clock
+		return x.MemberIds
 	}
 	return nil
 }
 
-type ServerStatusUpdateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *LobbySnapshot) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
 }
 
-func (x *ServerStatusUpdateResponse) Reset() {
-	*x = ServerStatusUpdateResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[8]
+// Revancha ofrecida tras una partida, aún sin arrancar.
+type RematchSnapshot struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MatchId        string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"` // la partida terminada
+	GameMode       string                 `protobuf:"bytes,2,opt,name=game_mode,json=gameMode,proto3" json:"game_mode,omitempty"`
+	ServerId       string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`    // afinidad: el servidor de esa partida
+	PlayerIds      []string               `protobuf:"bytes,4,rep,name=player_ids,json=playerIds,proto3" json:"player_ids,omitempty"` // en el orden de la partida
+	DeadlineUnixMs int64                  `protobuf:"varint,5,opt,name=deadline_unix_ms,json=deadlineUnixMs,proto3" json:"deadline_unix_ms,omitempty"`
+	AcceptedIds    []string               `protobuf:"bytes,6,rep,name=accepted_ids,json=acceptedIds,proto3" json:"accepted_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RematchSnapshot) Reset() {
+	*x = RematchSnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerStatusUpdateResponse) String() string {
+func (x *RematchSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerStatusUpdateResponse) ProtoMessage() {}
+func (*RematchSnapshot) ProtoMessage() {}
 
-func (x *ServerStatusUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[8]
+func (x *RematchSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -634,54 +11357,79 @@ func (x *ServerStatusUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerStatusUpdateResponse.ProtoReflect.Descriptor instead.
-func (*ServerStatusUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RematchSnapshot.ProtoReflect.Descriptor instead.
+func (*RematchSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *ServerStatusUpdateResponse) GetSuccess() bool {
+func (x *RematchSnapshot) GetMatchId() string {
 	if x != nil {
-		return x.Success
+		return x.MatchId
 	}
-	return false
+	return ""
 }
 
-func (x *ServerStatusUpdateResponse) GetMessage() string {
+func (x *RematchSnapshot) GetGameMode() string {
 	if x != nil {
-		return x.Message
+		return x.GameMode
 	}
 	return ""
 }
 
-func (x *ServerStatusUpdateResponse) GetClock() *VectorClock {
+func (x *RematchSnapshot) GetServerId() string {
 	if x != nil {
-		return x.Clock
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RematchSnapshot) GetPlayerIds() []string {
+	if x != nil {
+		return x.PlayerIds
 	}
 	return nil
 }
 
-type PingRequest struct {
+func (x *RematchSnapshot) GetDeadlineUnixMs() int64 {
+	if x != nil {
+		return x.DeadlineUnixMs
+	}
+	return 0
+}
+
+func (x *RematchSnapshot) GetAcceptedIds() []string {
+	if x != nil {
+		return x.AcceptedIds
+	}
+	return nil
+}
+
+// Retransmisión de una partida a espectadores; los suscriptores no viajan:
+// vuelven a conectarse con el primario nuevo.
+type RelaySnapshot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // servidor que publica
+	Last          *SpectateEvent         `protobuf:"bytes,3,opt,name=last,proto3" json:"last,omitempty"`                         // último evento publicado
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingRequest) Reset() {
-	*x = PingRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[9]
+func (x *RelaySnapshot) Reset() {
+	*x = RelaySnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingRequest) String() string {
+func (x *RelaySnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingRequest) ProtoMessage() {}
+func (*RelaySnapshot) ProtoMessage() {}
 
-func (x *PingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[9]
+func (x *RelaySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,40 +11440,55 @@ func (x *PingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingRequest.ProtoReflect.Descriptor instead.
-func (*PingRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RelaySnapshot.ProtoReflect.Descriptor instead.
+func (*RelaySnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{121}
 }
 
-func (x *PingRequest) GetServerId() string {
+func (x *RelaySnapshot) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RelaySnapshot) GetServerId() string {
 	if x != nil {
 		return x.ServerId
 	}
 	return ""
 }
 
-type PingResponse struct {
+func (x *RelaySnapshot) GetLast() *SpectateEvent {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+type PlayerEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Alive         bool                   `protobuf:"varint,1,opt,name=alive,proto3" json:"alive,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Update        *MatchUpdate           `protobuf:"bytes,2,opt,name=update,proto3" json:"update,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PingResponse) Reset() {
-	*x = PingResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[10]
+func (x *PlayerEvent) Reset() {
+	*x = PlayerEvent{}
+	mi := &file_proto_matchmaking_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PingResponse) String() string {
+func (x *PlayerEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PingResponse) ProtoMessage() {}
+func (*PlayerEvent) ProtoMessage() {}
 
-func (x *PingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[10]
+func (x *PlayerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -736,40 +11499,65 @@ func (x *PingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PingResponse.ProtoReflect.Descriptor instead.
-func (*PingResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use PlayerEvent.ProtoReflect.Descriptor instead.
+func (*PlayerEvent) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *PingResponse) GetAlive() bool {
+func (x *PlayerEvent) GetPlayerId() string {
 	if x != nil {
-		return x.Alive
+		return x.PlayerId
 	}
-	return false
+	return ""
 }
 
-// ──────────── MENSAJES ADMIN ──────────
-type AdminRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PlayerEvent) GetUpdate() *MatchUpdate {
+	if x != nil {
+		return x.Update
+	}
+	return nil
 }
 
-func (x *AdminRequest) Reset() {
-	*x = AdminRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[11]
+type NamespaceSnapshot struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Players          []*PlayerSnapshot      `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	Servers          []*ServerSnapshot      `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`
+	Queue            []string               `protobuf:"bytes,4,rep,name=queue,proto3" json:"queue,omitempty"` // FIFO en orden
+	Matches          []*MatchSnapshot       `protobuf:"bytes,5,rep,name=matches,proto3" json:"matches,omitempty"`
+	VectorClock      *VectorClock           `protobuf:"bytes,6,opt,name=vector_clock,json=vectorClock,proto3" json:"vector_clock,omitempty"`
+	Modes            []*GameMode            `protobuf:"bytes,7,rep,name=modes,proto3" json:"modes,omitempty"`
+	Events           []*PlayerEvent         `protobuf:"bytes,8,rep,name=events,proto3" json:"events,omitempty"` // log de notificaciones recientes
+	Parties          []*PartySnapshot       `protobuf:"bytes,9,rep,name=parties,proto3" json:"parties,omitempty"`
+	Banned           []*BannedPlayer        `protobuf:"bytes,10,rep,name=banned,proto3" json:"banned,omitempty"`
+	ClockTombstones  *VectorClock           `protobuf:"bytes,11,opt,name=clock_tombstones,json=clockTombstones,proto3" json:"clock_tombstones,omitempty"`                                                                                   // componentes podados → último valor
+	History          []*MatchRecord         `protobuf:"bytes,12,rep,name=history,proto3" json:"history,omitempty"`                                                                                                                          // partidas terminadas retenidas
+	Stats            []*PlayerStats         `protobuf:"bytes,13,rep,name=stats,proto3" json:"stats,omitempty"`                                                                                                                              // acumulados de la clasificación
+	Lobbies          []*LobbySnapshot       `protobuf:"bytes,14,rep,name=lobbies,proto3" json:"lobbies,omitempty"`                                                                                                                          // salas privadas sin arrancar
+	Rematches        []*RematchSnapshot     `protobuf:"bytes,15,rep,name=rematches,proto3" json:"rematches,omitempty"`                                                                                                                      // revanchas pendientes
+	LobbyReadyUnixMs map[string]int64       `protobuf:"bytes,16,rep,name=lobby_ready_unix_ms,json=lobbyReadyUnixMs,proto3" json:"lobby_ready_unix_ms,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // modo de sala → cuándo reunió el mínimo
+	Removed          []*RemovedServer       `protobuf:"bytes,17,rep,name=removed,proto3" json:"removed,omitempty"`                                                                                                                          // retirados del pool, el más viejo primero
+	Sessions         []*PlayerSession       `protobuf:"bytes,18,rep,name=sessions,proto3" json:"sessions,omitempty"`                                                                                                                        // última sesión de cada jugador
+	Relays           []*RelaySnapshot       `protobuf:"bytes,19,rep,name=relays,proto3" json:"relays,omitempty"`                                                                                                                            // partidas retransmitidas
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NamespaceSnapshot) Reset() {
+	*x = NamespaceSnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminRequest) String() string {
+func (x *NamespaceSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminRequest) ProtoMessage() {}
+func (*NamespaceSnapshot) ProtoMessage() {}
 
-func (x *AdminRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[11]
+func (x *NamespaceSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -780,155 +11568,170 @@ func (x *AdminRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminRequest.ProtoReflect.Descriptor instead.
-func (*AdminRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use NamespaceSnapshot.ProtoReflect.Descriptor instead.
+func (*NamespaceSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{123}
 }
 
-type ServerInfo struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ServerId       string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	State          ServerState            `protobuf:"varint,2,opt,name=state,proto3,enum=matchmaking.ServerState" json:"state,omitempty"`
-	Address        string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	CurrentMatchId string                 `protobuf:"bytes,4,opt,name=current_match_id,json=currentMatchId,proto3" json:"current_match_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *NamespaceSnapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *ServerInfo) Reset() {
-	*x = ServerInfo{}
-	mi := &file_proto_matchmaking_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *NamespaceSnapshot) GetPlayers() []*PlayerSnapshot {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *NamespaceSnapshot) GetServers() []*ServerSnapshot {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *NamespaceSnapshot) GetQueue() []string {
+	if x != nil {
+		return x.Queue
+	}
+	return nil
+}
+
+func (x *NamespaceSnapshot) GetMatches() []*MatchSnapshot {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
 }
 
-func (x *ServerInfo) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *NamespaceSnapshot) GetVectorClock() *VectorClock {
+	if x != nil {
+		return x.VectorClock
+	}
+	return nil
 }
 
-func (*ServerInfo) ProtoMessage() {}
-
-func (x *ServerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[12]
+func (x *NamespaceSnapshot) GetModes() []*GameMode {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Modes
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ServerInfo.ProtoReflect.Descriptor instead.
-func (*ServerInfo) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{12}
+func (x *NamespaceSnapshot) GetEvents() []*PlayerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
 }
 
-func (x *ServerInfo) GetServerId() string {
+func (x *NamespaceSnapshot) GetParties() []*PartySnapshot {
 	if x != nil {
-		return x.ServerId
+		return x.Parties
 	}
-	return ""
+	return nil
 }
 
-func (x *ServerInfo) GetState() ServerState {
+func (x *NamespaceSnapshot) GetBanned() []*BannedPlayer {
 	if x != nil {
-		return x.State
+		return x.Banned
 	}
-	return ServerState_SERVER_STATE_UNKNOWN
+	return nil
 }
 
-func (x *ServerInfo) GetAddress() string {
+func (x *NamespaceSnapshot) GetClockTombstones() *VectorClock {
 	if x != nil {
-		return x.Address
+		return x.ClockTombstones
 	}
-	return ""
+	return nil
 }
 
-func (x *ServerInfo) GetCurrentMatchId() string {
+func (x *NamespaceSnapshot) GetHistory() []*MatchRecord {
 	if x != nil {
-		return x.CurrentMatchId
+		return x.History
 	}
-	return ""
+	return nil
 }
 
-type PlayerQueueEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	QueuedSince   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=queued_since,json=queuedSince,proto3" json:"queued_since,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *NamespaceSnapshot) GetStats() []*PlayerStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
 }
 
-func (x *PlayerQueueEntry) Reset() {
-	*x = PlayerQueueEntry{}
-	mi := &file_proto_matchmaking_proto_msgTypes[13]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *NamespaceSnapshot) GetLobbies() []*LobbySnapshot {
+	if x != nil {
+		return x.Lobbies
+	}
+	return nil
 }
 
-func (x *PlayerQueueEntry) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *NamespaceSnapshot) GetRematches() []*RematchSnapshot {
+	if x != nil {
+		return x.Rematches
+	}
+	return nil
 }
 
-func (*PlayerQueueEntry) ProtoMessage() {}
-
-func (x *PlayerQueueEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[13]
+func (x *NamespaceSnapshot) GetLobbyReadyUnixMs() map[string]int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.LobbyReadyUnixMs
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use PlayerQueueEntry.ProtoReflect.Descriptor instead.
-func (*PlayerQueueEntry) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{13}
+func (x *NamespaceSnapshot) GetRemoved() []*RemovedServer {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
 }
 
-func (x *PlayerQueueEntry) GetPlayerId() string {
+func (x *NamespaceSnapshot) GetSessions() []*PlayerSession {
 	if x != nil {
-		return x.PlayerId
+		return x.Sessions
 	}
-	return ""
+	return nil
 }
 
-func (x *PlayerQueueEntry) GetQueuedSince() *timestamppb.Timestamp {
+func (x *NamespaceSnapshot) GetRelays() []*RelaySnapshot {
 	if x != nil {
-		return x.QueuedSince
+		return x.Relays
 	}
 	return nil
 }
 
-type SystemStatusResponse struct {
+// Cada snapshot enviado por el primario también actúa como heartbeat.
+type StateSnapshot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Servers       []*ServerInfo          `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
-	Players       []*PlayerQueueEntry    `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	PrimaryId     string                 `protobuf:"bytes,1,opt,name=primary_id,json=primaryId,proto3" json:"primary_id,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Namespaces    []*NamespaceSnapshot   `protobuf:"bytes,3,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	LastMatchId   string                 `protobuf:"bytes,4,opt,name=last_match_id,json=lastMatchId,proto3" json:"last_match_id,omitempty"` // el respaldo sigue desde aquí
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SystemStatusResponse) Reset() {
-	*x = SystemStatusResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[14]
+func (x *StateSnapshot) Reset() {
+	*x = StateSnapshot{}
+	mi := &file_proto_matchmaking_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemStatusResponse) String() string {
+func (x *StateSnapshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemStatusResponse) ProtoMessage() {}
+func (*StateSnapshot) ProtoMessage() {}
 
-func (x *SystemStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[14]
+func (x *StateSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,56 +11742,61 @@ func (x *SystemStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemStatusResponse.ProtoReflect.Descriptor instead.
-func (*SystemStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use StateSnapshot.ProtoReflect.Descriptor instead.
+func (*StateSnapshot) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *SystemStatusResponse) GetServers() []*ServerInfo {
+func (x *StateSnapshot) GetPrimaryId() string {
 	if x != nil {
-		return x.Servers
+		return x.PrimaryId
 	}
-	return nil
+	return ""
 }
 
-func (x *SystemStatusResponse) GetPlayers() []*PlayerQueueEntry {
+func (x *StateSnapshot) GetSequence() uint64 {
 	if x != nil {
-		return x.Players
+		return x.Sequence
 	}
-	return nil
+	return 0
 }
 
-func (x *SystemStatusResponse) GetClock() *VectorClock {
+func (x *StateSnapshot) GetNamespaces() []*NamespaceSnapshot {
 	if x != nil {
-		return x.Clock
+		return x.Namespaces
 	}
 	return nil
 }
 
-type AdminServerUpdateRequest struct {
+func (x *StateSnapshot) GetLastMatchId() string {
+	if x != nil {
+		return x.LastMatchId
+	}
+	return ""
+}
+
+type ReplicationAck struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
-	ForcedState   ServerState            `protobuf:"varint,2,opt,name=forced_state,json=forcedState,proto3,enum=matchmaking.ServerState" json:"forced_state,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
+	LastSequence  uint64                 `protobuf:"varint,1,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminServerUpdateRequest) Reset() {
-	*x = AdminServerUpdateRequest{}
-	mi := &file_proto_matchmaking_proto_msgTypes[15]
+func (x *ReplicationAck) Reset() {
+	*x = ReplicationAck{}
+	mi := &file_proto_matchmaking_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminServerUpdateRequest) String() string {
+func (x *ReplicationAck) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminServerUpdateRequest) ProtoMessage() {}
+func (*ReplicationAck) ProtoMessage() {}
 
-func (x *AdminServerUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[15]
+func (x *ReplicationAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,56 +11807,44 @@ func (x *AdminServerUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminServerUpdateRequest.ProtoReflect.Descriptor instead.
-func (*AdminServerUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *AdminServerUpdateRequest) GetServerId() string {
-	if x != nil {
-		return x.ServerId
-	}
-	return ""
-}
-
-func (x *AdminServerUpdateRequest) GetForcedState() ServerState {
-	if x != nil {
-		return x.ForcedState
-	}
-	return ServerState_SERVER_STATE_UNKNOWN
+// Deprecated: Use ReplicationAck.ProtoReflect.Descriptor instead.
+func (*ReplicationAck) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *AdminServerUpdateRequest) GetClock() *VectorClock {
+func (x *ReplicationAck) GetLastSequence() uint64 {
 	if x != nil {
-		return x.Clock
+		return x.LastSequence
 	}
-	return nil
+	return 0
 }
 
-type AdminUpdateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Clock         *VectorClock           `protobuf:"bytes,3,opt,name=clock,proto3" json:"clock,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Traspaso al apagarse: el Matchmaker que se retira envía su último
+// snapshot con TransferState.
+type TransferStateResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Adopted        bool                   `protobuf:"varint,1,opt,name=adopted,proto3" json:"adopted,omitempty"`                                     // respaldo pasivo: tomó el estado completo y asumió como primario
+	QueuedImported int32                  `protobuf:"varint,2,opt,name=queued_imported,json=queuedImported,proto3" json:"queued_imported,omitempty"` // Matchmaker activo: jugadores en cola sumados a la suya
+	Message        string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *AdminUpdateResponse) Reset() {
-	*x = AdminUpdateResponse{}
-	mi := &file_proto_matchmaking_proto_msgTypes[16]
+func (x *TransferStateResponse) Reset() {
+	*x = TransferStateResponse{}
+	mi := &file_proto_matchmaking_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminUpdateResponse) String() string {
+func (x *TransferStateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminUpdateResponse) ProtoMessage() {}
+func (*TransferStateResponse) ProtoMessage() {}
 
-func (x *AdminUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_proto_msgTypes[16]
+func (x *TransferStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,117 +11855,1233 @@ func (x *AdminUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminUpdateResponse.ProtoReflect.Descriptor instead.
-func (*AdminUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TransferStateResponse.ProtoReflect.Descriptor instead.
+func (*TransferStateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_proto_rawDescGZIP(), []int{126}
 }
 
-func (x *AdminUpdateResponse) GetSuccess() bool {
+func (x *TransferStateResponse) GetAdopted() bool {
 	if x != nil {
-		return x.Success
+		return x.Adopted
 	}
 	return false
 }
 
-func (x *AdminUpdateResponse) GetMessage() string {
+func (x *TransferStateResponse) GetQueuedImported() int32 {
 	if x != nil {
-		return x.Message
+		return x.QueuedImported
 	}
-	return ""
+	return 0
 }
 
-func (x *AdminUpdateResponse) GetClock() *VectorClock {
+func (x *TransferStateResponse) GetMessage() string {
 	if x != nil {
-		return x.Clock
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
 var File_proto_matchmaking_proto protoreflect.FileDescriptor
 
 const file_proto_matchmaking_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/matchmaking.proto\x12\vmatchmaking\x1a\x1fgoogle/protobuf/timestamp.proto\"'\n" +
-	"\vVectorClock\x12\x18\n" +
-	"\aentries\x18\x01 \x03(\rR\aentries\"}\n" +
+	"\x17proto/matchmaking.proto\x12\vmatchmaking\"\x8e\x01\n" +
+	"\vVectorClock\x12B\n" +
+	"\bcounters\x18\x01 \x03(\v2&.matchmaking.VectorClock.CountersEntryR\bcounters\x1a;\n" +
+	"\rCountersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xb2\x04\n" +
+	"\bGameMode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tteam_size\x18\x02 \x01(\x05R\bteamSize\x12$\n" +
+	"\x0emin_duration_s\x18\x03 \x01(\x05R\fminDurationS\x12$\n" +
+	"\x0emax_duration_s\x18\x04 \x01(\x05R\fmaxDurationS\x12\x18\n" +
+	"\aenabled\x18\x05 \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"cooldown_s\x18\x06 \x01(\x05R\tcooldownS\x12*\n" +
+	"\x11lobby_min_players\x18\a \x01(\x05R\x0flobbyMinPlayers\x12*\n" +
+	"\x11lobby_max_players\x18\b \x01(\x05R\x0flobbyMaxPlayers\x12 \n" +
+	"\flobby_wait_s\x18\t \x01(\x05R\n" +
+	"lobbyWaitS\x127\n" +
+	"\ascoring\x18\n" +
+	" \x01(\x0e2\x1d.matchmaking.GameMode.ScoringR\ascoring\x12*\n" +
+	"\x11event_interval_ms\x18\v \x01(\x05R\x0feventIntervalMs\x12\x1d\n" +
+	"\n" +
+	"score_prob\x18\f \x01(\x02R\tscoreProb\x12\x1f\n" +
+	"\vkill_points\x18\r \x01(\x05R\n" +
+	"killPoints\x12!\n" +
+	"\fdeath_points\x18\x0e \x01(\x05R\vdeathPoints\".\n" +
+	"\aScoring\x12\x0e\n" +
+	"\n" +
+	"DEATHMATCH\x10\x00\x12\x13\n" +
+	"\x0fTEAM_DEATHMATCH\x10\x01\"\xb9\x02\n" +
 	"\x11PlayerInfoRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
 	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"y\n" +
-	"\x13QueuePlayerResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"b\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x126\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x1a.matchmaking.QueuePriorityR\bpriority\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12L\n" +
+	"\x11on_assign_failure\x18\a \x01(\x0e2 .matchmaking.AssignFailureActionR\x0fonAssignFailure\"\xe9\x03\n" +
+	"\x13QueuePlayerResponse\x12L\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2+.matchmaking.QueuePlayerResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x122\n" +
+	"\x15cooldown_remaining_ms\x18\x04 \x01(\x03R\x13cooldownRemainingMs\x12\x1d\n" +
+	"\n" +
+	"shard_addr\x18\x05 \x01(\tR\tshardAddr\"\xd9\x01\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x14\n" +
+	"\x10ALREADY_IN_QUEUE\x10\x01\x12\f\n" +
+	"\bIN_MATCH\x10\x02\x12\x10\n" +
+	"\fUNKNOWN_MODE\x10\x03\x12\x14\n" +
+	"\x10NOT_PARTY_LEADER\x10\x04\x12\x13\n" +
+	"\x0fPARTY_TOO_LARGE\x10\x05\x12\x13\n" +
+	"\x0fPARTY_NOT_READY\x10\x06\x12\n" +
+	"\n" +
+	"\x06BANNED\x10\a\x12\f\n" +
+	"\bCOOLDOWN\x10\b\x12\x0f\n" +
+	"\vWRONG_SHARD\x10\t\x12\f\n" +
+	"\bIN_LOBBY\x10\n" +
+	"\x12\x14\n" +
+	"\x10SESSION_CONFLICT\x10\v\"\x7f\n" +
+	"\x12CancelQueueRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12.\n" +
+	"\x05clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\xe2\x01\n" +
+	"\x13CancelQueueResponse\x12L\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2+.matchmaking.CancelQueueResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"&\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x10\n" +
+	"\fNOT_IN_QUEUE\x10\x01\"\x99\x01\n" +
+	"\x11LeaveMatchRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x98\x02\n" +
+	"\x12LeaveMatchResponse\x12K\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2*.matchmaking.LeaveMatchResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bmatch_id\x18\x03 \x01(\tR\amatchId\x12\x1b\n" +
+	"\twinner_id\x18\x04 \x01(\tR\bwinnerId\x12;\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"&\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x10\n" +
+	"\fNOT_IN_MATCH\x10\x01\"\xbc\x01\n" +
 	"\x13PlayerStatusRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12.\n" +
-	"\x05clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xb2\x01\n" +
-	"\x14PlayerStatusResponse\x12.\n" +
-	"\x05state\x18\x01 \x01(\x0e2\x18.matchmaking.PlayerStateR\x05state\x12\x19\n" +
+	"\x05clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12:\n" +
+	"\vconsistency\x18\x04 \x01(\x0e2\x18.matchmaking.ConsistencyR\vconsistency\"\x85\x03\n" +
+	"\x14PlayerStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x1f\n" +
+	"\vserver_addr\x18\x03 \x01(\tR\n" +
+	"serverAddr\x12;\n" +
+	"\fvector_clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12$\n" +
+	"\x0eretry_after_ms\x18\x05 \x01(\x03R\fretryAfterMs\x12\x1d\n" +
+	"\n" +
+	"match_addr\x18\x06 \x01(\tR\tmatchAddr\x12:\n" +
+	"\vconsistency\x18\a \x01(\x0e2\x18.matchmaking.ConsistencyR\vconsistency\x12%\n" +
+	"\x0equeue_position\x18\b \x01(\x05R\rqueuePosition\x12\x17\n" +
+	"\await_ms\x18\t \x01(\x03R\x06waitMs\x12\x1b\n" +
+	"\tgame_mode\x18\n" +
+	" \x01(\tR\bgameMode\"Q\n" +
+	"\x14ResumeSessionRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xfa\x01\n" +
+	"\x15ResumeSessionResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x19\n" +
+	"\bmatch_id\x18\x03 \x01(\tR\amatchId\x12\x1f\n" +
+	"\vserver_addr\x18\x04 \x01(\tR\n" +
+	"serverAddr\x12\x1b\n" +
+	"\tgame_mode\x18\x05 \x01(\tR\bgameMode\x12;\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1d\n" +
+	"\n" +
+	"match_addr\x18\a \x01(\tR\tmatchAddr\"1\n" +
+	"\x11ServerInfoRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\xea\x01\n" +
+	"\x12ServerInfoResponse\x12)\n" +
+	"\x10protocol_version\x18\x01 \x01(\x05R\x0fprotocolVersion\x120\n" +
+	"\x14min_protocol_version\x18\x02 \x01(\x05R\x12minProtocolVersion\x126\n" +
+	"\x17client_protocol_version\x18\x03 \x01(\x05R\x15clientProtocolVersion\x12\x1a\n" +
+	"\bfeatures\x18\x04 \x03(\tR\bfeatures\x12#\n" +
+	"\rmatchmaker_id\x18\x05 \x01(\tR\fmatchmakerId\"\x83\x01\n" +
+	"\x16PlayerHeartbeatRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12.\n" +
+	"\x05clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\x8f\x01\n" +
+	"\x17PlayerHeartbeatResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1f\n" +
+	"\vinterval_ms\x18\x02 \x01(\x03R\n" +
+	"intervalMs\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\x82\x01\n" +
+	"\x15RegisterPlayerRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xd8\x02\n" +
+	"\x16RegisterPlayerResponse\x12O\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2..matchmaking.RegisterPlayerResponse.StatusCodeR\n" +
+	"statusCode\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12+\n" +
+	"\x12expires_at_unix_ms\x18\x03 \x01(\x03R\x0fexpiresAtUnixMs\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"S\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x16\n" +
+	"\x12ALREADY_REGISTERED\x10\x01\x12\x11\n" +
+	"\rAUTH_DISABLED\x10\x02\x12\x12\n" +
+	"\x0eINVALID_PLAYER\x10\x03\"4\n" +
+	"\x14ListGameModesRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x81\x01\n" +
+	"\x15ListGameModesResponse\x12+\n" +
+	"\x05modes\x18\x01 \x03(\v2\x15.matchmaking.GameModeR\x05modes\x12;\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\xb8\x01\n" +
+	"\x10SubscribeRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x129\n" +
+	"\vresume_from\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\n" +
+	"resumeFrom\"\xb7\x05\n" +
+	"\vMatchUpdate\x124\n" +
+	"\x05event\x18\x01 \x01(\x0e2\x1e.matchmaking.MatchUpdate.EventR\x05event\x12\x19\n" +
 	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x1f\n" +
 	"\vserver_addr\x18\x03 \x01(\tR\n" +
-	"serverAddr\x12.\n" +
-	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"~\n" +
+	"serverAddr\x12\x1b\n" +
+	"\twinner_id\x18\x04 \x01(\tR\bwinnerId\x12;\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x123\n" +
+	"\x16ready_deadline_unix_ms\x18\x06 \x01(\x03R\x13readyDeadlineUnixMs\x12\x1a\n" +
+	"\brequeued\x18\a \x01(\bR\brequeued\x12\x1d\n" +
+	"\n" +
+	"winner_ids\x18\b \x03(\tR\twinnerIds\x12B\n" +
+	"\x1erequeue_offer_deadline_unix_ms\x18\t \x01(\x03R\x1arequeueOfferDeadlineUnixMs\x127\n" +
+	"\x18rematch_deadline_unix_ms\x18\n" +
+	" \x01(\x03R\x15rematchDeadlineUnixMs\"\xee\x01\n" +
+	"\x05Event\x12\x0f\n" +
+	"\vMATCH_FOUND\x10\x00\x12\x12\n" +
+	"\x0eMATCH_FINISHED\x10\x01\x12\x11\n" +
+	"\rMATCH_ABORTED\x10\x02\x12\v\n" +
+	"\aREMOVED\x10\x03\x12\x0f\n" +
+	"\vPLAYER_LEFT\x10\x04\x12\x13\n" +
+	"\x0fQUEUE_TIMED_OUT\x10\x05\x12\x0f\n" +
+	"\vREADY_CHECK\x10\x06\x12\x16\n" +
+	"\x12READY_CHECK_FAILED\x10\a\x12\x12\n" +
+	"\x0eHEARTBEAT_LOST\x10\b\x12\x11\n" +
+	"\rASSIGN_FAILED\x10\t\x12\x13\n" +
+	"\x0fREMATCH_OFFERED\x10\n" +
+	"\x12\x15\n" +
+	"\x11REMATCH_CANCELLED\x10\v\"\xb2\x01\n" +
+	"\x12AcceptMatchRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\x12.\n" +
+	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"\x97\x02\n" +
+	"\x13AcceptMatchResponse\x12L\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2+.matchmaking.AcceptMatchResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\baccepted\x18\x03 \x01(\x05R\baccepted\x12\x18\n" +
+	"\aplayers\x18\x04 \x01(\x05R\aplayers\x12;\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"%\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x0f\n" +
+	"\vNOT_PENDING\x10\x01\"\x96\x01\n" +
+	"\x0eRematchRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x95\x03\n" +
+	"\x0fRematchResponse\x12H\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2'.matchmaking.RematchResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\baccepted\x18\x03 \x01(\x05R\baccepted\x12\x18\n" +
+	"\aplayers\x18\x04 \x01(\x05R\aplayers\x12(\n" +
+	"\x10deadline_unix_ms\x18\x05 \x01(\x03R\x0edeadlineUnixMs\x12\x1d\n" +
+	"\n" +
+	"rematch_id\x18\x06 \x01(\tR\trematchId\x12\x1b\n" +
+	"\tserver_id\x18\a \x01(\tR\bserverId\x12;\n" +
+	"\fvector_clock\x18\b \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"E\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\f\n" +
+	"\bNO_OFFER\x10\x01\x12\x10\n" +
+	"\fNOT_A_PLAYER\x10\x02\x12\x0f\n" +
+	"\vPLAYER_BUSY\x10\x03\"\xee\x01\n" +
 	"\x12AssignMatchRequest\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1d\n" +
 	"\n" +
-	"player_ids\x18\x02 \x03(\tR\tplayerIds\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"y\n" +
-	"\x13AssignMatchResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xb9\x01\n" +
+	"player_ids\x18\x02 \x03(\tR\tplayerIds\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12)\n" +
+	"\x04mode\x18\x05 \x01(\v2\x15.matchmaking.GameModeR\x04mode\x12\x18\n" +
+	"\aattempt\x18\x06 \x01(\x05R\aattempt\"\xc2\x02\n" +
+	"\x13AssignMatchResponse\x12L\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2+.matchmaking.AssignMatchResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1c\n" +
+	"\tduplicate\x18\x04 \x01(\bR\tduplicate\x12)\n" +
+	"\x10original_attempt\x18\x05 \x01(\x05R\x0foriginalAttempt\x12\x1d\n" +
+	"\n" +
+	"match_addr\x18\x06 \x01(\tR\tmatchAddr\"\x1e\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\b\n" +
+	"\x04BUSY\x10\x01\"\xa2\x01\n" +
+	"\x12CancelMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xa6\x01\n" +
+	"\x13CancelMatchResponse\x12L\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2+.matchmaking.CancelMatchResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"'\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x11\n" +
+	"\rUNKNOWN_MATCH\x10\x01\"O\n" +
+	"\x0fSpectateRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12!\n" +
+	"\fspectator_id\x18\x02 \x01(\tR\vspectatorId\"\x81\x03\n" +
+	"\rSpectateEvent\x123\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1f.matchmaking.SpectateEvent.KindR\x04kind\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tgame_mode\x18\x03 \x01(\tR\bgameMode\x12!\n" +
+	"\fremaining_ms\x18\x04 \x01(\x03R\vremainingMs\x12\x1b\n" +
+	"\tplayer_id\x18\x05 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tvictim_id\x18\x06 \x01(\tR\bvictimId\x12=\n" +
+	"\n" +
+	"scoreboard\x18\a \x03(\v2\x1d.matchmaking.PlayerMatchStatsR\n" +
+	"scoreboard\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12\x17\n" +
+	"\aunix_ms\x18\t \x01(\x03R\x06unixMs\"4\n" +
+	"\x04Kind\x12\f\n" +
+	"\bSNAPSHOT\x10\x00\x12\t\n" +
+	"\x05SCORE\x10\x01\x12\b\n" +
+	"\x04TICK\x10\x02\x12\t\n" +
+	"\x05ENDED\x10\x03\"\x88\x01\n" +
+	"\x19PublishMatchEventsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x120\n" +
+	"\x05event\x18\x03 \x01(\v2\x1a.matchmaking.SpectateEventR\x05event\"p\n" +
+	"\x1aPublishMatchEventsResponse\x12\x1a\n" +
+	"\breceived\x18\x01 \x01(\x03R\breceived\x12\x1c\n" +
+	"\tdelivered\x18\x02 \x01(\x03R\tdelivered\x12\x18\n" +
+	"\adropped\x18\x03 \x01(\x03R\adropped\"{\n" +
+	"\x1bSubscribeMatchEventsRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12#\n" +
+	"\rsubscriber_id\x18\x03 \x01(\tR\fsubscriberId\"\xe3\x03\n" +
 	"\x19ServerStatusUpdateRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\x125\n" +
-	"\tnew_state\x18\x02 \x01(\x0e2\x18.matchmaking.ServerStateR\bnewState\x12\x18\n" +
-	"\aaddress\x18\x03 \x01(\tR\aaddress\x12.\n" +
-	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\x80\x01\n" +
-	"\x1aServerStatusUpdateResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"*\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x128\n" +
+	"\n" +
+	"new_status\x18\x02 \x01(\x0e2\x19.matchmaking.ServerStatusR\tnewStatus\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x19\n" +
+	"\bmatch_id\x18\x04 \x01(\tR\amatchId\x12.\n" +
+	"\x05clock\x18\x05 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x06 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\b \x01(\rR\tlatencyMs\x12\x1a\n" +
+	"\bcapacity\x18\t \x01(\x05R\bcapacity\x12\x1d\n" +
+	"\n" +
+	"free_slots\x18\n" +
+	" \x01(\x05R\tfreeSlots\x12B\n" +
+	"\x0frunning_matches\x18\v \x03(\v2\x19.matchmaking.RunningMatchR\x0erunningMatches\x12 \n" +
+	"\vtraceparent\x18\f \x01(\tR\vtraceparent\x12\x14\n" +
+	"\x05epoch\x18\r \x01(\x04R\x05epoch\"\xd8\x01\n" +
+	"\fRunningMatch\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x02 \x03(\tR\tplayerIds\x12\x1b\n" +
+	"\tgame_mode\x18\x03 \x01(\tR\bgameMode\x12+\n" +
+	"\x12started_at_unix_ms\x18\x04 \x01(\x03R\x0fstartedAtUnixMs\x12%\n" +
+	"\x0fends_at_unix_ms\x18\x05 \x01(\x03R\fendsAtUnixMs\x12\x1d\n" +
+	"\n" +
+	"match_addr\x18\x06 \x01(\tR\tmatchAddr\"\xab\x02\n" +
+	"\x1aServerStatusUpdateResponse\x12S\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e22.matchmaking.ServerStatusUpdateResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x14\n" +
+	"\x05epoch\x18\x04 \x01(\x04R\x05epoch\"K\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\v\n" +
+	"\aDRAINED\x10\x01\x12\x13\n" +
+	"\x0fINVALID_ADDRESS\x10\x02\x12\x13\n" +
+	"\x0fWRONG_NAMESPACE\x10\x03\"\xed\x02\n" +
+	"\rServerControl\x12<\n" +
+	"\acommand\x18\x01 \x01(\x0e2\".matchmaking.ServerControl.CommandR\acommand\x129\n" +
+	"\x03ack\x18\x02 \x01(\v2'.matchmaking.ServerStatusUpdateResponseR\x03ack\x12\x19\n" +
+	"\bmatch_id\x18\x03 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x18\n" +
+	"\aretired\x18\x05 \x01(\bR\aretired\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x06 \x03(\tR\tplayerIds\x12.\n" +
+	"\x05chaos\x18\a \x01(\v2\x18.matchmaking.ChaosConfigR\x05chaos\"G\n" +
+	"\aCommand\x12\a\n" +
+	"\x03ACK\x10\x00\x12\t\n" +
+	"\x05DRAIN\x10\x01\x12\x0f\n" +
+	"\vABORT_MATCH\x10\x02\x12\f\n" +
+	"\bBACKFILL\x10\x03\x12\t\n" +
+	"\x05CHAOS\x10\x04\"s\n" +
+	"\x10PlayerMatchStats\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x05R\x05score\x12\x14\n" +
+	"\x05kills\x18\x03 \x01(\x05R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\x04 \x01(\x05R\x06deaths\"[\n" +
+	"\n" +
+	"ScoreEvent\x12\x13\n" +
+	"\x05at_ms\x18\x01 \x01(\x03R\x04atMs\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tvictim_id\x18\x03 \x01(\tR\bvictimId\"\xea\x02\n" +
+	"\x12MatchResultRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x1b\n" +
+	"\twinner_id\x18\x03 \x01(\tR\bwinnerId\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12@\n" +
+	"\fplayer_stats\x18\x05 \x03(\v2\x1d.matchmaking.PlayerMatchStatsR\vplayerStats\x12.\n" +
+	"\x05clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\a \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"winner_ids\x18\b \x03(\tR\twinnerIds\x12/\n" +
+	"\x06events\x18\t \x03(\v2\x17.matchmaking.ScoreEventR\x06events\"\x84\x02\n" +
+	"\x13MatchResultResponse\x12L\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2+.matchmaking.MatchResultResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"H\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x11\n" +
+	"\rUNKNOWN_MATCH\x10\x01\x12\x10\n" +
+	"\fNOT_ASSIGNED\x10\x02\x12\r\n" +
+	"\tNOT_OWNER\x10\x03\"\xbb\x05\n" +
+	"\vMatchRecord\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tgame_mode\x18\x04 \x01(\tR\bgameMode\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x05 \x03(\tR\tplayerIds\x12\x1b\n" +
+	"\twinner_id\x18\x06 \x01(\tR\bwinnerId\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\x12+\n" +
+	"\x12started_at_unix_ms\x18\b \x01(\x03R\x0fstartedAtUnixMs\x12-\n" +
+	"\x13finished_at_unix_ms\x18\t \x01(\x03R\x10finishedAtUnixMs\x12:\n" +
+	"\aoutcome\x18\n" +
+	" \x01(\x0e2 .matchmaking.MatchRecord.OutcomeR\aoutcome\x12@\n" +
+	"\fplayer_stats\x18\v \x03(\v2\x1d.matchmaking.PlayerMatchStatsR\vplayerStats\x123\n" +
+	"\btimeline\x18\f \x03(\v2\x17.matchmaking.MatchEventR\btimeline\x12\x1d\n" +
+	"\n" +
+	"winner_ids\x18\r \x03(\tR\twinnerIds\x12:\n" +
+	"\fscore_events\x18\x0e \x03(\v2\x17.matchmaking.ScoreEventR\vscoreEvents\"t\n" +
+	"\aOutcome\x12\r\n" +
+	"\tCOMPLETED\x10\x00\x12\x0f\n" +
+	"\vSERVER_DOWN\x10\x01\x12\v\n" +
+	"\aTIMEOUT\x10\x02\x12\v\n" +
+	"\aABORTED\x10\x03\x12\r\n" +
+	"\tABANDONED\x10\x04\x12\x10\n" +
+	"\fDEREGISTERED\x10\x05\x12\x0e\n" +
+	"\n" +
+	"TERMINATED\x10\x06\"\xbd\x02\n" +
+	"\n" +
+	"MatchEvent\x123\n" +
+	"\x05stage\x18\x01 \x01(\x0e2\x1d.matchmaking.MatchEvent.StageR\x05stage\x12\x17\n" +
+	"\aunix_ms\x18\x02 \x01(\x03R\x06unixMs\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1b\n" +
+	"\tplayer_id\x18\x04 \x01(\tR\bplayerId\x12\x16\n" +
+	"\x06detail\x18\x05 \x01(\tR\x06detail\"o\n" +
+	"\x05Stage\x12\n" +
+	"\n" +
+	"\x06QUEUED\x10\x00\x12\v\n" +
+	"\aMATCHED\x10\x01\x12\f\n" +
+	"\bASSIGNED\x10\x02\x12\f\n" +
+	"\bACCEPTED\x10\x03\x12\v\n" +
+	"\aSTARTED\x10\x04\x12\t\n" +
+	"\x05ENDED\x10\x05\x12\x0e\n" +
+	"\n" +
+	"BACKFILLED\x10\x06\x12\t\n" +
+	"\x05READY\x10\a\"O\n" +
+	"\x14MatchTimelineRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\"\x91\x02\n" +
+	"\x15MatchTimelineResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tgame_mode\x18\x03 \x01(\tR\bgameMode\x12\x1b\n" +
+	"\tserver_id\x18\x04 \x01(\tR\bserverId\x12/\n" +
+	"\x06events\x18\x05 \x03(\v2\x17.matchmaking.MatchEventR\x06events\x12;\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1f\n" +
+	"\vserver_addr\x18\a \x01(\tR\n" +
+	"serverAddr\"\x8c\x01\n" +
+	"\x13MatchHistoryRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\xaf\x01\n" +
+	"\x14MatchHistoryResponse\x122\n" +
+	"\amatches\x18\x01 \x03(\v2\x18.matchmaking.MatchRecordR\amatches\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\xee\x01\n" +
+	"\vPlayerStats\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12%\n" +
+	"\x0ematches_played\x18\x02 \x01(\x05R\rmatchesPlayed\x12\x12\n" +
+	"\x04wins\x18\x03 \x01(\x05R\x04wins\x12\x19\n" +
+	"\bwin_rate\x18\x04 \x01(\x01R\awinRate\x12\x1e\n" +
+	"\vavg_wait_ms\x18\x05 \x01(\x03R\tavgWaitMs\x12\"\n" +
+	"\rtotal_wait_ms\x18\x06 \x01(\x03R\vtotalWaitMs\x12\x14\n" +
+	"\x05waits\x18\a \x01(\x05R\x05waits\x12\x12\n" +
+	"\x04rank\x18\b \x01(\x05R\x04rank\"\xec\x01\n" +
+	"\x12LeaderboardRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12?\n" +
+	"\asort_by\x18\x02 \x01(\x0e2&.matchmaking.LeaderboardRequest.SortByR\x06sortBy\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\";\n" +
+	"\x06SortBy\x12\b\n" +
+	"\x04WINS\x10\x00\x12\v\n" +
+	"\aMATCHES\x10\x01\x12\f\n" +
+	"\bWIN_RATE\x10\x02\x12\f\n" +
+	"\bAVG_WAIT\x10\x03\"\xd3\x01\n" +
+	"\x13LeaderboardResponse\x122\n" +
+	"\aentries\x18\x01 \x03(\v2\x18.matchmaking.PlayerStatsR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12#\n" +
+	"\rtotal_players\x18\x03 \x01(\x05R\ftotalPlayers\x12;\n" +
+	"\fvector_clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\xb1\x01\n" +
+	"\x11AbortMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12.\n" +
+	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"\xf0\x01\n" +
+	"\x12AbortMatchResponse\x12K\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2*.matchmaking.AbortMatchResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"6\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x11\n" +
+	"\rUNKNOWN_MATCH\x10\x01\x12\r\n" +
+	"\tNOT_OWNER\x10\x02\"\xed\x01\n" +
+	"\x16RequestBackfillRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x19\n" +
+	"\bmatch_id\x18\x03 \x01(\tR\amatchId\x12\x1d\n" +
+	"\n" +
+	"open_slots\x18\x04 \x01(\x05R\topenSlots\x12.\n" +
+	"\x13departed_player_ids\x18\x05 \x03(\tR\x11departedPlayerIds\x12.\n" +
+	"\x05clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xa8\x02\n" +
+	"\x17RequestBackfillResponse\x12P\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2/.matchmaking.RequestBackfillResponse.StatusCodeR\n" +
+	"statusCode\x12\x1d\n" +
+	"\n" +
+	"open_slots\x18\x02 \x01(\x05R\topenSlots\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"E\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x11\n" +
+	"\rUNKNOWN_MATCH\x10\x01\x12\r\n" +
+	"\tNOT_OWNER\x10\x02\x12\r\n" +
+	"\tNOT_LOBBY\x10\x03\"\x9c\x01\n" +
+	"\x17DeregisterServerRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12.\n" +
+	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\x97\x02\n" +
+	"\x18DeregisterServerResponse\x12Q\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e20.matchmaking.DeregisterServerResponse.StatusCodeR\n" +
+	"statusCode\x12'\n" +
+	"\x0faborted_matches\x18\x02 \x01(\x05R\x0eabortedMatches\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"(\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x12\n" +
+	"\x0eUNKNOWN_SERVER\x10\x01\"\xe0\x01\n" +
+	"\x14MatchFeedbackRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06rating\x18\x03 \x01(\x05R\x06rating\x12\x10\n" +
+	"\x03lag\x18\x04 \x01(\bR\x03lag\x12\x18\n" +
+	"\acomment\x18\x05 \x01(\tR\acomment\x12.\n" +
+	"\x05clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\a \x01(\tR\tnamespace\"\x9c\x02\n" +
+	"\x15MatchFeedbackResponse\x12N\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2-.matchmaking.MatchFeedbackResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\\\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x11\n" +
+	"\rUNKNOWN_MATCH\x10\x01\x12\x10\n" +
+	"\fNOT_A_PLAYER\x10\x02\x12\x12\n" +
+	"\x0eINVALID_RATING\x10\x03\x12\r\n" +
+	"\tDUPLICATE\x10\x04\"\x94\x01\n" +
+	"\fPartyRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
+	"\bparty_id\x18\x02 \x01(\tR\apartyId\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xf8\x02\n" +
+	"\rPartyResponse\x12F\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2%.matchmaking.PartyResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bparty_id\x18\x03 \x01(\tR\apartyId\x12\x1b\n" +
+	"\tleader_id\x18\x04 \x01(\tR\bleaderId\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x05 \x03(\tR\tmemberIds\x12;\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"q\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x14\n" +
+	"\x10ALREADY_IN_PARTY\x10\x01\x12\x13\n" +
+	"\x0fPARTY_NOT_FOUND\x10\x02\x12\x0e\n" +
+	"\n" +
+	"PARTY_FULL\x10\x03\x12\x0e\n" +
+	"\n" +
+	"PARTY_BUSY\x10\x04\x12\x10\n" +
+	"\fNOT_IN_PARTY\x10\x05\"\xb9\x01\n" +
+	"\x12CreateLobbyRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12.\n" +
+	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x05 \x01(\tR\tnamespace\"\x8d\x01\n" +
+	"\fLobbyRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\x88\x04\n" +
+	"\rLobbyResponse\x12F\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2%.matchmaking.LobbyResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1b\n" +
+	"\tgame_mode\x18\x04 \x01(\tR\bgameMode\x12\x1b\n" +
+	"\tserver_id\x18\x05 \x01(\tR\bserverId\x12\x19\n" +
+	"\bowner_id\x18\x06 \x01(\tR\aownerId\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\a \x03(\tR\tmemberIds\x12\x1a\n" +
+	"\bcapacity\x18\b \x01(\x05R\bcapacity\x12\x19\n" +
+	"\bmatch_id\x18\t \x01(\tR\amatchId\x12;\n" +
+	"\fvector_clock\x18\n" +
+	" \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\x98\x01\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\x14\n" +
+	"\x10ALREADY_IN_LOBBY\x10\x01\x12\x13\n" +
+	"\x0fLOBBY_NOT_FOUND\x10\x02\x12\x0e\n" +
+	"\n" +
+	"LOBBY_FULL\x10\x03\x12\x0f\n" +
+	"\vPLAYER_BUSY\x10\x04\x12\x10\n" +
+	"\fUNKNOWN_MODE\x10\x05\x12\x12\n" +
+	"\x0eUNKNOWN_SERVER\x10\x06\x12\x10\n" +
+	"\fNOT_IN_LOBBY\x10\a\"*\n" +
 	"\vPingRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\"$\n" +
 	"\fPingResponse\x12\x14\n" +
-	"\x05alive\x18\x01 \x01(\bR\x05alive\"\x0e\n" +
-	"\fAdminRequest\"\x9d\x01\n" +
+	"\x05alive\x18\x01 \x01(\bR\x05alive\",\n" +
+	"\fAdminRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x9e\x03\n" +
 	"\n" +
 	"ServerInfo\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12.\n" +
-	"\x05state\x18\x02 \x01(\x0e2\x18.matchmaking.ServerStateR\x05state\x12\x18\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x121\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x19.matchmaking.ServerStatusR\x06status\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12(\n" +
-	"\x10current_match_id\x18\x04 \x01(\tR\x0ecurrentMatchId\"n\n" +
+	"\x10current_match_id\x18\x04 \x01(\tR\x0ecurrentMatchId\x12%\n" +
+	"\x0elast_heartbeat\x18\x05 \x01(\x03R\rlastHeartbeat\x12\x1a\n" +
+	"\bdraining\x18\x06 \x01(\bR\bdraining\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\b \x01(\rR\tlatencyMs\x12\x1a\n" +
+	"\bcapacity\x18\t \x01(\x05R\bcapacity\x12\x1d\n" +
+	"\n" +
+	"free_slots\x18\n" +
+	" \x01(\x05R\tfreeSlots\x12 \n" +
+	"\vutilization\x18\v \x01(\x01R\vutilization\x12%\n" +
+	"\x0ematches_recent\x18\f \x01(\x05R\rmatchesRecent\"\x8e\x02\n" +
 	"\x10PlayerQueueEntry\x12\x1b\n" +
-	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12=\n" +
-	"\fqueued_since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vqueuedSince\"\xb2\x01\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12(\n" +
+	"\x10seconds_in_queue\x18\x02 \x01(\x03R\x0esecondsInQueue\x126\n" +
+	"\bpriority\x18\x03 \x01(\x0e2\x1a.matchmaking.QueuePriorityR\bpriority\x12+\n" +
+	"\x04tier\x18\x04 \x01(\x0e2\x17.matchmaking.PlayerTierR\x04tier\x12\x16\n" +
+	"\x06region\x18\x05 \x01(\tR\x06region\x12\x19\n" +
+	"\bparty_id\x18\x06 \x01(\tR\apartyId\x12\x1b\n" +
+	"\tgame_mode\x18\a \x01(\tR\bgameMode\"\x9c\x02\n" +
+	"\x0eModeQueueStats\x12\x1b\n" +
+	"\tgame_mode\x18\x01 \x01(\tR\bgameMode\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06queued\x18\x03 \x01(\x05R\x06queued\x12\x1e\n" +
+	"\vavg_wait_ms\x18\x04 \x01(\x03R\tavgWaitMs\x12\x1e\n" +
+	"\vmax_wait_ms\x18\x05 \x01(\x03R\tmaxWaitMs\x12%\n" +
+	"\x0ematches_recent\x18\x06 \x01(\x05R\rmatchesRecent\x12-\n" +
+	"\x13avg_matched_wait_ms\x18\a \x01(\x03R\x10avgMatchedWaitMs\x12%\n" +
+	"\x0eactive_matches\x18\b \x01(\x05R\ractiveMatches\"\xfe\x04\n" +
 	"\x14SystemStatusResponse\x121\n" +
-	"\aservers\x18\x01 \x03(\v2\x17.matchmaking.ServerInfoR\aservers\x127\n" +
-	"\aplayers\x18\x02 \x03(\v2\x1d.matchmaking.PlayerQueueEntryR\aplayers\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xa4\x01\n" +
+	"\aservers\x18\x01 \x03(\v2\x17.matchmaking.ServerInfoR\aservers\x12@\n" +
+	"\fplayer_queue\x18\x02 \x03(\v2\x1d.matchmaking.PlayerQueueEntryR\vplayerQueue\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x05 \x03(\tR\n" +
+	"namespaces\x121\n" +
+	"\x06banned\x18\x06 \x03(\v2\x19.matchmaking.BannedPlayerR\x06banned\x121\n" +
+	"\x05modes\x18\a \x03(\v2\x1b.matchmaking.ModeQueueStatsR\x05modes\x12\x19\n" +
+	"\bwindow_s\x18\b \x01(\x03R\awindowS\x124\n" +
+	"\aremoved\x18\t \x03(\v2\x1a.matchmaking.RemovedServerR\aremoved\x12E\n" +
+	"\x10queue_rate_limit\x18\n" +
+	" \x01(\v2\x1b.matchmaking.RateLimitStatsR\x0equeueRateLimit\x12#\n" +
+	"\rmatchmaker_id\x18\v \x01(\tR\fmatchmakerId\x12\x1f\n" +
+	"\vowned_modes\x18\f \x03(\tR\n" +
+	"ownedModes\x122\n" +
+	"\amatches\x18\r \x03(\v2\x18.matchmaking.MatchStatusR\amatches\"\xb1\x02\n" +
+	"\vMatchStatus\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x04 \x03(\tR\tplayerIds\x12-\n" +
+	"\x05phase\x18\x05 \x01(\x0e2\x17.matchmaking.MatchPhaseR\x05phase\x12&\n" +
+	"\x0fcreated_unix_ms\x18\x06 \x01(\x03R\rcreatedUnixMs\x12(\n" +
+	"\x10assigned_unix_ms\x18\a \x01(\x03R\x0eassignedUnixMs\x12-\n" +
+	"\x13in_progress_unix_ms\x18\b \x01(\x03R\x10inProgressUnixMs\"\xd0\x02\n" +
+	"\x0eRateLimitStats\x12\x1f\n" +
+	"\vplayer_rate\x18\x01 \x01(\x01R\n" +
+	"playerRate\x12!\n" +
+	"\fplayer_burst\x18\x02 \x01(\x05R\vplayerBurst\x12\x1f\n" +
+	"\vglobal_rate\x18\x03 \x01(\x01R\n" +
+	"globalRate\x12!\n" +
+	"\fglobal_burst\x18\x04 \x01(\x05R\vglobalBurst\x12\x18\n" +
+	"\aallowed\x18\x05 \x01(\x04R\aallowed\x12'\n" +
+	"\x0frejected_player\x18\x06 \x01(\x04R\x0erejectedPlayer\x12'\n" +
+	"\x0frejected_global\x18\a \x01(\x04R\x0erejectedGlobal\x12%\n" +
+	"\x0eactive_players\x18\b \x01(\x05R\ractivePlayers\x12#\n" +
+	"\rglobal_tokens\x18\t \x01(\x01R\fglobalTokens\"\x8b\x01\n" +
+	"\rRemovedServer\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12+\n" +
+	"\x12removed_at_unix_ms\x18\x04 \x01(\x03R\x0fremovedAtUnixMs\"C\n" +
+	"\fBannedPlayer\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xdb\x03\n" +
+	"\x14ClockMetricsResponse\x12\x18\n" +
+	"\aentries\x18\x01 \x01(\x05R\aentries\x12\x16\n" +
+	"\x06merges\x18\x02 \x01(\x04R\x06merges\x12(\n" +
+	"\x10avg_merge_micros\x18\x03 \x01(\x01R\x0eavgMergeMicros\x12(\n" +
+	"\x10max_merge_micros\x18\x04 \x01(\x01R\x0emaxMergeMicros\x12&\n" +
+	"\x0eserializations\x18\x05 \x01(\x04R\x0eserializations\x120\n" +
+	"\x14avg_serialized_bytes\x18\x06 \x01(\x01R\x12avgSerializedBytes\x122\n" +
+	"\x15last_serialized_bytes\x18\a \x01(\x05R\x13lastSerializedBytes\x12;\n" +
+	"\fvector_clock\x18\b \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12%\n" +
+	"\x0epruned_entries\x18\t \x01(\x04R\rprunedEntries\x12\x1e\n" +
+	"\n" +
+	"tombstones\x18\n" +
+	" \x01(\x05R\n" +
+	"tombstones\x12+\n" +
+	"\x11replica_conflicts\x18\v \x01(\x04R\x10replicaConflicts\"\xcb\x01\n" +
+	"\vEntityClock\x121\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x1d.matchmaking.EntityClock.KindR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12)\n" +
+	"\x11last_seen_unix_ms\x18\x04 \x01(\x03R\x0elastSeenUnixMs\"\x1e\n" +
+	"\x04Kind\x12\n" +
+	"\n" +
+	"\x06PLAYER\x10\x00\x12\n" +
+	"\n" +
+	"\x06SERVER\x10\x01\"\xe6\x01\n" +
+	"\x12ClockStateResponse\x12#\n" +
+	"\rmatchmaker_id\x18\x01 \x01(\tR\fmatchmakerId\x12;\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x128\n" +
+	"\n" +
+	"tombstones\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\n" +
+	"tombstones\x124\n" +
+	"\bentities\x18\x04 \x03(\v2\x18.matchmaking.EntityClockR\bentities\"\xe4\x01\n" +
+	"\x11ServerReliability\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x18\n" +
+	"\amatches\x18\x02 \x01(\x05R\amatches\x12%\n" +
+	"\x0efeedback_count\x18\x03 \x01(\x05R\rfeedbackCount\x12\x1d\n" +
+	"\n" +
+	"avg_rating\x18\x04 \x01(\x01R\tavgRating\x12\x1f\n" +
+	"\vlag_reports\x18\x05 \x01(\x05R\n" +
+	"lagReports\x12\x1b\n" +
+	"\tlag_ratio\x18\x06 \x01(\x01R\blagRatio\x12\x14\n" +
+	"\x05laggy\x18\a \x01(\bR\x05laggy\"\x8c\x01\n" +
+	"\x13ReliabilityResponse\x128\n" +
+	"\aservers\x18\x01 \x03(\v2\x1e.matchmaking.ServerReliabilityR\aservers\x12;\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\x8f\x02\n" +
+	"\tSLOStatus\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\x01R\x06target\x12!\n" +
+	"\fthreshold_ms\x18\x03 \x01(\x03R\vthresholdMs\x12\x18\n" +
+	"\amatched\x18\x04 \x01(\x05R\amatched\x12)\n" +
+	"\x10within_threshold\x18\x05 \x01(\x05R\x0fwithinThreshold\x12\x18\n" +
+	"\aoverdue\x18\x06 \x01(\x05R\aoverdue\x12\x1e\n" +
+	"\n" +
+	"compliance\x18\a \x01(\x01R\n" +
+	"compliance\x12\x1b\n" +
+	"\tburn_rate\x18\b \x01(\x01R\bburnRate\x12\x17\n" +
+	"\aat_risk\x18\t \x01(\bR\x06atRisk\"\x99\x01\n" +
+	"\x11SLOStatusResponse\x12*\n" +
+	"\x04slos\x18\x01 \x03(\v2\x16.matchmaking.SLOStatusR\x04slos\x12\x1b\n" +
+	"\twindow_ms\x18\x02 \x01(\x03R\bwindowMs\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\xd2\x01\n" +
+	"\rWhatIfRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12#\n" +
+	"\rextra_servers\x18\x03 \x01(\x05R\fextraServers\x12\x1b\n" +
+	"\tteam_size\x18\x04 \x01(\x05R\bteamSize\x12'\n" +
+	"\x0fduration_factor\x18\x05 \x01(\x01R\x0edurationFactor\x12\x1b\n" +
+	"\twindow_ms\x18\x06 \x01(\x03R\bwindowMs\"\xaf\x01\n" +
+	"\x0eWaitProjection\x12\x15\n" +
+	"\x06p50_ms\x18\x01 \x01(\x03R\x05p50Ms\x12\x15\n" +
+	"\x06p90_ms\x18\x02 \x01(\x03R\x05p90Ms\x12\x15\n" +
+	"\x06p99_ms\x18\x03 \x01(\x03R\x05p99Ms\x12\x18\n" +
+	"\amatched\x18\x04 \x01(\x05R\amatched\x12\x1c\n" +
+	"\tunmatched\x18\x05 \x01(\x05R\tunmatched\x12 \n" +
+	"\vutilization\x18\x06 \x01(\x01R\vutilization\"\xca\x02\n" +
+	"\x0eWhatIfResponse\x12\x1a\n" +
+	"\barrivals\x18\x01 \x01(\x05R\barrivals\x12\x1b\n" +
+	"\twindow_ms\x18\x02 \x01(\x03R\bwindowMs\x12\x18\n" +
+	"\aservers\x18\x03 \x01(\x05R\aservers\x12\x14\n" +
+	"\x05slots\x18\x04 \x01(\x05R\x05slots\x12 \n" +
+	"\favg_match_ms\x18\x05 \x01(\x03R\n" +
+	"avgMatchMs\x125\n" +
+	"\acurrent\x18\x06 \x01(\v2\x1b.matchmaking.WaitProjectionR\acurrent\x129\n" +
+	"\tprojected\x18\a \x01(\v2\x1b.matchmaking.WaitProjectionR\tprojected\x12;\n" +
+	"\fvector_clock\x18\b \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\x15\n" +
+	"\x13ReloadConfigRequest\"Z\n" +
+	"\fConfigChange\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\tR\bnewValue\"\x92\x02\n" +
+	"\x14ReloadConfigResponse\x12M\n" +
+	"\vstatus_code\x18\x01 \x01(\x0e2,.matchmaking.ReloadConfigResponse.StatusCodeR\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x123\n" +
+	"\achanges\x18\x04 \x03(\v2\x19.matchmaking.ConfigChangeR\achanges\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\"0\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\v\n" +
+	"\aINVALID\x10\x01\x12\r\n" +
+	"\tNO_CONFIG\x10\x02\"\xbf\x01\n" +
 	"\x18AdminServerUpdateRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12;\n" +
-	"\fforced_state\x18\x02 \x01(\x0e2\x18.matchmaking.ServerStateR\vforcedState\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"y\n" +
-	"\x13AdminUpdateResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
-	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock*Z\n" +
-	"\vPlayerState\x12\x15\n" +
-	"\x11PLAYER_STATE_IDLE\x10\x00\x12\x19\n" +
-	"\x15PLAYER_STATE_IN_QUEUE\x10\x01\x12\x19\n" +
-	"\x15PLAYER_STATE_IN_MATCH\x10\x02*q\n" +
-	"\vServerState\x12\x18\n" +
-	"\x14SERVER_STATE_UNKNOWN\x10\x00\x12\x1a\n" +
-	"\x16SERVER_STATE_AVAILABLE\x10\x01\x12\x15\n" +
-	"\x11SERVER_STATE_BUSY\x10\x02\x12\x15\n" +
-	"\x11SERVER_STATE_DOWN\x10\x032\xf5\x02\n" +
-	"\x11MatchmakerService\x12O\n" +
-	"\vQueuePlayer\x12\x1e.matchmaking.PlayerInfoRequest\x1a .matchmaking.QueuePlayerResponse\x12V\n" +
-	"\x0fGetPlayerStatus\x12 .matchmaking.PlayerStatusRequest\x1a!.matchmaking.PlayerStatusResponse\x12T\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x128\n" +
+	"\n" +
+	"new_status\x18\x02 \x01(\x0e2\x19.matchmaking.ServerStatusR\tnewStatus\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"3\n" +
+	"\x13QueueHistoryRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\x1e\n" +
+	"\bCsvChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x95\x01\n" +
+	"\x1aAdminUpsertGameModeRequest\x12)\n" +
+	"\x04mode\x18\x01 \x01(\v2\x15.matchmaking.GameModeR\x04mode\x12.\n" +
+	"\x05clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\xb3\x01\n" +
+	"\x19AdminSetPlayerTierRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12+\n" +
+	"\x04tier\x18\x02 \x01(\x0e2\x17.matchmaking.PlayerTierR\x04tier\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xb1\x01\n" +
+	"\x18AdminPlayerActionRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04lift\x18\x05 \x01(\bR\x04lift\"\x9d\x01\n" +
+	"\x1aAdminTerminateMatchRequest\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\"\xa5\x04\n" +
+	"\n" +
+	"StateEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x17\n" +
+	"\aunix_ms\x18\x02 \x01(\x03R\x06unixMs\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x120\n" +
+	"\x04kind\x18\x04 \x01(\x0e2\x1c.matchmaking.StateEvent.KindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x05 \x03(\tR\tplayerIds\x12\x19\n" +
+	"\bmatch_id\x18\x06 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\a \x01(\tR\bserverId\x12\x16\n" +
+	"\x06detail\x18\b \x01(\tR\x06detail\x12.\n" +
+	"\x05clock\x18\t \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xf2\x01\n" +
+	"\x04Kind\x12\x11\n" +
+	"\rPLAYER_QUEUED\x10\x00\x12\x13\n" +
+	"\x0fPLAYER_REQUEUED\x10\x01\x12\x13\n" +
+	"\x0fPLAYER_DEQUEUED\x10\x02\x12\x11\n" +
+	"\rMATCH_CREATED\x10\x03\x12\x14\n" +
+	"\x10MATCH_REASSIGNED\x10\x04\x12\x14\n" +
+	"\x10MATCH_BACKFILLED\x10\x05\x12\x11\n" +
+	"\rASSIGN_FAILED\x10\x06\x12\x0f\n" +
+	"\vMATCH_ENDED\x10\a\x12\r\n" +
+	"\tSERVER_UP\x10\b\x12\x0f\n" +
+	"\vSERVER_DOWN\x10\t\x12\x12\n" +
+	"\x0eSERVER_REMOVED\x10\n" +
+	"\x12\x16\n" +
+	"\x12READY_CHECK_FAILED\x10\v\"\xfb\x01\n" +
+	"\x0fEventLogRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tentity_id\x18\x02 \x01(\tR\bentityId\x12\"\n" +
+	"\rsince_unix_ms\x18\x03 \x01(\x03R\vsinceUnixMs\x12\"\n" +
+	"\runtil_unix_ms\x18\x04 \x01(\x03R\vuntilUnixMs\x122\n" +
+	"\x05kinds\x18\x05 \x03(\x0e2\x1c.matchmaking.StateEvent.KindR\x05kinds\x12\x1b\n" +
+	"\tafter_seq\x18\x06 \x01(\x04R\bafterSeq\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"|\n" +
+	"\x10EventLogResponse\x12/\n" +
+	"\x06events\x18\x01 \x03(\v2\x17.matchmaking.StateEventR\x06events\x12#\n" +
+	"\rlast_sequence\x18\x02 \x01(\x04R\flastSequence\x12\x12\n" +
+	"\x04more\x18\x03 \x01(\bR\x04more\"\x88\x03\n" +
+	"\x0fAdminAuditEntry\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12\x17\n" +
+	"\aunix_ms\x18\x02 \x01(\x03R\x06unixMs\x12\x19\n" +
+	"\badmin_id\x18\x03 \x01(\tR\aadminId\x12\x12\n" +
+	"\x04peer\x18\x04 \x01(\tR\x04peer\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x05 \x01(\tR\trequestId\x12\x16\n" +
+	"\x06method\x18\x06 \x01(\tR\x06method\x12\x1c\n" +
+	"\tnamespace\x18\a \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06target\x18\b \x01(\tR\x06target\x12%\n" +
+	"\x0eprevious_value\x18\t \x01(\tR\rpreviousValue\x12\x1b\n" +
+	"\tnew_value\x18\n" +
+	" \x01(\tR\bnewValue\x12\x16\n" +
+	"\x06result\x18\v \x01(\tR\x06result\x12\x18\n" +
+	"\amessage\x18\f \x01(\tR\amessage\x12.\n" +
+	"\x05clock\x18\r \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\xf5\x01\n" +
+	"\x0fAuditLogRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x19\n" +
+	"\badmin_id\x18\x02 \x01(\tR\aadminId\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\tR\x06target\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\"\n" +
+	"\rsince_unix_ms\x18\x05 \x01(\x03R\vsinceUnixMs\x12\"\n" +
+	"\runtil_unix_ms\x18\x06 \x01(\x03R\vuntilUnixMs\x12\x1b\n" +
+	"\tafter_seq\x18\a \x01(\x04R\bafterSeq\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\"\x83\x01\n" +
+	"\x10AuditLogResponse\x126\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.matchmaking.AdminAuditEntryR\aentries\x12#\n" +
+	"\rlast_sequence\x18\x02 \x01(\x04R\flastSequence\x12\x12\n" +
+	"\x04more\x18\x03 \x01(\bR\x04more\"C\n" +
+	"\x10LogStreamRequest\x12\x1b\n" +
+	"\tmin_level\x18\x01 \x01(\tR\bminLevel\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"\x80\x01\n" +
+	"\aLogLine\x12\x17\n" +
+	"\aunix_ms\x18\x01 \x01(\x03R\x06unixMs\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x18\n" +
+	"\adropped\x18\x05 \x01(\x04R\adropped\"\x84\x01\n" +
+	"\x17AdminDrainServerRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12.\n" +
+	"\x05clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\"\xbd\x01\n" +
+	"\vChaosConfig\x12\x1b\n" +
+	"\tdrop_prob\x18\x01 \x01(\x01R\bdropProb\x12\x1d\n" +
+	"\n" +
+	"delay_prob\x18\x02 \x01(\x01R\tdelayProb\x12 \n" +
+	"\fmax_delay_ms\x18\x03 \x01(\x03R\n" +
+	"maxDelayMs\x12\x1d\n" +
+	"\n" +
+	"clock_prob\x18\x04 \x01(\x01R\tclockProb\x12\x1d\n" +
+	"\n" +
+	"crash_prob\x18\x05 \x01(\x01R\tcrashProb\x12\x12\n" +
+	"\x04seed\x18\x06 \x01(\x03R\x04seed\"\xae\x01\n" +
+	"\x14AdminSetChaosRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x120\n" +
+	"\x06config\x18\x03 \x01(\v2\x18.matchmaking.ChaosConfigR\x06config\x12.\n" +
+	"\x05clock\x18\x04 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\x85\x01\n" +
+	"\x18AdminListSessionsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\x95\x02\n" +
+	"\rPlayerSession\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04peer\x18\x03 \x01(\tR\x04peer\x12+\n" +
+	"\x12started_at_unix_ms\x18\x04 \x01(\x03R\x0fstartedAtUnixMs\x12)\n" +
+	"\x11last_seen_unix_ms\x18\x05 \x01(\x03R\x0elastSeenUnixMs\x12\x12\n" +
+	"\x04live\x18\x06 \x01(\bR\x04live\x12\x12\n" +
+	"\x04rpcs\x18\a \x01(\x03R\x04rpcs\x12\x1c\n" +
+	"\tconflicts\x18\b \x01(\x05R\tconflicts\x12\x16\n" +
+	"\x06fenced\x18\t \x03(\tR\x06fenced\"\x90\x01\n" +
+	"\x19AdminListSessionsResponse\x126\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1a.matchmaking.PlayerSessionR\bsessions\x12;\n" +
+	"\fvector_clock\x18\x02 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\x84\x01\n" +
+	"\x17SimulateMatchingRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12.\n" +
+	"\x05clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\x05clock\"\x93\x02\n" +
+	"\x0eSimulatedMatch\x124\n" +
+	"\x04kind\x18\x01 \x01(\x0e2 .matchmaking.SimulatedMatch.KindR\x04kind\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x03 \x03(\tR\tplayerIds\x12\x1b\n" +
+	"\tserver_id\x18\x04 \x01(\tR\bserverId\x12\x19\n" +
+	"\bmatch_id\x18\x05 \x01(\tR\amatchId\x12\x18\n" +
+	"\arelaxed\x18\x06 \x01(\tR\arelaxed\"=\n" +
+	"\x04Kind\x12\t\n" +
+	"\x05TEAMS\x10\x00\x12\t\n" +
+	"\x05LOBBY\x10\x01\x12\x11\n" +
+	"\rPRIVATE_LOBBY\x10\x02\x12\f\n" +
+	"\bBACKFILL\x10\x03\"\x92\x01\n" +
+	"\rSkippedPlayer\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x16\n" +
+	"\x06region\x18\x03 \x01(\tR\x06region\x12\x17\n" +
+	"\await_ms\x18\x04 \x01(\x03R\x06waitMs\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xff\x01\n" +
+	"\x18SimulateMatchingResponse\x12\x16\n" +
+	"\x06policy\x18\x01 \x01(\tR\x06policy\x125\n" +
+	"\amatches\x18\x02 \x03(\v2\x1b.matchmaking.SimulatedMatchR\amatches\x124\n" +
+	"\askipped\x18\x03 \x03(\v2\x1a.matchmaking.SkippedPlayerR\askipped\x12!\n" +
+	"\ffree_servers\x18\x04 \x01(\x05R\vfreeServers\x12;\n" +
+	"\fvector_clock\x18\x05 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"\xd6\x01\n" +
+	"\x13AdminUpdateResponse\x12C\n" +
+	"\x06status\x18\x01 \x01(\x0e2+.matchmaking.AdminUpdateResponse.StatusCodeR\x06status\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12;\n" +
+	"\fvector_clock\x18\x03 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\"#\n" +
+	"\n" +
+	"StatusCode\x12\x06\n" +
+	"\x02OK\x10\x00\x12\r\n" +
+	"\tNOT_FOUND\x10\x01\"\xa4\x05\n" +
+	"\x0ePlayerSnapshot\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x19\n" +
+	"\bmatch_id\x18\x03 \x01(\tR\amatchId\x12%\n" +
+	"\x0flast_op_unix_ms\x18\x04 \x01(\x03R\flastOpUnixMs\x12\x1b\n" +
+	"\tgame_mode\x18\x05 \x01(\tR\bgameMode\x126\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x1a.matchmaking.QueuePriorityR\bpriority\x12+\n" +
+	"\x04tier\x18\a \x01(\x0e2\x17.matchmaking.PlayerTierR\x04tier\x12\x16\n" +
+	"\x06region\x18\b \x01(\tR\x06region\x12\x19\n" +
+	"\bparty_id\x18\t \x01(\tR\apartyId\x123\n" +
+	"\x16cooldown_until_unix_ms\x18\n" +
+	" \x01(\x03R\x13cooldownUntilUnixMs\x12\x1e\n" +
+	"\n" +
+	"registered\x18\v \x01(\bR\n" +
+	"registered\x123\n" +
+	"\x16queue_deadline_unix_ms\x18\f \x01(\x03R\x13queueDeadlineUnixMs\x12&\n" +
+	"\x0fqueue_timed_out\x18\r \x01(\bR\rqueueTimedOut\x123\n" +
+	"\x16last_heartbeat_unix_ms\x18\x0e \x01(\x03R\x13lastHeartbeatUnixMs\x12L\n" +
+	"\x11on_assign_failure\x18\x0f \x01(\x0e2 .matchmaking.AssignFailureActionR\x0fonAssignFailure\x121\n" +
+	"\x15requeue_offer_unix_ms\x18\x10 \x01(\x03R\x12requeueOfferUnixMs\"\xe7\x02\n" +
+	"\x0eServerSnapshot\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.matchmaking.ServerStatusR\x06status\x12(\n" +
+	"\x10current_match_id\x18\x04 \x01(\tR\x0ecurrentMatchId\x123\n" +
+	"\x16last_heartbeat_unix_ms\x18\x05 \x01(\x03R\x13lastHeartbeatUnixMs\x12\x1a\n" +
+	"\bdraining\x18\x06 \x01(\bR\bdraining\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\b \x01(\rR\tlatencyMs\x12\x1a\n" +
+	"\bcapacity\x18\t \x01(\x05R\bcapacity\x12\x1d\n" +
+	"\n" +
+	"free_slots\x18\n" +
+	" \x01(\x05R\tfreeSlots\"\xb6\x03\n" +
+	"\rMatchSnapshot\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x02 \x03(\tR\tplayerIds\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1b\n" +
+	"\tgame_mode\x18\x04 \x01(\tR\bgameMode\x12+\n" +
+	"\x12started_at_unix_ms\x18\x05 \x01(\x03R\x0fstartedAtUnixMs\x123\n" +
+	"\btimeline\x18\x06 \x03(\v2\x17.matchmaking.MatchEventR\btimeline\x12\x1d\n" +
+	"\n" +
+	"open_slots\x18\a \x01(\x05R\topenSlots\x123\n" +
+	"\x16ready_deadline_unix_ms\x18\b \x01(\x03R\x13readyDeadlineUnixMs\x12%\n" +
+	"\x0eready_accepted\x18\t \x03(\tR\rreadyAccepted\x12\x1d\n" +
+	"\n" +
+	"lobby_code\x18\n" +
+	" \x01(\tR\tlobbyCode\x12\x16\n" +
+	"\x06pinned\x18\v \x01(\bR\x06pinned\x12\x1d\n" +
+	"\n" +
+	"match_addr\x18\f \x01(\tR\tmatchAddr\"f\n" +
+	"\rPartySnapshot\x12\x19\n" +
+	"\bparty_id\x18\x01 \x01(\tR\apartyId\x12\x1b\n" +
+	"\tleader_id\x18\x02 \x01(\tR\bleaderId\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x03 \x03(\tR\tmemberIds\"\xa9\x01\n" +
+	"\rLobbySnapshot\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1d\n" +
+	"\n" +
+	"member_ids\x18\x04 \x03(\tR\tmemberIds\x12+\n" +
+	"\x12created_at_unix_ms\x18\x05 \x01(\x03R\x0fcreatedAtUnixMs\"\xd2\x01\n" +
+	"\x0fRematchSnapshot\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tgame_mode\x18\x02 \x01(\tR\bgameMode\x12\x1b\n" +
+	"\tserver_id\x18\x03 \x01(\tR\bserverId\x12\x1d\n" +
+	"\n" +
+	"player_ids\x18\x04 \x03(\tR\tplayerIds\x12(\n" +
+	"\x10deadline_unix_ms\x18\x05 \x01(\x03R\x0edeadlineUnixMs\x12!\n" +
+	"\faccepted_ids\x18\x06 \x03(\tR\vacceptedIds\"w\n" +
+	"\rRelaySnapshot\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12.\n" +
+	"\x04last\x18\x03 \x01(\v2\x1a.matchmaking.SpectateEventR\x04last\"\\\n" +
+	"\vPlayerEvent\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x120\n" +
+	"\x06update\x18\x02 \x01(\v2\x18.matchmaking.MatchUpdateR\x06update\"\xcd\b\n" +
+	"\x11NamespaceSnapshot\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x125\n" +
+	"\aplayers\x18\x02 \x03(\v2\x1b.matchmaking.PlayerSnapshotR\aplayers\x125\n" +
+	"\aservers\x18\x03 \x03(\v2\x1b.matchmaking.ServerSnapshotR\aservers\x12\x14\n" +
+	"\x05queue\x18\x04 \x03(\tR\x05queue\x124\n" +
+	"\amatches\x18\x05 \x03(\v2\x1a.matchmaking.MatchSnapshotR\amatches\x12;\n" +
+	"\fvector_clock\x18\x06 \x01(\v2\x18.matchmaking.VectorClockR\vvectorClock\x12+\n" +
+	"\x05modes\x18\a \x03(\v2\x15.matchmaking.GameModeR\x05modes\x120\n" +
+	"\x06events\x18\b \x03(\v2\x18.matchmaking.PlayerEventR\x06events\x124\n" +
+	"\aparties\x18\t \x03(\v2\x1a.matchmaking.PartySnapshotR\aparties\x121\n" +
+	"\x06banned\x18\n" +
+	" \x03(\v2\x19.matchmaking.BannedPlayerR\x06banned\x12C\n" +
+	"\x10clock_tombstones\x18\v \x01(\v2\x18.matchmaking.VectorClockR\x0fclockTombstones\x122\n" +
+	"\ahistory\x18\f \x03(\v2\x18.matchmaking.MatchRecordR\ahistory\x12.\n" +
+	"\x05stats\x18\r \x03(\v2\x18.matchmaking.PlayerStatsR\x05stats\x124\n" +
+	"\alobbies\x18\x0e \x03(\v2\x1a.matchmaking.LobbySnapshotR\alobbies\x12:\n" +
+	"\trematches\x18\x0f \x03(\v2\x1c.matchmaking.RematchSnapshotR\trematches\x12c\n" +
+	"\x13lobby_ready_unix_ms\x18\x10 \x03(\v24.matchmaking.NamespaceSnapshot.LobbyReadyUnixMsEntryR\x10lobbyReadyUnixMs\x124\n" +
+	"\aremoved\x18\x11 \x03(\v2\x1a.matchmaking.RemovedServerR\aremoved\x126\n" +
+	"\bsessions\x18\x12 \x03(\v2\x1a.matchmaking.PlayerSessionR\bsessions\x122\n" +
+	"\x06relays\x18\x13 \x03(\v2\x1a.matchmaking.RelaySnapshotR\x06relays\x1aC\n" +
+	"\x15LobbyReadyUnixMsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xae\x01\n" +
+	"\rStateSnapshot\x12\x1d\n" +
+	"\n" +
+	"primary_id\x18\x01 \x01(\tR\tprimaryId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\x12>\n" +
+	"\n" +
+	"namespaces\x18\x03 \x03(\v2\x1e.matchmaking.NamespaceSnapshotR\n" +
+	"namespaces\x12\"\n" +
+	"\rlast_match_id\x18\x04 \x01(\tR\vlastMatchId\"5\n" +
+	"\x0eReplicationAck\x12#\n" +
+	"\rlast_sequence\x18\x01 \x01(\x04R\flastSequence\"t\n" +
+	"\x15TransferStateResponse\x12\x18\n" +
+	"\aadopted\x18\x01 \x01(\bR\aadopted\x12'\n" +
+	"\x0fqueued_imported\x18\x02 \x01(\x05R\x0equeuedImported\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage*C\n" +
+	"\fServerStatus\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\x0e\n" +
+	"\n" +
+	"DISPONIBLE\x10\x01\x12\v\n" +
+	"\aOCUPADO\x10\x02\x12\t\n" +
+	"\x05CAIDO\x10\x03*Q\n" +
+	"\rQueuePriority\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x00\x12\x14\n" +
+	"\x10PRIORITY_PREMIUM\x10\x01\x12\x15\n" +
+	"\x11PRIORITY_REQUEUED\x10\x02*<\n" +
+	"\n" +
+	"PlayerTier\x12\x0f\n" +
+	"\vTIER_NORMAL\x10\x00\x12\f\n" +
+	"\bTIER_VIP\x10\x01\x12\x0f\n" +
+	"\vTIER_TESTER\x10\x02*M\n" +
+	"\x13AssignFailureAction\x12\x13\n" +
+	"\x0fFAILURE_REQUEUE\x10\x00\x12\x0f\n" +
+	"\vFAILURE_ASK\x10\x01\x12\x10\n" +
+	"\fFAILURE_IDLE\x10\x02*z\n" +
+	"\vConsistency\x12\x17\n" +
+	"\x13CONSISTENCY_DEFAULT\x10\x00\x12\x18\n" +
+	"\x14CONSISTENCY_EVENTUAL\x10\x01\x12 \n" +
+	"\x1cCONSISTENCY_READ_YOUR_WRITES\x10\x02\x12\x16\n" +
+	"\x12CONSISTENCY_STRONG\x10\x03*\x90\x01\n" +
+	"\n" +
+	"MatchPhase\x12\x17\n" +
+	"\x13MATCH_PHASE_CREATED\x10\x00\x12\x18\n" +
+	"\x14MATCH_PHASE_ASSIGNED\x10\x01\x12\x1b\n" +
+	"\x17MATCH_PHASE_IN_PROGRESS\x10\x02\x12\x19\n" +
+	"\x15MATCH_PHASE_COMPLETED\x10\x03\x12\x17\n" +
+	"\x13MATCH_PHASE_ABORTED\x10\x042\xc6$\n" +
+	"\n" +
+	"Matchmaker\x12P\n" +
+	"\rGetServerInfo\x12\x1e.matchmaking.ServerInfoRequest\x1a\x1f.matchmaking.ServerInfoResponse\x12Y\n" +
+	"\x0eRegisterPlayer\x12\".matchmaking.RegisterPlayerRequest\x1a#.matchmaking.RegisterPlayerResponse\x12O\n" +
+	"\vQueuePlayer\x12\x1e.matchmaking.PlayerInfoRequest\x1a .matchmaking.QueuePlayerResponse\x12P\n" +
+	"\vCancelQueue\x12\x1f.matchmaking.CancelQueueRequest\x1a .matchmaking.CancelQueueResponse\x12M\n" +
+	"\n" +
+	"LeaveMatch\x12\x1e.matchmaking.LeaveMatchRequest\x1a\x1f.matchmaking.LeaveMatchResponse\x12P\n" +
+	"\vAcceptMatch\x12\x1f.matchmaking.AcceptMatchRequest\x1a .matchmaking.AcceptMatchResponse\x12K\n" +
+	"\x0eRequestRematch\x12\x1b.matchmaking.RematchRequest\x1a\x1c.matchmaking.RematchResponse\x12V\n" +
+	"\x0fGetPlayerStatus\x12 .matchmaking.PlayerStatusRequest\x1a!.matchmaking.PlayerStatusResponse\x12V\n" +
+	"\rListGameModes\x12!.matchmaking.ListGameModesRequest\x1a\".matchmaking.ListGameModesResponse\x12V\n" +
+	"\rResumeSession\x12!.matchmaking.ResumeSessionRequest\x1a\".matchmaking.ResumeSessionResponse\x12\\\n" +
+	"\x0fPlayerHeartbeat\x12#.matchmaking.PlayerHeartbeatRequest\x1a$.matchmaking.PlayerHeartbeatResponse\x12\\\n" +
+	"\x13SubmitMatchFeedback\x12!.matchmaking.MatchFeedbackRequest\x1a\".matchmaking.MatchFeedbackResponse\x12D\n" +
+	"\vCreateParty\x12\x19.matchmaking.PartyRequest\x1a\x1a.matchmaking.PartyResponse\x12B\n" +
+	"\tJoinParty\x12\x19.matchmaking.PartyRequest\x1a\x1a.matchmaking.PartyResponse\x12C\n" +
+	"\n" +
+	"LeaveParty\x12\x19.matchmaking.PartyRequest\x1a\x1a.matchmaking.PartyResponse\x12J\n" +
+	"\vCreateLobby\x12\x1f.matchmaking.CreateLobbyRequest\x1a\x1a.matchmaking.LobbyResponse\x12B\n" +
+	"\tJoinLobby\x12\x19.matchmaking.LobbyRequest\x1a\x1a.matchmaking.LobbyResponse\x12C\n" +
+	"\n" +
+	"LeaveLobby\x12\x19.matchmaking.LobbyRequest\x1a\x1a.matchmaking.LobbyResponse\x12V\n" +
+	"\x0fGetMatchHistory\x12 .matchmaking.MatchHistoryRequest\x1a!.matchmaking.MatchHistoryResponse\x12Y\n" +
+	"\x10GetMatchTimeline\x12!.matchmaking.MatchTimelineRequest\x1a\".matchmaking.MatchTimelineResponse\x12S\n" +
+	"\x0eGetLeaderboard\x12\x1f.matchmaking.LeaderboardRequest\x1a .matchmaking.LeaderboardResponse\x12R\n" +
+	"\x15SubscribeMatchUpdates\x12\x1d.matchmaking.SubscribeRequest\x1a\x18.matchmaking.MatchUpdate0\x01\x12^\n" +
+	"\x14SubscribeMatchEvents\x12(.matchmaking.SubscribeMatchEventsRequest\x1a\x1a.matchmaking.SpectateEvent0\x01\x12S\n" +
+	"\tHeartbeat\x12&.matchmaking.ServerStatusUpdateRequest\x1a\x1a.matchmaking.ServerControl(\x010\x01\x12e\n" +
+	"\x12UpdateServerStatus\x12&.matchmaking.ServerStatusUpdateRequest\x1a'.matchmaking.ServerStatusUpdateResponse\x12V\n" +
+	"\x11ReportMatchResult\x12\x1f.matchmaking.MatchResultRequest\x1a .matchmaking.MatchResultResponse\x12M\n" +
+	"\n" +
+	"AbortMatch\x12\x1e.matchmaking.AbortMatchRequest\x1a\x1f.matchmaking.AbortMatchResponse\x12_\n" +
+	"\x10DeregisterServer\x12$.matchmaking.DeregisterServerRequest\x1a%.matchmaking.DeregisterServerResponse\x12\\\n" +
+	"\x0fRequestBackfill\x12#.matchmaking.RequestBackfillRequest\x1a$.matchmaking.RequestBackfillResponse\x12g\n" +
+	"\x12PublishMatchEvents\x12&.matchmaking.PublishMatchEventsRequest\x1a'.matchmaking.PublishMatchEventsResponse(\x01\x12T\n" +
 	"\x14AdminGetSystemStatus\x12\x19.matchmaking.AdminRequest\x1a!.matchmaking.SystemStatusResponse\x12a\n" +
-	"\x16AdminUpdateServerState\x12%.matchmaking.AdminServerUpdateRequest\x1a .matchmaking.AdminUpdateResponse2\x8f\x02\n" +
-	"\x11GameServerService\x12P\n" +
-	"\vAssignMatch\x12\x1f.matchmaking.AssignMatchRequest\x1a .matchmaking.AssignMatchResponse\x12e\n" +
-	"\x12UpdateServerStatus\x12&.matchmaking.ServerStatusUpdateRequest\x1a'.matchmaking.ServerStatusUpdateResponse\x12A\n" +
+	"\x16AdminUpdateServerState\x12%.matchmaking.AdminServerUpdateRequest\x1a .matchmaking.AdminUpdateResponse\x12T\n" +
+	"\x14AdminGetClockMetrics\x12\x19.matchmaking.AdminRequest\x1a!.matchmaking.ClockMetricsResponse\x12P\n" +
+	"\x12AdminGetClockState\x12\x19.matchmaking.AdminRequest\x1a\x1f.matchmaking.ClockStateResponse\x12Z\n" +
+	"\x10AdminDrainServer\x12$.matchmaking.AdminDrainServerRequest\x1a .matchmaking.AdminUpdateResponse\x12`\n" +
+	"\x13AdminUpsertGameMode\x12'.matchmaking.AdminUpsertGameModeRequest\x1a .matchmaking.AdminUpdateResponse\x12^\n" +
+	"\x12AdminSetPlayerTier\x12&.matchmaking.AdminSetPlayerTierRequest\x1a .matchmaking.AdminUpdateResponse\x12X\n" +
+	"\x19AdminGetServerReliability\x12\x19.matchmaking.AdminRequest\x1a .matchmaking.ReliabilityResponse\x12T\n" +
+	"\x17AdminExportQueueHistory\x12 .matchmaking.QueueHistoryRequest\x1a\x15.matchmaking.CsvChunk0\x01\x12N\n" +
+	"\x11AdminGetSLOStatus\x12\x19.matchmaking.AdminRequest\x1a\x1e.matchmaking.SLOStatusResponse\x12Z\n" +
+	"\x0fAdminKickPlayer\x12%.matchmaking.AdminPlayerActionRequest\x1a .matchmaking.AdminUpdateResponse\x12Y\n" +
+	"\x0eAdminBanPlayer\x12%.matchmaking.AdminPlayerActionRequest\x1a .matchmaking.AdminUpdateResponse\x12]\n" +
+	"\x12AdminResetCooldown\x12%.matchmaking.AdminPlayerActionRequest\x1a .matchmaking.AdminUpdateResponse\x12P\n" +
+	"\x15AdminSimulateCapacity\x12\x1a.matchmaking.WhatIfRequest\x1a\x1b.matchmaking.WhatIfResponse\x12X\n" +
+	"\x11AdminReloadConfig\x12 .matchmaking.ReloadConfigRequest\x1a!.matchmaking.ReloadConfigResponse\x12`\n" +
+	"\x13AdminTerminateMatch\x12'.matchmaking.AdminTerminateMatchRequest\x1a .matchmaking.AdminUpdateResponse\x12O\n" +
+	"\x10AdminGetEventLog\x12\x1c.matchmaking.EventLogRequest\x1a\x1d.matchmaking.EventLogResponse\x12H\n" +
+	"\x0fAdminStreamLogs\x12\x1d.matchmaking.LogStreamRequest\x1a\x14.matchmaking.LogLine0\x01\x12T\n" +
+	"\rAdminSetChaos\x12!.matchmaking.AdminSetChaosRequest\x1a .matchmaking.AdminUpdateResponse\x12b\n" +
+	"\x11AdminListSessions\x12%.matchmaking.AdminListSessionsRequest\x1a&.matchmaking.AdminListSessionsResponse\x12d\n" +
+	"\x15AdminSimulateMatching\x12$.matchmaking.SimulateMatchingRequest\x1a%.matchmaking.SimulateMatchingResponse\x12O\n" +
+	"\x10AdminGetAuditLog\x12\x1c.matchmaking.AuditLogRequest\x1a\x1d.matchmaking.AuditLogResponse\x12K\n" +
+	"\x0eReplicateState\x12\x1a.matchmaking.StateSnapshot\x1a\x1b.matchmaking.ReplicationAck(\x01\x12O\n" +
+	"\rTransferState\x12\x1a.matchmaking.StateSnapshot\x1a\".matchmaking.TransferStateResponse2\xc0\x02\n" +
+	"\n" +
+	"GameServer\x12P\n" +
+	"\vAssignMatch\x12\x1f.matchmaking.AssignMatchRequest\x1a .matchmaking.AssignMatchResponse\x12P\n" +
+	"\vCancelMatch\x12\x1f.matchmaking.CancelMatchRequest\x1a .matchmaking.CancelMatchResponse\x12K\n" +
+	"\rSpectateMatch\x12\x1c.matchmaking.SpectateRequest\x1a\x1a.matchmaking.SpectateEvent0\x01\x12A\n" +
 	"\n" +
 	"PingServer\x12\x18.matchmaking.PingRequest\x1a\x19.matchmaking.PingResponseB#Z!github.com/vimsent/L3/proto;protob\x06proto3"
 
@@ -1185,68 +13097,463 @@ func file_proto_matchmaking_proto_rawDescGZIP() []byte {
 	return file_proto_matchmaking_proto_rawDescData
 }
 
-var file_proto_matchmaking_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_matchmaking_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_matchmaking_proto_enumTypes = make([]protoimpl.EnumInfo, 34)
+var file_proto_matchmaking_proto_msgTypes = make([]protoimpl.MessageInfo, 129)
 var file_proto_matchmaking_proto_goTypes = []any{
-	(PlayerState)(0),                   // 0: matchmaking.PlayerState
-	(ServerState)(0),                   // 1: matchmaking.ServerState
-	(*VectorClock)(nil),                // 2: matchmaking.VectorClock
-	(*PlayerInfoRequest)(nil),          // 3: matchmaking.PlayerInfoRequest
-	(*QueuePlayerResponse)(nil),        // 4: matchmaking.QueuePlayerResponse
-	(*PlayerStatusRequest)(nil),        // 5: matchmaking.PlayerStatusRequest
-	(*PlayerStatusResponse)(nil),       // 6: matchmaking.PlayerStatusResponse
-	(*AssignMatchRequest)(nil),         // 7: matchmaking.AssignMatchRequest
-	(*AssignMatchResponse)(nil),        // 8: matchmaking.AssignMatchResponse
-	(*ServerStatusUpdateRequest)(nil),  // 9: matchmaking.ServerStatusUpdateRequest
-	(*ServerStatusUpdateResponse)(nil), // 10: matchmaking.ServerStatusUpdateResponse
-	(*PingRequest)(nil),                // 11: matchmaking.PingRequest
-	(*PingResponse)(nil),               // 12: matchmaking.PingResponse
-	(*AdminRequest)(nil),               // 13: matchmaking.AdminRequest
-	(*ServerInfo)(nil),                 // 14: matchmaking.ServerInfo
-	(*PlayerQueueEntry)(nil),           // 15: matchmaking.PlayerQueueEntry
-	(*SystemStatusResponse)(nil),       // 16: matchmaking.SystemStatusResponse
-	(*AdminServerUpdateRequest)(nil),   // 17: matchmaking.AdminServerUpdateRequest
-	(*AdminUpdateResponse)(nil),        // 18: matchmaking.AdminUpdateResponse
-	(*timestamppb.Timestamp)(nil),      // 19: google.protobuf.Timestamp
+	(ServerStatus)(0),                          // 0: matchmaking.ServerStatus
+	(QueuePriority)(0),                         // 1: matchmaking.QueuePriority
+	(PlayerTier)(0),                            // 2: matchmaking.PlayerTier
+	(AssignFailureAction)(0),                   // 3: matchmaking.AssignFailureAction
+	(Consistency)(0),                           // 4: matchmaking.Consistency
+	(MatchPhase)(0),                            // 5: matchmaking.MatchPhase
+	(GameMode_Scoring)(0),                      // 6: matchmaking.GameMode.Scoring
+	(QueuePlayerResponse_StatusCode)(0),        // 7: matchmaking.QueuePlayerResponse.StatusCode
+	(CancelQueueResponse_StatusCode)(0),        // 8: matchmaking.CancelQueueResponse.StatusCode
+	(LeaveMatchResponse_StatusCode)(0),         // 9: matchmaking.LeaveMatchResponse.StatusCode
+	(RegisterPlayerResponse_StatusCode)(0),     // 10: matchmaking.RegisterPlayerResponse.StatusCode
+	(MatchUpdate_Event)(0),                     // 11: matchmaking.MatchUpdate.Event
+	(AcceptMatchResponse_StatusCode)(0),        // 12: matchmaking.AcceptMatchResponse.StatusCode
+	(RematchResponse_StatusCode)(0),            // 13: matchmaking.RematchResponse.StatusCode
+	(AssignMatchResponse_StatusCode)(0),        // 14: matchmaking.AssignMatchResponse.StatusCode
+	(CancelMatchResponse_StatusCode)(0),        // 15: matchmaking.CancelMatchResponse.StatusCode
+	(SpectateEvent_Kind)(0),                    // 16: matchmaking.SpectateEvent.Kind
+	(ServerStatusUpdateResponse_StatusCode)(0), // 17: matchmaking.ServerStatusUpdateResponse.StatusCode
+	(ServerControl_Command)(0),                 // 18: matchmaking.ServerControl.Command
+	(MatchResultResponse_StatusCode)(0),        // 19: matchmaking.MatchResultResponse.StatusCode
+	(MatchRecord_Outcome)(0),                   // 20: matchmaking.MatchRecord.Outcome
+	(MatchEvent_Stage)(0),                      // 21: matchmaking.MatchEvent.Stage
+	(LeaderboardRequest_SortBy)(0),             // 22: matchmaking.LeaderboardRequest.SortBy
+	(AbortMatchResponse_StatusCode)(0),         // 23: matchmaking.AbortMatchResponse.StatusCode
+	(RequestBackfillResponse_StatusCode)(0),    // 24: matchmaking.RequestBackfillResponse.StatusCode
+	(DeregisterServerResponse_StatusCode)(0),   // 25: matchmaking.DeregisterServerResponse.StatusCode
+	(MatchFeedbackResponse_StatusCode)(0),      // 26: matchmaking.MatchFeedbackResponse.StatusCode
+	(PartyResponse_StatusCode)(0),              // 27: matchmaking.PartyResponse.StatusCode
+	(LobbyResponse_StatusCode)(0),              // 28: matchmaking.LobbyResponse.StatusCode
+	(EntityClock_Kind)(0),                      // 29: matchmaking.EntityClock.Kind
+	(ReloadConfigResponse_StatusCode)(0),       // 30: matchmaking.ReloadConfigResponse.StatusCode
+	(StateEvent_Kind)(0),                       // 31: matchmaking.StateEvent.Kind
+	(SimulatedMatch_Kind)(0),                   // 32: matchmaking.SimulatedMatch.Kind
+	(AdminUpdateResponse_StatusCode)(0),        // 33: matchmaking.AdminUpdateResponse.StatusCode
+	(*VectorClock)(nil),                        // 34: matchmaking.VectorClock
+	(*GameMode)(nil),                           // 35: matchmaking.GameMode
+	(*PlayerInfoRequest)(nil),                  // 36: matchmaking.PlayerInfoRequest
+	(*QueuePlayerResponse)(nil),                // 37: matchmaking.QueuePlayerResponse
+	(*CancelQueueRequest)(nil),                 // 38: matchmaking.CancelQueueRequest
+	(*CancelQueueResponse)(nil),                // 39: matchmaking.CancelQueueResponse
+	(*LeaveMatchRequest)(nil),                  // 40: matchmaking.LeaveMatchRequest
+	(*LeaveMatchResponse)(nil),                 // 41: matchmaking.LeaveMatchResponse
+	(*PlayerStatusRequest)(nil),                // 42: matchmaking.PlayerStatusRequest
+	(*PlayerStatusResponse)(nil),               // 43: matchmaking.PlayerStatusResponse
+	(*ResumeSessionRequest)(nil),               // 44: matchmaking.ResumeSessionRequest
+	(*ResumeSessionResponse)(nil),              // 45: matchmaking.ResumeSessionResponse
+	(*ServerInfoRequest)(nil),                  // 46: matchmaking.ServerInfoRequest
+	(*ServerInfoResponse)(nil),                 // 47: matchmaking.ServerInfoResponse
+	(*PlayerHeartbeatRequest)(nil),             // 48: matchmaking.PlayerHeartbeatRequest
+	(*PlayerHeartbeatResponse)(nil),            // 49: matchmaking.PlayerHeartbeatResponse
+	(*RegisterPlayerRequest)(nil),              // 50: matchmaking.RegisterPlayerRequest
+	(*RegisterPlayerResponse)(nil),             // 51: matchmaking.RegisterPlayerResponse
+	(*ListGameModesRequest)(nil),               // 52: matchmaking.ListGameModesRequest
+	(*ListGameModesResponse)(nil),              // 53: matchmaking.ListGameModesResponse
+	(*SubscribeRequest)(nil),                   // 54: matchmaking.SubscribeRequest
+	(*MatchUpdate)(nil),                        // 55: matchmaking.MatchUpdate
+	(*AcceptMatchRequest)(nil),                 // 56: matchmaking.AcceptMatchRequest
+	(*AcceptMatchResponse)(nil),                // 57: matchmaking.AcceptMatchResponse
+	(*RematchRequest)(nil),                     // 58: matchmaking.RematchRequest
+	(*RematchResponse)(nil),                    // 59: matchmaking.RematchResponse
+	(*AssignMatchRequest)(nil),                 // 60: matchmaking.AssignMatchRequest
+	(*AssignMatchResponse)(nil),                // 61: matchmaking.AssignMatchResponse
+	(*CancelMatchRequest)(nil),                 // 62: matchmaking.CancelMatchRequest
+	(*CancelMatchResponse)(nil),                // 63: matchmaking.CancelMatchResponse
+	(*SpectateRequest)(nil),                    // 64: matchmaking.SpectateRequest
+	(*SpectateEvent)(nil),                      // 65: matchmaking.SpectateEvent
+	(*PublishMatchEventsRequest)(nil),          // 66: matchmaking.PublishMatchEventsRequest
+	(*PublishMatchEventsResponse)(nil),         // 67: matchmaking.PublishMatchEventsResponse
+	(*SubscribeMatchEventsRequest)(nil),        // 68: matchmaking.SubscribeMatchEventsRequest
+	(*ServerStatusUpdateRequest)(nil),          // 69: matchmaking.ServerStatusUpdateRequest
+	(*RunningMatch)(nil),                       // 70: matchmaking.RunningMatch
+	(*ServerStatusUpdateResponse)(nil),         // 71: matchmaking.ServerStatusUpdateResponse
+	(*ServerControl)(nil),                      // 72: matchmaking.ServerControl
+	(*PlayerMatchStats)(nil),                   // 73: matchmaking.PlayerMatchStats
+	(*ScoreEvent)(nil),                         // 74: matchmaking.ScoreEvent
+	(*MatchResultRequest)(nil),                 // 75: matchmaking.MatchResultRequest
+	(*MatchResultResponse)(nil),                // 76: matchmaking.MatchResultResponse
+	(*MatchRecord)(nil),                        // 77: matchmaking.MatchRecord
+	(*MatchEvent)(nil),                         // 78: matchmaking.MatchEvent
+	(*MatchTimelineRequest)(nil),               // 79: matchmaking.MatchTimelineRequest
+	(*MatchTimelineResponse)(nil),              // 80: matchmaking.MatchTimelineResponse
+	(*MatchHistoryRequest)(nil),                // 81: matchmaking.MatchHistoryRequest
+	(*MatchHistoryResponse)(nil),               // 82: matchmaking.MatchHistoryResponse
+	(*PlayerStats)(nil),                        // 83: matchmaking.PlayerStats
+	(*LeaderboardRequest)(nil),                 // 84: matchmaking.LeaderboardRequest
+	(*LeaderboardResponse)(nil),                // 85: matchmaking.LeaderboardResponse
+	(*AbortMatchRequest)(nil),                  // 86: matchmaking.AbortMatchRequest
+	(*AbortMatchResponse)(nil),                 // 87: matchmaking.AbortMatchResponse
+	(*RequestBackfillRequest)(nil),             // 88: matchmaking.RequestBackfillRequest
+	(*RequestBackfillResponse)(nil),            // 89: matchmaking.RequestBackfillResponse
+	(*DeregisterServerRequest)(nil),            // 90: matchmaking.DeregisterServerRequest
+	(*DeregisterServerResponse)(nil),           // 91: matchmaking.DeregisterServerResponse
+	(*MatchFeedbackRequest)(nil),               // 92: matchmaking.MatchFeedbackRequest
+	(*MatchFeedbackResponse)(nil),              // 93: matchmaking.MatchFeedbackResponse
+	(*PartyRequest)(nil),                       // 94: matchmaking.PartyRequest
+	(*PartyResponse)(nil),                      // 95: matchmaking.PartyResponse
+	(*CreateLobbyRequest)(nil),                 // 96: matchmaking.CreateLobbyRequest
+	(*LobbyRequest)(nil),                       // 97: matchmaking.LobbyRequest
+	(*LobbyResponse)(nil),                      // 98: matchmaking.LobbyResponse
+	(*PingRequest)(nil),                        // 99: matchmaking.PingRequest
+	(*PingResponse)(nil),                       // 100: matchmaking.PingResponse
+	(*AdminRequest)(nil),                       // 101: matchmaking.AdminRequest
+	(*ServerInfo)(nil),                         // 102: matchmaking.ServerInfo
+	(*PlayerQueueEntry)(nil),                   // 103: matchmaking.PlayerQueueEntry
+	(*ModeQueueStats)(nil),                     // 104: matchmaking.ModeQueueStats
+	(*SystemStatusResponse)(nil),               // 105: matchmaking.SystemStatusResponse
+	(*MatchStatus)(nil),                        // 106: matchmaking.MatchStatus
+	(*RateLimitStats)(nil),                     // 107: matchmaking.RateLimitStats
+	(*RemovedServer)(nil),                      // 108: matchmaking.RemovedServer
+	(*BannedPlayer)(nil),                       // 109: matchmaking.BannedPlayer
+	(*ClockMetricsResponse)(nil),               // 110: matchmaking.ClockMetricsResponse
+	(*EntityClock)(nil),                        // 111: matchmaking.EntityClock
+	(*ClockStateResponse)(nil),                 // 112: matchmaking.ClockStateResponse
+	(*ServerReliability)(nil),                  // 113: matchmaking.ServerReliability
+	(*ReliabilityResponse)(nil),                // 114: matchmaking.ReliabilityResponse
+	(*SLOStatus)(nil),                          // 115: matchmaking.SLOStatus
+	(*SLOStatusResponse)(nil),                  // 116: matchmaking.SLOStatusResponse
+	(*WhatIfRequest)(nil),                      // 117: matchmaking.WhatIfRequest
+	(*WaitProjection)(nil),                     // 118: matchmaking.WaitProjection
+	(*WhatIfResponse)(nil),                     // 119: matchmaking.WhatIfResponse
+	(*ReloadConfigRequest)(nil),                // 120: matchmaking.ReloadConfigRequest
+	(*ConfigChange)(nil),                       // 121: matchmaking.ConfigChange
+	(*ReloadConfigResponse)(nil),               // 122: matchmaking.ReloadConfigResponse
+	(*AdminServerUpdateRequest)(nil),           // 123: matchmaking.AdminServerUpdateRequest
+	(*QueueHistoryRequest)(nil),                // 124: matchmaking.QueueHistoryRequest
+	(*CsvChunk)(nil),                           // 125: matchmaking.CsvChunk
+	(*AdminUpsertGameModeRequest)(nil),         // 126: matchmaking.AdminUpsertGameModeRequest
+	(*AdminSetPlayerTierRequest)(nil),          // 127: matchmaking.AdminSetPlayerTierRequest
+	(*AdminPlayerActionRequest)(nil),           // 128: matchmaking.AdminPlayerActionRequest
+	(*AdminTerminateMatchRequest)(nil),         // 129: matchmaking.AdminTerminateMatchRequest
+	(*StateEvent)(nil),                         // 130: matchmaking.StateEvent
+	(*EventLogRequest)(nil),                    // 131: matchmaking.EventLogRequest
+	(*EventLogResponse)(nil),                   // 132: matchmaking.EventLogResponse
+	(*AdminAuditEntry)(nil),                    // 133: matchmaking.AdminAuditEntry
+	(*AuditLogRequest)(nil),                    // 134: matchmaking.AuditLogRequest
+	(*AuditLogResponse)(nil),                   // 135: matchmaking.AuditLogResponse
+	(*LogStreamRequest)(nil),                   // 136: matchmaking.LogStreamRequest
+	(*LogLine)(nil),                            // 137: matchmaking.LogLine
+	(*AdminDrainServerRequest)(nil),            // 138: matchmaking.AdminDrainServerRequest
+	(*ChaosConfig)(nil),                        // 139: matchmaking.ChaosConfig
+	(*AdminSetChaosRequest)(nil),               // 140: matchmaking.AdminSetChaosRequest
+	(*AdminListSessionsRequest)(nil),           // 141: matchmaking.AdminListSessionsRequest
+	(*PlayerSession)(nil),                      // 142: matchmaking.PlayerSession
+	(*AdminListSessionsResponse)(nil),          // 143: matchmaking.AdminListSessionsResponse
+	(*SimulateMatchingRequest)(nil),            // 144: matchmaking.SimulateMatchingRequest
+	(*SimulatedMatch)(nil),                     // 145: matchmaking.SimulatedMatch
+	(*SkippedPlayer)(nil),                      // 146: matchmaking.SkippedPlayer
+	(*SimulateMatchingResponse)(nil),           // 147: matchmaking.SimulateMatchingResponse
+	(*AdminUpdateResponse)(nil),                // 148: matchmaking.AdminUpdateResponse
+	(*PlayerSnapshot)(nil),                     // 149: matchmaking.PlayerSnapshot
+	(*ServerSnapshot)(nil),                     // 150: matchmaking.ServerSnapshot
+	(*MatchSnapshot)(nil),                      // 151: matchmaking.MatchSnapshot
+	(*PartySnapshot)(nil),                      // 152: matchmaking.PartySnapshot
+	(*LobbySnapshot)(nil),                      // 153: matchmaking.LobbySnapshot
+	(*RematchSnapshot)(nil),                    // 154: matchmaking.RematchSnapshot
+	(*RelaySnapshot)(nil),                      // 155: matchmaking.RelaySnapshot
+	(*PlayerEvent)(nil),                        // 156: matchmaking.PlayerEvent
+	(*NamespaceSnapshot)(nil),                  // 157: matchmaking.NamespaceSnapshot
+	(*StateSnapshot)(nil),                      // 158: matchmaking.StateSnapshot
+	(*ReplicationAck)(nil),                     // 159: matchmaking.ReplicationAck
+	(*TransferStateResponse)(nil),              // 160: matchmaking.TransferStateResponse
+	nil,                                        // 161: matchmaking.VectorClock.CountersEntry
+	nil,                                        // 162: matchmaking.NamespaceSnapshot.LobbyReadyUnixMsEntry
 }
 var file_proto_matchmaking_proto_depIdxs = []int32{
-	2,  // 0: matchmaking.PlayerInfoRequest.clock:type_name -> matchmaking.VectorClock
-	2,  // 1: matchmaking.QueuePlayerResponse.clock:type_name -> matchmaking.VectorClock
-	2,  // 2: matchmaking.PlayerStatusRequest.clock:type_name -> matchmaking.VectorClock
-	0,  // 3: matchmaking.PlayerStatusResponse.state:type_name -> matchmaking.PlayerState
-	2,  // 4: matchmaking.PlayerStatusResponse.clock:type_name -> matchmaking.VectorClock
-	2,  // 5: matchmaking.AssignMatchRequest.clock:type_name -> matchmaking.VectorClock
-	2,  // 6: matchmaking.AssignMatchResponse.clock:type_name -> matchmaking.VectorClock
-	1,  // 7: matchmaking.ServerStatusUpdateRequest.new_state:type_name -> matchmaking.ServerState
-	2,  // 8: matchmaking.ServerStatusUpdateRequest.clock:type_name -> matchmaking.VectorClock
-	2,  // 9: matchmaking.ServerStatusUpdateResponse.clock:type_name -> matchmaking.VectorClock
-	1,  // 10: matchmaking.ServerInfo.state:type_name -> matchmaking.ServerState
-	19, // 11: matchmaking.PlayerQueueEntry.queued_since:type_name -> google.protobuf.Timestamp
-	14, // 12: matchmaking.SystemStatusResponse.servers:type_name -> matchmaking.ServerInfo
-	15, // 13: matchmaking.SystemStatusResponse.players:type_name -> matchmaking.PlayerQueueEntry
-	2,  // 14: matchmaking.SystemStatusResponse.clock:type_name -> matchmaking.VectorClock
-	1,  // 15: matchmaking.AdminServerUpdateRequest.forced_state:type_name -> matchmaking.ServerState
-	2,  // 16: matchmaking.AdminServerUpdateRequest.clock:type_name -> matchmaking.VectorClock
-	2,  // 17: matchmaking.AdminUpdateResponse.clock:type_name -> matchmaking.VectorClock
-	3,  // 18: matchmaking.MatchmakerService.QueuePlayer:input_type -> matchmaking.PlayerInfoRequest
-	5,  // 19: matchmaking.MatchmakerService.GetPlayerStatus:input_type -> matchmaking.PlayerStatusRequest
-	13, // 20: matchmaking.MatchmakerService.AdminGetSystemStatus:input_type -> matchmaking.AdminRequest
-	17, // 21: matchmaking.MatchmakerService.AdminUpdateServerState:input_type -> matchmaking.AdminServerUpdateRequest
-	7,  // 22: matchmaking.GameServerService.AssignMatch:input_type -> matchmaking.AssignMatchRequest
-	9,  // 23: matchmaking.GameServerService.UpdateServerStatus:input_type -> matchmaking.ServerStatusUpdateRequest
-	11, // 24: matchmaking.GameServerService.PingServer:input_type -> matchmaking.PingRequest
-	4,  // 25: matchmaking.MatchmakerService.QueuePlayer:output_type -> matchmaking.QueuePlayerResponse
-	6,  // 26: matchmaking.MatchmakerService.GetPlayerStatus:output_type -> matchmaking.PlayerStatusResponse
-	16, // 27: matchmaking.MatchmakerService.AdminGetSystemStatus:output_type -> matchmaking.SystemStatusResponse
-	18, // 28: matchmaking.MatchmakerService.AdminUpdateServerState:output_type -> matchmaking.AdminUpdateResponse
-	8,  // 29: matchmaking.GameServerService.AssignMatch:output_type -> matchmaking.AssignMatchResponse
-	10, // 30: matchmaking.GameServerService.UpdateServerStatus:output_type -> matchmaking.ServerStatusUpdateResponse
-	12, // 31: matchmaking.GameServerService.PingServer:output_type -> matchmaking.PingResponse
-	25, // [25:32] is the sub-list for method output_type
-	18, // [18:25] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	161, // 0: matchmaking.VectorClock.counters:type_name -> matchmaking.VectorClock.CountersEntry
+	6,   // 1: matchmaking.GameMode.scoring:type_name -> matchmaking.GameMode.Scoring
+	34,  // 2: matchmaking.PlayerInfoRequest.clock:type_name -> matchmaking.VectorClock
+	1,   // 3: matchmaking.PlayerInfoRequest.priority:type_name -> matchmaking.QueuePriority
+	3,   // 4: matchmaking.PlayerInfoRequest.on_assign_failure:type_name -> matchmaking.AssignFailureAction
+	7,   // 5: matchmaking.QueuePlayerResponse.status_code:type_name -> matchmaking.QueuePlayerResponse.StatusCode
+	34,  // 6: matchmaking.QueuePlayerResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 7: matchmaking.CancelQueueRequest.clock:type_name -> matchmaking.VectorClock
+	8,   // 8: matchmaking.CancelQueueResponse.status_code:type_name -> matchmaking.CancelQueueResponse.StatusCode
+	34,  // 9: matchmaking.CancelQueueResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 10: matchmaking.LeaveMatchRequest.clock:type_name -> matchmaking.VectorClock
+	9,   // 11: matchmaking.LeaveMatchResponse.status_code:type_name -> matchmaking.LeaveMatchResponse.StatusCode
+	34,  // 12: matchmaking.LeaveMatchResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 13: matchmaking.PlayerStatusRequest.clock:type_name -> matchmaking.VectorClock
+	4,   // 14: matchmaking.PlayerStatusRequest.consistency:type_name -> matchmaking.Consistency
+	34,  // 15: matchmaking.PlayerStatusResponse.vector_clock:type_name -> matchmaking.VectorClock
+	4,   // 16: matchmaking.PlayerStatusResponse.consistency:type_name -> matchmaking.Consistency
+	34,  // 17: matchmaking.ResumeSessionResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 18: matchmaking.PlayerHeartbeatRequest.clock:type_name -> matchmaking.VectorClock
+	34,  // 19: matchmaking.PlayerHeartbeatResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 20: matchmaking.RegisterPlayerRequest.clock:type_name -> matchmaking.VectorClock
+	10,  // 21: matchmaking.RegisterPlayerResponse.status_code:type_name -> matchmaking.RegisterPlayerResponse.StatusCode
+	34,  // 22: matchmaking.RegisterPlayerResponse.vector_clock:type_name -> matchmaking.VectorClock
+	35,  // 23: matchmaking.ListGameModesResponse.modes:type_name -> matchmaking.GameMode
+	34,  // 24: matchmaking.ListGameModesResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 25: matchmaking.SubscribeRequest.clock:type_name -> matchmaking.VectorClock
+	34,  // 26: matchmaking.SubscribeRequest.resume_from:type_name -> matchmaking.VectorClock
+	11,  // 27: matchmaking.MatchUpdate.event:type_name -> matchmaking.MatchUpdate.Event
+	34,  // 28: matchmaking.MatchUpdate.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 29: matchmaking.AcceptMatchRequest.clock:type_name -> matchmaking.VectorClock
+	12,  // 30: matchmaking.AcceptMatchResponse.status_code:type_name -> matchmaking.AcceptMatchResponse.StatusCode
+	34,  // 31: matchmaking.AcceptMatchResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 32: matchmaking.RematchRequest.clock:type_name -> matchmaking.VectorClock
+	13,  // 33: matchmaking.RematchResponse.status_code:type_name -> matchmaking.RematchResponse.StatusCode
+	34,  // 34: matchmaking.RematchResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 35: matchmaking.AssignMatchRequest.vector_clock:type_name -> matchmaking.VectorClock
+	35,  // 36: matchmaking.AssignMatchRequest.mode:type_name -> matchmaking.GameMode
+	14,  // 37: matchmaking.AssignMatchResponse.status_code:type_name -> matchmaking.AssignMatchResponse.StatusCode
+	34,  // 38: matchmaking.AssignMatchResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 39: matchmaking.CancelMatchRequest.vector_clock:type_name -> matchmaking.VectorClock
+	15,  // 40: matchmaking.CancelMatchResponse.status_code:type_name -> matchmaking.CancelMatchResponse.StatusCode
+	16,  // 41: matchmaking.SpectateEvent.kind:type_name -> matchmaking.SpectateEvent.Kind
+	73,  // 42: matchmaking.SpectateEvent.scoreboard:type_name -> matchmaking.PlayerMatchStats
+	65,  // 43: matchmaking.PublishMatchEventsRequest.event:type_name -> matchmaking.SpectateEvent
+	0,   // 44: matchmaking.ServerStatusUpdateRequest.new_status:type_name -> matchmaking.ServerStatus
+	34,  // 45: matchmaking.ServerStatusUpdateRequest.clock:type_name -> matchmaking.VectorClock
+	70,  // 46: matchmaking.ServerStatusUpdateRequest.running_matches:type_name -> matchmaking.RunningMatch
+	17,  // 47: matchmaking.ServerStatusUpdateResponse.status_code:type_name -> matchmaking.ServerStatusUpdateResponse.StatusCode
+	34,  // 48: matchmaking.ServerStatusUpdateResponse.vector_clock:type_name -> matchmaking.VectorClock
+	18,  // 49: matchmaking.ServerControl.command:type_name -> matchmaking.ServerControl.Command
+	71,  // 50: matchmaking.ServerControl.ack:type_name -> matchmaking.ServerStatusUpdateResponse
+	139, // 51: matchmaking.ServerControl.chaos:type_name -> matchmaking.ChaosConfig
+	73,  // 52: matchmaking.MatchResultRequest.player_stats:type_name -> matchmaking.PlayerMatchStats
+	34,  // 53: matchmaking.MatchResultRequest.clock:type_name -> matchmaking.VectorClock
+	74,  // 54: matchmaking.MatchResultRequest.events:type_name -> matchmaking.ScoreEvent
+	19,  // 55: matchmaking.MatchResultResponse.status_code:type_name -> matchmaking.MatchResultResponse.StatusCode
+	34,  // 56: matchmaking.MatchResultResponse.vector_clock:type_name -> matchmaking.VectorClock
+	20,  // 57: matchmaking.MatchRecord.outcome:type_name -> matchmaking.MatchRecord.Outcome
+	73,  // 58: matchmaking.MatchRecord.player_stats:type_name -> matchmaking.PlayerMatchStats
+	78,  // 59: matchmaking.MatchRecord.timeline:type_name -> matchmaking.MatchEvent
+	74,  // 60: matchmaking.MatchRecord.score_events:type_name -> matchmaking.ScoreEvent
+	21,  // 61: matchmaking.MatchEvent.stage:type_name -> matchmaking.MatchEvent.Stage
+	34,  // 62: matchmaking.MatchEvent.vector_clock:type_name -> matchmaking.VectorClock
+	78,  // 63: matchmaking.MatchTimelineResponse.events:type_name -> matchmaking.MatchEvent
+	34,  // 64: matchmaking.MatchTimelineResponse.vector_clock:type_name -> matchmaking.VectorClock
+	77,  // 65: matchmaking.MatchHistoryResponse.matches:type_name -> matchmaking.MatchRecord
+	34,  // 66: matchmaking.MatchHistoryResponse.vector_clock:type_name -> matchmaking.VectorClock
+	22,  // 67: matchmaking.LeaderboardRequest.sort_by:type_name -> matchmaking.LeaderboardRequest.SortBy
+	83,  // 68: matchmaking.LeaderboardResponse.entries:type_name -> matchmaking.PlayerStats
+	34,  // 69: matchmaking.LeaderboardResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 70: matchmaking.AbortMatchRequest.clock:type_name -> matchmaking.VectorClock
+	23,  // 71: matchmaking.AbortMatchResponse.status_code:type_name -> matchmaking.AbortMatchResponse.StatusCode
+	34,  // 72: matchmaking.AbortMatchResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 73: matchmaking.RequestBackfillRequest.clock:type_name -> matchmaking.VectorClock
+	24,  // 74: matchmaking.RequestBackfillResponse.status_code:type_name -> matchmaking.RequestBackfillResponse.StatusCode
+	34,  // 75: matchmaking.RequestBackfillResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 76: matchmaking.DeregisterServerRequest.clock:type_name -> matchmaking.VectorClock
+	25,  // 77: matchmaking.DeregisterServerResponse.status_code:type_name -> matchmaking.DeregisterServerResponse.StatusCode
+	34,  // 78: matchmaking.DeregisterServerResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 79: matchmaking.MatchFeedbackRequest.clock:type_name -> matchmaking.VectorClock
+	26,  // 80: matchmaking.MatchFeedbackResponse.status_code:type_name -> matchmaking.MatchFeedbackResponse.StatusCode
+	34,  // 81: matchmaking.MatchFeedbackResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 82: matchmaking.PartyRequest.clock:type_name -> matchmaking.VectorClock
+	27,  // 83: matchmaking.PartyResponse.status_code:type_name -> matchmaking.PartyResponse.StatusCode
+	34,  // 84: matchmaking.PartyResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 85: matchmaking.CreateLobbyRequest.clock:type_name -> matchmaking.VectorClock
+	34,  // 86: matchmaking.LobbyRequest.clock:type_name -> matchmaking.VectorClock
+	28,  // 87: matchmaking.LobbyResponse.status_code:type_name -> matchmaking.LobbyResponse.StatusCode
+	34,  // 88: matchmaking.LobbyResponse.vector_clock:type_name -> matchmaking.VectorClock
+	0,   // 89: matchmaking.ServerInfo.status:type_name -> matchmaking.ServerStatus
+	1,   // 90: matchmaking.PlayerQueueEntry.priority:type_name -> matchmaking.QueuePriority
+	2,   // 91: matchmaking.PlayerQueueEntry.tier:type_name -> matchmaking.PlayerTier
+	102, // 92: matchmaking.SystemStatusResponse.servers:type_name -> matchmaking.ServerInfo
+	103, // 93: matchmaking.SystemStatusResponse.player_queue:type_name -> matchmaking.PlayerQueueEntry
+	34,  // 94: matchmaking.SystemStatusResponse.vector_clock:type_name -> matchmaking.VectorClock
+	109, // 95: matchmaking.SystemStatusResponse.banned:type_name -> matchmaking.BannedPlayer
+	104, // 96: matchmaking.SystemStatusResponse.modes:type_name -> matchmaking.ModeQueueStats
+	108, // 97: matchmaking.SystemStatusResponse.removed:type_name -> matchmaking.RemovedServer
+	107, // 98: matchmaking.SystemStatusResponse.queue_rate_limit:type_name -> matchmaking.RateLimitStats
+	106, // 99: matchmaking.SystemStatusResponse.matches:type_name -> matchmaking.MatchStatus
+	5,   // 100: matchmaking.MatchStatus.phase:type_name -> matchmaking.MatchPhase
+	34,  // 101: matchmaking.ClockMetricsResponse.vector_clock:type_name -> matchmaking.VectorClock
+	29,  // 102: matchmaking.EntityClock.kind:type_name -> matchmaking.EntityClock.Kind
+	34,  // 103: matchmaking.EntityClock.clock:type_name -> matchmaking.VectorClock
+	34,  // 104: matchmaking.ClockStateResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 105: matchmaking.ClockStateResponse.tombstones:type_name -> matchmaking.VectorClock
+	111, // 106: matchmaking.ClockStateResponse.entities:type_name -> matchmaking.EntityClock
+	113, // 107: matchmaking.ReliabilityResponse.servers:type_name -> matchmaking.ServerReliability
+	34,  // 108: matchmaking.ReliabilityResponse.vector_clock:type_name -> matchmaking.VectorClock
+	115, // 109: matchmaking.SLOStatusResponse.slos:type_name -> matchmaking.SLOStatus
+	34,  // 110: matchmaking.SLOStatusResponse.vector_clock:type_name -> matchmaking.VectorClock
+	118, // 111: matchmaking.WhatIfResponse.current:type_name -> matchmaking.WaitProjection
+	118, // 112: matchmaking.WhatIfResponse.projected:type_name -> matchmaking.WaitProjection
+	34,  // 113: matchmaking.WhatIfResponse.vector_clock:type_name -> matchmaking.VectorClock
+	30,  // 114: matchmaking.ReloadConfigResponse.status_code:type_name -> matchmaking.ReloadConfigResponse.StatusCode
+	121, // 115: matchmaking.ReloadConfigResponse.changes:type_name -> matchmaking.ConfigChange
+	0,   // 116: matchmaking.AdminServerUpdateRequest.new_status:type_name -> matchmaking.ServerStatus
+	34,  // 117: matchmaking.AdminServerUpdateRequest.clock:type_name -> matchmaking.VectorClock
+	35,  // 118: matchmaking.AdminUpsertGameModeRequest.mode:type_name -> matchmaking.GameMode
+	34,  // 119: matchmaking.AdminUpsertGameModeRequest.clock:type_name -> matchmaking.VectorClock
+	2,   // 120: matchmaking.AdminSetPlayerTierRequest.tier:type_name -> matchmaking.PlayerTier
+	34,  // 121: matchmaking.AdminSetPlayerTierRequest.clock:type_name -> matchmaking.VectorClock
+	34,  // 122: matchmaking.AdminPlayerActionRequest.clock:type_name -> matchmaking.VectorClock
+	34,  // 123: matchmaking.AdminTerminateMatchRequest.clock:type_name -> matchmaking.VectorClock
+	31,  // 124: matchmaking.StateEvent.kind:type_name -> matchmaking.StateEvent.Kind
+	34,  // 125: matchmaking.StateEvent.clock:type_name -> matchmaking.VectorClock
+	31,  // 126: matchmaking.EventLogRequest.kinds:type_name -> matchmaking.StateEvent.Kind
+	130, // 127: matchmaking.EventLogResponse.events:type_name -> matchmaking.StateEvent
+	34,  // 128: matchmaking.AdminAuditEntry.clock:type_name -> matchmaking.VectorClock
+	133, // 129: matchmaking.AuditLogResponse.entries:type_name -> matchmaking.AdminAuditEntry
+	34,  // 130: matchmaking.AdminDrainServerRequest.clock:type_name -> matchmaking.VectorClock
+	139, // 131: matchmaking.AdminSetChaosRequest.config:type_name -> matchmaking.ChaosConfig
+	34,  // 132: matchmaking.AdminSetChaosRequest.clock:type_name -> matchmaking.VectorClock
+	34,  // 133: matchmaking.AdminListSessionsRequest.clock:type_name -> matchmaking.VectorClock
+	142, // 134: matchmaking.AdminListSessionsResponse.sessions:type_name -> matchmaking.PlayerSession
+	34,  // 135: matchmaking.AdminListSessionsResponse.vector_clock:type_name -> matchmaking.VectorClock
+	34,  // 136: matchmaking.SimulateMatchingRequest.clock:type_name -> matchmaking.VectorClock
+	32,  // 137: matchmaking.SimulatedMatch.kind:type_name -> matchmaking.SimulatedMatch.Kind
+	145, // 138: matchmaking.SimulateMatchingResponse.matches:type_name -> matchmaking.SimulatedMatch
+	146, // 139: matchmaking.SimulateMatchingResponse.skipped:type_name -> matchmaking.SkippedPlayer
+	34,  // 140: matchmaking.SimulateMatchingResponse.vector_clock:type_name -> matchmaking.VectorClock
+	33,  // 141: matchmaking.AdminUpdateResponse.status:type_name -> matchmaking.AdminUpdateResponse.StatusCode
+	34,  // 142: matchmaking.AdminUpdateResponse.vector_clock:type_name -> matchmaking.VectorClock
+	1,   // 143: matchmaking.PlayerSnapshot.priority:type_name -> matchmaking.QueuePriority
+	2,   // 144: matchmaking.PlayerSnapshot.tier:type_name -> matchmaking.PlayerTier
+	3,   // 145: matchmaking.PlayerSnapshot.on_assign_failure:type_name -> matchmaking.AssignFailureAction
+	0,   // 146: matchmaking.ServerSnapshot.status:type_name -> matchmaking.ServerStatus
+	78,  // 147: matchmaking.MatchSnapshot.timeline:type_name -> matchmaking.MatchEvent
+	65,  // 148: matchmaking.RelaySnapshot.last:type_name -> matchmaking.SpectateEvent
+	55,  // 149: matchmaking.PlayerEvent.update:type_name -> matchmaking.MatchUpdate
+	149, // 150: matchmaking.NamespaceSnapshot.players:type_name -> matchmaking.PlayerSnapshot
+	150, // 151: matchmaking.NamespaceSnapshot.servers:type_name -> matchmaking.ServerSnapshot
+	151, // 152: matchmaking.NamespaceSnapshot.matches:type_name -> matchmaking.MatchSnapshot
+	34,  // 153: matchmaking.NamespaceSnapshot.vector_clock:type_name -> matchmaking.VectorClock
+	35,  // 154: matchmaking.NamespaceSnapshot.modes:type_name -> matchmaking.GameMode
+	156, // 155: matchmaking.NamespaceSnapshot.events:type_name -> matchmaking.PlayerEvent
+	152, // 156: matchmaking.NamespaceSnapshot.parties:type_name -> matchmaking.PartySnapshot
+	109, // 157: matchmaking.NamespaceSnapshot.banned:type_name -> matchmaking.BannedPlayer
+	34,  // 158: matchmaking.NamespaceSnapshot.clock_tombstones:type_name -> matchmaking.VectorClock
+	77,  // 159: matchmaking.NamespaceSnapshot.history:type_name -> matchmaking.MatchRecord
+	83,  // 160: matchmaking.NamespaceSnapshot.stats:type_name -> matchmaking.PlayerStats
+	153, // 161: matchmaking.NamespaceSnapshot.lobbies:type_name -> matchmaking.LobbySnapshot
+	154, // 162: matchmaking.NamespaceSnapshot.rematches:type_name -> matchmaking.RematchSnapshot
+	162, // 163: matchmaking.NamespaceSnapshot.lobby_ready_unix_ms:type_name -> matchmaking.NamespaceSnapshot.LobbyReadyUnixMsEntry
+	108, // 164: matchmaking.NamespaceSnapshot.removed:type_name -> matchmaking.RemovedServer
+	142, // 165: matchmaking.NamespaceSnapshot.sessions:type_name -> matchmaking.PlayerSession
+	155, // 166: matchmaking.NamespaceSnapshot.relays:type_name -> matchmaking.RelaySnapshot
+	157, // 167: matchmaking.StateSnapshot.namespaces:type_name -> matchmaking.NamespaceSnapshot
+	46,  // 168: matchmaking.Matchmaker.GetServerInfo:input_type -> matchmaking.ServerInfoRequest
+	50,  // 169: matchmaking.Matchmaker.RegisterPlayer:input_type -> matchmaking.RegisterPlayerRequest
+	36,  // 170: matchmaking.Matchmaker.QueuePlayer:input_type -> matchmaking.PlayerInfoRequest
+	38,  // 171: matchmaking.Matchmaker.CancelQueue:input_type -> matchmaking.CancelQueueRequest
+	40,  // 172: matchmaking.Matchmaker.LeaveMatch:input_type -> matchmaking.LeaveMatchRequest
+	56,  // 173: matchmaking.Matchmaker.AcceptMatch:input_type -> matchmaking.AcceptMatchRequest
+	58,  // 174: matchmaking.Matchmaker.RequestRematch:input_type -> matchmaking.RematchRequest
+	42,  // 175: matchmaking.Matchmaker.GetPlayerStatus:input_type -> matchmaking.PlayerStatusRequest
+	52,  // 176: matchmaking.Matchmaker.ListGameModes:input_type -> matchmaking.ListGameModesRequest
+	44,  // 177: matchmaking.Matchmaker.ResumeSession:input_type -> matchmaking.ResumeSessionRequest
+	48,  // 178: matchmaking.Matchmaker.PlayerHeartbeat:input_type -> matchmaking.PlayerHeartbeatRequest
+	92,  // 179: matchmaking.Matchmaker.SubmitMatchFeedback:input_type -> matchmaking.MatchFeedbackRequest
+	94,  // 180: matchmaking.Matchmaker.CreateParty:input_type -> matchmaking.PartyRequest
+	94,  // 181: matchmaking.Matchmaker.JoinParty:input_type -> matchmaking.PartyRequest
+	94,  // 182: matchmaking.Matchmaker.LeaveParty:input_type -> matchmaking.PartyRequest
+	96,  // 183: matchmaking.Matchmaker.CreateLobby:input_type -> matchmaking.CreateLobbyRequest
+	97,  // 184: matchmaking.Matchmaker.JoinLobby:input_type -> matchmaking.LobbyRequest
+	97,  // 185: matchmaking.Matchmaker.LeaveLobby:input_type -> matchmaking.LobbyRequest
+	81,  // 186: matchmaking.Matchmaker.GetMatchHistory:input_type -> matchmaking.MatchHistoryRequest
+	79,  // 187: matchmaking.Matchmaker.GetMatchTimeline:input_type -> matchmaking.MatchTimelineRequest
+	84,  // 188: matchmaking.Matchmaker.GetLeaderboard:input_type -> matchmaking.LeaderboardRequest
+	54,  // 189: matchmaking.Matchmaker.SubscribeMatchUpdates:input_type -> matchmaking.SubscribeRequest
+	68,  // 190: matchmaking.Matchmaker.SubscribeMatchEvents:input_type -> matchmaking.SubscribeMatchEventsRequest
+	69,  // 191: matchmaking.Matchmaker.Heartbeat:input_type -> matchmaking.ServerStatusUpdateRequest
+	69,  // 192: matchmaking.Matchmaker.UpdateServerStatus:input_type -> matchmaking.ServerStatusUpdateRequest
+	75,  // 193: matchmaking.Matchmaker.ReportMatchResult:input_type -> matchmaking.MatchResultRequest
+	86,  // 194: matchmaking.Matchmaker.AbortMatch:input_type -> matchmaking.AbortMatchRequest
+	90,  // 195: matchmaking.Matchmaker.DeregisterServer:input_type -> matchmaking.DeregisterServerRequest
+	88,  // 196: matchmaking.Matchmaker.RequestBackfill:input_type -> matchmaking.RequestBackfillRequest
+	66,  // 197: matchmaking.Matchmaker.PublishMatchEvents:input_type -> matchmaking.PublishMatchEventsRequest
+	101, // 198: matchmaking.Matchmaker.AdminGetSystemStatus:input_type -> matchmaking.AdminRequest
+	123, // 199: matchmaking.Matchmaker.AdminUpdateServerState:input_type -> matchmaking.AdminServerUpdateRequest
+	101, // 200: matchmaking.Matchmaker.AdminGetClockMetrics:input_type -> matchmaking.AdminRequest
+	101, // 201: matchmaking.Matchmaker.AdminGetClockState:input_type -> matchmaking.AdminRequest
+	138, // 202: matchmaking.Matchmaker.AdminDrainServer:input_type -> matchmaking.AdminDrainServerRequest
+	126, // 203: matchmaking.Matchmaker.AdminUpsertGameMode:input_type -> matchmaking.AdminUpsertGameModeRequest
+	127, // 204: matchmaking.Matchmaker.AdminSetPlayerTier:input_type -> matchmaking.AdminSetPlayerTierRequest
+	101, // 205: matchmaking.Matchmaker.AdminGetServerReliability:input_type -> matchmaking.AdminRequest
+	124, // 206: matchmaking.Matchmaker.AdminExportQueueHistory:input_type -> matchmaking.QueueHistoryRequest
+	101, // 207: matchmaking.Matchmaker.AdminGetSLOStatus:input_type -> matchmaking.AdminRequest
+	128, // 208: matchmaking.Matchmaker.AdminKickPlayer:input_type -> matchmaking.AdminPlayerActionRequest
+	128, // 209: matchmaking.Matchmaker.AdminBanPlayer:input_type -> matchmaking.AdminPlayerActionRequest
+	128, // 210: matchmaking.Matchmaker.AdminResetCooldown:input_type -> matchmaking.AdminPlayerActionRequest
+	117, // 211: matchmaking.Matchmaker.AdminSimulateCapacity:input_type -> matchmaking.WhatIfRequest
+	120, // 212: matchmaking.Matchmaker.AdminReloadConfig:input_type -> matchmaking.ReloadConfigRequest
+	129, // 213: matchmaking.Matchmaker.AdminTerminateMatch:input_type -> matchmaking.AdminTerminateMatchRequest
+	131, // 214: matchmaking.Matchmaker.AdminGetEventLog:input_type -> matchmaking.EventLogRequest
+	136, // 215: matchmaking.Matchmaker.AdminStreamLogs:input_type -> matchmaking.LogStreamRequest
+	140, // 216: matchmaking.Matchmaker.AdminSetChaos:input_type -> matchmaking.AdminSetChaosRequest
+	141, // 217: matchmaking.Matchmaker.AdminListSessions:input_type -> matchmaking.AdminListSessionsRequest
+	144, // 218: matchmaking.Matchmaker.AdminSimulateMatching:input_type -> matchmaking.SimulateMatchingRequest
+	134, // 219: matchmaking.Matchmaker.AdminGetAuditLog:input_type -> matchmaking.AuditLogRequest
+	158, // 220: matchmaking.Matchmaker.ReplicateState:input_type -> matchmaking.StateSnapshot
+	158, // 221: matchmaking.Matchmaker.TransferState:input_type -> matchmaking.StateSnapshot
+	60,  // 222: matchmaking.GameServer.AssignMatch:input_type -> matchmaking.AssignMatchRequest
+	62,  // 223: matchmaking.GameServer.CancelMatch:input_type -> matchmaking.CancelMatchRequest
+	64,  // 224: matchmaking.GameServer.SpectateMatch:input_type -> matchmaking.SpectateRequest
+	99,  // 225: matchmaking.GameServer.PingServer:input_type -> matchmaking.PingRequest
+	47,  // 226: matchmaking.Matchmaker.GetServerInfo:output_type -> matchmaking.ServerInfoResponse
+	51,  // 227: matchmaking.Matchmaker.RegisterPlayer:output_type -> matchmaking.RegisterPlayerResponse
+	37,  // 228: matchmaking.Matchmaker.QueuePlayer:output_type -> matchmaking.QueuePlayerResponse
+	39,  // 229: matchmaking.Matchmaker.CancelQueue:output_type -> matchmaking.CancelQueueResponse
+	41,  // 230: matchmaking.Matchmaker.LeaveMatch:output_type -> matchmaking.LeaveMatchResponse
+	57,  // 231: matchmaking.Matchmaker.AcceptMatch:output_type -> matchmaking.AcceptMatchResponse
+	59,  // 232: matchmaking.Matchmaker.RequestRematch:output_type -> matchmaking.RematchResponse
+	43,  // 233: matchmaking.Matchmaker.GetPlayerStatus:output_type -> matchmaking.PlayerStatusResponse
+	53,  // 234: matchmaking.Matchmaker.ListGameModes:output_type -> matchmaking.ListGameModesResponse
+	45,  // 235: matchmaking.Matchmaker.ResumeSession:output_type -> matchmaking.ResumeSessionResponse
+	49,  // 236: matchmaking.Matchmaker.PlayerHeartbeat:output_type -> matchmaking.PlayerHeartbeatResponse
+	93,  // 237: matchmaking.Matchmaker.SubmitMatchFeedback:output_type -> matchmaking.MatchFeedbackResponse
+	95,  // 238: matchmaking.Matchmaker.CreateParty:output_type -> matchmaking.PartyResponse
+	95,  // 239: matchmaking.Matchmaker.JoinParty:output_type -> matchmaking.PartyResponse
+	95,  // 240: matchmaking.Matchmaker.LeaveParty:output_type -> matchmaking.PartyResponse
+	98,  // 241: matchmaking.Matchmaker.CreateLobby:output_type -> matchmaking.LobbyResponse
+	98,  // 242: matchmaking.Matchmaker.JoinLobby:output_type -> matchmaking.LobbyResponse
+	98,  // 243: matchmaking.Matchmaker.LeaveLobby:output_type -> matchmaking.LobbyResponse
+	82,  // 244: matchmaking.Matchmaker.GetMatchHistory:output_type -> matchmaking.MatchHistoryResponse
+	80,  // 245: matchmaking.Matchmaker.GetMatchTimeline:output_type -> matchmaking.MatchTimelineResponse
+	85,  // 246: matchmaking.Matchmaker.GetLeaderboard:output_type -> matchmaking.LeaderboardResponse
+	55,  // 247: matchmaking.Matchmaker.SubscribeMatchUpdates:output_type -> matchmaking.MatchUpdate
+	65,  // 248: matchmaking.Matchmaker.SubscribeMatchEvents:output_type -> matchmaking.SpectateEvent
+	72,  // 249: matchmaking.Matchmaker.Heartbeat:output_type -> matchmaking.ServerControl
+	71,  // 250: matchmaking.Matchmaker.UpdateServerStatus:output_type -> matchmaking.ServerStatusUpdateResponse
+	76,  // 251: matchmaking.Matchmaker.ReportMatchResult:output_type -> matchmaking.MatchResultResponse
+	87,  // 252: matchmaking.Matchmaker.AbortMatch:output_type -> matchmaking.AbortMatchResponse
+	91,  // 253: matchmaking.Matchmaker.DeregisterServer:output_type -> matchmaking.DeregisterServerResponse
+	89,  // 254: matchmaking.Matchmaker.RequestBackfill:output_type -> matchmaking.RequestBackfillResponse
+	67,  // 255: matchmaking.Matchmaker.PublishMatchEvents:output_type -> matchmaking.PublishMatchEventsResponse
+	105, // 256: matchmaking.Matchmaker.AdminGetSystemStatus:output_type -> matchmaking.SystemStatusResponse
+	148, // 257: matchmaking.Matchmaker.AdminUpdateServerState:output_type -> matchmaking.AdminUpdateResponse
+	110, // 258: matchmaking.Matchmaker.AdminGetClockMetrics:output_type -> matchmaking.ClockMetricsResponse
+	112, // 259: matchmaking.Matchmaker.AdminGetClockState:output_type -> matchmaking.ClockStateResponse
+	148, // 260: matchmaking.Matchmaker.AdminDrainServer:output_type -> matchmaking.AdminUpdateResponse
+	148, // 261: matchmaking.Matchmaker.AdminUpsertGameMode:output_type -> matchmaking.AdminUpdateResponse
+	148, // 262: matchmaking.Matchmaker.AdminSetPlayerTier:output_type -> matchmaking.AdminUpdateResponse
+	114, // 263: matchmaking.Matchmaker.AdminGetServerReliability:output_type -> matchmaking.ReliabilityResponse
+	125, // 264: matchmaking.Matchmaker.AdminExportQueueHistory:output_type -> matchmaking.CsvChunk
+	116, // 265: matchmaking.Matchmaker.AdminGetSLOStatus:output_type -> matchmaking.SLOStatusResponse
+	148, // 266: matchmaking.Matchmaker.AdminKickPlayer:output_type -> matchmaking.AdminUpdateResponse
+	148, // 267: matchmaking.Matchmaker.AdminBanPlayer:output_type -> matchmaking.AdminUpdateResponse
+	148, // 268: matchmaking.Matchmaker.AdminResetCooldown:output_type -> matchmaking.AdminUpdateResponse
+	119, // 269: matchmaking.Matchmaker.AdminSimulateCapacity:output_type -> matchmaking.WhatIfResponse
+	122, // 270: matchmaking.Matchmaker.AdminReloadConfig:output_type -> matchmaking.ReloadConfigResponse
+	148, // 271: matchmaking.Matchmaker.AdminTerminateMatch:output_type -> matchmaking.AdminUpdateResponse
+	132, // 272: matchmaking.Matchmaker.AdminGetEventLog:output_type -> matchmaking.EventLogResponse
+	137, // 273: matchmaking.Matchmaker.AdminStreamLogs:output_type -> matchmaking.LogLine
+	148, // 274: matchmaking.Matchmaker.AdminSetChaos:output_type -> matchmaking.AdminUpdateResponse
+	143, // 275: matchmaking.Matchmaker.AdminListSessions:output_type -> matchmaking.AdminListSessionsResponse
+	147, // 276: matchmaking.Matchmaker.AdminSimulateMatching:output_type -> matchmaking.SimulateMatchingResponse
+	135, // 277: matchmaking.Matchmaker.AdminGetAuditLog:output_type -> matchmaking.AuditLogResponse
+	159, // 278: matchmaking.Matchmaker.ReplicateState:output_type -> matchmaking.ReplicationAck
+	160, // 279: matchmaking.Matchmaker.TransferState:output_type -> matchmaking.TransferStateResponse
+	61,  // 280: matchmaking.GameServer.AssignMatch:output_type -> matchmaking.AssignMatchResponse
+	63,  // 281: matchmaking.GameServer.CancelMatch:output_type -> matchmaking.CancelMatchResponse
+	65,  // 282: matchmaking.GameServer.SpectateMatch:output_type -> matchmaking.SpectateEvent
+	100, // 283: matchmaking.GameServer.PingServer:output_type -> matchmaking.PingResponse
+	226, // [226:284] is the sub-list for method output_type
+	168, // [168:226] is the sub-list for method input_type
+	168, // [168:168] is the sub-list for extension type_name
+	168, // [168:168] is the sub-list for extension extendee
+	0,   // [0:168] is the sub-list for field type_name
 }
 
 func init() { file_proto_matchmaking_proto_init() }
@@ -1259,8 +13566,8 @@ func file_proto_matchmaking_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_matchmaking_proto_rawDesc), len(file_proto_matchmaking_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   17,
+			NumEnums:      34,
+			NumMessages:   129,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
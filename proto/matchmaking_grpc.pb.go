@@ -19,409 +19,2362 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchmakerService_QueuePlayer_FullMethodName            = "/matchmaking.MatchmakerService/QueuePlayer"
-	MatchmakerService_GetPlayerStatus_FullMethodName        = "/matchmaking.MatchmakerService/GetPlayerStatus"
-	MatchmakerService_AdminGetSystemStatus_FullMethodName   = "/matchmaking.MatchmakerService/AdminGetSystemStatus"
-	MatchmakerService_AdminUpdateServerState_FullMethodName = "/matchmaking.MatchmakerService/AdminUpdateServerState"
+	Matchmaker_GetServerInfo_FullMethodName             = "/matchmaking.Matchmaker/GetServerInfo"
+	Matchmaker_RegisterPlayer_FullMethodName            = "/matchmaking.Matchmaker/RegisterPlayer"
+	Matchmaker_QueuePlayer_FullMethodName               = "/matchmaking.Matchmaker/QueuePlayer"
+	Matchmaker_CancelQueue_FullMethodName               = "/matchmaking.Matchmaker/CancelQueue"
+	Matchmaker_LeaveMatch_FullMethodName                = "/matchmaking.Matchmaker/LeaveMatch"
+	Matchmaker_AcceptMatch_FullMethodName               = "/matchmaking.Matchmaker/AcceptMatch"
+	Matchmaker_RequestRematch_FullMethodName            = "/matchmaking.Matchmaker/RequestRematch"
+	Matchmaker_GetPlayerStatus_FullMethodName           = "/matchmaking.Matchmaker/GetPlayerStatus"
+	Matchmaker_ListGameModes_FullMethodName             = "/matchmaking.Matchmaker/ListGameModes"
+	Matchmaker_ResumeSession_FullMethodName             = "/matchmaking.Matchmaker/ResumeSession"
+	Matchmaker_PlayerHeartbeat_FullMethodName           = "/matchmaking.Matchmaker/PlayerHeartbeat"
+	Matchmaker_SubmitMatchFeedback_FullMethodName       = "/matchmaking.Matchmaker/SubmitMatchFeedback"
+	Matchmaker_CreateParty_FullMethodName               = "/matchmaking.Matchmaker/CreateParty"
+	Matchmaker_JoinParty_FullMethodName                 = "/matchmaking.Matchmaker/JoinParty"
+	Matchmaker_LeaveParty_FullMethodName                = "/matchmaking.Matchmaker/LeaveParty"
+	Matchmaker_CreateLobby_FullMethodName               = "/matchmaking.Matchmaker/CreateLobby"
+	Matchmaker_JoinLobby_FullMethodName                 = "/matchmaking.Matchmaker/JoinLobby"
+	Matchmaker_LeaveLobby_FullMethodName                = "/matchmaking.Matchmaker/LeaveLobby"
+	Matchmaker_GetMatchHistory_FullMethodName           = "/matchmaking.Matchmaker/GetMatchHistory"
+	Matchmaker_GetMatchTimeline_FullMethodName          = "/matchmaking.Matchmaker/GetMatchTimeline"
+	Matchmaker_GetLeaderboard_FullMethodName            = "/matchmaking.Matchmaker/GetLeaderboard"
+	Matchmaker_SubscribeMatchUpdates_FullMethodName     = "/matchmaking.Matchmaker/SubscribeMatchUpdates"
+	Matchmaker_SubscribeMatchEvents_FullMethodName      = "/matchmaking.Matchmaker/SubscribeMatchEvents"
+	Matchmaker_Heartbeat_FullMethodName                 = "/matchmaking.Matchmaker/Heartbeat"
+	Matchmaker_UpdateServerStatus_FullMethodName        = "/matchmaking.Matchmaker/UpdateServerStatus"
+	Matchmaker_ReportMatchResult_FullMethodName         = "/matchmaking.Matchmaker/ReportMatchResult"
+	Matchmaker_AbortMatch_FullMethodName                = "/matchmaking.Matchmaker/AbortMatch"
+	Matchmaker_DeregisterServer_FullMethodName          = "/matchmaking.Matchmaker/DeregisterServer"
+	Matchmaker_RequestBackfill_FullMethodName           = "/matchmaking.Matchmaker/RequestBackfill"
+	Matchmaker_PublishMatchEvents_FullMethodName        = "/matchmaking.Matchmaker/PublishMatchEvents"
+	Matchmaker_AdminGetSystemStatus_FullMethodName      = "/matchmaking.Matchmaker/AdminGetSystemStatus"
+	Matchmaker_AdminUpdateServerState_FullMethodName    = "/matchmaking.Matchmaker/AdminUpdateServerState"
+	Matchmaker_AdminGetClockMetrics_FullMethodName      = "/matchmaking.Matchmaker/AdminGetClockMetrics"
+	Matchmaker_AdminGetClockState_FullMethodName        = "/matchmaking.Matchmaker/AdminGetClockState"
+	Matchmaker_AdminDrainServer_FullMethodName          = "/matchmaking.Matchmaker/AdminDrainServer"
+	Matchmaker_AdminUpsertGameMode_FullMethodName       = "/matchmaking.Matchmaker/AdminUpsertGameMode"
+	Matchmaker_AdminSetPlayerTier_FullMethodName        = "/matchmaking.Matchmaker/AdminSetPlayerTier"
+	Matchmaker_AdminGetServerReliability_FullMethodName = "/matchmaking.Matchmaker/AdminGetServerReliability"
+	Matchmaker_AdminExportQueueHistory_FullMethodName   = "/matchmaking.Matchmaker/AdminExportQueueHistory"
+	Matchmaker_AdminGetSLOStatus_FullMethodName         = "/matchmaking.Matchmaker/AdminGetSLOStatus"
+	Matchmaker_AdminKickPlayer_FullMethodName           = "/matchmaking.Matchmaker/AdminKickPlayer"
+	Matchmaker_AdminBanPlayer_FullMethodName            = "/matchmaking.Matchmaker/AdminBanPlayer"
+	Matchmaker_AdminResetCooldown_FullMethodName        = "/matchmaking.Matchmaker/AdminResetCooldown"
+	Matchmaker_AdminSimulateCapacity_FullMethodName     = "/matchmaking.Matchmaker/AdminSimulateCapacity"
+	Matchmaker_AdminReloadConfig_FullMethodName         = "/matchmaking.Matchmaker/AdminReloadConfig"
+	Matchmaker_AdminTerminateMatch_FullMethodName       = "/matchmaking.Matchmaker/AdminTerminateMatch"
+	Matchmaker_AdminGetEventLog_FullMethodName          = "/matchmaking.Matchmaker/AdminGetEventLog"
+	Matchmaker_AdminStreamLogs_FullMethodName           = "/matchmaking.Matchmaker/AdminStreamLogs"
+	Matchmaker_AdminSetChaos_FullMethodName             = "/matchmaking.Matchmaker/AdminSetChaos"
+	Matchmaker_AdminListSessions_FullMethodName         = "/matchmaking.Matchmaker/AdminListSessions"
+	Matchmaker_AdminSimulateMatching_FullMethodName     = "/matchmaking.Matchmaker/AdminSimulateMatching"
+	Matchmaker_AdminGetAuditLog_FullMethodName          = "/matchmaking.Matchmaker/AdminGetAuditLog"
+	Matchmaker_ReplicateState_FullMethodName            = "/matchmaking.Matchmaker/ReplicateState"
+	Matchmaker_TransferState_FullMethodName             = "/matchmaking.Matchmaker/TransferState"
 )
 
-// MatchmakerServiceClient is the client API for MatchmakerService service.
+// MatchmakerClient is the client API for Matchmaker service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
 // ────────────── SERVICIOS ─────────────
-type MatchmakerServiceClient interface {
+type MatchmakerClient interface {
+	// Versión y capacidades (todos los clientes; no exige token)
+	GetServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error)
 	// API para Jugadores
+	RegisterPlayer(ctx context.Context, in *RegisterPlayerRequest, opts ...grpc.CallOption) (*RegisterPlayerResponse, error)
 	QueuePlayer(ctx context.Context, in *PlayerInfoRequest, opts ...grpc.CallOption) (*QueuePlayerResponse, error)
+	CancelQueue(ctx context.Context, in *CancelQueueRequest, opts ...grpc.CallOption) (*CancelQueueResponse, error)
+	LeaveMatch(ctx context.Context, in *LeaveMatchRequest, opts ...grpc.CallOption) (*LeaveMatchResponse, error)
+	AcceptMatch(ctx context.Context, in *AcceptMatchRequest, opts ...grpc.CallOption) (*AcceptMatchResponse, error)
+	RequestRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error)
 	GetPlayerStatus(ctx context.Context, in *PlayerStatusRequest, opts ...grpc.CallOption) (*PlayerStatusResponse, error)
+	ListGameModes(ctx context.Context, in *ListGameModesRequest, opts ...grpc.CallOption) (*ListGameModesResponse, error)
+	ResumeSession(ctx context.Context, in *ResumeSessionRequest, opts ...grpc.CallOption) (*ResumeSessionResponse, error)
+	PlayerHeartbeat(ctx context.Context, in *PlayerHeartbeatRequest, opts ...grpc.CallOption) (*PlayerHeartbeatResponse, error)
+	SubmitMatchFeedback(ctx context.Context, in *MatchFeedbackRequest, opts ...grpc.CallOption) (*MatchFeedbackResponse, error)
+	CreateParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error)
+	JoinParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error)
+	LeaveParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error)
+	CreateLobby(ctx context.Context, in *CreateLobbyRequest, opts ...grpc.CallOption) (*LobbyResponse, error)
+	JoinLobby(ctx context.Context, in *LobbyRequest, opts ...grpc.CallOption) (*LobbyResponse, error)
+	LeaveLobby(ctx context.Context, in *LobbyRequest, opts ...grpc.CallOption) (*LobbyResponse, error)
+	GetMatchHistory(ctx context.Context, in *MatchHistoryRequest, opts ...grpc.CallOption) (*MatchHistoryResponse, error)
+	GetMatchTimeline(ctx context.Context, in *MatchTimelineRequest, opts ...grpc.CallOption) (*MatchTimelineResponse, error)
+	GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
+	// Notificaciones push: partida encontrada / finalizada
+	SubscribeMatchUpdates(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchUpdate], error)
+	// Eventos de una partida en curso retransmitidos desde su GameServer
+	SubscribeMatchEvents(ctx context.Context, in *SubscribeMatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectateEvent], error)
+	// Invocado por el GameServer hacia el Matchmaker. Heartbeat lleva los
+	// mismos estados que UpdateServerStatus, más un latido periódico, y
+	// devuelve órdenes por el mismo stream; UpdateServerStatus se mantiene
+	// para servidores antiguos.
+	Heartbeat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ServerStatusUpdateRequest, ServerControl], error)
+	UpdateServerStatus(ctx context.Context, in *ServerStatusUpdateRequest, opts ...grpc.CallOption) (*ServerStatusUpdateResponse, error)
+	ReportMatchResult(ctx context.Context, in *MatchResultRequest, opts ...grpc.CallOption) (*MatchResultResponse, error)
+	AbortMatch(ctx context.Context, in *AbortMatchRequest, opts ...grpc.CallOption) (*AbortMatchResponse, error)
+	DeregisterServer(ctx context.Context, in *DeregisterServerRequest, opts ...grpc.CallOption) (*DeregisterServerResponse, error)
+	RequestBackfill(ctx context.Context, in *RequestBackfillRequest, opts ...grpc.CallOption) (*RequestBackfillResponse, error)
+	PublishMatchEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PublishMatchEventsRequest, PublishMatchEventsResponse], error)
 	// API para Cliente Administrador
 	AdminGetSystemStatus(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*SystemStatusResponse, error)
 	AdminUpdateServerState(ctx context.Context, in *AdminServerUpdateRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminGetClockMetrics(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*ClockMetricsResponse, error)
+	AdminGetClockState(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*ClockStateResponse, error)
+	AdminDrainServer(ctx context.Context, in *AdminDrainServerRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminUpsertGameMode(ctx context.Context, in *AdminUpsertGameModeRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminSetPlayerTier(ctx context.Context, in *AdminSetPlayerTierRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminGetServerReliability(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*ReliabilityResponse, error)
+	AdminExportQueueHistory(ctx context.Context, in *QueueHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CsvChunk], error)
+	AdminGetSLOStatus(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*SLOStatusResponse, error)
+	AdminKickPlayer(ctx context.Context, in *AdminPlayerActionRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminBanPlayer(ctx context.Context, in *AdminPlayerActionRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminResetCooldown(ctx context.Context, in *AdminPlayerActionRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminSimulateCapacity(ctx context.Context, in *WhatIfRequest, opts ...grpc.CallOption) (*WhatIfResponse, error)
+	AdminReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	AdminTerminateMatch(ctx context.Context, in *AdminTerminateMatchRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminGetEventLog(ctx context.Context, in *EventLogRequest, opts ...grpc.CallOption) (*EventLogResponse, error)
+	AdminStreamLogs(ctx context.Context, in *LogStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error)
+	AdminSetChaos(ctx context.Context, in *AdminSetChaosRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error)
+	AdminListSessions(ctx context.Context, in *AdminListSessionsRequest, opts ...grpc.CallOption) (*AdminListSessionsResponse, error)
+	AdminSimulateMatching(ctx context.Context, in *SimulateMatchingRequest, opts ...grpc.CallOption) (*SimulateMatchingResponse, error)
+	AdminGetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error)
+	// Replicación primario → respaldo
+	ReplicateState(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StateSnapshot, ReplicationAck], error)
+	// Traspaso del estado de un Matchmaker que se apaga
+	TransferState(ctx context.Context, in *StateSnapshot, opts ...grpc.CallOption) (*TransferStateResponse, error)
 }
 
-type matchmakerServiceClient struct {
+type matchmakerClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewMatchmakerServiceClient(cc grpc.ClientConnInterface) MatchmakerServiceClient {
-	return &matchmakerServiceClient{cc}
+func NewMatchmakerClient(cc grpc.ClientConnInterface) MatchmakerClient {
+	return &matchmakerClient{cc}
+}
+
+func (c *matchmakerClient) GetServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerInfoResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_GetServerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) RegisterPlayer(ctx context.Context, in *RegisterPlayerRequest, opts ...grpc.CallOption) (*RegisterPlayerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterPlayerResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_RegisterPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) QueuePlayer(ctx context.Context, in *PlayerInfoRequest, opts ...grpc.CallOption) (*QueuePlayerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueuePlayerResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_QueuePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) CancelQueue(ctx context.Context, in *CancelQueueRequest, opts ...grpc.CallOption) (*CancelQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelQueueResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_CancelQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) LeaveMatch(ctx context.Context, in *LeaveMatchRequest, opts ...grpc.CallOption) (*LeaveMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveMatchResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_LeaveMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AcceptMatch(ctx context.Context, in *AcceptMatchRequest, opts ...grpc.CallOption) (*AcceptMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptMatchResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AcceptMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) RequestRematch(ctx context.Context, in *RematchRequest, opts ...grpc.CallOption) (*RematchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RematchResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_RequestRematch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) GetPlayerStatus(ctx context.Context, in *PlayerStatusRequest, opts ...grpc.CallOption) (*PlayerStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerStatusResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_GetPlayerStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) ListGameModes(ctx context.Context, in *ListGameModesRequest, opts ...grpc.CallOption) (*ListGameModesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGameModesResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_ListGameModes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) ResumeSession(ctx context.Context, in *ResumeSessionRequest, opts ...grpc.CallOption) (*ResumeSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeSessionResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_ResumeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) PlayerHeartbeat(ctx context.Context, in *PlayerHeartbeatRequest, opts ...grpc.CallOption) (*PlayerHeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlayerHeartbeatResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_PlayerHeartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) SubmitMatchFeedback(ctx context.Context, in *MatchFeedbackRequest, opts ...grpc.CallOption) (*MatchFeedbackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchFeedbackResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_SubmitMatchFeedback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) CreateParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_CreateParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) JoinParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_JoinParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) LeaveParty(ctx context.Context, in *PartyRequest, opts ...grpc.CallOption) (*PartyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_LeaveParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) CreateLobby(ctx context.Context, in *CreateLobbyRequest, opts ...grpc.CallOption) (*LobbyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LobbyResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_CreateLobby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) JoinLobby(ctx context.Context, in *LobbyRequest, opts ...grpc.CallOption) (*LobbyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LobbyResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_JoinLobby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) LeaveLobby(ctx context.Context, in *LobbyRequest, opts ...grpc.CallOption) (*LobbyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LobbyResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_LeaveLobby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) GetMatchHistory(ctx context.Context, in *MatchHistoryRequest, opts ...grpc.CallOption) (*MatchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchHistoryResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_GetMatchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) GetMatchTimeline(ctx context.Context, in *MatchTimelineRequest, opts ...grpc.CallOption) (*MatchTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchTimelineResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_GetMatchTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) SubscribeMatchUpdates(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[0], Matchmaker_SubscribeMatchUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, MatchUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_SubscribeMatchUpdatesClient = grpc.ServerStreamingClient[MatchUpdate]
+
+func (c *matchmakerClient) SubscribeMatchEvents(ctx context.Context, in *SubscribeMatchEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectateEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[1], Matchmaker_SubscribeMatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeMatchEventsRequest, SpectateEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_SubscribeMatchEventsClient = grpc.ServerStreamingClient[SpectateEvent]
+
+func (c *matchmakerClient) Heartbeat(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ServerStatusUpdateRequest, ServerControl], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[2], Matchmaker_Heartbeat_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ServerStatusUpdateRequest, ServerControl]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_HeartbeatClient = grpc.BidiStreamingClient[ServerStatusUpdateRequest, ServerControl]
+
+func (c *matchmakerClient) UpdateServerStatus(ctx context.Context, in *ServerStatusUpdateRequest, opts ...grpc.CallOption) (*ServerStatusUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerStatusUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_UpdateServerStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) ReportMatchResult(ctx context.Context, in *MatchResultRequest, opts ...grpc.CallOption) (*MatchResultResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchResultResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_ReportMatchResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AbortMatch(ctx context.Context, in *AbortMatchRequest, opts ...grpc.CallOption) (*AbortMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortMatchResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AbortMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) DeregisterServer(ctx context.Context, in *DeregisterServerRequest, opts ...grpc.CallOption) (*DeregisterServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterServerResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_DeregisterServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) RequestBackfill(ctx context.Context, in *RequestBackfillRequest, opts ...grpc.CallOption) (*RequestBackfillResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestBackfillResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_RequestBackfill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) PublishMatchEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PublishMatchEventsRequest, PublishMatchEventsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[3], Matchmaker_PublishMatchEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PublishMatchEventsRequest, PublishMatchEventsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_PublishMatchEventsClient = grpc.ClientStreamingClient[PublishMatchEventsRequest, PublishMatchEventsResponse]
+
+func (c *matchmakerClient) AdminGetSystemStatus(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*SystemStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemStatusResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetSystemStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminUpdateServerState(ctx context.Context, in *AdminServerUpdateRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminUpdateServerState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminGetClockMetrics(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*ClockMetricsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClockMetricsResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetClockMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminGetClockState(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*ClockStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClockStateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetClockState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminDrainServer(ctx context.Context, in *AdminDrainServerRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminDrainServer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminUpsertGameMode(ctx context.Context, in *AdminUpsertGameModeRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminUpsertGameMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminSetPlayerTier(ctx context.Context, in *AdminSetPlayerTierRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminSetPlayerTier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminGetServerReliability(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*ReliabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReliabilityResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetServerReliability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminExportQueueHistory(ctx context.Context, in *QueueHistoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CsvChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[4], Matchmaker_AdminExportQueueHistory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[QueueHistoryRequest, CsvChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_AdminExportQueueHistoryClient = grpc.ServerStreamingClient[CsvChunk]
+
+func (c *matchmakerClient) AdminGetSLOStatus(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*SLOStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SLOStatusResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetSLOStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminKickPlayer(ctx context.Context, in *AdminPlayerActionRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminKickPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminBanPlayer(ctx context.Context, in *AdminPlayerActionRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminBanPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminResetCooldown(ctx context.Context, in *AdminPlayerActionRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminResetCooldown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminSimulateCapacity(ctx context.Context, in *WhatIfRequest, opts ...grpc.CallOption) (*WhatIfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WhatIfResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminSimulateCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminReloadConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminTerminateMatch(ctx context.Context, in *AdminTerminateMatchRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminTerminateMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminGetEventLog(ctx context.Context, in *EventLogRequest, opts ...grpc.CallOption) (*EventLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EventLogResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetEventLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminStreamLogs(ctx context.Context, in *LogStreamRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[5], Matchmaker_AdminStreamLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[LogStreamRequest, LogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_AdminStreamLogsClient = grpc.ServerStreamingClient[LogLine]
+
+func (c *matchmakerClient) AdminSetChaos(ctx context.Context, in *AdminSetChaosRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUpdateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminSetChaos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminListSessions(ctx context.Context, in *AdminListSessionsRequest, opts ...grpc.CallOption) (*AdminListSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminListSessionsResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminSimulateMatching(ctx context.Context, in *SimulateMatchingRequest, opts ...grpc.CallOption) (*SimulateMatchingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulateMatchingResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminSimulateMatching_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) AdminGetAuditLog(ctx context.Context, in *AuditLogRequest, opts ...grpc.CallOption) (*AuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditLogResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_AdminGetAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakerClient) ReplicateState(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[StateSnapshot, ReplicationAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Matchmaker_ServiceDesc.Streams[6], Matchmaker_ReplicateState_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StateSnapshot, ReplicationAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_ReplicateStateClient = grpc.ClientStreamingClient[StateSnapshot, ReplicationAck]
+
+func (c *matchmakerClient) TransferState(ctx context.Context, in *StateSnapshot, opts ...grpc.CallOption) (*TransferStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferStateResponse)
+	err := c.cc.Invoke(ctx, Matchmaker_TransferState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatchmakerServer is the server API for Matchmaker service.
+// All implementations must embed UnimplementedMatchmakerServer
+// for forward compatibility.
+//
+// ────────────── SERVICIOS ─────────────
+type MatchmakerServer interface {
+	// Versión y capacidades (todos los clientes; no exige token)
+	GetServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error)
+	// API para Jugadores
+	RegisterPlayer(context.Context, *RegisterPlayerRequest) (*RegisterPlayerResponse, error)
+	QueuePlayer(context.Context, *PlayerInfoRequest) (*QueuePlayerResponse, error)
+	CancelQueue(context.Context, *CancelQueueRequest) (*CancelQueueResponse, error)
+	LeaveMatch(context.Context, *LeaveMatchRequest) (*LeaveMatchResponse, error)
+	AcceptMatch(context.Context, *AcceptMatchRequest) (*AcceptMatchResponse, error)
+	RequestRematch(context.Context, *RematchRequest) (*RematchResponse, error)
+	GetPlayerStatus(context.Context, *PlayerStatusRequest) (*PlayerStatusResponse, error)
+	ListGameModes(context.Context, *ListGameModesRequest) (*ListGameModesResponse, error)
+	ResumeSession(context.Context, *ResumeSessionRequest) (*ResumeSessionResponse, error)
+	PlayerHeartbeat(context.Context, *PlayerHeartbeatRequest) (*PlayerHeartbeatResponse, error)
+	SubmitMatchFeedback(context.Context, *MatchFeedbackRequest) (*MatchFeedbackResponse, error)
+	CreateParty(context.Context, *PartyRequest) (*PartyResponse, error)
+	JoinParty(context.Context, *PartyRequest) (*PartyResponse, error)
+	LeaveParty(context.Context, *PartyRequest) (*PartyResponse, error)
+	CreateLobby(context.Context, *CreateLobbyRequest) (*LobbyResponse, error)
+	JoinLobby(context.Context, *LobbyRequest) (*LobbyResponse, error)
+	LeaveLobby(context.Context, *LobbyRequest) (*LobbyResponse, error)
+	GetMatchHistory(context.Context, *MatchHistoryRequest) (*MatchHistoryResponse, error)
+	GetMatchTimeline(context.Context, *MatchTimelineRequest) (*MatchTimelineResponse, error)
+	GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
+	// Notificaciones push: partida encontrada / finalizada
+	SubscribeMatchUpdates(*SubscribeRequest, grpc.ServerStreamingServer[MatchUpdate]) error
+	// Eventos de una partida en curso retransmitidos desde su GameServer
+	SubscribeMatchEvents(*SubscribeMatchEventsRequest, grpc.ServerStreamingServer[SpectateEvent]) error
+	// Invocado por el GameServer hacia el Matchmaker. Heartbeat lleva los
+	// mismos estados que UpdateServerStatus, más un latido periódico, y
+	// devuelve órdenes por el mismo stream; UpdateServerStatus se mantiene
+	// para servidores antiguos.
+	Heartbeat(grpc.BidiStreamingServer[ServerStatusUpdateRequest, ServerControl]) error
+	UpdateServerStatus(context.Context, *ServerStatusUpdateRequest) (*ServerStatusUpdateResponse, error)
+	ReportMatchResult(context.Context, *MatchResultRequest) (*MatchResultResponse, error)
+	AbortMatch(context.Context, *AbortMatchRequest) (*AbortMatchResponse, error)
+	DeregisterServer(context.Context, *DeregisterServerRequest) (*DeregisterServerResponse, error)
+	RequestBackfill(context.Context, *RequestBackfillRequest) (*RequestBackfillResponse, error)
+	PublishMatchEvents(grpc.ClientStreamingServer[PublishMatchEventsRequest, PublishMatchEventsResponse]) error
+	// API para Cliente Administrador
+	AdminGetSystemStatus(context.Context, *AdminRequest) (*SystemStatusResponse, error)
+	AdminUpdateServerState(context.Context, *AdminServerUpdateRequest) (*AdminUpdateResponse, error)
+	AdminGetClockMetrics(context.Context, *AdminRequest) (*ClockMetricsResponse, error)
+	AdminGetClockState(context.Context, *AdminRequest) (*ClockStateResponse, error)
+	AdminDrainServer(context.Context, *AdminDrainServerRequest) (*AdminUpdateResponse, error)
+	AdminUpsertGameMode(context.Context, *AdminUpsertGameModeRequest) (*AdminUpdateResponse, error)
+	AdminSetPlayerTier(context.Context, *AdminSetPlayerTierRequest) (*AdminUpdateResponse, error)
+	AdminGetServerReliability(context.Context, *AdminRequest) (*ReliabilityResponse, error)
+	AdminExportQueueHistory(*QueueHistoryRequest, grpc.ServerStreamingServer[CsvChunk]) error
+	AdminGetSLOStatus(context.Context, *AdminRequest) (*SLOStatusResponse, error)
+	AdminKickPlayer(context.Context, *AdminPlayerActionRequest) (*AdminUpdateResponse, error)
+	AdminBanPlayer(context.Context, *AdminPlayerActionRequest) (*AdminUpdateResponse, error)
+	AdminResetCooldown(context.Context, *AdminPlayerActionRequest) (*AdminUpdateResponse, error)
+	AdminSimulateCapacity(context.Context, *WhatIfRequest) (*WhatIfResponse, error)
+	AdminReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	AdminTerminateMatch(context.Context, *AdminTerminateMatchRequest) (*AdminUpdateResponse, error)
+	AdminGetEventLog(context.Context, *EventLogRequest) (*EventLogResponse, error)
+	AdminStreamLogs(*LogStreamRequest, grpc.ServerStreamingServer[LogLine]) error
+	AdminSetChaos(context.Context, *AdminSetChaosRequest) (*AdminUpdateResponse, error)
+	AdminListSessions(context.Context, *AdminListSessionsRequest) (*AdminListSessionsResponse, error)
+	AdminSimulateMatching(context.Context, *SimulateMatchingRequest) (*SimulateMatchingResponse, error)
+	AdminGetAuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error)
+	// Replicación primario → respaldo
+	ReplicateState(grpc.ClientStreamingServer[StateSnapshot, ReplicationAck]) error
+	// Traspaso del estado de un Matchmaker que se apaga
+	TransferState(context.Context, *StateSnapshot) (*TransferStateResponse, error)
+	mustEmbedUnimplementedMatchmakerServer()
+}
+
+// UnimplementedMatchmakerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatchmakerServer struct{}
+
+func (UnimplementedMatchmakerServer) GetServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerInfo not implemented")
+}
+func (UnimplementedMatchmakerServer) RegisterPlayer(context.Context, *RegisterPlayerRequest) (*RegisterPlayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPlayer not implemented")
+}
+func (UnimplementedMatchmakerServer) QueuePlayer(context.Context, *PlayerInfoRequest) (*QueuePlayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueuePlayer not implemented")
+}
+func (UnimplementedMatchmakerServer) CancelQueue(context.Context, *CancelQueueRequest) (*CancelQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelQueue not implemented")
+}
+func (UnimplementedMatchmakerServer) LeaveMatch(context.Context, *LeaveMatchRequest) (*LeaveMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveMatch not implemented")
+}
+func (UnimplementedMatchmakerServer) AcceptMatch(context.Context, *AcceptMatchRequest) (*AcceptMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptMatch not implemented")
+}
+func (UnimplementedMatchmakerServer) RequestRematch(context.Context, *RematchRequest) (*RematchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestRematch not implemented")
+}
+func (UnimplementedMatchmakerServer) GetPlayerStatus(context.Context, *PlayerStatusRequest) (*PlayerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerStatus not implemented")
+}
+func (UnimplementedMatchmakerServer) ListGameModes(context.Context, *ListGameModesRequest) (*ListGameModesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGameModes not implemented")
+}
+func (UnimplementedMatchmakerServer) ResumeSession(context.Context, *ResumeSessionRequest) (*ResumeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSession not implemented")
+}
+func (UnimplementedMatchmakerServer) PlayerHeartbeat(context.Context, *PlayerHeartbeatRequest) (*PlayerHeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayerHeartbeat not implemented")
+}
+func (UnimplementedMatchmakerServer) SubmitMatchFeedback(context.Context, *MatchFeedbackRequest) (*MatchFeedbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitMatchFeedback not implemented")
+}
+func (UnimplementedMatchmakerServer) CreateParty(context.Context, *PartyRequest) (*PartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateParty not implemented")
+}
+func (UnimplementedMatchmakerServer) JoinParty(context.Context, *PartyRequest) (*PartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinParty not implemented")
+}
+func (UnimplementedMatchmakerServer) LeaveParty(context.Context, *PartyRequest) (*PartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveParty not implemented")
+}
+func (UnimplementedMatchmakerServer) CreateLobby(context.Context, *CreateLobbyRequest) (*LobbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLobby not implemented")
+}
+func (UnimplementedMatchmakerServer) JoinLobby(context.Context, *LobbyRequest) (*LobbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinLobby not implemented")
+}
+func (UnimplementedMatchmakerServer) LeaveLobby(context.Context, *LobbyRequest) (*LobbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveLobby not implemented")
+}
+func (UnimplementedMatchmakerServer) GetMatchHistory(context.Context, *MatchHistoryRequest) (*MatchHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchHistory not implemented")
+}
+func (UnimplementedMatchmakerServer) GetMatchTimeline(context.Context, *MatchTimelineRequest) (*MatchTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchTimeline not implemented")
+}
+func (UnimplementedMatchmakerServer) GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedMatchmakerServer) SubscribeMatchUpdates(*SubscribeRequest, grpc.ServerStreamingServer[MatchUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMatchUpdates not implemented")
+}
+func (UnimplementedMatchmakerServer) SubscribeMatchEvents(*SubscribeMatchEventsRequest, grpc.ServerStreamingServer[SpectateEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMatchEvents not implemented")
+}
+func (UnimplementedMatchmakerServer) Heartbeat(grpc.BidiStreamingServer[ServerStatusUpdateRequest, ServerControl]) error {
+	return status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedMatchmakerServer) UpdateServerStatus(context.Context, *ServerStatusUpdateRequest) (*ServerStatusUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServerStatus not implemented")
+}
+func (UnimplementedMatchmakerServer) ReportMatchResult(context.Context, *MatchResultRequest) (*MatchResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportMatchResult not implemented")
+}
+func (UnimplementedMatchmakerServer) AbortMatch(context.Context, *AbortMatchRequest) (*AbortMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortMatch not implemented")
+}
+func (UnimplementedMatchmakerServer) DeregisterServer(context.Context, *DeregisterServerRequest) (*DeregisterServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterServer not implemented")
+}
+func (UnimplementedMatchmakerServer) RequestBackfill(context.Context, *RequestBackfillRequest) (*RequestBackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestBackfill not implemented")
+}
+func (UnimplementedMatchmakerServer) PublishMatchEvents(grpc.ClientStreamingServer[PublishMatchEventsRequest, PublishMatchEventsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PublishMatchEvents not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetSystemStatus(context.Context, *AdminRequest) (*SystemStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetSystemStatus not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminUpdateServerState(context.Context, *AdminServerUpdateRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUpdateServerState not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetClockMetrics(context.Context, *AdminRequest) (*ClockMetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetClockMetrics not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetClockState(context.Context, *AdminRequest) (*ClockStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetClockState not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminDrainServer(context.Context, *AdminDrainServerRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminDrainServer not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminUpsertGameMode(context.Context, *AdminUpsertGameModeRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminUpsertGameMode not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminSetPlayerTier(context.Context, *AdminSetPlayerTierRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSetPlayerTier not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetServerReliability(context.Context, *AdminRequest) (*ReliabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetServerReliability not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminExportQueueHistory(*QueueHistoryRequest, grpc.ServerStreamingServer[CsvChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method AdminExportQueueHistory not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetSLOStatus(context.Context, *AdminRequest) (*SLOStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetSLOStatus not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminKickPlayer(context.Context, *AdminPlayerActionRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminKickPlayer not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminBanPlayer(context.Context, *AdminPlayerActionRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminBanPlayer not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminResetCooldown(context.Context, *AdminPlayerActionRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminResetCooldown not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminSimulateCapacity(context.Context, *WhatIfRequest) (*WhatIfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSimulateCapacity not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminReloadConfig not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminTerminateMatch(context.Context, *AdminTerminateMatchRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminTerminateMatch not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetEventLog(context.Context, *EventLogRequest) (*EventLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetEventLog not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminStreamLogs(*LogStreamRequest, grpc.ServerStreamingServer[LogLine]) error {
+	return status.Errorf(codes.Unimplemented, "method AdminStreamLogs not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminSetChaos(context.Context, *AdminSetChaosRequest) (*AdminUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSetChaos not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminListSessions(context.Context, *AdminListSessionsRequest) (*AdminListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminListSessions not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminSimulateMatching(context.Context, *SimulateMatchingRequest) (*SimulateMatchingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminSimulateMatching not implemented")
+}
+func (UnimplementedMatchmakerServer) AdminGetAuditLog(context.Context, *AuditLogRequest) (*AuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdminGetAuditLog not implemented")
+}
+func (UnimplementedMatchmakerServer) ReplicateState(grpc.ClientStreamingServer[StateSnapshot, ReplicationAck]) error {
+	return status.Errorf(codes.Unimplemented, "method ReplicateState not implemented")
+}
+func (UnimplementedMatchmakerServer) TransferState(context.Context, *StateSnapshot) (*TransferStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferState not implemented")
+}
+func (UnimplementedMatchmakerServer) mustEmbedUnimplementedMatchmakerServer() {}
+func (UnimplementedMatchmakerServer) testEmbeddedByValue()                    {}
+
+// UnsafeMatchmakerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchmakerServer will
+// result in compilation errors.
+type UnsafeMatchmakerServer interface {
+	mustEmbedUnimplementedMatchmakerServer()
+}
+
+func RegisterMatchmakerServer(s grpc.ServiceRegistrar, srv MatchmakerServer) {
+	// If the following call pancis, it indicates UnimplementedMatchmakerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Matchmaker_ServiceDesc, srv)
+}
+
+func _Matchmaker_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_GetServerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).GetServerInfo(ctx, req.(*ServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_RegisterPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).RegisterPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_RegisterPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).RegisterPlayer(ctx, req.(*RegisterPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_QueuePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).QueuePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_QueuePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).QueuePlayer(ctx, req.(*PlayerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_CancelQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).CancelQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_CancelQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).CancelQueue(ctx, req.(*CancelQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_LeaveMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).LeaveMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_LeaveMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).LeaveMatch(ctx, req.(*LeaveMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AcceptMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AcceptMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AcceptMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AcceptMatch(ctx, req.(*AcceptMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_RequestRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).RequestRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_RequestRematch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).RequestRematch(ctx, req.(*RematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_GetPlayerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).GetPlayerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_GetPlayerStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).GetPlayerStatus(ctx, req.(*PlayerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_ListGameModes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGameModesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).ListGameModes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_ListGameModes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).ListGameModes(ctx, req.(*ListGameModesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_ResumeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).ResumeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_ResumeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).ResumeSession(ctx, req.(*ResumeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_PlayerHeartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlayerHeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).PlayerHeartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_PlayerHeartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).PlayerHeartbeat(ctx, req.(*PlayerHeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_SubmitMatchFeedback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchFeedbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).SubmitMatchFeedback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_SubmitMatchFeedback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).SubmitMatchFeedback(ctx, req.(*MatchFeedbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_CreateParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).CreateParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_CreateParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).CreateParty(ctx, req.(*PartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_JoinParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).JoinParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_JoinParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).JoinParty(ctx, req.(*PartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_LeaveParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).LeaveParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_LeaveParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).LeaveParty(ctx, req.(*PartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_CreateLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).CreateLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_CreateLobby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).CreateLobby(ctx, req.(*CreateLobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_JoinLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).JoinLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_JoinLobby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).JoinLobby(ctx, req.(*LobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_LeaveLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).LeaveLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_LeaveLobby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).LeaveLobby(ctx, req.(*LobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_GetMatchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).GetMatchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_GetMatchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).GetMatchHistory(ctx, req.(*MatchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_GetMatchTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).GetMatchTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_GetMatchTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).GetMatchTimeline(ctx, req.(*MatchTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).GetLeaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_SubscribeMatchUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchmakerServer).SubscribeMatchUpdates(m, &grpc.GenericServerStream[SubscribeRequest, MatchUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_SubscribeMatchUpdatesServer = grpc.ServerStreamingServer[MatchUpdate]
+
+func _Matchmaker_SubscribeMatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchmakerServer).SubscribeMatchEvents(m, &grpc.GenericServerStream[SubscribeMatchEventsRequest, SpectateEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_SubscribeMatchEventsServer = grpc.ServerStreamingServer[SpectateEvent]
+
+func _Matchmaker_Heartbeat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MatchmakerServer).Heartbeat(&grpc.GenericServerStream[ServerStatusUpdateRequest, ServerControl]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_HeartbeatServer = grpc.BidiStreamingServer[ServerStatusUpdateRequest, ServerControl]
+
+func _Matchmaker_UpdateServerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerStatusUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).UpdateServerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_UpdateServerStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).UpdateServerStatus(ctx, req.(*ServerStatusUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_ReportMatchResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).ReportMatchResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_ReportMatchResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).ReportMatchResult(ctx, req.(*MatchResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AbortMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AbortMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AbortMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AbortMatch(ctx, req.(*AbortMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_DeregisterServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).DeregisterServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_DeregisterServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).DeregisterServer(ctx, req.(*DeregisterServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_RequestBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).RequestBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_RequestBackfill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).RequestBackfill(ctx, req.(*RequestBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_PublishMatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MatchmakerServer).PublishMatchEvents(&grpc.GenericServerStream[PublishMatchEventsRequest, PublishMatchEventsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_PublishMatchEventsServer = grpc.ClientStreamingServer[PublishMatchEventsRequest, PublishMatchEventsResponse]
+
+func _Matchmaker_AdminGetSystemStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminGetSystemStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminGetSystemStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminGetSystemStatus(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminUpdateServerState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminServerUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminUpdateServerState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminUpdateServerState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminUpdateServerState(ctx, req.(*AdminServerUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminGetClockMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminGetClockMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminGetClockMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminGetClockMetrics(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminGetClockState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminGetClockState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminGetClockState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminGetClockState(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminDrainServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminDrainServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminDrainServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminDrainServer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminDrainServer(ctx, req.(*AdminDrainServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminUpsertGameMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUpsertGameModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminUpsertGameMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminUpsertGameMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminUpsertGameMode(ctx, req.(*AdminUpsertGameModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminSetPlayerTier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSetPlayerTierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminSetPlayerTier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminSetPlayerTier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminSetPlayerTier(ctx, req.(*AdminSetPlayerTierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminGetServerReliability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminGetServerReliability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminGetServerReliability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminGetServerReliability(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminExportQueueHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueueHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchmakerServer).AdminExportQueueHistory(m, &grpc.GenericServerStream[QueueHistoryRequest, CsvChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_AdminExportQueueHistoryServer = grpc.ServerStreamingServer[CsvChunk]
+
+func _Matchmaker_AdminGetSLOStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminGetSLOStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminGetSLOStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminGetSLOStatus(ctx, req.(*AdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminKickPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminPlayerActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminKickPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminKickPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminKickPlayer(ctx, req.(*AdminPlayerActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaker_AdminBanPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminPlayerActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminBanPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminBanPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminBanPlayer(ctx, req.(*AdminPlayerActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchmakerServiceClient) QueuePlayer(ctx context.Context, in *PlayerInfoRequest, opts ...grpc.CallOption) (*QueuePlayerResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(QueuePlayerResponse)
-	err := c.cc.Invoke(ctx, MatchmakerService_QueuePlayer_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Matchmaker_AdminResetCooldown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminPlayerActionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminResetCooldown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminResetCooldown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminResetCooldown(ctx, req.(*AdminPlayerActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchmakerServiceClient) GetPlayerStatus(ctx context.Context, in *PlayerStatusRequest, opts ...grpc.CallOption) (*PlayerStatusResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PlayerStatusResponse)
-	err := c.cc.Invoke(ctx, MatchmakerService_GetPlayerStatus_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Matchmaker_AdminSimulateCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhatIfRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminSimulateCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminSimulateCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminSimulateCapacity(ctx, req.(*WhatIfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchmakerServiceClient) AdminGetSystemStatus(ctx context.Context, in *AdminRequest, opts ...grpc.CallOption) (*SystemStatusResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(SystemStatusResponse)
-	err := c.cc.Invoke(ctx, MatchmakerService_AdminGetSystemStatus_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Matchmaker_AdminReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchmakerServiceClient) AdminUpdateServerState(ctx context.Context, in *AdminServerUpdateRequest, opts ...grpc.CallOption) (*AdminUpdateResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AdminUpdateResponse)
-	err := c.cc.Invoke(ctx, MatchmakerService_AdminUpdateServerState_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _Matchmaker_AdminTerminateMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminTerminateMatchRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminTerminateMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminTerminateMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminTerminateMatch(ctx, req.(*AdminTerminateMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// MatchmakerServiceServer is the server API for MatchmakerService service.
-// All implementations must embed UnimplementedMatchmakerServiceServer
-// for forward compatibility.
-//
-// ────────────── SERVICIOS ─────────────
-type MatchmakerServiceServer interface {
-	// API para Jugadores
-	QueuePlayer(context.Context, *PlayerInfoRequest) (*QueuePlayerResponse, error)
-	GetPlayerStatus(context.Context, *PlayerStatusRequest) (*PlayerStatusResponse, error)
-	// API para Cliente Administrador
-	AdminGetSystemStatus(context.Context, *AdminRequest) (*SystemStatusResponse, error)
-	AdminUpdateServerState(context.Context, *AdminServerUpdateRequest) (*AdminUpdateResponse, error)
-	mustEmbedUnimplementedMatchmakerServiceServer()
+func _Matchmaker_AdminGetEventLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminGetEventLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminGetEventLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminGetEventLog(ctx, req.(*EventLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedMatchmakerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedMatchmakerServiceServer struct{}
-
-func (UnimplementedMatchmakerServiceServer) QueuePlayer(context.Context, *PlayerInfoRequest) (*QueuePlayerResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method QueuePlayer not implemented")
-}
-func (UnimplementedMatchmakerServiceServer) GetPlayerStatus(context.Context, *PlayerStatusRequest) (*PlayerStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerStatus not implemented")
-}
-func (UnimplementedMatchmakerServiceServer) AdminGetSystemStatus(context.Context, *AdminRequest) (*SystemStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AdminGetSystemStatus not implemented")
-}
-func (UnimplementedMatchmakerServiceServer) AdminUpdateServerState(context.Context, *AdminServerUpdateRequest) (*AdminUpdateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AdminUpdateServerState not implemented")
+func _Matchmaker_AdminStreamLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchmakerServer).AdminStreamLogs(m, &grpc.GenericServerStream[LogStreamRequest, LogLine]{ServerStream: stream})
 }
-func (UnimplementedMatchmakerServiceServer) mustEmbedUnimplementedMatchmakerServiceServer() {}
-func (UnimplementedMatchmakerServiceServer) testEmbeddedByValue()                           {}
 
-// UnsafeMatchmakerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MatchmakerServiceServer will
-// result in compilation errors.
-type UnsafeMatchmakerServiceServer interface {
-	mustEmbedUnimplementedMatchmakerServiceServer()
-}
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_AdminStreamLogsServer = grpc.ServerStreamingServer[LogLine]
 
-func RegisterMatchmakerServiceServer(s grpc.ServiceRegistrar, srv MatchmakerServiceServer) {
-	// If the following call pancis, it indicates UnimplementedMatchmakerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _Matchmaker_AdminSetChaos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminSetChaosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakerServer).AdminSetChaos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaker_AdminSetChaos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakerServer).AdminSetChaos(ctx, req.(*AdminSetChaosRequest))
 	}
-	s.RegisterService(&MatchmakerService_ServiceDesc, srv)
+	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchmakerService_QueuePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PlayerInfoRequest)
+func _Matchmaker_AdminListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminListSessionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchmakerServiceServer).QueuePlayer(ctx, in)
+		return srv.(MatchmakerServer).AdminListSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchmakerService_QueuePlayer_FullMethodName,
+		FullMethod: Matchmaker_AdminListSessions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchmakerServiceServer).QueuePlayer(ctx, req.(*PlayerInfoRequest))
+		return srv.(MatchmakerServer).AdminListSessions(ctx, req.(*AdminListSessionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchmakerService_GetPlayerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PlayerStatusRequest)
+func _Matchmaker_AdminSimulateMatching_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateMatchingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchmakerServiceServer).GetPlayerStatus(ctx, in)
+		return srv.(MatchmakerServer).AdminSimulateMatching(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchmakerService_GetPlayerStatus_FullMethodName,
+		FullMethod: Matchmaker_AdminSimulateMatching_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchmakerServiceServer).GetPlayerStatus(ctx, req.(*PlayerStatusRequest))
+		return srv.(MatchmakerServer).AdminSimulateMatching(ctx, req.(*SimulateMatchingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchmakerService_AdminGetSystemStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdminRequest)
+func _Matchmaker_AdminGetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchmakerServiceServer).AdminGetSystemStatus(ctx, in)
+		return srv.(MatchmakerServer).AdminGetAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchmakerService_AdminGetSystemStatus_FullMethodName,
+		FullMethod: Matchmaker_AdminGetAuditLog_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchmakerServiceServer).AdminGetSystemStatus(ctx, req.(*AdminRequest))
+		return srv.(MatchmakerServer).AdminGetAuditLog(ctx, req.(*AuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchmakerService_AdminUpdateServerState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdminServerUpdateRequest)
+func _Matchmaker_ReplicateState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MatchmakerServer).ReplicateState(&grpc.GenericServerStream[StateSnapshot, ReplicationAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Matchmaker_ReplicateStateServer = grpc.ClientStreamingServer[StateSnapshot, ReplicationAck]
+
+func _Matchmaker_TransferState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateSnapshot)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchmakerServiceServer).AdminUpdateServerState(ctx, in)
+		return srv.(MatchmakerServer).TransferState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchmakerService_AdminUpdateServerState_FullMethodName,
+		FullMethod: Matchmaker_TransferState_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchmakerServiceServer).AdminUpdateServerState(ctx, req.(*AdminServerUpdateRequest))
+		return srv.(MatchmakerServer).TransferState(ctx, req.(*StateSnapshot))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// MatchmakerService_ServiceDesc is the grpc.ServiceDesc for MatchmakerService service.
+// Matchmaker_ServiceDesc is the grpc.ServiceDesc for Matchmaker service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var MatchmakerService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "matchmaking.MatchmakerService",
-	HandlerType: (*MatchmakerServiceServer)(nil),
+var Matchmaker_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "matchmaking.Matchmaker",
+	HandlerType: (*MatchmakerServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _Matchmaker_GetServerInfo_Handler,
+		},
+		{
+			MethodName: "RegisterPlayer",
+			Handler:    _Matchmaker_RegisterPlayer_Handler,
+		},
 		{
 			MethodName: "QueuePlayer",
-			Handler:    _MatchmakerService_QueuePlayer_Handler,
+			Handler:    _Matchmaker_QueuePlayer_Handler,
+		},
+		{
+			MethodName: "CancelQueue",
+			Handler:    _Matchmaker_CancelQueue_Handler,
+		},
+		{
+			MethodName: "LeaveMatch",
+			Handler:    _Matchmaker_LeaveMatch_Handler,
+		},
+		{
+			MethodName: "AcceptMatch",
+			Handler:    _Matchmaker_AcceptMatch_Handler,
+		},
+		{
+			MethodName: "RequestRematch",
+			Handler:    _Matchmaker_RequestRematch_Handler,
 		},
 		{
 			MethodName: "GetPlayerStatus",
-			Handler:    _MatchmakerService_GetPlayerStatus_Handler,
+			Handler:    _Matchmaker_GetPlayerStatus_Handler,
+		},
+		{
+			MethodName: "ListGameModes",
+			Handler:    _Matchmaker_ListGameModes_Handler,
+		},
+		{
+			MethodName: "ResumeSession",
+			Handler:    _Matchmaker_ResumeSession_Handler,
+		},
+		{
+			MethodName: "PlayerHeartbeat",
+			Handler:    _Matchmaker_PlayerHeartbeat_Handler,
+		},
+		{
+			MethodName: "SubmitMatchFeedback",
+			Handler:    _Matchmaker_SubmitMatchFeedback_Handler,
+		},
+		{
+			MethodName: "CreateParty",
+			Handler:    _Matchmaker_CreateParty_Handler,
+		},
+		{
+			MethodName: "JoinParty",
+			Handler:    _Matchmaker_JoinParty_Handler,
+		},
+		{
+			MethodName: "LeaveParty",
+			Handler:    _Matchmaker_LeaveParty_Handler,
+		},
+		{
+			MethodName: "CreateLobby",
+			Handler:    _Matchmaker_CreateLobby_Handler,
+		},
+		{
+			MethodName: "JoinLobby",
+			Handler:    _Matchmaker_JoinLobby_Handler,
+		},
+		{
+			MethodName: "LeaveLobby",
+			Handler:    _Matchmaker_LeaveLobby_Handler,
+		},
+		{
+			MethodName: "GetMatchHistory",
+			Handler:    _Matchmaker_GetMatchHistory_Handler,
+		},
+		{
+			MethodName: "GetMatchTimeline",
+			Handler:    _Matchmaker_GetMatchTimeline_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _Matchmaker_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "UpdateServerStatus",
+			Handler:    _Matchmaker_UpdateServerStatus_Handler,
+		},
+		{
+			MethodName: "ReportMatchResult",
+			Handler:    _Matchmaker_ReportMatchResult_Handler,
+		},
+		{
+			MethodName: "AbortMatch",
+			Handler:    _Matchmaker_AbortMatch_Handler,
+		},
+		{
+			MethodName: "DeregisterServer",
+			Handler:    _Matchmaker_DeregisterServer_Handler,
+		},
+		{
+			MethodName: "RequestBackfill",
+			Handler:    _Matchmaker_RequestBackfill_Handler,
 		},
 		{
 			MethodName: "AdminGetSystemStatus",
-			Handler:    _MatchmakerService_AdminGetSystemStatus_Handler,
+			Handler:    _Matchmaker_AdminGetSystemStatus_Handler,
 		},
 		{
 			MethodName: "AdminUpdateServerState",
-			Handler:    _MatchmakerService_AdminUpdateServerState_Handler,
+			Handler:    _Matchmaker_AdminUpdateServerState_Handler,
+		},
+		{
+			MethodName: "AdminGetClockMetrics",
+			Handler:    _Matchmaker_AdminGetClockMetrics_Handler,
+		},
+		{
+			MethodName: "AdminGetClockState",
+			Handler:    _Matchmaker_AdminGetClockState_Handler,
+		},
+		{
+			MethodName: "AdminDrainServer",
+			Handler:    _Matchmaker_AdminDrainServer_Handler,
+		},
+		{
+			MethodName: "AdminUpsertGameMode",
+			Handler:    _Matchmaker_AdminUpsertGameMode_Handler,
+		},
+		{
+			MethodName: "AdminSetPlayerTier",
+			Handler:    _Matchmaker_AdminSetPlayerTier_Handler,
+		},
+		{
+			MethodName: "AdminGetServerReliability",
+			Handler:    _Matchmaker_AdminGetServerReliability_Handler,
+		},
+		{
+			MethodName: "AdminGetSLOStatus",
+			Handler:    _Matchmaker_AdminGetSLOStatus_Handler,
+		},
+		{
+			MethodName: "AdminKickPlayer",
+			Handler:    _Matchmaker_AdminKickPlayer_Handler,
+		},
+		{
+			MethodName: "AdminBanPlayer",
+			Handler:    _Matchmaker_AdminBanPlayer_Handler,
+		},
+		{
+			MethodName: "AdminResetCooldown",
+			Handler:    _Matchmaker_AdminResetCooldown_Handler,
+		},
+		{
+			MethodName: "AdminSimulateCapacity",
+			Handler:    _Matchmaker_AdminSimulateCapacity_Handler,
+		},
+		{
+			MethodName: "AdminReloadConfig",
+			Handler:    _Matchmaker_AdminReloadConfig_Handler,
+		},
+		{
+			MethodName: "AdminTerminateMatch",
+			Handler:    _Matchmaker_AdminTerminateMatch_Handler,
+		},
+		{
+			MethodName: "AdminGetEventLog",
+			Handler:    _Matchmaker_AdminGetEventLog_Handler,
+		},
+		{
+			MethodName: "AdminSetChaos",
+			Handler:    _Matchmaker_AdminSetChaos_Handler,
+		},
+		{
+			MethodName: "AdminListSessions",
+			Handler:    _Matchmaker_AdminListSessions_Handler,
+		},
+		{
+			MethodName: "AdminSimulateMatching",
+			Handler:    _Matchmaker_AdminSimulateMatching_Handler,
+		},
+		{
+			MethodName: "AdminGetAuditLog",
+			Handler:    _Matchmaker_AdminGetAuditLog_Handler,
+		},
+		{
+			MethodName: "TransferState",
+			Handler:    _Matchmaker_TransferState_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeMatchUpdates",
+			Handler:       _Matchmaker_SubscribeMatchUpdates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMatchEvents",
+			Handler:       _Matchmaker_SubscribeMatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Heartbeat",
+			Handler:       _Matchmaker_Heartbeat_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PublishMatchEvents",
+			Handler:       _Matchmaker_PublishMatchEvents_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "AdminExportQueueHistory",
+			Handler:       _Matchmaker_AdminExportQueueHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AdminStreamLogs",
+			Handler:       _Matchmaker_AdminStreamLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReplicateState",
+			Handler:       _Matchmaker_ReplicateState_Handler,
+			ClientStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/matchmaking.proto",
 }
 
 const (
-	GameServerService_AssignMatch_FullMethodName        = "/matchmaking.GameServerService/AssignMatch"
-	GameServerService_UpdateServerStatus_FullMethodName = "/matchmaking.GameServerService/UpdateServerStatus"
-	GameServerService_PingServer_FullMethodName         = "/matchmaking.GameServerService/PingServer"
+	GameServer_AssignMatch_FullMethodName   = "/matchmaking.GameServer/AssignMatch"
+	GameServer_CancelMatch_FullMethodName   = "/matchmaking.GameServer/CancelMatch"
+	GameServer_SpectateMatch_FullMethodName = "/matchmaking.GameServer/SpectateMatch"
+	GameServer_PingServer_FullMethodName    = "/matchmaking.GameServer/PingServer"
 )
 
-// GameServerServiceClient is the client API for GameServerService service.
+// GameServerClient is the client API for GameServer service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type GameServerServiceClient interface {
+type GameServerClient interface {
 	// Invocado por el Matchmaker
 	AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error)
-	// Invocado por el GameServer hacia el Matchmaker
-	UpdateServerStatus(ctx context.Context, in *ServerStatusUpdateRequest, opts ...grpc.CallOption) (*ServerStatusUpdateResponse, error)
+	CancelMatch(ctx context.Context, in *CancelMatchRequest, opts ...grpc.CallOption) (*CancelMatchResponse, error)
+	// Invocado por los jugadores que miran una partida en curso
+	SpectateMatch(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectateEvent], error)
 	// Health-check opcional
 	PingServer(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 }
 
-type gameServerServiceClient struct {
+type gameServerClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewGameServerServiceClient(cc grpc.ClientConnInterface) GameServerServiceClient {
-	return &gameServerServiceClient{cc}
+func NewGameServerClient(cc grpc.ClientConnInterface) GameServerClient {
+	return &gameServerClient{cc}
 }
 
-func (c *gameServerServiceClient) AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error) {
+func (c *gameServerClient) AssignMatch(ctx context.Context, in *AssignMatchRequest, opts ...grpc.CallOption) (*AssignMatchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AssignMatchResponse)
-	err := c.cc.Invoke(ctx, GameServerService_AssignMatch_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, GameServer_AssignMatch_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gameServerServiceClient) UpdateServerStatus(ctx context.Context, in *ServerStatusUpdateRequest, opts ...grpc.CallOption) (*ServerStatusUpdateResponse, error) {
+func (c *gameServerClient) CancelMatch(ctx context.Context, in *CancelMatchRequest, opts ...grpc.CallOption) (*CancelMatchResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ServerStatusUpdateResponse)
-	err := c.cc.Invoke(ctx, GameServerService_UpdateServerStatus_FullMethodName, in, out, cOpts...)
+	out := new(CancelMatchResponse)
+	err := c.cc.Invoke(ctx, GameServer_CancelMatch_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *gameServerServiceClient) PingServer(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+func (c *gameServerClient) SpectateMatch(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SpectateEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameServer_ServiceDesc.Streams[0], GameServer_SpectateMatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SpectateRequest, SpectateEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameServer_SpectateMatchClient = grpc.ServerStreamingClient[SpectateEvent]
+
+func (c *gameServerClient) PingServer(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PingResponse)
-	err := c.cc.Invoke(ctx, GameServerService_PingServer_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, GameServer_PingServer_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// GameServerServiceServer is the server API for GameServerService service.
-// All implementations must embed UnimplementedGameServerServiceServer
+// GameServerServer is the server API for GameServer service.
+// All implementations must embed UnimplementedGameServerServer
 // for forward compatibility.
-type GameServerServiceServer interface {
+type GameServerServer interface {
 	// Invocado por el Matchmaker
 	AssignMatch(context.Context, *AssignMatchRequest) (*AssignMatchResponse, error)
-	// Invocado por el GameServer hacia el Matchmaker
-	UpdateServerStatus(context.Context, *ServerStatusUpdateRequest) (*ServerStatusUpdateResponse, error)
+	CancelMatch(context.Context, *CancelMatchRequest) (*CancelMatchResponse, error)
+	// Invocado por los jugadores que miran una partida en curso
+	SpectateMatch(*SpectateRequest, grpc.ServerStreamingServer[SpectateEvent]) error
 	// Health-check opcional
 	PingServer(context.Context, *PingRequest) (*PingResponse, error)
-	mustEmbedUnimplementedGameServerServiceServer()
+	mustEmbedUnimplementedGameServerServer()
 }
 
-// UnimplementedGameServerServiceServer must be embedded to have
+// UnimplementedGameServerServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedGameServerServiceServer struct{}
+type UnimplementedGameServerServer struct{}
 
-func (UnimplementedGameServerServiceServer) AssignMatch(context.Context, *AssignMatchRequest) (*AssignMatchResponse, error) {
+func (UnimplementedGameServerServer) AssignMatch(context.Context, *AssignMatchRequest) (*AssignMatchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssignMatch not implemented")
 }
-func (UnimplementedGameServerServiceServer) UpdateServerStatus(context.Context, *ServerStatusUpdateRequest) (*ServerStatusUpdateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateServerStatus not implemented")
+func (UnimplementedGameServerServer) CancelMatch(context.Context, *CancelMatchRequest) (*CancelMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMatch not implemented")
 }
-func (UnimplementedGameServerServiceServer) PingServer(context.Context, *PingRequest) (*PingResponse, error) {
+func (UnimplementedGameServerServer) SpectateMatch(*SpectateRequest, grpc.ServerStreamingServer[SpectateEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SpectateMatch not implemented")
+}
+func (UnimplementedGameServerServer) PingServer(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PingServer not implemented")
 }
-func (UnimplementedGameServerServiceServer) mustEmbedUnimplementedGameServerServiceServer() {}
-func (UnimplementedGameServerServiceServer) testEmbeddedByValue()                           {}
+func (UnimplementedGameServerServer) mustEmbedUnimplementedGameServerServer() {}
+func (UnimplementedGameServerServer) testEmbeddedByValue()                    {}
 
-// UnsafeGameServerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to GameServerServiceServer will
+// UnsafeGameServerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GameServerServer will
 // result in compilation errors.
-type UnsafeGameServerServiceServer interface {
-	mustEmbedUnimplementedGameServerServiceServer()
+type UnsafeGameServerServer interface {
+	mustEmbedUnimplementedGameServerServer()
 }
 
-func RegisterGameServerServiceServer(s grpc.ServiceRegistrar, srv GameServerServiceServer) {
-	// If the following call pancis, it indicates UnimplementedGameServerServiceServer was
+func RegisterGameServerServer(s grpc.ServiceRegistrar, srv GameServerServer) {
+	// If the following call pancis, it indicates UnimplementedGameServerServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&GameServerService_ServiceDesc, srv)
+	s.RegisterService(&GameServer_ServiceDesc, srv)
 }
 
-func _GameServerService_AssignMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _GameServer_AssignMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignMatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GameServerServiceServer).AssignMatch(ctx, in)
+		return srv.(GameServerServer).AssignMatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GameServerService_AssignMatch_FullMethodName,
+		FullMethod: GameServer_AssignMatch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GameServerServiceServer).AssignMatch(ctx, req.(*AssignMatchRequest))
+		return srv.(GameServerServer).AssignMatch(ctx, req.(*AssignMatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GameServerService_UpdateServerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServerStatusUpdateRequest)
+func _GameServer_CancelMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMatchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GameServerServiceServer).UpdateServerStatus(ctx, in)
+		return srv.(GameServerServer).CancelMatch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GameServerService_UpdateServerStatus_FullMethodName,
+		FullMethod: GameServer_CancelMatch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GameServerServiceServer).UpdateServerStatus(ctx, req.(*ServerStatusUpdateRequest))
+		return srv.(GameServerServer).CancelMatch(ctx, req.(*CancelMatchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GameServerService_PingServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _GameServer_SpectateMatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpectateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServerServer).SpectateMatch(m, &grpc.GenericServerStream[SpectateRequest, SpectateEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameServer_SpectateMatchServer = grpc.ServerStreamingServer[SpectateEvent]
+
+func _GameServer_PingServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GameServerServiceServer).PingServer(ctx, in)
+		return srv.(GameServerServer).PingServer(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GameServerService_PingServer_FullMethodName,
+		FullMethod: GameServer_PingServer_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GameServerServiceServer).PingServer(ctx, req.(*PingRequest))
+		return srv.(GameServerServer).PingServer(ctx, req.(*PingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// GameServerService_ServiceDesc is the grpc.ServiceDesc for GameServerService service.
+// GameServer_ServiceDesc is the grpc.ServiceDesc for GameServer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var GameServerService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "matchmaking.GameServerService",
-	HandlerType: (*GameServerServiceServer)(nil),
+var GameServer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "matchmaking.GameServer",
+	HandlerType: (*GameServerServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "AssignMatch",
-			Handler:    _GameServerService_AssignMatch_Handler,
+			Handler:    _GameServer_AssignMatch_Handler,
 		},
 		{
-			MethodName: "UpdateServerStatus",
-			Handler:    _GameServerService_UpdateServerStatus_Handler,
+			MethodName: "CancelMatch",
+			Handler:    _GameServer_CancelMatch_Handler,
 		},
 		{
 			MethodName: "PingServer",
-			Handler:    _GameServerService_PingServer_Handler,
+			Handler:    _GameServer_PingServer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SpectateMatch",
+			Handler:       _GameServer_SpectateMatch_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/matchmaking.proto",
 }